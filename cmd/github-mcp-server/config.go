@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// configName is the name of the config file looked for in the config search paths, with any
+// extension viper supports, such as config.yaml or config.toml.
+const configName = "config"
+
+// configAliases maps the flags that are named differently from the setting they set to that
+// setting, so that a config file can use either name.
+var configAliases = map[string]string{
+	"gh_host": "host",
+}
+
+// configSearchPaths returns the directories searched for a config file when none is given,
+// in order of precedence.
+func configSearchPaths() []string {
+	var paths []string
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "github-mcp-server"))
+	}
+	return append(paths, "/etc/github-mcp-server")
+}
+
+// readConfigFile reads server settings from a YAML, TOML or JSON config file into v, below
+// flags and environment variables in precedence. The file is the given one, or the first found
+// in the search paths; it is not an error if there is none to be found. Settings are named
+// like their flags or the settings they are bound to, with dashes or underscores.
+func readConfigFile(v *viper.Viper, file string, searchPaths []string) error {
+	f := viper.New()
+	if file != "" {
+		f.SetConfigFile(file)
+	} else {
+		f.SetConfigName(configName)
+		for _, path := range searchPaths {
+			f.AddConfigPath(path)
+		}
+	}
+	if err := f.ReadInConfig(); err != nil {
+		if errors.As(err, &viper.ConfigFileNotFoundError{}) {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	known := make(map[string]string)
+	for _, key := range v.AllKeys() {
+		// A config file cannot point to another
+		if key != "config" {
+			known[normalizeConfigKey(key)] = key
+		}
+	}
+	settings := make(map[string]any)
+	names := make(map[string]string)
+	var unknown []string
+	keys := f.AllKeys()
+	slices.Sort(keys)
+	for _, key := range keys {
+		name := normalizeConfigKey(key)
+		if alias, ok := configAliases[name]; ok {
+			name = alias
+		}
+		target, ok := known[name]
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		if other, ok := names[target]; ok {
+			return fmt.Errorf("settings %s and %s in config file %s are the same setting", other, key, f.ConfigFileUsed())
+		}
+		names[target] = key
+		settings[target] = f.Get(key)
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown settings in config file %s: %s", f.ConfigFileUsed(), strings.Join(unknown, ", "))
+	}
+	return v.MergeConfigMap(settings)
+}
+
+func normalizeConfigKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "-", "_")
+}
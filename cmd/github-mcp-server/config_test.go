@@ -0,0 +1,141 @@
+package main
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestViper returns a viper instance with a few settings bound to flags, like the server's.
+func newTestViper(t *testing.T) (*viper.Viper, *pflag.FlagSet) {
+	t.Helper()
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringSlice("toolsets", []string{"all"}, "")
+	flags.Bool("read-only", false, "")
+	flags.Duration("cache-ttl", 0, "")
+	flags.String("gh-host", "", "")
+	flags.String("config", "", "")
+
+	v := viper.New()
+	require.NoError(t, v.BindPFlag("toolsets", flags.Lookup("toolsets")))
+	require.NoError(t, v.BindPFlag("read-only", flags.Lookup("read-only")))
+	require.NoError(t, v.BindPFlag("cache_ttl", flags.Lookup("cache-ttl")))
+	require.NoError(t, v.BindPFlag("host", flags.Lookup("gh-host")))
+	require.NoError(t, v.BindPFlag("config", flags.Lookup("config")))
+	return v, flags
+}
+
+func writeConfigFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	file := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return file
+}
+
+func TestReadConfigFile(t *testing.T) {
+	v, _ := newTestViper(t)
+	file := writeConfigFile(t, t.TempDir(), "settings.yaml", `
+toolsets: [repos, issues]
+read_only: true
+cache-ttl: 30s
+host: github.example.com
+`)
+	require.NoError(t, readConfigFile(v, file, nil))
+
+	var toolsets []string
+	require.NoError(t, v.UnmarshalKey("toolsets", &toolsets))
+	assert.Equal(t, []string{"repos", "issues"}, toolsets)
+	assert.True(t, v.GetBool("read-only"))
+	assert.Equal(t, 30*time.Second, v.GetDuration("cache_ttl"))
+	assert.Equal(t, "github.example.com", v.GetString("host"))
+}
+
+func TestReadConfigFileFlagNames(t *testing.T) {
+	v, _ := newTestViper(t)
+	file := writeConfigFile(t, t.TempDir(), "config.yaml", "gh-host: https://github.example.com\n")
+	require.NoError(t, readConfigFile(v, file, nil))
+	assert.Equal(t, "https://github.example.com", v.GetString("host"))
+
+	v, _ = newTestViper(t)
+	file = writeConfigFile(t, t.TempDir(), "config.yaml", "gh_host: https://a.example.com\nhost: https://b.example.com\n")
+	err := readConfigFile(v, file, nil)
+	assert.ErrorContains(t, err, "settings gh_host and host in config file "+file+" are the same setting")
+}
+
+func TestReadConfigFileREADMEExample(t *testing.T) {
+	readme, err := os.ReadFile("../../README.md")
+	require.NoError(t, err)
+	_, section, ok := strings.Cut(string(readme), "### Configuration File")
+	require.True(t, ok)
+	_, example, ok := strings.Cut(section, "```yaml\n")
+	require.True(t, ok)
+	example, _, ok = strings.Cut(example, "```")
+	require.True(t, ok)
+
+	// The settings of the server, as bound to its flags when the package is initialized
+	v := viper.New()
+	for _, key := range viper.AllKeys() {
+		v.SetDefault(key, viper.Get(key))
+	}
+	file := writeConfigFile(t, t.TempDir(), "config.yaml", example)
+	require.NoError(t, readConfigFile(v, file, nil))
+
+	host, err := url.Parse(v.GetString("host"))
+	require.NoError(t, err)
+	assert.Equal(t, "https", host.Scheme)
+	assert.True(t, v.GetBool("read-only"))
+	assert.Equal(t, 30*time.Second, v.GetDuration("cache_ttl"))
+}
+
+func TestReadConfigFilePrecedence(t *testing.T) {
+	v, flags := newTestViper(t)
+	v.SetEnvPrefix("github")
+	v.AutomaticEnv()
+	t.Setenv("GITHUB_HOST", "env.example.com")
+	require.NoError(t, flags.Parse([]string{"--cache-ttl", "1m"}))
+
+	file := writeConfigFile(t, t.TempDir(), "config.toml", `
+read-only = true
+cache_ttl = "30s"
+host = "file.example.com"
+`)
+	require.NoError(t, readConfigFile(v, file, nil))
+
+	assert.True(t, v.GetBool("read-only"), "config file overrides defaults")
+	assert.Equal(t, time.Minute, v.GetDuration("cache_ttl"), "flags override the config file")
+	assert.Equal(t, "env.example.com", v.GetString("host"), "environment variables override the config file")
+}
+
+func TestReadConfigFileSearchPaths(t *testing.T) {
+	v, _ := newTestViper(t)
+	missing, dir := t.TempDir(), t.TempDir()
+	writeConfigFile(t, dir, "config.yaml", "read-only: true\n")
+
+	require.NoError(t, readConfigFile(v, "", []string{missing, dir}))
+	assert.True(t, v.GetBool("read-only"))
+
+	// It is not an error if no config file is found
+	v, _ = newTestViper(t)
+	require.NoError(t, readConfigFile(v, "", []string{missing}))
+	assert.False(t, v.GetBool("read-only"))
+}
+
+func TestReadConfigFileErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	v, _ := newTestViper(t)
+	err := readConfigFile(v, filepath.Join(dir, "missing.yaml"), nil)
+	assert.ErrorContains(t, err, "failed to read config file")
+
+	file := writeConfigFile(t, dir, "typo.yaml", "read-onyl: true\nconfig: other.yaml\n")
+	err = readConfigFile(v, file, nil)
+	assert.ErrorContains(t, err, "unknown settings in config file "+file+": config, read-onyl")
+}
@@ -5,9 +5,17 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/blobcache"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/localclone"
+	"github.com/github/github-mcp-server/pkg/macros"
+	"github.com/github/github-mcp-server/pkg/policy"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
+	"github.com/github/github-mcp-server/pkg/retry"
+	"github.com/github/github-mcp-server/pkg/signing"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -31,37 +39,296 @@ var (
 		Short: "Start stdio server",
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			token := viper.GetString("personal_access_token")
-			if token == "" {
-				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
+			credentials, err := credentialProvider()
+			if err != nil {
+				return err
 			}
-
-			// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
-			// it's because viper doesn't handle comma-separated values correctly for env
-			// vars when using GetStringSlice.
-			// https://github.com/spf13/viper/issues/380
-			var enabledToolsets []string
-			if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
-				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
+			token, enabledToolsets, err := serverSettings(credentials == nil)
+			if err != nil {
+				return err
+			}
+			budgets, err := apiBudgets()
+			if err != nil {
+				return err
+			}
+			timeouts, err := toolTimeouts()
+			if err != nil {
+				return err
+			}
+			aliasCutoff, err := toolAliasCutoff()
+			if err != nil {
+				return err
+			}
+			enabledTools, disabledTools, err := toolFilters()
+			if err != nil {
+				return err
+			}
+			writePolicy, err := loadPolicy()
+			if err != nil {
+				return err
+			}
+			toolMacros, err := loadMacros()
+			if err != nil {
+				return err
 			}
 
 			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
-				ContentWindowSize:    viper.GetInt("content-window-size"),
+				Version:                 version,
+				Host:                    viper.GetString("host"),
+				Token:                   token,
+				TokenFile:               viper.GetString("token_file"),
+				Credentials:             credentials,
+				EnabledToolsets:         enabledToolsets,
+				EnabledTools:            enabledTools,
+				DisabledTools:           disabledTools,
+				DynamicToolsets:         viper.GetBool("dynamic_toolsets"),
+				ReadOnly:                viper.GetBool("read-only"),
+				ExportTranslations:      viper.GetBool("export-translations"),
+				EnableCommandLogging:    viper.GetBool("enable-command-logging"),
+				LogFilePath:             viper.GetString("log-file"),
+				ContentWindowSize:       viper.GetInt("content-window-size"),
+				RepoAffinity:            viper.GetBool("search-repo-affinity"),
+				JournalDir:              viper.GetString("journal_dir"),
+				CacheTTL:                viper.GetDuration("cache_ttl"),
+				LocalCloneDir:           viper.GetString("local_clone_dir"),
+				LocalCloneFetchInterval: viper.GetDuration("local_clone_fetch_interval"),
+				BlobCacheMemoryBytes:    int64(viper.GetInt("blob_cache_memory_mb")) << 20,
+				BlobCacheDir:            viper.GetString("blob_cache_dir"),
+				BlobCacheDiskBytes:      int64(viper.GetInt("blob_cache_disk_mb")) << 20,
+				APIBudgets:              budgets,
+				RetryMaxAttempts:        viper.GetInt("retry_max_attempts"),
+				RetryNonIdempotent:      viper.GetBool("retry_non_idempotent"),
+				ToolAliasCutoff:         aliasCutoff,
+				Policy:                  writePolicy,
+				Macros:                  toolMacros,
+				AuditLog:                viper.GetString("audit_log"),
+				GHESVersion:             viper.GetString("ghes_version"),
+				ToolTimeouts:            timeouts,
+				FetchAllLimits: github.FetchAllLimits{
+					MaxItems: viper.GetInt("fetch_all_max_items"),
+					MaxBytes: viper.GetInt("fetch_all_max_bytes"),
+				},
+				BatchLimits: github.BatchLimits{
+					MaxCalls:       viper.GetInt("batch_max_calls"),
+					MaxConcurrency: viper.GetInt("batch_max_concurrency"),
+				},
+				CommitSigningKey:    viper.GetString("commit_signing_key"),
+				CommitSigningFormat: viper.GetString("commit_signing_format"),
+				WebhookListenAddr:   viper.GetString("webhook_listen_addr"),
+				WebhookSecret:       viper.GetString("webhook_secret"),
+				WebhookPublicURL:    viper.GetString("webhook_public_url"),
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
 	}
+
+	streamableHTTPCmd = &cobra.Command{
+		Use:   "streamable-http",
+		Short: "Start Streamable HTTP server",
+		Long:  `Start a server that communicates over the MCP Streamable HTTP transport, serving a single endpoint with session management.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			perRequestToken := viper.GetBool("per_request_token")
+			credentials, err := credentialProvider()
+			if err != nil {
+				return err
+			}
+			if perRequestToken && credentials != nil {
+				return errors.New("--per-request-token cannot be used with --token-command or --token-keychain-service")
+			}
+			token, enabledToolsets, err := serverSettings(!perRequestToken && credentials == nil)
+			if err != nil {
+				return err
+			}
+			budgets, err := apiBudgets()
+			if err != nil {
+				return err
+			}
+			timeouts, err := toolTimeouts()
+			if err != nil {
+				return err
+			}
+			readOnlyOverride, err := ghmcp.ParseReadOnlyOverride(viper.GetString("read_only_override"))
+			if err != nil {
+				return err
+			}
+			aliasCutoff, err := toolAliasCutoff()
+			if err != nil {
+				return err
+			}
+			enabledTools, disabledTools, err := toolFilters()
+			if err != nil {
+				return err
+			}
+			writePolicy, err := loadPolicy()
+			if err != nil {
+				return err
+			}
+			toolMacros, err := loadMacros()
+			if err != nil {
+				return err
+			}
+
+			httpServerConfig := ghmcp.StreamableHTTPServerConfig{
+				Version:                 version,
+				Host:                    viper.GetString("host"),
+				Token:                   token,
+				TokenFile:               viper.GetString("token_file"),
+				Credentials:             credentials,
+				EnabledToolsets:         enabledToolsets,
+				EnabledTools:            enabledTools,
+				DisabledTools:           disabledTools,
+				DynamicToolsets:         viper.GetBool("dynamic_toolsets"),
+				ReadOnly:                viper.GetBool("read-only"),
+				ExportTranslations:      viper.GetBool("export-translations"),
+				LogFilePath:             viper.GetString("log-file"),
+				ContentWindowSize:       viper.GetInt("content-window-size"),
+				RepoAffinity:            viper.GetBool("search-repo-affinity"),
+				JournalDir:              viper.GetString("journal_dir"),
+				CacheTTL:                viper.GetDuration("cache_ttl"),
+				LocalCloneDir:           viper.GetString("local_clone_dir"),
+				LocalCloneFetchInterval: viper.GetDuration("local_clone_fetch_interval"),
+				BlobCacheMemoryBytes:    int64(viper.GetInt("blob_cache_memory_mb")) << 20,
+				BlobCacheDir:            viper.GetString("blob_cache_dir"),
+				BlobCacheDiskBytes:      int64(viper.GetInt("blob_cache_disk_mb")) << 20,
+				APIBudgets:              budgets,
+				RetryMaxAttempts:        viper.GetInt("retry_max_attempts"),
+				RetryNonIdempotent:      viper.GetBool("retry_non_idempotent"),
+				ToolAliasCutoff:         aliasCutoff,
+				Policy:                  writePolicy,
+				Macros:                  toolMacros,
+				AuditLog:                viper.GetString("audit_log"),
+				GHESVersion:             viper.GetString("ghes_version"),
+				ToolTimeouts:            timeouts,
+				FetchAllLimits: github.FetchAllLimits{
+					MaxItems: viper.GetInt("fetch_all_max_items"),
+					MaxBytes: viper.GetInt("fetch_all_max_bytes"),
+				},
+				BatchLimits: github.BatchLimits{
+					MaxCalls:       viper.GetInt("batch_max_calls"),
+					MaxConcurrency: viper.GetInt("batch_max_concurrency"),
+				},
+				CommitSigningKey:    viper.GetString("commit_signing_key"),
+				CommitSigningFormat: viper.GetString("commit_signing_format"),
+				WebhookSecret:       viper.GetString("webhook_secret"),
+				WebhookPublicURL:    viper.GetString("webhook_public_url"),
+				ListenAddr:          viper.GetString("listen_addr"),
+				EndpointPath:        viper.GetString("endpoint_path"),
+				Stateless:           viper.GetBool("stateless"),
+				HeartbeatInterval:   viper.GetDuration("heartbeat_interval"),
+				PerRequestToken:     perRequestToken,
+				ReadOnlyOverride:    readOnlyOverride,
+			}
+			return ghmcp.RunStreamableHTTPServer(httpServerConfig)
+		},
+	}
 )
 
+// serverSettings returns the token and toolsets shared by all server commands.
+func serverSettings(requireToken bool) (string, []string, error) {
+	token := viper.GetString("personal_access_token")
+	if tokenFile := viper.GetString("token_file"); tokenFile != "" {
+		var err error
+		if token, err = ghmcp.ReadTokenFile(tokenFile); err != nil {
+			return "", nil, err
+		}
+	}
+	if token == "" && requireToken {
+		return "", nil, errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
+	}
+
+	// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
+	// it's because viper doesn't handle comma-separated values correctly for env
+	// vars when using GetStringSlice.
+	// https://github.com/spf13/viper/issues/380
+	var enabledToolsets []string
+	if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
+		return "", nil, fmt.Errorf("failed to unmarshal toolsets: %w", err)
+	}
+	return token, enabledToolsets, nil
+}
+
+// credentialProvider returns the provider of the GitHub token, if it is to be got from a
+// command or the keychain, and again whenever GitHub rejects it, rather than read at startup.
+func credentialProvider() (ghmcp.CredentialProvider, error) {
+	command := viper.GetString("token_command")
+	service := viper.GetString("token_keychain_service")
+	switch {
+	case command != "" && service != "":
+		return nil, errors.New("--token-command and --token-keychain-service cannot be used together")
+	case (command != "" || service != "") && viper.GetString("token_file") != "":
+		return nil, errors.New("--token-file cannot be used with --token-command or --token-keychain-service")
+	case command != "":
+		return ghmcp.CommandCredentials{Command: strings.Fields(command)}, nil
+	case service != "":
+		return ghmcp.KeychainCredentials{Service: service, Account: viper.GetString("token_keychain_account")}, nil
+	}
+	return nil, nil
+}
+
+// toolFilters returns the individual tools to enable and disable within the enabled toolsets.
+func toolFilters() ([]string, []string, error) {
+	// Unmarshalled rather than read with GetStringSlice for the same reason as toolsets.
+	var enabled, disabled []string
+	if err := viper.UnmarshalKey("enabled_tools", &enabled); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal enabled tools: %w", err)
+	}
+	if err := viper.UnmarshalKey("disabled_tools", &disabled); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal disabled tools: %w", err)
+	}
+	return enabled, disabled, nil
+}
+
+// loadPolicy loads the policy file, if one is configured.
+func loadPolicy() (*policy.Policy, error) {
+	file := viper.GetString("policy_file")
+	if file == "" {
+		return nil, nil
+	}
+	return policy.Load(file)
+}
+
+// loadMacros loads the macros file, if one is configured.
+func loadMacros() ([]macros.Macro, error) {
+	file := viper.GetString("macros_file")
+	if file == "" {
+		return nil, nil
+	}
+	return macros.Load(file)
+}
+
+// apiBudgets returns the configured API budgets, keyed by tool, toolset or session.
+func apiBudgets() (map[string]ratelimit.Budget, error) {
+	// Unmarshalled rather than read with GetStringSlice for the same reason as toolsets.
+	var specs []string
+	if err := viper.UnmarshalKey("api_budget", &specs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal API budgets: %w", err)
+	}
+	return ratelimit.ParseBudgets(specs)
+}
+
+// toolTimeouts returns the configured tool call timeouts.
+func toolTimeouts() (ghmcp.ToolTimeouts, error) {
+	// Unmarshalled rather than read with GetStringSlice for the same reason as toolsets.
+	var specs []string
+	if err := viper.UnmarshalKey("tool_timeout", &specs); err != nil {
+		return ghmcp.ToolTimeouts{}, fmt.Errorf("failed to unmarshal tool timeouts: %w", err)
+	}
+	return ghmcp.ParseToolTimeouts(specs)
+}
+
+// toolAliasCutoff returns the date before which deprecated tool aliases are no longer served.
+func toolAliasCutoff() (time.Time, error) {
+	cutoff := viper.GetString("tool_alias_cutoff")
+	if cutoff == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.DateOnly, cutoff)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid tool alias cutoff %q, expected YYYY-MM-DD", cutoff)
+	}
+	return t, nil
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.SetGlobalNormalizationFunc(wordSepNormalizeFunc)
@@ -69,7 +336,10 @@ func init() {
 	rootCmd.SetVersionTemplate("{{.Short}}\n{{.Version}}\n")
 
 	// Add global flags that will be shared by all commands
+	rootCmd.PersistentFlags().String("config", "", "YAML, TOML or JSON file to read settings from, named like their flags; defaults to config.yaml in the user config directory's github-mcp-server directory or /etc/github-mcp-server, if present")
 	rootCmd.PersistentFlags().StringSlice("toolsets", github.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
+	rootCmd.PersistentFlags().StringSlice("enabled-tools", nil, "An optional comma separated list of tools to allow within the enabled toolsets, defaults to all of their tools")
+	rootCmd.PersistentFlags().StringSlice("disabled-tools", nil, "An optional comma separated list of tools to leave out of the enabled toolsets")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
@@ -77,26 +347,107 @@ func init() {
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
+	rootCmd.PersistentFlags().String("ghes-version", "", "GitHub Enterprise Server version, such as 3.14, deciding which tools are offered; detected from the server if empty")
+	rootCmd.PersistentFlags().Bool("search-repo-affinity", false, "Rank search results from recently used repositories first")
+	rootCmd.PersistentFlags().String("journal-dir", "", "Directory to journal multi-step operations to so they can be resumed after a restart")
+	rootCmd.PersistentFlags().Duration("cache-ttl", 0, "Cache GitHub API responses and reuse them for this long (e.g. 30s) before revalidating; disabled if zero")
+	rootCmd.PersistentFlags().String("webhook-listen-addr", "", "Address to receive GitHub webhook deliveries on (e.g. :8090); disabled if empty")
+	rootCmd.PersistentFlags().String("webhook-secret", "", "Secret that webhook deliveries must be signed with")
+	rootCmd.PersistentFlags().String("webhook-public-url", "", "Public URL of the webhook receiver, used when registering webhooks")
+	rootCmd.PersistentFlags().String("local-clone-dir", "", "Directory to keep shallow clones of frequently used repositories in, to serve file contents and searches from disk; disabled if empty")
+	rootCmd.PersistentFlags().Duration("local-clone-fetch-interval", localclone.DefaultFetchInterval, "How often to fetch local clones to pick up new commits")
+	rootCmd.PersistentFlags().Int("blob-cache-memory-mb", blobcache.DefaultMemoryBytes>>20, "Megabytes of memory to cache file contents and diffs in, so that the same blob is never downloaded twice; disabled if zero")
+	rootCmd.PersistentFlags().String("blob-cache-dir", "", "Directory to also cache file contents and diffs in, so that they are kept across restarts")
+	rootCmd.PersistentFlags().Int("blob-cache-disk-mb", blobcache.DefaultDiskBytes>>20, "Megabytes of disk space to use for the blob cache directory")
+	rootCmd.PersistentFlags().StringSlice("api-budget", nil, "Comma separated list of API call budgets in the form name=calls/window, where name is a tool, a toolset or session (per session), e.g. search_code=50/1h")
+	rootCmd.PersistentFlags().StringSlice("tool-timeout", nil, fmt.Sprintf("Comma separated list of how long tool calls may take, as a duration for all tools and tool=duration for specific ones, e.g. 2m,get_job_logs=10m; defaults to %s, 0 for no limit", ghmcp.DefaultToolTimeout))
+	rootCmd.PersistentFlags().Int("fetch-all-max-items", github.DefaultFetchAllLimits.MaxItems, "Most items that paged tools return when called with fetch_all, which walks all pages; fetch_all is disabled if zero")
+	rootCmd.PersistentFlags().Int("fetch-all-max-bytes", github.DefaultFetchAllLimits.MaxBytes, "Most bytes of JSON that paged tools return when called with fetch_all")
+	rootCmd.PersistentFlags().Int("batch-max-calls", github.DefaultBatchLimits.MaxCalls, "Most tool calls batch_call may make at once; batch_call is disabled if zero")
+	rootCmd.PersistentFlags().Int("batch-max-concurrency", github.DefaultBatchLimits.MaxConcurrency, "Most tool calls of a batch_call made at the same time")
+	rootCmd.PersistentFlags().String("commit-signing-key", "", "Sign the commits that tools make with this key, the ID of a GPG key or the path of an SSH private key, so that they show as verified")
+	rootCmd.PersistentFlags().String("commit-signing-format", signing.FormatGPG, "Format of the commit signing key, gpg or ssh; signing runs gpg or ssh-keygen")
+	rootCmd.PersistentFlags().Int("retry-max-attempts", retry.DefaultMaxAttempts, "How often to send GitHub API requests that fail with transient server or network errors, including the first attempt; retries are disabled if 1")
+	rootCmd.PersistentFlags().Bool("retry-non-idempotent", false, "Also retry requests that are not idempotent, such as creating an issue, at the risk of doing it twice")
+	rootCmd.PersistentFlags().String("tool-alias-cutoff", "", "Stop serving the former names of renamed tools deprecated before this date (YYYY-MM-DD)")
+	rootCmd.PersistentFlags().String("policy-file", "", "YAML or JSON file restricting the repositories and branches write tools may be used in, and denying tools altogether")
+	rootCmd.PersistentFlags().String("macros-file", "", "YAML or JSON file defining macros, composite operations that call several tools in turn and are offered as tools")
+	rootCmd.PersistentFlags().String("audit-log", "", "File to append a JSON record of every tool call to, or an http(s) URL to POST the records to")
+	rootCmd.PersistentFlags().String("token-file", "", "Read the GitHub token from this file instead of GITHUB_PERSONAL_ACCESS_TOKEN, and read it again on SIGHUP or when GitHub rejects it")
+	rootCmd.PersistentFlags().String("token-command", "", "Command that prints the GitHub token, run at startup and again whenever GitHub rejects the token, instead of GITHUB_PERSONAL_ACCESS_TOKEN")
+	rootCmd.PersistentFlags().String("token-keychain-service", "", "Service the GitHub token is stored under in the OS keychain (macOS Keychain or Linux Secret Service), read at startup and again whenever GitHub rejects the token")
+	rootCmd.PersistentFlags().String("token-keychain-account", "", "Account the GitHub token is stored under in the OS keychain")
 
 	// Bind flag to viper
+	_ = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
+	_ = viper.BindPFlag("enabled_tools", rootCmd.PersistentFlags().Lookup("enabled-tools"))
+	_ = viper.BindPFlag("disabled_tools", rootCmd.PersistentFlags().Lookup("disabled-tools"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("ghes_version", rootCmd.PersistentFlags().Lookup("ghes-version"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
+	_ = viper.BindPFlag("search-repo-affinity", rootCmd.PersistentFlags().Lookup("search-repo-affinity"))
+	_ = viper.BindPFlag("journal_dir", rootCmd.PersistentFlags().Lookup("journal-dir"))
+	_ = viper.BindPFlag("cache_ttl", rootCmd.PersistentFlags().Lookup("cache-ttl"))
+	_ = viper.BindPFlag("webhook_listen_addr", rootCmd.PersistentFlags().Lookup("webhook-listen-addr"))
+	_ = viper.BindPFlag("webhook_secret", rootCmd.PersistentFlags().Lookup("webhook-secret"))
+	_ = viper.BindPFlag("webhook_public_url", rootCmd.PersistentFlags().Lookup("webhook-public-url"))
+	_ = viper.BindPFlag("local_clone_dir", rootCmd.PersistentFlags().Lookup("local-clone-dir"))
+	_ = viper.BindPFlag("local_clone_fetch_interval", rootCmd.PersistentFlags().Lookup("local-clone-fetch-interval"))
+	_ = viper.BindPFlag("blob_cache_memory_mb", rootCmd.PersistentFlags().Lookup("blob-cache-memory-mb"))
+	_ = viper.BindPFlag("blob_cache_dir", rootCmd.PersistentFlags().Lookup("blob-cache-dir"))
+	_ = viper.BindPFlag("blob_cache_disk_mb", rootCmd.PersistentFlags().Lookup("blob-cache-disk-mb"))
+	_ = viper.BindPFlag("api_budget", rootCmd.PersistentFlags().Lookup("api-budget"))
+	_ = viper.BindPFlag("tool_timeout", rootCmd.PersistentFlags().Lookup("tool-timeout"))
+	_ = viper.BindPFlag("fetch_all_max_items", rootCmd.PersistentFlags().Lookup("fetch-all-max-items"))
+	_ = viper.BindPFlag("fetch_all_max_bytes", rootCmd.PersistentFlags().Lookup("fetch-all-max-bytes"))
+	_ = viper.BindPFlag("batch_max_calls", rootCmd.PersistentFlags().Lookup("batch-max-calls"))
+	_ = viper.BindPFlag("batch_max_concurrency", rootCmd.PersistentFlags().Lookup("batch-max-concurrency"))
+	_ = viper.BindPFlag("commit_signing_key", rootCmd.PersistentFlags().Lookup("commit-signing-key"))
+	_ = viper.BindPFlag("commit_signing_format", rootCmd.PersistentFlags().Lookup("commit-signing-format"))
+	_ = viper.BindPFlag("retry_max_attempts", rootCmd.PersistentFlags().Lookup("retry-max-attempts"))
+	_ = viper.BindPFlag("retry_non_idempotent", rootCmd.PersistentFlags().Lookup("retry-non-idempotent"))
+	_ = viper.BindPFlag("tool_alias_cutoff", rootCmd.PersistentFlags().Lookup("tool-alias-cutoff"))
+	_ = viper.BindPFlag("policy_file", rootCmd.PersistentFlags().Lookup("policy-file"))
+	_ = viper.BindPFlag("macros_file", rootCmd.PersistentFlags().Lookup("macros-file"))
+	_ = viper.BindPFlag("audit_log", rootCmd.PersistentFlags().Lookup("audit-log"))
+	_ = viper.BindPFlag("token_file", rootCmd.PersistentFlags().Lookup("token-file"))
+	_ = viper.BindPFlag("token_command", rootCmd.PersistentFlags().Lookup("token-command"))
+	_ = viper.BindPFlag("token_keychain_service", rootCmd.PersistentFlags().Lookup("token-keychain-service"))
+	_ = viper.BindPFlag("token_keychain_account", rootCmd.PersistentFlags().Lookup("token-keychain-account"))
+
+	// Streamable HTTP flags
+	streamableHTTPCmd.Flags().String("listen-addr", ":8080", "Address to listen on")
+	streamableHTTPCmd.Flags().String("endpoint-path", "/mcp", "Path to serve the MCP endpoint on")
+	streamableHTTPCmd.Flags().Bool("stateless", false, "Handle every request independently instead of managing sessions")
+	streamableHTTPCmd.Flags().Duration("heartbeat-interval", 0, "Interval to ping clients listening for server messages, e.g. 30s; disabled if zero")
+	_ = viper.BindPFlag("listen_addr", streamableHTTPCmd.Flags().Lookup("listen-addr"))
+	_ = viper.BindPFlag("endpoint_path", streamableHTTPCmd.Flags().Lookup("endpoint-path"))
+	_ = viper.BindPFlag("stateless", streamableHTTPCmd.Flags().Lookup("stateless"))
+	streamableHTTPCmd.Flags().Bool("per-request-token", false, "Authenticate every request with the GitHub token in its Authorization header instead of GITHUB_PERSONAL_ACCESS_TOKEN")
+	_ = viper.BindPFlag("heartbeat_interval", streamableHTTPCmd.Flags().Lookup("heartbeat-interval"))
+	_ = viper.BindPFlag("per_request_token", streamableHTTPCmd.Flags().Lookup("per-request-token"))
+	streamableHTTPCmd.Flags().String("read-only-override", "none", "Which read-only mode sessions may ask for with the X-MCP-Read-Only header or read_only query parameter: none, restrict (read-only only) or any")
+	_ = viper.BindPFlag("read_only_override", streamableHTTPCmd.Flags().Lookup("read-only-override"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
+	rootCmd.AddCommand(streamableHTTPCmd)
 }
 
 func initConfig() {
 	// Initialize Viper configuration
 	viper.SetEnvPrefix("github")
+	// Settings with dashes, such as read-only, are set with underscores, as GITHUB_READ_ONLY
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 
+	cobra.CheckErr(readConfigFile(viper.GetViper(), viper.GetString("config"), configSearchPaths()))
 }
 
 func main() {
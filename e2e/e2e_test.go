@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/githubmock"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v74/github"
 	mcpClient "github.com/mark3labs/mcp-go/client"
@@ -94,6 +96,9 @@ func ensureDockerImageBuilt(t *testing.T) {
 type clientOpts struct {
 	// Toolsets to enable in the MCP server
 	enabledToolsets []string
+
+	// mockGitHub is the fake GitHub to run the MCP server against instead of the live API
+	mockGitHub *githubmock.Server
 }
 
 // clientOption defines a function type for configuring ClientOpts
@@ -107,10 +112,23 @@ func withToolsets(toolsets []string) clientOption {
 	}
 }
 
-func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
-	// Get token and ensure Docker image is built
-	token := getE2EToken(t)
+// withMockGitHub returns an option that runs the MCP server against a fake GitHub seeded with the
+// scenario fixture in testdata/scenarios, instead of the live API. No token is needed.
+func withMockGitHub(t *testing.T, scenario string) clientOption {
+	fixture, err := githubmock.LoadScenario(filepath.Join("testdata", "scenarios", scenario+".json"))
+	require.NoError(t, err, "expected to load scenario successfully")
+
+	// Listen on all interfaces, so that the server can reach the fake from its container.
+	srv, err := githubmock.Listen(":0", fixture)
+	require.NoError(t, err, "expected to start mock GitHub successfully")
+	t.Cleanup(func() { _ = srv.Close() })
+
+	return func(opts *clientOpts) {
+		opts.mockGitHub = srv
+	}
+}
 
+func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
 	// Create and configure options
 	opts := &clientOpts{}
 
@@ -119,6 +137,16 @@ func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
 		option(opts)
 	}
 
+	// Get token, unless we run against the mock GitHub, which accepts any token
+	var token string
+	host := getE2EHost()
+	if opts.mockGitHub != nil {
+		token = "mock-token"
+		host = opts.mockGitHub.URL
+	} else {
+		token = getE2EToken(t)
+	}
+
 	// By default, we run the tests including the Docker image, but with DEBUG
 	// enabled, we run the server in-process, allowing for easier debugging.
 	var client *mcpClient.Client
@@ -135,7 +163,11 @@ func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
 			"GITHUB_PERSONAL_ACCESS_TOKEN", // Personal access token is all required
 		}
 
-		host := getE2EHost()
+		if opts.mockGitHub != nil {
+			// The container reaches the mock GitHub through the host's gateway.
+			host = fmt.Sprintf("http://host.docker.internal:%d", opts.mockGitHub.Port())
+			args = append(args, "--add-host", "host.docker.internal:host-gateway")
+		}
 		if host != "" {
 			args = append(args, "-e", "GITHUB_HOST")
 		}
@@ -175,7 +207,7 @@ func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
 		ghServer, err := ghmcp.NewMCPServer(ghmcp.MCPServerConfig{
 			Token:           token,
 			EnabledToolsets: enabledToolsets,
-			Host:            getE2EHost(),
+			Host:            host,
 			Translator:      translations.NullTranslationHelper,
 		})
 		require.NoError(t, err, "expected to construct MCP server successfully")
@@ -324,29 +356,29 @@ func TestTags(t *testing.T) {
 	})
 
 	// Then create a tag
-	// MCP Server doesn't support tag creation, but we can use the GitHub Client
-	ghClient := getRESTClient(t)
+	createTagRequest := mcp.CallToolRequest{}
+	createTagRequest.Params.Name = "create_tag"
+	createTagRequest.Params.Arguments = map[string]any{
+		"owner":   currentOwner,
+		"repo":    repoName,
+		"tag":     "v0.0.1",
+		"ref":     "main",
+		"message": "v0.0.1",
+	}
+
 	t.Logf("Creating tag %s/%s:%s...", currentOwner, repoName, "v0.0.1")
-	ref, _, err := ghClient.Git.GetRef(context.Background(), currentOwner, repoName, "refs/heads/main")
-	require.NoError(t, err, "expected to get ref successfully")
-
-	tagObj, _, err := ghClient.Git.CreateTag(context.Background(), currentOwner, repoName, &gogithub.Tag{
-		Tag:     gogithub.Ptr("v0.0.1"),
-		Message: gogithub.Ptr("v0.0.1"),
-		Object: &gogithub.GitObject{
-			SHA:  ref.Object.SHA,
-			Type: gogithub.Ptr("commit"),
-		},
-	})
-	require.NoError(t, err, "expected to create tag object successfully")
+	resp, err = mcpClient.CallTool(ctx, createTagRequest)
+	require.NoError(t, err, "expected to call 'create_tag' tool successfully")
+	require.False(t, resp.IsError, fmt.Sprintf("expected result not to be an error: %+v", resp))
 
-	_, _, err = ghClient.Git.CreateRef(context.Background(), currentOwner, repoName, &gogithub.Reference{
-		Ref: gogithub.Ptr("refs/tags/v0.0.1"),
-		Object: &gogithub.GitObject{
-			SHA: tagObj.SHA,
-		},
-	})
-	require.NoError(t, err, "expected to create tag ref successfully")
+	textContent, ok = resp.Content[0].(mcp.TextContent)
+	require.True(t, ok, "expected content to be of type TextContent")
+
+	var createdTag struct {
+		CommitSHA string `json:"commit_sha"`
+	}
+	err = json.Unmarshal([]byte(textContent.Text), &createdTag)
+	require.NoError(t, err, "expected to unmarshal text content successfully")
 
 	// List the tags
 	listTagsRequest := mcp.CallToolRequest{}
@@ -378,7 +410,7 @@ func TestTags(t *testing.T) {
 
 	require.Len(t, trimmedTags, 1, "expected to find one tag")
 	require.Equal(t, "v0.0.1", trimmedTags[0].Name, "expected tag name to match")
-	require.Equal(t, *ref.Object.SHA, trimmedTags[0].Commit.SHA, "expected tag SHA to match")
+	require.Equal(t, createdTag.CommitSHA, trimmedTags[0].Commit.SHA, "expected tag SHA to match")
 
 	// And fetch an individual tag
 	getTagRequest := mcp.CallToolRequest{}
@@ -404,7 +436,7 @@ func TestTags(t *testing.T) {
 	require.NoError(t, err, "expected to unmarshal text content successfully")
 	require.Len(t, trimmedTag, 1, "expected to find one tag")
 	require.Equal(t, "v0.0.1", trimmedTag[0].Name, "expected tag name to match")
-	require.Equal(t, *ref.Object.SHA, trimmedTag[0].Commit.SHA, "expected tag SHA to match")
+	require.Equal(t, createdTag.CommitSHA, trimmedTag[0].Commit.SHA, "expected tag SHA to match")
 }
 
 func TestFileDeletion(t *testing.T) {
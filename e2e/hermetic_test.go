@@ -0,0 +1,126 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	mcpClient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// The hermetic tests run the server against a fake GitHub seeded with scenario fixtures, so
+// they need no token and leave no resources behind. Run them on their own with:
+//
+//	go test -v --tags e2e -run TestHermetic ./e2e
+
+// callTool calls a tool and requires it to succeed.
+func callTool(t *testing.T, client *mcpClient.Client, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = args
+
+	response, err := client.CallTool(context.Background(), request)
+	require.NoError(t, err, "expected to call '%s' tool successfully", name)
+	require.NotEmpty(t, response.Content, "expected content not to be empty")
+	require.False(t, response.IsError, "expected result not to be an error: %+v", response.Content)
+	return response
+}
+
+func TestHermeticGetMe(t *testing.T) {
+	t.Parallel()
+
+	mcpClient := setupMCPClient(t, withMockGitHub(t, "basic"))
+	response := callTool(t, mcpClient, "get_me", nil)
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(response.Content[0].(mcp.TextContent).Text), &user))
+	require.Equal(t, "octocat", user.Login)
+}
+
+func TestHermeticFileContents(t *testing.T) {
+	t.Parallel()
+
+	mcpClient := setupMCPClient(t, withMockGitHub(t, "basic"), withToolsets([]string{"repos"}))
+
+	response := callTool(t, mcpClient, "get_file_contents", map[string]any{
+		"owner": "octo-org",
+		"repo":  "hello-world",
+		"path":  "README.md",
+	})
+	require.Len(t, response.Content, 2, "expected a message and the file as a resource")
+	resource, ok := response.Content[1].(mcp.EmbeddedResource)
+	require.True(t, ok, "expected content to be an embedded resource")
+	text, ok := resource.Resource.(mcp.TextResourceContents)
+	require.True(t, ok, "expected a text resource")
+	require.Equal(t, "# Hello World\n\nA repository for end to end tests.\n", text.Text)
+
+	response = callTool(t, mcpClient, "get_file_contents", map[string]any{
+		"owner": "octo-org",
+		"repo":  "hello-world",
+		"path":  "src/",
+	})
+	var entries []struct {
+		Path string `json:"path"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(response.Content[0].(mcp.TextContent).Text), &entries))
+	require.Len(t, entries, 1)
+	require.Equal(t, "src/main.go", entries[0].Path)
+}
+
+func TestHermeticPullRequests(t *testing.T) {
+	t.Parallel()
+
+	mcpClient := setupMCPClient(t, withMockGitHub(t, "basic"), withToolsets([]string{"pull_requests"}))
+
+	response := callTool(t, mcpClient, "list_pull_requests", map[string]any{
+		"owner": "octo-org",
+		"repo":  "hello-world",
+	})
+	var pulls []struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(response.Content[0].(mcp.TextContent).Text), &pulls))
+	require.Len(t, pulls, 1, "expected only the open pull request")
+	require.Equal(t, "Add greeting", pulls[0].Title)
+}
+
+func TestHermeticWorkflowRuns(t *testing.T) {
+	t.Parallel()
+
+	mcpClient := setupMCPClient(t, withMockGitHub(t, "basic"), withToolsets([]string{"actions"}))
+
+	response := callTool(t, mcpClient, "get_workflow_run", map[string]any{
+		"owner":  "octo-org",
+		"repo":   "hello-world",
+		"run_id": float64(101),
+	})
+	var run struct {
+		Conclusion string `json:"conclusion"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(response.Content[0].(mcp.TextContent).Text), &run))
+	require.Equal(t, "failure", run.Conclusion)
+}
+
+func TestHermeticStubs(t *testing.T) {
+	t.Parallel()
+
+	mcpClient := setupMCPClient(t, withMockGitHub(t, "basic"), withToolsets([]string{"releases"}))
+
+	response := callTool(t, mcpClient, "get_latest_release", map[string]any{
+		"owner": "octo-org",
+		"repo":  "hello-world",
+	})
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(response.Content[0].(mcp.TextContent).Text), &release))
+	require.Equal(t, "v1.0.0", release.TagName)
+}
@@ -0,0 +1,90 @@
+package ghmcp
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/audit"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// auditMiddleware records every tool call to sink, with the GitHub API requests made for it.
+// Requests are only collected if the API transport is wrapped in an audit.Transport.
+func auditMiddleware(sink audit.Sink, tokens *TokenStore, logger *slog.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, calls := audit.WithCalls(ctx)
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			args := request.GetArguments()
+			record := audit.Record{
+				Time:       start.UTC(),
+				Tool:       request.Params.Name,
+				ArgsHash:   audit.HashArgs(args),
+				Args:       audit.RedactArgs(args),
+				DurationMS: time.Since(start).Milliseconds(),
+			}
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				record.SessionID = session.SessionID()
+				if withInfo, ok := session.(server.SessionWithClientInfo); ok {
+					info := withInfo.GetClientInfo()
+					record.Client = strings.TrimSpace(info.Name + " " + info.Version)
+				}
+			}
+			token, ok := GitHubTokenFromContext(ctx)
+			if !ok && tokens != nil {
+				token = tokens.Token()
+			}
+			if token != "" {
+				record.TokenFingerprint = audit.TokenFingerprint(token)
+			}
+			switch {
+			case err != nil:
+				record.IsError = true
+				record.Error = audit.RedactError(err.Error())
+			case result != nil && result.IsError:
+				record.IsError = true
+				record.Error = audit.RedactError(resultText(result))
+			}
+			calls.Fill(&record)
+
+			if werr := sink.Write(record); werr != nil && logger != nil {
+				logger.Error("failed to write audit record", "tool", record.Tool, "error", werr)
+			}
+			return result, err
+		}
+	}
+}
+
+// resultText returns the text content of a tool result.
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// openAuditSink opens the audit log at dest, logging records that could not be delivered to
+// an HTTP endpoint. It returns nil if dest is empty.
+func openAuditSink(dest string, logger *slog.Logger) (audit.Sink, error) {
+	if dest == "" {
+		return nil, nil
+	}
+	sink, err := audit.Open(dest)
+	if err != nil {
+		return nil, err
+	}
+	if httpSink, ok := sink.(*audit.HTTPSink); ok {
+		httpSink.OnError = func(err error) {
+			logger.Error("failed to send audit record", "error", err)
+		}
+	}
+	return sink, nil
+}
@@ -0,0 +1,95 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/audit"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySink keeps the records written to it.
+type memorySink struct {
+	records []audit.Record
+}
+
+func (s *memorySink) Write(r audit.Record) error {
+	s.records = append(s.records, r)
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+func TestAuditMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: audit.NewTransport(http.DefaultTransport)}
+
+	sink := &memorySink{}
+	token := "ghp_" + strings.Repeat("c", 36)
+	handler := auditMiddleware(sink, NewTokenStore(token), nil)(
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL, nil)
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			if request.Params.Name == "fail" {
+				return mcp.NewToolResultError("bad token " + token), nil
+			}
+			if request.Params.Name == "crash" {
+				return nil, errors.New("boom")
+			}
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+	call := func(name string, args map[string]any) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = args
+		_, _ = handler(context.Background(), request)
+	}
+
+	args := map[string]any{"owner": "octocat", "repo": "hello-world", "title": "Bug", "body": "Details"}
+	call("create_issue", args)
+	call("fail", nil)
+	call("crash", nil)
+	require.Len(t, sink.records, 3)
+
+	r := sink.records[0]
+	assert.Equal(t, "create_issue", r.Tool)
+	assert.Equal(t, audit.HashArgs(args), r.ArgsHash)
+	assert.Equal(t, "[REDACTED]", r.Args["body"])
+	assert.Equal(t, "Bug", r.Args["title"])
+	assert.Equal(t, audit.TokenFingerprint(token), r.TokenFingerprint)
+	assert.False(t, r.IsError)
+	assert.Equal(t, []int{http.StatusCreated}, r.StatusCodes)
+	assert.Equal(t, 1, r.RateLimitCost)
+	require.NotNil(t, r.RateLimitRemaining)
+	assert.Equal(t, 4321, *r.RateLimitRemaining)
+
+	assert.True(t, sink.records[1].IsError)
+	assert.Equal(t, "bad token [REDACTED]", sink.records[1].Error)
+	assert.True(t, sink.records[2].IsError)
+	assert.Equal(t, "boom", sink.records[2].Error)
+}
+
+func TestOpenAuditSink(t *testing.T) {
+	sink, err := openAuditSink("", nil)
+	require.NoError(t, err)
+	assert.Nil(t, sink)
+
+	sink, err = openAuditSink(t.TempDir()+"/audit.jsonl", nil)
+	require.NoError(t, err)
+	require.NotNil(t, sink)
+	require.NoError(t, sink.Close())
+}
@@ -0,0 +1,48 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/ratelimit"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// apiBudgetMiddleware tags the context of each tool call with the tool, its toolset and the
+// session, so that the API requests made for the call count against their budgets.
+func apiBudgetMiddleware(toolToolsets map[string]string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope := ratelimit.Scope{
+				Tool:    request.Params.Name,
+				Toolset: toolToolsets[request.Params.Name],
+			}
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				scope.Session = session.SessionID()
+			}
+			return next(ratelimit.WithScope(ctx, scope), request)
+		}
+	}
+}
+
+// toolToolsets maps the name of every tool in tsg to the name of its toolset, and checks that
+// every budget is for a tool, a toolset or the session.
+func toolToolsets(tsg *toolsets.ToolsetGroup, budgets map[string]ratelimit.Budget) (map[string]string, error) {
+	tools := make(map[string]string)
+	for name, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			tools[tool.Tool.Name] = name
+		}
+	}
+	for name := range budgets {
+		if _, ok := tsg.Toolsets[name]; ok || name == ratelimit.SessionBudget {
+			continue
+		}
+		if _, ok := tools[name]; !ok {
+			return nil, fmt.Errorf("API budget for unknown tool or toolset: %s", name)
+		}
+	}
+	return tools, nil
+}
@@ -0,0 +1,53 @@
+package ghmcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolToolsets(t *testing.T) {
+	tsg := github.DefaultToolsetGroup(false, nil, nil, nil, translations.NullTranslationHelper, 5000)
+
+	tools, err := toolToolsets(tsg, map[string]ratelimit.Budget{
+		"search_code":           {Calls: 50, Window: time.Hour},
+		"pull_requests":         {Calls: 500, Window: time.Hour},
+		ratelimit.SessionBudget: {Calls: 1000, Window: time.Hour},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "repos", tools["search_code"])
+	assert.Equal(t, "pull_requests", tools["get_pull_request"])
+
+	_, err = toolToolsets(tsg, map[string]ratelimit.Budget{"search": {Calls: 50, Window: time.Hour}})
+	assert.EqualError(t, err, "API budget for unknown tool or toolset: search")
+}
+
+func TestAPIBudgetMiddleware(t *testing.T) {
+	limiter := ratelimit.NewLimiter(map[string]ratelimit.Budget{"repos": {Calls: 1, Window: time.Hour}})
+	handler := apiBudgetMiddleware(map[string]string{"search_code": "repos"})(
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if err := limiter.Allow(ctx); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "search_code"
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	// The second call exceeds the budget of the tool's toolset.
+	result, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
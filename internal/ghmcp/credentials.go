@@ -0,0 +1,100 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// credentialCommandTimeout bounds how long a command or keychain lookup may take to print a token.
+const credentialCommandTimeout = 30 * time.Second
+
+// CredentialProvider supplies the GitHub token the server authenticates with. The server asks
+// for a token at startup and again whenever GitHub rejects the current one, so that short-lived
+// tokens are renewed without restarting the server.
+type CredentialProvider interface {
+	// Token returns the token to authenticate with.
+	Token(ctx context.Context) (string, error)
+}
+
+// FileCredentials reads the token from a file, such as a secret mounted by the environment
+// and rewritten when the token is renewed.
+type FileCredentials struct {
+	Path string
+}
+
+// Token reads the token from the file.
+func (c FileCredentials) Token(_ context.Context) (string, error) {
+	return ReadTokenFile(c.Path)
+}
+
+// CommandCredentials runs a command and uses what it prints as the token, such as a helper
+// that mints a GitHub App installation token.
+type CommandCredentials struct {
+	// Command is the program to run followed by its arguments.
+	Command []string
+}
+
+// Token runs the command and returns its output, ignoring surrounding whitespace.
+func (c CommandCredentials) Token(ctx context.Context) (string, error) {
+	if len(c.Command) == 0 {
+		return "", errors.New("no token command configured")
+	}
+	return runTokenCommand(ctx, c.Command[0], c.Command[1:]...)
+}
+
+// KeychainCredentials looks up the token in the keychain of the operating system: the login
+// keychain on macOS, through the security tool, and the Secret Service on Linux, through
+// secret-tool.
+type KeychainCredentials struct {
+	// Service is the service the token is stored under.
+	Service string
+	// Account is the account the token is stored under, if any.
+	Account string
+}
+
+// Token looks up the token in the keychain.
+func (c KeychainCredentials) Token(ctx context.Context) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		args := []string{"find-generic-password", "-s", c.Service, "-w"}
+		if c.Account != "" {
+			args = append(args, "-a", c.Account)
+		}
+		return runTokenCommand(ctx, "security", args...)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		args := []string{"lookup", "service", c.Service}
+		if c.Account != "" {
+			args = append(args, "account", c.Account)
+		}
+		return runTokenCommand(ctx, "secret-tool", args...)
+	default:
+		return "", fmt.Errorf("reading the token from the keychain is not supported on %s", runtime.GOOS)
+	}
+}
+
+// runTokenCommand runs a command and returns what it prints, which must be a token.
+func runTokenCommand(ctx context.Context, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialCommandTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("failed to get token from %s: %w: %s", name, err, message)
+		}
+		return "", fmt.Errorf("failed to get token from %s: %w", name, err)
+	}
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("%s printed no token", name)
+	}
+	return token, nil
+}
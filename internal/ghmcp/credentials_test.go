@@ -0,0 +1,40 @@
+package ghmcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("ghs_abc\n"), 0600))
+
+	token, err := FileCredentials{Path: path}.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_abc", token)
+}
+
+func TestCommandCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	token, err := CommandCredentials{Command: []string{"sh", "-c", "echo '  ghs_abc  '"}}.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_abc", token)
+
+	_, err = CommandCredentials{Command: []string{"sh", "-c", "echo 'not logged in' >&2; exit 1"}}.Token(context.Background())
+	assert.ErrorContains(t, err, "not logged in")
+
+	_, err = CommandCredentials{Command: []string{"true"}}.Token(context.Background())
+	assert.ErrorContains(t, err, "printed no token")
+
+	_, err = CommandCredentials{}.Token(context.Background())
+	assert.ErrorContains(t, err, "no token command configured")
+}
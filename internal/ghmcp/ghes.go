@@ -0,0 +1,35 @@
+package ghmcp
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	gogithub "github.com/google/go-github/v74/github"
+)
+
+// ghesVersionTimeout limits how long the server waits for the Enterprise Server version at startup.
+const ghesVersionTimeout = 10 * time.Second
+
+// enterpriseServerVersion returns the configured GitHub Enterprise Server version, or detects
+// it with client. It returns nil if the version cannot be detected, so that no tools are left out.
+func enterpriseServerVersion(configured string, client *gogithub.Client, logger *slog.Logger) (*github.GHESVersion, error) {
+	if configured != "" {
+		version, err := github.ParseGHESVersion(configured)
+		if err != nil {
+			return nil, err
+		}
+		return &version, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ghesVersionTimeout)
+	defer cancel()
+	version, err := github.DetectGHESVersion(ctx, client)
+	if err != nil {
+		if logger != nil {
+			logger.Warn("offering all tools, as the GitHub Enterprise Server version is unknown", "error", err)
+		}
+		return nil, nil
+	}
+	return &version, nil
+}
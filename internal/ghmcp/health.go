@@ -0,0 +1,97 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	gogithub "github.com/google/go-github/v74/github"
+)
+
+// readinessTimeout limits how long the readiness check waits for GitHub.
+const readinessTimeout = 5 * time.Second
+
+// healthStatus is the response of the health and readiness endpoints.
+type healthStatus struct {
+	Status    string           `json:"status"`
+	Error     string           `json:"error,omitempty"`
+	RateLimit *healthRateLimit `json:"rate_limit,omitempty"`
+}
+
+type healthRateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// healthHandler reports that the server is up, without calling GitHub.
+func healthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeHealthStatus(w, http.StatusOK, healthStatus{Status: "ok"})
+	})
+}
+
+// readinessHandler reports whether the server can serve tool calls, by checking that client's
+// token is accepted by GitHub, and reports the rate limit remaining for it. Servers without a
+// token of their own, which use the token of each request, pass a nil client and are always ready.
+func readinessHandler(client *gogithub.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if client == nil {
+			writeHealthStatus(w, http.StatusOK, healthStatus{Status: "ready"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+		rateLimit, err := checkToken(ctx, client)
+		if err != nil {
+			writeHealthStatus(w, http.StatusServiceUnavailable, healthStatus{Status: "unavailable", Error: err.Error()})
+			return
+		}
+		writeHealthStatus(w, http.StatusOK, healthStatus{Status: "ready", RateLimit: rateLimit})
+	})
+}
+
+// checkToken verifies the client's token with the rate limit endpoint, which does not count
+// against the rate limit. Enterprise Server instances without rate limits do not serve it, so
+// the token is checked by fetching its user instead.
+func checkToken(ctx context.Context, client *gogithub.Client) (*healthRateLimit, error) {
+	limits, resp, err := client.RateLimit.Get(ctx)
+	if err == nil {
+		core := limits.GetCore()
+		return &healthRateLimit{Limit: core.Limit, Remaining: core.Remaining, Reset: core.Reset.Time}, nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return nil, fmt.Errorf("failed to verify GitHub token: %w", err)
+	}
+	if _, _, err := client.Users.Get(ctx, ""); err != nil {
+		return nil, fmt.Errorf("failed to verify GitHub token: %w", err)
+	}
+	return nil, nil
+}
+
+func writeHealthStatus(w http.ResponseWriter, code int, status healthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// newHealthClient returns a client for the readiness check, authenticated with the server's
+// token. It does not retry or wait out rate limits, so that probes fail fast.
+func newHealthClient(host, version string, tokens *TokenStore) (*gogithub.Client, error) {
+	apiHost, err := parseAPIHost(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API host: %w", err)
+	}
+	client := gogithub.NewClient(&http.Client{
+		Transport: &tokenAuthTransport{
+			transport: http.DefaultTransport,
+			tokens:    tokens,
+		},
+	})
+	client.UserAgent = fmt.Sprintf("github-mcp-server/%s", version)
+	client.BaseURL = apiHost.baseRESTURL
+	return client, nil
+}
@@ -0,0 +1,91 @@
+package ghmcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHealthClient returns a client for the readiness check that sends its requests to handler.
+func newTestHealthClient(t *testing.T, handler http.HandlerFunc) *gogithub.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client, err := newHealthClient("", "test", NewTokenStore("ghp_test"))
+	require.NoError(t, err)
+	client.BaseURL, err = url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	return client
+}
+
+func getHealthStatus(t *testing.T, handler http.Handler) (int, healthStatus) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var status healthStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	return rec.Code, status
+}
+
+func TestHealthHandler(t *testing.T) {
+	code, status := getHealthStatus(t, healthHandler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", status.Status)
+}
+
+func TestReadinessHandler(t *testing.T) {
+	t.Run("reports the rate limit", func(t *testing.T) {
+		client := newTestHealthClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/rate_limit", r.URL.Path)
+			assert.Equal(t, "Bearer ghp_test", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"resources":{"core":{"limit":5000,"remaining":4321,"reset":1750000000}}}`))
+		})
+		code, status := getHealthStatus(t, readinessHandler(client))
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", status.Status)
+		require.NotNil(t, status.RateLimit)
+		assert.Equal(t, 5000, status.RateLimit.Limit)
+		assert.Equal(t, 4321, status.RateLimit.Remaining)
+		assert.Equal(t, int64(1750000000), status.RateLimit.Reset.Unix())
+	})
+
+	t.Run("fails for a rejected token", func(t *testing.T) {
+		client := newTestHealthClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+		})
+		code, status := getHealthStatus(t, readinessHandler(client))
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "unavailable", status.Status)
+		assert.Contains(t, status.Error, "Bad credentials")
+	})
+
+	t.Run("checks the user without rate limits", func(t *testing.T) {
+		client := newTestHealthClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/rate_limit" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"Rate limiting is not enabled."}`))
+				return
+			}
+			assert.Equal(t, "/user", r.URL.Path)
+			_, _ = w.Write([]byte(`{"login":"octocat"}`))
+		})
+		code, status := getHealthStatus(t, readinessHandler(client))
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", status.Status)
+		assert.Nil(t, status.RateLimit)
+	})
+
+	t.Run("is ready without a token of its own", func(t *testing.T) {
+		code, status := getHealthStatus(t, readinessHandler(nil))
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", status.Status)
+	})
+}
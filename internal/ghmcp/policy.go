@@ -0,0 +1,46 @@
+package ghmcp
+
+import (
+	"context"
+
+	"github.com/github/github-mcp-server/pkg/policy"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// policyMiddleware refuses calls to write tools that the policy does not allow, before any
+// other middleware sees them.
+func policyMiddleware(p *policy.Policy, writeTools map[string]bool) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if writeTools[request.Params.Name] {
+				if err := p.CheckWrite(request.Params.Name, request.GetArguments()); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// applyPolicy removes the tools the policy denies from tsg, and returns the names of the write
+// tools that remain.
+func applyPolicy(tsg *toolsets.ToolsetGroup, p *policy.Policy) map[string]bool {
+	var denied []string
+	writeTools := make(map[string]bool)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			annotations := tool.Tool.Annotations
+			destructive := annotations.DestructiveHint != nil && *annotations.DestructiveHint
+			switch {
+			case p.Denies(tool.Tool.Name, destructive):
+				denied = append(denied, tool.Tool.Name)
+			case annotations.ReadOnlyHint == nil || !*annotations.ReadOnlyHint:
+				writeTools[tool.Tool.Name] = true
+			}
+		}
+	}
+	tsg.FilterTools(nil, denied)
+	return writeTools
+}
@@ -0,0 +1,65 @@
+package ghmcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/policy"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPolicy(t *testing.T) {
+	tsg := github.DefaultToolsetGroup(false, nil, nil, nil, translations.NullTranslationHelper, 5000)
+	require.NoError(t, tsg.EnableToolsets([]string{"all"}))
+
+	writeTools := applyPolicy(tsg, &policy.Policy{
+		DenyTools:       []string{"create_branch"},
+		DenyDestructive: true,
+	})
+	assert.True(t, writeTools["create_issue"])
+	assert.False(t, writeTools["get_issue"])
+	assert.False(t, writeTools["create_branch"])
+
+	tools := make(map[string]bool)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			tools[tool.Tool.Name] = true
+		}
+	}
+	assert.True(t, tools["create_issue"])
+	assert.False(t, tools["create_branch"], "denied tools are removed")
+	assert.False(t, tools["delete_file"], "destructive tools are removed")
+}
+
+func TestPolicyMiddleware(t *testing.T) {
+	p, err := policy.Parse([]byte(`write: [{repos: ["octo-org/*"], branches: ["agent/*"]}]`))
+	require.NoError(t, err)
+	handler := policyMiddleware(p, map[string]bool{"push_files": true})(
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+	call := func(name string, args map[string]any) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = args
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call("push_files", map[string]any{"owner": "octo-org", "repo": "app", "branch": "agent/fix"})
+	assert.False(t, result.IsError)
+
+	result = call("push_files", map[string]any{"owner": "octo-org", "repo": "app", "branch": "main"})
+	require.True(t, result.IsError)
+	assert.Equal(t, "policy does not allow push_files on branch main of octo-org/app", result.Content[0].(mcp.TextContent).Text)
+
+	// Read-only tools are not restricted
+	result = call("get_file_contents", map[string]any{"owner": "octocat", "repo": "hello-world"})
+	assert.False(t, result.IsError)
+}
@@ -0,0 +1,232 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ReadOnlyOverride is which read-only mode clients of the Streamable HTTP server may ask for,
+// instead of the mode of the server.
+type ReadOnlyOverride string
+
+const (
+	// ReadOnlyOverrideNone refuses requests asking for a mode other than the server's.
+	ReadOnlyOverrideNone ReadOnlyOverride = "none"
+	// ReadOnlyOverrideRestrict lets clients ask for read-only mode, but not turn it off.
+	ReadOnlyOverrideRestrict ReadOnlyOverride = "restrict"
+	// ReadOnlyOverrideAny lets clients ask for read-only mode, or turn it off.
+	ReadOnlyOverrideAny ReadOnlyOverride = "any"
+)
+
+// ParseReadOnlyOverride parses a ReadOnlyOverride, which is none if empty.
+func ParseReadOnlyOverride(value string) (ReadOnlyOverride, error) {
+	switch override := ReadOnlyOverride(value); override {
+	case "":
+		return ReadOnlyOverrideNone, nil
+	case ReadOnlyOverrideNone, ReadOnlyOverrideRestrict, ReadOnlyOverrideAny:
+		return override, nil
+	default:
+		return "", fmt.Errorf("invalid read-only override %q, expected none, restrict or any", value)
+	}
+}
+
+const (
+	// readOnlyHeader is the header a request asks for a read-only mode with.
+	readOnlyHeader = "X-MCP-Read-Only"
+	// readOnlyQueryParam is the query parameter a request asks for a read-only mode with, for
+	// clients that cannot set headers.
+	readOnlyQueryParam = "read_only"
+)
+
+type readOnlyCtxKey struct{}
+
+// ContextWithReadOnly returns a context carrying whether the request is served in read-only
+// mode, for servers created with a ReadOnlyOverride.
+func ContextWithReadOnly(ctx context.Context, readOnly bool) context.Context {
+	return context.WithValue(ctx, readOnlyCtxKey{}, readOnly)
+}
+
+// readOnlyFromContext returns whether the request is served in read-only mode, which is
+// fallback unless the request asked otherwise.
+func readOnlyFromContext(ctx context.Context, fallback bool) bool {
+	if readOnly, ok := ctx.Value(readOnlyCtxKey{}).(bool); ok {
+		return readOnly
+	}
+	return fallback
+}
+
+// readOnlySessionIdleTimeout is how long the read-only mode of a session is kept after its last
+// request, for clients that do not end their sessions.
+const readOnlySessionIdleTimeout = 24 * time.Hour
+
+// readOnlySession is the read-only mode a session was started in.
+type readOnlySession struct {
+	readOnly bool
+	lastSeen time.Time
+}
+
+// readOnlySessions holds the read-only mode of each session, by session ID.
+type readOnlySessions struct {
+	mu       sync.Mutex
+	sessions map[string]readOnlySession
+	now      func() time.Time
+}
+
+// get returns the mode of a session, marking it as used.
+func (s *readOnlySessions) get(id string) (bool, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	if ok {
+		session.lastSeen = s.now()
+		s.sessions[id] = session
+	}
+	return session.readOnly, ok
+}
+
+// start records the mode of a session unless it already has one, and forgets the modes of
+// sessions idle for longer than readOnlySessionIdleTimeout.
+func (s *readOnlySessions) start(id string, readOnly bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for other, session := range s.sessions {
+		if now.Sub(session.lastSeen) > readOnlySessionIdleTimeout {
+			delete(s.sessions, other)
+		}
+	}
+	if _, ok := s.sessions[id]; !ok {
+		s.sessions[id] = readOnlySession{readOnly: readOnly, lastSeen: now}
+	}
+}
+
+// end forgets the mode of a session.
+func (s *readOnlySessions) end(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+}
+
+// readOnlyHandler serves each session in the read-only mode its initialize request asks for with
+// the X-MCP-Read-Only header or the read_only query parameter, or in the mode of the server if it
+// asks for none. The mode is kept for the lifetime of the session, identified by its
+// Mcp-Session-Id header: later requests of the session asking for another mode are refused.
+// Requests asking for a mode the override does not allow are refused too. Stateless servers have
+// no sessions, so each of their requests is served in the mode it asks for.
+func readOnlyHandler(next http.Handler, readOnly bool, override ReadOnlyOverride) http.Handler {
+	if override == "" {
+		override = ReadOnlyOverrideNone
+	}
+	sessions := &readOnlySessions{sessions: make(map[string]readOnlySession), now: time.Now}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(readOnlyHeader)
+		if value == "" {
+			value = r.URL.Query().Get(readOnlyQueryParam)
+		}
+		var requested *bool
+		if value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid read-only mode %q, expected true or false", value), http.StatusBadRequest)
+				return
+			}
+			requested = &parsed
+		}
+
+		sessionID := r.Header.Get(server.HeaderKeySessionID)
+		if sessionID != "" {
+			if sessionReadOnly, ok := sessions.get(sessionID); ok {
+				if requested != nil && *requested != sessionReadOnly {
+					http.Error(w, "the read-only mode of a session cannot be changed after it started", http.StatusForbidden)
+					return
+				}
+				if r.Method == http.MethodDelete {
+					sessions.end(sessionID)
+				}
+				next.ServeHTTP(w, r.WithContext(ContextWithReadOnly(r.Context(), sessionReadOnly)))
+				return
+			}
+		}
+
+		mode := readOnly
+		if requested != nil {
+			switch {
+			case *requested == readOnly:
+			case *requested && override != ReadOnlyOverrideNone:
+			case !*requested && override == ReadOnlyOverrideAny:
+			case *requested:
+				http.Error(w, "this server does not allow sessions to switch to read-only mode", http.StatusForbidden)
+				return
+			default:
+				http.Error(w, "this server does not allow sessions to turn off read-only mode", http.StatusForbidden)
+				return
+			}
+			mode = *requested
+		}
+
+		// Sessions not seen before, such as those started before the server restarted, keep the
+		// mode of their first request.
+		if sessionID != "" && r.Method != http.MethodDelete {
+			sessions.start(sessionID, mode)
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithReadOnly(r.Context(), mode)))
+		// The session ID of a new session is in the response to its initialize request
+		if sessionID == "" {
+			if started := w.Header().Get(server.HeaderKeySessionID); started != "" {
+				sessions.start(started, mode)
+			}
+		}
+	})
+}
+
+// readOnlyMiddleware refuses calls to write tools in requests served in read-only mode.
+func readOnlyMiddleware(readOnly bool, writeTools map[string]bool) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if writeTools[request.Params.Name] && readOnlyFromContext(ctx, readOnly) {
+				return mcp.NewToolResultError(fmt.Sprintf("tool %s is not available in read-only mode", request.Params.Name)), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// readOnlyToolFilter leaves write tools out of the tools listed in requests served in
+// read-only mode.
+func readOnlyToolFilter(readOnly bool, writeTools map[string]bool) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		if !readOnlyFromContext(ctx, readOnly) {
+			return tools
+		}
+		filtered := make([]mcp.Tool, 0, len(tools))
+		for _, tool := range tools {
+			if !writeTools[tool.Name] {
+				filtered = append(filtered, tool)
+			}
+		}
+		return filtered
+	}
+}
+
+// readOnlyWriteTools returns the names of the write tools of tsg. batch_call is not one of them,
+// as the calls it makes are checked themselves.
+func readOnlyWriteTools(tsg *toolsets.ToolsetGroup) map[string]bool {
+	writeTools := make(map[string]bool)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			readOnly := tool.Tool.Annotations.ReadOnlyHint
+			if (readOnly == nil || !*readOnly) && tool.Tool.Name != "batch_call" {
+				writeTools[tool.Tool.Name] = true
+			}
+		}
+	}
+	return writeTools
+}
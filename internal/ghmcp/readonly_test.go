@@ -0,0 +1,177 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReadOnlyOverride(t *testing.T) {
+	override, err := ParseReadOnlyOverride("")
+	require.NoError(t, err)
+	assert.Equal(t, ReadOnlyOverrideNone, override)
+
+	override, err = ParseReadOnlyOverride("any")
+	require.NoError(t, err)
+	assert.Equal(t, ReadOnlyOverrideAny, override)
+
+	_, err = ParseReadOnlyOverride("sometimes")
+	assert.ErrorContains(t, err, "invalid read-only override")
+}
+
+func TestReadOnlyHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		readOnly         bool
+		override         ReadOnlyOverride
+		header           string
+		query            string
+		expectedCode     int
+		expectedReadOnly bool
+	}{
+		{name: "no mode asked for", readOnly: true, override: ReadOnlyOverrideNone, expectedCode: http.StatusOK, expectedReadOnly: true},
+		{name: "mode of the server", readOnly: false, override: ReadOnlyOverrideNone, header: "false", expectedCode: http.StatusOK},
+		{name: "restrict allows read-only", readOnly: false, override: ReadOnlyOverrideRestrict, header: "true", expectedCode: http.StatusOK, expectedReadOnly: true},
+		{name: "restrict refuses writes", readOnly: true, override: ReadOnlyOverrideRestrict, header: "false", expectedCode: http.StatusForbidden},
+		{name: "none refuses read-only", readOnly: false, override: ReadOnlyOverrideNone, query: "true", expectedCode: http.StatusForbidden},
+		{name: "empty override refuses read-only", readOnly: false, header: "true", expectedCode: http.StatusForbidden},
+		{name: "any allows writes", readOnly: true, override: ReadOnlyOverrideAny, query: "false", expectedCode: http.StatusOK},
+		{name: "invalid mode", readOnly: false, override: ReadOnlyOverrideAny, header: "maybe", expectedCode: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var readOnly bool
+			handler := readOnlyHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				readOnly = readOnlyFromContext(r.Context(), tc.readOnly)
+				w.WriteHeader(http.StatusOK)
+			}), tc.readOnly, tc.override)
+
+			target := "/mcp"
+			if tc.query != "" {
+				target += "?read_only=" + tc.query
+			}
+			r := httptest.NewRequest(http.MethodPost, target, nil)
+			if tc.header != "" {
+				r.Header.Set("X-MCP-Read-Only", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedCode == http.StatusOK {
+				assert.Equal(t, tc.expectedReadOnly, readOnly)
+			}
+		})
+	}
+}
+
+func TestReadOnlyHandler_KeepsModeOfSession(t *testing.T) {
+	var readOnly bool
+	handler := readOnlyHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readOnly = readOnlyFromContext(r.Context(), false)
+		if r.Header.Get("Mcp-Session-Id") == "" {
+			// Initialize requests start a session
+			w.Header().Set("Mcp-Session-Id", "session-1")
+		}
+		w.WriteHeader(http.StatusOK)
+	}), false, ReadOnlyOverrideRestrict)
+
+	send := func(method, sessionID, mode string) int {
+		r := httptest.NewRequest(method, "/mcp", nil)
+		if sessionID != "" {
+			r.Header.Set("Mcp-Session-Id", sessionID)
+		}
+		if mode != "" {
+			r.Header.Set("X-MCP-Read-Only", mode)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "", "true"))
+	assert.True(t, readOnly)
+
+	// Later requests of the session keep its mode without asking for it
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "session-1", ""))
+	assert.True(t, readOnly)
+
+	// and cannot ask for another one
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "session-1", "false"))
+
+	// Sessions started before the server restarted keep the mode of their first request
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "session-2", ""))
+	assert.False(t, readOnly)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "session-2", "true"))
+
+	// Once the session ends, its mode is forgotten
+	require.Equal(t, http.StatusOK, send(http.MethodDelete, "session-1", ""))
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "session-1", "false"))
+	assert.False(t, readOnly)
+}
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	handler := readOnlyMiddleware(false, map[string]bool{"create_issue": true})(
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+	call := func(ctx context.Context, name string) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		return result
+	}
+
+	assert.False(t, call(context.Background(), "create_issue").IsError)
+	assert.False(t, call(ContextWithReadOnly(context.Background(), true), "get_issue").IsError)
+
+	result := call(ContextWithReadOnly(context.Background(), true), "create_issue")
+	require.True(t, result.IsError)
+	assert.Equal(t, "tool create_issue is not available in read-only mode", result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestNewMCPServer_ReadOnlyOverride(t *testing.T) {
+	s, err := NewMCPServer(MCPServerConfig{
+		Token:            "ghp_abc",
+		EnabledToolsets:  []string{"issues"},
+		ReadOnly:         true,
+		ReadOnlyOverride: ReadOnlyOverrideAny,
+		Translator:       translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	listTools := func(ctx context.Context) map[string]bool {
+		response := s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+		result, ok := response.(mcp.JSONRPCResponse).Result.(mcp.ListToolsResult)
+		require.True(t, ok)
+		names := make(map[string]bool)
+		for _, tool := range result.Tools {
+			names[tool.Name] = true
+		}
+		return names
+	}
+
+	// Write tools are registered, but hidden from sessions in the read-only mode of the server
+	require.NotNil(t, s.GetTool("create_issue"))
+	tools := listTools(context.Background())
+	assert.True(t, tools["get_issue"])
+	assert.False(t, tools["create_issue"])
+
+	tools = listTools(ContextWithReadOnly(context.Background(), false))
+	assert.True(t, tools["create_issue"])
+
+	// and refused to them when called anyway
+	response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"create_issue","arguments":{"owner":"o","repo":"r","title":"t"}}}`))
+	result, ok := response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult)
+	require.True(t, ok)
+	require.True(t, result.IsError)
+	assert.Equal(t, "tool create_issue is not available in read-only mode", result.Content[0].(mcp.TextContent).Text)
+}
@@ -0,0 +1,190 @@
+package ghmcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// streamHistorySize is the number of events of each session's stream kept for resuming it.
+const streamHistorySize = 100
+
+// streamSessionIdleTimeout is how long the events of a session are kept after its last event,
+// for clients that do not end their sessions.
+const streamSessionIdleTimeout = 24 * time.Hour
+
+// streamEvent is an event sent on the stream of a session, without its ID.
+type streamEvent struct {
+	id    uint64
+	frame []byte
+}
+
+// sessionStream is the recent history of the stream of a session.
+type sessionStream struct {
+	lastID   uint64
+	events   []streamEvent
+	lastSeen time.Time
+}
+
+// resumableStreams holds the recent events of the stream of each session, by session ID.
+type resumableStreams struct {
+	mu       sync.Mutex
+	sessions map[string]*sessionStream
+	now      func() time.Time
+}
+
+// record numbers an event sent to a session and returns it with its ID. Events other than
+// heartbeats are kept for resuming the stream. Recording the first event of a session forgets
+// the events of sessions idle for longer than streamSessionIdleTimeout.
+func (s *resumableStreams) record(sessionID string, frame []byte) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	stream, ok := s.sessions[sessionID]
+	if !ok {
+		for other, idle := range s.sessions {
+			if now.Sub(idle.lastSeen) > streamSessionIdleTimeout {
+				delete(s.sessions, other)
+			}
+		}
+		stream = &sessionStream{}
+		s.sessions[sessionID] = stream
+	}
+	stream.lastID++
+	stream.lastSeen = now
+
+	if !isHeartbeat(frame) {
+		stream.events = append(stream.events, streamEvent{id: stream.lastID, frame: bytes.Clone(frame)})
+		if len(stream.events) > streamHistorySize {
+			stream.events = stream.events[len(stream.events)-streamHistorySize:]
+		}
+	}
+	return withEventID(stream.lastID, frame)
+}
+
+// since returns the kept events of a session sent after the event with the given ID.
+func (s *resumableStreams) since(sessionID string, lastID uint64) [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stream, ok := s.sessions[sessionID]
+	if !ok {
+		return nil
+	}
+	var frames [][]byte
+	for _, event := range stream.events {
+		if event.id > lastID {
+			frames = append(frames, withEventID(event.id, event.frame))
+		}
+	}
+	return frames
+}
+
+// end forgets the events of a session.
+func (s *resumableStreams) end(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+}
+
+func withEventID(id uint64, frame []byte) []byte {
+	return append([]byte(fmt.Sprintf("id: %d\n", id)), frame...)
+}
+
+// isHeartbeat reports whether an event is a ping, which is not worth replaying.
+func isHeartbeat(frame []byte) bool {
+	for _, line := range bytes.Split(frame, []byte("\n")) {
+		if data, ok := bytes.CutPrefix(line, []byte("data: ")); ok {
+			var message struct {
+				Method string `json:"method"`
+			}
+			return json.Unmarshal(data, &message) == nil && message.Method == "ping"
+		}
+	}
+	return false
+}
+
+// resumableStreamHandler numbers the events of the streams sessions listen on with GET requests,
+// so that a client whose stream broke can open a new one with the Last-Event-ID header and
+// receive the events sent after that one first. The last streamHistorySize events of each
+// session are kept until it ends. Responses streamed back to POST requests are not numbered and
+// cannot be resumed.
+func resumableStreamHandler(next http.Handler) http.Handler {
+	streams := &resumableStreams{sessions: make(map[string]*sessionStream), now: time.Now}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get(server.HeaderKeySessionID)
+		switch {
+		case sessionID == "":
+			next.ServeHTTP(w, r)
+		case r.Method == http.MethodDelete:
+			streams.end(sessionID)
+			next.ServeHTTP(w, r)
+		case r.Method == http.MethodGet:
+			var replay [][]byte
+			if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+				id, err := strconv.ParseUint(lastEventID, 10, 64)
+				if err != nil {
+					http.Error(w, fmt.Sprintf("invalid Last-Event-ID %q", lastEventID), http.StatusBadRequest)
+					return
+				}
+				replay = streams.since(sessionID, id)
+			}
+			next.ServeHTTP(&resumableStreamWriter{ResponseWriter: w, streams: streams, sessionID: sessionID, replay: replay}, r)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// resumableStreamWriter numbers the events written to the stream of a session, after replaying
+// the events the client missed once the stream is opened.
+type resumableStreamWriter struct {
+	http.ResponseWriter
+	streams   *resumableStreams
+	sessionID string
+	replay    [][]byte
+	pending   []byte
+}
+
+func (w *resumableStreamWriter) WriteHeader(statusCode int) {
+	w.ResponseWriter.WriteHeader(statusCode)
+	if statusCode == http.StatusOK {
+		for _, frame := range w.replay {
+			if _, err := w.ResponseWriter.Write(frame); err != nil {
+				break
+			}
+		}
+	}
+	w.replay = nil
+}
+
+// Write numbers each complete event, which ends with a blank line, and holds back the rest.
+func (w *resumableStreamWriter) Write(p []byte) (int, error) {
+	if w.Header().Get("Content-Type") != "text/event-stream" {
+		return w.ResponseWriter.Write(p)
+	}
+	w.pending = append(w.pending, p...)
+	for {
+		end := bytes.Index(w.pending, []byte("\n\n"))
+		if end < 0 {
+			break
+		}
+		frame := w.pending[:end+2]
+		w.pending = w.pending[end+2:]
+		if _, err := w.ResponseWriter.Write(w.streams.record(w.sessionID, frame)); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (w *resumableStreamWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,149 @@
+package ghmcp
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumableStreamHandler(t *testing.T) {
+	var events []string
+	handler := resumableStreamHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for _, event := range events {
+			// Events may be written in parts
+			half := len(event) / 2
+			_, _ = w.Write([]byte(event[:half]))
+			_, _ = w.Write([]byte(event[half:]))
+		}
+	}))
+
+	listen := func(sessionID, lastEventID string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/mcp", nil)
+		r.Header.Set("Mcp-Session-Id", sessionID)
+		if lastEventID != "" {
+			r.Header.Set("Last-Event-ID", lastEventID)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec
+	}
+
+	events = []string{
+		"event: message\ndata: {\"method\":\"notifications/one\"}\n\n",
+		"event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":1,\"method\":\"ping\"}\n\n",
+		"event: message\ndata: {\"method\":\"notifications/two\"}\n\n",
+	}
+	rec := listen("session-1", "")
+	assert.Equal(t, "id: 1\n"+events[0]+"id: 2\n"+events[1]+"id: 3\n"+events[2], rec.Body.String())
+
+	// A resumed stream first replays the events after the last one received, except heartbeats
+	events = []string{"event: message\ndata: {\"method\":\"notifications/three\"}\n\n"}
+	rec = listen("session-1", "1")
+	assert.Equal(t,
+		"id: 3\nevent: message\ndata: {\"method\":\"notifications/two\"}\n\n"+
+			"id: 4\nevent: message\ndata: {\"method\":\"notifications/three\"}\n\n",
+		rec.Body.String())
+
+	// Each session has its own events
+	events = nil
+	assert.Empty(t, listen("session-2", "1").Body.String())
+
+	assert.Equal(t, http.StatusBadRequest, listen("session-1", "abc").Code)
+
+	// Once the session ends, its events are forgotten
+	r := httptest.NewRequest(http.MethodDelete, "/mcp", nil)
+	r.Header.Set("Mcp-Session-Id", "session-1")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Empty(t, listen("session-1", "1").Body.String())
+}
+
+func TestResumableStreams_KeepsRecentEvents(t *testing.T) {
+	now := time.Now()
+	streams := &resumableStreams{sessions: make(map[string]*sessionStream), now: func() time.Time { return now }}
+	for i := 0; i < streamHistorySize+5; i++ {
+		streams.record("session-1", []byte("data: {}\n\n"))
+	}
+	frames := streams.since("session-1", 0)
+	require.Len(t, frames, streamHistorySize)
+	assert.Equal(t, "id: 6\ndata: {}\n\n", string(frames[0]))
+
+	// Idle sessions are forgotten when another one starts
+	now = now.Add(streamSessionIdleTimeout + time.Minute)
+	streams.record("session-2", []byte("data: {}\n\n"))
+	assert.Empty(t, streams.since("session-1", 0))
+	assert.Len(t, streams.since("session-2", 0), 1)
+}
+
+func TestHTTPHandler_ResumesStreams(t *testing.T) {
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"repos"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+	srv := httptest.NewServer(newHTTPHandler(ghServer, StreamableHTTPServerConfig{EndpointPath: "/mcp"}, nil, nil))
+	t.Cleanup(srv.Close)
+
+	rec := postMCP(t, srv.Config.Handler, nil, testInitializeRequest)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	sessionID := rec.Header().Get("Mcp-Session-Id")
+	require.NotEmpty(t, sessionID)
+
+	// listen opens the stream of the session and returns the first event sent on it.
+	listen := func(lastEventID string, send bool) []string {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		// A new stream is refused until the server notices the previous one was closed
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			r, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/mcp", nil)
+			if err != nil {
+				return false
+			}
+			r.Header.Set("Mcp-Session-Id", sessionID)
+			if lastEventID != "" {
+				r.Header.Set("Last-Event-ID", lastEventID)
+			}
+			resp, err = http.DefaultClient.Do(r)
+			if err != nil {
+				return false
+			}
+			if resp.StatusCode != http.StatusOK {
+				_ = resp.Body.Close()
+				return false
+			}
+			return true
+		}, time.Second, 10*time.Millisecond)
+		defer func() { _ = resp.Body.Close() }()
+
+		if send {
+			// The session is registered once the stream is open
+			require.Eventually(t, func() bool {
+				return ghServer.SendNotificationToSpecificClient(sessionID, "notifications/test", map[string]any{"n": 1}) == nil
+			}, time.Second, 10*time.Millisecond)
+		}
+		var lines []string
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() && scanner.Text() != "" {
+			lines = append(lines, scanner.Text())
+		}
+		return lines
+	}
+
+	lines := listen("", true)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "id: 1", lines[0])
+	assert.Contains(t, lines[2], "notifications/test")
+
+	// The stream broke before the client saw the event, so it is sent again
+	assert.Equal(t, lines, listen("0", false))
+}
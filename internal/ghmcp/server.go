@@ -2,22 +2,40 @@ package ghmcp
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/github/github-mcp-server/pkg/audit"
+	"github.com/github/github-mcp-server/pkg/blobcache"
+	"github.com/github/github-mcp-server/pkg/cache"
 	"github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/journal"
+	"github.com/github/github-mcp-server/pkg/localclone"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/macros"
+	"github.com/github/github-mcp-server/pkg/policy"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/retry"
+	"github.com/github/github-mcp-server/pkg/signing"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/tracing"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/webhooks"
 	gogithub "github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -34,10 +52,20 @@ type MCPServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// Tokens holds the GitHub token to authenticate with if set, taking precedence over
+	// Token. The token can be rotated through it while the server is running.
+	Tokens *TokenStore
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
 
+	// EnabledTools, if not empty, limits the tools of the enabled toolsets to the ones named in it
+	EnabledTools []string
+
+	// DisabledTools lists tools to leave out of the enabled toolsets
+	DisabledTools []string
+
 	// Whether to enable dynamic toolsets
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#dynamic-tool-discovery
 	DynamicToolsets bool
@@ -45,11 +73,131 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// ReadOnlyOverride lets requests be served in another read-only mode than ReadOnly, as
+	// carried by their context with ContextWithReadOnly. Write tools are then hidden from and
+	// refused to requests served in read-only mode, rather than left out of the server.
+	ReadOnlyOverride ReadOnlyOverride
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
 
 	// Content window size
 	ContentWindowSize int
+
+	// RepoAffinity enables ranking search results towards repositories the session recently touched
+	RepoAffinity bool
+
+	// JournalDir is the directory multi-step operations are journaled to. If empty,
+	// operations are only journaled in memory for the lifetime of the server.
+	JournalDir string
+
+	// WebhookHub receives webhook deliveries. If set, received events are forwarded to
+	// connected clients as notifications and the webhooks tools can register webhooks for it.
+	WebhookHub *webhooks.Hub
+
+	// PerRequestToken makes tools authenticate with the token stored in the request context
+	// by ContextWithGitHubToken instead of Token, so that one server can serve many users.
+	PerRequestToken bool
+
+	// CacheTTL enables caching of REST API responses. Cached responses younger than the TTL
+	// are reused as is; older ones are revalidated with conditional requests.
+	CacheTTL time.Duration
+
+	// LocalCloneDir enables serving file contents and searches of frequently used repositories
+	// from shallow clones kept in this directory.
+	LocalCloneDir string
+
+	// LocalCloneFetchInterval is how often local clones are fetched. Defaults to
+	// localclone.DefaultFetchInterval if zero.
+	LocalCloneFetchInterval time.Duration
+
+	// BlobCacheMemoryBytes is the size of the in-memory tier of the cache for file contents
+	// and diffs, which are keyed by SHA and never change.
+	BlobCacheMemoryBytes int64
+
+	// BlobCacheDir adds a disk tier to the blob cache, which keeps blobs across restarts.
+	BlobCacheDir string
+
+	// BlobCacheDiskBytes is the size of the disk tier of the blob cache.
+	BlobCacheDiskBytes int64
+
+	// APIBudgets limits the API requests made for tools, toolsets or each session, keyed by the
+	// name of the tool or toolset, or by ratelimit.SessionBudget.
+	APIBudgets map[string]ratelimit.Budget
+
+	// RetryPolicy retries API requests that failed with transient server or network errors.
+	// Requests are not retried if its MaxAttempts is 1 or less.
+	RetryPolicy retry.Policy
+
+	// ToolAliasCutoff stops serving the former names of renamed tools that were deprecated
+	// before it. All aliases are served if zero.
+	ToolAliasCutoff time.Time
+
+	// ToolAliasUsage records the calls made to tools by their former names, if set.
+	ToolAliasUsage *github.AliasUsage
+
+	// Policy restricts the repositories and branches write tools may be used in, and denies
+	// tools that may not be used at all. Nothing is restricted if nil.
+	Policy *policy.Policy
+
+	// AuditSink records every tool call, if set.
+	AuditSink audit.Sink
+
+	// Macros are composite operations offered as tools of the macros toolset.
+	Macros []macros.Macro
+
+	// GHESVersion is the version of the GitHub Enterprise Server instance at Host, such as 3.14.
+	// It is detected from the meta endpoint if empty.
+	GHESVersion string
+
+	// Tracing starts OpenTelemetry spans for tool calls and GitHub API requests, with the
+	// global tracer provider.
+	Tracing bool
+
+	// ToolTimeouts limits how long tool calls may take. Calls are not limited if zero.
+	ToolTimeouts ToolTimeouts
+
+	// FetchAllLimits bounds the items that paged tools return when called with fetch_all. The
+	// fetch_all parameter is not offered if MaxItems is zero.
+	FetchAllLimits github.FetchAllLimits
+
+	// BatchLimits bounds the calls made by batch_call. batch_call is not offered if MaxCalls is
+	// zero.
+	BatchLimits github.BatchLimits
+
+	// CommitSigner signs the commits that tools make, if set.
+	CommitSigner gogithub.MessageSigner
+
+	// Logger logs background work, such as updating local clones. Nothing is logged if nil.
+	Logger *slog.Logger
+}
+
+type githubTokenCtxKey struct{}
+
+// ContextWithGitHubToken returns a context carrying the GitHub token to use for the request,
+// for servers created with PerRequestToken.
+func ContextWithGitHubToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, githubTokenCtxKey{}, token)
+}
+
+// GitHubTokenFromContext returns the GitHub token stored by ContextWithGitHubToken.
+func GitHubTokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(githubTokenCtxKey{}).(string)
+	return token, ok && token != ""
+}
+
+// journalCaller identifies the caller operations are journaled for. With per-request tokens,
+// that is a hash of the request's token, so that only the same token can resume an operation.
+// Otherwise every call uses the server's token, and all operations belong to one caller.
+func journalCaller(perRequestToken bool) func(ctx context.Context) string {
+	if !perRequestToken {
+		return nil
+	}
+	return func(ctx context.Context) string {
+		token, _ := GitHubTokenFromContext(ctx)
+		sum := sha256.Sum256([]byte(token))
+		return hex.EncodeToString(sum[:])
+	}
 }
 
 const stdioServerLogPrefix = "stdioserver"
@@ -60,8 +208,43 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
-	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	tokens := cfg.Tokens
+	if tokens == nil {
+		tokens = NewTokenStore(cfg.Token)
+	}
+
+	// All API requests wait out rate limits and retry rather than failing straight away, and
+	// transient failures are retried underneath, so that they do not use up rate limit retries
+	apiTransport := http.RoundTripper(http.DefaultTransport)
+	// Every response is audited, including the ones that are retried
+	if cfg.AuditSink != nil {
+		apiTransport = audit.NewTransport(apiTransport)
+	}
+	if cfg.Tracing {
+		apiTransport = tracing.NewTransport(apiTransport)
+	}
+	if cfg.RetryPolicy.MaxAttempts > 1 {
+		apiTransport = retry.NewTransport(apiTransport, cfg.RetryPolicy)
+	}
+	apiTransport = ratelimit.NewTransport(apiTransport, ratelimit.DefaultMaxRetries, ratelimit.DefaultMaxWait)
+	// Budgets are checked before retries, so that each API call only counts once
+	if len(cfg.APIBudgets) > 0 {
+		apiTransport = ratelimit.NewLimiter(cfg.APIBudgets).Transport(apiTransport)
+	}
+
+	// Construct our REST client, sharing one response cache between all clients if enabled
+	restTransport := http.RoundTripper(apiTransport)
+	if cfg.CacheTTL > 0 {
+		restTransport = cache.NewTransport(apiTransport, cfg.CacheTTL, cache.DefaultMaxEntries)
+	}
+	restHTTPClient := &http.Client{Transport: restTransport}
+	// The token is read for every request rather than fixed on the client, so that it can be rotated.
+	restClient := gogithub.NewClient(&http.Client{
+		Transport: &tokenAuthTransport{
+			transport: restTransport,
+			tokens:    tokens,
+		},
+	})
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
@@ -70,9 +253,9 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
 	// did the necessary API host parsing so that github.com will return the correct URL anyway.
 	gqlHTTPClient := &http.Client{
-		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
-			token:     cfg.Token,
+		Transport: &tokenAuthTransport{
+			transport: apiTransport,
+			tokens:    tokens,
 		},
 	} // We're going to wrap the Transport later in beforeInit
 	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
@@ -94,8 +277,10 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
+	calls := newToolCalls()
 	hooks := &server.Hooks{
 		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
+		OnBeforeCallTool:   []server.OnBeforeCallToolFunc{calls.rememberRequestID},
 		OnBeforeAny: []server.BeforeAnyHookFunc{
 			func(ctx context.Context, _ any, _ mcp.MCPMethod, _ any) {
 				// Ensure the context is cleared of any previous errors
@@ -105,7 +290,137 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		},
 	}
 
-	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
+	// Tools and parameters that an Enterprise Server instance does not support yet are left out,
+	// rather than failing with 404s
+	var ghesVersion *github.GHESVersion
+	if apiHost.enterpriseServer {
+		versionClient := restClient
+		if cfg.PerRequestToken {
+			// There is no token to ask with, so ask anonymously
+			versionClient = gogithub.NewClient(restHTTPClient)
+			versionClient.BaseURL = apiHost.baseRESTURL
+		}
+		ghesVersion, err = enterpriseServerVersion(cfg.GHESVersion, versionClient, cfg.Logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	journalStore := journal.Store(journal.NewMemoryStore())
+	if cfg.JournalDir != "" {
+		journalStore, err = journal.NewFileStore(cfg.JournalDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open operation journal: %w", err)
+		}
+	}
+
+	serverOpts := []server.ServerOption{
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(github.OperationJournalMiddleware(journal.New(journalStore), journalCaller(cfg.PerRequestToken))),
+	}
+	if cfg.RepoAffinity {
+		tracker := github.NewRepoAffinityTracker(github.DefaultRepoAffinitySize)
+		hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+			tracker.Forget(session.SessionID())
+		})
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.RepoAffinityMiddleware(tracker)))
+	}
+	if cfg.WebhookHub != nil {
+		if cfg.PerRequestToken {
+			// Events are shared by everyone using the server, so they would reach users whose
+			// token cannot read the repositories they come from.
+			return nil, fmt.Errorf("the webhook receiver cannot be used with per-request tokens")
+		}
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.WebhookHubMiddleware(cfg.WebhookHub)))
+	}
+	if cfg.CacheTTL > 0 {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.CacheMetadataMiddleware()))
+	}
+	if cfg.RetryPolicy.MaxAttempts > 1 {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.RetryMetadataMiddleware()))
+	}
+	if cfg.CommitSigner != nil {
+		if cfg.PerRequestToken {
+			// The key belongs to one user, and commits are only verified for that user.
+			return nil, fmt.Errorf("commit signing cannot be used with per-request tokens")
+		}
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.CommitSigningMiddleware(cfg.CommitSigner)))
+	}
+	if cfg.BlobCacheMemoryBytes > 0 || cfg.BlobCacheDir != "" {
+		blobs, err := blobcache.New(cfg.BlobCacheMemoryBytes, cfg.BlobCacheDir, cfg.BlobCacheDiskBytes)
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.BlobCacheMiddleware(blobs)))
+	}
+	if cfg.LocalCloneDir != "" {
+		if cfg.PerRequestToken {
+			// Clones are shared by everyone using the server, so they cannot respect the
+			// permissions of each user's token.
+			return nil, fmt.Errorf("local clones cannot be used with per-request tokens")
+		}
+		clones, err := localclone.NewManager(localclone.Config{
+			Dir:           cfg.LocalCloneDir,
+			BaseURL:       apiHost.cloneURL.String(),
+			Token:         tokens.Token,
+			FetchInterval: cfg.LocalCloneFetchInterval,
+			Logger:        cfg.Logger,
+		})
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.LocalClonesMiddleware(clones)))
+	}
+
+	// Outside the journal and everything else that may make API requests for the call.
+	serverOpts = slices.Insert(serverOpts, 1, server.WithToolHandlerMiddleware(toolTimeoutMiddleware(cfg.ToolTimeouts, calls)))
+
+	// Filled in once the toolsets are created, before any tool is called.
+	policyWriteTools := make(map[string]bool)
+	if cfg.Policy != nil {
+		// Checked first, so that calls the policy refuses are not journaled or counted.
+		serverOpts = slices.Insert(serverOpts, 1, server.WithToolHandlerMiddleware(policyMiddleware(cfg.Policy, policyWriteTools)))
+	}
+	var readOnlyWrites map[string]bool
+	if cfg.ReadOnlyOverride != "" && cfg.ReadOnlyOverride != ReadOnlyOverrideNone {
+		// Filled in once the toolsets are created, like the write tools of the policy
+		readOnlyWrites = make(map[string]bool)
+		serverOpts = slices.Insert(serverOpts, 1, server.WithToolHandlerMiddleware(readOnlyMiddleware(cfg.ReadOnly, readOnlyWrites)))
+		serverOpts = append(serverOpts, server.WithToolFilter(readOnlyToolFilter(cfg.ReadOnly, readOnlyWrites)))
+	}
+	if cfg.AuditSink != nil {
+		// Outside the policy, so that refused calls are audited too.
+		serverOpts = slices.Insert(serverOpts, 1, server.WithToolHandlerMiddleware(auditMiddleware(cfg.AuditSink, tokens, cfg.Logger)))
+	}
+	if cfg.Tracing {
+		// Outermost, so that the span covers everything done for the call.
+		serverOpts = slices.Insert(serverOpts, 1, server.WithToolHandlerMiddleware(tracing.Middleware()))
+	}
+	if ghesVersion != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.GHESParameterMiddleware(*ghesVersion)))
+	}
+	budgetToolsets := make(map[string]string)
+	if len(cfg.APIBudgets) > 0 {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(apiBudgetMiddleware(budgetToolsets)))
+	}
+
+	ghServer := github.NewServer(cfg.Version, serverOpts...)
+	ghServer.AddNotificationHandler(cancelledNotificationMethod, calls.handleCancelled)
+
+	if cfg.WebhookHub != nil {
+		cfg.WebhookHub.Subscribe(func(event webhooks.Event) {
+			ghServer.SendNotificationToAllClients(github.WebhookNotificationMethod, map[string]any{
+				"delivery_id":  event.DeliveryID,
+				"type":         event.Type,
+				"action":       event.Action,
+				"repository":   event.Repository,
+				"organization": event.Organization,
+				"sender":       event.Sender,
+				"received_at":  event.ReceivedAt,
+				"payload":      event.Payload,
+			})
+		})
+	}
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
@@ -118,14 +433,47 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
-	getClient := func(_ context.Context) (*gogithub.Client, error) {
+	var getClient github.GetClientFn = func(_ context.Context) (*gogithub.Client, error) {
 		return restClient, nil // closing over client
 	}
 
-	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
+	var getGQLClient github.GetGQLClientFn = func(_ context.Context) (*githubv4.Client, error) {
 		return gqlClient, nil // closing over client
 	}
 
+	if cfg.PerRequestToken {
+		// Each request brings its own token, so clients are created per request and never
+		// fall back to the server-wide token.
+		getClient = func(ctx context.Context) (*gogithub.Client, error) {
+			token, ok := GitHubTokenFromContext(ctx)
+			if !ok {
+				return nil, fmt.Errorf("no GitHub token provided with the request")
+			}
+			client := gogithub.NewClient(restHTTPClient).WithAuthToken(token)
+			client.UserAgent = restClient.UserAgent
+			client.BaseURL = apiHost.baseRESTURL
+			client.UploadURL = apiHost.uploadURL
+			return client, nil
+		}
+
+		getGQLClient = func(ctx context.Context) (*githubv4.Client, error) {
+			token, ok := GitHubTokenFromContext(ctx)
+			if !ok {
+				return nil, fmt.Errorf("no GitHub token provided with the request")
+			}
+			httpClient := &http.Client{
+				Transport: &bearerAuthTransport{
+					transport: &userAgentTransport{
+						transport: apiTransport,
+						agent:     restClient.UserAgent,
+					},
+					token: token,
+				},
+			}
+			return githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), httpClient), nil
+		}
+	}
+
 	getRawClient := func(ctx context.Context) (*raw.Client, error) {
 		client, err := getClient(ctx)
 		if err != nil {
@@ -134,13 +482,87 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
 	}
 
-	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize)
+	// Create default toolsets, leaving out those the host does not offer. Write tools are kept if
+	// requests may turn read-only mode off, and hidden from the others.
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly && cfg.ReadOnlyOverride != ReadOnlyOverrideAny, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize)
+	for _, name := range apiHost.unavailableToolsets {
+		if slices.Contains(enabledToolsets, name) {
+			return nil, fmt.Errorf("toolset %s is not available on %s", name, cfg.Host)
+		}
+		delete(tsg.Toolsets, name)
+	}
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
 	}
+	toolNames := slices.Concat(cfg.EnabledTools, cfg.DisabledTools, slices.Sorted(maps.Keys(cfg.ToolTimeouts.Tools)))
+	for _, macro := range cfg.Macros {
+		for _, step := range macro.Steps {
+			toolNames = append(toolNames, step.Tool)
+		}
+	}
+	if len(toolNames) > 0 {
+		// Check names against all tools, so that filtering write tools is not an error in read-only mode
+		allTools := github.DefaultToolsetGroup(false, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize)
+		if err := checkToolNames(allTools, toolNames); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.EnabledTools) > 0 || len(cfg.DisabledTools) > 0 {
+		tsg.FilterTools(cfg.EnabledTools, cfg.DisabledTools)
+	}
+	if ghesVersion != nil {
+		unsupported := github.GHESUnsupportedTools(tsg, *ghesVersion)
+		for _, name := range cfg.EnabledTools {
+			if err, ok := unsupported[name]; ok {
+				return nil, fmt.Errorf("tool %s is not available on %s: %w", name, cfg.Host, err)
+			}
+		}
+		tsg.FilterTools(nil, slices.Collect(maps.Keys(unsupported)))
+	}
+	aliasUsage := cfg.ToolAliasUsage
+	if aliasUsage == nil {
+		aliasUsage = github.NewAliasUsage()
+	}
+	if err := github.AddToolAliases(tsg, github.ToolAliases, cfg.ToolAliasCutoff, aliasUsage); err != nil {
+		return nil, err
+	}
+	if cfg.FetchAllLimits.MaxItems > 0 {
+		github.AddFetchAllParameter(tsg, cfg.FetchAllLimits)
+	}
+	github.AddFieldsParameter(tsg)
+	github.AddStructuredContent(tsg)
+	if cfg.Policy != nil {
+		maps.Copy(policyWriteTools, applyPolicy(tsg, cfg.Policy))
+	}
+	// Macros and batches are added after the policy is applied, so that they cannot call denied
+	// tools, and check the policy and budgets of the tools they call as calls from the client are.
+	nestedCalls := nestedCallMiddleware(cfg.ReadOnly, readOnlyWrites, cfg.Policy, policyWriteTools, len(cfg.APIBudgets) > 0, budgetToolsets)
+	if len(cfg.Macros) > 0 {
+		skipped, err := github.AddMacros(tsg, cfg.Macros, nestedCalls)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Logger != nil {
+			for _, name := range skipped {
+				cfg.Logger.Warn("macro not offered, as some of its tools are not available", "macro", name)
+			}
+		}
+	}
+	if cfg.BatchLimits.MaxCalls > 0 {
+		github.AddBatchCall(tsg, cfg.BatchLimits, nestedCalls)
+	}
+	if readOnlyWrites != nil {
+		maps.Copy(readOnlyWrites, readOnlyWriteTools(tsg))
+	}
+	if len(cfg.APIBudgets) > 0 {
+		tools, err := toolToolsets(tsg, cfg.APIBudgets)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(budgetToolsets, tools)
+	}
 
 	// Register all mcp functionality with the server
 	tsg.RegisterAll(ghServer)
@@ -153,6 +575,47 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	return ghServer, nil
 }
 
+// nestedCallMiddleware returns the middleware for the calls that tools such as macros make to
+// other tools, checking the read-only mode, policy and budgets for each, or nil if there is
+// nothing to check.
+func nestedCallMiddleware(readOnly bool, readOnlyWrites map[string]bool, p *policy.Policy, policyWriteTools map[string]bool, budgets bool, budgetToolsets map[string]string) server.ToolHandlerMiddleware {
+	var middleware []server.ToolHandlerMiddleware
+	if readOnlyWrites != nil {
+		middleware = append(middleware, readOnlyMiddleware(readOnly, readOnlyWrites))
+	}
+	if p != nil {
+		middleware = append(middleware, policyMiddleware(p, policyWriteTools))
+	}
+	if budgets {
+		middleware = append(middleware, apiBudgetMiddleware(budgetToolsets))
+	}
+	if len(middleware) == 0 {
+		return nil
+	}
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			next = middleware[i](next)
+		}
+		return next
+	}
+}
+
+// checkToolNames checks that every name is the name of a tool in tsg.
+func checkToolNames(tsg *toolsets.ToolsetGroup, names []string) error {
+	tools := make(map[string]bool)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			tools[tool.Tool.Name] = true
+		}
+	}
+	for _, name := range names {
+		if !tools[name] {
+			return fmt.Errorf("unknown tool: %s", name)
+		}
+	}
+	return nil
+}
+
 type StdioServerConfig struct {
 	// Version of the server
 	Version string
@@ -163,10 +626,25 @@ type StdioServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// TokenFile is the file Token was read from. If set, the file is read again and the
+	// token rotated without a restart when the process receives SIGHUP, or when GitHub
+	// rejects the token.
+	TokenFile string
+
+	// Credentials, if set, supplies the token instead of Token and TokenFile, and is asked for
+	// a new token whenever GitHub rejects the current one
+	Credentials CredentialProvider
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
 
+	// EnabledTools, if not empty, limits the tools of the enabled toolsets to the ones named in it
+	EnabledTools []string
+
+	// DisabledTools lists tools to leave out of the enabled toolsets
+	DisabledTools []string
+
 	// Whether to enable dynamic toolsets
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#dynamic-tool-discovery
 	DynamicToolsets bool
@@ -186,6 +664,83 @@ type StdioServerConfig struct {
 
 	// Content window size
 	ContentWindowSize int
+
+	// RepoAffinity enables ranking search results towards repositories the session recently touched
+	RepoAffinity bool
+
+	// JournalDir is the directory multi-step operations are journaled to. If empty,
+	// operations are only journaled in memory for the lifetime of the server.
+	JournalDir string
+
+	// CacheTTL enables caching of REST API responses for the given duration
+	CacheTTL time.Duration
+
+	// LocalCloneDir enables serving frequently used repositories from shallow clones kept in
+	// this directory
+	LocalCloneDir string
+
+	// LocalCloneFetchInterval is how often local clones are fetched
+	LocalCloneFetchInterval time.Duration
+
+	// BlobCacheMemoryBytes is the size of the in-memory blob cache; disabled if zero
+	BlobCacheMemoryBytes int64
+
+	// BlobCacheDir adds a disk tier to the blob cache
+	BlobCacheDir string
+
+	// BlobCacheDiskBytes is the size of the disk tier of the blob cache
+	BlobCacheDiskBytes int64
+
+	// APIBudgets limits the API requests made for tools, toolsets or each session
+	APIBudgets map[string]ratelimit.Budget
+
+	// RetryMaxAttempts is how often API requests that failed with transient errors are sent at most
+	RetryMaxAttempts int
+
+	// RetryNonIdempotent also retries requests that are not idempotent, such as creating an issue
+	RetryNonIdempotent bool
+
+	// ToolAliasCutoff stops serving the former names of renamed tools deprecated before it
+	ToolAliasCutoff time.Time
+
+	// Policy restricts what write tools may do, if set
+	Policy *policy.Policy
+
+	// Macros are composite operations offered as tools
+	Macros []macros.Macro
+
+	// GHESVersion is the GitHub Enterprise Server version, detected if empty
+	GHESVersion string
+
+	// ToolTimeouts limits how long tool calls may take
+	ToolTimeouts ToolTimeouts
+
+	// FetchAllLimits bounds the items that paged tools return when called with fetch_all
+	FetchAllLimits github.FetchAllLimits
+
+	// BatchLimits bounds the calls made by batch_call
+	BatchLimits github.BatchLimits
+
+	// CommitSigningKey signs the commits that tools make: the ID of a GPG key, or the path of an
+	// SSH private key. Commits are not signed if empty.
+	CommitSigningKey string
+
+	// CommitSigningFormat is the format of CommitSigningKey, gpg or ssh
+	CommitSigningFormat string
+
+	// AuditLog is a file or http(s) URL to record every tool call to. Nothing is recorded if empty.
+	AuditLog string
+
+	// WebhookListenAddr is the address to receive webhook deliveries on, e.g. ":8090".
+	// The webhook receiver is disabled if empty.
+	WebhookListenAddr string
+
+	// WebhookSecret is the secret webhook deliveries must be signed with
+	WebhookSecret string
+
+	// WebhookPublicURL is the externally reachable URL of the webhook receiver, used
+	// when registering webhooks
+	WebhookPublicURL string
 }
 
 // RunStdioServer is not concurrent safe.
@@ -196,15 +751,79 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
+	var webhookHub *webhooks.Hub
+	if cfg.WebhookListenAddr != "" {
+		var err error
+		webhookHub, err = webhooks.NewHub(cfg.WebhookSecret, cfg.WebhookPublicURL, webhooks.DefaultHistorySize)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook receiver: %w", err)
+		}
+	}
+
+	logger, logOutput, err := newLogger(cfg.LogFilePath)
+	if err != nil {
+		return err
+	}
+
+	aliasUsage := github.NewAliasUsage()
+	defer logAliasUsage(logger, aliasUsage)
+
+	auditSink, err := openAuditSink(cfg.AuditLog, logger)
+	if err != nil {
+		return err
+	}
+	commitSigner, err := newCommitSigner(cfg.CommitSigningFormat, cfg.CommitSigningKey)
+	if err != nil {
+		return err
+	}
+	if auditSink != nil {
+		defer func() { _ = auditSink.Close() }()
+	}
+
+	tracingEnabled, flushTraces, err := startTracing(ctx, cfg.Version, logger)
+	if err != nil {
+		return err
+	}
+	defer flushTraces()
+
+	tokens, err := newTokenStore(ctx, cfg.Token, cfg.TokenFile, cfg.Credentials, logger)
+	if err != nil {
+		return err
+	}
 	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:           cfg.Version,
-		Host:              cfg.Host,
-		Token:             cfg.Token,
-		EnabledToolsets:   cfg.EnabledToolsets,
-		DynamicToolsets:   cfg.DynamicToolsets,
-		ReadOnly:          cfg.ReadOnly,
-		Translator:        t,
-		ContentWindowSize: cfg.ContentWindowSize,
+		Version:                 cfg.Version,
+		Host:                    cfg.Host,
+		Tokens:                  tokens,
+		EnabledToolsets:         cfg.EnabledToolsets,
+		EnabledTools:            cfg.EnabledTools,
+		DisabledTools:           cfg.DisabledTools,
+		DynamicToolsets:         cfg.DynamicToolsets,
+		ReadOnly:                cfg.ReadOnly,
+		Translator:              t,
+		ContentWindowSize:       cfg.ContentWindowSize,
+		RepoAffinity:            cfg.RepoAffinity,
+		JournalDir:              cfg.JournalDir,
+		CacheTTL:                cfg.CacheTTL,
+		WebhookHub:              webhookHub,
+		LocalCloneDir:           cfg.LocalCloneDir,
+		LocalCloneFetchInterval: cfg.LocalCloneFetchInterval,
+		BlobCacheMemoryBytes:    cfg.BlobCacheMemoryBytes,
+		BlobCacheDir:            cfg.BlobCacheDir,
+		BlobCacheDiskBytes:      cfg.BlobCacheDiskBytes,
+		APIBudgets:              cfg.APIBudgets,
+		RetryPolicy:             retryPolicy(cfg.RetryMaxAttempts, cfg.RetryNonIdempotent),
+		ToolAliasCutoff:         cfg.ToolAliasCutoff,
+		Policy:                  cfg.Policy,
+		Macros:                  cfg.Macros,
+		AuditSink:               auditSink,
+		GHESVersion:             cfg.GHESVersion,
+		ToolTimeouts:            cfg.ToolTimeouts,
+		FetchAllLimits:          cfg.FetchAllLimits,
+		BatchLimits:             cfg.BatchLimits,
+		CommitSigner:            commitSigner,
+		Tracing:                 tracingEnabled,
+		ToolAliasUsage:          aliasUsage,
+		Logger:                  logger,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -212,21 +831,10 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	stdioServer := server.NewStdioServer(ghServer)
 
-	var slogHandler slog.Handler
-	var logOutput io.Writer
-	if cfg.LogFilePath != "" {
-		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
-		if err != nil {
-			return fmt.Errorf("failed to open log file: %w", err)
-		}
-		logOutput = file
-		slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelDebug})
-	} else {
-		logOutput = os.Stderr
-		slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelInfo})
-	}
-	logger := slog.New(slogHandler)
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly)
+	if cfg.TokenFile != "" {
+		defer rotateTokenOnSIGHUP(ctx, tokens, cfg.TokenFile, logger)()
+	}
 	stdLogger := log.New(logOutput, stdioServerLogPrefix, 0)
 	stdioServer.SetErrorLogger(stdLogger)
 
@@ -236,7 +844,25 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	}
 
 	// Start listening for messages
-	errC := make(chan error, 1)
+	errC := make(chan error, 2)
+
+	if webhookHub != nil {
+		mux := http.NewServeMux()
+		mux.Handle("/webhooks", webhookHub)
+		webhookServer := &http.Server{
+			Addr:              cfg.WebhookListenAddr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := webhookServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				errC <- fmt.Errorf("webhook receiver failed: %w", err)
+			}
+		}()
+		defer func() { _ = webhookServer.Close() }()
+		logger.Info("receiving webhooks", "addr", cfg.WebhookListenAddr, "path", "/webhooks")
+	}
+
 	go func() {
 		in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
 
@@ -266,13 +892,410 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	return nil
 }
 
+// StreamableHTTPServerConfig configures a server that speaks the MCP Streamable HTTP transport.
+type StreamableHTTPServerConfig struct {
+	// Version of the server
+	Version string
+
+	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
+	Host string
+
+	// GitHub Token to authenticate with the GitHub API
+	Token string
+
+	// TokenFile is the file Token was read from. If set, the file is read again and the
+	// token rotated without a restart when the process receives SIGHUP, or when GitHub
+	// rejects the token.
+	TokenFile string
+
+	// Credentials, if set, supplies the token instead of Token and TokenFile, and is asked for
+	// a new token whenever GitHub rejects the current one
+	Credentials CredentialProvider
+
+	// EnabledToolsets is a list of toolsets to enable
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
+	EnabledToolsets []string
+
+	// EnabledTools, if not empty, limits the tools of the enabled toolsets to the ones named in it
+	EnabledTools []string
+
+	// DisabledTools lists tools to leave out of the enabled toolsets
+	DisabledTools []string
+
+	// Whether to enable dynamic toolsets
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#dynamic-tool-discovery
+	DynamicToolsets bool
+
+	// ReadOnly indicates if we should only offer read-only tools
+	ReadOnly bool
+
+	// ReadOnlyOverride is which read-only mode sessions may ask for with the X-MCP-Read-Only
+	// header or the read_only query parameter, instead of ReadOnly. Requests asking for a mode
+	// it does not allow are refused.
+	ReadOnlyOverride ReadOnlyOverride
+
+	// ExportTranslations indicates if we should export translations
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
+	ExportTranslations bool
+
+	// Path to the log file if not stderr
+	LogFilePath string
+
+	// Content window size
+	ContentWindowSize int
+
+	// RepoAffinity enables ranking search results towards repositories the session recently touched
+	RepoAffinity bool
+
+	// JournalDir is the directory multi-step operations are journaled to. If empty,
+	// operations are only journaled in memory for the lifetime of the server.
+	JournalDir string
+
+	// CacheTTL enables caching of REST API responses for the given duration
+	CacheTTL time.Duration
+
+	// LocalCloneDir enables serving frequently used repositories from shallow clones kept in
+	// this directory
+	LocalCloneDir string
+
+	// LocalCloneFetchInterval is how often local clones are fetched
+	LocalCloneFetchInterval time.Duration
+
+	// BlobCacheMemoryBytes is the size of the in-memory blob cache; disabled if zero
+	BlobCacheMemoryBytes int64
+
+	// BlobCacheDir adds a disk tier to the blob cache
+	BlobCacheDir string
+
+	// BlobCacheDiskBytes is the size of the disk tier of the blob cache
+	BlobCacheDiskBytes int64
+
+	// APIBudgets limits the API requests made for tools, toolsets or each session
+	APIBudgets map[string]ratelimit.Budget
+
+	// RetryMaxAttempts is how often API requests that failed with transient errors are sent at most
+	RetryMaxAttempts int
+
+	// RetryNonIdempotent also retries requests that are not idempotent, such as creating an issue
+	RetryNonIdempotent bool
+
+	// ToolAliasCutoff stops serving the former names of renamed tools deprecated before it
+	ToolAliasCutoff time.Time
+
+	// Policy restricts what write tools may do, if set
+	Policy *policy.Policy
+
+	// Macros are composite operations offered as tools
+	Macros []macros.Macro
+
+	// GHESVersion is the GitHub Enterprise Server version, detected if empty
+	GHESVersion string
+
+	// ToolTimeouts limits how long tool calls may take
+	ToolTimeouts ToolTimeouts
+
+	// FetchAllLimits bounds the items that paged tools return when called with fetch_all
+	FetchAllLimits github.FetchAllLimits
+
+	// BatchLimits bounds the calls made by batch_call
+	BatchLimits github.BatchLimits
+
+	// CommitSigningKey signs the commits that tools make: the ID of a GPG key, or the path of an
+	// SSH private key. Commits are not signed if empty.
+	CommitSigningKey string
+
+	// CommitSigningFormat is the format of CommitSigningKey, gpg or ssh
+	CommitSigningFormat string
+
+	// AuditLog is a file or http(s) URL to record every tool call to. Nothing is recorded if empty.
+	AuditLog string
+
+	// WebhookSecret is the secret webhook deliveries must be signed with. If set, the
+	// webhook receiver is served on the /webhooks path of the same listener.
+	WebhookSecret string
+
+	// WebhookPublicURL is the externally reachable URL of the webhook receiver, used
+	// when registering webhooks
+	WebhookPublicURL string
+
+	// ListenAddr is the address to listen on, e.g. ":8080"
+	ListenAddr string
+
+	// EndpointPath is the path the MCP endpoint is served on, e.g. "/mcp"
+	EndpointPath string
+
+	// Stateless disables session management, so that every request is handled independently
+	Stateless bool
+
+	// HeartbeatInterval is how often to ping clients listening for server messages, to keep
+	// idle connections open through proxies. Heartbeats are disabled if zero.
+	HeartbeatInterval time.Duration
+
+	// PerRequestToken requires every request to authenticate with its own GitHub token in
+	// the Authorization header, which is used instead of Token for that request.
+	PerRequestToken bool
+}
+
+// RunStreamableHTTPServer serves the MCP server over the Streamable HTTP transport until
+// it receives an interrupt or termination signal.
+func RunStreamableHTTPServer(cfg StreamableHTTPServerConfig) error {
+	// Create app context
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	t, dumpTranslations := translations.TranslationHelper()
+
+	var webhookHub *webhooks.Hub
+	if cfg.WebhookSecret != "" {
+		var err error
+		webhookHub, err = webhooks.NewHub(cfg.WebhookSecret, cfg.WebhookPublicURL, webhooks.DefaultHistorySize)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook receiver: %w", err)
+		}
+	}
+
+	logger, _, err := newLogger(cfg.LogFilePath)
+	if err != nil {
+		return err
+	}
+
+	aliasUsage := github.NewAliasUsage()
+	defer logAliasUsage(logger, aliasUsage)
+
+	auditSink, err := openAuditSink(cfg.AuditLog, logger)
+	if err != nil {
+		return err
+	}
+	commitSigner, err := newCommitSigner(cfg.CommitSigningFormat, cfg.CommitSigningKey)
+	if err != nil {
+		return err
+	}
+	if auditSink != nil {
+		defer func() { _ = auditSink.Close() }()
+	}
+
+	tracingEnabled, flushTraces, err := startTracing(ctx, cfg.Version, logger)
+	if err != nil {
+		return err
+	}
+	defer flushTraces()
+
+	tokens, err := newTokenStore(ctx, cfg.Token, cfg.TokenFile, cfg.Credentials, logger)
+	if err != nil {
+		return err
+	}
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:                 cfg.Version,
+		Host:                    cfg.Host,
+		Tokens:                  tokens,
+		EnabledToolsets:         cfg.EnabledToolsets,
+		EnabledTools:            cfg.EnabledTools,
+		DisabledTools:           cfg.DisabledTools,
+		DynamicToolsets:         cfg.DynamicToolsets,
+		ReadOnly:                cfg.ReadOnly,
+		Translator:              t,
+		ContentWindowSize:       cfg.ContentWindowSize,
+		RepoAffinity:            cfg.RepoAffinity,
+		JournalDir:              cfg.JournalDir,
+		CacheTTL:                cfg.CacheTTL,
+		WebhookHub:              webhookHub,
+		LocalCloneDir:           cfg.LocalCloneDir,
+		LocalCloneFetchInterval: cfg.LocalCloneFetchInterval,
+		BlobCacheMemoryBytes:    cfg.BlobCacheMemoryBytes,
+		BlobCacheDir:            cfg.BlobCacheDir,
+		BlobCacheDiskBytes:      cfg.BlobCacheDiskBytes,
+		APIBudgets:              cfg.APIBudgets,
+		RetryPolicy:             retryPolicy(cfg.RetryMaxAttempts, cfg.RetryNonIdempotent),
+		ToolAliasCutoff:         cfg.ToolAliasCutoff,
+		Policy:                  cfg.Policy,
+		Macros:                  cfg.Macros,
+		AuditSink:               auditSink,
+		GHESVersion:             cfg.GHESVersion,
+		ToolTimeouts:            cfg.ToolTimeouts,
+		FetchAllLimits:          cfg.FetchAllLimits,
+		BatchLimits:             cfg.BatchLimits,
+		CommitSigner:            commitSigner,
+		Tracing:                 tracingEnabled,
+		ToolAliasUsage:          aliasUsage,
+		Logger:                  logger,
+		PerRequestToken:         cfg.PerRequestToken,
+		ReadOnlyOverride:        cfg.ReadOnlyOverride,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly, "readOnlyOverride", cfg.ReadOnlyOverride, "addr", cfg.ListenAddr, "endpoint", cfg.EndpointPath)
+	if cfg.TokenFile != "" {
+		defer rotateTokenOnSIGHUP(ctx, tokens, cfg.TokenFile, logger)()
+	}
+
+	if cfg.ExportTranslations {
+		// Once server is initialized, all translations are loaded
+		dumpTranslations()
+	}
+
+	// Servers using the token of each request have no token of their own to check
+	var healthClient *gogithub.Client
+	if !cfg.PerRequestToken {
+		healthClient, err = newHealthClient(cfg.Host, cfg.Version, tokens)
+		if err != nil {
+			return err
+		}
+	}
+
+	httpServer := &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           newHTTPHandler(ghServer, cfg, healthClient, webhookHub),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errC <- err
+		}
+	}()
+
+	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on http://%s%s\n", cfg.ListenAddr, cfg.EndpointPath)
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		logger.Info("shutting down server", "signal", "context done")
+	case err := <-errC:
+		logger.Error("error running server", "error", err)
+		return fmt.Errorf("error running server: %w", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		// Clients listening for server messages keep their streams open, so force them closed.
+		logger.Warn("closing open connections", "error", err)
+		_ = httpServer.Close()
+	}
+	return nil
+}
+
+// newHTTPHandler serves the MCP endpoint of a streamable HTTP server, its health endpoints and,
+// if it has a hub, its webhook receiver. healthClient is nil for servers without a token of
+// their own.
+func newHTTPHandler(ghServer *server.MCPServer, cfg StreamableHTTPServerConfig, healthClient *gogithub.Client, webhookHub *webhooks.Hub) http.Handler {
+	streamableServer := server.NewStreamableHTTPServer(ghServer,
+		server.WithEndpointPath(cfg.EndpointPath),
+		server.WithStateLess(cfg.Stateless),
+		server.WithHeartbeatInterval(cfg.HeartbeatInterval),
+		server.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+			if token, ok := bearerToken(r); ok {
+				ctx = ContextWithGitHubToken(ctx, token)
+			}
+			ctx = tracing.ExtractHTTP(ctx, r)
+			// enable GitHub errors in the context
+			return errors.ContextWithGitHubErrors(ctx)
+		}),
+	)
+
+	var mcpHandler http.Handler = streamableServer
+	if !cfg.Stateless {
+		mcpHandler = resumableStreamHandler(mcpHandler)
+	}
+	mcpHandler = readOnlyHandler(mcpHandler, cfg.ReadOnly, cfg.ReadOnlyOverride)
+	if cfg.PerRequestToken {
+		mcpHandler = requireBearerToken(mcpHandler)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.EndpointPath, mcpHandler)
+	mux.Handle("/healthz", healthHandler())
+	mux.Handle("/readyz", readinessHandler(healthClient))
+	if webhookHub != nil {
+		mux.Handle("/webhooks", webhookHub)
+	}
+	return mux
+}
+
+// bearerToken returns the token of a bearer Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// requireBearerToken rejects requests that do not carry a bearer token.
+func requireBearerToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := bearerToken(r); !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="github-mcp-server"`)
+			http.Error(w, "a GitHub token must be provided in the Authorization header", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newLogger logs to the given file at debug level, or to stderr at info level if no file is given.
+func newLogger(logFilePath string) (*slog.Logger, io.Writer, error) {
+	if logFilePath == "" {
+		return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo})), os.Stderr, nil
+	}
+	file, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return slog.New(slog.NewTextHandler(file, &slog.HandlerOptions{Level: slog.LevelDebug})), file, nil
+}
+
+// retryPolicy returns the default retry policy with the given attempts.
+func retryPolicy(maxAttempts int, nonIdempotent bool) retry.Policy {
+	policy := retry.DefaultPolicy()
+	policy.MaxAttempts = maxAttempts
+	policy.NonIdempotent = nonIdempotent
+	return policy
+}
+
+// newCommitSigner returns a signer for the commit signing key, or nil if there is none.
+func newCommitSigner(format, key string) (gogithub.MessageSigner, error) {
+	if key == "" {
+		return nil, nil
+	}
+	signer, err := signing.New(format, key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit signing configuration: %w", err)
+	}
+	return signer, nil
+}
+
+// logAliasUsage reports the calls made to tools by their former names, so that operators can tell
+// which clients still need to move to the new names before the aliases are cut off.
+func logAliasUsage(logger *slog.Logger, usage *github.AliasUsage) {
+	for _, entry := range usage.Report() {
+		logger.Warn("deprecated tool alias used", "alias", entry.Alias, "tool", entry.Tool, "calls", entry.Calls, "lastCalledAt", entry.LastCalledAt)
+	}
+}
+
 type apiHost struct {
 	baseRESTURL *url.URL
 	graphqlURL  *url.URL
 	uploadURL   *url.URL
 	rawURL      *url.URL
+	cloneURL    *url.URL
+
+	// unavailableToolsets are the toolsets whose features the host does not offer.
+	unavailableToolsets []string
+
+	// enterpriseServer is set for GitHub Enterprise Server hosts, whose version decides which
+	// tools they offer.
+	enterpriseServer bool
 }
 
+// gheUnavailableToolsets are the toolsets for features that GitHub Enterprise Cloud with data
+// residency does not offer.
+var gheUnavailableToolsets = []string{"gists"}
+
 func newDotcomHost() (apiHost, error) {
 	baseRestURL, err := url.Parse("https://api.github.com/")
 	if err != nil {
@@ -284,7 +1307,7 @@ func newDotcomHost() (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse dotcom GraphQL URL: %w", err)
 	}
 
-	uploadURL, err := url.Parse("https://uploads.github.com")
+	uploadURL, err := url.Parse("https://uploads.github.com/")
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse dotcom Upload URL: %w", err)
 	}
@@ -294,11 +1317,17 @@ func newDotcomHost() (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse dotcom Raw URL: %w", err)
 	}
 
+	cloneURL, err := url.Parse("https://github.com/")
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse dotcom Clone URL: %w", err)
+	}
+
 	return apiHost{
 		baseRESTURL: baseRestURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		cloneURL:    cloneURL,
 	}, nil
 }
 
@@ -313,6 +1342,16 @@ func newGHECHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("GHEC URL must be HTTPS")
 	}
 
+	// Accept the API and upload hosts as well as the web host, e.g. api.octocorp.ghe.com
+	subdomain := strings.TrimSuffix(u.Hostname(), ".ghe.com")
+	if i := strings.LastIndex(subdomain, "."); i >= 0 {
+		subdomain = subdomain[i+1:]
+	}
+	if subdomain == "" {
+		return apiHost{}, fmt.Errorf("GHEC URL must include your subdomain, e.g. https://octocorp.ghe.com")
+	}
+	u.Host = subdomain + ".ghe.com"
+
 	restURL, err := url.Parse(fmt.Sprintf("https://api.%s/", u.Hostname()))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHEC REST URL: %w", err)
@@ -323,7 +1362,7 @@ func newGHECHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse GHEC GraphQL URL: %w", err)
 	}
 
-	uploadURL, err := url.Parse(fmt.Sprintf("https://uploads.%s", u.Hostname()))
+	uploadURL, err := url.Parse(fmt.Sprintf("https://uploads.%s/", u.Hostname()))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHEC Upload URL: %w", err)
 	}
@@ -333,11 +1372,18 @@ func newGHECHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse GHEC Raw URL: %w", err)
 	}
 
+	cloneURL, err := url.Parse(fmt.Sprintf("https://%s/", u.Hostname()))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHEC Clone URL: %w", err)
+	}
+
 	return apiHost{
-		baseRESTURL: restURL,
-		graphqlURL:  gqlURL,
-		uploadURL:   uploadURL,
-		rawURL:      rawURL,
+		baseRESTURL:         restURL,
+		graphqlURL:          gqlURL,
+		uploadURL:           uploadURL,
+		rawURL:              rawURL,
+		cloneURL:            cloneURL,
+		unavailableToolsets: gheUnavailableToolsets,
 	}, nil
 }
 
@@ -347,34 +1393,41 @@ func newGHESHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse GHES URL: %w", err)
 	}
 
-	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Hostname()))
+	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES REST URL: %w", err)
 	}
 
-	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Hostname()))
+	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES GraphQL URL: %w", err)
 	}
 
-	uploadURL, err := url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Hostname()))
+	uploadURL, err := url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES Upload URL: %w", err)
 	}
-	rawURL, err := url.Parse(fmt.Sprintf("%s://%s/raw/", u.Scheme, u.Hostname()))
+	rawURL, err := url.Parse(fmt.Sprintf("%s://%s/raw/", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES Raw URL: %w", err)
 	}
+	cloneURL, err := url.Parse(fmt.Sprintf("%s://%s/", u.Scheme, u.Host))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHES Clone URL: %w", err)
+	}
 
 	return apiHost{
-		baseRESTURL: restURL,
-		graphqlURL:  gqlURL,
-		uploadURL:   uploadURL,
-		rawURL:      rawURL,
+		baseRESTURL:      restURL,
+		graphqlURL:       gqlURL,
+		uploadURL:        uploadURL,
+		rawURL:           rawURL,
+		cloneURL:         cloneURL,
+		enterpriseServer: true,
 	}, nil
 }
 
-// Note that this does not handle ports yet, so development environments are out.
+// Ports are only kept for GHES hosts, so that the server can be pointed at development
+// environments and fakes such as githubmock.
 func parseAPIHost(s string) (apiHost, error) {
 	if s == "" {
 		return newDotcomHost()
@@ -389,11 +1442,11 @@ func parseAPIHost(s string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("host must have a scheme (http or https): %s", s)
 	}
 
-	if strings.HasSuffix(u.Hostname(), "github.com") {
+	if hostname := u.Hostname(); hostname == "github.com" || strings.HasSuffix(hostname, ".github.com") {
 		return newDotcomHost()
 	}
 
-	if strings.HasSuffix(u.Hostname(), "ghe.com") {
+	if strings.HasSuffix(u.Hostname(), ".ghe.com") {
 		return newGHECHost(s)
 	}
 
@@ -0,0 +1,373 @@
+package ghmcp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/webhooks"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+		ok       bool
+	}{
+		{header: "Bearer ghp_abc", expected: "ghp_abc", ok: true},
+		{header: "bearer gho_abc ", expected: "gho_abc", ok: true},
+		{header: "Basic dXNlcjpwYXNz"},
+		{header: "Bearer "},
+		{header: ""},
+	}
+
+	for _, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		if tc.header != "" {
+			r.Header.Set("Authorization", tc.header)
+		}
+		token, ok := bearerToken(r)
+		assert.Equal(t, tc.ok, ok, tc.header)
+		assert.Equal(t, tc.expected, token, tc.header)
+	}
+}
+
+func TestRequireBearerToken(t *testing.T) {
+	handler := requireBearerToken(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Bearer")
+
+	rec = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	r.Header.Set("Authorization", "Bearer ghp_abc")
+	handler.ServeHTTP(rec, r)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGitHubTokenFromContext(t *testing.T) {
+	_, ok := GitHubTokenFromContext(context.Background())
+	assert.False(t, ok)
+
+	token, ok := GitHubTokenFromContext(ContextWithGitHubToken(context.Background(), "ghp_abc"))
+	assert.True(t, ok)
+	assert.Equal(t, "ghp_abc", token)
+}
+
+func TestParseAPIHost(t *testing.T) {
+	host, err := parseAPIHost("http://127.0.0.1:8443")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:8443/api/v3/", host.baseRESTURL.String())
+	assert.Equal(t, "http://127.0.0.1:8443/api/graphql", host.graphqlURL.String())
+	assert.Equal(t, "http://127.0.0.1:8443/raw/", host.rawURL.String())
+
+	host, err = parseAPIHost("https://github.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.github.com/", host.baseRESTURL.String())
+	assert.Equal(t, "https://uploads.github.com/", host.uploadURL.String())
+	assert.Empty(t, host.unavailableToolsets)
+
+	// Hosts that merely end in github.com are GHES
+	host, err = parseAPIHost("https://notgithub.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://notgithub.com/api/v3/", host.baseRESTURL.String())
+}
+
+func TestParseAPIHost_GHEC(t *testing.T) {
+	for _, s := range []string{"https://octocorp.ghe.com", "https://api.octocorp.ghe.com/", "https://uploads.octocorp.ghe.com"} {
+		host, err := parseAPIHost(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, "https://api.octocorp.ghe.com/", host.baseRESTURL.String(), s)
+		assert.Equal(t, "https://api.octocorp.ghe.com/graphql", host.graphqlURL.String(), s)
+		assert.Equal(t, "https://uploads.octocorp.ghe.com/", host.uploadURL.String(), s)
+		assert.Equal(t, "https://raw.octocorp.ghe.com/", host.rawURL.String(), s)
+		assert.Equal(t, "https://octocorp.ghe.com/", host.cloneURL.String(), s)
+		assert.Contains(t, host.unavailableToolsets, "gists", s)
+	}
+
+	_, err := parseAPIHost("http://octocorp.ghe.com")
+	assert.ErrorContains(t, err, "GHEC URL must be HTTPS")
+
+	// Hosts that merely end in ghe.com are GHES
+	host, err := parseAPIHost("https://notghe.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://notghe.com/api/v3/", host.baseRESTURL.String())
+}
+
+func TestNewMCPServer_UnavailableToolsets(t *testing.T) {
+	_, err := NewMCPServer(MCPServerConfig{
+		Host:            "https://octocorp.ghe.com",
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"repos", "gists"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	assert.ErrorContains(t, err, "toolset gists is not available on https://octocorp.ghe.com")
+
+	// Unavailable toolsets are left out of all toolsets
+	s, err := NewMCPServer(MCPServerConfig{
+		Host:            "https://octocorp.ghe.com",
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"all"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, s.GetTool("get_me"))
+	assert.Nil(t, s.GetTool("list_gists"))
+}
+
+func TestNewMCPServer_PerRequestTokenRefusesSharedState(t *testing.T) {
+	hub, err := webhooks.NewHub("s3cret", "", webhooks.DefaultHistorySize)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		cfg           MCPServerConfig
+		expectedError string
+	}{
+		{
+			name:          "local clones",
+			cfg:           MCPServerConfig{LocalCloneDir: t.TempDir()},
+			expectedError: "local clones cannot be used with per-request tokens",
+		},
+		{
+			name:          "webhook receiver",
+			cfg:           MCPServerConfig{WebhookHub: hub},
+			expectedError: "the webhook receiver cannot be used with per-request tokens",
+		},
+		{
+			name: "commit signing",
+			cfg: MCPServerConfig{CommitSigner: gogithub.MessageSignerFunc(func(_ io.Writer, _ io.Reader) error {
+				return nil
+			})},
+			expectedError: "commit signing cannot be used with per-request tokens",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.PerRequestToken = true
+			cfg.EnabledToolsets = []string{"repos"}
+			cfg.Translator = translations.NullTranslationHelper
+			_, err := NewMCPServer(cfg)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestNewMCPServer_ToolFilters(t *testing.T) {
+	s, err := NewMCPServer(MCPServerConfig{
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"issues"},
+		DisabledTools:   []string{"update_issue"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, s.GetTool("get_issue"))
+	assert.Nil(t, s.GetTool("update_issue"))
+
+	s, err = NewMCPServer(MCPServerConfig{
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"issues", "repos"},
+		EnabledTools:    []string{"get_issue", "get_file_contents"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, s.GetTool("get_issue"))
+	assert.NotNil(t, s.GetTool("get_file_contents"))
+	assert.Nil(t, s.GetTool("list_issues"))
+
+	// Write tools can be named in read-only mode, where they are left out anyway
+	_, err = NewMCPServer(MCPServerConfig{
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"issues"},
+		DisabledTools:   []string{"create_issue"},
+		ReadOnly:        true,
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	_, err = NewMCPServer(MCPServerConfig{
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"issues"},
+		DisabledTools:   []string{"delete_everything"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	assert.ErrorContains(t, err, "unknown tool: delete_everything")
+}
+
+func TestNewMCPServer_EnterpriseServerVersion(t *testing.T) {
+	s, err := NewMCPServer(MCPServerConfig{
+		Host:            "https://github.example.com",
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"issues", "repos"},
+		GHESVersion:     "3.10",
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, s.GetTool("get_issue"))
+	assert.Nil(t, s.GetTool("add_sub_issue"), "sub-issues need a later version")
+	assert.Nil(t, s.GetTool("update_repository_ruleset"), "rulesets need a later version")
+	assert.Nil(t, s.GetTool("assign_copilot_to_issue"), "Copilot is not available at all")
+
+	_, err = NewMCPServer(MCPServerConfig{
+		Host:            "https://github.example.com",
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"issues"},
+		EnabledTools:    []string{"get_issue", "add_sub_issue"},
+		GHESVersion:     "3.10",
+		Translator:      translations.NullTranslationHelper,
+	})
+	assert.ErrorContains(t, err, "tool add_sub_issue is not available on https://github.example.com: GitHub Enterprise Server 3.10 does not support sub-issues; 3.17 or later is required")
+
+	_, err = NewMCPServer(MCPServerConfig{
+		Host:        "https://github.example.com",
+		Token:       "ghp_abc",
+		GHESVersion: "latest",
+		Translator:  translations.NullTranslationHelper,
+	})
+	assert.ErrorContains(t, err, `invalid GitHub Enterprise Server version "latest"`)
+}
+
+func TestNewMCPServer_DetectsEnterpriseServerVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/meta", r.URL.Path)
+		assert.Equal(t, "Bearer ghp_abc", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"installed_version":"3.17.2"}`))
+	}))
+	defer srv.Close()
+
+	s, err := NewMCPServer(MCPServerConfig{
+		Host:            srv.URL,
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"issues"},
+		Translator:      translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, s.GetTool("add_sub_issue"))
+	assert.Nil(t, s.GetTool("assign_copilot_to_issue"))
+}
+
+// postMCP sends a JSON-RPC request to the MCP endpoint of handler.
+func postMCP(t *testing.T, handler http.Handler, header http.Header, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "application/json, text/event-stream")
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	return rec
+}
+
+const testInitializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+
+func TestHTTPHandler_PerRequestToken(t *testing.T) {
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		EnabledToolsets:  []string{"repos"},
+		Translator:       translations.NullTranslationHelper,
+		PerRequestToken:  true,
+		ReadOnlyOverride: ReadOnlyOverrideRestrict,
+	})
+	require.NoError(t, err)
+	handler := newHTTPHandler(ghServer, StreamableHTTPServerConfig{
+		EndpointPath:     "/mcp",
+		PerRequestToken:  true,
+		ReadOnlyOverride: ReadOnlyOverrideRestrict,
+	}, nil, nil)
+
+	// Requests without a token are rejected before reaching the MCP server
+	rec := postMCP(t, handler, nil, testInitializeRequest)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer realm="github-mcp-server"`, rec.Header().Get("WWW-Authenticate"))
+
+	// A session started in read-only mode keeps it
+	rec = postMCP(t, handler, http.Header{
+		"Authorization":   {"Bearer ghp_user"},
+		"X-Mcp-Read-Only": {"true"},
+	}, testInitializeRequest)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	sessionID := rec.Header().Get(server.HeaderKeySessionID)
+	require.NotEmpty(t, sessionID)
+
+	session := http.Header{
+		"Authorization":           {"Bearer ghp_user"},
+		server.HeaderKeySessionID: {sessionID},
+	}
+	rec = postMCP(t, handler, session, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"create_branch","arguments":{"owner":"owner","repo":"repo","branch":"new"}}}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "tool create_branch is not available in read-only mode")
+
+	session.Set("X-Mcp-Read-Only", "false")
+	rec = postMCP(t, handler, session, `{"jsonrpc":"2.0","id":3,"method":"tools/list"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// Health endpoints need no token, and there is no server token to check readiness with
+	for path, status := range map[string]string{"/healthz": "ok", "/readyz": "ready"} {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.JSONEq(t, `{"status":"`+status+`"}`, rec.Body.String(), path)
+	}
+
+	// Without a hub, there is no webhook receiver
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader("{}")))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHTTPHandler_Webhooks(t *testing.T) {
+	hub, err := webhooks.NewHub("s3cret", "", webhooks.DefaultHistorySize)
+	require.NoError(t, err)
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Token:           "ghp_abc",
+		EnabledToolsets: []string{"repos"},
+		Translator:      translations.NullTranslationHelper,
+		WebhookHub:      hub,
+	})
+	require.NoError(t, err)
+	handler := newHTTPHandler(ghServer, StreamableHTTPServerConfig{EndpointPath: "/mcp"}, nil, hub)
+
+	deliver := func(signature string) int {
+		body := `{"zen":"Keep it logically awesome.","repository":{"full_name":"owner/repo"}}`
+		r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("X-GitHub-Event", "ping")
+		r.Header.Set("X-GitHub-Delivery", "delivery-1")
+		if signature == "" {
+			mac := hmac.New(sha256.New, []byte("s3cret"))
+			mac.Write([]byte(body))
+			signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		}
+		r.Header.Set("X-Hub-Signature-256", signature)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, deliver("sha256=00"))
+	assert.Equal(t, http.StatusAccepted, deliver(""))
+	events := hub.Events(webhooks.Filter{})
+	require.Len(t, events, 1)
+	assert.Equal(t, "delivery-1", events[0].DeliveryID)
+
+	// The MCP endpoint does not need a bearer token when the server has its own
+	rec := postMCP(t, handler, nil, testInitializeRequest)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
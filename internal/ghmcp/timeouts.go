@@ -0,0 +1,202 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultToolTimeout is how long tool calls may take unless configured otherwise.
+const DefaultToolTimeout = 5 * time.Minute
+
+// cancelledNotificationMethod is sent by clients to cancel a request they no longer need.
+const cancelledNotificationMethod = "notifications/cancelled"
+
+// requestIDMetaKey carries the JSON-RPC ID of a tool call from the hook that sees it to the
+// middleware running the call, which is only given the request.
+const requestIDMetaKey = "github-mcp-server/request-id"
+
+// ToolTimeouts limits how long tool calls may take.
+type ToolTimeouts struct {
+	// Default applies to tools without a timeout of their own. Calls are not limited if zero.
+	Default time.Duration
+	// Tools holds the timeouts of specific tools, by name.
+	Tools map[string]time.Duration
+}
+
+// ParseToolTimeouts parses timeouts that are either a duration, applying to all tools, or of
+// the form tool=duration, such as get_job_logs=10m. The default is DefaultToolTimeout unless
+// one of the timeouts is a plain duration.
+func ParseToolTimeouts(specs []string) (ToolTimeouts, error) {
+	timeouts := ToolTimeouts{Default: DefaultToolTimeout, Tools: make(map[string]time.Duration)}
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		name, duration, ok := strings.Cut(spec, "=")
+		if !ok {
+			duration = spec
+		}
+		d, err := time.ParseDuration(duration)
+		if err != nil || d < 0 || (ok && name == "") {
+			return ToolTimeouts{}, fmt.Errorf("invalid tool timeout %q, expected a duration or tool=duration", spec)
+		}
+		if ok {
+			timeouts.Tools[name] = d
+		} else {
+			timeouts.Default = d
+		}
+	}
+	return timeouts, nil
+}
+
+// For returns the timeout of a tool, or zero if its calls are not limited.
+func (t ToolTimeouts) For(tool string) time.Duration {
+	if d, ok := t.Tools[tool]; ok {
+		return d
+	}
+	return t.Default
+}
+
+type toolCallKey struct {
+	session string
+	id      string
+}
+
+// toolCalls tracks the tool calls in progress, so that they can be cancelled when the client
+// sends a cancelled notification for them.
+type toolCalls struct {
+	mu      sync.Mutex
+	cancels map[toolCallKey]context.CancelFunc
+}
+
+func newToolCalls() *toolCalls {
+	return &toolCalls{cancels: make(map[toolCallKey]context.CancelFunc)}
+}
+
+func callKey(ctx context.Context, id any) toolCallKey {
+	key := toolCallKey{id: fmt.Sprint(id)}
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		key.session = session.SessionID()
+	}
+	return key
+}
+
+// rememberRequestID is an OnBeforeCallTool hook passing the request ID of a tool call on to
+// the timeout middleware.
+func (c *toolCalls) rememberRequestID(_ context.Context, id any, request *mcp.CallToolRequest) {
+	if id == nil {
+		return
+	}
+	if request.Params.Meta == nil {
+		request.Params.Meta = &mcp.Meta{}
+	}
+	if request.Params.Meta.AdditionalFields == nil {
+		request.Params.Meta.AdditionalFields = map[string]any{}
+	}
+	request.Params.Meta.AdditionalFields[requestIDMetaKey] = id
+}
+
+// handleCancelled cancels the tool call named by a notifications/cancelled notification, if it
+// is still in progress.
+func (c *toolCalls) handleCancelled(ctx context.Context, notification mcp.JSONRPCNotification) {
+	id, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	cancel, ok := c.cancels[callKey(ctx, id)]
+	c.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// takeRequestID removes the request ID that rememberRequestID added, so that tools do not see it.
+func takeRequestID(request *mcp.CallToolRequest) (any, bool) {
+	if request.Params.Meta == nil {
+		return nil, false
+	}
+	id, ok := request.Params.Meta.AdditionalFields[requestIDMetaKey]
+	if !ok {
+		return nil, false
+	}
+	fields := make(map[string]any, len(request.Params.Meta.AdditionalFields)-1)
+	for k, v := range request.Params.Meta.AdditionalFields {
+		if k != requestIDMetaKey {
+			fields[k] = v
+		}
+	}
+	meta := *request.Params.Meta
+	meta.AdditionalFields = fields
+	request.Params.Meta = &meta
+	if len(fields) == 0 && meta.ProgressToken == nil {
+		// The hook added the only metadata there is
+		request.Params.Meta = nil
+	}
+	return id, true
+}
+
+// toolTimeoutMiddleware gives every tool call a context that is cancelled when the call takes
+// longer than its timeout or when the client cancels it, which aborts the GitHub API requests
+// made for it. Calls that time out fail with an error result that says so.
+func toolTimeoutMiddleware(timeouts ToolTimeouts, calls *toolCalls) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name := request.Params.Name
+			timeout := timeouts.For(name)
+
+			callCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			if timeout > 0 {
+				var cancelTimeout context.CancelFunc
+				callCtx, cancelTimeout = context.WithTimeout(callCtx, timeout)
+				defer cancelTimeout()
+			}
+			if id, ok := takeRequestID(&request); ok {
+				key := callKey(ctx, id)
+				calls.mu.Lock()
+				calls.cancels[key] = cancel
+				calls.mu.Unlock()
+				defer func() {
+					calls.mu.Lock()
+					delete(calls.cancels, key)
+					calls.mu.Unlock()
+				}()
+			}
+
+			result, err := next(callCtx, request)
+			failed := err != nil || result == nil || result.IsError
+			switch {
+			case !failed || ctx.Err() != nil:
+				// Results are kept even if they came in late. If the session or server is
+				// going away, there is nobody to report to.
+				return result, err
+			case errors.Is(callCtx.Err(), context.DeadlineExceeded):
+				return timeoutResult(name, timeout), nil
+			case errors.Is(callCtx.Err(), context.Canceled):
+				return mcp.NewToolResultError(fmt.Sprintf("tool %s was cancelled by the client", name)), nil
+			default:
+				return result, err
+			}
+		}
+	}
+}
+
+// timeoutResult is the error result of a tool call that timed out, describing the timeout in
+// its metadata as well as its text.
+func timeoutResult(tool string, timeout time.Duration) *mcp.CallToolResult {
+	result := mcp.NewToolResultError(fmt.Sprintf("tool %s timed out after %s; try narrowing the request, e.g. with fewer items per page", tool, timeout))
+	result.Meta = &mcp.Meta{AdditionalFields: map[string]any{
+		"error": map[string]any{
+			"type":            "timeout",
+			"tool":            tool,
+			"timeout_seconds": timeout.Seconds(),
+		},
+	}}
+	return result
+}
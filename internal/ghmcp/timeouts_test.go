@@ -0,0 +1,126 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseToolTimeouts(t *testing.T) {
+	timeouts, err := ParseToolTimeouts(nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultToolTimeout, timeouts.For("get_me"))
+
+	timeouts, err = ParseToolTimeouts([]string{"get_job_logs=10m", " 30s", "search_code=0"})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, timeouts.For("get_job_logs"))
+	assert.Equal(t, 30*time.Second, timeouts.For("get_me"))
+	assert.Zero(t, timeouts.For("search_code"))
+
+	for _, spec := range []string{"soon", "=1m", "get_me=-1s", "get_me="} {
+		_, err = ParseToolTimeouts([]string{spec})
+		assert.ErrorContains(t, err, "invalid tool timeout", spec)
+	}
+}
+
+// waitForCancel is a tool handler that fails once its context is done, like a tool waiting on
+// a GitHub API request.
+func waitForCancel(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestToolTimeoutMiddleware(t *testing.T) {
+	timeouts := ToolTimeouts{Default: time.Hour, Tools: map[string]time.Duration{"slow_tool": 10 * time.Millisecond}}
+	middleware := toolTimeoutMiddleware(timeouts, newToolCalls())
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "slow_tool"
+	result, err := middleware(waitForCancel)(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "tool slow_tool timed out after 10ms")
+	assert.Equal(t, map[string]any{"type": "timeout", "tool": "slow_tool", "timeout_seconds": 0.01}, result.Meta.AdditionalFields["error"])
+
+	// Results that came in late are kept
+	late := func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-ctx.Done()
+		return mcp.NewToolResultText("done anyway"), nil
+	}
+	result, err = middleware(late)(context.Background(), request)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	// Tools within their timeout are not affected
+	request.Params.Name = "fast_tool"
+	result, err = middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, time.Minute)
+		return mcp.NewToolResultText("ok"), nil
+	})(context.Background(), request)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+type testSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testSession) SessionID() string { return "test-session" }
+func (s *testSession) Initialize()       {}
+func (s *testSession) Initialized() bool { return true }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestToolCallCancellation(t *testing.T) {
+	calls := newToolCalls()
+	s := server.NewMCPServer("test", "1.0.0",
+		server.WithToolCapabilities(true),
+		server.WithHooks(&server.Hooks{OnBeforeCallTool: []server.OnBeforeCallToolFunc{calls.rememberRequestID}}),
+		server.WithToolHandlerMiddleware(toolTimeoutMiddleware(ToolTimeouts{}, calls)),
+	)
+	s.AddNotificationHandler(cancelledNotificationMethod, calls.handleCancelled)
+
+	started := make(chan struct{})
+	s.AddTool(mcp.NewTool("slow_tool"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		assert.Nil(t, request.Params.Meta, "the request ID is not passed on to tools")
+		close(started)
+		return waitForCancel(ctx, request)
+	})
+	session := &testSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, s.RegisterSession(context.Background(), session))
+	ctx := s.WithContext(context.Background(), session)
+
+	responses := make(chan mcp.JSONRPCMessage)
+	go func() {
+		responses <- s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"slow_tool","arguments":{}}}`))
+	}()
+	<-started
+
+	// Cancelling another request leaves the call running
+	assert.Nil(t, s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":8}}`)))
+	select {
+	case <-responses:
+		t.Fatal("call finished before it was cancelled")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	assert.Nil(t, s.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user stopped"}}`)))
+	select {
+	case response := <-responses:
+		result, ok := response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult)
+		require.True(t, ok)
+		assert.True(t, result.IsError)
+		assert.Equal(t, "tool slow_tool was cancelled by the client", result.Content[0].(mcp.TextContent).Text)
+	case <-time.After(5 * time.Second):
+		t.Fatal("call was not cancelled")
+	}
+}
@@ -0,0 +1,191 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// TokenStore holds the GitHub token the server authenticates with, which can be replaced
+// while the server is running. Every API request reads the token when it is sent, so
+// requests already in flight complete with the old token and later requests use the new one.
+type TokenStore struct {
+	token atomic.Pointer[string]
+
+	// provider, if set, is asked for a new token when GitHub rejects the current one
+	provider CredentialProvider
+	logger   *slog.Logger
+	// refreshing serializes refreshes, so that requests rejected together refresh once
+	refreshing sync.Mutex
+}
+
+// NewTokenStore returns a store holding the given token.
+func NewTokenStore(token string) *TokenStore {
+	s := &TokenStore{}
+	s.SetToken(token)
+	return s
+}
+
+// NewCredentialTokenStore returns a store holding the token of a provider, which is asked for
+// a new token whenever GitHub rejects the current one.
+func NewCredentialTokenStore(ctx context.Context, provider CredentialProvider, logger *slog.Logger) (*TokenStore, error) {
+	token, err := provider.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	s := NewTokenStore(token)
+	s.provider = provider
+	s.logger = logger
+	return s, nil
+}
+
+// newTokenStore returns the store of a server authenticating with the token of credentials if
+// it is set, or else with token, read again from tokenFile when rejected if that is set.
+func newTokenStore(ctx context.Context, token, tokenFile string, credentials CredentialProvider, logger *slog.Logger) (*TokenStore, error) {
+	if credentials != nil {
+		tokens, err := NewCredentialTokenStore(ctx, credentials, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub token: %w", err)
+		}
+		return tokens, nil
+	}
+	tokens := NewTokenStore(token)
+	if tokenFile != "" {
+		tokens.provider = FileCredentials{Path: tokenFile}
+		tokens.logger = logger
+	}
+	return tokens, nil
+}
+
+// Token returns the current token.
+func (s *TokenStore) Token() string {
+	return *s.token.Load()
+}
+
+// SetToken replaces the current token.
+func (s *TokenStore) SetToken(token string) {
+	s.token.Store(&token)
+}
+
+// refresh asks the provider for a new token after GitHub rejected the given one, and returns
+// whether there is a new token to retry with. If another request has already replaced the
+// rejected token, the provider is not asked again.
+func (s *TokenStore) refresh(ctx context.Context, rejected string) bool {
+	if s.provider == nil {
+		return false
+	}
+	s.refreshing.Lock()
+	defer s.refreshing.Unlock()
+	if s.Token() != rejected {
+		return true
+	}
+	token, err := s.provider.Token(ctx)
+	if err != nil {
+		s.logger.Error("failed to refresh rejected GitHub token", "error", err)
+		return false
+	}
+	if token == rejected {
+		s.logger.Warn("GitHub token rejected, but the credential provider returned the same token")
+		return false
+	}
+	s.SetToken(token)
+	s.logger.Info("refreshed rejected GitHub token")
+	return true
+}
+
+// ReadTokenFile reads a GitHub token from a file, ignoring surrounding whitespace.
+func ReadTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// rotateTokenOnSIGHUP re-reads the token file into the store whenever the process receives
+// SIGHUP, until the context is done or the returned function is called.
+func rotateTokenOnSIGHUP(ctx context.Context, tokens *TokenStore, path string, logger *slog.Logger) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go reloadTokenFile(ctx, tokens, path, signals, logger)
+	return func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+// reloadTokenFile re-reads the token file into the store every time a signal is received,
+// until the context is done. A token that cannot be read is logged and the current one kept.
+func reloadTokenFile(ctx context.Context, tokens *TokenStore, path string, signals <-chan os.Signal, logger *slog.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			token, err := ReadTokenFile(path)
+			if err != nil {
+				logger.Error("failed to rotate GitHub token, keeping the current one", "error", err)
+				continue
+			}
+			if token == tokens.Token() {
+				logger.Info("GitHub token unchanged", "file", path)
+				continue
+			}
+			tokens.SetToken(token)
+			logger.Info("rotated GitHub token", "file", path)
+		}
+	}
+}
+
+// tokenAuthTransport authenticates requests with the current token of a TokenStore. A request
+// rejected with 401 Unauthorized is sent again once if the store can refresh the token.
+type tokenAuthTransport struct {
+	transport http.RoundTripper
+	tokens    *TokenStore
+}
+
+func (t *tokenAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := t.tokens.Token()
+	resp, err := t.transport.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// A request whose body has been consumed cannot be sent again
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	if !t.tokens.refresh(req.Context(), token) {
+		return resp, nil
+	}
+	retry := withBearerToken(req, t.tokens.Token())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	_ = resp.Body.Close()
+	return t.transport.RoundTrip(retry)
+}
+
+func withBearerToken(req *http.Request, token string) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
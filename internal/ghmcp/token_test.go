@@ -0,0 +1,144 @@
+package ghmcp
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTokenFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+
+	_, err := ReadTokenFile(path)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("  \n"), 0600))
+	_, err = ReadTokenFile(path)
+	assert.ErrorContains(t, err, "is empty")
+
+	require.NoError(t, os.WriteFile(path, []byte("ghp_abc\n"), 0600))
+	token, err := ReadTokenFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_abc", token)
+}
+
+func TestReloadTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("ghp_old"), 0600))
+
+	var authorization []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tokens := NewTokenStore("ghp_old")
+	client := &http.Client{Transport: &tokenAuthTransport{transport: http.DefaultTransport, tokens: tokens}}
+	send := func() {
+		resp, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal)
+	done := make(chan struct{})
+	go func() {
+		reloadTokenFile(ctx, tokens, path, signals, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		close(done)
+	}()
+
+	send()
+
+	// Each signal is only received once the previous one has been handled, so the store is
+	// up to date after sending a second one.
+	require.NoError(t, os.WriteFile(path, []byte("ghp_new\n"), 0600))
+	signals <- syscall.SIGHUP
+	signals <- syscall.SIGHUP
+	send()
+
+	// An unreadable token file keeps the current token.
+	require.NoError(t, os.Remove(path))
+	signals <- syscall.SIGHUP
+	signals <- syscall.SIGHUP
+	send()
+
+	cancel()
+	<-done
+
+	assert.Equal(t, []string{"Bearer ghp_old", "Bearer ghp_new", "Bearer ghp_new"}, authorization)
+}
+
+// sequenceCredentials returns its tokens in turn, then the last one again.
+type sequenceCredentials struct {
+	tokens []string
+	calls  int
+}
+
+func (c *sequenceCredentials) Token(_ context.Context) (string, error) {
+	token := c.tokens[min(c.calls, len(c.tokens)-1)]
+	c.calls++
+	return token, nil
+}
+
+func TestTokenAuthTransportRefreshesRejectedToken(t *testing.T) {
+	var authorization []string
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer ghs_valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	credentials := &sequenceCredentials{tokens: []string{"ghs_expired", "ghs_valid"}}
+	tokens, err := NewCredentialTokenStore(context.Background(), credentials, logger)
+	require.NoError(t, err)
+	client := &http.Client{Transport: &tokenAuthTransport{transport: http.DefaultTransport, tokens: tokens}}
+
+	// The rejected request is sent again, with its body, once the token is refreshed
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"Bearer ghs_expired", "Bearer ghs_valid"}, authorization)
+	assert.Equal(t, []string{"payload", "payload"}, bodies)
+	assert.Equal(t, 2, credentials.calls)
+
+	// A provider that keeps returning a rejected token is not retried with it
+	tokens.SetToken("ghs_revoked")
+	credentials.tokens = []string{"ghs_revoked"}
+	credentials.calls = 0
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 1, credentials.calls)
+
+	// Without a provider, rejected requests are not sent again
+	authorization = nil
+	client = &http.Client{Transport: &tokenAuthTransport{transport: http.DefaultTransport, tokens: NewTokenStore("ghp_static")}}
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, []string{"Bearer ghp_static"}, authorization)
+}
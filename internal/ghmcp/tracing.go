@@ -0,0 +1,33 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/tracing"
+)
+
+// tracingShutdownTimeout limits how long the remaining spans are exported for on shutdown.
+const tracingShutdownTimeout = 5 * time.Second
+
+// startTracing exports traces if the environment configures an OTLP endpoint. It reports
+// whether tracing is enabled, and returns a function flushing the remaining spans.
+func startTracing(ctx context.Context, version string, logger *slog.Logger) (bool, func(), error) {
+	if !tracing.Enabled() {
+		return false, func() {}, nil
+	}
+	shutdown, err := tracing.Setup(ctx, version)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	return true, func() {
+		// The server context is already cancelled on shutdown
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			logger.Error("failed to export traces", "error", err)
+		}
+	}, nil
+}
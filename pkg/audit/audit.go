@@ -0,0 +1,198 @@
+// Package audit records every tool call made to the server, with the GitHub API requests made
+// for it, to a JSON Lines file or an HTTP endpoint. Tokens and content bodies are redacted from
+// the recorded arguments and errors.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is the audit record of one tool call.
+type Record struct {
+	Time time.Time `json:"time"`
+	Tool string    `json:"tool"`
+	// ArgsHash is the SHA-256 of the call's arguments as JSON, before redaction, so that calls
+	// with the same arguments can be told apart from others without recording their content.
+	ArgsHash string         `json:"args_hash"`
+	Args     map[string]any `json:"args,omitempty"`
+	// SessionID, Client and TokenFingerprint identify the caller, as far as they are known.
+	SessionID        string `json:"session_id,omitempty"`
+	Client           string `json:"client,omitempty"`
+	TokenFingerprint string `json:"token_fingerprint,omitempty"`
+	DurationMS       int64  `json:"duration_ms"`
+	IsError          bool   `json:"is_error"`
+	Error            string `json:"error,omitempty"`
+	// StatusCodes are the status codes of the GitHub API responses, in order.
+	StatusCodes []int `json:"status_codes,omitempty"`
+	// RateLimitCost is the number of API requests that counted against the rate limit, which
+	// leaves out conditional requests answered with 304 Not Modified.
+	RateLimitCost int `json:"rate_limit_cost"`
+	// RateLimitRemaining is the lowest remaining rate limit reported while handling the call.
+	RateLimitRemaining *int `json:"rate_limit_remaining,omitempty"`
+}
+
+// maxErrorLength is the longest error message recorded.
+const maxErrorLength = 1000
+
+// redacted replaces redacted values.
+const redacted = "[REDACTED]"
+
+// contentKeys are arguments holding content bodies, which are never recorded.
+var contentKeys = map[string]bool{
+	"body":    true,
+	"content": true,
+	"files":   true,
+	"patch":   true,
+	"diff":    true,
+	"readme":  true,
+}
+
+// secretKeyParts mark arguments holding credentials, which are never recorded.
+var secretKeyParts = []string{"token", "secret", "password", "key"}
+
+// tokenPattern matches GitHub tokens in free text.
+var tokenPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)
+
+// HashArgs returns the hex SHA-256 of the arguments as JSON.
+func HashArgs(args map[string]any) string {
+	// encoding/json sorts map keys, so the hash does not depend on the order of the arguments
+	data, _ := json.Marshal(args)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// RedactArgs returns a copy of the arguments with content bodies and credentials replaced, and
+// tokens removed from the remaining strings.
+func RedactArgs(args map[string]any) map[string]any {
+	if args == nil {
+		return nil
+	}
+	return redactValue("", args).(map[string]any)
+}
+
+func redactValue(key string, value any) any {
+	if key != "" && isSensitiveKey(key) {
+		return redacted
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = redactValue(k, item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redactValue("", item)
+		}
+		return out
+	case string:
+		return RedactTokens(v)
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if contentKeys[key] {
+		return true
+	}
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactTokens removes GitHub tokens from text.
+func RedactTokens(s string) string {
+	return tokenPattern.ReplaceAllString(s, redacted)
+}
+
+// RedactError prepares an error message for recording, removing tokens and truncating it.
+func RedactError(s string) string {
+	s = RedactTokens(s)
+	if len(s) > maxErrorLength {
+		s = s[:maxErrorLength] + "…"
+	}
+	return s
+}
+
+// TokenFingerprint returns a short hash identifying a token without revealing it.
+func TokenFingerprint(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Calls collects the GitHub API responses received while handling a tool call.
+type Calls struct {
+	mu                 sync.Mutex
+	statusCodes        []int
+	rateLimitCost      int
+	rateLimitRemaining *int
+}
+
+type callsCtxKey struct{}
+
+// WithCalls returns a context that collects the responses to the API requests made with it.
+func WithCalls(ctx context.Context) (context.Context, *Calls) {
+	calls := &Calls{}
+	return context.WithValue(ctx, callsCtxKey{}, calls), calls
+}
+
+func (c *Calls) add(resp *http.Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statusCodes = append(c.statusCodes, resp.StatusCode)
+	if resp.StatusCode != http.StatusNotModified {
+		c.rateLimitCost++
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		if c.rateLimitRemaining == nil || remaining < *c.rateLimitRemaining {
+			c.rateLimitRemaining = &remaining
+		}
+	}
+}
+
+// Fill sets the API request fields of a record.
+func (c *Calls) Fill(r *Record) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r.StatusCodes = append([]int(nil), c.statusCodes...)
+	r.RateLimitCost = c.rateLimitCost
+	r.RateLimitRemaining = c.rateLimitRemaining
+}
+
+// Transport is an http.RoundTripper that adds the responses it receives to the Calls of the
+// request's context, if any.
+type Transport struct {
+	next http.RoundTripper
+}
+
+// NewTransport returns a Transport sending requests with next.
+func NewTransport(next http.RoundTripper) *Transport {
+	return &Transport{next: next}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if calls, ok := req.Context().Value(callsCtxKey{}).(*Calls); ok {
+		calls.add(resp)
+	}
+	return resp, nil
+}
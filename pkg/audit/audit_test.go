@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashArgs(t *testing.T) {
+	a := HashArgs(map[string]any{"owner": "octocat", "repo": "hello-world"})
+	b := HashArgs(map[string]any{"repo": "hello-world", "owner": "octocat"})
+	assert.Equal(t, a, b)
+	assert.Len(t, a, 64)
+	assert.NotEqual(t, a, HashArgs(map[string]any{"owner": "octocat", "repo": "other"}))
+}
+
+func TestRedactArgs(t *testing.T) {
+	token := "ghp_" + strings.Repeat("a", 36)
+	args := map[string]any{
+		"owner":   "octocat",
+		"repo":    "hello-world",
+		"body":    "secret plans",
+		"content": "file content",
+		"files":   []any{map[string]any{"path": "a.txt", "content": "x"}},
+		"secret":  "webhook secret",
+		"title":   "Leaked " + token,
+		"labels":  []any{"bug", token},
+		"perPage": float64(10),
+	}
+
+	assert.Equal(t, map[string]any{
+		"owner":   "octocat",
+		"repo":    "hello-world",
+		"body":    "[REDACTED]",
+		"content": "[REDACTED]",
+		"files":   "[REDACTED]",
+		"secret":  "[REDACTED]",
+		"title":   "Leaked [REDACTED]",
+		"labels":  []any{"bug", "[REDACTED]"},
+		"perPage": float64(10),
+	}, RedactArgs(args))
+	// The arguments themselves are left alone
+	assert.Equal(t, "secret plans", args["body"])
+	assert.Nil(t, RedactArgs(nil))
+}
+
+func TestRedactError(t *testing.T) {
+	token := "github_pat_" + strings.Repeat("b", 40)
+	assert.Equal(t, "bad credentials for [REDACTED]", RedactError("bad credentials for "+token))
+
+	long := RedactError(strings.Repeat("x", 2000))
+	assert.Equal(t, maxErrorLength+len("…"), len(long))
+}
+
+func TestTokenFingerprint(t *testing.T) {
+	assert.Len(t, TokenFingerprint("ghp_abc"), 16)
+	assert.Equal(t, TokenFingerprint("ghp_abc"), TokenFingerprint("ghp_abc"))
+	assert.NotEqual(t, TokenFingerprint("ghp_abc"), TokenFingerprint("ghp_def"))
+}
+
+func TestTransport(t *testing.T) {
+	remaining := []string{"4999", "4998", "", ""}
+	status := []int{http.StatusOK, http.StatusNotModified, http.StatusNotFound, http.StatusOK}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if remaining[requests] != "" {
+			w.Header().Set("X-RateLimit-Remaining", remaining[requests])
+		}
+		w.WriteHeader(status[requests])
+		requests++
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	ctx, calls := WithCalls(context.Background())
+	for range 3 {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	var r Record
+	calls.Fill(&r)
+	assert.Equal(t, []int{200, 304, 404}, r.StatusCodes)
+	assert.Equal(t, 2, r.RateLimitCost)
+	require.NotNil(t, r.RateLimitRemaining)
+	assert.Equal(t, 4998, *r.RateLimitRemaining)
+
+	// Requests made without Calls in their context are not collected
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sink receives audit records.
+type Sink interface {
+	Write(Record) error
+	Close() error
+}
+
+// Open returns a sink for a destination, which is either an http:// or https:// URL to POST
+// records to, or a file to append them to as JSON Lines.
+func Open(dest string) (Sink, error) {
+	if strings.HasPrefix(dest, "http://") || strings.HasPrefix(dest, "https://") {
+		return NewHTTPSink(dest, http.DefaultClient), nil
+	}
+	sink, err := NewFileSink(dest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return sink, nil
+}
+
+// FileSink appends records to a file as JSON Lines.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens a file to append records to, creating it if needed.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Write(r Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// httpSinkQueueSize is how many records an HTTPSink holds before dropping new ones.
+const httpSinkQueueSize = 1000
+
+// httpSinkTimeout limits how long a record is sent for.
+const httpSinkTimeout = 10 * time.Second
+
+// ErrQueueFull is returned when an HTTPSink drops a record because the endpoint is not keeping up.
+var ErrQueueFull = errors.New("audit log queue full, record dropped")
+
+// HTTPSink POSTs every record as JSON to an endpoint. Records are sent in the background, so
+// that tool calls do not wait for the endpoint.
+type HTTPSink struct {
+	url     string
+	client  *http.Client
+	records chan Record
+	done    chan struct{}
+	// OnError is called with errors sending records, if set before the first record is written.
+	OnError func(error)
+}
+
+// NewHTTPSink returns a sink sending records to url with client.
+func NewHTTPSink(url string, client *http.Client) *HTTPSink {
+	s := &HTTPSink{
+		url:     url,
+		client:  client,
+		records: make(chan Record, httpSinkQueueSize),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *HTTPSink) Write(r Record) error {
+	select {
+	case s.records <- r:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close sends the queued records and stops the sink.
+func (s *HTTPSink) Close() error {
+	close(s.records)
+	<-s.done
+	return nil
+}
+
+func (s *HTTPSink) run() {
+	defer close(s.done)
+	for r := range s.records {
+		if err := s.send(r); err != nil && s.OnError != nil {
+			s.OnError(err)
+		}
+	}
+}
+
+func (s *HTTPSink) send(r Record) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := *s.client
+	client.Timeout = httpSinkTimeout
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send audit record: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send audit record: unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	sink, err := Open(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	assert.IsType(t, &FileSink{}, sink)
+	require.NoError(t, sink.Close())
+
+	sink, err = Open("https://audit.example.com/records")
+	require.NoError(t, err)
+	assert.IsType(t, &HTTPSink{}, sink)
+	require.NoError(t, sink.Close())
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing", "audit.jsonl"))
+	assert.ErrorContains(t, err, "failed to open audit log")
+}
+
+func TestFileSink(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFileSink(file)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Write(Record{Tool: "get_me", DurationMS: 12}))
+	require.NoError(t, sink.Write(Record{Tool: "create_issue", IsError: true, Error: "not found"}))
+	require.NoError(t, sink.Close())
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var r Record
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &r))
+	assert.Equal(t, "create_issue", r.Tool)
+	assert.True(t, r.IsError)
+	assert.Equal(t, "not found", r.Error)
+
+	// Records are appended to existing files
+	sink, err = NewFileSink(file)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(Record{Tool: "get_me"}))
+	require.NoError(t, sink.Close())
+	data, err = os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(data), "\n"))
+}
+
+func TestHTTPSink(t *testing.T) {
+	var mu sync.Mutex
+	var received []Record
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		var record Record
+		assert.NoError(t, json.Unmarshal(body, &record))
+		mu.Lock()
+		received = append(received, record)
+		mu.Unlock()
+		if record.Tool == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	sink := NewHTTPSink(srv.URL, srv.Client())
+	var errs []error
+	sink.OnError = func(err error) { errs = append(errs, err) }
+
+	require.NoError(t, sink.Write(Record{Tool: "get_me", Time: time.Unix(0, 0).UTC()}))
+	require.NoError(t, sink.Write(Record{Tool: "fail"}))
+	// Close waits for the queued records to be sent
+	require.NoError(t, sink.Close())
+
+	require.Len(t, received, 2)
+	assert.Equal(t, "get_me", received[0].Tool)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "unexpected status 500")
+}
@@ -0,0 +1,286 @@
+// Package blobcache caches immutable content, such as file blobs and diffs between commits,
+// keyed by repository and SHA. Content addressed by a SHA never changes, so cached entries
+// never need to be revalidated.
+package blobcache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultMemoryBytes is the default size of the memory tier.
+	DefaultMemoryBytes = 64 << 20
+	// DefaultDiskBytes is the default size of the disk tier.
+	DefaultDiskBytes = 1 << 30
+)
+
+// Tier is where a cached blob was found.
+type Tier string
+
+const (
+	TierMemory Tier = "memory"
+	TierDisk   Tier = "disk"
+)
+
+// Blob is cached content.
+type Blob struct {
+	Content     []byte
+	ContentType string
+}
+
+func (b Blob) size() int64 {
+	return int64(len(b.Content) + len(b.ContentType))
+}
+
+// Stats counts how blobs were served since the cache was created.
+type Stats struct {
+	MemoryHits  int64 `json:"memory_hits"`
+	DiskHits    int64 `json:"disk_hits"`
+	Misses      int64 `json:"misses"`
+	MemoryBytes int64 `json:"memory_bytes"`
+	DiskBytes   int64 `json:"disk_bytes"`
+}
+
+// Cache is a two-tier cache of blobs. Recently used blobs are kept in memory, and if a
+// directory is configured, every blob is also written to disk so that it survives restarts
+// and memory evictions. Both tiers evict the least recently used blobs when they are full.
+type Cache struct {
+	memoryLimit int64
+	dir         string
+	diskLimit   int64
+
+	memoryHits atomic.Int64
+	diskHits   atomic.Int64
+	misses     atomic.Int64
+
+	mu          sync.Mutex
+	memory      map[string]*list.Element
+	memoryLRU   *list.List
+	memoryBytes int64
+	disk        map[string]*diskEntry
+	diskBytes   int64
+}
+
+type memoryEntry struct {
+	key  string
+	blob Blob
+}
+
+type diskEntry struct {
+	size    int64
+	usedAt  time.Time
+	storing bool
+}
+
+// New creates a cache holding up to memoryBytes in memory and, if dir is not empty, up to
+// diskBytes on disk in dir. Blobs already in dir are reused.
+func New(memoryBytes int64, dir string, diskBytes int64) (*Cache, error) {
+	c := &Cache{
+		memoryLimit: memoryBytes,
+		dir:         dir,
+		diskLimit:   diskBytes,
+		memory:      make(map[string]*list.Element),
+		memoryLRU:   list.New(),
+		disk:        make(map[string]*diskEntry),
+	}
+	if dir == "" {
+		return c, nil
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create blob cache directory: %w", err)
+	}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if strings.HasSuffix(path, ".tmp") {
+			return os.Remove(path)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		c.disk[d.Name()] = &diskEntry{size: info.Size(), usedAt: info.ModTime()}
+		c.diskBytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob cache directory: %w", err)
+	}
+	c.evictDisk()
+	return c, nil
+}
+
+// Get returns the blob stored for a SHA in a repository, and the tier it was found in.
+func (c *Cache) Get(repo, sha string) (Blob, Tier, bool) {
+	key := cacheKey(repo, sha)
+
+	c.mu.Lock()
+	if elem, ok := c.memory[key]; ok {
+		c.memoryLRU.MoveToFront(elem)
+		blob := elem.Value.(*memoryEntry).blob
+		c.mu.Unlock()
+		c.memoryHits.Add(1)
+		return blob, TierMemory, true
+	}
+	entry, onDisk := c.disk[key]
+	if onDisk {
+		entry.usedAt = time.Now()
+	}
+	c.mu.Unlock()
+
+	if onDisk {
+		if blob, err := c.readDisk(key); err == nil {
+			c.diskHits.Add(1)
+			c.putMemory(key, blob)
+			return blob, TierDisk, true
+		}
+	}
+	c.misses.Add(1)
+	return Blob{}, "", false
+}
+
+// Put stores the blob for a SHA in a repository.
+func (c *Cache) Put(repo, sha string, blob Blob) {
+	key := cacheKey(repo, sha)
+	c.putMemory(key, blob)
+	if c.dir != "" {
+		c.putDisk(key, blob)
+	}
+}
+
+// Stats returns how blobs were served so far and how much space they take up.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{
+		MemoryHits:  c.memoryHits.Load(),
+		DiskHits:    c.diskHits.Load(),
+		Misses:      c.misses.Load(),
+		MemoryBytes: c.memoryBytes,
+		DiskBytes:   c.diskBytes,
+	}
+}
+
+func (c *Cache) putMemory(key string, blob Blob) {
+	if blob.size() > c.memoryLimit {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.memory[key]; ok {
+		c.memoryLRU.MoveToFront(elem)
+		return
+	}
+	c.memory[key] = c.memoryLRU.PushFront(&memoryEntry{key: key, blob: blob})
+	c.memoryBytes += blob.size()
+	for c.memoryBytes > c.memoryLimit {
+		oldest := c.memoryLRU.Back()
+		entry := oldest.Value.(*memoryEntry)
+		c.memoryLRU.Remove(oldest)
+		delete(c.memory, entry.key)
+		c.memoryBytes -= entry.blob.size()
+	}
+}
+
+// Blobs are stored on disk as the content type, a newline and the content.
+func (c *Cache) putDisk(key string, blob Blob) {
+	c.mu.Lock()
+	if _, ok := c.disk[key]; ok {
+		c.mu.Unlock()
+		return
+	}
+	entry := &diskEntry{usedAt: time.Now(), storing: true}
+	c.disk[key] = entry
+	c.mu.Unlock()
+
+	data := make([]byte, 0, len(blob.ContentType)+1+len(blob.Content))
+	data = append(append(append(data, blob.ContentType...), '\n'), blob.Content...)
+	path := c.diskPath(key)
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err == nil {
+		// Write to a temporary file first, so that readers never see a partial blob.
+		tmp := path + ".tmp"
+		if err = os.WriteFile(tmp, data, 0600); err == nil {
+			err = os.Rename(tmp, path)
+		}
+	}
+
+	c.mu.Lock()
+	if err != nil {
+		delete(c.disk, key)
+	} else {
+		entry.size = int64(len(data))
+		entry.storing = false
+		c.diskBytes += entry.size
+	}
+	c.mu.Unlock()
+	c.evictDisk()
+}
+
+func (c *Cache) readDisk(key string) (Blob, error) {
+	data, err := os.ReadFile(c.diskPath(key))
+	if err != nil {
+		return Blob{}, err
+	}
+	contentType, content, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return Blob{}, fmt.Errorf("invalid blob cache entry %s", key)
+	}
+	return Blob{Content: content, ContentType: string(contentType)}, nil
+}
+
+func (c *Cache) evictDisk() {
+	c.mu.Lock()
+	if c.diskBytes <= c.diskLimit {
+		c.mu.Unlock()
+		return
+	}
+	keys := make([]string, 0, len(c.disk))
+	for key, entry := range c.disk {
+		if !entry.storing {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.disk[keys[i]].usedAt.Before(c.disk[keys[j]].usedAt)
+	})
+	var evicted []string
+	for _, key := range keys {
+		if c.diskBytes <= c.diskLimit {
+			break
+		}
+		c.diskBytes -= c.disk[key].size
+		delete(c.disk, key)
+		evicted = append(evicted, key)
+	}
+	c.mu.Unlock()
+
+	for _, key := range evicted {
+		_ = os.Remove(c.diskPath(key))
+	}
+}
+
+// diskPath spreads blobs over subdirectories named after the first byte of their key.
+func (c *Cache) diskPath(key string) string {
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+// cacheKey hashes the repository and SHA, so that keys are safe to use as file names.
+func cacheKey(repo, sha string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(repo) + "@" + sha))
+	return hex.EncodeToString(sum[:])
+}
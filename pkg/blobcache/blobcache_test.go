@@ -0,0 +1,101 @@
+package blobcache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_Memory(t *testing.T) {
+	c, err := New(90, "", 0)
+	require.NoError(t, err)
+
+	_, _, ok := c.Get("octo/repo", "aaa")
+	assert.False(t, ok)
+
+	blob := Blob{Content: []byte("hello"), ContentType: "text/plain"}
+	c.Put("octo/repo", "aaa", blob)
+	got, tier, ok := c.Get("Octo/Repo", "aaa")
+	require.True(t, ok, "repository names are case-insensitive")
+	assert.Equal(t, TierMemory, tier)
+	assert.Equal(t, blob, got)
+
+	// The same SHA in another repository is a different blob.
+	_, _, ok = c.Get("octo/other", "aaa")
+	assert.False(t, ok)
+
+	// Filling the memory tier evicts the least recently used blob.
+	c.Put("octo/repo", "bbb", Blob{Content: []byte(strings.Repeat("b", 40))})
+	_, _, _ = c.Get("octo/repo", "aaa")
+	c.Put("octo/repo", "ccc", Blob{Content: []byte(strings.Repeat("c", 40))})
+	_, _, ok = c.Get("octo/repo", "bbb")
+	assert.False(t, ok)
+	_, _, ok = c.Get("octo/repo", "aaa")
+	assert.True(t, ok)
+
+	// Blobs larger than the memory tier are not cached.
+	c.Put("octo/repo", "ddd", Blob{Content: []byte(strings.Repeat("d", 200))})
+	_, _, ok = c.Get("octo/repo", "ddd")
+	assert.False(t, ok)
+
+	stats := c.Stats()
+	assert.Equal(t, int64(3), stats.MemoryHits)
+	assert.Equal(t, int64(4), stats.Misses)
+	assert.Equal(t, int64(55), stats.MemoryBytes)
+}
+
+func TestCache_Disk(t *testing.T) {
+	dir := t.TempDir()
+	c, err := New(DefaultMemoryBytes, dir, DefaultDiskBytes)
+	require.NoError(t, err)
+
+	blob := Blob{Content: []byte("line one\nline two\n"), ContentType: "text/plain; charset=utf-8"}
+	c.Put("octo/repo", "aaa", blob)
+
+	// A new cache on the same directory finds the blob on disk, then keeps it in memory.
+	c, err = New(DefaultMemoryBytes, dir, DefaultDiskBytes)
+	require.NoError(t, err)
+	got, tier, ok := c.Get("octo/repo", "aaa")
+	require.True(t, ok)
+	assert.Equal(t, TierDisk, tier)
+	assert.Equal(t, blob, got)
+	_, tier, _ = c.Get("octo/repo", "aaa")
+	assert.Equal(t, TierMemory, tier)
+
+	stats := c.Stats()
+	assert.Equal(t, int64(1), stats.DiskHits)
+	assert.Equal(t, int64(1), stats.MemoryHits)
+	assert.Equal(t, int64(len("text/plain; charset=utf-8\nline one\nline two\n")), stats.DiskBytes)
+}
+
+func TestCache_DiskEviction(t *testing.T) {
+	dir := t.TempDir()
+	c, err := New(0, dir, 25)
+	require.NoError(t, err)
+
+	c.Put("octo/repo", "aaa", Blob{Content: []byte("0123456789")})
+	c.Put("octo/repo", "bbb", Blob{Content: []byte("0123456789")})
+	_, _, ok := c.Get("octo/repo", "aaa")
+	require.True(t, ok)
+	c.Put("octo/repo", "ccc", Blob{Content: []byte("0123456789")})
+
+	_, _, ok = c.Get("octo/repo", "bbb")
+	assert.False(t, ok, "least recently used blob is evicted")
+	_, _, ok = c.Get("octo/repo", "aaa")
+	assert.True(t, ok)
+	_, _, ok = c.Get("octo/repo", "ccc")
+	assert.True(t, ok)
+
+	var files []string
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	}))
+	assert.Len(t, files, 2)
+}
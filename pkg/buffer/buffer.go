@@ -26,9 +26,20 @@ import (
 // The function uses a ring buffer to efficiently store only the last maxJobLogLines lines.
 // If the response contains more lines than maxJobLogLines, only the most recent lines are kept.
 func ProcessResponseAsRingBufferToEnd(httpResp *http.Response, maxJobLogLines int) (string, int, *http.Response, error) {
+	result, totalLines, _, httpResp, err := ProcessResponseAsFilteredRingBufferToEnd(httpResp, maxJobLogLines, nil)
+	return result, totalLines, httpResp, err
+}
+
+// ProcessResponseAsFilteredRingBufferToEnd works like ProcessResponseAsRingBufferToEnd, but
+// only retains the lines for which keep returns true. A nil keep retains every line.
+//
+// Returns the retained lines, the total number of lines read, the number of lines kept
+// (which may exceed maxJobLogLines), the original HTTP response, and any read error.
+func ProcessResponseAsFilteredRingBufferToEnd(httpResp *http.Response, maxJobLogLines int, keep func(line string) bool) (string, int, int, *http.Response, error) {
 	lines := make([]string, maxJobLogLines)
 	validLines := make([]bool, maxJobLogLines)
 	totalLines := 0
+	keptLines := 0
 	writeIndex := 0
 
 	scanner := bufio.NewScanner(httpResp.Body)
@@ -37,6 +48,10 @@ func ProcessResponseAsRingBufferToEnd(httpResp *http.Response, maxJobLogLines in
 	for scanner.Scan() {
 		line := scanner.Text()
 		totalLines++
+		if keep != nil && !keep(line) {
+			continue
+		}
+		keptLines++
 
 		lines[writeIndex] = line
 		validLines[writeIndex] = true
@@ -44,17 +59,17 @@ func ProcessResponseAsRingBufferToEnd(httpResp *http.Response, maxJobLogLines in
 	}
 
 	if err := scanner.Err(); err != nil {
-		return "", 0, httpResp, fmt.Errorf("failed to read log content: %w", err)
+		return "", 0, 0, httpResp, fmt.Errorf("failed to read log content: %w", err)
 	}
 
 	var result []string
-	linesInBuffer := totalLines
+	linesInBuffer := keptLines
 	if linesInBuffer > maxJobLogLines {
 		linesInBuffer = maxJobLogLines
 	}
 
 	startIndex := 0
-	if totalLines > maxJobLogLines {
+	if keptLines > maxJobLogLines {
 		startIndex = writeIndex
 	}
 
@@ -65,5 +80,5 @@ func ProcessResponseAsRingBufferToEnd(httpResp *http.Response, maxJobLogLines in
 		}
 	}
 
-	return strings.Join(result, "\n"), totalLines, httpResp, nil
+	return strings.Join(result, "\n"), totalLines, keptLines, httpResp, nil
 }
@@ -0,0 +1,278 @@
+// Package cache provides an HTTP transport that caches GET responses from the GitHub API in
+// memory and revalidates them with conditional requests, which GitHub does not count against
+// the rate limit when the resource is unchanged.
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultMaxEntries is the number of responses kept when no limit is given.
+	DefaultMaxEntries = 1000
+	// maxBodySize is the largest response body that is cached.
+	maxBodySize = 1 << 20
+)
+
+// Stats counts how the requests made with a context were served.
+type Stats struct {
+	// Hits were served from the cache without contacting GitHub.
+	Hits atomic.Int64
+	// Revalidated were served from the cache after GitHub confirmed they were unchanged.
+	Revalidated atomic.Int64
+	// Misses were fetched from GitHub.
+	Misses atomic.Int64
+}
+
+type statsCtxKey struct{}
+
+// WithStats returns a context that records how the requests made with it are served.
+func WithStats(ctx context.Context) (context.Context, *Stats) {
+	stats := &Stats{}
+	return context.WithValue(ctx, statsCtxKey{}, stats), stats
+}
+
+func statsFromContext(ctx context.Context) *Stats {
+	stats, _ := ctx.Value(statsCtxKey{}).(*Stats)
+	return stats
+}
+
+type entry struct {
+	key      string
+	path     string
+	response []byte // the response as written by httputil.DumpResponse
+	etag     string
+	modified string
+	storedAt time.Time
+}
+
+// Transport is an http.RoundTripper that caches GET responses. Responses younger than the TTL
+// are served without a request; older responses are revalidated using their ETag or
+// Last-Modified header. Any other request to a repository, organization or user evicts the
+// cached responses for it, so that tools see their own writes.
+type Transport struct {
+	next       http.RoundTripper
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// NewTransport creates a caching transport that sends requests with next, or
+// http.DefaultTransport if next is nil.
+func NewTransport(next http.RoundTripper, ttl time.Duration, maxEntries int) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &Transport{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	case req.Method == http.MethodHead || (req.Method == http.MethodGet && req.Header.Get("Range") != ""):
+		return t.next.RoundTrip(req)
+	case req.Method != http.MethodGet:
+		resp, err := t.next.RoundTrip(req)
+		if err == nil && resp.StatusCode < http.StatusBadRequest {
+			t.invalidate(req.URL.Path)
+		}
+		return resp, err
+	}
+
+	stats := statsFromContext(req.Context())
+	key := cacheKey(req)
+	cached := t.get(key)
+
+	if cached != nil && t.now().Sub(cached.storedAt) < t.ttl {
+		if resp, err := cached.toResponse(req); err == nil {
+			if stats != nil {
+				stats.Hits.Add(1)
+			}
+			return resp, nil
+		}
+	}
+
+	if cached != nil && (cached.etag != "" || cached.modified != "") {
+		req = req.Clone(req.Context())
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.modified != "" {
+			req.Header.Set("If-Modified-Since", cached.modified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		t.touch(cached)
+		if stats != nil {
+			stats.Revalidated.Add(1)
+		}
+		return cached.toResponse(req)
+	}
+
+	if stats != nil {
+		stats.Misses.Add(1)
+	}
+	if resp.StatusCode != http.StatusOK || strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+	return t.store(key, req.URL.Path, resp)
+}
+
+// store caches a successful response and returns an equivalent response for the caller.
+func (t *Transport) store(key, path string, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxBodySize {
+		// Too large to cache: hand back the response with the part already read restored.
+		resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.put(&entry{
+		key:      key,
+		path:     path,
+		response: dump,
+		etag:     resp.Header.Get("ETag"),
+		modified: resp.Header.Get("Last-Modified"),
+		storedAt: t.now(),
+	})
+	return resp, nil
+}
+
+func (e *entry) toResponse(req *http.Request) (*http.Response, error) {
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(e.response)), req)
+}
+
+func (t *Transport) get(key string) *entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	elem, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+	t.lru.MoveToFront(elem)
+	return elem.Value.(*entry)
+}
+
+// touch marks a revalidated entry as fresh again.
+func (t *Transport) touch(e *entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if elem, ok := t.entries[e.key]; ok && elem.Value == e {
+		updated := *e
+		updated.storedAt = t.now()
+		elem.Value = &updated
+	}
+}
+
+func (t *Transport) put(e *entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if elem, ok := t.entries[e.key]; ok {
+		elem.Value = e
+		t.lru.MoveToFront(elem)
+		return
+	}
+	t.entries[e.key] = t.lru.PushFront(e)
+	for t.lru.Len() > t.maxEntries {
+		oldest := t.lru.Back()
+		t.lru.Remove(oldest)
+		delete(t.entries, oldest.Value.(*entry).key)
+	}
+}
+
+// invalidate evicts the cached responses in the same scope as path, e.g. everything
+// under /repos/{owner}/{repo} for a write to one of its issues.
+func (t *Transport) invalidate(path string) {
+	scope := invalidationScope(path)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, elem := range t.entries {
+		if p := elem.Value.(*entry).path; p == scope || strings.HasPrefix(p, scope+"/") {
+			t.lru.Remove(elem)
+			delete(t.entries, key)
+		}
+	}
+}
+
+func invalidationScope(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	// GitHub Enterprise Server serves the API under /api/v3.
+	prefix := ""
+	if len(segments) >= 2 && segments[0] == "api" && segments[1] == "v3" {
+		prefix, segments = "/api/v3", segments[2:]
+	}
+	n := 2
+	if len(segments) > 0 && segments[0] == "repos" {
+		n = 3
+	}
+	if len(segments) > n {
+		segments = segments[:n]
+	}
+	return prefix + "/" + strings.Join(segments, "/")
+}
+
+// cacheKey identifies a response by URL, the headers that change its content, and the
+// credentials it was fetched with, so that users never see each other's responses.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	for _, part := range []string{
+		req.URL.String(),
+		req.Header.Get("Accept"),
+		req.Header.Get("Authorization"),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitHub serves a versioned resource with an ETag, answering conditional requests with 304.
+type fakeGitHub struct {
+	version  int
+	requests []*http.Request
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests = append(f.requests, r)
+	if r.Method != http.MethodGet {
+		f.version++
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	etag := `"v` + string(rune('0'+f.version)) + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	_, _ = io.WriteString(w, "body for "+r.Header.Get("Authorization")+" at "+etag)
+}
+
+func get(ctx context.Context, t *testing.T, client *http.Client, url, token string) string {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", token)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestTransport(t *testing.T) {
+	fake := &fakeGitHub{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	transport := NewTransport(nil, time.Minute, 0)
+	transport.now = func() time.Time { return now }
+	client := &http.Client{Transport: transport}
+	issueURL := srv.URL + "/repos/octo/repo/issues/1"
+
+	ctx, stats := WithStats(context.Background())
+
+	// First request is a miss, the second is served from the cache while fresh.
+	assert.Equal(t, `body for token-a at "v0"`, get(ctx, t, client, issueURL, "token-a"))
+	assert.Equal(t, `body for token-a at "v0"`, get(ctx, t, client, issueURL, "token-a"))
+	assert.Len(t, fake.requests, 1)
+	assert.Equal(t, int64(1), stats.Misses.Load())
+	assert.Equal(t, int64(1), stats.Hits.Load())
+
+	// Responses are never shared between credentials.
+	assert.Equal(t, `body for token-b at "v0"`, get(ctx, t, client, issueURL, "token-b"))
+	assert.Len(t, fake.requests, 2)
+
+	// Once stale, the response is revalidated with its ETag.
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, `body for token-a at "v0"`, get(ctx, t, client, issueURL, "token-a"))
+	require.Len(t, fake.requests, 3)
+	assert.Equal(t, `"v0"`, fake.requests[2].Header.Get("If-None-Match"))
+	assert.Equal(t, int64(1), stats.Revalidated.Load())
+
+	// Revalidation makes the entry fresh again.
+	assert.Equal(t, `body for token-a at "v0"`, get(ctx, t, client, issueURL, "token-a"))
+	assert.Len(t, fake.requests, 3)
+
+	// A write to the repository evicts its cached responses.
+	req, err := http.NewRequest(http.MethodPatch, srv.URL+"/repos/octo/repo/issues/1", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, `body for token-a at "v1"`, get(ctx, t, client, issueURL, "token-a"))
+	assert.Len(t, fake.requests, 5)
+	assert.Empty(t, fake.requests[4].Header.Get("If-None-Match"))
+}
+
+func TestTransport_Eviction(t *testing.T) {
+	fake := &fakeGitHub{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(nil, time.Hour, 2)}
+	ctx := context.Background()
+
+	get(ctx, t, client, srv.URL+"/repos/octo/a", "token")
+	get(ctx, t, client, srv.URL+"/repos/octo/b", "token")
+	get(ctx, t, client, srv.URL+"/repos/octo/a", "token")
+	get(ctx, t, client, srv.URL+"/repos/octo/c", "token")
+	assert.Len(t, fake.requests, 3)
+
+	// b was the least recently used entry, so it was evicted to make room for c.
+	get(ctx, t, client, srv.URL+"/repos/octo/b", "token")
+	assert.Len(t, fake.requests, 4)
+	get(ctx, t, client, srv.URL+"/repos/octo/c", "token")
+	assert.Len(t, fake.requests, 4)
+}
+
+func TestInvalidationScope(t *testing.T) {
+	assert.Equal(t, "/repos/octo/repo", invalidationScope("/repos/octo/repo/issues/1/comments"))
+	assert.Equal(t, "/api/v3/repos/octo/repo", invalidationScope("/api/v3/repos/octo/repo/pulls"))
+	assert.Equal(t, "/orgs/octo", invalidationScope("/orgs/octo/teams"))
+	assert.Equal(t, "/user", invalidationScope("/user"))
+}
@@ -2,8 +2,13 @@ package errors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -45,6 +50,8 @@ func (e *GitHubGraphQLError) Error() string {
 
 type GitHubErrorKey struct{}
 type GitHubCtxErrors struct {
+	// mu guards the errors of tools that call other tools concurrently, such as batch_call
+	mu      sync.Mutex
 	api     []*GitHubAPIError
 	graphQL []*GitHubGraphQLError
 }
@@ -56,8 +63,10 @@ func ContextWithGitHubErrors(ctx context.Context) context.Context {
 	}
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
 		// If the context already has GitHubCtxErrors, we just empty the slices to start fresh
+		val.mu.Lock()
 		val.api = []*GitHubAPIError{}
 		val.graphQL = []*GitHubGraphQLError{}
+		val.mu.Unlock()
 	} else {
 		// If not, we create a new GitHubCtxErrors and set it in the context
 		ctx = context.WithValue(ctx, GitHubErrorKey{}, &GitHubCtxErrors{})
@@ -69,6 +78,8 @@ func ContextWithGitHubErrors(ctx context.Context) context.Context {
 // GetGitHubAPIErrors retrieves the slice of GitHubAPIErrors from the context.
 func GetGitHubAPIErrors(ctx context.Context) ([]*GitHubAPIError, error) {
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
+		val.mu.Lock()
+		defer val.mu.Unlock()
 		return val.api, nil // return the slice of API errors from the context
 	}
 	return nil, fmt.Errorf("context does not contain GitHubCtxErrors")
@@ -77,6 +88,8 @@ func GetGitHubAPIErrors(ctx context.Context) ([]*GitHubAPIError, error) {
 // GetGitHubGraphQLErrors retrieves the slice of GitHubGraphQLErrors from the context.
 func GetGitHubGraphQLErrors(ctx context.Context) ([]*GitHubGraphQLError, error) {
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
+		val.mu.Lock()
+		defer val.mu.Unlock()
 		return val.graphQL, nil // return the slice of GraphQL errors from the context
 	}
 	return nil, fmt.Errorf("context does not contain GitHubCtxErrors")
@@ -92,6 +105,8 @@ func NewGitHubAPIErrorToCtx(ctx context.Context, message string, resp *github.Re
 
 func addGitHubAPIErrorToContext(ctx context.Context, err *GitHubAPIError) (context.Context, error) {
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
+		val.mu.Lock()
+		defer val.mu.Unlock()
 		val.api = append(val.api, err) // append the error to the existing slice in the context
 		return ctx, nil
 	}
@@ -100,6 +115,8 @@ func addGitHubAPIErrorToContext(ctx context.Context, err *GitHubAPIError) (conte
 
 func addGitHubGraphQLErrorToContext(ctx context.Context, err *GitHubGraphQLError) (context.Context, error) {
 	if val, ok := ctx.Value(GitHubErrorKey{}).(*GitHubCtxErrors); ok {
+		val.mu.Lock()
+		defer val.mu.Unlock()
 		val.graphQL = append(val.graphQL, err) // append the error to the existing slice in the context
 		return ctx, nil
 	}
@@ -108,15 +125,56 @@ func addGitHubGraphQLErrorToContext(ctx context.Context, err *GitHubGraphQLError
 
 // NewGitHubAPIErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
 func NewGitHubAPIErrorResponse(ctx context.Context, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	err = unwrapBudgetError(err)
 	apiErr := newGitHubAPIError(message, resp, err)
 	if ctx != nil {
 		_, _ = addGitHubAPIErrorToContext(ctx, apiErr) // Explicitly ignore error for graceful handling
 	}
+	if quota := rateLimitQuota(resp, err); quota != "" {
+		err = fmt.Errorf("%w (%s)", err, quota)
+	}
 	return mcp.NewToolResultErrorFromErr(message, err)
 }
 
+// rateLimitQuota describes the remaining rate limit quota when a request was rejected by a
+// rate limit, so that callers know how long to back off for.
+func rateLimitQuota(resp *github.Response, err error) string {
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		if retryAfter := abuseErr.GetRetryAfter(); retryAfter > 0 {
+			return fmt.Sprintf("secondary rate limit exceeded, retry after %s", retryAfter)
+		}
+		return "secondary rate limit exceeded"
+	}
+
+	var rateErr *github.RateLimitError
+	rate := github.Rate{}
+	switch {
+	case errors.As(err, &rateErr):
+		rate = rateErr.Rate
+	case resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests):
+		rate = resp.Rate
+	}
+	if rate.Limit == 0 {
+		return ""
+	}
+	return fmt.Sprintf("rate limit: %d of %d requests remaining, resets at %s",
+		rate.Remaining, rate.Limit, rate.Reset.UTC().Format(time.RFC3339))
+}
+
+// unwrapBudgetError strips the request details the HTTP client wraps around API budget
+// errors, since the budget was exceeded before the request was sent.
+func unwrapBudgetError(err error) error {
+	var budgetErr *ratelimit.BudgetExceededError
+	if errors.As(err, &budgetErr) {
+		return budgetErr
+	}
+	return err
+}
+
 // NewGitHubGraphQLErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
 func NewGitHubGraphQLErrorResponse(ctx context.Context, message string, err error) *mcp.CallToolResult {
+	err = unwrapBudgetError(err)
 	graphQLErr := newGitHubGraphQLError(message, err)
 	if ctx != nil {
 		_, _ = addGitHubGraphQLErrorToContext(ctx, graphQLErr) // Explicitly ignore error for graceful handling
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -208,6 +212,56 @@ func TestGitHubErrorContext(t *testing.T) {
 		assert.Equal(t, originalErr, apiError.Err)
 	})
 
+	t.Run("NewGitHubAPIErrorResponse reports the remaining rate limit quota", func(t *testing.T) {
+		// Given a request rejected by the primary rate limit
+		ctx := ContextWithGitHubErrors(context.Background())
+		reset := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		resp := &github.Response{
+			Response: &http.Response{StatusCode: http.StatusForbidden},
+			Rate:     github.Rate{Limit: 5000, Remaining: 0, Reset: github.Timestamp{Time: reset}},
+		}
+		rateErr := &github.RateLimitError{Rate: resp.Rate, Response: resp.Response, Message: "API rate limit exceeded"}
+
+		// When we create an API error response
+		result := NewGitHubAPIErrorResponse(ctx, "failed to list issues", resp, rateErr)
+
+		// Then the message should include the quota
+		require.NotNil(t, result)
+		text := result.Content[0].(mcp.TextContent).Text
+		assert.Contains(t, text, "rate limit: 0 of 5000 requests remaining, resets at 2024-05-01T12:00:00Z")
+
+		// And a secondary rate limit should say when to retry
+		retryAfter := 30 * time.Second
+		abuseErr := &github.AbuseRateLimitError{Response: resp.Response, RetryAfter: &retryAfter}
+		result = NewGitHubAPIErrorResponse(ctx, "failed to create issue", resp, abuseErr)
+		text = result.Content[0].(mcp.TextContent).Text
+		assert.Contains(t, text, "secondary rate limit exceeded, retry after 30s")
+	})
+
+	t.Run("NewGitHubAPIErrorResponse reports exceeded API budgets", func(t *testing.T) {
+		// Given a request the HTTP client refused to send because of an API budget
+		ctx := ContextWithGitHubErrors(context.Background())
+		budgetErr := &ratelimit.BudgetExceededError{
+			Name:    "search_code",
+			Budget:  ratelimit.Budget{Calls: 50, Window: time.Hour},
+			ResetAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		}
+		urlErr := &url.Error{Op: "Get", URL: "https://api.github.com/search/code?q=foo", Err: budgetErr}
+
+		// When we create an API error response
+		result := NewGitHubAPIErrorResponse(ctx, "failed to search code", nil, urlErr)
+
+		// Then the message should only describe the budget
+		text := result.Content[0].(mcp.TextContent).Text
+		assert.Equal(t, "failed to search code: API budget for search_code of 50 calls per 1h0m0s exceeded, resets at 2024-05-01T12:00:00Z", text)
+
+		// And the typed error should be stored in the context
+		apiErrors, err := GetGitHubAPIErrors(ctx)
+		require.NoError(t, err)
+		require.Len(t, apiErrors, 1)
+		assert.Equal(t, budgetErr, apiErrors[0].Err)
+	})
+
 	t.Run("NewGitHubGraphQLErrorResponse creates MCP error result and stores context error", func(t *testing.T) {
 		// Given a context with GitHub error tracking enabled
 		ctx := ContextWithGitHubErrors(context.Background())
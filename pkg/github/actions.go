@@ -1,17 +1,26 @@
 package github
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/profiler"
 	buffer "github.com/github/github-mcp-server/pkg/buffer"
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/workflows"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -78,7 +87,115 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// GetWorkflowDefinition creates a tool to get the YAML of a workflow file
+func GetWorkflowDefinition(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_definition",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_DEFINITION_DESCRIPTION", "Get the YAML of a workflow file along with its state, triggers, jobs and the inputs it can be run with. Check edits to it with validate_workflow_file before committing them")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_DEFINITION_USER_TITLE", "Get workflow definition"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit to get the workflow file at. Defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := RequiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var workflow *github.Workflow
+			var resp *github.Response
+			if workflowIDInt, parseErr := strconv.ParseInt(workflowID, 10, 64); parseErr == nil {
+				workflow, resp, err = client.Actions.GetWorkflowByID(ctx, owner, repo, workflowIDInt)
+			} else {
+				workflow, resp, err = client.Actions.GetWorkflowByFileName(ctx, owner, repo, workflowID)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, workflow.GetPath(), &github.RepositoryContentGetOptions{Ref: ref})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow file", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if file == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is not a file", workflow.GetPath())), nil
+			}
+			content, err := file.GetContent()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode workflow file: %w", err)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"id":       workflow.GetID(),
+				"name":     workflow.GetName(),
+				"path":     workflow.GetPath(),
+				"state":    workflow.GetState(),
+				"html_url": workflow.GetHTMLURL(),
+				"ref":      ref,
+				"sha":      file.GetSHA(),
+				"summary":  workflows.Validate([]byte(content)).Summary,
+				"content":  content,
+			}), nil
+		}
+}
+
+// ValidateWorkflowFile creates a tool to check a workflow file before it is committed
+func ValidateWorkflowFile(t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("validate_workflow_file",
+			mcp.WithDescription(t("TOOL_VALIDATE_WORKFLOW_FILE_DESCRIPTION", "Check the YAML of a GitHub Actions workflow file for mistakes that would stop it from running, such as unknown keys or events, jobs without runs-on or steps, steps with neither uses nor run, needs of missing jobs and invalid inputs, with the lines they are on. Expressions are not evaluated and actions are not looked up")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_VALIDATE_WORKFLOW_FILE_USER_TITLE", "Validate workflow file"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("YAML content of the workflow file"),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return MarshalledTextResult(workflows.Validate([]byte(content))), nil
 		}
 }
 
@@ -90,6 +207,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_WORKFLOW_RUNS_USER_TITLE", "List workflow runs"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			mcp.WithOutputSchema[workflowRunListOutput](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -110,40 +228,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			),
 			mcp.WithString("event",
 				mcp.Description("Returns workflow runs for a specific event type"),
-				mcp.Enum(
-					"branch_protection_rule",
-					"check_run",
-					"check_suite",
-					"create",
-					"delete",
-					"deployment",
-					"deployment_status",
-					"discussion",
-					"discussion_comment",
-					"fork",
-					"gollum",
-					"issue_comment",
-					"issues",
-					"label",
-					"merge_group",
-					"milestone",
-					"page_build",
-					"public",
-					"pull_request",
-					"pull_request_review",
-					"pull_request_review_comment",
-					"pull_request_target",
-					"push",
-					"registry_package",
-					"release",
-					"repository_dispatch",
-					"schedule",
-					"status",
-					"watch",
-					"workflow_call",
-					"workflow_dispatch",
-					"workflow_run",
-				),
+				mcp.Enum(workflows.Events...),
 			),
 			mcp.WithString("status",
 				mcp.Description("Returns workflow runs with the check run status"),
@@ -217,7 +302,115 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// ListRepositoryWorkflowRuns creates a tool to list the workflow runs of all workflows in a repository
+func ListRepositoryWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_workflow_runs",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_WORKFLOW_RUNS_DESCRIPTION", "List workflow runs of all workflows in a repository, such as the runs for a commit or a branch")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_WORKFLOW_RUNS_USER_TITLE", "List repository workflow runs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithOutputSchema[workflowRunListOutput](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("head_sha",
+				mcp.Description("Returns workflow runs for a commit. Use the full SHA of the commit."),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Returns workflow runs associated with a branch. Use the name of the branch."),
+			),
+			mcp.WithString("actor",
+				mcp.Description("Returns someone's workflow runs. Use the login for the user who created the workflow run."),
+			),
+			mcp.WithString("event",
+				mcp.Description("Returns workflow runs for a specific event type"),
+				mcp.Enum(workflows.Events...),
+			),
+			mcp.WithString("status",
+				mcp.Description("Returns workflow runs with the check run status"),
+				mcp.Enum("queued", "in_progress", "completed", "requested", "waiting"),
+			),
+			mcp.WithString("created",
+				mcp.Description("Returns workflow runs created in a date range, such as '>=2025-01-01' or '2025-01-01..2025-01-31'"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			headSHA, err := OptionalParam[string](request, "head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			actor, err := OptionalParam[string](request, "actor")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			event, err := OptionalParam[string](request, "event")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, err := OptionalParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			created, err := OptionalParam[string](request, "created")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			workflowRuns, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+				Actor:   actor,
+				Branch:  branch,
+				Event:   event,
+				Status:  status,
+				Created: created,
+				HeadSHA: headSHA,
+				ListOptions: github.ListOptions{
+					PerPage: pagination.PerPage,
+					Page:    pagination.Page,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow runs", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(workflowRuns)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, workflowRuns.TotalCount)), nil
 		}
 }
 
@@ -328,6 +521,7 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_GET_WORKFLOW_RUN_USER_TITLE", "Get workflow run"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			mcp.WithOutputSchema[workflowRunOutput](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -526,7 +720,7 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
 		}
 }
 
@@ -562,6 +756,9 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 				mcp.Description("Number of lines to return from the end of the log"),
 				mcp.DefaultNumber(500),
 			),
+			mcp.WithString("grep",
+				mcp.Description("Regular expression (RE2 syntax) to filter the log lines by when return_content is true, e.g. '(?i)error|fail'. tail_lines then applies to the matching lines"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -598,6 +795,17 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 			if tailLines == 0 {
 				tailLines = 500
 			}
+			grepPattern, err := OptionalParam[string](request, "grep")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var grep *regexp.Regexp
+			if grepPattern != "" {
+				grep, err = regexp.Compile(grepPattern)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid grep pattern: %s", err)), nil
+				}
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -614,10 +822,10 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 
 			if failedOnly && runID > 0 {
 				// Handle failed-only mode: get logs for all failed jobs in the workflow run
-				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, tailLines, contentWindowSize)
+				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, tailLines, grep, contentWindowSize, newProgressReporter(ctx, request, 0))
 			} else if jobID > 0 {
 				// Handle single job mode
-				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), returnContent, tailLines, contentWindowSize)
+				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), returnContent, tailLines, grep, contentWindowSize)
 			}
 
 			return mcp.NewToolResultError("Either job_id must be provided for single job logs, or run_id with failed_only=true for failed job logs"), nil
@@ -625,7 +833,7 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 }
 
 // handleFailedJobLogs gets logs for all failed jobs in a workflow run
-func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, error) {
+func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int, grep *regexp.Regexp, contentWindowSize int, progress *progressReporter) (*mcp.CallToolResult, error) {
 	// First, get all jobs for the workflow run
 	jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
 		Filter: "latest",
@@ -655,9 +863,10 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 	}
 
 	// Collect logs for all failed jobs
+	progress.setTotal(len(failedJobs))
 	var logResults []map[string]any
 	for _, job := range failedJobs {
-		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, tailLines, contentWindowSize)
+		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, tailLines, grep, contentWindowSize)
 		if err != nil {
 			// Continue with other jobs even if one fails
 			jobResult = map[string]any{
@@ -670,6 +879,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		}
 
 		logResults = append(logResults, jobResult)
+		progress.step(ctx, fmt.Sprintf("Retrieved logs for job %s", job.GetName()))
 	}
 
 	result := map[string]any{
@@ -690,8 +900,8 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 }
 
 // handleSingleJobLogs gets logs for a single job
-func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, error) {
-	jobResult, resp, err := getJobLogData(ctx, client, owner, repo, jobID, "", returnContent, tailLines, contentWindowSize)
+func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, returnContent bool, tailLines int, grep *regexp.Regexp, contentWindowSize int) (*mcp.CallToolResult, error) {
+	jobResult, resp, err := getJobLogData(ctx, client, owner, repo, jobID, "", returnContent, tailLines, grep, contentWindowSize)
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get job logs", resp, err), nil
 	}
@@ -705,7 +915,7 @@ func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo
 }
 
 // getJobLogData retrieves log data for a single job, either as URL or content
-func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, returnContent bool, tailLines int, contentWindowSize int) (map[string]any, *github.Response, error) {
+func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, returnContent bool, tailLines int, grep *regexp.Regexp, contentWindowSize int) (map[string]any, *github.Response, error) {
 	// Get the download URL for the job logs
 	url, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, 1)
 	if err != nil {
@@ -722,7 +932,7 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 
 	if returnContent {
 		// Download and return the actual log content
-		content, originalLength, httpResp, err := downloadLogContent(ctx, url.String(), tailLines, contentWindowSize) //nolint:bodyclose // Response body is closed in downloadLogContent, but we need to return httpResp
+		content, originalLength, matchedLines, httpResp, err := downloadLogContent(ctx, url.String(), tailLines, contentWindowSize, grep) //nolint:bodyclose // Response body is closed in downloadLogContent, but we need to return httpResp
 		if err != nil {
 			// To keep the return value consistent wrap the response as a GitHub Response
 			ghRes := &github.Response{
@@ -733,6 +943,9 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 		result["logs_content"] = content
 		result["message"] = "Job logs content retrieved successfully"
 		result["original_length"] = originalLength
+		if grep != nil {
+			result["matched_lines"] = matchedLines
+		}
 	} else {
 		// Return just the URL
 		result["logs_url"] = url.String()
@@ -743,18 +956,21 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 	return result, resp, nil
 }
 
-func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLines int) (string, int, *http.Response, error) {
+// downloadLogContent downloads a plain-text log and returns its last tailLines lines, capped at
+// maxLines, along with its total line count. If grep is set, only matching lines are kept and
+// their count is returned as well.
+func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLines int, grep *regexp.Regexp) (string, int, int, *http.Response, error) {
 	prof := profiler.New(nil, profiler.IsProfilingEnabled())
 	finish := prof.Start(ctx, "log_buffer_processing")
 
 	httpResp, err := http.Get(logURL) //nolint:gosec
 	if err != nil {
-		return "", 0, httpResp, fmt.Errorf("failed to download logs: %w", err)
+		return "", 0, 0, httpResp, fmt.Errorf("failed to download logs: %w", err)
 	}
 	defer func() { _ = httpResp.Body.Close() }()
 
 	if httpResp.StatusCode != http.StatusOK {
-		return "", 0, httpResp, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
+		return "", 0, 0, httpResp, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
 	}
 
 	bufferSize := tailLines
@@ -762,9 +978,13 @@ func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLi
 		bufferSize = maxLines
 	}
 
-	processedInput, totalLines, httpResp, err := buffer.ProcessResponseAsRingBufferToEnd(httpResp, bufferSize)
+	var keep func(string) bool
+	if grep != nil {
+		keep = grep.MatchString
+	}
+	processedInput, totalLines, matchedLines, httpResp, err := buffer.ProcessResponseAsFilteredRingBufferToEnd(httpResp, bufferSize, keep)
 	if err != nil {
-		return "", 0, httpResp, fmt.Errorf("failed to process log content: %w", err)
+		return "", 0, 0, httpResp, fmt.Errorf("failed to process log content: %w", err)
 	}
 
 	lines := strings.Split(processedInput, "\n")
@@ -775,16 +995,22 @@ func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLi
 
 	_ = finish(len(lines), int64(len(finalResult)))
 
-	return finalResult, totalLines, httpResp, nil
+	return finalResult, totalLines, matchedLines, httpResp, nil
 }
 
-// RerunWorkflowRun creates a tool to re-run an entire workflow run
-func RerunWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("rerun_workflow_run",
-			mcp.WithDescription(t("TOOL_RERUN_WORKFLOW_RUN_DESCRIPTION", "Re-run an entire workflow run")),
+// failureExcerptPattern matches the log lines most likely to explain why a job failed.
+var failureExcerptPattern = regexp.MustCompile(`(?i)##\[error\]|\berror\b|\bfail(ed|ure)?\b|panic:|exception|fatal`)
+
+// logTimestampPattern matches the timestamp GitHub Actions prefixes each log line with.
+var logTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T[0-9:.]+Z `)
+
+// SummarizeWorkflowRunFailure creates a tool that summarizes why a workflow run failed
+func SummarizeWorkflowRunFailure(getClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("summarize_workflow_run_failure",
+			mcp.WithDescription(t("TOOL_SUMMARIZE_WORKFLOW_RUN_FAILURE_DESCRIPTION", "Summarize why a workflow run failed: its failed jobs, their failing steps and excerpts of the error lines from their logs. Use this before reaching for get_job_logs.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_RERUN_WORKFLOW_RUN_USER_TITLE", "Rerun workflow run"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:        t("TOOL_SUMMARIZE_WORKFLOW_RUN_FAILURE_USER_TITLE", "Summarize workflow run failure"),
+				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -798,6 +1024,10 @@ func RerunWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			mcp.WithNumber("excerpt_lines",
+				mcp.Description("Maximum number of error lines to return per failed job, taken from the end of its log"),
+				mcp.DefaultNumber(20),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -813,23 +1043,63 @@ func RerunWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			runID := int64(runIDInt)
+			excerptLines, err := OptionalIntParamWithDefault(request, "excerpt_lines", 20)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if excerptLines < 1 {
+				return mcp.NewToolResultError("excerpt_lines must be at least 1"), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			resp, err := client.Actions.RerunWorkflowByID(ctx, owner, repo, runID)
+			run, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
 			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to rerun workflow run", resp, err), nil
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow run", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
+				Filter:      "latest",
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow jobs", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			var toSummarize []*github.WorkflowJob
+			for _, job := range jobs.Jobs {
+				if job.GetConclusion() == "failure" || job.GetConclusion() == "timed_out" {
+					toSummarize = append(toSummarize, job)
+				}
+			}
+			progress := newProgressReporter(ctx, request, len(toSummarize))
+			var failedJobs []map[string]any
+			for _, job := range toSummarize {
+				failedJobs = append(failedJobs, summarizeFailedJob(ctx, client, owner, repo, job, excerptLines, contentWindowSize))
+				progress.step(ctx, fmt.Sprintf("Summarized job %s", job.GetName()))
 			}
-			defer func() { _ = resp.Body.Close() }()
 
 			result := map[string]any{
-				"message":     "Workflow run has been queued for re-run",
 				"run_id":      runID,
-				"status":      resp.Status,
-				"status_code": resp.StatusCode,
+				"workflow":    run.GetName(),
+				"run_number":  run.GetRunNumber(),
+				"run_attempt": run.GetRunAttempt(),
+				"event":       run.GetEvent(),
+				"head_branch": run.GetHeadBranch(),
+				"head_sha":    run.GetHeadSHA(),
+				"status":      run.GetStatus(),
+				"conclusion":  run.GetConclusion(),
+				"html_url":    run.GetHTMLURL(),
+				"total_jobs":  jobs.GetTotalCount(),
+				"failed_jobs": failedJobs,
+			}
+			if len(failedJobs) == 0 {
+				result["message"] = "No failed jobs found in this workflow run"
 			}
 
 			r, err := json.Marshal(result)
@@ -841,13 +1111,23 @@ func RerunWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
-// RerunFailedJobs creates a tool to re-run only the failed jobs in a workflow run
-func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("rerun_failed_jobs",
-			mcp.WithDescription(t("TOOL_RERUN_FAILED_JOBS_DESCRIPTION", "Re-run only the failed jobs in a workflow run")),
+// Polling intervals of wait_for_workflow_run, which backs off from the first to the second.
+var (
+	workflowRunPollInterval    = 5 * time.Second
+	workflowRunMaxPollInterval = 30 * time.Second
+)
+
+// workflowRunWaitMargin is how long before the deadline of a call wait_for_workflow_run stops
+// waiting, leaving time to return the run as it is rather than failing with a timeout.
+const workflowRunWaitMargin = 5 * time.Second
+
+// WaitForWorkflowRun creates a tool that waits for a workflow run to complete
+func WaitForWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("wait_for_workflow_run",
+			mcp.WithDescription(t("TOOL_WAIT_FOR_WORKFLOW_RUN_DESCRIPTION", "Wait for a workflow run to complete, then return its conclusion along with a summary of its failed jobs. If the run is still going when the timeout is reached, its current status is returned and the tool can be called again to keep waiting. Use this instead of polling get_workflow_run.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_RERUN_FAILED_JOBS_USER_TITLE", "Rerun failed jobs"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:        t("TOOL_WAIT_FOR_WORKFLOW_RUN_USER_TITLE", "Wait for workflow run"),
+				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -861,6 +1141,15 @@ func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			mcp.WithNumber("timeout_seconds",
+				mcp.Description("Longest time to wait, in seconds. Waiting also stops before the tool call itself would time out"),
+				mcp.DefaultNumber(240),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("excerpt_lines",
+				mcp.Description("Maximum number of error lines to return per failed job, taken from the end of its log"),
+				mcp.DefaultNumber(20),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -876,40 +1165,279 @@ func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			runID := int64(runIDInt)
+			timeoutSeconds, err := OptionalIntParamWithDefault(request, "timeout_seconds", 240)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if timeoutSeconds < 1 {
+				return mcp.NewToolResultError("timeout_seconds must be at least 1"), nil
+			}
+			excerptLines, err := OptionalIntParamWithDefault(request, "excerpt_lines", 20)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if excerptLines < 1 {
+				return mcp.NewToolResultError("excerpt_lines must be at least 1"), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			resp, err := client.Actions.RerunFailedJobsByID(ctx, owner, repo, runID)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to rerun failed jobs", resp, err), nil
+			start := time.Now()
+			deadline := start.Add(time.Duration(timeoutSeconds) * time.Second)
+			if callDeadline, ok := ctx.Deadline(); ok && callDeadline.Add(-workflowRunWaitMargin).Before(deadline) {
+				deadline = callDeadline.Add(-workflowRunWaitMargin)
 			}
-			defer func() { _ = resp.Body.Close() }()
+			progress := newProgressReporter(ctx, request, 0)
+			interval := workflowRunPollInterval
 
-			result := map[string]any{
-				"message":     "Failed jobs have been queued for re-run",
-				"run_id":      runID,
-				"status":      resp.Status,
-				"status_code": resp.StatusCode,
+			var run *github.WorkflowRun
+			for {
+				var resp *github.Response
+				run, resp, err = client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow run", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				if run.GetStatus() == "completed" {
+					break
+				}
+				progress.step(ctx, fmt.Sprintf("Workflow run is %s after %s", run.GetStatus(), time.Since(start).Round(time.Second)))
+
+				wait := min(interval, time.Until(deadline))
+				if wait <= 0 {
+					break
+				}
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return nil, ctx.Err()
+				case <-timer.C:
+				}
+				interval = min(interval*2, workflowRunMaxPollInterval)
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			result := map[string]any{
+				"run_id":         runID,
+				"workflow":       run.GetName(),
+				"run_number":     run.GetRunNumber(),
+				"run_attempt":    run.GetRunAttempt(),
+				"head_branch":    run.GetHeadBranch(),
+				"head_sha":       run.GetHeadSHA(),
+				"status":         run.GetStatus(),
+				"conclusion":     run.GetConclusion(),
+				"html_url":       run.GetHTMLURL(),
+				"waited_seconds": int(time.Since(start).Seconds()),
+			}
+			if run.GetStatus() != "completed" {
+				result["completed"] = false
+				result["message"] = fmt.Sprintf("The workflow run is still %s, call wait_for_workflow_run again to keep waiting", run.GetStatus())
+				return MarshalledTextResult(result), nil
+			}
+			result["completed"] = true
+
+			jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
+				Filter:      "latest",
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow jobs", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			failedJobs := []map[string]any{}
+			for _, job := range jobs.Jobs {
+				if job.GetConclusion() == "failure" || job.GetConclusion() == "timed_out" {
+					failedJobs = append(failedJobs, summarizeFailedJob(ctx, client, owner, repo, job, excerptLines, contentWindowSize))
+				}
 			}
+			result["total_jobs"] = jobs.GetTotalCount()
+			result["failed_jobs"] = failedJobs
+			return MarshalledTextResult(result), nil
+		}
+}
 
-			return mcp.NewToolResultText(string(r)), nil
+// summarizeFailedJob returns the failing steps of a job along with the last error lines of its
+// log. Log download failures are reported in the summary rather than failing the whole tool.
+func summarizeFailedJob(ctx context.Context, client *github.Client, owner, repo string, job *github.WorkflowJob, excerptLines int, contentWindowSize int) map[string]any {
+	var failedSteps []map[string]any
+	for _, step := range job.Steps {
+		if step.GetConclusion() == "failure" || step.GetConclusion() == "timed_out" {
+			failedSteps = append(failedSteps, map[string]any{
+				"number":     step.GetNumber(),
+				"name":       step.GetName(),
+				"conclusion": step.GetConclusion(),
+			})
+		}
+	}
+
+	summary := map[string]any{
+		"job_id":       job.GetID(),
+		"name":         job.GetName(),
+		"conclusion":   job.GetConclusion(),
+		"html_url":     job.GetHTMLURL(),
+		"failed_steps": failedSteps,
+	}
+
+	url, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, job.GetID(), 1)
+	if err != nil {
+		summary["log_error"] = fmt.Sprintf("failed to get job logs: %s", err)
+		_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get job logs", resp, err)
+		return summary
+	}
+	_ = resp.Body.Close()
+
+	content, _, _, _, err := downloadLogContent(ctx, url.String(), excerptLines, contentWindowSize, failureExcerptPattern) //nolint:bodyclose // Response body is closed in downloadLogContent
+	if err != nil {
+		summary["log_error"] = err.Error()
+		return summary
+	}
+
+	excerpt := []string{}
+	if content != "" {
+		for _, line := range strings.Split(content, "\n") {
+			excerpt = append(excerpt, logTimestampPattern.ReplaceAllString(line, ""))
 		}
+	}
+	summary["error_excerpt"] = excerpt
+	return summary
 }
 
-// CancelWorkflowRun creates a tool to cancel a workflow run
-func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("cancel_workflow_run",
-			mcp.WithDescription(t("TOOL_CANCEL_WORKFLOW_RUN_DESCRIPTION", "Cancel a workflow run")),
+// RerunWorkflowRun creates a tool to re-run an entire workflow run
+func RerunWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rerun_workflow_run",
+			mcp.WithDescription(t("TOOL_RERUN_WORKFLOW_RUN_DESCRIPTION", "Re-run an entire workflow run")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_CANCEL_WORKFLOW_RUN_USER_TITLE", "Cancel workflow run"),
+				Title:        t("TOOL_RERUN_WORKFLOW_RUN_USER_TITLE", "Rerun workflow run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.RerunWorkflowByID(ctx, owner, repo, runID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to rerun workflow run", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"message":     "Workflow run has been queued for re-run",
+				"run_id":      runID,
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RerunFailedJobs creates a tool to re-run only the failed jobs in a workflow run
+func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rerun_failed_jobs",
+			mcp.WithDescription(t("TOOL_RERUN_FAILED_JOBS_DESCRIPTION", "Re-run only the failed jobs in a workflow run")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RERUN_FAILED_JOBS_USER_TITLE", "Rerun failed jobs"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.RerunFailedJobsByID(ctx, owner, repo, runID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to rerun failed jobs", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"message":     "Failed jobs have been queued for re-run",
+				"run_id":      runID,
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CancelWorkflowRun creates a tool to cancel a workflow run
+func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("cancel_workflow_run",
+			mcp.WithDescription(t("TOOL_CANCEL_WORKFLOW_RUN_DESCRIPTION", "Cancel a workflow run")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CANCEL_WORKFLOW_RUN_USER_TITLE", "Cancel workflow run"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
@@ -969,6 +1497,225 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 		}
 }
 
+// ListPendingDeployments creates a tool to list the deployments of a workflow run waiting for approval
+func ListPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pending_deployments",
+			mcp.WithDescription(t("TOOL_LIST_PENDING_DEPLOYMENTS_DESCRIPTION", "List the deployments of a workflow run that are waiting for environment protection rules, such as required reviewers, with whether the authenticated user can approve them")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PENDING_DEPLOYMENTS_USER_TITLE", "List pending deployments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pending, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, runID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(pending), nil
+		}
+}
+
+// ReviewPendingDeployments creates a tool to approve or reject the pending deployments of a workflow run
+func ReviewPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_pending_deployments",
+			mcp.WithDescription(t("TOOL_REVIEW_PENDING_DEPLOYMENTS_DESCRIPTION", "Approve or reject deployments of a workflow run waiting for review, so that its jobs for those environments run or fail")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_PENDING_DEPLOYMENTS_USER_TITLE", "Review pending deployments"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("Whether to approve or reject the deployments"),
+				mcp.Enum("approved", "rejected"),
+			),
+			mcp.WithString("comment",
+				mcp.Required(),
+				mcp.Description("Comment to record with the review"),
+			),
+			mcp.WithArray("environment_ids",
+				mcp.Description("IDs of the environments to review, as returned by list_pending_deployments. Defaults to every pending environment the authenticated user can approve"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "number",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := RequiredParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ids, err := OptionalIntArrayParam(request, "environment_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			environmentIDs := make([]int64, 0, len(ids))
+			for _, id := range ids {
+				environmentIDs = append(environmentIDs, int64(id))
+			}
+			if len(environmentIDs) == 0 {
+				pending, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, runID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pending deployments", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				for _, deployment := range pending {
+					if deployment.GetCurrentUserCanApprove() {
+						environmentIDs = append(environmentIDs, deployment.GetEnvironment().GetID())
+					}
+				}
+				if len(environmentIDs) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("workflow run %d has no pending deployments that you can review", runID)), nil
+				}
+			}
+
+			deployments, resp, err := client.Actions.PendingDeployments(ctx, owner, repo, runID, &github.PendingDeploymentsRequest{
+				EnvironmentIDs: environmentIDs,
+				State:          state,
+				Comment:        comment,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to review pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"run_id":          runID,
+				"state":           state,
+				"environment_ids": environmentIDs,
+				"deployments":     deployments,
+			}), nil
+		}
+}
+
+// ApproveWorkflowRun creates a tool to approve a workflow run from a fork that is waiting for approval
+func ApproveWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("approve_workflow_run",
+			mcp.WithDescription(t("TOOL_APPROVE_WORKFLOW_RUN_DESCRIPTION", "Approve a workflow run of a pull request from a fork, which waits for approval before running if the repository requires it. Review the changes of the pull request first, since approving lets them run with the repository's workflows")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_APPROVE_WORKFLOW_RUN_USER_TITLE", "Approve workflow run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github has no method for approving fork runs
+			req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/actions/runs/%d/approve", owner, repo, runID), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			resp, err := client.Do(ctx, req, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to approve workflow run", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message": "Workflow run has been approved",
+				"run_id":  runID,
+			}), nil
+		}
+}
+
 // ListWorkflowRunArtifacts creates a tool to list artifacts for a workflow run
 func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflow_run_artifacts",
@@ -1034,7 +1781,7 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
 		}
 }
 
@@ -1058,6 +1805,13 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 				mcp.Required(),
 				mcp.Description("The unique identifier of the artifact"),
 			),
+			mcp.WithBoolean("return_content",
+				mcp.Description("Download the artifact and return the contents of its files instead of the download URL"),
+			),
+			mcp.WithArray("files",
+				mcp.Description("Paths or glob patterns of the files in the artifact to return, e.g. ['report.xml', 'logs/*.txt']. Defaults to all files. Only used with return_content"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1073,6 +1827,19 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			artifactID := int64(artifactIDInt)
+			returnContent, err := OptionalParam[bool](request, "return_content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			patterns, err := OptionalStringArrayParam(request, "files")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid file pattern %q: %s", pattern, err)), nil
+				}
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -1086,11 +1853,19 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			if returnContent {
+				archive, err := downloadArtifactArchive(ctx, url.String())
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to download artifact %d: %s", artifactID, err)), nil
+				}
+				return artifactContentsResult(owner, repo, artifactID, archive, patterns)
+			}
+
 			// Create response with the download URL and information
 			result := map[string]any{
 				"download_url": url.String(),
 				"message":      "Artifact is available for download",
-				"note":         "The download_url provides a download link for the artifact as a ZIP archive. The link is temporary and expires after a short time.",
+				"note":         "The download_url provides a download link for the artifact as a ZIP archive. The link is temporary and expires after a short time. Use return_content=true to get the contents of its files.",
 				"artifact_id":  artifactID,
 			}
 
@@ -1103,6 +1878,138 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 		}
 }
 
+const (
+	// maxArtifactArchiveSize bounds the size of artifact ZIP archives downloaded by the server.
+	maxArtifactArchiveSize = 100 * 1024 * 1024
+	// maxArtifactContentSize bounds the total size of the artifact files returned in one result.
+	maxArtifactContentSize = 1024 * 1024
+)
+
+// ArtifactFile describes a file in a workflow run artifact, and whether its contents were returned.
+type ArtifactFile struct {
+	Path     string `json:"path"`
+	Size     uint64 `json:"size"`
+	Returned bool   `json:"returned"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// downloadArtifactArchive downloads the ZIP archive of an artifact from its signed download URL.
+func downloadArtifactArchive(ctx context.Context, downloadURL string) (*zip.Reader, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", httpResp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(httpResp.Body, maxArtifactArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxArtifactArchiveSize {
+		return nil, fmt.Errorf("archive is larger than %d MB", maxArtifactArchiveSize/1024/1024)
+	}
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// artifactContentsResult returns the files of an artifact archive that match the patterns as
+// embedded resources, up to maxArtifactContentSize in total, preceded by a listing of all files.
+func artifactContentsResult(owner, repo string, artifactID int64, archive *zip.Reader, patterns []string) (*mcp.CallToolResult, error) {
+	files := []ArtifactFile{}
+	var resources []mcp.Content
+	remaining := uint64(maxArtifactContentSize)
+	for _, f := range archive.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		file := ArtifactFile{Path: f.Name, Size: f.UncompressedSize64}
+		switch {
+		case !matchesAnyPattern(f.Name, patterns):
+			file.Reason = "not selected"
+		case f.UncompressedSize64 > remaining:
+			file.Reason = "exceeds size limit"
+		default:
+			content, err := readArtifactFile(f, remaining)
+			if err != nil {
+				file.Reason = fmt.Sprintf("failed to read: %s", err)
+				break
+			}
+			remaining -= uint64(len(content))
+			file.Returned = true
+			resources = append(resources, mcp.NewEmbeddedResource(artifactFileResource(owner, repo, artifactID, f.Name, content)))
+		}
+		files = append(files, file)
+	}
+
+	listing, err := json.Marshal(map[string]any{
+		"artifact_id": artifactID,
+		"files":       files,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal artifact files: %w", err)
+	}
+	return &mcp.CallToolResult{
+		Content: append([]mcp.Content{mcp.NewTextContent(string(listing))}, resources...),
+	}, nil
+}
+
+func matchesAnyPattern(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// readArtifactFile reads a file from an artifact archive, failing if it holds more than limit
+// bytes regardless of the size its header claims.
+func readArtifactFile(f *zip.File, limit uint64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	content, err := io.ReadAll(io.LimitReader(rc, int64(limit)+1)) // #nosec G115 - limit is at most maxArtifactContentSize
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(content)) > limit {
+		return nil, errors.New("exceeds size limit")
+	}
+	return content, nil
+}
+
+// artifactFileResource returns a file from an artifact as a text resource, or as a blob if it
+// is not text.
+func artifactFileResource(owner, repo string, artifactID int64, name string, content []byte) mcp.ResourceContents {
+	uri := fmt.Sprintf("repo://%s/%s/actions/artifacts/%d/%s", owner, repo, artifactID, name)
+	contentType := http.DetectContentType(content)
+	if strings.HasPrefix(contentType, "text/") {
+		return mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: contentType,
+			Text:     string(content),
+		}
+	}
+	return mcp.BlobResourceContents{
+		URI:      uri,
+		MIMEType: contentType,
+		Blob:     base64.StdEncoding.EncodeToString(content),
+	}
+}
+
 // DeleteWorkflowRunLogs creates a tool to delete logs for a workflow run
 func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("delete_workflow_run_logs",
@@ -1,9 +1,13 @@
 package github
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -11,11 +15,14 @@ import (
 	"runtime/debug"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/profiler"
 	buffer "github.com/github/github-mcp-server/pkg/buffer"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/workflows"
 	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -132,6 +139,234 @@ func Test_ListWorkflows(t *testing.T) {
 	}
 }
 
+func Test_ListRepositoryWorkflowRuns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryWorkflowRuns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_repository_workflow_runs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "head_sha")
+	assert.Contains(t, tool.InputSchema.Properties, "created")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	runs := &github.WorkflowRuns{
+		TotalCount: github.Ptr(1),
+		WorkflowRuns: []*github.WorkflowRun{
+			{ID: github.Ptr(int64(42)), Name: github.Ptr("CI"), HeadSHA: github.Ptr("abc123"), Status: github.Ptr("completed")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "lists runs for a commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"head_sha": "abc123",
+						"branch":   "main",
+						"event":    "push",
+						"created":  ">=2025-01-01",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, runs),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"head_sha": "abc123",
+				"branch":   "main",
+				"event":    "push",
+				"created":  ">=2025-01-01",
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response github.WorkflowRuns
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 1, response.GetTotalCount())
+			require.Len(t, response.WorkflowRuns, 1)
+			assert.Equal(t, "abc123", response.WorkflowRuns[0].GetHeadSHA())
+			assert.Equal(t, PageInfo{TotalCount: github.Ptr(1)}, result.Meta.AdditionalFields["pageInfo"])
+		})
+	}
+}
+
+func Test_GetWorkflowDefinition(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowDefinition(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_workflow_definition", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+
+	content := "name: CI\non:\n  workflow_dispatch:\n    inputs:\n      target:\n        required: true\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make\n"
+	workflow := &github.Workflow{
+		ID:      github.Ptr(int64(123)),
+		Name:    github.Ptr("CI"),
+		Path:    github.Ptr(".github/workflows/ci.yml"),
+		State:   github.Ptr("active"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/.github/workflows/ci.yml"),
+	}
+	file := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Path:     github.Ptr(".github/workflows/ci.yml"),
+		SHA:      github.Ptr("def456"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "gets the workflow by file name at a ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					workflow,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "feature"}).andThen(
+						mockResponse(t, http.StatusOK, file),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+				"ref":         "feature",
+			},
+		},
+		{
+			name: "workflow not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "123",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowDefinition(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				ID      int64             `json:"id"`
+				Path    string            `json:"path"`
+				Ref     string            `json:"ref"`
+				SHA     string            `json:"sha"`
+				Content string            `json:"content"`
+				Summary workflows.Summary `json:"summary"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, int64(123), response.ID)
+			assert.Equal(t, ".github/workflows/ci.yml", response.Path)
+			assert.Equal(t, "feature", response.Ref)
+			assert.Equal(t, "def456", response.SHA)
+			assert.Equal(t, content, response.Content)
+			assert.Equal(t, workflows.Summary{
+				Name:     "CI",
+				Triggers: []string{"workflow_dispatch"},
+				Jobs:     []string{"build"},
+				Inputs:   map[string]workflows.Input{"target": {Type: "string", Required: true}},
+			}, response.Summary)
+		})
+	}
+}
+
+func Test_ValidateWorkflowFile(t *testing.T) {
+	tool, handler := ValidateWorkflowFile(translations.NullTranslationHelper)
+
+	assert.Equal(t, "validate_workflow_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"content"})
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"content": "on: push\njobs:\n  build:\n    steps:\n      - uses: actions/checkout\n",
+	}))
+	require.NoError(t, err)
+
+	var response workflows.Result
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.False(t, response.Valid)
+	assert.Equal(t, []workflows.Problem{
+		{Line: 3, Message: `job "build" has no runs-on`},
+		{Line: 5, Message: `step 1 of job "build" uses "actions/checkout" without a version, such as @v4 or a commit SHA`},
+	}, response.Errors)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, "missing required parameter: content", getErrorResult(t, result).Text)
+}
+
 func Test_RunWorkflow(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -413,6 +648,212 @@ func Test_CancelWorkflowRun(t *testing.T) {
 	}
 }
 
+func Test_ListPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pending_deployments", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+			[]*github.PendingDeployment{{
+				Environment:           &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(7)), Name: github.Ptr("production")},
+				CurrentUserCanApprove: github.Ptr(true),
+			}},
+		),
+	))
+	_, handler := ListPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":  "owner",
+		"repo":   "repo",
+		"run_id": float64(42),
+	}))
+	require.NoError(t, err)
+
+	var pending []*github.PendingDeployment
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &pending))
+	require.Len(t, pending, 1)
+	assert.Equal(t, "production", pending[0].GetEnvironment().GetName())
+	assert.True(t, pending[0].GetCurrentUserCanApprove())
+}
+
+func Test_ReviewPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_pending_deployments", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "environment_ids")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "state", "comment"})
+
+	pending := mock.WithRequestMatch(
+		mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+		[]*github.PendingDeployment{
+			{Environment: &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(7)), Name: github.Ptr("production")}, CurrentUserCanApprove: github.Ptr(true)},
+			{Environment: &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(8)), Name: github.Ptr("staging")}, CurrentUserCanApprove: github.Ptr(false)},
+		},
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "approves the given environments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(8)},
+						"state":           "approved",
+						"comment":         "Ship it",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Deployment{{ID: github.Ptr(int64(1)), Environment: github.Ptr("staging")}}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(42),
+				"state":           "approved",
+				"comment":         "Ship it",
+				"environment_ids": []any{float64(8)},
+			},
+		},
+		{
+			name: "defaults to the environments the user can approve",
+			mockedClient: mock.NewMockedHTTPClient(
+				pending,
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(7)},
+						"state":           "rejected",
+						"comment":         "Not yet",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Deployment{}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"run_id":  float64(42),
+				"state":   "rejected",
+				"comment": "Not yet",
+			},
+		},
+		{
+			name: "no pending deployments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					[]*github.PendingDeployment{},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"run_id":  float64(42),
+				"state":   "approved",
+				"comment": "Ship it",
+			},
+			expectError:    true,
+			expectedErrMsg: "workflow run 42 has no pending deployments that you can review",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.requestArgs["state"], response["state"])
+		})
+	}
+}
+
+func Test_ApproveWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ApproveWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "approve_workflow_run", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "approves a fork run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsApproveByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusCreated, map[string]any{}),
+				),
+			),
+		},
+		{
+			name: "run does not need approval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsApproveByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "This run is not from a fork pull request"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to approve workflow run",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ApproveWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(42),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, float64(42), response["run_id"])
+		})
+	}
+}
+
 func Test_ListWorkflowRunArtifacts(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -629,6 +1070,138 @@ func Test_DownloadWorkflowRunArtifact(t *testing.T) {
 	}
 }
 
+func Test_DownloadWorkflowRunArtifact_ReturnContent(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"report.xml":       "<testsuite tests=\"1\"/>",
+		"logs/build.txt":   "build ok",
+		"coverage/out.bin": "\x00\x01\x02\x03",
+		"big.txt":          strings.Repeat("a", maxArtifactContentSize+1),
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(archive.Bytes())
+	}))
+	defer srv.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.EndpointPattern{
+				Pattern: "/repos/owner/repo/actions/artifacts/123/zip",
+				Method:  "GET",
+			},
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", srv.URL)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+
+	tests := []struct {
+		name             string
+		files            []any
+		expectedReturned map[string]string
+		expectedBlobs    []string
+		expectedReasons  map[string]string
+	}{
+		{
+			name: "all files",
+			expectedReturned: map[string]string{
+				"report.xml":     "<testsuite tests=\"1\"/>",
+				"logs/build.txt": "build ok",
+			},
+			expectedBlobs: []string{"coverage/out.bin"},
+			expectedReasons: map[string]string{
+				"big.txt": "exceeds size limit",
+			},
+		},
+		{
+			name:  "selected files",
+			files: []any{"logs/*.txt"},
+			expectedReturned: map[string]string{
+				"logs/build.txt": "build ok",
+			},
+			expectedReasons: map[string]string{
+				"report.xml":       "not selected",
+				"coverage/out.bin": "not selected",
+				"big.txt":          "not selected",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockedClient)
+			_, handler := DownloadWorkflowRunArtifact(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"artifact_id":    float64(123),
+				"return_content": true,
+			}
+			if tc.files != nil {
+				args["files"] = tc.files
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var listing struct {
+				ArtifactID int64          `json:"artifact_id"`
+				Files      []ArtifactFile `json:"files"`
+			}
+			require.NotEmpty(t, result.Content)
+			text, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			require.NoError(t, json.Unmarshal([]byte(text.Text), &listing))
+			assert.Equal(t, int64(123), listing.ArtifactID)
+			assert.Len(t, listing.Files, 4)
+			for _, file := range listing.Files {
+				assert.Equal(t, tc.expectedReasons[file.Path], file.Reason, file.Path)
+				assert.Equal(t, tc.expectedReasons[file.Path] == "", file.Returned, file.Path)
+			}
+
+			texts := map[string]string{}
+			var blobs []string
+			for _, content := range result.Content[1:] {
+				resource, ok := content.(mcp.EmbeddedResource)
+				require.True(t, ok)
+				switch r := resource.Resource.(type) {
+				case mcp.TextResourceContents:
+					texts[strings.TrimPrefix(r.URI, "repo://owner/repo/actions/artifacts/123/")] = r.Text
+				case mcp.BlobResourceContents:
+					blobs = append(blobs, strings.TrimPrefix(r.URI, "repo://owner/repo/actions/artifacts/123/"))
+				}
+			}
+			assert.Equal(t, tc.expectedReturned, texts)
+			assert.ElementsMatch(t, tc.expectedBlobs, blobs)
+		})
+	}
+
+	t.Run("invalid file pattern", func(t *testing.T) {
+		_, handler := DownloadWorkflowRunArtifact(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient())), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"artifact_id":    float64(123),
+			"return_content": true,
+			"files":          []any{"["},
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "invalid file pattern")
+	})
+}
+
 func Test_DeleteWorkflowRunLogs(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1222,6 +1795,422 @@ func Test_GetJobLogs_WithContentReturnAndLargeTailLines(t *testing.T) {
 	assert.NotContains(t, response, "logs_url")
 }
 
+func Test_GetJobLogs_WithContentReturnAndGrep(t *testing.T) {
+	logContent := "Run go test ./...\n--- FAIL: TestA\nok  pkg/a\n--- FAIL: TestB\nFAIL pkg/b\nError: Process completed with exit code 1."
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer testServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", testServer.URL)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+
+	tests := []struct {
+		name               string
+		requestArgs        map[string]any
+		expectError        bool
+		expectedErrMsg     string
+		expectedLogContent string
+		expectedMatches    float64
+	}{
+		{
+			name: "returns matching lines",
+			requestArgs: map[string]any{
+				"grep": "(?i)fail|error",
+			},
+			expectedLogContent: "--- FAIL: TestA\n--- FAIL: TestB\nFAIL pkg/b\nError: Process completed with exit code 1.",
+			expectedMatches:    4,
+		},
+		{
+			name: "tail applies to matching lines",
+			requestArgs: map[string]any{
+				"grep":       "^--- FAIL",
+				"tail_lines": float64(1),
+			},
+			expectedLogContent: "--- FAIL: TestB",
+			expectedMatches:    2,
+		},
+		{
+			name: "no matching lines",
+			requestArgs: map[string]any{
+				"grep": "panic:",
+			},
+			expectedLogContent: "",
+			expectedMatches:    0,
+		},
+		{
+			name: "invalid pattern",
+			requestArgs: map[string]any{
+				"grep": "(unclosed",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid grep pattern",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockedClient)
+			_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+			args := map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"job_id":         float64(123),
+				"return_content": true,
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+
+			assert.Equal(t, float64(6), response["original_length"])
+			assert.Equal(t, tc.expectedMatches, response["matched_lines"])
+			assert.Equal(t, tc.expectedLogContent, response["logs_content"])
+		})
+	}
+}
+
+func Test_SummarizeWorkflowRunFailure(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SummarizeWorkflowRunFailure(stubGetClientFn(mockClient), translations.NullTranslationHelper, 5000)
+
+	assert.Equal(t, "summarize_workflow_run_failure", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "excerpt_lines")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "2024-05-01T10:00:00.0000000Z ##[group]Run go test ./...\n"+
+			"2024-05-01T10:00:01.0000000Z ok  \tpkg/a\n"+
+			"2024-05-01T10:00:02.0000000Z --- FAIL: TestB (0.01s)\n"+
+			"2024-05-01T10:00:03.0000000Z     b_test.go:12: expected 1, got 2\n"+
+			"2024-05-01T10:00:04.0000000Z ##[error]Process completed with exit code 1.\n")
+	}))
+	defer logServer.Close()
+
+	run := &github.WorkflowRun{
+		ID:         github.Ptr(int64(42)),
+		Name:       github.Ptr("CI"),
+		RunNumber:  github.Ptr(7),
+		HeadBranch: github.Ptr("feature"),
+		HeadSHA:    github.Ptr("abc123"),
+		Status:     github.Ptr("completed"),
+		Conclusion: github.Ptr("failure"),
+	}
+	failedJob := &github.WorkflowJob{
+		ID:         github.Ptr(int64(1)),
+		Name:       github.Ptr("test"),
+		Conclusion: github.Ptr("failure"),
+		Steps: []*github.TaskStep{
+			{Number: github.Ptr(int64(1)), Name: github.Ptr("Checkout"), Conclusion: github.Ptr("success")},
+			{Number: github.Ptr(int64(2)), Name: github.Ptr("Test"), Conclusion: github.Ptr("failure")},
+		},
+	}
+	passedJob := &github.WorkflowJob{
+		ID:         github.Ptr(int64(2)),
+		Name:       github.Ptr("lint"),
+		Conclusion: github.Ptr("success"),
+	}
+
+	jobLogsRedirect := mock.WithRequestMatchHandler(
+		mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", logServer.URL)
+			w.WriteHeader(http.StatusFound)
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		verify         func(t *testing.T, summary map[string]any)
+	}{
+		{
+			name: "summarizes failed jobs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepoByRunId, run),
+				mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, &github.Jobs{
+					TotalCount: github.Ptr(2),
+					Jobs:       []*github.WorkflowJob{failedJob, passedJob},
+				}),
+				jobLogsRedirect,
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(42),
+			},
+			verify: func(t *testing.T, summary map[string]any) {
+				assert.Equal(t, "CI", summary["workflow"])
+				assert.Equal(t, "failure", summary["conclusion"])
+				assert.Equal(t, float64(2), summary["total_jobs"])
+				require.Len(t, summary["failed_jobs"], 1)
+				job := summary["failed_jobs"].([]any)[0].(map[string]any)
+				assert.Equal(t, "test", job["name"])
+				assert.Equal(t, []any{
+					map[string]any{"number": float64(2), "name": "Test", "conclusion": "failure"},
+				}, job["failed_steps"])
+				assert.Equal(t, []any{
+					"--- FAIL: TestB (0.01s)",
+					"##[error]Process completed with exit code 1.",
+				}, job["error_excerpt"])
+			},
+		},
+		{
+			name: "limits excerpt lines",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepoByRunId, run),
+				mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, &github.Jobs{
+					TotalCount: github.Ptr(1),
+					Jobs:       []*github.WorkflowJob{failedJob},
+				}),
+				jobLogsRedirect,
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"run_id":        float64(42),
+				"excerpt_lines": float64(1),
+			},
+			verify: func(t *testing.T, summary map[string]any) {
+				job := summary["failed_jobs"].([]any)[0].(map[string]any)
+				assert.Equal(t, []any{"##[error]Process completed with exit code 1."}, job["error_excerpt"])
+			},
+		},
+		{
+			name: "reports log errors per job",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepoByRunId, run),
+				mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, &github.Jobs{
+					TotalCount: github.Ptr(1),
+					Jobs:       []*github.WorkflowJob{failedJob},
+				}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusGone)
+						_, _ = w.Write([]byte(`{"message": "Logs have expired"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(42),
+			},
+			verify: func(t *testing.T, summary map[string]any) {
+				job := summary["failed_jobs"].([]any)[0].(map[string]any)
+				assert.Contains(t, job["log_error"], "failed to get job logs")
+				assert.NotContains(t, job, "error_excerpt")
+				assert.Len(t, job["failed_steps"], 1)
+			},
+		},
+		{
+			name: "no failed jobs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepoByRunId, run),
+				mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, &github.Jobs{
+					TotalCount: github.Ptr(1),
+					Jobs:       []*github.WorkflowJob{passedJob},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(42),
+			},
+			verify: func(t *testing.T, summary map[string]any) {
+				assert.Equal(t, "No failed jobs found in this workflow run", summary["message"])
+				assert.Nil(t, summary["failed_jobs"])
+			},
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow run",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SummarizeWorkflowRunFailure(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var summary map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+			tc.verify(t, summary)
+		})
+	}
+}
+
+func Test_WaitForWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := WaitForWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper, 5000)
+
+	assert.Equal(t, "wait_for_workflow_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "timeout_seconds")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	interval, maxInterval := workflowRunPollInterval, workflowRunMaxPollInterval
+	workflowRunPollInterval, workflowRunMaxPollInterval = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() { workflowRunPollInterval, workflowRunMaxPollInterval = interval, maxInterval })
+
+	// runAfter returns the run as in progress for the first polls, then as completed.
+	runAfter := func(polls int, conclusion string) (http.HandlerFunc, *int) {
+		calls := 0
+		return func(w http.ResponseWriter, _ *http.Request) {
+			calls++
+			run := &github.WorkflowRun{ID: github.Ptr(int64(42)), Name: github.Ptr("CI"), Status: github.Ptr("in_progress")}
+			if calls > polls {
+				run.Status = github.Ptr("completed")
+				run.Conclusion = github.Ptr(conclusion)
+			}
+			_ = json.NewEncoder(w).Encode(run)
+		}, &calls
+	}
+	requestArgs := map[string]any{
+		"owner":  "owner",
+		"repo":   "repo",
+		"run_id": float64(42),
+	}
+
+	t.Run("waits for a failed run and summarizes its failed jobs", func(t *testing.T) {
+		handler, calls := runAfter(3, "failure")
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposActionsRunsByOwnerByRepoByRunId, handler),
+			mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, &github.Jobs{
+				TotalCount: github.Ptr(2),
+				Jobs: []*github.WorkflowJob{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("lint"), Conclusion: github.Ptr("success")},
+				},
+			}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusGone)
+				}),
+			),
+		))
+		_, waitHandler := WaitForWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+		result, err := waitHandler(context.Background(), createMCPRequest(requestArgs))
+		require.NoError(t, err)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, 4, *calls)
+		assert.Equal(t, true, response["completed"])
+		assert.Equal(t, "failure", response["conclusion"])
+		assert.Equal(t, float64(2), response["total_jobs"])
+		require.Len(t, response["failed_jobs"], 1)
+		assert.Equal(t, "test", response["failed_jobs"].([]any)[0].(map[string]any)["name"])
+	})
+
+	t.Run("returns a successful run without failed jobs", func(t *testing.T) {
+		handler, _ := runAfter(0, "success")
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposActionsRunsByOwnerByRepoByRunId, handler),
+			mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, &github.Jobs{
+				TotalCount: github.Ptr(1),
+				Jobs:       []*github.WorkflowJob{{ID: github.Ptr(int64(2)), Name: github.Ptr("lint"), Conclusion: github.Ptr("success")}},
+			}),
+		))
+		_, waitHandler := WaitForWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+		result, err := waitHandler(context.Background(), createMCPRequest(requestArgs))
+		require.NoError(t, err)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, "success", response["conclusion"])
+		assert.Equal(t, []any{}, response["failed_jobs"])
+	})
+
+	t.Run("stops waiting before the call times out", func(t *testing.T) {
+		handler, _ := runAfter(math.MaxInt, "")
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetReposActionsRunsByOwnerByRepoByRunId, handler),
+		))
+		_, waitHandler := WaitForWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+		ctx, cancel := context.WithTimeout(context.Background(), workflowRunWaitMargin+50*time.Millisecond)
+		defer cancel()
+		result, err := waitHandler(ctx, createMCPRequest(requestArgs))
+		require.NoError(t, err)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, false, response["completed"])
+		assert.Equal(t, "in_progress", response["status"])
+		assert.Contains(t, response["message"], "call wait_for_workflow_run again")
+	})
+
+	t.Run("fails if the run does not exist", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsRunsByOwnerByRepoByRunId,
+				mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+			),
+		))
+		_, waitHandler := WaitForWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+		result, err := waitHandler(context.Background(), createMCPRequest(requestArgs))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get workflow run")
+	})
+}
+
 func Test_MemoryUsage_SlidingWindow_vs_NoWindow(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping memory profiling test in short mode")
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolAlias is a former name of a renamed tool, which keeps working so that clients that pin tool
+// names do not break on the rename.
+type ToolAlias struct {
+	// Name is the former name of the tool.
+	Name string
+	// Tool is the current name of the tool.
+	Tool string
+	// DeprecatedOn is the day the tool was renamed, in YYYY-MM-DD format.
+	DeprecatedOn string
+}
+
+// ToolAliases are the former names of renamed tools. When renaming a tool, add its former name
+// here rather than removing it outright, e.g.
+//
+//	{Name: "old_tool_name", Tool: "new_tool_name", DeprecatedOn: "2025-09-01"},
+var ToolAliases = []ToolAlias{}
+
+// AliasUsage counts the calls made to tools by their former names, to tell when an alias is no
+// longer used and can be removed.
+type AliasUsage struct {
+	mu      sync.Mutex
+	entries map[string]*AliasUsageEntry
+}
+
+// AliasUsageEntry is the number of calls made to a tool by one of its former names.
+type AliasUsageEntry struct {
+	Alias        string    `json:"alias"`
+	Tool         string    `json:"tool"`
+	Calls        int64     `json:"calls"`
+	LastCalledAt time.Time `json:"last_called_at"`
+}
+
+// NewAliasUsage creates an empty alias usage report.
+func NewAliasUsage() *AliasUsage {
+	return &AliasUsage{entries: make(map[string]*AliasUsageEntry)}
+}
+
+func (u *AliasUsage) record(alias ToolAlias) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	entry := u.entries[alias.Name]
+	if entry == nil {
+		entry = &AliasUsageEntry{Alias: alias.Name, Tool: alias.Tool}
+		u.entries[alias.Name] = entry
+	}
+	entry.Calls++
+	entry.LastCalledAt = time.Now()
+}
+
+// Report returns the aliases that have been called, most called first.
+func (u *AliasUsage) Report() []AliasUsageEntry {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	report := make([]AliasUsageEntry, 0, len(u.entries))
+	for _, entry := range u.entries {
+		report = append(report, *entry)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Calls != report[j].Calls {
+			return report[i].Calls > report[j].Calls
+		}
+		return report[i].Alias < report[j].Alias
+	})
+	return report
+}
+
+// AddToolAliases adds the aliases deprecated on or after cutoff to the toolsets of the tools they
+// stand for, so that they are enabled and disabled together with them. Aliases for tools that are
+// not available, such as write tools in read-only mode, are skipped. Calls made through aliases
+// are recorded in usage.
+func AddToolAliases(tsg *toolsets.ToolsetGroup, aliases []ToolAlias, cutoff time.Time, usage *AliasUsage) error {
+	for _, alias := range aliases {
+		deprecatedOn, err := time.Parse(time.DateOnly, alias.DeprecatedOn)
+		if err != nil {
+			return fmt.Errorf("invalid deprecation date of tool alias %s: %w", alias.Name, err)
+		}
+		if deprecatedOn.Before(cutoff) {
+			continue
+		}
+		for _, ts := range tsg.Toolsets {
+			target, ok := findTool(ts.GetAvailableTools(), alias.Tool)
+			if !ok {
+				continue
+			}
+			aliased := aliasTool(alias, target, usage)
+			if *target.Tool.Annotations.ReadOnlyHint {
+				ts.AddReadTools(aliased)
+			} else {
+				ts.AddWriteTools(aliased)
+			}
+		}
+	}
+	return nil
+}
+
+func findTool(tools []server.ServerTool, name string) (server.ServerTool, bool) {
+	for _, tool := range tools {
+		if tool.Tool.Name == name {
+			return tool, true
+		}
+	}
+	return server.ServerTool{}, false
+}
+
+// aliasTool serves a tool under a former name, warning callers in the result metadata.
+func aliasTool(alias ToolAlias, target server.ServerTool, usage *AliasUsage) server.ServerTool {
+	tool := target.Tool
+	tool.Name = alias.Name
+	tool.Description = fmt.Sprintf("Deprecated: use %s instead. %s", alias.Tool, target.Tool.Description)
+
+	return toolsets.NewServerTool(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		usage.record(alias)
+		result, err := target.Handler(ctx, request)
+		if result != nil {
+			setResultMeta(result, "deprecation", map[string]any{
+				"alias":         alias.Name,
+				"tool":          alias.Tool,
+				"deprecated_on": alias.DeprecatedOn,
+				"message":       fmt.Sprintf("%s has been renamed to %s and will stop working in a future release; call %s instead", alias.Name, alias.Tool, alias.Tool),
+			})
+		}
+		return result, err
+	})
+}
@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ToolAliases(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, nil, nil, nil, translations.NullTranslationHelper, 5000)
+	tools := make(map[string]bool)
+	for _, ts := range tsg.Toolsets {
+		for _, tool := range ts.GetAvailableTools() {
+			tools[tool.Tool.Name] = true
+		}
+	}
+
+	for _, alias := range ToolAliases {
+		_, err := time.Parse(time.DateOnly, alias.DeprecatedOn)
+		assert.NoError(t, err, "alias %s", alias.Name)
+		assert.True(t, tools[alias.Tool], "alias %s is for unknown tool %s", alias.Name, alias.Tool)
+		assert.False(t, tools[alias.Name], "alias %s is the name of a tool", alias.Name)
+	}
+}
+
+func toolNames(tools []server.ServerTool) []string {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Tool.Name)
+	}
+	return names
+}
+
+func Test_AddToolAliases(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetUser,
+			mockResponse(t, http.StatusOK, &github.User{Login: github.Ptr("octocat")}),
+		),
+	))
+	newToolsetGroup := func(readOnly bool) *toolsets.ToolsetGroup {
+		tsg := toolsets.NewToolsetGroup(readOnly)
+		tsg.AddToolset(toolsets.NewToolset("test", "Test tools").
+			AddReadTools(toolsets.NewServerTool(GetMe(stubGetClientFn(client), translations.NullTranslationHelper))).
+			AddWriteTools(toolsets.NewServerTool(CreateMilestone(stubGetClientFn(client), translations.NullTranslationHelper))))
+		return tsg
+	}
+	aliases := []ToolAlias{
+		{Name: "get_user", Tool: "get_me", DeprecatedOn: "2025-06-01"},
+		{Name: "new_milestone", Tool: "create_milestone", DeprecatedOn: "2025-06-01"},
+		{Name: "whoami", Tool: "get_me", DeprecatedOn: "2025-01-01"},
+		{Name: "missing", Tool: "no_such_tool", DeprecatedOn: "2025-06-01"},
+	}
+	cutoff := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("aliases tools deprecated after the cutoff", func(t *testing.T) {
+		tsg := newToolsetGroup(false)
+		require.NoError(t, AddToolAliases(tsg, aliases, cutoff, NewAliasUsage()))
+		assert.ElementsMatch(t, []string{"get_me", "get_user", "create_milestone", "new_milestone"}, toolNames(tsg.Toolsets["test"].GetAvailableTools()))
+	})
+
+	t.Run("skips aliases for tools that are not available", func(t *testing.T) {
+		tsg := newToolsetGroup(true)
+		require.NoError(t, AddToolAliases(tsg, aliases, cutoff, NewAliasUsage()))
+		assert.ElementsMatch(t, []string{"get_me", "get_user"}, toolNames(tsg.Toolsets["test"].GetAvailableTools()))
+	})
+
+	t.Run("rejects invalid deprecation dates", func(t *testing.T) {
+		tsg := newToolsetGroup(false)
+		err := AddToolAliases(tsg, []ToolAlias{{Name: "get_user", Tool: "get_me", DeprecatedOn: "June"}}, cutoff, NewAliasUsage())
+		assert.ErrorContains(t, err, "invalid deprecation date of tool alias get_user")
+	})
+
+	t.Run("calls the tool with a deprecation warning", func(t *testing.T) {
+		tsg := newToolsetGroup(false)
+		usage := NewAliasUsage()
+		require.NoError(t, AddToolAliases(tsg, aliases, time.Time{}, usage))
+
+		alias, ok := findTool(tsg.Toolsets["test"].GetAvailableTools(), "get_user")
+		require.True(t, ok)
+		assert.True(t, *alias.Tool.Annotations.ReadOnlyHint)
+		assert.Contains(t, alias.Tool.Description, "Deprecated: use get_me instead.")
+
+		for i := 0; i < 2; i++ {
+			result, err := alias.Handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+			assert.Contains(t, getTextResult(t, result).Text, "octocat")
+			require.Contains(t, result.Meta.AdditionalFields, "deprecation")
+			deprecation := result.Meta.AdditionalFields["deprecation"].(map[string]any)
+			assert.Equal(t, "get_me", deprecation["tool"])
+			assert.Equal(t, "2025-06-01", deprecation["deprecated_on"])
+		}
+
+		report := usage.Report()
+		require.Len(t, report, 1)
+		assert.Equal(t, "get_user", report[0].Alias)
+		assert.Equal(t, "get_me", report[0].Tool)
+		assert.Equal(t, int64(2), report[0].Calls)
+	})
+}
@@ -0,0 +1,270 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxAnomalyRepositories bounds the number of repositories analyzed in a single call.
+	maxAnomalyRepositories = 20
+	// maxAnomalyPullRequestPages bounds the pages of pull requests fetched per repository.
+	maxAnomalyPullRequestPages = 10
+	// minSpikeVolume avoids flagging tiny absolute increases on quiet repositories as spikes.
+	minSpikeVolume = 5
+	// minSilenceBaseline is the weekly baseline above which a week without activity is flagged.
+	minSilenceBaseline = 3
+)
+
+// Anomaly kinds reported by detect_activity_anomalies.
+const (
+	anomalySpike   = "spike"
+	anomalyDrop    = "drop"
+	anomalySilence = "silence"
+)
+
+// ActivityMetric compares one week of activity to a trailing baseline.
+type ActivityMetric struct {
+	Current        int     `json:"current"`
+	BaselineMean   float64 `json:"baseline_mean"`
+	BaselineStdDev float64 `json:"baseline_stddev"`
+	ZScore         float64 `json:"z_score"`
+	Anomaly        string  `json:"anomaly,omitempty"`
+}
+
+// RepositoryActivityReport is the per-repository output of detect_activity_anomalies.
+type RepositoryActivityReport struct {
+	Repository   string          `json:"repository"`
+	Commits      *ActivityMetric `json:"commits,omitempty"`
+	PullRequests *ActivityMetric `json:"pull_requests,omitempty"`
+	Anomalies    []string        `json:"anomalies"`
+	Errors       []string        `json:"errors,omitempty"`
+}
+
+// ActivityAnomalyReport is the output of detect_activity_anomalies.
+type ActivityAnomalyReport struct {
+	WeekStart     string                     `json:"week_start"`
+	BaselineWeeks int                        `json:"baseline_weeks"`
+	Threshold     float64                    `json:"threshold"`
+	Anomalous     []string                   `json:"anomalous_repositories"`
+	Repositories  []RepositoryActivityReport `json:"repositories"`
+}
+
+// analyzeWeeklyActivity compares the current week's volume to the baseline weeks. A week is
+// anomalous when its z-score against the baseline exceeds threshold in either direction, or when
+// a repository that is usually active has no activity at all.
+func analyzeWeeklyActivity(current int, baseline []int, threshold float64) ActivityMetric {
+	metric := ActivityMetric{Current: current}
+	if len(baseline) == 0 {
+		return metric
+	}
+
+	var sum float64
+	for _, v := range baseline {
+		sum += float64(v)
+	}
+	mean := sum / float64(len(baseline))
+
+	var variance float64
+	for _, v := range baseline {
+		variance += (float64(v) - mean) * (float64(v) - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(baseline)))
+
+	// Treat very steady baselines as having a standard deviation of at least one event per
+	// week, so that small absolute changes do not produce huge z-scores.
+	z := (float64(current) - mean) / math.Max(stddev, 1)
+
+	metric.BaselineMean = math.Round(mean*100) / 100
+	metric.BaselineStdDev = math.Round(stddev*100) / 100
+	metric.ZScore = math.Round(z*100) / 100
+
+	switch {
+	case current == 0 && mean >= minSilenceBaseline:
+		metric.Anomaly = anomalySilence
+	case z >= threshold && current >= minSpikeVolume:
+		metric.Anomaly = anomalySpike
+	case z <= -threshold:
+		metric.Anomaly = anomalyDrop
+	}
+	return metric
+}
+
+// activityWeekStart returns the start of the week (Sunday, 00:00 UTC) containing t, matching
+// the week boundaries of the repository statistics API.
+func activityWeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -int(day.Weekday()))
+}
+
+// weeklyCounts returns the counts for the week starting at currentWeek and the baselineWeeks
+// weeks before it, oldest first, from a map of week start to count.
+func weeklyCounts(byWeek map[time.Time]int, currentWeek time.Time, baselineWeeks int) (int, []int) {
+	baseline := make([]int, 0, baselineWeeks)
+	for i := baselineWeeks; i > 0; i-- {
+		baseline = append(baseline, byWeek[currentWeek.AddDate(0, 0, -7*i)])
+	}
+	return byWeek[currentWeek], baseline
+}
+
+// DetectActivityAnomalies creates a tool that flags repositories whose weekly commit or pull request
+// volume deviates sharply from their trailing baseline.
+func DetectActivityAnomalies(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("detect_activity_anomalies",
+			mcp.WithDescription(t("TOOL_DETECT_ACTIVITY_ANOMALIES_DESCRIPTION", "Compare the commit and pull request volume of repositories in the most recent complete week (Sunday to Saturday, UTC) to their trailing weekly baseline, and flag anomalies such as sudden silence, drops or spikes. Useful for spotting stuck teams or runaway bot activity.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DETECT_ACTIVITY_ANOMALIES_USER_TITLE", "Detect repository activity anomalies"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithArray("repositories",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Repositories to analyze, as 'owner/repo' (max %d)", maxAnomalyRepositories)),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("baseline_weeks",
+				mcp.Description("Number of weeks before the analyzed week to use as the baseline (default: 8)"),
+				mcp.Min(2),
+				mcp.Max(26),
+			),
+			mcp.WithNumber("threshold",
+				mcp.Description("Number of standard deviations from the baseline mean at which a week is flagged (default: 2)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			repositories, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(repositories) == 0 {
+				return mcp.NewToolResultError("missing required parameter: repositories"), nil
+			}
+			if len(repositories) > maxAnomalyRepositories {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d repositories can be analyzed at once", maxAnomalyRepositories)), nil
+			}
+			baselineWeeks, err := OptionalIntParamWithDefault(request, "baseline_weeks", 8)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if baselineWeeks < 2 || baselineWeeks > 26 {
+				return mcp.NewToolResultError("baseline_weeks must be between 2 and 26"), nil
+			}
+			threshold, err := OptionalParam[float64](request, "threshold")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if threshold <= 0 {
+				threshold = 2
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			currentWeek := activityWeekStart(time.Now()).AddDate(0, 0, -7)
+			baselineStart := currentWeek.AddDate(0, 0, -7*baselineWeeks)
+
+			report := ActivityAnomalyReport{
+				WeekStart:     currentWeek.Format("2006-01-02"),
+				BaselineWeeks: baselineWeeks,
+				Threshold:     threshold,
+				Anomalous:     []string{},
+				Repositories:  make([]RepositoryActivityReport, 0, len(repositories)),
+			}
+
+			for _, fullName := range repositories {
+				owner, repo, ok := strings.Cut(fullName, "/")
+				if !ok || owner == "" || repo == "" {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid repository %q, expected 'owner/repo'", fullName)), nil
+				}
+				repoReport := RepositoryActivityReport{Repository: fullName, Anomalies: []string{}}
+
+				// Failures for one repository are reported alongside the others rather than failing the whole report.
+				activity, resp, err := client.Repositories.ListCommitActivity(ctx, owner, repo)
+				switch {
+				case err != nil && isAcceptedError(err):
+					repoReport.Errors = append(repoReport.Errors, "commit statistics are still being computed by GitHub, retry shortly")
+				case err != nil:
+					repoReport.Errors = append(repoReport.Errors, fmt.Sprintf("failed to get commit activity: %s", err))
+				default:
+					commitsByWeek := make(map[time.Time]int, len(activity))
+					for _, week := range activity {
+						commitsByWeek[week.GetWeek().UTC()] = week.GetTotal()
+					}
+					current, baseline := weeklyCounts(commitsByWeek, currentWeek, baselineWeeks)
+					metric := analyzeWeeklyActivity(current, baseline, threshold)
+					repoReport.Commits = &metric
+				}
+				if resp != nil {
+					_ = resp.Body.Close()
+				}
+
+				prsByWeek := map[time.Time]int{}
+				opts := &github.PullRequestListOptions{
+					State:     "all",
+					Sort:      "created",
+					Direction: "desc",
+					ListOptions: github.ListOptions{
+						PerPage: 100,
+					},
+				}
+				var prErr error
+			pages:
+				for page := 0; page < maxAnomalyPullRequestPages; page++ {
+					prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+					if err != nil {
+						prErr = err
+						break
+					}
+					_ = resp.Body.Close()
+
+					for _, pr := range prs {
+						createdAt := pr.GetCreatedAt().Time
+						if createdAt.Before(baselineStart) {
+							break pages
+						}
+						prsByWeek[activityWeekStart(createdAt)]++
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					opts.ListOptions.Page = resp.NextPage
+				}
+				if prErr != nil {
+					repoReport.Errors = append(repoReport.Errors, fmt.Sprintf("failed to list pull requests: %s", prErr))
+				} else {
+					current, baseline := weeklyCounts(prsByWeek, currentWeek, baselineWeeks)
+					metric := analyzeWeeklyActivity(current, baseline, threshold)
+					repoReport.PullRequests = &metric
+				}
+
+				if repoReport.Commits != nil && repoReport.Commits.Anomaly != "" {
+					repoReport.Anomalies = append(repoReport.Anomalies, "commits_"+repoReport.Commits.Anomaly)
+				}
+				if repoReport.PullRequests != nil && repoReport.PullRequests.Anomaly != "" {
+					repoReport.Anomalies = append(repoReport.Anomalies, "pull_requests_"+repoReport.PullRequests.Anomaly)
+				}
+				if len(repoReport.Anomalies) > 0 {
+					report.Anomalous = append(report.Anomalous, fullName)
+				}
+				report.Repositories = append(report.Repositories, repoReport)
+			}
+
+			r, err := json.Marshal(report)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
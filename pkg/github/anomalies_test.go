@@ -0,0 +1,199 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AnalyzeWeeklyActivity(t *testing.T) {
+	tests := []struct {
+		name            string
+		current         int
+		baseline        []int
+		expectedAnomaly string
+	}{
+		{name: "normal week", current: 11, baseline: []int{10, 12, 9, 11}},
+		{name: "spike", current: 40, baseline: []int{10, 12, 9, 11}, expectedAnomaly: anomalySpike},
+		{name: "small spike on quiet repository", current: 3, baseline: []int{0, 0, 1, 0}},
+		{name: "drop", current: 2, baseline: []int{10, 12, 9, 11}, expectedAnomaly: anomalyDrop},
+		{name: "silence", current: 0, baseline: []int{4, 3, 5, 4}, expectedAnomaly: anomalySilence},
+		{name: "quiet repository stays quiet", current: 0, baseline: []int{0, 1, 0, 0}},
+		{name: "no baseline", current: 5, baseline: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			metric := analyzeWeeklyActivity(tc.current, tc.baseline, 2)
+			assert.Equal(t, tc.current, metric.Current)
+			assert.Equal(t, tc.expectedAnomaly, metric.Anomaly)
+		})
+	}
+
+	metric := analyzeWeeklyActivity(14, []int{10, 12, 8, 10}, 2)
+	assert.Equal(t, 10.0, metric.BaselineMean)
+	assert.Equal(t, 1.41, metric.BaselineStdDev)
+	assert.Equal(t, 2.83, metric.ZScore)
+}
+
+func Test_ActivityWeekStart(t *testing.T) {
+	// Wednesday
+	assert.Equal(t, time.Date(2024, 3, 24, 0, 0, 0, 0, time.UTC), activityWeekStart(time.Date(2024, 3, 27, 15, 4, 5, 0, time.UTC)))
+	// Sunday
+	assert.Equal(t, time.Date(2024, 3, 24, 0, 0, 0, 0, time.UTC), activityWeekStart(time.Date(2024, 3, 24, 0, 0, 0, 0, time.UTC)))
+}
+
+func Test_DetectActivityAnomalies(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DetectActivityAnomalies(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "detect_activity_anomalies", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "baseline_weeks")
+	assert.Contains(t, tool.InputSchema.Properties, "threshold")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"repositories"})
+
+	currentWeek := activityWeekStart(time.Now()).AddDate(0, 0, -7)
+	weekAgo := func(n int) time.Time {
+		return currentWeek.AddDate(0, 0, -7*n)
+	}
+
+	// Steady commit activity that goes silent in the analyzed week.
+	var commitActivity []*github.WeeklyCommitActivity
+	for i := 4; i >= 1; i-- {
+		commitActivity = append(commitActivity, &github.WeeklyCommitActivity{
+			Week:  &github.Timestamp{Time: weekAgo(i)},
+			Total: github.Ptr(10),
+		})
+	}
+	commitActivity = append(commitActivity, &github.WeeklyCommitActivity{
+		Week:  &github.Timestamp{Time: currentWeek},
+		Total: github.Ptr(0),
+	})
+
+	// A burst of pull requests in the analyzed week, newest first as returned by the API.
+	var pullRequests []*github.PullRequest
+	for i := 0; i < 20; i++ {
+		pullRequests = append(pullRequests, &github.PullRequest{
+			CreatedAt: &github.Timestamp{Time: currentWeek.Add(time.Duration(i) * time.Hour)},
+		})
+	}
+	pullRequests = append(pullRequests,
+		&github.PullRequest{CreatedAt: &github.Timestamp{Time: weekAgo(2).Add(time.Hour)}},
+		&github.PullRequest{CreatedAt: &github.Timestamp{Time: weekAgo(10)}},
+	)
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedErrMsg    string
+		expectedAnomalies []string
+		expectedErrors    []string
+	}{
+		{
+			name: "flags commit silence and pull request spike",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposStatsCommitActivityByOwnerByRepo,
+					commitActivity,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "all",
+						"sort":      "created",
+						"direction": "desc",
+						"per_page":  "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, pullRequests),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"repositories":   []interface{}{"owner/repo"},
+				"baseline_weeks": float64(4),
+			},
+			expectedAnomalies: []string{"commits_silence", "pull_requests_spike"},
+		},
+		{
+			name: "reports statistics that are still being computed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStatsCommitActivityByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusAccepted)
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepo,
+					[]*github.PullRequest{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"repositories": []interface{}{"owner/repo"},
+			},
+			expectedAnomalies: []string{},
+			expectedErrors:    []string{"commit statistics are still being computed by GitHub, retry shortly"},
+		},
+		{
+			name:         "invalid repository name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"repositories": []interface{}{"not-a-repo"},
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid repository \"not-a-repo\"",
+		},
+		{
+			name:         "missing repositories",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"repositories": []interface{}{},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DetectActivityAnomalies(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var report ActivityAnomalyReport
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &report))
+			assert.Equal(t, currentWeek.Format("2006-01-02"), report.WeekStart)
+			require.Len(t, report.Repositories, 1)
+			assert.Equal(t, tc.expectedAnomalies, report.Repositories[0].Anomalies)
+			assert.Equal(t, tc.expectedErrors, report.Repositories[0].Errors)
+			if len(tc.expectedAnomalies) > 0 {
+				assert.Equal(t, []string{"owner/repo"}, report.Anomalous)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package github
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxArchiveDownloadBytes caps how much of an archive is read before giving up.
+	maxArchiveDownloadBytes = 100 << 20
+	// maxArchiveFiles caps the number of files listed from an archive.
+	maxArchiveFiles = 1000
+	// defaultArchiveContentBytes is the default budget for file contents returned from an archive.
+	defaultArchiveContentBytes = 512 << 10
+	// maxArchiveContentBytes is the largest content budget a caller can ask for.
+	maxArchiveContentBytes = 5 << 20
+)
+
+// ArchiveFile is a file found in a repository archive. Content is only set when contents were
+// requested, the file is text and it fits in the remaining budget; otherwise Skipped says why not.
+type ArchiveFile struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	Content string `json:"content,omitempty"`
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// RepositoryArchive is the result of download_repository_archive.
+type RepositoryArchive struct {
+	Ref        string        `json:"ref,omitempty"`
+	Format     string        `json:"format"`
+	Files      []ArchiveFile `json:"files"`
+	TotalFiles int           `json:"total_files"`
+	// Truncated reports whether files were left out of the listing.
+	Truncated bool `json:"truncated"`
+}
+
+// archiveCollector gathers the files of an archive that match a path filter, reading their
+// contents until the content budget is spent.
+type archiveCollector struct {
+	pathFilter      string
+	includeContents bool
+	budget          int64
+	result          *RepositoryArchive
+}
+
+func (c *archiveCollector) add(name string, size int64, open func() (io.Reader, error)) error {
+	// Archives put everything in a "<owner>-<repo>-<sha>/" top level directory.
+	_, path, ok := strings.Cut(name, "/")
+	if !ok || path == "" {
+		return nil
+	}
+	if c.pathFilter != "" && path != c.pathFilter && !strings.HasPrefix(path, c.pathFilter+"/") {
+		return nil
+	}
+
+	c.result.TotalFiles++
+	if len(c.result.Files) == maxArchiveFiles {
+		c.result.Truncated = true
+		return nil
+	}
+
+	file := ArchiveFile{Path: path, Size: size}
+	switch {
+	case !c.includeContents:
+	case size > c.budget:
+		file.Skipped = "budget"
+	default:
+		r, err := open()
+		if err != nil {
+			return err
+		}
+		content, err := io.ReadAll(io.LimitReader(r, size))
+		if err != nil {
+			return err
+		}
+		if utf8.Valid(content) {
+			file.Content = string(content)
+			c.budget -= size
+		} else {
+			file.Skipped = "binary"
+		}
+	}
+	c.result.Files = append(c.result.Files, file)
+	return nil
+}
+
+func (c *archiveCollector) readTarball(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to decompress archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := c.add(header.Name, header.Size, func() (io.Reader, error) { return tr, nil }); err != nil {
+			return fmt.Errorf("failed to read %s from archive: %w", header.Name, err)
+		}
+	}
+}
+
+func (c *archiveCollector) readZipball(r io.Reader) error {
+	// Zip archives keep their index at the end, so the whole archive has to be buffered.
+	data, err := io.ReadAll(io.LimitReader(r, maxArchiveDownloadBytes+1))
+	if err != nil {
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+	if len(data) > maxArchiveDownloadBytes {
+		return fmt.Errorf("archive is larger than %d bytes", maxArchiveDownloadBytes)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		var rc io.ReadCloser
+		err := c.add(f.Name, int64(f.UncompressedSize64), func() (io.Reader, error) {
+			var err error
+			rc, err = f.Open()
+			return rc, err
+		})
+		if rc != nil {
+			_ = rc.Close()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s from archive: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// DownloadRepositoryArchive creates a tool to list and read many files of a repository from a
+// single archive download.
+func DownloadRepositoryArchive(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("download_repository_archive",
+			mcp.WithDescription(t("TOOL_DOWNLOAD_REPOSITORY_ARCHIVE_DESCRIPTION", "Download a tarball or zipball of a GitHub repository at a ref and list its files, optionally limited to a path. Set include_contents to also return the contents of text files within a size budget, which is cheaper than fetching many files one at a time.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DOWNLOAD_REPOSITORY_ARCHIVE_USER_TITLE", "Download repository archive"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA. Defaults to the default branch"),
+			),
+			mcp.WithString("format",
+				mcp.Description("Archive format"),
+				mcp.Enum("tarball", "zipball"),
+				mcp.DefaultString("tarball"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Only include files under this path, e.g. 'docs'"),
+			),
+			mcp.WithBoolean("include_contents",
+				mcp.Description("Return the contents of text files in addition to the listing"),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description(fmt.Sprintf("Budget in bytes for returned file contents (default %d, at most %d). Files that do not fit are listed without content", defaultArchiveContentBytes, maxArchiveContentBytes)),
+				mcp.Min(1),
+				mcp.Max(maxArchiveContentBytes),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeContents, err := OptionalParam[bool](request, "include_contents")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxBytes, err := OptionalIntParamWithDefault(request, "max_bytes", defaultArchiveContentBytes)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			archiveFormat := github.Tarball
+			switch format {
+			case "", "tarball":
+				format = "tarball"
+			case "zipball":
+				archiveFormat = github.Zipball
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid format %q, must be 'tarball' or 'zipball'", format)), nil
+			}
+			if maxBytes > maxArchiveContentBytes {
+				maxBytes = maxArchiveContentBytes
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			link, resp, err := client.Repositories.GetArchiveLink(ctx, owner, repo, archiveFormat, &github.RepositoryContentGetOptions{Ref: ref}, 1)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository archive link",
+					resp,
+					err,
+				), nil
+			}
+
+			// Archives are served from a signed URL GitHub redirects to, which must not be sent
+			// the GitHub token.
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create archive request: %w", err)
+			}
+			archiveResp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to download repository archive: %s", err)), nil
+			}
+			defer func() { _ = archiveResp.Body.Close() }()
+			if archiveResp.StatusCode != http.StatusOK {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to download repository archive: unexpected status %d", archiveResp.StatusCode)), nil
+			}
+
+			result := RepositoryArchive{
+				Ref:    ref,
+				Format: format,
+				Files:  []ArchiveFile{},
+			}
+			collector := &archiveCollector{
+				pathFilter:      strings.Trim(path, "/"),
+				includeContents: includeContents,
+				budget:          int64(maxBytes),
+				result:          &result,
+			}
+			body := io.LimitReader(archiveResp.Body, maxArchiveDownloadBytes)
+			if archiveFormat == github.Zipball {
+				err = collector.readZipball(body)
+			} else {
+				err = collector.readTarball(body)
+			}
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,200 @@
+package github
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveFiles are the files in the test archives, in archive order.
+var archiveFiles = []struct {
+	name    string
+	content []byte
+}{
+	{"owner-repo-abc123/README.md", []byte("# Repo\n")},
+	{"owner-repo-abc123/docs/guide.md", []byte("Read me first.\n")},
+	{"owner-repo-abc123/docs/logo.png", []byte{0x89, 0x50, 0x4e, 0x47, 0xff}},
+	{"owner-repo-abc123/docs/reference.md", []byte("A long reference document.\n")},
+}
+
+func testTarball(t *testing.T) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "owner-repo-abc123/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for _, f := range archiveFiles {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: f.name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(f.content))}))
+		_, err := tw.Write(f.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func testZipball(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	_, err := zw.Create("owner-repo-abc123/")
+	require.NoError(t, err)
+	for _, f := range archiveFiles {
+		w, err := zw.Create(f.name)
+		require.NoError(t, err)
+		_, err = w.Write(f.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func Test_DownloadRepositoryArchive(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DownloadRepositoryArchive(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "download_repository_archive", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "include_contents")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Archives are downloaded from the URL the API redirects to.
+	tarball, zipball := testTarball(t), testZipball(t)
+	archiveServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/tarball":
+			_, _ = w.Write(tarball)
+		case "/zipball":
+			_, _ = w.Write(zipball)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(archiveServer.Close)
+
+	redirectTo := func(path string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, archiveServer.URL+path, http.StatusFound)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedFiles  []ArchiveFile
+		expectedTotal  int
+	}{
+		{
+			name: "lists all files of a tarball",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposTarballByOwnerByRepoByRef, redirectTo("/tarball")),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectedFiles: []ArchiveFile{
+				{Path: "README.md", Size: 7},
+				{Path: "docs/guide.md", Size: 15},
+				{Path: "docs/logo.png", Size: 5},
+				{Path: "docs/reference.md", Size: 27},
+			},
+			expectedTotal: 4,
+		},
+		{
+			name: "reads contents of a path within the budget",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposTarballByOwnerByRepoByRef, redirectTo("/tarball")),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"ref":              "main",
+				"path":             "docs/",
+				"include_contents": true,
+				"max_bytes":        float64(30),
+			},
+			expectedFiles: []ArchiveFile{
+				{Path: "docs/guide.md", Size: 15, Content: "Read me first.\n"},
+				{Path: "docs/logo.png", Size: 5, Skipped: "binary"},
+				{Path: "docs/reference.md", Size: 27, Skipped: "budget"},
+			},
+			expectedTotal: 3,
+		},
+		{
+			name: "reads contents of a zipball",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposZipballByOwnerByRepoByRef, redirectTo("/zipball")),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"ref":              "v1.0.0",
+				"format":           "zipball",
+				"path":             "README.md",
+				"include_contents": true,
+			},
+			expectedFiles: []ArchiveFile{
+				{Path: "README.md", Size: 7, Content: "# Repo\n"},
+			},
+			expectedTotal: 1,
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTarballByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository archive link",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DownloadRepositoryArchive(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var archive RepositoryArchive
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &archive))
+			assert.Equal(t, tc.expectedFiles, archive.Files)
+			assert.Equal(t, tc.expectedTotal, archive.TotalFiles)
+			assert.False(t, archive.Truncated)
+		})
+	}
+}
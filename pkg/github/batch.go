@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// BatchToolset is the name of the toolset that offers batch_call.
+const BatchToolset = "batch"
+
+// BatchLimits bounds the calls made by batch_call.
+type BatchLimits struct {
+	// MaxCalls is the most calls a batch may make
+	MaxCalls int
+	// MaxConcurrency is the most calls of a batch made at the same time
+	MaxConcurrency int
+}
+
+// DefaultBatchLimits are the limits of batch_call unless configured otherwise.
+var DefaultBatchLimits = BatchLimits{MaxCalls: 50, MaxConcurrency: 4}
+
+// batchCallResult is the result of one of the calls of a batch.
+type batchCallResult struct {
+	Tool   string `json:"tool"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// AddBatchCall adds a toolset offering batch_call, which calls several enabled tools at once,
+// such as the same tool for many repositories, and returns the result of each call. The calls go
+// through wrap, if it is not nil, as calls from the client go through the middleware of the
+// server. batch_call is read-only if all tools in tsg are, and destructive if any of them is.
+func AddBatchCall(tsg *toolsets.ToolsetGroup, limits BatchLimits, wrap server.ToolHandlerMiddleware) {
+	readOnly, destructive := true, false
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			annotations := tool.Tool.Annotations
+			readOnly = readOnly && annotations.ReadOnlyHint != nil && *annotations.ReadOnlyHint
+			destructive = destructive || (annotations.DestructiveHint != nil && *annotations.DestructiveHint)
+		}
+	}
+
+	tool := toolsets.NewServerTool(batchCall(tsg, limits, wrap, readOnly, destructive))
+	toolset := toolsets.NewToolset(BatchToolset, "Call several tools at once")
+	if readOnly {
+		toolset.AddReadTools(tool)
+	} else {
+		toolset.AddWriteTools(tool)
+	}
+	tsg.AddToolset(toolset)
+	toolset.Enabled = true
+}
+
+// batchCall creates a tool that calls the enabled tools of tsg concurrently.
+func batchCall(tsg *toolsets.ToolsetGroup, limits BatchLimits, wrap server.ToolHandlerMiddleware, readOnly, destructive bool) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("batch_call",
+			mcp.WithDescription(fmt.Sprintf("Call up to %d enabled tools at once, %d at a time, such as the same tool for many repositories, saving a round trip per call. The calls are independent: they run in no particular order and do not see each other's results. Returns the result or error of each call, in the order of the calls", limits.MaxCalls, limits.MaxConcurrency)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Call several tools at once",
+				ReadOnlyHint:    ToBoolPtr(readOnly),
+				DestructiveHint: ToBoolPtr(destructive),
+			}),
+			mcp.WithArray("calls",
+				mcp.Required(),
+				mcp.Description("The tool calls to make"),
+				mcp.MinItems(1),
+				mcp.MaxItems(limits.MaxCalls),
+				mcp.Items(map[string]any{
+					"type":                 "object",
+					"additionalProperties": false,
+					"required":             []string{"tool"},
+					"properties": map[string]any{
+						"tool": map[string]any{
+							"type":        "string",
+							"description": "Name of the tool to call",
+						},
+						"arguments": map[string]any{
+							"type":        "object",
+							"description": "Arguments to call the tool with",
+						},
+					},
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls, ok := request.GetArguments()["calls"].([]any)
+			if !ok || len(calls) == 0 {
+				return mcp.NewToolResultError("missing required parameter: calls"), nil
+			}
+			if len(calls) > limits.MaxCalls {
+				return mcp.NewToolResultError(fmt.Sprintf("too many calls, at most %d can be made at once", limits.MaxCalls)), nil
+			}
+			requests := make([]mcp.CallToolRequest, len(calls))
+			for i, call := range calls {
+				fields, _ := call.(map[string]any)
+				name, _ := fields["tool"].(string)
+				if name == "" {
+					return mcp.NewToolResultError(fmt.Sprintf("call %d has no tool", i+1)), nil
+				}
+				args, ok := fields["arguments"].(map[string]any)
+				if _, given := fields["arguments"]; given && !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("arguments of call %d are not an object", i+1)), nil
+				}
+				requests[i].Params.Name = name
+				requests[i].Params.Arguments = args
+			}
+
+			// Looked up for every batch, as toolsets may be enabled while the server runs
+			enabled := make(map[string]server.ToolHandlerFunc)
+			for _, toolset := range tsg.Toolsets {
+				for _, tool := range toolset.GetActiveTools() {
+					enabled[tool.Tool.Name] = tool.Handler
+				}
+			}
+
+			results := make([]batchCallResult, len(requests))
+			sem := make(chan struct{}, max(limits.MaxConcurrency, 1))
+			var wg sync.WaitGroup
+			for i, call := range requests {
+				results[i].Tool = call.Params.Name
+				handler, ok := enabled[call.Params.Name]
+				if !ok || call.Params.Name == "batch_call" {
+					results[i].Error = fmt.Sprintf("tool %s is not enabled", call.Params.Name)
+					continue
+				}
+				if wrap != nil {
+					handler = wrap(handler)
+				}
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					select {
+					case sem <- struct{}{}:
+						defer func() { <-sem }()
+					case <-ctx.Done():
+						results[i].Error = ctx.Err().Error()
+						return
+					}
+					result, err := handler(ctx, call)
+					switch {
+					case err != nil:
+						results[i].Error = err.Error()
+					case result.IsError:
+						results[i].Error = fmt.Sprint(resultValue(result))
+					default:
+						results[i].Result = resultValue(result)
+					}
+				}()
+			}
+			wg.Wait()
+
+			failed := 0
+			for _, result := range results {
+				if result.Error != "" {
+					failed++
+				}
+			}
+			return MarshalledTextResult(map[string]any{
+				"succeeded": len(results) - failed,
+				"failed":    failed,
+				"results":   results,
+			}), nil
+		}
+}
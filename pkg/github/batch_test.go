@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddBatchCall(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetUser,
+			mockResponse(t, http.StatusOK, &github.User{Login: github.Ptr("octocat")}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposMilestonesByOwnerByRepo,
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+		),
+	))
+	newToolsetGroup := func(readOnly bool) *toolsets.ToolsetGroup {
+		tsg := toolsets.NewToolsetGroup(readOnly)
+		tsg.AddToolset(toolsets.NewToolset("test", "Test tools").
+			AddReadTools(toolsets.NewServerTool(GetMe(stubGetClientFn(client), translations.NullTranslationHelper))).
+			AddWriteTools(toolsets.NewServerTool(CreateMilestone(stubGetClientFn(client), translations.NullTranslationHelper))))
+		tsg.AddToolset(toolsets.NewToolset("disabled", "Disabled tools").
+			AddReadTools(toolsets.NewServerTool(GetTeams(stubGetClientFn(client), nil, translations.NullTranslationHelper))))
+		require.NoError(t, tsg.EnableToolset("test"))
+		return tsg
+	}
+	batchTool := func(t *testing.T, tsg *toolsets.ToolsetGroup) server.ServerTool {
+		tool, ok := findTool(tsg.Toolsets[BatchToolset].GetActiveTools(), "batch_call")
+		require.True(t, ok)
+		return tool
+	}
+
+	t.Run("annotated after the tools it may call", func(t *testing.T) {
+		tsg := newToolsetGroup(false)
+		AddBatchCall(tsg, DefaultBatchLimits, nil)
+		assert.False(t, *batchTool(t, tsg).Tool.Annotations.ReadOnlyHint)
+
+		tsg = newToolsetGroup(true)
+		AddBatchCall(tsg, DefaultBatchLimits, nil)
+		assert.True(t, *batchTool(t, tsg).Tool.Annotations.ReadOnlyHint)
+	})
+
+	t.Run("returns the result of each call", func(t *testing.T) {
+		tsg := newToolsetGroup(false)
+		var wrapped atomic.Int32
+		wrap := func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+			return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				wrapped.Add(1)
+				return next(ctx, request)
+			}
+		}
+		AddBatchCall(tsg, DefaultBatchLimits, wrap)
+
+		result, err := batchTool(t, tsg).Handler(context.Background(), createMCPRequest(map[string]any{
+			"calls": []any{
+				map[string]any{"tool": "get_me"},
+				map[string]any{"tool": "create_milestone", "arguments": map[string]any{"owner": "octo-org", "repo": "missing", "title": "v1"}},
+				map[string]any{"tool": "get_teams"},
+				map[string]any{"tool": "batch_call"},
+			},
+		}))
+		require.NoError(t, err)
+
+		var response struct {
+			Succeeded int               `json:"succeeded"`
+			Failed    int               `json:"failed"`
+			Results   []batchCallResult `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, 1, response.Succeeded)
+		assert.Equal(t, 3, response.Failed)
+		require.Len(t, response.Results, 4)
+		assert.Equal(t, "get_me", response.Results[0].Tool)
+		assert.Equal(t, "octocat", response.Results[0].Result.(map[string]any)["login"])
+		assert.Contains(t, response.Results[1].Error, "failed to create milestone")
+		assert.Equal(t, "tool get_teams is not enabled", response.Results[2].Error)
+		assert.Equal(t, "tool batch_call is not enabled", response.Results[3].Error)
+		assert.Equal(t, int32(2), wrapped.Load())
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		var running, most atomic.Int32
+		slow := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			n := running.Add(1)
+			for {
+				m := most.Load()
+				if n <= m || most.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return mcp.NewToolResultText("done"), nil
+		}
+		tsg := toolsets.NewToolsetGroup(false)
+		tsg.AddToolset(toolsets.NewToolset("test", "Test tools").
+			AddReadTools(toolsets.NewServerTool(mcp.NewTool("slow", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})), slow)))
+		require.NoError(t, tsg.EnableToolset("test"))
+		AddBatchCall(tsg, BatchLimits{MaxCalls: 10, MaxConcurrency: 2}, nil)
+
+		calls := make([]any, 8)
+		for i := range calls {
+			calls[i] = map[string]any{"tool": "slow"}
+		}
+		result, err := batchTool(t, tsg).Handler(context.Background(), createMCPRequest(map[string]any{"calls": calls}))
+		require.NoError(t, err)
+		assert.Contains(t, getTextResult(t, result).Text, `"succeeded":8`)
+		assert.Equal(t, int32(2), most.Load())
+
+		result, err = batchTool(t, tsg).Handler(context.Background(), createMCPRequest(map[string]any{"calls": append(calls, calls...)}))
+		require.NoError(t, err)
+		assert.Equal(t, "too many calls, at most 10 can be made at once", getErrorResult(t, result).Text)
+	})
+}
@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+
+	"github.com/github/github-mcp-server/pkg/blobcache"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type blobCacheCtxKey struct{}
+
+// BlobCacheMiddleware makes a blob cache available to tools, so that content addressed by a
+// SHA is only downloaded once.
+func BlobCacheMiddleware(cache *blobcache.Cache) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(context.WithValue(ctx, blobCacheCtxKey{}, cache), request)
+		}
+	}
+}
+
+func blobCacheFromContext(ctx context.Context) *blobcache.Cache {
+	cache, _ := ctx.Value(blobCacheCtxKey{}).(*blobcache.Cache)
+	return cache
+}
+
+// cachedBlob returns the content cached for a SHA in a repository, and the tier it was found in.
+// Callers must only look up SHAs they obtained with the caller's own credentials, so that the
+// cache never serves content the caller cannot access.
+func cachedBlob(ctx context.Context, owner, repo, sha string) (blobcache.Blob, blobcache.Tier, bool) {
+	cache := blobCacheFromContext(ctx)
+	if cache == nil || sha == "" {
+		return blobcache.Blob{}, "", false
+	}
+	return cache.Get(owner+"/"+repo, sha)
+}
+
+// cacheBlob caches the content for a SHA in a repository, if the server has a blob cache.
+func cacheBlob(ctx context.Context, owner, repo, sha string, content []byte, contentType string) {
+	cache := blobCacheFromContext(ctx)
+	if cache == nil || sha == "" {
+		return
+	}
+	cache.Put(owner+"/"+repo, sha, blobcache.Blob{Content: content, ContentType: contentType})
+}
+
+// setBlobCacheMeta tells the caller that a result was served from the blob cache, along with the
+// cache's metrics.
+func setBlobCacheMeta(ctx context.Context, result *mcp.CallToolResult, tier blobcache.Tier) {
+	cache := blobCacheFromContext(ctx)
+	if cache == nil {
+		return
+	}
+	setResultMeta(result, "blob_cache", map[string]any{
+		"tier":  tier,
+		"stats": cache.Stats(),
+	})
+}
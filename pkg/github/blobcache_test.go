@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/blobcache"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBlobCache(t *testing.T) context.Context {
+	t.Helper()
+	cache, err := blobcache.New(blobcache.DefaultMemoryBytes, "", 0)
+	require.NoError(t, err)
+	return context.WithValue(context.Background(), blobCacheCtxKey{}, cache)
+}
+
+func Test_GetFileContents_BlobCache(t *testing.T) {
+	ctx := newBlobCache(t)
+	rawDownloads := 0
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			mockResponse(t, http.StatusOK, &github.RepositoryContent{
+				Name: github.Ptr("README.md"),
+				Path: github.Ptr("README.md"),
+				SHA:  github.Ptr("abc123"),
+				Type: github.Ptr("file"),
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				rawDownloads++
+				w.Header().Set("Content-Type", "text/markdown")
+				_, _ = w.Write([]byte("# Hello\n"))
+			}),
+		),
+	))
+	rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+	_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]interface{}{
+		"owner": "octo",
+		"repo":  "hello",
+		"path":  "README.md",
+		"sha":   "0123456789abcdef0123456789abcdef01234567",
+	})
+	result, err := handler(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, "# Hello\n", getTextResourceResult(t, result).Text)
+	assert.Nil(t, result.Meta)
+
+	// The second request only looks up the blob SHA.
+	result, err = handler(ctx, request)
+	require.NoError(t, err)
+	resource := getTextResourceResult(t, result)
+	assert.Equal(t, "# Hello\n", resource.Text)
+	assert.Equal(t, "text/markdown", resource.MIMEType)
+	assert.Equal(t, 1, rawDownloads)
+	require.Contains(t, result.Meta.AdditionalFields, "blob_cache")
+	meta := result.Meta.AdditionalFields["blob_cache"].(map[string]any)
+	assert.Equal(t, blobcache.TierMemory, meta["tier"])
+}
+
+func Test_GetPullRequestDiff_BlobCache(t *testing.T) {
+	ctx := newBlobCache(t)
+	diffDownloads := 0
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposPullsByOwnerByRepoByPullNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept") == "application/vnd.github.v3.diff" {
+					diffDownloads++
+					_, _ = w.Write([]byte("diff --git a/README.md b/README.md\n"))
+					return
+				}
+				mockResponse(t, http.StatusOK, &github.PullRequest{
+					Number: github.Ptr(42),
+					Base:   &github.PullRequestBranch{SHA: github.Ptr("base123")},
+					Head:   &github.PullRequestBranch{SHA: github.Ptr("head456")},
+				})(w, r)
+			}),
+		),
+	))
+	_, handler := GetPullRequestDiff(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]interface{}{
+		"owner":      "octo",
+		"repo":       "hello",
+		"pullNumber": float64(42),
+	})
+	for i := 0; i < 2; i++ {
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, "diff --git a/README.md b/README.md\n", getTextResult(t, result).Text)
+		if i == 1 {
+			assert.Contains(t, result.Meta.AdditionalFields, "blob_cache")
+		}
+	}
+	assert.Equal(t, 1, diffDownloads)
+}
@@ -0,0 +1,605 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetBranchProtection creates a tool to get the classic branch protection of a branch.
+func GetBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_protection",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_PROTECTION_DESCRIPTION", "Get the classic branch protection of a branch in a GitHub repository, such as its required status checks and pull request reviews. Rulesets that apply to the branch are not included.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_PROTECTION_USER_TITLE", "Get branch protection"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			if errors.Is(err, github.ErrBranchNotProtected) {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultText(fmt.Sprintf("branch %s is not protected", branch)), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(protection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal branch protection: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateBranchProtection creates a tool to protect a branch or change its classic branch protection.
+func UpdateBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_branch_protection",
+			mcp.WithDescription(t("TOOL_UPDATE_BRANCH_PROTECTION_DESCRIPTION", "Protect a branch in a GitHub repository or change its classic branch protection. Only the given settings are changed; the others, including push and dismissal restrictions, are kept as they are.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_BRANCH_PROTECTION_USER_TITLE", "Update branch protection"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+			mcp.WithArray("required_status_checks",
+				mcp.Description("Names of the status checks that must pass before merging, replacing the current ones. Pass an empty list to stop requiring status checks"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithBoolean("strict",
+				mcp.Description("Whether branches must be up to date with the base branch before merging. Requires status checks"),
+			),
+			mcp.WithBoolean("require_pull_request",
+				mcp.Description("Whether changes must be made through pull requests"),
+			),
+			mcp.WithNumber("required_approving_review_count",
+				mcp.Description("Number of approving reviews required before merging a pull request. Requires pull requests"),
+				mcp.Min(0),
+				mcp.Max(6),
+			),
+			mcp.WithBoolean("dismiss_stale_reviews",
+				mcp.Description("Whether approving reviews are dismissed when new commits are pushed. Requires pull requests"),
+			),
+			mcp.WithBoolean("require_code_owner_reviews",
+				mcp.Description("Whether code owners must approve pull requests that change the files they own. Requires pull requests"),
+			),
+			mcp.WithBoolean("require_last_push_approval",
+				mcp.Description("Whether the most recent push must be approved by someone other than the person who pushed it. Requires pull requests"),
+			),
+			mcp.WithBoolean("enforce_admins",
+				mcp.Description("Whether the protection also applies to repository administrators"),
+			),
+			mcp.WithBoolean("required_linear_history",
+				mcp.Description("Whether merge commits are prevented from being pushed to the branch"),
+			),
+			mcp.WithBoolean("required_conversation_resolution",
+				mcp.Description("Whether all review conversations must be resolved before merging"),
+			),
+			mcp.WithBoolean("allow_force_pushes",
+				mcp.Description("Whether users with push access can force push to the branch"),
+			),
+			mcp.WithBoolean("allow_deletions",
+				mcp.Description("Whether users with push access can delete the branch"),
+			),
+			mcp.WithBoolean("lock_branch",
+				mcp.Description("Whether the branch is read-only"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API replaces the whole protection, so start from the current one to keep the
+			// settings that are not given.
+			current, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			if err != nil && !errors.Is(err, github.ErrBranchNotProtected) {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			protection := protectionRequest(current)
+			if err := applyBranchProtectionParams(request, protection); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			updated, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, protection)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal branch protection: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// protectionRequest returns a request that keeps the given branch protection as it is, or that
+// protects the branch with no requirements if it is not protected.
+func protectionRequest(current *github.Protection) *github.ProtectionRequest {
+	protection := &github.ProtectionRequest{}
+	if current == nil {
+		return protection
+	}
+
+	if checks := current.RequiredStatusChecks; checks != nil {
+		protection.RequiredStatusChecks = &github.RequiredStatusChecks{
+			Strict: checks.Strict,
+			Checks: checks.Checks,
+		}
+		if checks.Checks == nil {
+			protection.RequiredStatusChecks.Contexts = checks.Contexts
+		}
+	}
+	if reviews := current.RequiredPullRequestReviews; reviews != nil {
+		protection.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      github.Ptr(reviews.RequireLastPushApproval),
+		}
+		if dismissal := reviews.DismissalRestrictions; dismissal != nil {
+			users, teams, apps := actorNames(dismissal.Users, dismissal.Teams, dismissal.Apps)
+			protection.RequiredPullRequestReviews.DismissalRestrictionsRequest = &github.DismissalRestrictionsRequest{
+				Users: &users,
+				Teams: &teams,
+				Apps:  &apps,
+			}
+		}
+		if bypass := reviews.BypassPullRequestAllowances; bypass != nil {
+			users, teams, apps := actorNames(bypass.Users, bypass.Teams, bypass.Apps)
+			protection.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &github.BypassPullRequestAllowancesRequest{
+				Users: users,
+				Teams: teams,
+				Apps:  apps,
+			}
+		}
+	}
+	if restrictions := current.Restrictions; restrictions != nil {
+		users, teams, apps := actorNames(restrictions.Users, restrictions.Teams, restrictions.Apps)
+		protection.Restrictions = &github.BranchRestrictionsRequest{
+			Users: users,
+			Teams: teams,
+			Apps:  apps,
+		}
+	}
+
+	if current.EnforceAdmins != nil {
+		protection.EnforceAdmins = current.EnforceAdmins.Enabled
+	}
+	if current.RequireLinearHistory != nil {
+		protection.RequireLinearHistory = github.Ptr(current.RequireLinearHistory.Enabled)
+	}
+	if current.AllowForcePushes != nil {
+		protection.AllowForcePushes = github.Ptr(current.AllowForcePushes.Enabled)
+	}
+	if current.AllowDeletions != nil {
+		protection.AllowDeletions = github.Ptr(current.AllowDeletions.Enabled)
+	}
+	if current.RequiredConversationResolution != nil {
+		protection.RequiredConversationResolution = github.Ptr(current.RequiredConversationResolution.Enabled)
+	}
+	if current.BlockCreations != nil {
+		protection.BlockCreations = current.BlockCreations.Enabled
+	}
+	if current.LockBranch != nil {
+		protection.LockBranch = current.LockBranch.Enabled
+	}
+	if current.AllowForkSyncing != nil {
+		protection.AllowForkSyncing = current.AllowForkSyncing.Enabled
+	}
+	return protection
+}
+
+// actorNames returns the logins of users and the slugs of teams and apps, which is how branch
+// protection requests refer to them.
+func actorNames(users []*github.User, teams []*github.Team, apps []*github.App) ([]string, []string, []string) {
+	userNames := make([]string, 0, len(users))
+	for _, user := range users {
+		userNames = append(userNames, user.GetLogin())
+	}
+	teamNames := make([]string, 0, len(teams))
+	for _, team := range teams {
+		teamNames = append(teamNames, team.GetSlug())
+	}
+	appNames := make([]string, 0, len(apps))
+	for _, app := range apps {
+		appNames = append(appNames, app.GetSlug())
+	}
+	return userNames, teamNames, appNames
+}
+
+// applyBranchProtectionParams changes the settings given in the request.
+func applyBranchProtectionParams(request mcp.CallToolRequest, protection *github.ProtectionRequest) error {
+	if _, ok := request.GetArguments()["required_status_checks"]; ok {
+		contexts, err := OptionalStringArrayParam(request, "required_status_checks")
+		if err != nil {
+			return err
+		}
+		if len(contexts) == 0 {
+			protection.RequiredStatusChecks = nil
+		} else {
+			checks := make([]*github.RequiredStatusCheck, 0, len(contexts))
+			for _, context := range contexts {
+				checks = append(checks, &github.RequiredStatusCheck{Context: context})
+			}
+			if protection.RequiredStatusChecks == nil {
+				protection.RequiredStatusChecks = &github.RequiredStatusChecks{}
+			}
+			protection.RequiredStatusChecks.Checks = &checks
+			protection.RequiredStatusChecks.Contexts = nil
+		}
+	}
+	strict, ok, err := OptionalParamOK[bool](request, "strict")
+	if err != nil {
+		return err
+	}
+	if ok {
+		if protection.RequiredStatusChecks == nil {
+			return errors.New("strict requires required_status_checks")
+		}
+		protection.RequiredStatusChecks.Strict = strict
+	}
+
+	requirePullRequest, ok, err := OptionalParamOK[bool](request, "require_pull_request")
+	if err != nil {
+		return err
+	}
+	if ok {
+		if !requirePullRequest {
+			protection.RequiredPullRequestReviews = nil
+		} else if protection.RequiredPullRequestReviews == nil {
+			protection.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{}
+		}
+	}
+	if _, ok := request.GetArguments()["required_approving_review_count"]; ok {
+		count, err := RequiredInt(request, "required_approving_review_count")
+		if err != nil {
+			return err
+		}
+		if protection.RequiredPullRequestReviews == nil {
+			return errors.New("required_approving_review_count requires require_pull_request")
+		}
+		protection.RequiredPullRequestReviews.RequiredApprovingReviewCount = count
+	}
+	reviewSettings := []struct {
+		param string
+		set   func(value bool)
+	}{
+		{"dismiss_stale_reviews", func(value bool) { protection.RequiredPullRequestReviews.DismissStaleReviews = value }},
+		{"require_code_owner_reviews", func(value bool) { protection.RequiredPullRequestReviews.RequireCodeOwnerReviews = value }},
+		{"require_last_push_approval", func(value bool) { protection.RequiredPullRequestReviews.RequireLastPushApproval = github.Ptr(value) }},
+	}
+	for _, setting := range reviewSettings {
+		value, ok, err := OptionalParamOK[bool](request, setting.param)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if protection.RequiredPullRequestReviews == nil {
+			return fmt.Errorf("%s requires require_pull_request", setting.param)
+		}
+		setting.set(value)
+	}
+
+	enforceAdmins, ok, err := OptionalParamOK[bool](request, "enforce_admins")
+	if err != nil {
+		return err
+	}
+	if ok {
+		protection.EnforceAdmins = enforceAdmins
+	}
+	for param, field := range map[string]**bool{
+		"required_linear_history":          &protection.RequireLinearHistory,
+		"required_conversation_resolution": &protection.RequiredConversationResolution,
+		"allow_force_pushes":               &protection.AllowForcePushes,
+		"allow_deletions":                  &protection.AllowDeletions,
+		"lock_branch":                      &protection.LockBranch,
+	} {
+		value, ok, err := OptionalParamOK[bool](request, param)
+		if err != nil {
+			return err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	return nil
+}
+
+// DeleteBranchProtection creates a tool to remove the classic branch protection of a branch.
+func DeleteBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_branch_protection",
+			mcp.WithDescription(t("TOOL_DELETE_BRANCH_PROTECTION_DESCRIPTION", "Remove the classic branch protection of a branch in a GitHub repository. Rulesets that apply to the branch are not affected.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_BRANCH_PROTECTION_USER_TITLE", "Delete branch protection"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.RemoveBranchProtection(ctx, owner, repo, branch)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("protection of branch %s deleted", branch)), nil
+		}
+}
+
+// UpdateRequiredStatusChecks creates a tool to change the required status checks of a protected branch.
+func UpdateRequiredStatusChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_required_status_checks",
+			mcp.WithDescription(t("TOOL_UPDATE_REQUIRED_STATUS_CHECKS_DESCRIPTION", "Add, remove or replace the status checks that must pass before merging into a protected branch in a GitHub repository. Use update_branch_protection to protect a branch first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_REQUIRED_STATUS_CHECKS_USER_TITLE", "Update required status checks"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+			mcp.WithArray("add",
+				mcp.Description("Names of status checks to start requiring"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("remove",
+				mcp.Description("Names of status checks to stop requiring"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("checks",
+				mcp.Description("Names of the status checks to require, replacing the current ones. Cannot be combined with add or remove"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithBoolean("strict",
+				mcp.Description("Whether branches must be up to date with the base branch before merging"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			add, err := OptionalStringArrayParam(request, "add")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			remove, err := OptionalStringArrayParam(request, "remove")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checks, err := OptionalStringArrayParam(request, "checks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, replace := request.GetArguments()["checks"]
+			strict, strictSet, err := OptionalParamOK[bool](request, "strict")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if replace && (len(add) > 0 || len(remove) > 0) {
+				return mcp.NewToolResultError("checks cannot be combined with add or remove"), nil
+			}
+			if !replace && len(add) == 0 && len(remove) == 0 && !strictSet {
+				return mcp.NewToolResultError("at least one of add, remove, checks or strict must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			update := &github.RequiredStatusChecksRequest{}
+			if strictSet {
+				update.Strict = github.Ptr(strict)
+			}
+			if len(add) > 0 || len(remove) > 0 {
+				current, resp, err := client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get required status checks of branch %s", branch),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				checks = updateStatusCheckNames(current, add, remove)
+				replace = true
+			}
+			if replace && len(checks) == 0 {
+				// Requiring no checks is the same as removing the status check protection
+				resp, err := client.Repositories.RemoveRequiredStatusChecks(ctx, owner, repo, branch)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to remove required status checks of branch %s", branch),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				return mcp.NewToolResultText(fmt.Sprintf("branch %s no longer requires status checks", branch)), nil
+			}
+			for _, check := range checks {
+				update.Checks = append(update.Checks, &github.RequiredStatusCheck{Context: check})
+			}
+
+			updated, resp, err := client.Repositories.UpdateRequiredStatusChecks(ctx, owner, repo, branch, update)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update required status checks of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal required status checks: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// updateStatusCheckNames returns the names of the current required status checks with the added
+// ones appended and the removed ones left out.
+func updateStatusCheckNames(current *github.RequiredStatusChecks, add, remove []string) []string {
+	var names []string
+	switch {
+	case current.Checks != nil:
+		for _, check := range *current.Checks {
+			names = append(names, check.Context)
+		}
+	case current.Contexts != nil:
+		names = append(names, *current.Contexts...)
+	}
+
+	removed := make(map[string]bool, len(remove))
+	for _, name := range remove {
+		removed[name] = true
+	}
+	seen := make(map[string]bool, len(names)+len(add))
+	result := make([]string, 0, len(names)+len(add))
+	for _, name := range append(names, add...) {
+		if removed[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
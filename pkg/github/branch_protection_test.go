@@ -0,0 +1,519 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notProtectedHandler responds like GitHub does for branches without classic protection.
+var notProtectedHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"message": "Branch not protected"}`))
+})
+
+func Test_GetBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_branch_protection", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					github.Protection{
+						RequiredStatusChecks: &github.RequiredStatusChecks{
+							Strict: true,
+							Checks: &[]*github.RequiredStatusCheck{{Context: "ci/build"}},
+						},
+						EnforceAdmins: &github.AdminEnforcement{Enabled: true},
+					},
+				),
+			),
+			expectedText: `"context":"ci/build"`,
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					notProtectedHandler,
+				),
+			),
+			expectedText: "branch main is not protected",
+		},
+		{
+			name: "get fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Upgrade to GitHub Pro"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get protection of branch main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
+
+func Test_UpdateBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_branch_protection", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "required_status_checks")
+	assert.Contains(t, tool.InputSchema.Properties, "required_approving_review_count")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	currentProtection := github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict: true,
+			Checks: &[]*github.RequiredStatusCheck{{Context: "ci/build"}},
+		},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 1,
+			DismissalRestrictions: &github.DismissalRestrictions{
+				Teams: []*github.Team{{Slug: github.Ptr("maintainers")}},
+			},
+		},
+		EnforceAdmins: &github.AdminEnforcement{Enabled: true},
+		Restrictions: &github.BranchRestrictions{
+			Users: []*github.User{{Login: github.Ptr("octocat")}},
+		},
+		AllowForcePushes: &github.AllowForcePushes{Enabled: false},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "changes the given settings and keeps the others",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					currentProtection,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"required_status_checks": map[string]interface{}{
+							"strict": true,
+							"checks": []interface{}{
+								map[string]interface{}{"context": "ci/build"},
+							},
+						},
+						"required_pull_request_reviews": map[string]interface{}{
+							"dismissal_restrictions": map[string]interface{}{
+								"users": []interface{}{},
+								"teams": []interface{}{"maintainers"},
+								"apps":  []interface{}{},
+							},
+							"dismiss_stale_reviews":           false,
+							"require_code_owner_reviews":      true,
+							"required_approving_review_count": float64(2),
+							"require_last_push_approval":      false,
+						},
+						"enforce_admins": true,
+						"restrictions": map[string]interface{}{
+							"users": []interface{}{"octocat"},
+							"teams": []interface{}{},
+							"apps":  []interface{}{},
+						},
+						"required_linear_history": true,
+						"allow_force_pushes":      false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, currentProtection),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                           "owner",
+				"repo":                            "repo",
+				"branch":                          "main",
+				"required_approving_review_count": float64(2),
+				"require_code_owner_reviews":      true,
+				"required_linear_history":         true,
+			},
+		},
+		{
+			name: "protects an unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					notProtectedHandler,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"required_status_checks": map[string]interface{}{
+							"strict": true,
+							"checks": []interface{}{
+								map[string]interface{}{"context": "ci/test"},
+							},
+						},
+						"required_pull_request_reviews": map[string]interface{}{
+							"dismiss_stale_reviews":           false,
+							"require_code_owner_reviews":      false,
+							"required_approving_review_count": float64(0),
+						},
+						"enforce_admins": false,
+						"restrictions":   nil,
+					}).andThen(
+						mockResponse(t, http.StatusOK, github.Protection{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"branch":                 "main",
+				"required_status_checks": []interface{}{"ci/test"},
+				"strict":                 true,
+				"require_pull_request":   true,
+			},
+		},
+		{
+			name: "review settings require pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					notProtectedHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"branch":                "main",
+				"dismiss_stale_reviews": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "dismiss_stale_reviews requires require_pull_request",
+		},
+		{
+			name: "strict requires status checks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					currentProtection,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"branch":                 "main",
+				"required_status_checks": []interface{}{},
+				"strict":                 true,
+			},
+			expectError:    true,
+			expectedErrMsg: "strict requires required_status_checks",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					currentProtection,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"branch":         "main",
+				"enforce_admins": false,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update protection of branch main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var protection github.Protection
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &protection))
+		})
+	}
+}
+
+func Test_DeleteBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_branch_protection", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposBranchesProtectionByOwnerByRepoByBranch,
+					notProtectedHandler,
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to delete protection of branch main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "protection of branch main deleted", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_UpdateRequiredStatusChecks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRequiredStatusChecks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_required_status_checks", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "add")
+	assert.Contains(t, tool.InputSchema.Properties, "remove")
+	assert.Contains(t, tool.InputSchema.Properties, "checks")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	currentChecks := github.RequiredStatusChecks{
+		Strict: true,
+		Checks: &[]*github.RequiredStatusCheck{{Context: "ci/build"}, {Context: "ci/lint"}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "add and remove checks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					currentChecks,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"checks": []interface{}{
+							map[string]interface{}{"context": "ci/build"},
+							map[string]interface{}{"context": "ci/test"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, github.RequiredStatusChecks{
+							Strict: true,
+							Checks: &[]*github.RequiredStatusCheck{{Context: "ci/build"}, {Context: "ci/test"}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"add":    []interface{}{"ci/test", "ci/build"},
+				"remove": []interface{}{"ci/lint"},
+			},
+			expectedText: `"context":"ci/test"`,
+		},
+		{
+			name: "replace checks and change strictness",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]interface{}{
+						"strict": false,
+						"checks": []interface{}{
+							map[string]interface{}{"context": "ci/all"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, github.RequiredStatusChecks{
+							Checks: &[]*github.RequiredStatusCheck{{Context: "ci/all"}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"checks": []interface{}{"ci/all"},
+				"strict": false,
+			},
+			expectedText: `"context":"ci/all"`,
+		},
+		{
+			name: "requiring no checks removes the status check protection",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"checks": []interface{}{},
+			},
+			expectedText: "branch main no longer requires status checks",
+		},
+		{
+			name:         "checks cannot be combined with add",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"checks": []interface{}{"ci/all"},
+				"add":    []interface{}{"ci/test"},
+			},
+			expectError:    true,
+			expectedErrMsg: "checks cannot be combined with add or remove",
+		},
+		{
+			name:         "nothing to update",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of add, remove, checks or strict must be given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRequiredStatusChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
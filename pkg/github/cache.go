@@ -0,0 +1,33 @@
+package github
+
+import (
+	"context"
+
+	"github.com/github/github-mcp-server/pkg/cache"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CacheMetadataMiddleware reports how the GitHub API requests made by each tool call were
+// served by the response cache, in the "cache" field of the result metadata.
+func CacheMetadataMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, stats := cache.WithStats(ctx)
+			result, err := next(ctx, request)
+			if result == nil {
+				return result, err
+			}
+
+			hits, revalidated, misses := stats.Hits.Load(), stats.Revalidated.Load(), stats.Misses.Load()
+			if hits+revalidated+misses > 0 {
+				setResultMeta(result, "cache", map[string]int64{
+					"hits":        hits,
+					"revalidated": revalidated,
+					"misses":      misses,
+				})
+			}
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/cache"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CacheMetadataMiddleware(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"abc"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name": "main"}]`))
+	}))
+	defer srv.Close()
+
+	client := github.NewClient(&http.Client{Transport: cache.NewTransport(nil, time.Minute, 0)})
+	baseURL, err := client.BaseURL.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = baseURL
+
+	_, handler := ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+	handler = CacheMetadataMiddleware()(handler)
+	request := createMCPRequest(map[string]interface{}{"owner": "owner", "repo": "repo"})
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, map[string]int64{"hits": 0, "revalidated": 0, "misses": 1}, result.Meta.AdditionalFields["cache"])
+
+	result, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, map[string]int64{"hits": 1, "revalidated": 0, "misses": 0}, result.Meta.AdditionalFields["cache"])
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,626 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CreateCommitStatus creates a tool to set a commit status on a commit.
+func CreateCommitStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_status",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_STATUS_DESCRIPTION", "Set a commit status on a commit, e.g. to report the result of an external check. A new status with the same context replaces the previous one.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_COMMIT_STATUS_USER_TITLE", "Create commit status"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("State of the status"),
+				mcp.Enum("pending", "success", "failure", "error"),
+			),
+			mcp.WithString("context",
+				mcp.Description("Label that tells this status apart from the statuses of other systems. Defaults to default"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the status"),
+			),
+			mcp.WithString("target_url",
+				mcp.Description("URL with the details of the status"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			status := &github.RepoStatus{State: github.Ptr(state)}
+			for param, field := range map[string]**string{
+				"context":     &status.Context,
+				"description": &status.Description,
+				"target_url":  &status.TargetURL,
+			} {
+				value, ok, err := OptionalParamOK[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateStatus(ctx, owner, repo, sha, status)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create status for commit %s", sha),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal commit status: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListCommitStatuses creates a tool to list the commit statuses of a ref.
+func ListCommitStatuses(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_commit_statuses",
+			mcp.WithDescription(t("TOOL_LIST_COMMIT_STATUSES_DESCRIPTION", "List the commit statuses of a commit, branch or tag, newest first. Check runs, such as those of GitHub Actions, are listed by list_check_runs_for_ref instead.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COMMIT_STATUSES_USER_TITLE", "List commit statuses"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			statuses, resp, err := client.Repositories.ListStatuses(ctx, owner, repo, ref, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list statuses of %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(statuses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal commit statuses: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// ListCheckRunsForRef creates a tool to list the check runs of a ref.
+func ListCheckRunsForRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_check_runs_for_ref",
+			mcp.WithDescription(t("TOOL_LIST_CHECK_RUNS_FOR_REF_DESCRIPTION", "List the check runs of a commit, branch or tag, such as those of GitHub Actions jobs and GitHub Apps")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CHECK_RUNS_FOR_REF_USER_TITLE", "List check runs for ref"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name"),
+			),
+			mcp.WithString("check_name",
+				mcp.Description("Only list check runs with this name"),
+			),
+			mcp.WithString("status",
+				mcp.Description("Only list check runs with this status"),
+				mcp.Enum("queued", "in_progress", "completed"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("Whether to list only the latest check run of each check, or all of them. Defaults to latest"),
+				mcp.Enum("latest", "all"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListCheckRunsOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			for param, field := range map[string]**string{
+				"check_name": &opts.CheckName,
+				"status":     &opts.Status,
+				"filter":     &opts.Filter,
+			} {
+				value, err := OptionalParam[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if value != "" {
+					*field = github.Ptr(value)
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list check runs of %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(checkRuns)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal check runs: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// GetCheckRun creates a tool to get a check run.
+func GetCheckRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_check_run",
+			mcp.WithDescription(t("TOOL_GET_CHECK_RUN_DESCRIPTION", "Get a check run, including its status, conclusion and output")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CHECK_RUN_USER_TITLE", "Get check run"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("check_run_id",
+				mcp.Required(),
+				mcp.Description("The ID of the check run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkRunID, err := RequiredInt(request, "check_run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			checkRun, resp, err := client.Checks.GetCheckRun(ctx, owner, repo, int64(checkRunID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get check run %d", checkRunID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(checkRun)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal check run: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// withCheckRunParams adds the parameters shared by create_check_run and update_check_run.
+func withCheckRunParams() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("status",
+			mcp.Description("Status of the check run"),
+			mcp.Enum("queued", "in_progress", "completed"),
+		)(tool)
+		mcp.WithString("conclusion",
+			mcp.Description("Conclusion of the check run. Giving a conclusion completes the check run"),
+			mcp.Enum("success", "failure", "neutral", "cancelled", "skipped", "timed_out", "action_required"),
+		)(tool)
+		mcp.WithString("details_url",
+			mcp.Description("URL with the full details of the check"),
+		)(tool)
+		mcp.WithString("external_id",
+			mcp.Description("Reference of the check run in the system that runs it"),
+		)(tool)
+		mcp.WithString("title",
+			mcp.Description("Title of the check run output. Required with summary"),
+		)(tool)
+		mcp.WithString("summary",
+			mcp.Description("Summary of the check run output in Markdown. Required with title"),
+		)(tool)
+		mcp.WithString("text",
+			mcp.Description("Details of the check run output in Markdown"),
+		)(tool)
+		mcp.WithArray("annotations",
+			mcp.Description("Up to 50 annotations on lines of files, e.g. [{\"path\": \"main.go\", \"start_line\": 3, \"end_line\": 3, \"annotation_level\": \"failure\", \"message\": \"undefined: x\"}]. annotation_level is notice, warning or failure. Requires title and summary"),
+			mcp.Items(map[string]any{"type": "object"}),
+		)(tool)
+	}
+}
+
+// checkRunParams holds the parameters shared by create_check_run and update_check_run.
+type checkRunParams struct {
+	Status      *string
+	Conclusion  *string
+	DetailsURL  *string
+	ExternalID  *string
+	CompletedAt *github.Timestamp
+	Output      *github.CheckRunOutput
+}
+
+// checkRunParamsFromRequest reads the parameters added by withCheckRunParams.
+func checkRunParamsFromRequest(request mcp.CallToolRequest) (checkRunParams, error) {
+	var params checkRunParams
+	for param, field := range map[string]**string{
+		"status":      &params.Status,
+		"conclusion":  &params.Conclusion,
+		"details_url": &params.DetailsURL,
+		"external_id": &params.ExternalID,
+	} {
+		value, err := OptionalParam[string](request, param)
+		if err != nil {
+			return params, err
+		}
+		if value != "" {
+			*field = github.Ptr(value)
+		}
+	}
+	if params.Conclusion != nil {
+		params.CompletedAt = &github.Timestamp{Time: time.Now()}
+	}
+
+	title, err := OptionalParam[string](request, "title")
+	if err != nil {
+		return params, err
+	}
+	summary, err := OptionalParam[string](request, "summary")
+	if err != nil {
+		return params, err
+	}
+	text, err := OptionalParam[string](request, "text")
+	if err != nil {
+		return params, err
+	}
+	annotations, hasAnnotations := request.GetArguments()["annotations"]
+	if title == "" && summary == "" && text == "" && !hasAnnotations {
+		return params, nil
+	}
+	if title == "" || summary == "" {
+		return params, errors.New("title and summary are required to set the check run output")
+	}
+
+	params.Output = &github.CheckRunOutput{
+		Title:   github.Ptr(title),
+		Summary: github.Ptr(summary),
+	}
+	if text != "" {
+		params.Output.Text = github.Ptr(text)
+	}
+	if hasAnnotations {
+		params.Output.Annotations, err = checkRunAnnotations(annotations)
+		if err != nil {
+			return params, err
+		}
+	}
+	return params, nil
+}
+
+// checkRunAnnotations converts the annotations parameter of the check run tools.
+func checkRunAnnotations(value any) ([]*github.CheckRunAnnotation, error) {
+	if _, ok := value.([]any); !ok {
+		return nil, errors.New("annotations must be an array of objects")
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal annotations: %w", err)
+	}
+	var annotations []*github.CheckRunAnnotation
+	if err := json.Unmarshal(data, &annotations); err != nil {
+		return nil, fmt.Errorf("invalid annotations: %w", err)
+	}
+	if len(annotations) > 50 {
+		return nil, errors.New("at most 50 annotations can be given at once")
+	}
+	for i, annotation := range annotations {
+		if annotation == nil || annotation.Path == nil || annotation.StartLine == nil || annotation.EndLine == nil || annotation.AnnotationLevel == nil || annotation.Message == nil {
+			return nil, fmt.Errorf("annotation %d must have path, start_line, end_line, annotation_level and message", i)
+		}
+	}
+	return annotations, nil
+}
+
+// CreateCheckRun creates a tool to create a check run on a commit.
+func CreateCheckRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_check_run",
+			mcp.WithDescription(t("TOOL_CREATE_CHECK_RUN_DESCRIPTION", "Create a check run on a commit to publish the result of a check, optionally with annotations on lines of files. Only works when authenticated as a GitHub App.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_CHECK_RUN_USER_TITLE", "Create check run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the check, e.g. code-coverage"),
+			),
+			mcp.WithString("head_sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to check"),
+			),
+			withCheckRunParams(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			headSHA, err := RequiredParam[string](request, "head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params, err := checkRunParamsFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			checkRun, resp, err := client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+				Name:        name,
+				HeadSHA:     headSHA,
+				Status:      params.Status,
+				Conclusion:  params.Conclusion,
+				DetailsURL:  params.DetailsURL,
+				ExternalID:  params.ExternalID,
+				CompletedAt: params.CompletedAt,
+				Output:      params.Output,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create check run %s", name),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(checkRun)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal check run: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateCheckRun creates a tool to update a check run, e.g. to complete it.
+func UpdateCheckRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_check_run",
+			mcp.WithDescription(t("TOOL_UPDATE_CHECK_RUN_DESCRIPTION", "Update a check run, e.g. to complete it with a conclusion or add annotations. Only works when authenticated as the GitHub App that created it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_CHECK_RUN_USER_TITLE", "Update check run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("check_run_id",
+				mcp.Required(),
+				mcp.Description("The ID of the check run"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New name of the check"),
+			),
+			withCheckRunParams(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkRunID, err := RequiredInt(request, "check_run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params, err := checkRunParamsFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API requires the name on every update, so keep the current one if none is given.
+			if name == "" {
+				current, resp, err := client.Checks.GetCheckRun(ctx, owner, repo, int64(checkRunID))
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get check run %d", checkRunID),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				name = current.GetName()
+			}
+
+			checkRun, resp, err := client.Checks.UpdateCheckRun(ctx, owner, repo, int64(checkRunID), github.UpdateCheckRunOptions{
+				Name:        name,
+				Status:      params.Status,
+				Conclusion:  params.Conclusion,
+				DetailsURL:  params.DetailsURL,
+				ExternalID:  params.ExternalID,
+				CompletedAt: params.CompletedAt,
+				Output:      params.Output,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update check run %d", checkRunID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(checkRun)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal check run: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
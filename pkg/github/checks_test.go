@@ -0,0 +1,465 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateCommitStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_commit_status", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "state"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates status",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expectRequestBody(t, map[string]interface{}{
+						"state":       "success",
+						"context":     "agent/review",
+						"description": "No issues found",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepoStatus{
+							ID:      github.Ptr(int64(1)),
+							State:   github.Ptr("success"),
+							Context: github.Ptr("agent/review"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"sha":         "abc123",
+				"state":       "success",
+				"context":     "agent/review",
+				"description": "No issues found",
+			},
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: abc123"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"state": "failure",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create status for commit abc123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var status github.RepoStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, "agent/review", status.GetContext())
+		})
+	}
+}
+
+func Test_ListCommitStatuses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCommitStatuses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_commit_statuses", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposCommitsStatusesByOwnerByRepoByRef,
+			[]*github.RepoStatus{
+				{ID: github.Ptr(int64(2)), State: github.Ptr("failure"), Context: github.Ptr("ci/lint")},
+			},
+		),
+	))
+	_, handler := ListCommitStatuses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"ref":   "main",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var statuses []*github.RepoStatus
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &statuses))
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "ci/lint", statuses[0].GetContext())
+}
+
+func Test_ListCheckRunsForRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCheckRunsForRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_check_runs_for_ref", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+			expectQueryParams(t, map[string]string{
+				"check_name": "build",
+				"status":     "completed",
+				"page":       "1",
+				"per_page":   "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{
+					Total: github.Ptr(1),
+					CheckRuns: []*github.CheckRun{
+						{ID: github.Ptr(int64(5)), Name: github.Ptr("build"), Conclusion: github.Ptr("failure")},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListCheckRunsForRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"ref":        "main",
+		"check_name": "build",
+		"status":     "completed",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var checkRuns github.ListCheckRunsResults
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &checkRuns))
+	require.Len(t, checkRuns.CheckRuns, 1)
+	assert.Equal(t, "failure", checkRuns.CheckRuns[0].GetConclusion())
+}
+
+func Test_GetCheckRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCheckRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_check_run", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "gets check run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCheckRunsByOwnerByRepoByCheckRunId,
+					&github.CheckRun{ID: github.Ptr(int64(5)), Name: github.Ptr("build"), Status: github.Ptr("in_progress")},
+				),
+			),
+		},
+		{
+			name: "check run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsByOwnerByRepoByCheckRunId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get check run 5",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCheckRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(5),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var checkRun github.CheckRun
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &checkRun))
+			assert.Equal(t, "in_progress", checkRun.GetStatus())
+		})
+	}
+}
+
+func Test_CreateCheckRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCheckRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_check_run", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "annotations")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "head_sha"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates completed check run with annotations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckRunsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body map[string]any
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, "lint", body["name"])
+						assert.Equal(t, "abc123", body["head_sha"])
+						assert.Equal(t, "failure", body["conclusion"])
+						assert.NotEmpty(t, body["completed_at"], "a conclusion completes the check run")
+						assert.Equal(t, map[string]any{
+							"title":   "1 problem",
+							"summary": "Found 1 problem",
+							"annotations": []any{
+								map[string]any{
+									"path":             "main.go",
+									"start_line":       float64(3),
+									"end_line":         float64(3),
+									"annotation_level": "failure",
+									"message":          "undefined: x",
+								},
+							},
+						}, body["output"])
+						mockResponse(t, http.StatusCreated, &github.CheckRun{
+							ID:         github.Ptr(int64(9)),
+							Name:       github.Ptr("lint"),
+							Conclusion: github.Ptr("failure"),
+						})(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"name":       "lint",
+				"head_sha":   "abc123",
+				"conclusion": "failure",
+				"title":      "1 problem",
+				"summary":    "Found 1 problem",
+				"annotations": []interface{}{
+					map[string]interface{}{
+						"path":             "main.go",
+						"start_line":       float64(3),
+						"end_line":         float64(3),
+						"annotation_level": "failure",
+						"message":          "undefined: x",
+					},
+				},
+			},
+		},
+		{
+			name:         "output needs title and summary",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"name":     "lint",
+				"head_sha": "abc123",
+				"text":     "details",
+			},
+			expectError:    true,
+			expectedErrMsg: "title and summary are required to set the check run output",
+		},
+		{
+			name:         "incomplete annotation",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "lint",
+				"head_sha":    "abc123",
+				"title":       "1 problem",
+				"summary":     "Found 1 problem",
+				"annotations": []interface{}{map[string]interface{}{"path": "main.go"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "annotation 0 must have path, start_line, end_line, annotation_level and message",
+		},
+		{
+			name: "not a GitHub App",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckRunsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "You must authenticate via a GitHub App."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"name":     "lint",
+				"head_sha": "abc123",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create check run lint",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCheckRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var checkRun github.CheckRun
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &checkRun))
+			assert.Equal(t, int64(9), checkRun.GetID())
+		})
+	}
+}
+
+func Test_UpdateCheckRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateCheckRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_check_run", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "keeps the current name",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCheckRunsByOwnerByRepoByCheckRunId,
+					&github.CheckRun{ID: github.Ptr(int64(9)), Name: github.Ptr("lint")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCheckRunsByOwnerByRepoByCheckRunId,
+					expectRequestBody(t, map[string]interface{}{
+						"name":   "lint",
+						"status": "in_progress",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.CheckRun{ID: github.Ptr(int64(9)), Name: github.Ptr("lint"), Status: github.Ptr("in_progress")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(9),
+				"status":       "in_progress",
+			},
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCheckRunsByOwnerByRepoByCheckRunId,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(9),
+				"name":         "lint",
+				"status":       "in_progress",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update check run 9",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateCheckRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var checkRun github.CheckRun
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &checkRun))
+			assert.Equal(t, "in_progress", checkRun.GetStatus())
+		})
+	}
+}
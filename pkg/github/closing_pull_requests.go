@@ -0,0 +1,249 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// closingIssuesPerQuery is how many issues are looked up in one GraphQL query, as aliased
+	// fields of the repository.
+	closingIssuesPerQuery = 25
+	// closingQueryConcurrency is how many of the queries are made at the same time.
+	closingQueryConcurrency = 4
+	// maxClosingIssues is the most issues that can be looked up in one call.
+	maxClosingIssues = 200
+)
+
+type closingPullRequestNode struct {
+	Number     githubv4.Int
+	Title      githubv4.String
+	State      githubv4.String
+	URL        githubv4.String `graphql:"url"`
+	Merged     githubv4.Boolean
+	Repository struct {
+		NameWithOwner githubv4.String
+	}
+}
+
+type closingIssueNode struct {
+	Number                         githubv4.Int
+	State                          githubv4.String
+	ClosedByPullRequestsReferences struct {
+		TotalCount githubv4.Int
+		Nodes      []closingPullRequestNode
+	} `graphql:"closedByPullRequestsReferences(first: 10, includeClosedPrs: true)"`
+}
+
+type graphQLRateLimit struct {
+	Cost      githubv4.Int
+	Remaining githubv4.Int
+}
+
+// ClosingPullRequest is a pull request that closes an issue when merged, or closed it.
+type ClosingPullRequest struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	Merged     bool   `json:"merged"`
+	URL        string `json:"url"`
+	Repository string `json:"repository"`
+}
+
+// IssueClosingPullRequests are the pull requests linked to close an issue.
+type IssueClosingPullRequests struct {
+	Number       int                  `json:"number"`
+	State        string               `json:"state,omitempty"`
+	TotalCount   int                  `json:"total_count"`
+	PullRequests []ClosingPullRequest `json:"pull_requests"`
+	Error        string               `json:"error,omitempty"`
+}
+
+// closingPullRequestsQuery returns a query for the closing pull requests of issues, with each
+// issue as an aliased field of the repository, and the cost of the query.
+func closingPullRequestsQuery(numbers []int) any {
+	fields := make([]reflect.StructField, len(numbers))
+	for i, number := range numbers {
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("Issue%d", i),
+			Type: reflect.TypeOf(&closingIssueNode{}),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"issue%d: issue(number: %d)"`, number, number)),
+		}
+	}
+	query := reflect.StructOf([]reflect.StructField{
+		{
+			Name: "Repository",
+			Type: reflect.StructOf(fields),
+			Tag:  `graphql:"repository(owner: $owner, name: $repo)"`,
+		},
+		{
+			Name: "RateLimit",
+			Type: reflect.TypeOf(graphQLRateLimit{}),
+		},
+	})
+	return reflect.New(query).Interface()
+}
+
+// closingPullRequestsResult reads the issues and cost of a query made with
+// closingPullRequestsQuery. Issues that could not be found are nil.
+func closingPullRequestsResult(query any) ([]*closingIssueNode, graphQLRateLimit) {
+	v := reflect.ValueOf(query).Elem()
+	repository := v.Field(0)
+	issues := make([]*closingIssueNode, repository.NumField())
+	for i := range issues {
+		issues[i], _ = repository.Field(i).Interface().(*closingIssueNode)
+	}
+	rateLimit, _ := v.Field(1).Interface().(graphQLRateLimit)
+	return issues, rateLimit
+}
+
+// FindClosingPullRequests creates a tool to find the pull requests linked to close issues.
+func FindClosingPullRequests(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("find_closing_pull_requests",
+			mcp.WithDescription(t("TOOL_FIND_CLOSING_PULL_REQUESTS_DESCRIPTION", fmt.Sprintf("Find the pull requests linked to close each of up to %d issues of a repository, such as with 'Fixes #123', including closed ones, and whether they were merged. Issues are looked up %d per GraphQL query, so triaging many issues takes few requests. Returns the query count, time taken and GraphQL rate limit cost as stats", maxClosingIssues, closingIssuesPerQuery))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_CLOSING_PULL_REQUESTS_USER_TITLE", "Find pull requests closing issues"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Required(),
+				mcp.Description("Numbers of the issues"),
+				mcp.MinItems(1),
+				mcp.MaxItems(maxClosingIssues),
+				mcp.Items(map[string]any{"type": "number"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			numbers, err := OptionalIntArrayParam(request, "issue_numbers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			slices.Sort(numbers)
+			numbers = slices.Compact(numbers)
+			if len(numbers) == 0 {
+				return mcp.NewToolResultError("missing required parameter: issue_numbers"), nil
+			}
+			if len(numbers) > maxClosingIssues {
+				return mcp.NewToolResultError(fmt.Sprintf("too many issues, at most %d can be looked up at once", maxClosingIssues)), nil
+			}
+			if slices.ContainsFunc(numbers, func(n int) bool { return n <= 0 }) {
+				return mcp.NewToolResultError("issue numbers must be positive"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+			}
+
+			chunks := slices.Collect(slices.Chunk(numbers, closingIssuesPerQuery))
+			issues := make([][]*closingIssueNode, len(chunks))
+			costs := make([]graphQLRateLimit, len(chunks))
+			errs := make([]error, len(chunks))
+			start := time.Now()
+			sem := make(chan struct{}, closingQueryConcurrency)
+			var wg sync.WaitGroup
+			for i, chunk := range chunks {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+					query := closingPullRequestsQuery(chunk)
+					// Issues that do not exist fail the query, but the others are still returned
+					errs[i] = client.Query(ctx, query, vars)
+					issues[i], costs[i] = closingPullRequestsResult(query)
+				}()
+			}
+			wg.Wait()
+			elapsed := time.Since(start)
+
+			results := make([]IssueClosingPullRequests, 0, len(numbers))
+			found := 0
+			var firstErr error
+			for i, chunk := range chunks {
+				for j, number := range chunk {
+					issue := issues[i][j]
+					if issue == nil {
+						reason := "issue not found"
+						if errs[i] != nil {
+							reason = errs[i].Error()
+						}
+						results = append(results, IssueClosingPullRequests{Number: number, PullRequests: []ClosingPullRequest{}, Error: reason})
+						continue
+					}
+					found++
+					result := IssueClosingPullRequests{
+						Number:       number,
+						State:        string(issue.State),
+						TotalCount:   int(issue.ClosedByPullRequestsReferences.TotalCount),
+						PullRequests: make([]ClosingPullRequest, 0, len(issue.ClosedByPullRequestsReferences.Nodes)),
+					}
+					for _, pr := range issue.ClosedByPullRequestsReferences.Nodes {
+						result.PullRequests = append(result.PullRequests, ClosingPullRequest{
+							Number:     int(pr.Number),
+							Title:      string(pr.Title),
+							State:      string(pr.State),
+							Merged:     bool(pr.Merged),
+							URL:        string(pr.URL),
+							Repository: string(pr.Repository.NameWithOwner),
+						})
+					}
+					results = append(results, result)
+				}
+				if firstErr == nil {
+					firstErr = errs[i]
+				}
+			}
+			if found == 0 && firstErr != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find closing pull requests", firstErr), nil
+			}
+
+			// The queries are made concurrently, so the least remaining is the latest
+			cost, remaining := 0, 0
+			for _, c := range costs {
+				cost += int(c.Cost)
+				if c.Remaining > 0 && (remaining == 0 || int(c.Remaining) < remaining) {
+					remaining = int(c.Remaining)
+				}
+			}
+			return MarshalledTextResult(map[string]any{
+				"issues": results,
+				"stats": map[string]any{
+					"queries":              len(chunks),
+					"duration_ms":          elapsed.Milliseconds(),
+					"cost":                 cost,
+					"rate_limit_remaining": remaining,
+				},
+			}), nil
+		}
+}
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FindClosingPullRequests(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := FindClosingPullRequests(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "find_closing_pull_requests", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_numbers"})
+
+	vars := map[string]any{
+		"owner": githubv4.String("octo-org"),
+		"repo":  githubv4.String("api"),
+	}
+	issue := func(number int, state string, prs ...map[string]any) map[string]any {
+		return map[string]any{
+			"number": number,
+			"state":  state,
+			"closedByPullRequestsReferences": map[string]any{
+				"totalCount": len(prs),
+				"nodes":      prs,
+			},
+		}
+	}
+	fix := map[string]any{
+		"number":     10,
+		"title":      "Fix crash",
+		"state":      "MERGED",
+		"url":        "https://github.com/octo-org/api/pull/10",
+		"merged":     true,
+		"repository": map[string]any{"nameWithOwner": "octo-org/api"},
+	}
+
+	type response struct {
+		Issues []IssueClosingPullRequests `json:"issues"`
+		Stats  struct {
+			Queries            int `json:"queries"`
+			Cost               int `json:"cost"`
+			RateLimitRemaining int `json:"rate_limit_remaining"`
+		} `json:"stats"`
+	}
+
+	t.Run("batches issues into one query", func(t *testing.T) {
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(closingPullRequestsQuery([]int{1, 2}), vars, githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"issue1": issue(1, "CLOSED", fix),
+					"issue2": issue(2, "OPEN"),
+				},
+				"rateLimit": map[string]any{"cost": 1, "remaining": 4999},
+			})),
+		))
+		_, handler := FindClosingPullRequests(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "octo-org",
+			"repo":          "api",
+			"issue_numbers": []any{float64(2), float64(1), float64(2)},
+		}))
+		require.NoError(t, err)
+
+		var got response
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+		assert.Equal(t, []IssueClosingPullRequests{
+			{
+				Number:     1,
+				State:      "CLOSED",
+				TotalCount: 1,
+				PullRequests: []ClosingPullRequest{{
+					Number:     10,
+					Title:      "Fix crash",
+					State:      "MERGED",
+					Merged:     true,
+					URL:        "https://github.com/octo-org/api/pull/10",
+					Repository: "octo-org/api",
+				}},
+			},
+			{Number: 2, State: "OPEN", PullRequests: []ClosingPullRequest{}},
+		}, got.Issues)
+		assert.Equal(t, 1, got.Stats.Queries)
+		assert.Equal(t, 1, got.Stats.Cost)
+		assert.Equal(t, 4999, got.Stats.RateLimitRemaining)
+	})
+
+	t.Run("reports issues that do not exist", func(t *testing.T) {
+		partial := githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"issue1":     issue(1, "OPEN"),
+				"issue99999": nil,
+			},
+			"rateLimit": map[string]any{"cost": 1, "remaining": 4998},
+		})
+		partial.Errors = githubv4mock.ErrorResponse("Could not resolve to an Issue with the number of 99999.").Errors
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(closingPullRequestsQuery([]int{1, 99999}), vars, partial),
+		))
+		_, handler := FindClosingPullRequests(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "octo-org",
+			"repo":          "api",
+			"issue_numbers": []any{float64(1), float64(99999)},
+		}))
+		require.NoError(t, err)
+
+		var got response
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+		require.Len(t, got.Issues, 2)
+		assert.Empty(t, got.Issues[0].Error)
+		assert.Contains(t, got.Issues[1].Error, "Could not resolve to an Issue with the number of 99999")
+	})
+
+	t.Run("splits many issues across queries", func(t *testing.T) {
+		numbers := make([]any, closingIssuesPerQuery+1)
+		var matchers []githubv4mock.Matcher
+		for _, chunk := range [][]int{makeRange(1, closingIssuesPerQuery), {closingIssuesPerQuery + 1}} {
+			repository := map[string]any{}
+			for _, n := range chunk {
+				repository[fmt.Sprintf("issue%d", n)] = issue(n, "OPEN")
+				numbers[n-1] = float64(n)
+			}
+			matchers = append(matchers, githubv4mock.NewQueryMatcher(closingPullRequestsQuery(chunk), vars, githubv4mock.DataResponse(map[string]any{
+				"repository": repository,
+				"rateLimit":  map[string]any{"cost": 1, "remaining": 5000 - len(matchers) - 1},
+			})))
+		}
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matchers...))
+		_, handler := FindClosingPullRequests(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org", "repo": "api", "issue_numbers": numbers}))
+		require.NoError(t, err)
+
+		var got response
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &got))
+		assert.Len(t, got.Issues, closingIssuesPerQuery+1)
+		assert.Equal(t, 2, got.Stats.Queries)
+		assert.Equal(t, 2, got.Stats.Cost)
+		assert.Equal(t, 4998, got.Stats.RateLimitRemaining)
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(closingPullRequestsQuery([]int{1}), vars, githubv4mock.ErrorResponse("Could not resolve to a Repository with the name 'octo-org/api'.")),
+		))
+		_, handler := FindClosingPullRequests(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org", "repo": "api", "issue_numbers": []any{float64(1)}}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to find closing pull requests")
+	})
+}
+
+func makeRange(from, to int) []int {
+	numbers := make([]int, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
@@ -0,0 +1,357 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// repositoryPermissions are the permissions a collaborator can have on a repository, from least to most.
+var repositoryPermissions = []string{"pull", "triage", "push", "maintain", "admin"}
+
+// Collaborator is a collaborator of a repository and their access to it.
+type Collaborator struct {
+	Login string `json:"login"`
+	// RoleName is the collaborator's role, read, triage, write, maintain, admin or a custom role.
+	RoleName    string          `json:"role_name,omitempty"`
+	Permissions map[string]bool `json:"permissions,omitempty"`
+}
+
+// RepositoryInvitation is a pending invitation to collaborate on a repository.
+type RepositoryInvitation struct {
+	ID          int64             `json:"id"`
+	Invitee     string            `json:"invitee"`
+	Inviter     string            `json:"inviter,omitempty"`
+	Permissions string            `json:"permissions"`
+	CreatedAt   *github.Timestamp `json:"created_at,omitempty"`
+	Expired     bool              `json:"expired"`
+	HTMLURL     string            `json:"html_url,omitempty"`
+}
+
+func toRepositoryInvitation(invitation *github.RepositoryInvitation) RepositoryInvitation {
+	return RepositoryInvitation{
+		ID:          invitation.GetID(),
+		Invitee:     invitation.GetInvitee().GetLogin(),
+		Inviter:     invitation.GetInviter().GetLogin(),
+		Permissions: invitation.GetPermissions(),
+		CreatedAt:   invitation.CreatedAt,
+		Expired:     invitation.GetExpired(),
+		HTMLURL:     invitation.GetHTMLURL(),
+	}
+}
+
+// ListCollaborators creates a tool to list the collaborators of a repository.
+func ListCollaborators(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_collaborators",
+			mcp.WithDescription(t("TOOL_LIST_COLLABORATORS_DESCRIPTION", "List the collaborators of a repository with their role and permissions. For organization repositories this includes organization members with access through teams or base permissions, unless affiliation is direct or outside. Users with pending invitations are listed by list_invitations")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COLLABORATORS_USER_TITLE", "List repository collaborators"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("affiliation",
+				mcp.Description("Only list outside collaborators, collaborators given access directly rather than through an organization, or all collaborators. Defaults to all"),
+				mcp.Enum("outside", "direct", "all"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Only list collaborators with this permission"),
+				mcp.Enum(repositoryPermissions...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			affiliation, err := OptionalParam[string](request, "affiliation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			users, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{
+				Affiliation: affiliation,
+				Permission:  permission,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list collaborators", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			collaborators := make([]Collaborator, 0, len(users))
+			for _, user := range users {
+				collaborators = append(collaborators, Collaborator{
+					Login:       user.GetLogin(),
+					RoleName:    user.GetRoleName(),
+					Permissions: user.Permissions,
+				})
+			}
+
+			return withPageInfo(MarshalledTextResult(collaborators), restPageInfo(resp, nil)), nil
+		}
+}
+
+// AddCollaborator creates a tool to invite a user to collaborate on a repository, or to change
+// the permission of a collaborator.
+func AddCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_collaborator",
+			mcp.WithDescription(t("TOOL_ADD_COLLABORATOR_DESCRIPTION", "Invite a user to collaborate on a repository with a permission, or change the permission of an existing collaborator. Invited users get access once they accept the invitation, which expires after 7 days. Organization members are added without an invitation")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_COLLABORATOR_USER_TITLE", "Add repository collaborator"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Login of the user to add"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Permission to grant, or the name of a custom repository role. Only organization repositories have permissions other than push. Defaults to push"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitation, resp, err := client.Repositories.AddCollaborator(ctx, owner, repo, username, &github.RepositoryAddCollaboratorOptions{
+				Permission: permission,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to add %s as a collaborator", username), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// GitHub answers with no content when the user was added without an invitation
+			if resp.StatusCode == http.StatusNoContent {
+				return mcp.NewToolResultText(fmt.Sprintf("%s is a collaborator of %s/%s", username, owner, repo)), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"invitation": toRepositoryInvitation(&github.RepositoryInvitation{
+					ID:          invitation.ID,
+					Invitee:     invitation.Invitee,
+					Inviter:     invitation.Inviter,
+					Permissions: invitation.Permissions,
+					CreatedAt:   invitation.CreatedAt,
+					HTMLURL:     invitation.HTMLURL,
+				}),
+			}), nil
+		}
+}
+
+// RemoveCollaborator creates a tool to remove a collaborator from a repository.
+func RemoveCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_collaborator",
+			mcp.WithDescription(t("TOOL_REMOVE_COLLABORATOR_DESCRIPTION", "Remove a collaborator from a repository, which also removes their forks of a private repository. Access they have through an organization or its teams is not affected. To withdraw an invitation that was not accepted, use delete_invitation")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_COLLABORATOR_USER_TITLE", "Remove repository collaborator"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Login of the collaborator to remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.RemoveCollaborator(ctx, owner, repo, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to remove collaborator %s", username), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("%s removed from the collaborators of %s/%s", username, owner, repo)), nil
+		}
+}
+
+// ListInvitations creates a tool to list the pending invitations to collaborate on a repository.
+func ListInvitations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_invitations",
+			mcp.WithDescription(t("TOOL_LIST_INVITATIONS_DESCRIPTION", "List the invitations to collaborate on a repository that were not accepted yet, with the permission they grant and whether they expired")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_INVITATIONS_USER_TITLE", "List repository invitations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitations, resp, err := client.Repositories.ListInvitations(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list invitations", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]RepositoryInvitation, 0, len(invitations))
+			for _, invitation := range invitations {
+				result = append(result, toRepositoryInvitation(invitation))
+			}
+
+			return withPageInfo(MarshalledTextResult(result), restPageInfo(resp, nil)), nil
+		}
+}
+
+// DeleteInvitation creates a tool to withdraw an invitation to collaborate on a repository.
+func DeleteInvitation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_invitation",
+			mcp.WithDescription(t("TOOL_DELETE_INVITATION_DESCRIPTION", "Withdraw an invitation to collaborate on a repository that was not accepted yet")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_INVITATION_USER_TITLE", "Delete repository invitation"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("invitation_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the invitation, from list_invitations"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			invitationID, err := RequiredInt(request, "invitation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteInvitation(ctx, owner, repo, int64(invitationID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete invitation", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Invitation %d deleted", invitationID)), nil
+		}
+}
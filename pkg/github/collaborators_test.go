@@ -0,0 +1,265 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCollaborators(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCollaborators(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_collaborators", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "affiliation")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "outside collaborators",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"affiliation": "outside",
+						"permission":  "push",
+						"page":        "1",
+						"per_page":    "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.User{{
+							Login:       github.Ptr("contractor"),
+							RoleName:    github.Ptr("write"),
+							Permissions: map[string]bool{"pull": true, "push": true, "admin": false},
+						}}),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "acme", "repo": "api", "affiliation": "outside", "permission": "push"},
+		},
+		{
+			name: "no push access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have push access to view repository collaborators."}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "acme", "repo": "api"},
+			expectError:    true,
+			expectedErrMsg: "failed to list collaborators",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCollaborators(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var collaborators []Collaborator
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &collaborators))
+			assert.Equal(t, []Collaborator{{
+				Login:       "contractor",
+				RoleName:    "write",
+				Permissions: map[string]bool{"pull": true, "push": true, "admin": false},
+			}}, collaborators)
+		})
+	}
+}
+
+func Test_AddCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_collaborator", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		expected       *RepositoryInvitation
+	}{
+		{
+			name: "invites a user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{"permission": "triage"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.CollaboratorInvitation{
+							ID:          github.Ptr(int64(77)),
+							Invitee:     &github.User{Login: github.Ptr("newhire")},
+							Inviter:     &github.User{Login: github.Ptr("octocat")},
+							Permissions: github.Ptr("triage"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "acme", "repo": "api", "username": "newhire", "permission": "triage"},
+			expected:    &RepositoryInvitation{ID: 77, Invitee: "newhire", Inviter: "octocat", Permissions: "triage"},
+		},
+		{
+			name: "adds a member without an invitation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs:  map[string]any{"owner": "acme", "repo": "api", "username": "member"},
+			expectedText: "member is a collaborator of acme/api",
+		},
+		{
+			name: "unknown user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "acme", "repo": "api", "username": "ghost"},
+			expectError:    true,
+			expectedErrMsg: "failed to add ghost as a collaborator",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			text := getTextResult(t, result).Text
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, text)
+				return
+			}
+			var response struct {
+				Invitation RepositoryInvitation `json:"invitation"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(text), &response))
+			assert.Equal(t, *tc.expected, response.Invitation)
+		})
+	}
+}
+
+func Test_RemoveCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_collaborator", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposCollaboratorsByOwnerByRepoByUsername,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+	))
+	_, handler := RemoveCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "acme", "repo": "api", "username": "contractor"}))
+	require.NoError(t, err)
+	assert.Equal(t, "contractor removed from the collaborators of acme/api", getTextResult(t, result).Text)
+}
+
+func Test_ListInvitations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListInvitations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_invitations", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposInvitationsByOwnerByRepo,
+			[]*github.RepositoryInvitation{{
+				ID:          github.Ptr(int64(77)),
+				Invitee:     &github.User{Login: github.Ptr("newhire")},
+				Inviter:     &github.User{Login: github.Ptr("octocat")},
+				Permissions: github.Ptr("write"),
+				Expired:     github.Ptr(true),
+			}},
+		),
+	))
+	_, handler := ListInvitations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "acme", "repo": "api"}))
+	require.NoError(t, err)
+
+	var invitations []RepositoryInvitation
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &invitations))
+	assert.Equal(t, []RepositoryInvitation{{ID: 77, Invitee: "newhire", Inviter: "octocat", Permissions: "write", Expired: true}}, invitations)
+}
+
+func Test_DeleteInvitation(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteInvitation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_invitation", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "invitation_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposInvitationsByOwnerByRepoByInvitationId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+	))
+	_, handler := DeleteInvitation(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "acme", "repo": "api", "invitation_id": float64(77)}))
+	require.NoError(t, err)
+	assert.Equal(t, "Invitation 77 deleted", getTextResult(t, result).Text)
+}
@@ -6,6 +6,7 @@ import (
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/shurcooL/githubv4"
@@ -60,38 +61,41 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 		}
 
 		// Create minimal user representation instead of returning full user object
-		minimalUser := MinimalUser{
-			Login:      user.GetLogin(),
-			ID:         user.GetID(),
-			ProfileURL: user.GetHTMLURL(),
-			AvatarURL:  user.GetAvatarURL(),
-			Details: &UserDetails{
-				Name:              user.GetName(),
-				Company:           user.GetCompany(),
-				Blog:              user.GetBlog(),
-				Location:          user.GetLocation(),
-				Email:             user.GetEmail(),
-				Hireable:          user.GetHireable(),
-				Bio:               user.GetBio(),
-				TwitterUsername:   user.GetTwitterUsername(),
-				PublicRepos:       user.GetPublicRepos(),
-				PublicGists:       user.GetPublicGists(),
-				Followers:         user.GetFollowers(),
-				Following:         user.GetFollowing(),
-				CreatedAt:         user.GetCreatedAt().Time,
-				UpdatedAt:         user.GetUpdatedAt().Time,
-				PrivateGists:      user.GetPrivateGists(),
-				TotalPrivateRepos: user.GetTotalPrivateRepos(),
-				OwnedPrivateRepos: user.GetOwnedPrivateRepos(),
-			},
-		}
-
-		return MarshalledTextResult(minimalUser), nil
+		return MarshalledTextResult(minimalUserWithDetails(user)), nil
 	})
 
 	return tool, handler
 }
 
+// minimalUserWithDetails converts a user profile to a MinimalUser including its details.
+func minimalUserWithDetails(user *github.User) MinimalUser {
+	return MinimalUser{
+		Login:      user.GetLogin(),
+		ID:         user.GetID(),
+		ProfileURL: user.GetHTMLURL(),
+		AvatarURL:  user.GetAvatarURL(),
+		Details: &UserDetails{
+			Name:              user.GetName(),
+			Company:           user.GetCompany(),
+			Blog:              user.GetBlog(),
+			Location:          user.GetLocation(),
+			Email:             user.GetEmail(),
+			Hireable:          user.GetHireable(),
+			Bio:               user.GetBio(),
+			TwitterUsername:   user.GetTwitterUsername(),
+			PublicRepos:       user.GetPublicRepos(),
+			PublicGists:       user.GetPublicGists(),
+			Followers:         user.GetFollowers(),
+			Following:         user.GetFollowing(),
+			CreatedAt:         user.GetCreatedAt().Time,
+			UpdatedAt:         user.GetUpdatedAt().Time,
+			PrivateGists:      user.GetPrivateGists(),
+			TotalPrivateRepos: user.GetTotalPrivateRepos(),
+			OwnedPrivateRepos: user.GetOwnedPrivateRepos(),
+		},
+	}
+}
+
 type TeamInfo struct {
 	Name        string `json:"name"`
 	Slug        string `json:"slug"`
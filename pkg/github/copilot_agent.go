@@ -0,0 +1,479 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// copilotAgentSearchQualifier finds pull requests opened by the Copilot coding agent.
+	copilotAgentSearchQualifier = "author:app/copilot-swe-agent"
+	// copilotAgentRunEvent is the workflow run event of Copilot coding agent sessions.
+	copilotAgentRunEvent = "dynamic"
+	// maxCopilotAgentSessions caps the number of sessions reported for a task.
+	maxCopilotAgentSessions = 10
+)
+
+// isCopilotAgent reports whether a user is the Copilot coding agent. The REST API reports the
+// agent as "Copilot", while GraphQL and search use the app's "copilot-swe-agent" login.
+func isCopilotAgent(user *github.User) bool {
+	switch user.GetLogin() {
+	case "Copilot", "copilot-swe-agent", "copilot-swe-agent[bot]":
+		return true
+	}
+	return false
+}
+
+// CopilotAgentTask is a pull request the Copilot coding agent is working on.
+type CopilotAgentTask struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	State     string `json:"state"`
+	Draft     bool   `json:"draft"`
+	HTMLURL   string `json:"html_url"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+func copilotAgentTaskFromIssue(issue *github.Issue) CopilotAgentTask {
+	task := CopilotAgentTask{
+		Number:  issue.GetNumber(),
+		Title:   issue.GetTitle(),
+		State:   issue.GetState(),
+		Draft:   issue.GetDraft(),
+		HTMLURL: issue.GetHTMLURL(),
+	}
+	if issue.GetPullRequestLinks().MergedAt != nil {
+		task.State = "merged"
+	}
+	if issue.CreatedAt != nil {
+		task.CreatedAt = issue.GetCreatedAt().Format(time.RFC3339)
+	}
+	if issue.UpdatedAt != nil {
+		task.UpdatedAt = issue.GetUpdatedAt().Format(time.RFC3339)
+	}
+	return task
+}
+
+// CopilotAgentSession is a single Copilot coding agent session, which runs as a workflow run on
+// the pull request's branch.
+type CopilotAgentSession struct {
+	RunID      int64  `json:"run_id"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion,omitempty"`
+	HTMLURL    string `json:"html_url"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+// CopilotAgentTaskStatus is the result of get_copilot_agent_task_status.
+type CopilotAgentTaskStatus struct {
+	CopilotAgentTask
+	// Status summarizes the task: in_progress, failed, completed, merged or closed.
+	Status     string                `json:"status"`
+	HeadBranch string                `json:"head_branch"`
+	Sessions   []CopilotAgentSession `json:"sessions"`
+}
+
+// listCopilotAgentSessions lists the most recent Copilot coding agent sessions on a branch, newest first.
+func listCopilotAgentSessions(ctx context.Context, client *github.Client, owner, repo, branch string) ([]*github.WorkflowRun, *github.Response, error) {
+	runs, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+		Branch: branch,
+		Event:  copilotAgentRunEvent,
+		ListOptions: github.ListOptions{
+			PerPage: maxCopilotAgentSessions,
+		},
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return runs.WorkflowRuns, resp, nil
+}
+
+// copilotAgentTaskStatus derives the overall status of a task from its pull request and latest session.
+func copilotAgentTaskStatus(pr *github.PullRequest, latest *github.WorkflowRun) string {
+	switch {
+	case pr.GetMerged():
+		return "merged"
+	case pr.GetState() == "closed":
+		return "closed"
+	case latest != nil && latest.GetStatus() != "completed":
+		return "in_progress"
+	case latest != nil && latest.GetConclusion() != "success":
+		return "failed"
+	default:
+		return "completed"
+	}
+}
+
+// ListCopilotAgentTasks creates a tool to list the pull requests the Copilot coding agent opened in a repository.
+func ListCopilotAgentTasks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_copilot_agent_tasks",
+			mcp.WithDescription(t("TOOL_LIST_COPILOT_AGENT_TASKS_DESCRIPTION", "List the pull requests the Copilot coding agent opened in a repository, for example after assign_copilot_to_issue. Set issue_number to only list the pull requests opened for that issue. Use get_copilot_agent_task_status to check on a task.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COPILOT_AGENT_TASKS_USER_TITLE", "List Copilot coding agent tasks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Description("Only list pull requests opened for this issue"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by pull request state"),
+				mcp.Enum("open", "closed", "all"),
+				mcp.DefaultString("all"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := OptionalIntParam(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if issueNumber != 0 {
+				return copilotAgentTasksForIssue(ctx, client, owner, repo, issueNumber, state, pagination)
+			}
+
+			query := fmt.Sprintf("repo:%s/%s is:pr %s", owner, repo, copilotAgentSearchQualifier)
+			if state == "open" || state == "closed" {
+				query += " is:" + state
+			}
+			result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{
+				Sort:  "created",
+				Order: "desc",
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list Copilot coding agent tasks",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			tasks := make([]CopilotAgentTask, 0, len(result.Issues))
+			for _, issue := range result.Issues {
+				tasks = append(tasks, copilotAgentTaskFromIssue(issue))
+			}
+
+			r, err := json.Marshal(tasks)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, result.Total)), nil
+		}
+}
+
+// copilotAgentTasksForIssue lists the Copilot pull requests that cross-reference an issue, which
+// the agent does when it opens a pull request for an issue assigned to it.
+func copilotAgentTasksForIssue(ctx context.Context, client *github.Client, owner, repo string, issueNumber int, state string, pagination PaginationParams) (*mcp.CallToolResult, error) {
+	events, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, issueNumber, &github.ListOptions{
+		Page:    pagination.Page,
+		PerPage: pagination.PerPage,
+	})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to get timeline of issue %d", issueNumber),
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	repoURL := strings.ToLower(fmt.Sprintf("/repos/%s/%s/", owner, repo))
+	tasks := []CopilotAgentTask{}
+	for _, event := range events {
+		if event.GetEvent() != "cross-referenced" {
+			continue
+		}
+		issue := event.GetSource().GetIssue()
+		if issue == nil || !issue.IsPullRequest() || !isCopilotAgent(issue.GetUser()) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(issue.GetURL()), repoURL) {
+			continue
+		}
+		if (state == "open" || state == "closed") && issue.GetState() != state {
+			continue
+		}
+		tasks = append(tasks, copilotAgentTaskFromIssue(issue))
+	}
+
+	r, err := json.Marshal(tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+}
+
+// GetCopilotAgentTaskStatus creates a tool to check on a pull request the Copilot coding agent is working on.
+func GetCopilotAgentTaskStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_copilot_agent_task_status",
+			mcp.WithDescription(t("TOOL_GET_COPILOT_AGENT_TASK_STATUS_DESCRIPTION", "Get the status of a pull request the Copilot coding agent is working on, along with its recent agent sessions. Status is in_progress while a session runs, failed if the latest session did not succeed, completed when the agent is waiting for review or feedback, or merged/closed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COPILOT_AGENT_TASK_STATUS_USER_TITLE", "Get Copilot coding agent task status"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get pull request %d", pullNumber),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			if !isCopilotAgent(pr.GetUser()) {
+				return mcp.NewToolResultError(fmt.Sprintf("pull request %d was not opened by the Copilot coding agent", pullNumber)), nil
+			}
+
+			runs, resp, err := listCopilotAgentSessions(ctx, client, owner, repo, pr.GetHead().GetRef())
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list Copilot coding agent sessions",
+					resp,
+					err,
+				), nil
+			}
+
+			status := CopilotAgentTaskStatus{
+				CopilotAgentTask: CopilotAgentTask{
+					Number:  pr.GetNumber(),
+					Title:   pr.GetTitle(),
+					State:   pr.GetState(),
+					Draft:   pr.GetDraft(),
+					HTMLURL: pr.GetHTMLURL(),
+				},
+				HeadBranch: pr.GetHead().GetRef(),
+				Sessions:   make([]CopilotAgentSession, 0, len(runs)),
+			}
+			if pr.GetMerged() {
+				status.State = "merged"
+			}
+			if pr.CreatedAt != nil {
+				status.CreatedAt = pr.GetCreatedAt().Format(time.RFC3339)
+			}
+			if pr.UpdatedAt != nil {
+				status.UpdatedAt = pr.GetUpdatedAt().Format(time.RFC3339)
+			}
+			var latest *github.WorkflowRun
+			if len(runs) > 0 {
+				latest = runs[0]
+			}
+			status.Status = copilotAgentTaskStatus(pr, latest)
+			for _, run := range runs {
+				session := CopilotAgentSession{
+					RunID:      run.GetID(),
+					Status:     run.GetStatus(),
+					Conclusion: run.GetConclusion(),
+					HTMLURL:    run.GetHTMLURL(),
+				}
+				if run.CreatedAt != nil {
+					session.CreatedAt = run.GetCreatedAt().Format(time.RFC3339)
+				}
+				if run.UpdatedAt != nil {
+					session.UpdatedAt = run.GetUpdatedAt().Format(time.RFC3339)
+				}
+				status.Sessions = append(status.Sessions, session)
+			}
+
+			r, err := json.Marshal(status)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetCopilotAgentSessionLogs creates a tool to read the logs of a Copilot coding agent session.
+func GetCopilotAgentSessionLogs(getClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_copilot_agent_session_logs",
+			mcp.WithDescription(t("TOOL_GET_COPILOT_AGENT_SESSION_LOGS_DESCRIPTION", "Get the logs of a Copilot coding agent session on a pull request. Defaults to the latest session; use get_copilot_agent_task_status to find the run_id of earlier ones. Logs are only available once the session's jobs have started.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COPILOT_AGENT_SESSION_LOGS_USER_TITLE", "Get Copilot coding agent session logs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Description("Workflow run ID of the session. Defaults to the latest session"),
+			),
+			mcp.WithNumber("tail_lines",
+				mcp.Description("Number of lines to return from the end of each log"),
+				mcp.DefaultNumber(500),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := OptionalIntParam(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tailLines, err := OptionalIntParamWithDefault(request, "tail_lines", 500)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if runID == 0 {
+				pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get pull request %d", pullNumber),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				runs, resp, err := listCopilotAgentSessions(ctx, client, owner, repo, pr.GetHead().GetRef())
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list Copilot coding agent sessions",
+						resp,
+						err,
+					), nil
+				}
+				if len(runs) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("no Copilot coding agent sessions found for pull request %d", pullNumber)), nil
+				}
+				runID = int(runs[0].GetID())
+			}
+
+			jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, int64(runID), &github.ListWorkflowJobsOptions{
+				Filter: "latest",
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list session jobs", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			logs := make([]map[string]any, 0, len(jobs.Jobs))
+			for _, job := range jobs.Jobs {
+				jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), true, tailLines, nil, contentWindowSize)
+				if err != nil {
+					// Jobs that have not started yet have no logs, so report them and carry on.
+					jobResult = map[string]any{
+						"job_id":   job.GetID(),
+						"job_name": job.GetName(),
+						"status":   job.GetStatus(),
+						"error":    err.Error(),
+					}
+					_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get session logs", resp, err)
+				}
+				logs = append(logs, jobResult)
+			}
+
+			result := map[string]any{
+				"pull_number": pullNumber,
+				"run_id":      runID,
+				"logs":        logs,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,380 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCopilotAgentTasks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCopilotAgentTasks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_copilot_agent_tasks", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	copilot := &github.User{Login: github.Ptr("Copilot")}
+	copilotPR := &github.Issue{
+		Number:           github.Ptr(42),
+		Title:            github.Ptr("Fix flaky test"),
+		State:            github.Ptr("open"),
+		Draft:            github.Ptr(true),
+		HTMLURL:          github.Ptr("https://github.com/owner/repo/pull/42"),
+		URL:              github.Ptr("https://api.github.com/repos/owner/repo/issues/42"),
+		User:             copilot,
+		PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/42")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedTasks  []int
+	}{
+		{
+			name: "searches repository for Copilot pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        "repo:owner/repo is:pr author:app/copilot-swe-agent is:open",
+						"sort":     "created",
+						"order":    "desc",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+							Total:  github.Ptr(1),
+							Issues: []*github.Issue{copilotPR},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"state": "open",
+			},
+			expectedTasks: []int{42},
+		},
+		{
+			name: "lists Copilot pull requests referencing an issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusOK, []*github.Timeline{
+						{Event: github.Ptr("assigned")},
+						{Event: github.Ptr("cross-referenced"), Source: &github.Source{Issue: copilotPR}},
+						{Event: github.Ptr("cross-referenced"), Source: &github.Source{Issue: &github.Issue{
+							Number:           github.Ptr(43),
+							URL:              github.Ptr("https://api.github.com/repos/owner/repo/issues/43"),
+							User:             &github.User{Login: github.Ptr("octocat")},
+							PullRequestLinks: &github.PullRequestLinks{},
+						}}},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(7),
+			},
+			expectedTasks: []int{42},
+		},
+		{
+			name: "search fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list Copilot coding agent tasks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCopilotAgentTasks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var tasks []CopilotAgentTask
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &tasks))
+			numbers := make([]int, 0, len(tasks))
+			for _, task := range tasks {
+				numbers = append(numbers, task.Number)
+			}
+			assert.Equal(t, tc.expectedTasks, numbers)
+			assert.True(t, tasks[0].Draft)
+		})
+	}
+}
+
+func Test_GetCopilotAgentTaskStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCopilotAgentTaskStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_copilot_agent_task_status", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	copilotPR := func(state string, merged bool) *github.PullRequest {
+		return &github.PullRequest{
+			Number: github.Ptr(42),
+			State:  github.Ptr(state),
+			Merged: github.Ptr(merged),
+			Draft:  github.Ptr(true),
+			User:   &github.User{Login: github.Ptr("Copilot")},
+			Head:   &github.PullRequestBranch{Ref: github.Ptr("copilot/fix-42")},
+		}
+	}
+	sessions := func(runs ...*github.WorkflowRun) *github.WorkflowRuns {
+		return &github.WorkflowRuns{TotalCount: github.Ptr(len(runs)), WorkflowRuns: runs}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedStatus string
+		expectedRuns   int
+	}{
+		{
+			name: "session in progress",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, copilotPR("open", false)),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"branch":   "copilot/fix-42",
+						"event":    "dynamic",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, sessions(
+							&github.WorkflowRun{ID: github.Ptr(int64(2)), Status: github.Ptr("in_progress")},
+							&github.WorkflowRun{ID: github.Ptr(int64(1)), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+						)),
+					),
+				),
+			),
+			expectedStatus: "in_progress",
+			expectedRuns:   2,
+		},
+		{
+			name: "latest session failed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, copilotPR("open", false)),
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepo, sessions(
+					&github.WorkflowRun{ID: github.Ptr(int64(1)), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+				)),
+			),
+			expectedStatus: "failed",
+			expectedRuns:   1,
+		},
+		{
+			name: "merged",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, copilotPR("closed", true)),
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepo, sessions()),
+			),
+			expectedStatus: "merged",
+		},
+		{
+			name: "not a Copilot pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{
+					Number: github.Ptr(42),
+					User:   &github.User{Login: github.Ptr("octocat")},
+				}),
+			),
+			expectError:    true,
+			expectedErrMsg: "pull request 42 was not opened by the Copilot coding agent",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCopilotAgentTaskStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var status CopilotAgentTaskStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, tc.expectedStatus, status.Status)
+			assert.Equal(t, "copilot/fix-42", status.HeadBranch)
+			assert.Len(t, status.Sessions, tc.expectedRuns)
+		})
+	}
+}
+
+func Test_GetCopilotAgentSessionLogs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCopilotAgentSessionLogs(stubGetClientFn(mockClient), translations.NullTranslationHelper, 5000)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_copilot_agent_session_logs", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("Cloning repository\nRunning tests\nCommitting changes"))
+	}))
+	t.Cleanup(logServer.Close)
+
+	jobs := &github.Jobs{
+		TotalCount: github.Ptr(1),
+		Jobs: []*github.WorkflowJob{
+			{ID: github.Ptr(int64(300)), Name: github.Ptr("copilot"), Status: github.Ptr("completed")},
+		},
+	}
+	jobLogs := mock.WithRequestMatchHandler(
+		mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", logServer.URL)
+			w.WriteHeader(http.StatusFound)
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRunID  float64
+	}{
+		{
+			name: "latest session",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{
+					Head: &github.PullRequestBranch{Ref: github.Ptr("copilot/fix-42")},
+				}),
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepo, &github.WorkflowRuns{
+					TotalCount:   github.Ptr(1),
+					WorkflowRuns: []*github.WorkflowRun{{ID: github.Ptr(int64(200))}},
+				}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					expectPath(t, "/repos/owner/repo/actions/runs/200/jobs").andThen(
+						mockResponse(t, http.StatusOK, jobs),
+					),
+				),
+				jobLogs,
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedRunID: 200,
+		},
+		{
+			name: "specific session",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					expectPath(t, "/repos/owner/repo/actions/runs/100/jobs").andThen(
+						mockResponse(t, http.StatusOK, jobs),
+					),
+				),
+				jobLogs,
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"run_id":     float64(100),
+			},
+			expectedRunID: 100,
+		},
+		{
+			name: "no sessions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, &github.PullRequest{
+					Head: &github.PullRequestBranch{Ref: github.Ptr("copilot/fix-42")},
+				}),
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepo, &github.WorkflowRuns{TotalCount: github.Ptr(0)}),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "no Copilot coding agent sessions found for pull request 42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCopilotAgentSessionLogs(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedRunID, response["run_id"])
+			logs, ok := response["logs"].([]any)
+			require.True(t, ok)
+			require.Len(t, logs, 1)
+			job := logs[0].(map[string]any)
+			assert.Equal(t, "copilot", job["job_name"])
+			assert.Contains(t, job["logs_content"], "Committing changes")
+		})
+	}
+}
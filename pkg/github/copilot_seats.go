@@ -0,0 +1,402 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CopilotSeat is a Copilot seat of an organization, with its assignee reduced to a name.
+type CopilotSeat struct {
+	Assignee string `json:"assignee"`
+	// AssigneeType is User, Team or Organization.
+	AssigneeType            string            `json:"assignee_type"`
+	AssigningTeam           string            `json:"assigning_team,omitempty"`
+	PlanType                string            `json:"plan_type,omitempty"`
+	CreatedAt               *github.Timestamp `json:"created_at,omitempty"`
+	LastActivityAt          *github.Timestamp `json:"last_activity_at,omitempty"`
+	LastActivityEditor      string            `json:"last_activity_editor,omitempty"`
+	PendingCancellationDate string            `json:"pending_cancellation_date,omitempty"`
+}
+
+func toCopilotSeat(seat *github.CopilotSeatDetails) CopilotSeat {
+	result := CopilotSeat{
+		AssigningTeam:           seat.AssigningTeam.GetSlug(),
+		PlanType:                seat.GetPlanType(),
+		CreatedAt:               seat.CreatedAt,
+		LastActivityAt:          seat.LastActivityAt,
+		LastActivityEditor:      seat.GetLastActivityEditor(),
+		PendingCancellationDate: seat.GetPendingCancellationDate(),
+	}
+	if user, ok := seat.GetUser(); ok {
+		result.Assignee, result.AssigneeType = user.GetLogin(), "User"
+	} else if team, ok := seat.GetTeam(); ok {
+		result.Assignee, result.AssigneeType = team.GetSlug(), "Team"
+	} else if org, ok := seat.GetOrganization(); ok {
+		result.Assignee, result.AssigneeType = org.GetLogin(), "Organization"
+	}
+	return result
+}
+
+// CopilotUsage summarizes Copilot usage metrics, of a day or of a period.
+type CopilotUsage struct {
+	Date string `json:"date,omitempty"`
+	// ActiveUsers and EngagedUsers are the most of any day for a period.
+	ActiveUsers          int     `json:"active_users"`
+	EngagedUsers         int     `json:"engaged_users"`
+	CodeSuggestions      int     `json:"code_suggestions"`
+	CodeAcceptances      int     `json:"code_acceptances"`
+	AcceptanceRate       float64 `json:"acceptance_rate"`
+	CodeLinesSuggested   int     `json:"code_lines_suggested"`
+	CodeLinesAccepted    int     `json:"code_lines_accepted"`
+	IDEChats             int     `json:"ide_chats"`
+	DotcomChats          int     `json:"dotcom_chats"`
+	PullRequestSummaries int     `json:"pull_request_summaries"`
+}
+
+// add adds the usage of a day to the usage of a period.
+func (u *CopilotUsage) add(day CopilotUsage) {
+	u.ActiveUsers = max(u.ActiveUsers, day.ActiveUsers)
+	u.EngagedUsers = max(u.EngagedUsers, day.EngagedUsers)
+	u.CodeSuggestions += day.CodeSuggestions
+	u.CodeAcceptances += day.CodeAcceptances
+	u.CodeLinesSuggested += day.CodeLinesSuggested
+	u.CodeLinesAccepted += day.CodeLinesAccepted
+	u.IDEChats += day.IDEChats
+	u.DotcomChats += day.DotcomChats
+	u.PullRequestSummaries += day.PullRequestSummaries
+	u.setAcceptanceRate()
+}
+
+func (u *CopilotUsage) setAcceptanceRate() {
+	if u.CodeSuggestions > 0 {
+		u.AcceptanceRate = math.Round(float64(u.CodeAcceptances)/float64(u.CodeSuggestions)*1000) / 1000
+	}
+}
+
+// summarizeCopilotMetrics totals the metrics of a day, which GitHub breaks down by editor, model
+// and language.
+func summarizeCopilotMetrics(metrics *github.CopilotMetrics) CopilotUsage {
+	usage := CopilotUsage{
+		Date:         metrics.Date,
+		ActiveUsers:  metrics.GetTotalActiveUsers(),
+		EngagedUsers: metrics.GetTotalEngagedUsers(),
+	}
+	if completions := metrics.CopilotIDECodeCompletions; completions != nil {
+		for _, editor := range completions.Editors {
+			for _, model := range editor.Models {
+				for _, language := range model.Languages {
+					usage.CodeSuggestions += language.TotalCodeSuggestions
+					usage.CodeAcceptances += language.TotalCodeAcceptances
+					usage.CodeLinesSuggested += language.TotalCodeLinesSuggested
+					usage.CodeLinesAccepted += language.TotalCodeLinesAccepted
+				}
+			}
+		}
+	}
+	if chat := metrics.CopilotIDEChat; chat != nil {
+		for _, editor := range chat.Editors {
+			for _, model := range editor.Models {
+				usage.IDEChats += model.TotalChats
+			}
+		}
+	}
+	if chat := metrics.CopilotDotcomChat; chat != nil {
+		for _, model := range chat.Models {
+			usage.DotcomChats += model.TotalChats
+		}
+	}
+	if pullRequests := metrics.CopilotDotcomPullRequests; pullRequests != nil {
+		for _, repo := range pullRequests.Repositories {
+			for _, model := range repo.Models {
+				usage.PullRequestSummaries += model.TotalPRSummariesCreated
+			}
+		}
+	}
+	usage.setAcceptanceRate()
+	return usage
+}
+
+// withCopilotAssignees adds the users and teams parameters of the seat assignment tools.
+func withCopilotAssignees(action string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithArray("users",
+			mcp.Description(fmt.Sprintf("Logins of the users to %s", action)),
+			mcp.Items(map[string]any{
+				"type": "string",
+			}),
+		)(tool)
+		mcp.WithArray("teams",
+			mcp.Description(fmt.Sprintf("Slugs of the teams whose members to %s", action)),
+			mcp.Items(map[string]any{
+				"type": "string",
+			}),
+		)(tool)
+	}
+}
+
+// copilotAssigneesParams returns the parameters added by withCopilotAssignees.
+func copilotAssigneesParams(request mcp.CallToolRequest) (users, teams []string, err error) {
+	users, err = OptionalStringArrayParam(request, "users")
+	if err != nil {
+		return nil, nil, err
+	}
+	teams, err = OptionalStringArrayParam(request, "teams")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(users) == 0 && len(teams) == 0 {
+		return nil, nil, fmt.Errorf("at least one of users and teams is required")
+	}
+	return users, teams, nil
+}
+
+// ListCopilotSeats creates a tool to list the Copilot seats of an organization.
+func ListCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_copilot_seats",
+			mcp.WithDescription(t("TOOL_LIST_COPILOT_SEATS_DESCRIPTION", "List the Copilot seats of an organization, with who each is assigned to, the team it was assigned through, when its user was last active and in which editor, and whether it is pending cancellation. Requires organization owner access")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COPILOT_SEATS_USER_TITLE", "List Copilot seats"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			seats, resp, err := client.Copilot.ListCopilotSeats(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list Copilot seats", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]CopilotSeat, 0, len(seats.Seats))
+			for _, seat := range seats.Seats {
+				result = append(result, toCopilotSeat(seat))
+			}
+			totalSeats := int(seats.TotalSeats)
+
+			return withPageInfo(MarshalledTextResult(map[string]any{
+				"total_seats": totalSeats,
+				"seats":       result,
+			}), restPageInfo(resp, &totalSeats)), nil
+		}
+}
+
+// AddCopilotSeats creates a tool to assign Copilot seats to users and teams of an organization.
+func AddCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_copilot_seats",
+			mcp.WithDescription(t("TOOL_ADD_COPILOT_SEATS_DESCRIPTION", "Assign Copilot seats of an organization to users, and to the members of teams. The organization is billed for each new seat. Requires organization owner access and a Copilot Business or Enterprise plan whose seat management is set to assign seats to selected users")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_COPILOT_SEATS_USER_TITLE", "Add Copilot seats"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			withCopilotAssignees("assign seats to"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			users, teams, err := copilotAssigneesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created := 0
+			if len(users) > 0 {
+				assignments, resp, err := client.Copilot.AddCopilotUsers(ctx, org, users)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add Copilot seats for users", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				created += assignments.SeatsCreated
+			}
+			if len(teams) > 0 {
+				assignments, resp, err := client.Copilot.AddCopilotTeams(ctx, org, teams)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add Copilot seats for teams", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				created += assignments.SeatsCreated
+			}
+
+			return MarshalledTextResult(github.SeatAssignments{SeatsCreated: created}), nil
+		}
+}
+
+// RemoveCopilotSeats creates a tool to cancel the Copilot seats of users and teams of an organization.
+func RemoveCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_copilot_seats",
+			mcp.WithDescription(t("TOOL_REMOVE_COPILOT_SEATS_DESCRIPTION", "Cancel the Copilot seats of an organization assigned to users, or to the members of teams. Seats stay usable until the end of the billing cycle, and users keep seats assigned to them through other teams. Requires organization owner access")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_COPILOT_SEATS_USER_TITLE", "Remove Copilot seats"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			withCopilotAssignees("cancel the seats of"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			users, teams, err := copilotAssigneesParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			cancelled := 0
+			if len(users) > 0 {
+				cancellations, resp, err := client.Copilot.RemoveCopilotUsers(ctx, org, users)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove Copilot seats of users", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				cancelled += cancellations.SeatsCancelled
+			}
+			if len(teams) > 0 {
+				cancellations, resp, err := client.Copilot.RemoveCopilotTeams(ctx, org, teams)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove Copilot seats of teams", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				cancelled += cancellations.SeatsCancelled
+			}
+
+			return MarshalledTextResult(github.SeatCancellations{SeatsCancelled: cancelled}), nil
+		}
+}
+
+// GetCopilotMetrics creates a tool to summarize the Copilot usage of an organization or team.
+func GetCopilotMetrics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_copilot_metrics",
+			mcp.WithDescription(t("TOOL_GET_COPILOT_METRICS_DESCRIPTION", "Summarize the Copilot usage of an organization, or of one of its teams, per day and over the period: active and engaged users, code suggestions and acceptances in editors, chats in editors and on GitHub, and pull request summaries. Covers up to the last 28 days, and only days on which at least five users had seats")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COPILOT_METRICS_USER_TITLE", "Get Copilot metrics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team",
+				mcp.Description("Slug of a team to get the usage of its members only"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Start of the period, as YYYY-MM-DD or an ISO 8601 timestamp. Defaults to 28 days ago"),
+			),
+			mcp.WithString("until",
+				mcp.Description("End of the period, as YYYY-MM-DD or an ISO 8601 timestamp. Defaults to today"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			team, err := OptionalParam[string](request, "team")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts := &github.CopilotMetricsListOptions{
+				// A page holds all 28 days
+				ListOptions: github.ListOptions{PerPage: 28},
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if since != "" {
+				sinceTime, err := parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since: %s", err)), nil
+				}
+				opts.Since = &sinceTime
+			}
+			until, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if until != "" {
+				untilTime, err := parseISOTimestamp(until)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid until: %s", err)), nil
+				}
+				opts.Until = &untilTime
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var metrics []*github.CopilotMetrics
+			var resp *github.Response
+			if team != "" {
+				metrics, resp, err = client.Copilot.GetOrganizationTeamMetrics(ctx, org, team, opts)
+			} else {
+				metrics, resp, err = client.Copilot.GetOrganizationMetrics(ctx, org, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get Copilot metrics", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			days := make([]CopilotUsage, 0, len(metrics))
+			var total CopilotUsage
+			for _, day := range metrics {
+				usage := summarizeCopilotMetrics(day)
+				days = append(days, usage)
+				total.add(usage)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"total": total,
+				"days":  days,
+			}), nil
+		}
+}
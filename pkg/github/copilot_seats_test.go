@@ -0,0 +1,333 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_copilot_seats", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	// Seat assignees are decoded by their type, so the response is given as GitHub sends it
+	seats := map[string]any{
+		"total_seats": 2,
+		"seats": []map[string]any{
+			{
+				"assignee":             map[string]any{"login": "octocat", "type": "User"},
+				"assigning_team":       map[string]any{"slug": "platform"},
+				"plan_type":            "business",
+				"created_at":           "2025-01-01T00:00:00Z",
+				"last_activity_at":     "2025-03-01T12:00:00Z",
+				"last_activity_editor": "vscode/1.98.0",
+			},
+			{
+				"assignee":                  map[string]any{"slug": "security", "type": "Team"},
+				"created_at":                "2025-01-01T00:00:00Z",
+				"pending_cancellation_date": "2025-04-01",
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "lists seats",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, seats),
+					),
+				),
+			),
+			requestArgs: map[string]any{"org": "acme"},
+		},
+		{
+			name: "not an owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must be an org owner"}),
+				),
+			),
+			requestArgs:    map[string]any{"org": "acme"},
+			expectError:    true,
+			expectedErrMsg: "failed to list Copilot seats",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				TotalSeats int           `json:"total_seats"`
+				Seats      []CopilotSeat `json:"seats"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 2, response.TotalSeats)
+			require.Len(t, response.Seats, 2)
+			assert.Equal(t, "octocat", response.Seats[0].Assignee)
+			assert.Equal(t, "User", response.Seats[0].AssigneeType)
+			assert.Equal(t, "platform", response.Seats[0].AssigningTeam)
+			assert.Equal(t, "vscode/1.98.0", response.Seats[0].LastActivityEditor)
+			assert.Equal(t, "security", response.Seats[1].Assignee)
+			assert.Equal(t, "Team", response.Seats[1].AssigneeType)
+			assert.Equal(t, "2025-04-01", response.Seats[1].PendingCancellationDate)
+			assert.Equal(t, PageInfo{TotalCount: github.Ptr(2)}, result.Meta.AdditionalFields["pageInfo"])
+		})
+	}
+}
+
+func Test_AddCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_copilot_seats", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostOrgsCopilotBillingSelectedUsersByOrg,
+			expectRequestBody(t, map[string]any{"selected_usernames": []any{"octocat", "hubot"}}).andThen(
+				mockResponse(t, http.StatusCreated, github.SeatAssignments{SeatsCreated: 2}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostOrgsCopilotBillingSelectedTeamsByOrg,
+			expectRequestBody(t, map[string]any{"selected_teams": []any{"platform"}}).andThen(
+				mockResponse(t, http.StatusCreated, github.SeatAssignments{SeatsCreated: 5}),
+			),
+		),
+	))
+	_, handler := AddCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org":   "acme",
+		"users": []any{"octocat", "hubot"},
+		"teams": []any{"platform"},
+	}))
+	require.NoError(t, err)
+
+	var assignments github.SeatAssignments
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &assignments))
+	assert.Equal(t, 7, assignments.SeatsCreated)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{"org": "acme"}))
+	require.NoError(t, err)
+	assert.Equal(t, "at least one of users and teams is required", getErrorResult(t, result).Text)
+}
+
+func Test_RemoveCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_copilot_seats", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsCopilotBillingSelectedUsersByOrg,
+			expectRequestBody(t, map[string]any{"selected_usernames": []any{"octocat"}}).andThen(
+				mockResponse(t, http.StatusOK, github.SeatCancellations{SeatsCancelled: 1}),
+			),
+		),
+	))
+	_, handler := RemoveCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org":   "acme",
+		"users": []any{"octocat"},
+	}))
+	require.NoError(t, err)
+
+	var cancellations github.SeatCancellations
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &cancellations))
+	assert.Equal(t, 1, cancellations.SeatsCancelled)
+
+	client = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsCopilotBillingSelectedTeamsByOrg,
+			mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Copilot seat management is not set to selected users"}),
+		),
+	))
+	_, handler = RemoveCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{
+		"org":   "acme",
+		"teams": []any{"platform"},
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "failed to remove Copilot seats of teams")
+}
+
+func Test_GetCopilotMetrics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCopilotMetrics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_copilot_metrics", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	day := func(date string, activeUsers, suggestions, acceptances int) *github.CopilotMetrics {
+		return &github.CopilotMetrics{
+			Date:              date,
+			TotalActiveUsers:  github.Ptr(activeUsers),
+			TotalEngagedUsers: github.Ptr(activeUsers - 1),
+			CopilotIDECodeCompletions: &github.CopilotIDECodeCompletions{
+				Editors: []*github.CopilotIDECodeCompletionsEditor{{
+					Name: "vscode",
+					Models: []*github.CopilotIDECodeCompletionsModel{{
+						Name: "default",
+						Languages: []*github.CopilotIDECodeCompletionsModelLanguage{
+							{Name: "go", TotalCodeSuggestions: suggestions, TotalCodeAcceptances: acceptances, TotalCodeLinesSuggested: 2 * suggestions, TotalCodeLinesAccepted: 2 * acceptances},
+							{Name: "python", TotalCodeSuggestions: suggestions, TotalCodeAcceptances: acceptances},
+						},
+					}},
+				}},
+			},
+			CopilotIDEChat: &github.CopilotIDEChat{
+				Editors: []*github.CopilotIDEChatEditor{{
+					Models: []*github.CopilotIDEChatModel{{Name: "default", TotalChats: 4}},
+				}},
+			},
+			CopilotDotcomChat: &github.CopilotDotcomChat{
+				Models: []*github.CopilotDotcomChatModel{{Name: "default", TotalChats: 1}},
+			},
+			CopilotDotcomPullRequests: &github.CopilotDotcomPullRequests{
+				Repositories: []*github.CopilotDotcomPullRequestsRepository{{
+					Name:   "acme/api",
+					Models: []*github.CopilotDotcomPullRequestsModel{{Name: "default", TotalPRSummariesCreated: 2}},
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "organization metrics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotMetricsByOrg,
+					expectQueryParams(t, map[string]string{
+						"since":    "2025-03-01T00:00:00Z",
+						"per_page": "28",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.CopilotMetrics{day("2025-03-01", 10, 50, 20), day("2025-03-02", 12, 30, 15)}),
+					),
+				),
+			),
+			requestArgs: map[string]any{"org": "acme", "since": "2025-03-01"},
+		},
+		{
+			name: "team metrics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamCopilotMetricsByOrgByTeamSlug,
+					[]*github.CopilotMetrics{day("2025-03-01", 10, 50, 20), day("2025-03-02", 12, 30, 15)},
+				),
+			),
+			requestArgs: map[string]any{"org": "acme", "team": "platform"},
+		},
+		{
+			name:           "invalid since",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"org": "acme", "since": "last week"},
+			expectError:    true,
+			expectedErrMsg: "invalid since",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCopilotMetrics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Total CopilotUsage   `json:"total"`
+				Days  []CopilotUsage `json:"days"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response.Days, 2)
+			assert.Equal(t, CopilotUsage{
+				Date:                 "2025-03-01",
+				ActiveUsers:          10,
+				EngagedUsers:         9,
+				CodeSuggestions:      100,
+				CodeAcceptances:      40,
+				AcceptanceRate:       0.4,
+				CodeLinesSuggested:   100,
+				CodeLinesAccepted:    40,
+				IDEChats:             4,
+				DotcomChats:          1,
+				PullRequestSummaries: 2,
+			}, response.Days[0])
+			assert.Equal(t, CopilotUsage{
+				ActiveUsers:          12,
+				EngagedUsers:         11,
+				CodeSuggestions:      160,
+				CodeAcceptances:      70,
+				AcceptanceRate:       0.438,
+				CodeLinesSuggested:   160,
+				CodeLinesAccepted:    70,
+				IDEChats:             8,
+				DotcomChats:          2,
+				PullRequestSummaries: 4,
+			}, response.Total)
+		})
+	}
+}
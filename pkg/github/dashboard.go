@@ -0,0 +1,253 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxDashboardRepositories is the most repositories whose items can be listed at once.
+	maxDashboardRepositories = 50
+	// dashboardReposPerQuery is how many repositories are searched in one query, as the length of
+	// search queries is limited.
+	dashboardReposPerQuery = 10
+	// maxDashboardItems is the most items that can be listed at once.
+	maxDashboardItems = 100
+)
+
+// Reasons an item needs the attention of the user.
+const (
+	dashboardReviewRequested = "review_requested"
+	dashboardAssigned        = "assigned"
+)
+
+// DashboardItem is an open issue or pull request that needs the attention of the user.
+type DashboardItem struct {
+	Repository string   `json:"repository"`
+	Number     int      `json:"number"`
+	Type       string   `json:"type"`
+	Title      string   `json:"title"`
+	Author     string   `json:"author"`
+	URL        string   `json:"url"`
+	Labels     []string `json:"labels"`
+	Draft      bool     `json:"draft,omitempty"`
+	UpdatedAt  string   `json:"updated_at"`
+	// Reasons are why the item needs attention: review_requested, assigned or both
+	Reasons []string `json:"reasons"`
+	updated time.Time
+}
+
+// dashboardSearch is a search for items that need attention for a reason.
+type dashboardSearch struct {
+	reason string
+	query  string
+}
+
+// dashboardSearches returns the searches for the items of repositories, or of all repositories of
+// org if repositories is empty.
+func dashboardSearches(repositories []string, org string) []dashboardSearch {
+	var scopes []string
+	if len(repositories) == 0 {
+		scopes = []string{"org:" + org}
+	}
+	for chunk := range slices.Chunk(repositories, dashboardReposPerQuery) {
+		qualifiers := make([]string, len(chunk))
+		for i, fullName := range chunk {
+			qualifiers[i] = "repo:" + fullName
+		}
+		scopes = append(scopes, strings.Join(qualifiers, " "))
+	}
+	searches := make([]dashboardSearch, 0, 2*len(scopes))
+	for _, scope := range scopes {
+		searches = append(searches,
+			dashboardSearch{reason: dashboardReviewRequested, query: "is:open is:pr review-requested:@me archived:false " + scope},
+			dashboardSearch{reason: dashboardAssigned, query: "is:open assignee:@me archived:false " + scope},
+		)
+	}
+	return searches
+}
+
+// topicRepositories returns the names of the repositories of an organization with a topic.
+func topicRepositories(ctx context.Context, client *github.Client, org, topic string) ([]string, error) {
+	result, resp, err := client.Search.Repositories(ctx, fmt.Sprintf("org:%s topic:%s archived:false", org, topic), &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: maxDashboardRepositories},
+	})
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	repositories := make([]string, 0, len(result.Repositories))
+	for _, repo := range result.Repositories {
+		repositories = append(repositories, repo.GetFullName())
+	}
+	return repositories, nil
+}
+
+// ListItemsAcrossRepos creates a tool to list the open pull requests awaiting review by the user
+// and the open issues assigned to them, across repositories.
+func ListItemsAcrossRepos(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_items_across_repos",
+			mcp.WithDescription(t("TOOL_LIST_ITEMS_ACROSS_REPOS_DESCRIPTION", "List what needs the attention of the authenticated user across repositories: open pull requests awaiting their review and open issues and pull requests assigned to them, merged into one list without duplicates and most recently updated first. Give either a list of repositories, or an organization and optionally a topic its repositories must have")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ITEMS_ACROSS_REPOS_USER_TITLE", "List items needing my attention across repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithArray("repositories",
+				mcp.Description(fmt.Sprintf("Repositories to list items of, as 'owner/repo' (max %d)", maxDashboardRepositories)),
+				mcp.MaxItems(maxDashboardRepositories),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("org",
+				mcp.Description("Organization whose repositories to list items of, if repositories is not given"),
+			),
+			mcp.WithString("topic",
+				mcp.Description("Topic the repositories of org must have"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description(fmt.Sprintf("Most items to list, up to %d", maxDashboardItems)),
+				mcp.Min(1),
+				mcp.Max(maxDashboardItems),
+				mcp.DefaultNumber(30),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			repositories, err := OptionalStringArrayParam(request, "repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			topic, err := OptionalParam[string](request, "topic")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch {
+			case len(repositories) > 0 && org != "":
+				return mcp.NewToolResultError("give either repositories or org, not both"), nil
+			case len(repositories) == 0 && org == "":
+				return mcp.NewToolResultError("missing required parameter: repositories or org"), nil
+			case topic != "" && org == "":
+				return mcp.NewToolResultError("topic can only be given with org"), nil
+			case len(repositories) > maxDashboardRepositories:
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d repositories can be listed at once", maxDashboardRepositories)), nil
+			case limit < 1 || limit > maxDashboardItems:
+				return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxDashboardItems)), nil
+			}
+			for _, fullName := range repositories {
+				owner, repo, ok := strings.Cut(fullName, "/")
+				if !ok || owner == "" || repo == "" {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid repository %q, expected 'owner/repo'", fullName)), nil
+				}
+			}
+			slices.Sort(repositories)
+			repositories = slices.Compact(repositories)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if topic != "" {
+				repositories, err = topicRepositories(ctx, client, org, topic)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to find repositories of %s with topic %s: %s", org, topic, err)), nil
+				}
+				if len(repositories) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("no repositories of %s have topic %s", org, topic)), nil
+				}
+			}
+
+			// Items found by several searches, such as a pull request both awaiting review and
+			// assigned, are listed once with all of their reasons.
+			byURL := make(map[string]*DashboardItem)
+			var errs []string
+			searches := dashboardSearches(repositories, org)
+			for _, search := range searches {
+				result, resp, err := client.Search.Issues(ctx, search.query, &github.SearchOptions{
+					Sort:        "updated",
+					Order:       "desc",
+					ListOptions: github.ListOptions{PerPage: limit},
+				})
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("failed to search %q: %s", search.query, err))
+					continue
+				}
+				_ = resp.Body.Close()
+				for _, issue := range result.Issues {
+					item, ok := byURL[issue.GetHTMLURL()]
+					if !ok {
+						item = &DashboardItem{
+							Repository: repoFullNameFromURL(issue.GetRepositoryURL()),
+							Number:     issue.GetNumber(),
+							Type:       "issue",
+							Title:      issue.GetTitle(),
+							Author:     issue.GetUser().GetLogin(),
+							URL:        issue.GetHTMLURL(),
+							Labels:     make([]string, 0, len(issue.Labels)),
+							Draft:      issue.GetDraft(),
+							UpdatedAt:  issue.GetUpdatedAt().Format(time.RFC3339),
+							Reasons:    []string{},
+							updated:    issue.GetUpdatedAt().Time,
+						}
+						if issue.IsPullRequest() {
+							item.Type = "pull_request"
+						}
+						for _, label := range issue.Labels {
+							item.Labels = append(item.Labels, label.GetName())
+						}
+						byURL[item.URL] = item
+					}
+					if !slices.Contains(item.Reasons, search.reason) {
+						item.Reasons = append(item.Reasons, search.reason)
+					}
+				}
+			}
+			if len(errs) == len(searches) {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list items: %s", errs[0])), nil
+			}
+
+			items := make([]*DashboardItem, 0, len(byURL))
+			for _, item := range byURL {
+				items = append(items, item)
+			}
+			sort.Slice(items, func(i, j int) bool {
+				if !items[i].updated.Equal(items[j].updated) {
+					return items[i].updated.After(items[j].updated)
+				}
+				return items[i].URL < items[j].URL
+			})
+			total := len(items)
+			if len(items) > limit {
+				items = items[:limit]
+			}
+
+			scope := repositories
+			if len(scope) == 0 {
+				scope = []string{org + "/*"}
+			}
+			result := map[string]any{
+				"repositories": scope,
+				"total":        total,
+				"items":        items,
+			}
+			if len(errs) > 0 {
+				result["errors"] = errs
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DashboardSearches(t *testing.T) {
+	searches := dashboardSearches([]string{"o/a", "o/b"}, "")
+	assert.Equal(t, []dashboardSearch{
+		{reason: dashboardReviewRequested, query: "is:open is:pr review-requested:@me archived:false repo:o/a repo:o/b"},
+		{reason: dashboardAssigned, query: "is:open assignee:@me archived:false repo:o/a repo:o/b"},
+	}, searches)
+
+	searches = dashboardSearches(nil, "octo-org")
+	assert.Equal(t, "is:open assignee:@me archived:false org:octo-org", searches[1].query)
+
+	var many []string
+	for i := 0; i < dashboardReposPerQuery+1; i++ {
+		many = append(many, "o/r")
+	}
+	assert.Len(t, dashboardSearches(many, ""), 4)
+}
+
+func Test_ListItemsAcrossRepos(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListItemsAcrossRepos(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_items_across_repos", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "topic")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	pull := &github.Issue{
+		Number:           github.Ptr(42),
+		Title:            github.Ptr("Add caching"),
+		HTMLURL:          github.Ptr("https://github.com/octo-org/api/pull/42"),
+		RepositoryURL:    github.Ptr("https://api.github.com/repos/octo-org/api"),
+		User:             &github.User{Login: github.Ptr("hubot")},
+		UpdatedAt:        &github.Timestamp{Time: now.Add(-time.Hour)},
+		PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/octo-org/api/pulls/42")},
+	}
+	issue := &github.Issue{
+		Number:        github.Ptr(7),
+		Title:         github.Ptr("Flaky test"),
+		HTMLURL:       github.Ptr("https://github.com/octo-org/web/issues/7"),
+		RepositoryURL: github.Ptr("https://api.github.com/repos/octo-org/web"),
+		Labels:        []*github.Label{{Name: github.Ptr("bug")}},
+		UpdatedAt:     &github.Timestamp{Time: now},
+	}
+	search := func(t *testing.T, scope string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch q := r.URL.Query().Get("q"); q {
+			case "is:open is:pr review-requested:@me archived:false " + scope:
+				mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(1), Issues: []*github.Issue{pull}})(w, r)
+			case "is:open assignee:@me archived:false " + scope:
+				mockResponse(t, http.StatusOK, &github.IssuesSearchResult{Total: github.Ptr(2), Issues: []*github.Issue{issue, pull}})(w, r)
+			default:
+				t.Errorf("unexpected search %q", q)
+			}
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedRepos  []string
+	}{
+		{
+			name: "merges items of repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetSearchIssues, search(t, "repo:octo-org/api repo:octo-org/web")),
+			),
+			requestArgs: map[string]any{
+				"repositories": []any{"octo-org/web", "octo-org/api", "octo-org/web"},
+			},
+			expectedRepos: []string{"octo-org/api", "octo-org/web"},
+		},
+		{
+			name: "finds repositories of an organization by topic",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchRepositories,
+					expectQueryParams(t, map[string]string{
+						"q":        "org:octo-org topic:platform archived:false",
+						"per_page": "50",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoriesSearchResult{
+							Repositories: []*github.Repository{{FullName: github.Ptr("octo-org/api")}, {FullName: github.Ptr("octo-org/web")}},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(mock.GetSearchIssues, search(t, "repo:octo-org/api repo:octo-org/web")),
+			),
+			requestArgs: map[string]any{
+				"org":   "octo-org",
+				"topic": "platform",
+			},
+			expectedRepos: []string{"octo-org/api", "octo-org/web"},
+		},
+		{
+			name: "searches all repositories of an organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetSearchIssues, search(t, "org:octo-org")),
+			),
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+			expectedRepos: []string{"octo-org/*"},
+		},
+		{
+			name:         "both repositories and org",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"repositories": []any{"octo-org/api"},
+				"org":          "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "give either repositories or org, not both",
+		},
+		{
+			name:           "neither repositories nor org",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: repositories or org",
+		},
+		{
+			name:         "invalid repository name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"repositories": []any{"not-a-repo"},
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid repository \"not-a-repo\"",
+		},
+		{
+			name: "all searches fail",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"repositories": []any{"octo-org/api"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list items",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListItemsAcrossRepos(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				Repositories []string        `json:"repositories"`
+				Total        int             `json:"total"`
+				Items        []DashboardItem `json:"items"`
+				Errors       []string        `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedRepos, returned.Repositories)
+			assert.Empty(t, returned.Errors)
+			assert.Equal(t, 2, returned.Total)
+			require.Len(t, returned.Items, 2)
+
+			// Most recently updated first, and the pull request found by both searches is listed once
+			assert.Equal(t, "octo-org/web", returned.Items[0].Repository)
+			assert.Equal(t, "issue", returned.Items[0].Type)
+			assert.Equal(t, []string{"bug"}, returned.Items[0].Labels)
+			assert.Equal(t, []string{"assigned"}, returned.Items[0].Reasons)
+			assert.Equal(t, "octo-org/api", returned.Items[1].Repository)
+			assert.Equal(t, "pull_request", returned.Items[1].Type)
+			assert.Equal(t, "hubot", returned.Items[1].Author)
+			assert.Equal(t, []string{"review_requested", "assigned"}, returned.Items[1].Reasons)
+		})
+	}
+}
@@ -159,3 +159,249 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+func ListOrgDependabotAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_org_dependabot_alerts",
+			mcp.WithDescription(t("TOOL_LIST_ORG_DEPENDABOT_ALERTS_DESCRIPTION", "List dependabot alerts across all repositories of a GitHub organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_DEPENDABOT_ALERTS_USER_TITLE", "List organization dependabot alerts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter dependabot alerts by state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "fixed", "dismissed", "auto_dismissed"),
+			),
+			mcp.WithString("severity",
+				mcp.Description("Filter dependabot alerts by severity"),
+				mcp.Enum("low", "medium", "high", "critical"),
+			),
+			mcp.WithString("ecosystem",
+				mcp.Description("Filter dependabot alerts by package ecosystem, e.g. npm, pip or maven"),
+			),
+			mcp.WithString("package",
+				mcp.Description("Filter dependabot alerts by package name"),
+			),
+			mcp.WithString("scope",
+				mcp.Description("Filter dependabot alerts by the scope of the vulnerable dependency"),
+				mcp.Enum("development", "runtime"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			severity, err := OptionalParam[string](request, "severity")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ecosystem, err := OptionalParam[string](request, "ecosystem")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pkg, err := OptionalParam[string](request, "package")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			scope, err := OptionalParam[string](request, "scope")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			alerts, resp, err := client.Dependabot.ListOrgAlerts(ctx, org, &github.ListAlertsOptions{
+				State:     ToStringPtr(state),
+				Severity:  ToStringPtr(severity),
+				Ecosystem: ToStringPtr(ecosystem),
+				Package:   ToStringPtr(pkg),
+				Scope:     ToStringPtr(scope),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list alerts for organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(alerts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func UpdateDependabotAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"update_dependabot_alert",
+			mcp.WithDescription(t("TOOL_UPDATE_DEPENDABOT_ALERT_DESCRIPTION", "Dismiss or reopen a dependabot alert in a GitHub repository. A dismissed reason is required when dismissing.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_DEPENDABOT_ALERT_USER_TITLE", "Update dependabot alert"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("alertNumber",
+				mcp.Required(),
+				mcp.Description("The number of the alert."),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("The new state of the alert."),
+				mcp.Enum("open", "dismissed"),
+			),
+			mcp.WithString("dismissed_reason",
+				mcp.Description("Why the alert is dismissed. Required when state is dismissed."),
+				mcp.Enum("fix_started", "inaccurate", "no_bandwidth", "not_used", "tolerable_risk"),
+			),
+			mcp.WithString("dismissed_comment",
+				mcp.Description("A comment on why the alert is dismissed."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertNumber, err := RequiredInt(request, "alertNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dismissedReason, err := OptionalParam[string](request, "dismissed_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dismissedComment, err := OptionalParam[string](request, "dismissed_comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			stateInfo := &github.DependabotAlertState{State: state}
+			switch state {
+			case "dismissed":
+				if dismissedReason == "" {
+					return mcp.NewToolResultError("dismissed_reason is required when dismissing an alert"), nil
+				}
+				stateInfo.DismissedReason = github.Ptr(dismissedReason)
+				stateInfo.DismissedComment = ToStringPtr(dismissedComment)
+			case "open":
+				if dismissedReason != "" || dismissedComment != "" {
+					return mcp.NewToolResultError("dismissed_reason and dismissed_comment can only be set when dismissing an alert"), nil
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state: %s", state)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			alert, resp, err := client.Dependabot.UpdateAlert(ctx, owner, repo, alertNumber, stateInfo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update alert with number '%d'", alertNumber),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(alert)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal alert: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func GetDependencySBOM(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_dependency_sbom",
+			mcp.WithDescription(t("TOOL_GET_DEPENDENCY_SBOM_DESCRIPTION", "Get the software bill of materials (SBOM) of a GitHub repository from its dependency graph, in SPDX format.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEPENDENCY_SBOM_USER_TITLE", "Get dependency SBOM"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			sbom, resp, err := client.DependencyGraph.GetSBOM(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get SBOM for repository '%s/%s'", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(sbom)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal SBOM: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -274,3 +274,337 @@ func Test_ListDependabotAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListOrgDependabotAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgDependabotAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_dependabot_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "ecosystem")
+	assert.Contains(t, tool.InputSchema.Properties, "package")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	alert := github.DependabotAlert{
+		Number:  github.Ptr(1),
+		HTMLURL: github.Ptr("https://github.com/org/repo/security/dependabot/1"),
+		State:   github.Ptr("open"),
+		Repository: &github.Repository{
+			FullName: github.Ptr("org/repo"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedAlerts []*github.DependabotAlert
+		expectedErrMsg string
+	}{
+		{
+			name: "successful filtered listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsDependabotAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"state":     "open",
+						"ecosystem": "npm",
+						"package":   "lodash",
+						"scope":     "runtime",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.DependabotAlert{&alert}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"state":     "open",
+				"ecosystem": "npm",
+				"package":   "lodash",
+				"scope":     "runtime",
+			},
+			expectError:    false,
+			expectedAlerts: []*github.DependabotAlert{&alert},
+		},
+		{
+			name: "alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsDependabotAlertsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list alerts for organization 'org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgDependabotAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returnedAlerts []*github.DependabotAlert
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlerts)
+			require.NoError(t, err)
+			require.Len(t, returnedAlerts, len(tc.expectedAlerts))
+			for i, alert := range returnedAlerts {
+				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
+				assert.Equal(t, *tc.expectedAlerts[i].Repository.FullName, *alert.Repository.FullName)
+			}
+		})
+	}
+}
+
+func Test_UpdateDependabotAlert(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateDependabotAlert(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_dependabot_alert", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "dismissed_reason")
+	assert.Contains(t, tool.InputSchema.Properties, "dismissed_comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber", "state"})
+
+	dismissedAlert := github.DependabotAlert{
+		Number:          github.Ptr(42),
+		State:           github.Ptr("dismissed"),
+		DismissedReason: github.Ptr("tolerable_risk"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedAlert  *github.DependabotAlert
+		expectedErrMsg string
+	}{
+		{
+			name: "successful dismissal",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state":             "dismissed",
+						"dismissed_reason":  "tolerable_risk",
+						"dismissed_comment": "Only used in tests",
+					}).andThen(
+						mockResponse(t, http.StatusOK, dismissedAlert),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"alertNumber":       float64(42),
+				"state":             "dismissed",
+				"dismissed_reason":  "tolerable_risk",
+				"dismissed_comment": "Only used in tests",
+			},
+			expectError:   false,
+			expectedAlert: &dismissedAlert,
+		},
+		{
+			name: "successful reopening",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state": "open",
+					}).andThen(
+						mockResponse(t, http.StatusOK, github.DependabotAlert{Number: github.Ptr(42), State: github.Ptr("open")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "open",
+			},
+			expectError:   false,
+			expectedAlert: &github.DependabotAlert{Number: github.Ptr(42), State: github.Ptr("open")},
+		},
+		{
+			name:         "dismissal without reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "dismissed",
+			},
+			expectError:    true,
+			expectedErrMsg: "dismissed_reason is required when dismissing an alert",
+		},
+		{
+			name:         "reopening with reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"alertNumber":      float64(42),
+				"state":            "open",
+				"dismissed_reason": "inaccurate",
+			},
+			expectError:    true,
+			expectedErrMsg: "can only be set when dismissing an alert",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(999),
+				"state":       "open",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update alert with number '999'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateDependabotAlert(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returnedAlert github.DependabotAlert
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlert)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedAlert.Number, *returnedAlert.Number)
+			assert.Equal(t, *tc.expectedAlert.State, *returnedAlert.State)
+		})
+	}
+}
+
+func Test_GetDependencySBOM(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDependencySBOM(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_dependency_sbom", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	sbom := github.SBOM{
+		SBOM: &github.SBOMInfo{
+			SPDXID:      github.Ptr("SPDXRef-DOCUMENT"),
+			SPDXVersion: github.Ptr("SPDX-2.3"),
+			Name:        github.Ptr("com.github.owner/repo"),
+			Packages: []*github.RepoDependencies{
+				{Name: github.Ptr("npm:lodash"), VersionInfo: github.Ptr("4.17.21")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful SBOM retrieval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					sbom,
+				),
+			),
+			expectError: false,
+		},
+		{
+			name: "SBOM retrieval fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get SBOM for repository 'owner/repo'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetDependencySBOM(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returnedSBOM github.SBOM
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedSBOM)
+			require.NoError(t, err)
+			assert.Equal(t, "SPDX-2.3", *returnedSBOM.SBOM.SPDXVersion)
+			require.Len(t, returnedSBOM.SBOM.Packages, 1)
+			assert.Equal(t, "npm:lodash", *returnedSBOM.SBOM.Packages[0].Name)
+		})
+	}
+}
@@ -0,0 +1,837 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListDeployments creates a tool to list the deployments of a repository.
+func ListDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deployments",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOYMENTS_DESCRIPTION", "List the deployments of a GitHub repository, newest first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOYMENTS_USER_TITLE", "List deployments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Only list deployments to this environment, e.g. production"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Only list deployments of this branch, tag or SHA"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Only list deployments of this commit SHA"),
+			),
+			mcp.WithString("task",
+				mcp.Description("Only list deployments of this task, e.g. deploy or deploy:migrations"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := OptionalParam[string](request, "environment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			task, err := OptionalParam[string](request, "task")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deployments, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, &github.DeploymentsListOptions{
+				Environment: environment,
+				Ref:         ref,
+				SHA:         sha,
+				Task:        task,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list deployments of repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(deployments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal deployments: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// CreateDeployment creates a tool to create a deployment of a ref to an environment.
+func CreateDeployment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deployment",
+			mcp.WithDescription(t("TOOL_CREATE_DEPLOYMENT_DESCRIPTION", "Create a deployment of a branch, tag or SHA to an environment of a GitHub repository. The deployment starts out pending; report its progress with create_deployment_status.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DEPLOYMENT_USER_TITLE", "Create deployment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Branch, tag or SHA to deploy"),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Environment to deploy to. Defaults to production"),
+			),
+			mcp.WithString("task",
+				mcp.Description("Task to run, e.g. deploy:migrations. Defaults to deploy"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the deployment"),
+			),
+			mcp.WithBoolean("auto_merge",
+				mcp.Description("Whether to merge the default branch into ref first if ref is behind it. Defaults to true"),
+			),
+			mcp.WithArray("required_contexts",
+				mcp.Description("Status check contexts that must pass on ref before deploying. Defaults to all of them; pass an empty list to skip the checks"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithObject("payload",
+				mcp.Description("Extra information for the deployment system, as a JSON object"),
+			),
+			mcp.WithBoolean("transient_environment",
+				mcp.Description("Whether the environment is specific to this deployment and goes away in the future, such as a review app"),
+			),
+			mcp.WithBoolean("production_environment",
+				mcp.Description("Whether the environment is one end users interact with. Defaults to true for production and false otherwise"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			deployment := &github.DeploymentRequest{Ref: github.Ptr(ref)}
+			for param, field := range map[string]**string{
+				"environment": &deployment.Environment,
+				"task":        &deployment.Task,
+				"description": &deployment.Description,
+			} {
+				value, ok, err := OptionalParamOK[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+				}
+			}
+			for param, field := range map[string]**bool{
+				"auto_merge":             &deployment.AutoMerge,
+				"transient_environment":  &deployment.TransientEnvironment,
+				"production_environment": &deployment.ProductionEnvironment,
+			} {
+				value, ok, err := OptionalParamOK[bool](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+				}
+			}
+			if _, ok := request.GetArguments()["required_contexts"]; ok {
+				contexts, err := OptionalStringArrayParam(request, "required_contexts")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if contexts == nil {
+					contexts = []string{}
+				}
+				deployment.RequiredContexts = &contexts
+			}
+			if payload, ok := request.GetArguments()["payload"]; ok {
+				if _, ok := payload.(map[string]any); !ok {
+					return mcp.NewToolResultError("payload must be an object"), nil
+				}
+				deployment.Payload = payload
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateDeployment(ctx, owner, repo, deployment)
+			// The API accepts the request without creating a deployment when it merged the
+			// default branch into ref first; the deployment has to be requested again.
+			var acceptedErr *github.AcceptedError
+			if errors.As(err, &acceptedErr) {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultText(fmt.Sprintf("the default branch was merged into %s, no deployment was created; create the deployment again", ref)), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create deployment of %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal deployment: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListDeploymentStatuses creates a tool to list the statuses of a deployment.
+func ListDeploymentStatuses(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deployment_statuses",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOYMENT_STATUSES_DESCRIPTION", "List the statuses of a deployment, newest first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOYMENT_STATUSES_USER_TITLE", "List deployment statuses"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("deployment_id",
+				mcp.Required(),
+				mcp.Description("The ID of the deployment"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deploymentID, err := RequiredInt(request, "deployment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			statuses, resp, err := client.Repositories.ListDeploymentStatuses(ctx, owner, repo, int64(deploymentID), &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list statuses of deployment %d", deploymentID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(statuses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal deployment statuses: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// CreateDeploymentStatus creates a tool to report the progress of a deployment.
+func CreateDeploymentStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deployment_status",
+			mcp.WithDescription(t("TOOL_CREATE_DEPLOYMENT_STATUS_DESCRIPTION", "Set the status of a deployment, e.g. to report that it is in progress, succeeded or failed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DEPLOYMENT_STATUS_USER_TITLE", "Create deployment status"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("deployment_id",
+				mcp.Required(),
+				mcp.Description("The ID of the deployment"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("State of the deployment"),
+				mcp.Enum("queued", "in_progress", "success", "failure", "error", "inactive"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the status, at most 140 characters"),
+			),
+			mcp.WithString("log_url",
+				mcp.Description("URL of the deployment output"),
+			),
+			mcp.WithString("environment_url",
+				mcp.Description("URL to access the deployed environment"),
+			),
+			mcp.WithString("environment",
+				mcp.Description("Name of the environment the deployment was made to, if it changed"),
+			),
+			mcp.WithBoolean("auto_inactive",
+				mcp.Description("Whether to mark earlier successful deployments to the same environment as inactive when state is success. Defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deploymentID, err := RequiredInt(request, "deployment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			status := &github.DeploymentStatusRequest{State: github.Ptr(state)}
+			for param, field := range map[string]**string{
+				"description":     &status.Description,
+				"log_url":         &status.LogURL,
+				"environment_url": &status.EnvironmentURL,
+				"environment":     &status.Environment,
+			} {
+				value, ok, err := OptionalParamOK[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+				}
+			}
+			autoInactive, ok, err := OptionalParamOK[bool](request, "auto_inactive")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ok {
+				status.AutoInactive = github.Ptr(autoInactive)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateDeploymentStatus(ctx, owner, repo, int64(deploymentID), status)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to set status of deployment %d", deploymentID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal deployment status: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// EnvironmentDetails is a deployment environment along with the branch patterns it can be
+// deployed from, when it uses custom deployment branch policies.
+type EnvironmentDetails struct {
+	*github.Environment
+	BranchPatterns []string `json:"branch_patterns,omitempty"`
+}
+
+// getEnvironmentDetails returns an environment and its custom deployment branch patterns.
+func getEnvironmentDetails(ctx context.Context, client *github.Client, owner, repo, name string) (*EnvironmentDetails, *github.Response, error) {
+	environment, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, name)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	details := &EnvironmentDetails{Environment: environment}
+	if !environment.GetDeploymentBranchPolicy().GetCustomBranchPolicies() {
+		return details, resp, nil
+	}
+
+	policies, resp, err := client.Repositories.ListDeploymentBranchPolicies(ctx, owner, repo, name)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	for _, policy := range policies.BranchPolicies {
+		details.BranchPatterns = append(details.BranchPatterns, policy.GetName())
+	}
+	return details, resp, nil
+}
+
+// ListEnvironments creates a tool to list the deployment environments of a repository.
+func ListEnvironments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_environments",
+			mcp.WithDescription(t("TOOL_LIST_ENVIRONMENTS_DESCRIPTION", "List the deployment environments of a GitHub repository, along with their protection rules")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ENVIRONMENTS_USER_TITLE", "List environments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			environments, resp, err := client.Repositories.ListEnvironments(ctx, owner, repo, &github.EnvironmentListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list environments of repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(environments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal environments: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// GetEnvironment creates a tool to get a deployment environment of a repository.
+func GetEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_environment",
+			mcp.WithDescription(t("TOOL_GET_ENVIRONMENT_DESCRIPTION", "Get a deployment environment of a GitHub repository, including its protection rules and the branch patterns it can be deployed from")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ENVIRONMENT_USER_TITLE", "Get environment"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment_name",
+				mcp.Required(),
+				mcp.Description("Name of the environment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "environment_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			environment, resp, err := getEnvironmentDetails(ctx, client, owner, repo, name)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get environment %s", name),
+					resp,
+					err,
+				), nil
+			}
+
+			r, err := json.Marshal(environment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal environment: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// environmentRequest returns the settings of an environment as a request that keeps them.
+func environmentRequest(environment *github.Environment) *github.CreateUpdateEnvironment {
+	settings := &github.CreateUpdateEnvironment{
+		WaitTimer:              github.Ptr(0),
+		Reviewers:              []*github.EnvReviewers{},
+		CanAdminsBypass:        environment.CanAdminsBypass,
+		DeploymentBranchPolicy: environment.DeploymentBranchPolicy,
+	}
+	for _, rule := range environment.ProtectionRules {
+		switch rule.GetType() {
+		case "wait_timer":
+			settings.WaitTimer = rule.WaitTimer
+		case "required_reviewers":
+			settings.PreventSelfReview = rule.PreventSelfReview
+			for _, reviewer := range rule.Reviewers {
+				switch r := reviewer.Reviewer.(type) {
+				case *github.User:
+					settings.Reviewers = append(settings.Reviewers, &github.EnvReviewers{Type: github.Ptr("User"), ID: r.ID})
+				case *github.Team:
+					settings.Reviewers = append(settings.Reviewers, &github.EnvReviewers{Type: github.Ptr("Team"), ID: r.ID})
+				}
+			}
+		}
+	}
+	return settings
+}
+
+// environmentReviewers converts the reviewers parameter of create_or_update_environment.
+func environmentReviewers(value any) ([]*github.EnvReviewers, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, errors.New("reviewers must be an array of objects")
+	}
+	reviewers := []*github.EnvReviewers{}
+	for _, item := range items {
+		reviewer, ok := item.(map[string]any)
+		if !ok {
+			return nil, errors.New("reviewers must be an array of objects")
+		}
+		reviewerType, _ := reviewer["type"].(string)
+		if reviewerType != "User" && reviewerType != "Team" {
+			return nil, errors.New("reviewer type must be User or Team")
+		}
+		id, ok := reviewer["id"].(float64)
+		if !ok || id != float64(int64(id)) {
+			return nil, errors.New("reviewer id must be an integer")
+		}
+		reviewers = append(reviewers, &github.EnvReviewers{Type: github.Ptr(reviewerType), ID: github.Ptr(int64(id))})
+	}
+	return reviewers, nil
+}
+
+// CreateOrUpdateEnvironment creates a tool to create a deployment environment or change its protection rules.
+func CreateOrUpdateEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_or_update_environment",
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_ENVIRONMENT_DESCRIPTION", "Create a deployment environment in a GitHub repository, or change the protection rules of an existing one. Only the given settings are changed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_OR_UPDATE_ENVIRONMENT_USER_TITLE", "Create or update environment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment_name",
+				mcp.Required(),
+				mcp.Description("Name of the environment"),
+			),
+			mcp.WithNumber("wait_timer",
+				mcp.Description("Minutes to wait before a deployment to the environment may proceed. Pass 0 to remove the wait timer"),
+				mcp.Min(0),
+				mcp.Max(43200),
+			),
+			mcp.WithArray("reviewers",
+				mcp.Description("Users or teams that must approve deployments to the environment, replacing the current ones, e.g. [{\"type\": \"User\", \"id\": 1}, {\"type\": \"Team\", \"id\": 2}]. Pass an empty list to stop requiring approval"),
+				mcp.Items(map[string]any{"type": "object"}),
+			),
+			mcp.WithBoolean("prevent_self_review",
+				mcp.Description("Whether the user who triggered a deployment is prevented from approving it"),
+			),
+			mcp.WithBoolean("can_admins_bypass",
+				mcp.Description("Whether repository administrators can bypass the protection rules"),
+			),
+			mcp.WithString("deployment_branch_policy",
+				mcp.Description("Which branches can deploy to the environment: all of them, protected branches only, or branches matching branch_patterns"),
+				mcp.Enum("all", "protected", "custom"),
+			),
+			mcp.WithArray("branch_patterns",
+				mcp.Description("Branch name patterns that can deploy to the environment, e.g. ['main', 'release/*'], replacing the current ones. Implies the custom deployment branch policy"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "environment_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branchPolicy, err := OptionalParam[string](request, "deployment_branch_policy")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasBranchPatterns := request.GetArguments()["branch_patterns"]
+			branchPatterns, err := OptionalStringArrayParam(request, "branch_patterns")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if hasBranchPatterns {
+				if branchPolicy != "" && branchPolicy != "custom" {
+					return mcp.NewToolResultError("branch_patterns can only be used with the custom deployment branch policy"), nil
+				}
+				branchPolicy = "custom"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API resets the settings left out of the request, so start from the current ones.
+			settings := &github.CreateUpdateEnvironment{}
+			current, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, name)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+				settings = environmentRequest(current)
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				_ = resp.Body.Close()
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get environment %s", name),
+					resp,
+					err,
+				), nil
+			}
+
+			waitTimer, ok, err := OptionalParamOK[float64](request, "wait_timer")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ok {
+				settings.WaitTimer = github.Ptr(int(waitTimer))
+			}
+			if value, ok := request.GetArguments()["reviewers"]; ok {
+				settings.Reviewers, err = environmentReviewers(value)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			for param, field := range map[string]**bool{
+				"prevent_self_review": &settings.PreventSelfReview,
+				"can_admins_bypass":   &settings.CanAdminsBypass,
+			} {
+				value, ok, err := OptionalParamOK[bool](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+				}
+			}
+			switch branchPolicy {
+			case "all":
+				settings.DeploymentBranchPolicy = nil
+			case "protected":
+				settings.DeploymentBranchPolicy = &github.BranchPolicy{
+					ProtectedBranches:    github.Ptr(true),
+					CustomBranchPolicies: github.Ptr(false),
+				}
+			case "custom":
+				settings.DeploymentBranchPolicy = &github.BranchPolicy{
+					ProtectedBranches:    github.Ptr(false),
+					CustomBranchPolicies: github.Ptr(true),
+				}
+			}
+
+			_, resp, err = client.Repositories.CreateUpdateEnvironment(ctx, owner, repo, name, settings)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create or update environment %s", name),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			if hasBranchPatterns {
+				resp, err = replaceDeploymentBranchPolicies(ctx, client, owner, repo, name, branchPatterns)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to set branch patterns of environment %s", name),
+						resp,
+						err,
+					), nil
+				}
+			}
+
+			environment, resp, err := getEnvironmentDetails(ctx, client, owner, repo, name)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get environment %s", name),
+					resp,
+					err,
+				), nil
+			}
+
+			r, err := json.Marshal(environment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal environment: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// replaceDeploymentBranchPolicies makes the branch patterns of an environment match the given
+// ones. Tag patterns are left alone.
+func replaceDeploymentBranchPolicies(ctx context.Context, client *github.Client, owner, repo, name string, patterns []string) (*github.Response, error) {
+	current, resp, err := client.Repositories.ListDeploymentBranchPolicies(ctx, owner, repo, name)
+	if err != nil {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+
+	wanted := make(map[string]bool, len(patterns))
+	for _, pattern := range patterns {
+		wanted[pattern] = true
+	}
+	for _, policy := range current.BranchPolicies {
+		if policy.GetType() == "tag" {
+			continue
+		}
+		if wanted[policy.GetName()] {
+			delete(wanted, policy.GetName())
+			continue
+		}
+		resp, err := client.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repo, name, policy.GetID())
+		if err != nil {
+			return resp, err
+		}
+		_ = resp.Body.Close()
+	}
+	for _, pattern := range patterns {
+		if !wanted[pattern] {
+			continue
+		}
+		delete(wanted, pattern)
+		_, resp, err := client.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repo, name, &github.DeploymentBranchPolicyRequest{
+			Name: github.Ptr(pattern),
+			Type: github.Ptr("branch"),
+		})
+		if err != nil {
+			return resp, err
+		}
+		_ = resp.Body.Close()
+	}
+	return nil, nil
+}
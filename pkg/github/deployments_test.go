@@ -0,0 +1,631 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_deployments", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "filters by environment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDeploymentsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"environment": "production",
+						"page":        "1",
+						"per_page":    "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Deployment{
+							{ID: github.Ptr(int64(1)), Environment: github.Ptr("production"), Ref: github.Ptr("main")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDeploymentsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list deployments of repository owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var deployments []*github.Deployment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &deployments))
+			require.Len(t, deployments, 1)
+			assert.Equal(t, "production", deployments[0].GetEnvironment())
+		})
+	}
+}
+
+func Test_CreateDeployment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateDeployment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_deployment", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "creates deployment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref":               "v1.2.0",
+						"environment":       "staging",
+						"auto_merge":        false,
+						"required_contexts": []interface{}{},
+						"payload":           map[string]interface{}{"migrate": true},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Deployment{
+							ID:          github.Ptr(int64(7)),
+							Ref:         github.Ptr("v1.2.0"),
+							Environment: github.Ptr("staging"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"ref":               "v1.2.0",
+				"environment":       "staging",
+				"auto_merge":        false,
+				"required_contexts": []interface{}{},
+				"payload":           map[string]interface{}{"migrate": true},
+			},
+			expectedText: `"id":7`,
+		},
+		{
+			name: "default branch merged first",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsByOwnerByRepo,
+					mockResponse(t, http.StatusAccepted, `{"message": "Auto-merged main into topic on deployment."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "topic",
+			},
+			expectedText: "the default branch was merged into topic, no deployment was created",
+		},
+		{
+			name:         "payload must be an object",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"ref":     "main",
+				"payload": "migrate",
+			},
+			expectError:    true,
+			expectedErrMsg: "payload must be an object",
+		},
+		{
+			name: "required status checks failed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsByOwnerByRepo,
+					mockResponse(t, http.StatusConflict, `{"message": "Conflict: Commit status checks failed for main."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create deployment of main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateDeployment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
+
+func Test_ListDeploymentStatuses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDeploymentStatuses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_deployment_statuses", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "deployment_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposDeploymentsStatusesByOwnerByRepoByDeploymentId,
+			[]*github.DeploymentStatus{
+				{ID: github.Ptr(int64(2)), State: github.Ptr("success")},
+				{ID: github.Ptr(int64(1)), State: github.Ptr("in_progress")},
+			},
+		),
+	))
+	_, handler := ListDeploymentStatuses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":         "owner",
+		"repo":          "repo",
+		"deployment_id": float64(7),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var statuses []*github.DeploymentStatus
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &statuses))
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "success", statuses[0].GetState())
+}
+
+func Test_CreateDeploymentStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateDeploymentStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_deployment_status", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "deployment_id", "state"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "sets status",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsStatusesByOwnerByRepoByDeploymentId,
+					expectRequestBody(t, map[string]interface{}{
+						"state":           "success",
+						"environment_url": "https://staging.example.com",
+						"auto_inactive":   false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.DeploymentStatus{
+							ID:    github.Ptr(int64(3)),
+							State: github.Ptr("success"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"deployment_id":   float64(7),
+				"state":           "success",
+				"environment_url": "https://staging.example.com",
+				"auto_inactive":   false,
+			},
+		},
+		{
+			name:         "missing state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"deployment_id": float64(7),
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: state",
+		},
+		{
+			name: "deployment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDeploymentsStatusesByOwnerByRepoByDeploymentId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"deployment_id": float64(7),
+				"state":         "failure",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set status of deployment 7",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateDeploymentStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var status github.DeploymentStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, "success", status.GetState())
+		})
+	}
+}
+
+func Test_ListEnvironments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnvironments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_environments", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposEnvironmentsByOwnerByRepo,
+			&github.EnvResponse{
+				TotalCount:   github.Ptr(1),
+				Environments: []*github.Environment{{Name: github.Ptr("production")}},
+			},
+		),
+	))
+	_, handler := ListEnvironments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var environments github.EnvResponse
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &environments))
+	require.Len(t, environments.Environments, 1)
+	assert.Equal(t, "production", environments.Environments[0].GetName())
+}
+
+func Test_GetEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_environment", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+			&github.Environment{
+				Name: github.Ptr("production"),
+				DeploymentBranchPolicy: &github.BranchPolicy{
+					ProtectedBranches:    github.Ptr(false),
+					CustomBranchPolicies: github.Ptr(true),
+				},
+			},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+			&github.DeploymentBranchPolicyResponse{
+				TotalCount: github.Ptr(2),
+				BranchPolicies: []*github.DeploymentBranchPolicy{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("main"), Type: github.Ptr("branch")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("release/*"), Type: github.Ptr("branch")},
+				},
+			},
+		),
+	))
+	_, handler := GetEnvironment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"environment_name": "production",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var environment map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &environment))
+	assert.Equal(t, "production", environment["name"])
+	assert.Equal(t, []any{"main", "release/*"}, environment["branch_patterns"])
+}
+
+func Test_CreateOrUpdateEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_or_update_environment", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment_name"})
+
+	existing := &github.Environment{
+		Name:            github.Ptr("production"),
+		CanAdminsBypass: github.Ptr(true),
+		ProtectionRules: []*github.ProtectionRule{
+			{Type: github.Ptr("wait_timer"), WaitTimer: github.Ptr(30)},
+			{
+				Type:              github.Ptr("required_reviewers"),
+				PreventSelfReview: github.Ptr(true),
+				Reviewers: []*github.RequiredReviewer{
+					{Type: github.Ptr("User"), Reviewer: &github.User{ID: github.Ptr(int64(1))}},
+					{Type: github.Ptr("Team"), Reviewer: &github.Team{ID: github.Ptr(int64(2))}},
+				},
+			},
+		},
+		DeploymentBranchPolicy: &github.BranchPolicy{
+			ProtectedBranches:    github.Ptr(true),
+			CustomBranchPolicies: github.Ptr(false),
+		},
+	}
+	notFound := mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "keeps the settings that are not given",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName, existing, existing),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]interface{}{
+						"wait_timer": float64(5),
+						"reviewers": []interface{}{
+							map[string]interface{}{"type": "User", "id": float64(1)},
+							map[string]interface{}{"type": "Team", "id": float64(2)},
+						},
+						"can_admins_bypass":   true,
+						"prevent_self_review": true,
+						"deployment_branch_policy": map[string]interface{}{
+							"protected_branches":     true,
+							"custom_branch_policies": false,
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, existing),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"wait_timer":       float64(5),
+			},
+		},
+		{
+			name: "creates environment with custom branch patterns",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					func() http.HandlerFunc {
+						calls := 0
+						return func(w http.ResponseWriter, r *http.Request) {
+							calls++
+							if calls == 1 {
+								notFound(w, r)
+								return
+							}
+							mockResponse(t, http.StatusOK, &github.Environment{
+								Name: github.Ptr("production"),
+								DeploymentBranchPolicy: &github.BranchPolicy{
+									ProtectedBranches:    github.Ptr(false),
+									CustomBranchPolicies: github.Ptr(true),
+								},
+							})(w, r)
+						}
+					}(),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]interface{}{
+						"wait_timer":        float64(0),
+						"reviewers":         []interface{}{map[string]interface{}{"type": "User", "id": float64(9)}},
+						"can_admins_bypass": true,
+						"deployment_branch_policy": map[string]interface{}{
+							"protected_branches":     false,
+							"custom_branch_policies": true,
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Environment{Name: github.Ptr("production")}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					&github.DeploymentBranchPolicyResponse{
+						BranchPolicies: []*github.DeploymentBranchPolicy{
+							{ID: github.Ptr(int64(1)), Name: github.Ptr("main"), Type: github.Ptr("branch")},
+							{ID: github.Ptr(int64(2)), Name: github.Ptr("old/*"), Type: github.Ptr("branch")},
+							{ID: github.Ptr(int64(3)), Name: github.Ptr("v*"), Type: github.Ptr("tag")},
+						},
+					},
+					&github.DeploymentBranchPolicyResponse{
+						BranchPolicies: []*github.DeploymentBranchPolicy{
+							{ID: github.Ptr(int64(1)), Name: github.Ptr("main"), Type: github.Ptr("branch")},
+							{ID: github.Ptr(int64(3)), Name: github.Ptr("v*"), Type: github.Ptr("tag")},
+							{ID: github.Ptr(int64(4)), Name: github.Ptr("release/*"), Type: github.Ptr("branch")},
+						},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentNameByBranchPolicyId,
+					expectPath(t, "/repos/owner/repo/environments/production/deployment-branch-policies/2").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "release/*",
+						"type": "branch",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.DeploymentBranchPolicy{ID: github.Ptr(int64(4)), Name: github.Ptr("release/*")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"reviewers":        []interface{}{map[string]interface{}{"type": "User", "id": float64(9)}},
+				"branch_patterns":  []interface{}{"main", "release/*"},
+			},
+		},
+		{
+			name:         "branch patterns need the custom policy",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":                    "owner",
+				"repo":                     "repo",
+				"environment_name":         "production",
+				"deployment_branch_policy": "protected",
+				"branch_patterns":          []interface{}{"main"},
+			},
+			expectError:    true,
+			expectedErrMsg: "branch_patterns can only be used with the custom deployment branch policy",
+		},
+		{
+			name: "invalid reviewer",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName, existing),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"reviewers":        []interface{}{map[string]interface{}{"type": "Bot", "id": float64(9)}},
+			},
+			expectError:    true,
+			expectedErrMsg: "reviewer type must be User or Team",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName, notFound),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create or update environment production",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateEnvironment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var environment map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &environment))
+			assert.Equal(t, "production", environment["name"])
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// defaultDigestWindow is the window of a digest if since is not given.
+	defaultDigestWindow = 7 * 24 * time.Hour
+	// maxDigestItems is the most items listed in each section of a digest.
+	maxDigestItems = 50
+	// digestDiscussions is how many of the most recently updated discussions are considered for a
+	// digest.
+	digestDiscussions = 50
+)
+
+// DigestItem is an issue or pull request listed in a digest.
+type DigestItem struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"created_at"`
+	MergedAt  string `json:"merged_at,omitempty"`
+	Comments  int    `json:"comments"`
+}
+
+// DigestItems are the items of a section of a digest, of which there may be more than listed.
+type DigestItems struct {
+	Total int          `json:"total"`
+	Items []DigestItem `json:"items"`
+}
+
+// DigestRelease is a release published in the window of a digest.
+type DigestRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Prerelease  bool   `json:"prerelease"`
+	Author      string `json:"author"`
+	URL         string `json:"url"`
+	PublishedAt string `json:"published_at"`
+}
+
+// DigestFailingWorkflow is a workflow that failed in the window of a digest.
+type DigestFailingWorkflow struct {
+	Workflow      string   `json:"workflow"`
+	Failures      int      `json:"failures"`
+	Branches      []string `json:"branches"`
+	LatestRunURL  string   `json:"latest_run_url"`
+	LatestFailure string   `json:"latest_failure"`
+}
+
+// DigestDiscussion is a discussion active in the window of a digest.
+type DigestDiscussion struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	Category  string `json:"category"`
+	URL       string `json:"url"`
+	Comments  int    `json:"comments"`
+	Upvotes   int    `json:"upvotes"`
+	Answered  bool   `json:"answered"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// RepositoryDigest summarizes the activity of a repository in a window of time.
+type RepositoryDigest struct {
+	Repository         string                  `json:"repository"`
+	Since              string                  `json:"since"`
+	Until              string                  `json:"until"`
+	NewIssues          *DigestItems            `json:"new_issues"`
+	MergedPullRequests *DigestItems            `json:"merged_pull_requests"`
+	Releases           []DigestRelease         `json:"releases"`
+	FailingWorkflows   []DigestFailingWorkflow `json:"failing_workflows"`
+	FailedRuns         int                     `json:"failed_runs"`
+	NotableDiscussions []DigestDiscussion      `json:"notable_discussions"`
+	// Errors lists the sections that could not be collected, which are left empty
+	Errors []string `json:"errors,omitempty"`
+}
+
+type digestDiscussionsQuery struct {
+	Repository struct {
+		Discussions struct {
+			Nodes []struct {
+				Number      githubv4.Int
+				Title       githubv4.String
+				URL         githubv4.String `graphql:"url"`
+				UpdatedAt   githubv4.DateTime
+				UpvoteCount githubv4.Int
+				IsAnswered  githubv4.Boolean
+				Category    struct {
+					Name githubv4.String
+				}
+				Comments struct {
+					TotalCount githubv4.Int
+				}
+			}
+		} `graphql:"discussions(first: $first, orderBy: {field: UPDATED_AT, direction: DESC})"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// searchDigestItems lists the issues or pull requests matching a search query, most recent first.
+func searchDigestItems(ctx context.Context, client *github.Client, query, sort string, limit int) (*DigestItems, error) {
+	result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{
+		Sort:        sort,
+		Order:       "desc",
+		ListOptions: github.ListOptions{PerPage: limit},
+	})
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	items := &DigestItems{Total: result.GetTotal(), Items: make([]DigestItem, 0, len(result.Issues))}
+	for _, issue := range result.Issues {
+		item := DigestItem{
+			Number:    issue.GetNumber(),
+			Title:     issue.GetTitle(),
+			Author:    issue.GetUser().GetLogin(),
+			URL:       issue.GetHTMLURL(),
+			CreatedAt: issue.GetCreatedAt().Format(time.RFC3339),
+			Comments:  issue.GetComments(),
+		}
+		if mergedAt := issue.GetPullRequestLinks().GetMergedAt(); !mergedAt.IsZero() {
+			item.MergedAt = mergedAt.Format(time.RFC3339)
+		}
+		items.Items = append(items.Items, item)
+	}
+	return items, nil
+}
+
+// failingWorkflows groups the failed runs of workflows, most failures first.
+func failingWorkflows(runs []*github.WorkflowRun) []DigestFailingWorkflow {
+	byWorkflow := make(map[string]*DigestFailingWorkflow)
+	var order []string
+	for _, run := range runs {
+		name := run.GetName()
+		workflow, ok := byWorkflow[name]
+		if !ok {
+			// Runs are listed newest first, so the first run of a workflow is its latest
+			workflow = &DigestFailingWorkflow{
+				Workflow:      name,
+				Branches:      []string{},
+				LatestRunURL:  run.GetHTMLURL(),
+				LatestFailure: run.GetCreatedAt().Format(time.RFC3339),
+			}
+			byWorkflow[name] = workflow
+			order = append(order, name)
+		}
+		workflow.Failures++
+		if branch := run.GetHeadBranch(); branch != "" && !slices.Contains(workflow.Branches, branch) {
+			workflow.Branches = append(workflow.Branches, branch)
+		}
+	}
+	workflows := make([]DigestFailingWorkflow, 0, len(order))
+	for _, name := range order {
+		workflows = append(workflows, *byWorkflow[name])
+	}
+	sort.SliceStable(workflows, func(i, j int) bool { return workflows[i].Failures > workflows[j].Failures })
+	return workflows
+}
+
+// GetRepositoryDigest creates a tool to summarize the activity of a repository in a window of time.
+func GetRepositoryDigest(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_digest",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_DIGEST_DESCRIPTION", "Summarize the activity of a repository in a window of time for a standup or report: new issues, merged pull requests, published releases, failing workflows and the most active discussions. Sections that cannot be collected, such as discussions when they are disabled, are listed in errors and left empty")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_DIGEST_USER_TITLE", "Get repository activity digest"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("since",
+				mcp.Description("Start of the window, as an ISO 8601 timestamp or date (default: 7 days ago)"),
+			),
+			mcp.WithString("until",
+				mcp.Description("End of the window, as an ISO 8601 timestamp or date (default: now)"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description(fmt.Sprintf("Most items to list in each section, up to %d", maxDigestItems)),
+				mcp.Min(1),
+				mcp.Max(maxDigestItems),
+				mcp.DefaultNumber(10),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 10)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limit < 1 || limit > maxDigestItems {
+				return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxDigestItems)), nil
+			}
+			until := time.Now().UTC()
+			if value, err := OptionalParam[string](request, "until"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if value != "" {
+				if until, err = parseISOTimestamp(value); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid until: %s", err)), nil
+				}
+			}
+			since := until.Add(-defaultDigestWindow)
+			if value, err := OptionalParam[string](request, "since"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if value != "" {
+				if since, err = parseISOTimestamp(value); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since: %s", err)), nil
+				}
+			}
+			if !since.Before(until) {
+				return mcp.NewToolResultError("since must be before until"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			window := since.UTC().Format(time.RFC3339) + ".." + until.UTC().Format(time.RFC3339)
+			digest := RepositoryDigest{
+				Repository:         owner + "/" + repo,
+				Since:              since.UTC().Format(time.RFC3339),
+				Until:              until.UTC().Format(time.RFC3339),
+				Releases:           []DigestRelease{},
+				FailingWorkflows:   []DigestFailingWorkflow{},
+				NotableDiscussions: []DigestDiscussion{},
+			}
+
+			// Failures of one section are reported alongside the others rather than failing the whole digest.
+			digest.NewIssues, err = searchDigestItems(ctx, client, fmt.Sprintf("repo:%s/%s is:issue created:%s", owner, repo, window), "created", limit)
+			if err != nil {
+				digest.Errors = append(digest.Errors, fmt.Sprintf("failed to search new issues: %s", err))
+			}
+			digest.MergedPullRequests, err = searchDigestItems(ctx, client, fmt.Sprintf("repo:%s/%s is:pr is:merged merged:%s", owner, repo, window), "updated", limit)
+			if err != nil {
+				digest.Errors = append(digest.Errors, fmt.Sprintf("failed to search merged pull requests: %s", err))
+			}
+
+			releases, resp, err := client.Repositories.ListReleases(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				digest.Errors = append(digest.Errors, fmt.Sprintf("failed to list releases: %s", err))
+			} else {
+				_ = resp.Body.Close()
+				for _, release := range releases {
+					published := release.GetPublishedAt().Time
+					if release.GetDraft() || published.Before(since) || published.After(until) {
+						continue
+					}
+					digest.Releases = append(digest.Releases, DigestRelease{
+						TagName:     release.GetTagName(),
+						Name:        release.GetName(),
+						Prerelease:  release.GetPrerelease(),
+						Author:      release.GetAuthor().GetLogin(),
+						URL:         release.GetHTMLURL(),
+						PublishedAt: published.UTC().Format(time.RFC3339),
+					})
+				}
+				if len(digest.Releases) > limit {
+					digest.Releases = digest.Releases[:limit]
+				}
+			}
+
+			runs, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+				Status:      "failure",
+				Created:     window,
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				digest.Errors = append(digest.Errors, fmt.Sprintf("failed to list failed workflow runs: %s", err))
+			} else {
+				_ = resp.Body.Close()
+				digest.FailedRuns = runs.GetTotalCount()
+				digest.FailingWorkflows = failingWorkflows(runs.WorkflowRuns)
+				if len(digest.FailingWorkflows) > limit {
+					digest.FailingWorkflows = digest.FailingWorkflows[:limit]
+				}
+			}
+
+			var discussions digestDiscussionsQuery
+			err = gqlClient.Query(ctx, &discussions, map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"first": githubv4.Int(digestDiscussions),
+			})
+			if err != nil {
+				digest.Errors = append(digest.Errors, fmt.Sprintf("failed to list discussions: %s", err))
+			} else {
+				for _, d := range discussions.Repository.Discussions.Nodes {
+					updated := d.UpdatedAt.Time
+					if updated.Before(since) || updated.After(until) {
+						continue
+					}
+					digest.NotableDiscussions = append(digest.NotableDiscussions, DigestDiscussion{
+						Number:    int(d.Number),
+						Title:     string(d.Title),
+						Category:  string(d.Category.Name),
+						URL:       string(d.URL),
+						Comments:  int(d.Comments.TotalCount),
+						Upvotes:   int(d.UpvoteCount),
+						Answered:  bool(d.IsAnswered),
+						UpdatedAt: updated.UTC().Format(time.RFC3339),
+					})
+				}
+				// The most discussed first
+				sort.SliceStable(digest.NotableDiscussions, func(i, j int) bool {
+					a, b := digest.NotableDiscussions[i], digest.NotableDiscussions[j]
+					return a.Comments+a.Upvotes > b.Comments+b.Upvotes
+				})
+				if len(digest.NotableDiscussions) > limit {
+					digest.NotableDiscussions = digest.NotableDiscussions[:limit]
+				}
+			}
+
+			return MarshalledTextResult(digest), nil
+		}
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryDigest(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryDigest(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_digest", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+	window := "2025-03-01T00:00:00Z..2025-03-08T00:00:00Z"
+	inWindow := since.Add(48 * time.Hour)
+
+	search := func(t *testing.T) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query().Get("q")
+			switch {
+			case strings.Contains(q, "is:issue"):
+				assert.Equal(t, "repo:owner/repo is:issue created:"+window, q)
+				mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+					Total: github.Ptr(12),
+					Issues: []*github.Issue{
+						{Number: github.Ptr(7), Title: github.Ptr("Crash on start"), User: &github.User{Login: github.Ptr("octocat")}, CreatedAt: &github.Timestamp{Time: inWindow}},
+					},
+				})(w, r)
+			case strings.Contains(q, "is:pr"):
+				assert.Equal(t, "repo:owner/repo is:pr is:merged merged:"+window, q)
+				mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+					Total: github.Ptr(1),
+					Issues: []*github.Issue{
+						{
+							Number:           github.Ptr(8),
+							Title:            github.Ptr("Fix crash on start"),
+							CreatedAt:        &github.Timestamp{Time: since},
+							PullRequestLinks: &github.PullRequestLinks{MergedAt: &github.Timestamp{Time: inWindow}},
+						},
+					},
+				})(w, r)
+			default:
+				t.Errorf("unexpected search %q", q)
+			}
+		}
+	}
+	releases := []*github.RepositoryRelease{
+		{TagName: github.Ptr("v1.1.0"), PublishedAt: &github.Timestamp{Time: inWindow}},
+		{TagName: github.Ptr("v1.1.0-rc1"), Draft: github.Ptr(true), PublishedAt: &github.Timestamp{Time: inWindow}},
+		{TagName: github.Ptr("v1.0.0"), PublishedAt: &github.Timestamp{Time: since.AddDate(0, -1, 0)}},
+	}
+	runs := &github.WorkflowRuns{
+		TotalCount: github.Ptr(3),
+		WorkflowRuns: []*github.WorkflowRun{
+			{Name: github.Ptr("Lint"), HeadBranch: github.Ptr("main"), HTMLURL: github.Ptr("https://github.com/owner/repo/actions/runs/3"), CreatedAt: &github.Timestamp{Time: inWindow}},
+			{Name: github.Ptr("CI"), HeadBranch: github.Ptr("main"), HTMLURL: github.Ptr("https://github.com/owner/repo/actions/runs/2"), CreatedAt: &github.Timestamp{Time: inWindow}},
+			{Name: github.Ptr("CI"), HeadBranch: github.Ptr("feature"), CreatedAt: &github.Timestamp{Time: since}},
+		},
+	}
+	vars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"first": githubv4.Int(digestDiscussions),
+	}
+	discussion := func(number int, updatedAt time.Time, comments, upvotes int) map[string]any {
+		return map[string]any{
+			"number":      number,
+			"title":       "Discussion",
+			"url":         "https://github.com/owner/repo/discussions",
+			"updatedAt":   updatedAt.Format(time.RFC3339),
+			"upvoteCount": upvotes,
+			"isAnswered":  false,
+			"category":    map[string]any{"name": "Ideas"},
+			"comments":    map[string]any{"totalCount": comments},
+		}
+	}
+	discussions := githubv4mock.NewQueryMatcher(digestDiscussionsQuery{}, vars, githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussions": map[string]any{
+				"nodes": []any{
+					discussion(1, inWindow, 1, 0),
+					discussion(2, inWindow, 5, 3),
+					discussion(3, since.AddDate(0, 0, -1), 40, 40),
+				},
+			},
+		},
+	}))
+
+	t.Run("summarizes the activity of the window", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetSearchIssues, search(t)),
+			mock.WithRequestMatch(mock.GetReposReleasesByOwnerByRepo, releases),
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsRunsByOwnerByRepo,
+				expectQueryParams(t, map[string]string{
+					"status":   "failure",
+					"created":  window,
+					"per_page": "100",
+				}).andThen(
+					mockResponse(t, http.StatusOK, runs),
+				),
+			),
+		))
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(discussions))
+		_, handler := GetRepositoryDigest(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"since": "2025-03-01",
+			"until": "2025-03-08T00:00:00Z",
+		}))
+		require.NoError(t, err)
+
+		var digest RepositoryDigest
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &digest))
+		assert.Equal(t, "owner/repo", digest.Repository)
+		assert.Equal(t, since.Format(time.RFC3339), digest.Since)
+		assert.Equal(t, until.Format(time.RFC3339), digest.Until)
+		assert.Empty(t, digest.Errors)
+
+		require.NotNil(t, digest.NewIssues)
+		assert.Equal(t, 12, digest.NewIssues.Total)
+		require.Len(t, digest.NewIssues.Items, 1)
+		assert.Equal(t, "octocat", digest.NewIssues.Items[0].Author)
+		require.NotNil(t, digest.MergedPullRequests)
+		require.Len(t, digest.MergedPullRequests.Items, 1)
+		assert.Equal(t, inWindow.Format(time.RFC3339), digest.MergedPullRequests.Items[0].MergedAt)
+
+		require.Len(t, digest.Releases, 1)
+		assert.Equal(t, "v1.1.0", digest.Releases[0].TagName)
+
+		assert.Equal(t, 3, digest.FailedRuns)
+		require.Len(t, digest.FailingWorkflows, 2)
+		assert.Equal(t, "CI", digest.FailingWorkflows[0].Workflow)
+		assert.Equal(t, 2, digest.FailingWorkflows[0].Failures)
+		assert.Equal(t, []string{"main", "feature"}, digest.FailingWorkflows[0].Branches)
+		assert.Equal(t, "https://github.com/owner/repo/actions/runs/2", digest.FailingWorkflows[0].LatestRunURL)
+
+		require.Len(t, digest.NotableDiscussions, 2)
+		assert.Equal(t, 2, digest.NotableDiscussions[0].Number)
+		assert.Equal(t, 1, digest.NotableDiscussions[1].Number)
+	})
+
+	t.Run("reports sections that could not be collected", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.GetSearchIssues, search(t)),
+			mock.WithRequestMatch(mock.GetReposReleasesByOwnerByRepo, []*github.RepositoryRelease{}),
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsRunsByOwnerByRepo,
+				mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+			),
+		))
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(digestDiscussionsQuery{}, vars, githubv4mock.ErrorResponse("Discussions are disabled")),
+		))
+		_, handler := GetRepositoryDigest(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"since": "2025-03-01",
+			"until": "2025-03-08",
+		}))
+		require.NoError(t, err)
+
+		var digest RepositoryDigest
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &digest))
+		require.Len(t, digest.Errors, 2)
+		assert.Contains(t, digest.Errors[0], "failed to list failed workflow runs")
+		assert.Contains(t, digest.Errors[1], "failed to list discussions")
+		assert.Empty(t, digest.FailingWorkflows)
+		assert.Empty(t, digest.NotableDiscussions)
+		assert.Equal(t, 12, digest.NewIssues.Total)
+	})
+
+	t.Run("rejects an empty window", func(t *testing.T) {
+		_, handler := GetRepositoryDigest(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"since": "2025-03-08",
+			"until": "2025-03-01",
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "since must be before until")
+	})
+}
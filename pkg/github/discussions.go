@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v74/github"
@@ -252,7 +253,7 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal discussions: %w", err)
 			}
-			return mcp.NewToolResultText(string(out)), nil
+			return withPageInfo(mcp.NewToolResultText(string(out)), graphQLPageInfo(pageInfo.HasNextPage, pageInfo.EndCursor, github.Ptr(int(totalCount)))), nil
 		}
 }
 
@@ -387,6 +388,7 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 					Discussion struct {
 						Comments struct {
 							Nodes []struct {
+								ID   githubv4.ID
 								Body githubv4.String
 							}
 							PageInfo struct {
@@ -417,7 +419,10 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 
 			var comments []*github.IssueComment
 			for _, c := range q.Repository.Discussion.Comments.Nodes {
-				comments = append(comments, &github.IssueComment{Body: github.Ptr(string(c.Body))})
+				comments = append(comments, &github.IssueComment{
+					NodeID: github.Ptr(fmt.Sprint(c.ID)),
+					Body:   github.Ptr(string(c.Body)),
+				})
 			}
 
 			// Create response with pagination info
@@ -437,7 +442,8 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				return nil, fmt.Errorf("failed to marshal comments: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(out)), nil
+			page := q.Repository.Discussion.Comments
+			return withPageInfo(mcp.NewToolResultText(string(out)), graphQLPageInfo(bool(page.PageInfo.HasNextPage), page.PageInfo.EndCursor, &page.TotalCount)), nil
 		}
 }
 
@@ -526,6 +532,276 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal discussion categories: %w", err)
 			}
+			page := q.Repository.DiscussionCategories
+			return withPageInfo(mcp.NewToolResultText(string(out)), graphQLPageInfo(bool(page.PageInfo.HasNextPage), page.PageInfo.EndCursor, github.Ptr(int(page.TotalCount)))), nil
+		}
+}
+
+// discussionID looks up the node ID of a discussion, which mutations take instead of its number.
+func discussionID(ctx context.Context, client *githubv4.Client, owner, repo string, number int) (githubv4.ID, error) {
+	var q struct {
+		Repository struct {
+			Discussion struct {
+				ID githubv4.ID
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":            githubv4.String(owner),
+		"repo":             githubv4.String(repo),
+		"discussionNumber": githubv4.Int(number), // #nosec G115 - discussion numbers are always small positive integers
+	}
+	if err := client.Query(ctx, &q, vars); err != nil {
+		return nil, err
+	}
+	return q.Repository.Discussion.ID, nil
+}
+
+// CreateDiscussion creates a tool to start a discussion in a repository.
+func CreateDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_discussion",
+			mcp.WithDescription(t("TOOL_CREATE_DISCUSSION_DESCRIPTION", "Start a new discussion in a GitHub repository. Use list_discussion_categories to find the category ID.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DISCUSSION_USER_TITLE", "Create discussion"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("category",
+				mcp.Required(),
+				mcp.Description("ID of the discussion category"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Discussion title"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Discussion body in Markdown"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			category, err := RequiredParam[string](request, "category")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			var repoQuery struct {
+				Repository struct {
+					ID githubv4.ID
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			if err := client.Query(ctx, &repoQuery, map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+			}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to get repository",
+					err,
+				), nil
+			}
+
+			var mutation struct {
+				CreateDiscussion struct {
+					Discussion struct {
+						ID     githubv4.ID
+						Number githubv4.Int
+						URL    githubv4.String `graphql:"url"`
+					}
+				} `graphql:"createDiscussion(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.CreateDiscussionInput{
+				RepositoryID: repoQuery.Repository.ID,
+				CategoryID:   githubv4.ID(category),
+				Title:        githubv4.String(title),
+				Body:         githubv4.String(body),
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to create discussion",
+					err,
+				), nil
+			}
+
+			d := mutation.CreateDiscussion.Discussion
+			out, err := json.Marshal(map[string]any{
+				"id":     fmt.Sprint(d.ID),
+				"number": int(d.Number),
+				"url":    string(d.URL),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal discussion: %w", err)
+			}
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
+
+// AddDiscussionComment creates a tool to comment on a discussion or reply to one of its comments.
+func AddDiscussionComment(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_discussion_comment",
+			mcp.WithDescription(t("TOOL_ADD_DISCUSSION_COMMENT_DESCRIPTION", "Add a comment to a discussion, or reply to one of its comments")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_DISCUSSION_COMMENT_USER_TITLE", "Add discussion comment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("discussionNumber",
+				mcp.Required(),
+				mcp.Description("Discussion Number"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Comment body in Markdown"),
+			),
+			mcp.WithString("replyTo",
+				mcp.Description("ID of the top-level comment to reply to, as returned by get_discussion_comments"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "discussionNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			replyTo, err := OptionalParam[string](request, "replyTo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			id, err := discussionID(ctx, client, owner, repo, number)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to get discussion",
+					err,
+				), nil
+			}
+
+			input := githubv4.AddDiscussionCommentInput{
+				DiscussionID: id,
+				Body:         githubv4.String(body),
+			}
+			if replyTo != "" {
+				input.ReplyToID = githubv4.NewID(githubv4.ID(replyTo))
+			}
+
+			var mutation struct {
+				AddDiscussionComment struct {
+					Comment struct {
+						ID  githubv4.ID
+						URL githubv4.String `graphql:"url"`
+					}
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to add discussion comment",
+					err,
+				), nil
+			}
+
+			c := mutation.AddDiscussionComment.Comment
+			out, err := json.Marshal(map[string]any{
+				"id":  fmt.Sprint(c.ID),
+				"url": string(c.URL),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal comment: %w", err)
+			}
 			return mcp.NewToolResultText(string(out)), nil
 		}
 }
+
+// MarkAnswer creates a tool to mark a discussion comment as the answer to a question.
+func MarkAnswer(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("mark_answer",
+			mcp.WithDescription(t("TOOL_MARK_ANSWER_DESCRIPTION", "Mark a discussion comment as the answer to the discussion. Only works in categories that accept answers, such as Q&A.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MARK_ANSWER_USER_TITLE", "Mark discussion answer"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("commentId",
+				mcp.Required(),
+				mcp.Description("ID of the discussion comment, as returned by get_discussion_comments"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			commentID, err := RequiredParam[string](request, "commentId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			var mutation struct {
+				MarkDiscussionCommentAsAnswer struct {
+					Discussion struct {
+						Number githubv4.Int
+						URL    githubv4.String `graphql:"url"`
+					}
+				} `graphql:"markDiscussionCommentAsAnswer(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.MarkDiscussionCommentAsAnswerInput{
+				ID: githubv4.ID(commentID),
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to mark discussion comment as answer",
+					err,
+				), nil
+			}
+
+			d := mutation.MarkDiscussionCommentAsAnswer.Discussion
+			return mcp.NewToolResultText(fmt.Sprintf("marked comment as the answer to discussion #%d: %s", d.Number, d.URL)), nil
+		}
+}
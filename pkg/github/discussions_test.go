@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/shurcooL/githubv4"
@@ -570,7 +571,7 @@ func Test_GetDiscussionComments(t *testing.T) {
 	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"owner", "repo", "discussionNumber"})
 
 	// Use exact string query that matches implementation output
-	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
 
 	// Variables matching what GraphQL receives after JSON marshaling/unmarshaling
 	vars := map[string]interface{}{
@@ -586,8 +587,8 @@ func Test_GetDiscussionComments(t *testing.T) {
 			"discussion": map[string]any{
 				"comments": map[string]any{
 					"nodes": []map[string]any{
-						{"body": "This is the first comment"},
-						{"body": "This is the second comment"},
+						{"id": "DC_kwDOA0xdyM4AAAAA", "body": "This is the first comment"},
+						{"id": "DC_kwDOA0xdyM4AAAAB", "body": "This is the second comment"},
 					},
 					"pageInfo": map[string]any{
 						"hasNextPage":     false,
@@ -635,6 +636,7 @@ func Test_GetDiscussionComments(t *testing.T) {
 	for i, comment := range response.Comments {
 		assert.Equal(t, expectedBodies[i], *comment.Body)
 	}
+	assert.Equal(t, "DC_kwDOA0xdyM4AAAAA", response.Comments[0].GetNodeID())
 }
 
 func Test_ListDiscussionCategories(t *testing.T) {
@@ -776,3 +778,209 @@ func Test_ListDiscussionCategories(t *testing.T) {
 		})
 	}
 }
+
+func Test_CreateDiscussion(t *testing.T) {
+	toolDef, _ := CreateDiscussion(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(toolDef.Name, toolDef))
+	assert.Equal(t, "create_discussion", toolDef.Name)
+	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"owner", "repo", "category", "title", "body"})
+
+	repoQuery := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				ID githubv4.ID
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"id": "R_kgDOA0xdyA"},
+		}),
+	)
+	createMutation := struct {
+		CreateDiscussion struct {
+			Discussion struct {
+				ID     githubv4.ID
+				Number githubv4.Int
+				URL    githubv4.String `graphql:"url"`
+			}
+		} `graphql:"createDiscussion(input: $input)"`
+	}{}
+	createInput := githubv4.CreateDiscussionInput{
+		RepositoryID: "R_kgDOA0xdyA",
+		CategoryID:   "DIC_kwDOA0xdyM4CXYZ",
+		Title:        "How do I configure this?",
+		Body:         "I could not find it in the docs.",
+	}
+	requestArgs := map[string]interface{}{
+		"owner":    "owner",
+		"repo":     "repo",
+		"category": "DIC_kwDOA0xdyM4CXYZ",
+		"title":    "How do I configure this?",
+		"body":     "I could not find it in the docs.",
+	}
+
+	tests := []struct {
+		name        string
+		matchers    []githubv4mock.Matcher
+		expectError string
+	}{
+		{
+			name: "creates discussion",
+			matchers: []githubv4mock.Matcher{
+				repoQuery,
+				githubv4mock.NewMutationMatcher(createMutation, createInput, nil,
+					githubv4mock.DataResponse(map[string]any{
+						"createDiscussion": map[string]any{
+							"discussion": map[string]any{
+								"id":     "D_kwDOA0xdyM4AAAAC",
+								"number": 7,
+								"url":    "https://github.com/owner/repo/discussions/7",
+							},
+						},
+					}),
+				),
+			},
+		},
+		{
+			name: "category does not exist",
+			matchers: []githubv4mock.Matcher{
+				repoQuery,
+				githubv4mock.NewMutationMatcher(createMutation, createInput, nil,
+					githubv4mock.ErrorResponse("Could not resolve to a node with the global id of 'DIC_kwDOA0xdyM4CXYZ'"),
+				),
+			},
+			expectError: "failed to create discussion",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))
+			_, handler := CreateDiscussion(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectError)
+				return
+			}
+
+			var discussion map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &discussion))
+			assert.Equal(t, "D_kwDOA0xdyM4AAAAC", discussion["id"])
+			assert.Equal(t, float64(7), discussion["number"])
+			assert.Equal(t, "https://github.com/owner/repo/discussions/7", discussion["url"])
+		})
+	}
+}
+
+func Test_AddDiscussionComment(t *testing.T) {
+	toolDef, _ := AddDiscussionComment(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(toolDef.Name, toolDef))
+	assert.Equal(t, "add_discussion_comment", toolDef.Name)
+	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"owner", "repo", "discussionNumber", "body"})
+
+	replyTo := githubv4.ID("DC_kwDOA0xdyM4AAAAA")
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					Discussion struct {
+						ID githubv4.ID
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner":            githubv4.String("owner"),
+				"repo":             githubv4.String("repo"),
+				"discussionNumber": githubv4.Int(7),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"discussion": map[string]any{"id": "D_kwDOA0xdyM4AAAAC"},
+				},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				AddDiscussionComment struct {
+					Comment struct {
+						ID  githubv4.ID
+						URL githubv4.String `graphql:"url"`
+					}
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}{},
+			githubv4.AddDiscussionCommentInput{
+				DiscussionID: "D_kwDOA0xdyM4AAAAC",
+				Body:         "Set it in the config file.",
+				ReplyToID:    &replyTo,
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"addDiscussionComment": map[string]any{
+					"comment": map[string]any{
+						"id":  "DC_kwDOA0xdyM4AAAAD",
+						"url": "https://github.com/owner/repo/discussions/7#discussioncomment-4",
+					},
+				},
+			}),
+		),
+	))
+	_, handler := AddDiscussionComment(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"discussionNumber": float64(7),
+		"body":             "Set it in the config file.",
+		"replyTo":          "DC_kwDOA0xdyM4AAAAA",
+	}))
+	require.NoError(t, err)
+
+	var comment map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &comment))
+	assert.Equal(t, "DC_kwDOA0xdyM4AAAAD", comment["id"])
+}
+
+func Test_MarkAnswer(t *testing.T) {
+	toolDef, _ := MarkAnswer(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(toolDef.Name, toolDef))
+	assert.Equal(t, "mark_answer", toolDef.Name)
+	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"commentId"})
+
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(
+			struct {
+				MarkDiscussionCommentAsAnswer struct {
+					Discussion struct {
+						Number githubv4.Int
+						URL    githubv4.String `graphql:"url"`
+					}
+				} `graphql:"markDiscussionCommentAsAnswer(input: $input)"`
+			}{},
+			githubv4.MarkDiscussionCommentAsAnswerInput{
+				ID: "DC_kwDOA0xdyM4AAAAD",
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"markDiscussionCommentAsAnswer": map[string]any{
+					"discussion": map[string]any{
+						"number": 7,
+						"url":    "https://github.com/owner/repo/discussions/7",
+					},
+				},
+			}),
+		),
+	))
+	_, handler := MarkAnswer(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"commentId": "DC_kwDOA0xdyM4AAAAD",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "marked comment as the answer to discussion #7: https://github.com/owner/repo/discussions/7", getTextResult(t, result).Text)
+}
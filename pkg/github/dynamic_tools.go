@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -12,11 +14,14 @@ import (
 )
 
 func ToolsetEnum(toolsetGroup *toolsets.ToolsetGroup) mcp.PropertyOption {
-	toolsetNames := make([]string, 0, len(toolsetGroup.Toolsets))
-	for name := range toolsetGroup.Toolsets {
-		toolsetNames = append(toolsetNames, name)
-	}
-	return mcp.Enum(toolsetNames...)
+	return mcp.Enum(sortedToolsetNames(toolsetGroup)...)
+}
+
+// sortedToolsetNames returns the names of the toolsets in the group in alphabetical order.
+func sortedToolsetNames(toolsetGroup *toolsets.ToolsetGroup) []string {
+	toolsetNames := slices.Collect(maps.Keys(toolsetGroup.Toolsets))
+	slices.Sort(toolsetNames)
+	return toolsetNames
 }
 
 func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
@@ -51,14 +56,87 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 
 			// caution: this currently affects the global tools and notifies all clients:
 			//
-			// Send notification to all initialized sessions
-			// s.sendNotificationToAllClients("notifications/tools/list_changed", nil)
+			// AddTools sends notifications/tools/list_changed to all initialized sessions
 			s.AddTools(toolset.GetActiveTools()...)
+			if templates := toolset.GetActiveResourceTemplates(); len(templates) > 0 {
+				s.AddResourceTemplates(templates...)
+			}
+			if prompts := toolset.GetActivePrompts(); len(prompts) > 0 {
+				s.AddPrompts(prompts...)
+			}
 
 			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled", toolsetName)), nil
 		}
 }
 
+func DisableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("disable_toolset",
+			mcp.WithDescription(t("TOOL_DISABLE_TOOLSET_DESCRIPTION", "Disable one of the enabled sets of tools the GitHub MCP server provides, removing its tools for every client of the server. Its resource templates remain available. Use this to drop toolsets that are no longer needed for the task at hand")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title: t("TOOL_DISABLE_TOOLSET_USER_TITLE", "Disable a toolset"),
+				// Does not modify GitHub data, but removes tools other clients may be using
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("toolset",
+				mcp.Required(),
+				mcp.Description("The name of the toolset to disable"),
+				ToolsetEnum(toolsetGroup),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			toolsetName, err := RequiredParam[string](request, "toolset")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolset := toolsetGroup.Toolsets[toolsetName]
+			if toolset == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
+			}
+			if !toolset.Enabled {
+				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already disabled", toolsetName)), nil
+			}
+
+			toolset.Enabled = false
+
+			// Like enabling, this affects the global tools of every session and notifies all
+			// clients. Resource templates stay registered, as the server offers no way to remove
+			// them.
+			// Tools also offered by another enabled toolset, such as list_releases in repos and
+			// releases, are kept.
+			toolNames := make([]string, 0, len(toolset.GetAvailableTools()))
+			for _, st := range toolset.GetAvailableTools() {
+				if !offeredByEnabledToolset(toolsetGroup, st.Tool.Name) {
+					toolNames = append(toolNames, st.Tool.Name)
+				}
+			}
+			s.DeleteTools(toolNames...)
+			if prompts := toolset.GetAvailablePrompts(); len(prompts) > 0 {
+				promptNames := make([]string, 0, len(prompts))
+				for _, p := range prompts {
+					promptNames = append(promptNames, p.Prompt.Name)
+				}
+				s.DeletePrompts(promptNames...)
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s disabled", toolsetName)), nil
+		}
+}
+
+// offeredByEnabledToolset reports whether an enabled toolset of toolsetGroup offers the tool.
+func offeredByEnabledToolset(toolsetGroup *toolsets.ToolsetGroup, name string) bool {
+	for _, ts := range toolsetGroup.Toolsets {
+		if !ts.Enabled {
+			continue
+		}
+		for _, st := range ts.GetAvailableTools() {
+			if st.Tool.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_available_toolsets",
 			mcp.WithDescription(t("TOOL_LIST_AVAILABLE_TOOLSETS_DESCRIPTION", "List all available toolsets this GitHub MCP server can offer, providing the enabled status of each. Use this when a task could be achieved with a GitHub tool and the currently available tools aren't enough. Call get_toolset_tools with these toolset names to discover specific tools you can call")),
@@ -72,7 +150,8 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 
 			payload := []map[string]string{}
 
-			for name, ts := range toolsetGroup.Toolsets {
+			for _, name := range sortedToolsetNames(toolsetGroup) {
+				ts := toolsetGroup.Toolsets[name]
 				{
 					t := map[string]string{
 						"name":              name,
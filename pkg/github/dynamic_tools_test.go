@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notificationSession is an initialized client session that records the notifications sent to it.
+type notificationSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *notificationSession) SessionID() string { return "test-session" }
+func (s *notificationSession) Initialize()       {}
+func (s *notificationSession) Initialized() bool { return true }
+func (s *notificationSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+// newDynamicTestServer returns a server with one registered session and a toolset group
+// holding disabled "users" and "gists" toolsets.
+func newDynamicTestServer(t *testing.T) (*server.MCPServer, *toolsets.ToolsetGroup, *notificationSession) {
+	t.Helper()
+
+	s := NewServer("test")
+	session := &notificationSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, s.RegisterSession(context.Background(), session))
+
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("users", "GitHub user related tools").
+		AddReadTools(
+			toolsets.NewServerTool(GetUser(stubGetClientFn(nil), translations.NullTranslationHelper)),
+			toolsets.NewServerTool(ListFollowers(stubGetClientFn(nil), translations.NullTranslationHelper)),
+		))
+	tsg.AddToolset(toolsets.NewToolset("gists", "GitHub Gist related tools"))
+	return s, tsg, session
+}
+
+// drainNotifications returns the methods of the notifications received so far.
+func drainNotifications(session *notificationSession) []string {
+	var methods []string
+	for {
+		select {
+		case n := <-session.notifications:
+			methods = append(methods, n.Method)
+		default:
+			return methods
+		}
+	}
+}
+
+func Test_ListAvailableToolsets(t *testing.T) {
+	_, tsg, _ := newDynamicTestServer(t)
+	require.NoError(t, tsg.EnableToolset("gists"))
+
+	tool, handler := ListAvailableToolsets(tsg, translations.NullTranslationHelper)
+	assert.Equal(t, "list_available_toolsets", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var toolsetList []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &toolsetList))
+	require.Len(t, toolsetList, 2)
+	// Toolsets are listed in name order
+	assert.Equal(t, "gists", toolsetList[0]["name"])
+	assert.Equal(t, "true", toolsetList[0]["currently_enabled"])
+	assert.Equal(t, "users", toolsetList[1]["name"])
+	assert.Equal(t, "false", toolsetList[1]["currently_enabled"])
+}
+
+func Test_GetToolsetsTools(t *testing.T) {
+	_, tsg, _ := newDynamicTestServer(t)
+
+	tool, handler := GetToolsetsTools(tsg, translations.NullTranslationHelper)
+	assert.Equal(t, "get_toolset_tools", tool.Name)
+	assert.Equal(t, []string{"gists", "users"}, tool.InputSchema.Properties["toolset"].(map[string]any)["enum"])
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"toolset": "users"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var tools []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &tools))
+	require.Len(t, tools, 2)
+	assert.Equal(t, "get_user", tools[0]["name"])
+	assert.Equal(t, "list_followers", tools[1]["name"])
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{"toolset": "missing"}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, "Toolset missing not found", getErrorResult(t, result).Text)
+}
+
+func Test_EnableAndDisableToolset(t *testing.T) {
+	s, tsg, session := newDynamicTestServer(t)
+
+	enableTool, enable := EnableToolset(s, tsg, translations.NullTranslationHelper)
+	disableTool, disable := DisableToolset(s, tsg, translations.NullTranslationHelper)
+	assert.Equal(t, "enable_toolset", enableTool.Name)
+	assert.Equal(t, "disable_toolset", disableTool.Name)
+	assert.False(t, *disableTool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, disableTool.InputSchema.Required, []string{"toolset"})
+
+	// Enabling registers the toolset's tools and tells clients the tool list changed
+	result, err := enable(context.Background(), createMCPRequest(map[string]any{"toolset": "users"}))
+	require.NoError(t, err)
+	assert.Equal(t, "Toolset users enabled", getTextResult(t, result).Text)
+	assert.True(t, tsg.IsEnabled("users"))
+	assert.NotNil(t, s.GetTool("get_user"))
+	assert.NotNil(t, s.GetTool("list_followers"))
+	assert.Contains(t, drainNotifications(session), mcp.MethodNotificationToolsListChanged)
+
+	result, err = enable(context.Background(), createMCPRequest(map[string]any{"toolset": "users"}))
+	require.NoError(t, err)
+	assert.Equal(t, "Toolset users is already enabled", getTextResult(t, result).Text)
+
+	// Disabling removes them again
+	result, err = disable(context.Background(), createMCPRequest(map[string]any{"toolset": "users"}))
+	require.NoError(t, err)
+	assert.Equal(t, "Toolset users disabled", getTextResult(t, result).Text)
+	assert.False(t, tsg.IsEnabled("users"))
+	assert.Nil(t, s.GetTool("get_user"))
+	assert.Nil(t, s.GetTool("list_followers"))
+	assert.Contains(t, drainNotifications(session), mcp.MethodNotificationToolsListChanged)
+
+	result, err = disable(context.Background(), createMCPRequest(map[string]any{"toolset": "users"}))
+	require.NoError(t, err)
+	assert.Equal(t, "Toolset users is already disabled", getTextResult(t, result).Text)
+	assert.Empty(t, drainNotifications(session))
+
+	result, err = disable(context.Background(), createMCPRequest(map[string]any{"toolset": "missing"}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, "Toolset missing not found", getErrorResult(t, result).Text)
+}
+
+func Test_DisableToolset_KeepsToolsOfOtherEnabledToolsets(t *testing.T) {
+	s, tsg, _ := newDynamicTestServer(t)
+	tsg.AddToolset(toolsets.NewToolset("profiles", "Tools shared with users").
+		AddReadTools(toolsets.NewServerTool(GetUser(stubGetClientFn(nil), translations.NullTranslationHelper))))
+
+	_, enable := EnableToolset(s, tsg, translations.NullTranslationHelper)
+	_, disable := DisableToolset(s, tsg, translations.NullTranslationHelper)
+	for _, toolset := range []string{"users", "profiles"} {
+		_, err := enable(context.Background(), createMCPRequest(map[string]any{"toolset": toolset}))
+		require.NoError(t, err)
+	}
+
+	_, err := disable(context.Background(), createMCPRequest(map[string]any{"toolset": "users"}))
+	require.NoError(t, err)
+	assert.Nil(t, s.GetTool("list_followers"))
+	assert.NotNil(t, s.GetTool("get_user"))
+}
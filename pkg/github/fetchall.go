@@ -0,0 +1,206 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// fetchAllParam is the parameter that AddFetchAllParameter adds to paginated tools.
+const fetchAllParam = "fetch_all"
+
+// FetchAllLimits bounds the results of list tools called with fetch_all.
+type FetchAllLimits struct {
+	// MaxItems is the most items returned
+	MaxItems int
+	// MaxBytes is the most bytes of JSON the items returned may take up
+	MaxBytes int
+}
+
+// DefaultFetchAllLimits are the limits of fetch_all unless configured otherwise.
+var DefaultFetchAllLimits = FetchAllLimits{MaxItems: 1000, MaxBytes: 1 << 20}
+
+// pageItems finds the items of a page of results, which are either the page itself if it is a
+// JSON array, or its only array field if it is an object, such as workflow_runs. The field is
+// empty for arrays, and ok is false if the items cannot be told apart.
+func pageItems(v any) (items []any, field string, ok bool) {
+	switch v := v.(type) {
+	case []any:
+		return v, "", true
+	case map[string]any:
+		for name, value := range v {
+			if arr, isArray := value.([]any); isArray {
+				if ok {
+					return nil, "", false
+				}
+				items, field, ok = arr, name, true
+			}
+		}
+		return items, field, ok
+	default:
+		return nil, "", false
+	}
+}
+
+// resultText returns the text of a result with a single text content.
+func resultText(result *mcp.CallToolResult) (string, bool) {
+	if len(result.Content) != 1 {
+		return "", false
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	return text.Text, ok
+}
+
+// withFetchAllParameter adds the fetch_all parameter to a paginated tool, walking its pages
+// until there are none left or the limits are reached and returning their items together.
+func withFetchAllParameter(tool server.ServerTool, limits FetchAllLimits) server.ServerTool {
+	properties := maps.Clone(tool.Tool.InputSchema.Properties)
+	properties[fetchAllParam] = map[string]any{
+		"type":        "boolean",
+		"description": fmt.Sprintf("Fetch all pages and return their items together, up to %d items. Use with filters that keep the results small", limits.MaxItems),
+	}
+	tool.Tool.InputSchema.Properties = properties
+	_, cursorPaginated := properties["after"]
+	_, pagePaginated := properties["page"]
+
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fetchAll, err := OptionalParam[bool](request, fetchAllParam)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if !fetchAll {
+			return next(ctx, request)
+		}
+
+		args := maps.Clone(request.GetArguments())
+		delete(args, fetchAllParam)
+		if _, ok := args["perPage"]; !ok {
+			args["perPage"] = float64(100)
+		}
+		progress := newProgressReporter(ctx, request, 0)
+
+		var (
+			items     []any
+			size      int
+			pages     int
+			truncated bool
+			last      any
+			field     string
+			result    *mcp.CallToolResult
+		)
+		for {
+			request.Params.Arguments = args
+			result, err = next(ctx, request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+			pages++
+
+			last = nil
+			text, ok := resultText(result)
+			if ok {
+				ok = json.Unmarshal([]byte(text), &last) == nil
+			}
+			var pageItemList []any
+			if ok {
+				pageItemList, field, ok = pageItems(last)
+			}
+			if !ok {
+				if pages == 1 {
+					// Results that are not a list are returned as they are
+					return result, nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("fetch_all failed: page %d of %s is not a list", pages, tool.Tool.Name)), nil
+			}
+
+			for _, item := range pageItemList {
+				data, err := json.Marshal(item)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal item: %w", err)
+				}
+				if len(items) >= limits.MaxItems || size+len(data) > limits.MaxBytes {
+					truncated = true
+					break
+				}
+				items = append(items, item)
+				size += len(data)
+			}
+			progress.step(ctx, fmt.Sprintf("Fetched page %d, %d items so far", pages, len(items)))
+
+			var pageInfo PageInfo
+			if result.Meta != nil {
+				pageInfo, _ = result.Meta.AdditionalFields["pageInfo"].(PageInfo)
+			}
+			if truncated || !pageInfo.HasNextPage {
+				// Tools that do not report their pages have only the one
+				break
+			}
+			args = maps.Clone(args)
+			if pageInfo.NextCursor != "" && cursorPaginated {
+				args["after"] = pageInfo.NextCursor
+			} else if pageInfo.NextPage != 0 && pagePaginated {
+				args["page"] = float64(pageInfo.NextPage)
+			} else {
+				truncated = true
+				break
+			}
+		}
+
+		if items == nil {
+			items = []any{}
+		}
+		var aggregated any = items
+		if field != "" {
+			// Other fields, such as counts, are those of the last page
+			object := last.(map[string]any)
+			object[field] = items
+			aggregated = object
+		}
+		data, err := json.Marshal(aggregated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+		combined := mcp.NewToolResultText(string(data))
+		if result.Meta != nil {
+			combined.Meta = &mcp.Meta{AdditionalFields: maps.Clone(result.Meta.AdditionalFields)}
+		}
+		return withFetchAllMeta(combined, pages, len(items), truncated), nil
+	}
+	return tool
+}
+
+// withFetchAllMeta describes the pages fetched for a fetch_all call in the result metadata.
+func withFetchAllMeta(result *mcp.CallToolResult, pages, items int, truncated bool) *mcp.CallToolResult {
+	setResultMeta(result, "fetch_all", map[string]any{
+		"pages":     pages,
+		"items":     items,
+		"truncated": truncated,
+	})
+	return result
+}
+
+// AddFetchAllParameter adds a fetch_all parameter to every read-only tool in tsg that is paged,
+// so that agents can get a whole list in one call rather than paging through it themselves.
+// The items returned are bounded by limits.
+func AddFetchAllParameter(tsg *toolsets.ToolsetGroup, limits FetchAllLimits) {
+	tsg.MapTools(func(tool server.ServerTool) server.ServerTool {
+		readOnly := tool.Tool.Annotations.ReadOnlyHint
+		if readOnly == nil || !*readOnly {
+			return tool
+		}
+		properties := tool.Tool.InputSchema.Properties
+		if _, ok := properties[fetchAllParam]; ok {
+			return tool
+		}
+		if _, ok := properties["perPage"]; !ok {
+			return tool
+		}
+		return withFetchAllParameter(tool, limits)
+	})
+}
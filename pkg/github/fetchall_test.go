@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fetchAllTool adds fetch_all to tools and returns them by name.
+func fetchAllTool(t *testing.T, limits FetchAllLimits, tools ...server.ServerTool) map[string]server.ServerTool {
+	t.Helper()
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("test", "Test tools").AddReadTools(tools...))
+	AddFetchAllParameter(tsg, limits)
+
+	byName := make(map[string]server.ServerTool)
+	for _, tool := range tsg.Toolsets["test"].GetAvailableTools() {
+		byName[tool.Tool.Name] = tool
+	}
+	return byName
+}
+
+func Test_FetchAll_REST(t *testing.T) {
+	var perPages []string
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposBranchesByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				perPages = append(perPages, r.URL.Query().Get("per_page"))
+				switch r.URL.Query().Get("page") {
+				case "", "1":
+					w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/branches?page=2>; rel="next"`)
+					_, _ = w.Write([]byte(`[{"name":"main"},{"name":"develop"}]`))
+				default:
+					_, _ = w.Write([]byte(`[{"name":"release"}]`))
+				}
+			}),
+		),
+	))
+	tools := fetchAllTool(t, DefaultFetchAllLimits, toolsets.NewServerTool(ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)))
+	tool := tools["list_branches"]
+	assert.Contains(t, tool.Tool.InputSchema.Properties, fetchAllParam)
+
+	result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":     "owner",
+		"repo":      "repo",
+		"fetch_all": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var branches []*github.Branch
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &branches))
+	require.Len(t, branches, 3)
+	assert.Equal(t, "release", branches[2].GetName())
+	assert.Equal(t, []string{"100", "100"}, perPages, "pages are as large as they can be")
+	assert.Equal(t, map[string]any{"pages": 2, "items": 3, "truncated": false}, result.Meta.AdditionalFields["fetch_all"])
+	assert.Equal(t, PageInfo{}, result.Meta.AdditionalFields["pageInfo"], "the page info is that of the last page")
+}
+
+// pagedRunsHandler pages through runs numbered 1 to total, as a GraphQL tool would.
+func pagedRunsHandler(total int) server.ToolHandlerFunc {
+	return func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		perPage, err := OptionalIntParamWithDefault(request, "perPage", 30)
+		if err != nil {
+			return nil, err
+		}
+		start := 0
+		if after, _ := OptionalParam[string](request, "after"); after != "" {
+			_, _ = fmt.Sscanf(after, "run-%d", &start)
+		}
+		runs := []map[string]any{}
+		for n := start + 1; n <= min(start+perPage, total); n++ {
+			runs = append(runs, map[string]any{"id": n})
+		}
+		end := start + len(runs)
+		result := MarshalledTextResult(map[string]any{"total_count": total, "workflow_runs": runs})
+		return withPageInfo(result, graphQLPageInfo(end < total, githubv4.String(fmt.Sprintf("run-%d", end)), &total)), nil
+	}
+}
+
+func Test_FetchAll_Limits(t *testing.T) {
+	readOnly := mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})
+	tools := fetchAllTool(t, FetchAllLimits{MaxItems: 250, MaxBytes: 1 << 20},
+		toolsets.NewServerTool(mcp.NewTool("list_runs", readOnly, WithCursorPagination()), pagedRunsHandler(1000)),
+		toolsets.NewServerTool(mcp.NewTool("list_few_runs", readOnly, WithCursorPagination()), pagedRunsHandler(150)),
+	)
+
+	call := func(name string, args map[string]any) (map[string]any, *mcp.CallToolResult) {
+		result, err := tools[name].Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		return response, result
+	}
+
+	response, result := call("list_few_runs", map[string]any{"fetch_all": true})
+	assert.Len(t, response["workflow_runs"], 150)
+	assert.Equal(t, float64(150), response["total_count"])
+	assert.Equal(t, map[string]any{"pages": 2, "items": 150, "truncated": false}, result.Meta.AdditionalFields["fetch_all"])
+
+	response, result = call("list_runs", map[string]any{"fetch_all": true, "perPage": float64(100)})
+	assert.Len(t, response["workflow_runs"], 250)
+	assert.Equal(t, map[string]any{"pages": 3, "items": 250, "truncated": true}, result.Meta.AdditionalFields["fetch_all"])
+
+	response, _ = call("list_runs", map[string]any{"perPage": float64(10)})
+	assert.Len(t, response["workflow_runs"], 10, "without fetch_all a single page is returned")
+
+	// The byte limit applies as well as the item limit
+	tools = fetchAllTool(t, FetchAllLimits{MaxItems: 1000, MaxBytes: 100},
+		toolsets.NewServerTool(mcp.NewTool("list_runs", readOnly, WithCursorPagination()), pagedRunsHandler(1000)))
+	response, result = call("list_runs", map[string]any{"fetch_all": true})
+	assert.Len(t, response["workflow_runs"], 12, `runs 1 to 12 take up 99 bytes of JSON`)
+	assert.Equal(t, true, result.Meta.AdditionalFields["fetch_all"].(map[string]any)["truncated"])
+}
+
+func Test_AddFetchAllParameter(t *testing.T) {
+	handler := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("[]"), nil
+	}
+	tools := fetchAllTool(t, DefaultFetchAllLimits,
+		toolsets.NewServerTool(mcp.NewTool("list_things", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)}), WithPagination()), handler),
+		toolsets.NewServerTool(mcp.NewTool("get_thing", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})), handler),
+	)
+	assert.Contains(t, tools["list_things"].Tool.InputSchema.Properties, fetchAllParam)
+	assert.NotContains(t, tools["get_thing"].Tool.InputSchema.Properties, fetchAllParam, "tools without pages are left alone")
+}
@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// fieldsParam is the parameter that AddFieldsParameter adds to read-only tools.
+const fieldsParam = "fields"
+
+// fieldTree holds the field paths to keep, by field name. A nil subtree keeps the whole field.
+type fieldTree map[string]fieldTree
+
+// parseFields parses a comma separated list of dot paths, such as number,user.login.
+func parseFields(s string) fieldTree {
+	tree := fieldTree{}
+	for _, path := range strings.Split(s, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		node := tree
+		names := strings.Split(path, ".")
+		for i, name := range names {
+			sub, seen := node[name]
+			if seen && sub == nil {
+				// A shorter path already keeps the whole field
+				break
+			}
+			if i == len(names)-1 {
+				node[name] = nil
+				break
+			}
+			if sub == nil {
+				sub = fieldTree{}
+				node[name] = sub
+			}
+			node = sub
+		}
+	}
+	return tree
+}
+
+// filter keeps the fields of v in the tree. Arrays are filtered element by element, so that a
+// path continues into the objects of an array, and values that are not objects are kept whole.
+func (tree fieldTree) filter(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(tree))
+		for name, sub := range tree {
+			value, ok := v[name]
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				out[name] = value
+			} else {
+				out[name] = sub.filter(value)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = tree.filter(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// filterResultFields trims the JSON text of a result to the fields in the tree. Text that is not
+// JSON is left as is.
+func filterResultFields(result *mcp.CallToolResult, tree fieldTree) {
+	for i, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal([]byte(text.Text), &v); err != nil {
+			continue
+		}
+		filtered, err := json.Marshal(tree.filter(v))
+		if err != nil {
+			continue
+		}
+		text.Text = string(filtered)
+		result.Content[i] = text
+	}
+}
+
+// withFieldsParameter adds the fields parameter to a tool, trimming the JSON it returns to the
+// requested fields.
+func withFieldsParameter(tool server.ServerTool) server.ServerTool {
+	properties := maps.Clone(tool.Tool.InputSchema.Properties)
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	properties[fieldsParam] = map[string]any{
+		"type":        "string",
+		"description": "Comma separated fields to return, as dot paths into nested objects and arrays, e.g. number,title,user.login,labels.name. Returns all fields if empty",
+	}
+	tool.Tool.InputSchema.Properties = properties
+
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fields, err := OptionalParam[string](request, fieldsParam)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		if tree := parseFields(fields); len(tree) > 0 {
+			filterResultFields(result, tree)
+		}
+		return result, nil
+	}
+	return tool
+}
+
+// AddFieldsParameter adds a fields parameter to every read-only tool in tsg that does not have
+// one, so that agents can ask for only the fields they need rather than GitHub's full objects.
+func AddFieldsParameter(tsg *toolsets.ToolsetGroup) {
+	tsg.MapTools(func(tool server.ServerTool) server.ServerTool {
+		readOnly := tool.Tool.Annotations.ReadOnlyHint
+		if readOnly == nil || !*readOnly {
+			return tool
+		}
+		if _, ok := tool.Tool.InputSchema.Properties[fieldsParam]; ok {
+			return tool
+		}
+		return withFieldsParameter(tool)
+	})
+}
@@ -0,0 +1,81 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseFields(t *testing.T) {
+	assert.Equal(t, fieldTree{
+		"number": nil,
+		"user":   fieldTree{"login": nil},
+		"labels": nil,
+	}, parseFields(" number, user.login,labels.name,labels,, "))
+	assert.Empty(t, parseFields(""))
+}
+
+func Test_fieldTreeFilter(t *testing.T) {
+	issues := []any{
+		map[string]any{
+			"number":   float64(1),
+			"title":    "Bug",
+			"html_url": "https://github.com/octocat/hello-world/issues/1",
+			"user":     map[string]any{"login": "octocat", "avatar_url": "https://avatars.githubusercontent.com/u/1"},
+			"labels": []any{
+				map[string]any{"name": "bug", "color": "d73a4a"},
+			},
+		},
+		"not an object",
+	}
+
+	filtered := parseFields("number,user.login,labels.name,milestone.title").filter(issues)
+	assert.Equal(t, []any{
+		map[string]any{
+			"number": float64(1),
+			"user":   map[string]any{"login": "octocat"},
+			"labels": []any{map[string]any{"name": "bug"}},
+		},
+		"not an object",
+	}, filtered)
+}
+
+func Test_AddFieldsParameter(t *testing.T) {
+	readOnly, write := true, false
+	handler := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(`{"number":1,"title":"Bug","user":{"login":"octocat","id":1}}`), nil
+	}
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("issues", "Issue tools").
+		AddReadTools(
+			toolsets.NewServerTool(mcp.NewTool("get_issue", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly})), handler),
+			toolsets.NewServerTool(GetMe(stubGetClientFn(nil), translations.NullTranslationHelper)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(mcp.NewTool("create_issue", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &write})), handler),
+		))
+
+	AddFieldsParameter(tsg)
+	tools := make(map[string]server.ServerTool)
+	for _, tool := range tsg.Toolsets["issues"].GetAvailableTools() {
+		tools[tool.Tool.Name] = tool
+	}
+	assert.Contains(t, tools["get_issue"].Tool.InputSchema.Properties, "fields")
+	assert.Contains(t, tools["get_me"].Tool.InputSchema.Properties, "fields")
+	assert.NotContains(t, tools["create_issue"].Tool.InputSchema.Properties, "fields", "write tools are left alone")
+
+	call := func(args map[string]any) string {
+		result, err := tools["get_issue"].Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		return getTextResult(t, result).Text
+	}
+	assert.JSONEq(t, `{"number":1,"user":{"login":"octocat"}}`, call(map[string]any{"fields": "number,user.login"}))
+	assert.JSONEq(t, `{"number":1,"title":"Bug","user":{"login":"octocat","id":1}}`, call(map[string]any{}))
+}
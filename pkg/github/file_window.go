@@ -0,0 +1,124 @@
+package github
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultFileMaxBytes is how much of a text file get_file_contents returns unless asked for
+// more, so that large files do not use up the model's context.
+const defaultFileMaxBytes = 100 * 1024
+
+// fileWindowOptions select the part of a text file that get_file_contents returns.
+type fileWindowOptions struct {
+	StartLine int
+	// EndLine is the last line to return, or 0 for the end of the file.
+	EndLine int
+	// MaxBytes cuts the returned lines short, or 0 for no limit.
+	MaxBytes int
+}
+
+// FileWindow describes the part of a text file that get_file_contents returned, if it did not
+// return all of it.
+type FileWindow struct {
+	StartLine  int `json:"start_line"`
+	EndLine    int `json:"end_line"`
+	TotalLines int `json:"total_lines"`
+	TotalBytes int `json:"total_bytes"`
+	// Truncated is set if fewer lines than asked for were returned, because of max_bytes.
+	Truncated bool `json:"truncated"`
+	// NextStartLine is the start_line to continue reading the file from, if there is more.
+	NextStartLine int `json:"next_start_line,omitempty"`
+}
+
+// fileWindowParams returns the start_line, end_line and max_bytes parameters.
+func fileWindowParams(r mcp.CallToolRequest) (fileWindowOptions, error) {
+	startLine, err := OptionalIntParamWithDefault(r, "start_line", 1)
+	if err != nil {
+		return fileWindowOptions{}, err
+	}
+	endLine, err := OptionalIntParam(r, "end_line")
+	if err != nil {
+		return fileWindowOptions{}, err
+	}
+	maxBytes, err := OptionalIntParamWithDefault(r, "max_bytes", defaultFileMaxBytes)
+	if err != nil {
+		return fileWindowOptions{}, err
+	}
+	switch {
+	case startLine < 1:
+		return fileWindowOptions{}, errors.New("start_line must be at least 1")
+	case endLine != 0 && endLine < startLine:
+		return fileWindowOptions{}, errors.New("end_line must not be before start_line")
+	case maxBytes < 0:
+		return fileWindowOptions{}, errors.New("max_bytes must not be negative")
+	}
+	return fileWindowOptions{StartLine: startLine, EndLine: endLine, MaxBytes: maxBytes}, nil
+}
+
+// windowFile returns the lines of content that opts select, cut short after the last whole line
+// that fits into opts.MaxBytes. A first line longer than that is cut itself, so that something
+// is returned. The window is nil if all of content is returned.
+func windowFile(content []byte, opts fileWindowOptions) ([]byte, *FileWindow, error) {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	// A trailing newline ends the last line rather than starting another
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if opts.StartLine > max(len(lines), 1) {
+		return nil, nil, fmt.Errorf("start_line %d is past the end of the file, which has %d lines", opts.StartLine, len(lines))
+	}
+	end := len(lines)
+	if opts.EndLine != 0 && opts.EndLine < end {
+		end = opts.EndLine
+	}
+
+	window := &FileWindow{StartLine: opts.StartLine, EndLine: opts.StartLine - 1, TotalLines: len(lines), TotalBytes: len(content)}
+	var out []byte
+	for i := opts.StartLine - 1; i < end; i++ {
+		if opts.MaxBytes > 0 && len(out)+len(lines[i]) > opts.MaxBytes {
+			if len(out) == 0 {
+				out = truncateUTF8(lines[i], opts.MaxBytes)
+				window.EndLine = i + 1
+			}
+			window.Truncated = true
+			break
+		}
+		out = append(out, lines[i]...)
+		window.EndLine = i + 1
+	}
+	if window.EndLine < window.TotalLines {
+		window.NextStartLine = window.EndLine + 1
+	}
+	if window.StartLine == 1 && window.EndLine == window.TotalLines && !window.Truncated {
+		return content, nil, nil
+	}
+	return out, window, nil
+}
+
+// truncateUTF8 cuts b to at most n bytes, without splitting a character.
+func truncateUTF8(b []byte, n int) []byte {
+	if len(b) <= n {
+		return b
+	}
+	for n > 0 && !utf8.RuneStart(b[n]) {
+		n--
+	}
+	return b[:n]
+}
+
+// describe summarizes the window for the text of a tool result.
+func (w *FileWindow) describe() string {
+	s := fmt.Sprintf("returned lines %d-%d of %d", w.StartLine, w.EndLine, w.TotalLines)
+	if w.Truncated {
+		s += ", cut short by max_bytes"
+	}
+	if w.NextStartLine != 0 {
+		s += fmt.Sprintf(", continue with start_line %d", w.NextStartLine)
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_windowFile(t *testing.T) {
+	content := []byte("one\ntwo\nthree\nfour\n")
+
+	tests := []struct {
+		name           string
+		opts           fileWindowOptions
+		expected       string
+		expectedWindow *FileWindow
+		expectedErrMsg string
+	}{
+		{
+			name:     "whole file",
+			opts:     fileWindowOptions{StartLine: 1, MaxBytes: 100},
+			expected: "one\ntwo\nthree\nfour\n",
+		},
+		{
+			name:           "line range",
+			opts:           fileWindowOptions{StartLine: 2, EndLine: 3},
+			expected:       "two\nthree\n",
+			expectedWindow: &FileWindow{StartLine: 2, EndLine: 3, TotalLines: 4, TotalBytes: 19, NextStartLine: 4},
+		},
+		{
+			name:           "end line past the end of the file",
+			opts:           fileWindowOptions{StartLine: 4, EndLine: 10},
+			expected:       "four\n",
+			expectedWindow: &FileWindow{StartLine: 4, EndLine: 4, TotalLines: 4, TotalBytes: 19},
+		},
+		{
+			name:           "cut short after the last whole line",
+			opts:           fileWindowOptions{StartLine: 1, MaxBytes: 12},
+			expected:       "one\ntwo\n",
+			expectedWindow: &FileWindow{StartLine: 1, EndLine: 2, TotalLines: 4, TotalBytes: 19, Truncated: true, NextStartLine: 3},
+		},
+		{
+			name:           "first line longer than max bytes",
+			opts:           fileWindowOptions{StartLine: 3, MaxBytes: 3},
+			expected:       "thr",
+			expectedWindow: &FileWindow{StartLine: 3, EndLine: 3, TotalLines: 4, TotalBytes: 19, Truncated: true, NextStartLine: 4},
+		},
+		{
+			name:           "start line past the end of the file",
+			opts:           fileWindowOptions{StartLine: 5},
+			expectedErrMsg: "start_line 5 is past the end of the file, which has 4 lines",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, window, err := windowFile(content, tc.opts)
+			if tc.expectedErrMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(out))
+			assert.Equal(t, tc.expectedWindow, window)
+		})
+	}
+}
+
+func Test_windowFileEdgeCases(t *testing.T) {
+	// Empty files have no lines, but can be read from the start
+	out, window, err := windowFile(nil, fileWindowOptions{StartLine: 1})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+	assert.Nil(t, window)
+
+	// The last line does not need a trailing newline
+	out, window, err = windowFile([]byte("a\nb"), fileWindowOptions{StartLine: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(out))
+	assert.Equal(t, &FileWindow{StartLine: 2, EndLine: 2, TotalLines: 2, TotalBytes: 3}, window)
+
+	// Characters are not split when a line is cut
+	out, _, err = windowFile([]byte("héllo\n"), fileWindowOptions{StartLine: 1, MaxBytes: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "h", string(out))
+}
+
+func Test_FileWindowDescribe(t *testing.T) {
+	window := &FileWindow{StartLine: 1, EndLine: 250, TotalLines: 1200, Truncated: true, NextStartLine: 251}
+	assert.Equal(t, "returned lines 1-250 of 1200, cut short by max_bytes, continue with start_line 251", window.describe())
+}
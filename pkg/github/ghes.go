@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GHESVersion is the feature release of a GitHub Enterprise Server instance, such as 3.14.
+type GHESVersion struct {
+	Major int
+	Minor int
+}
+
+// ParseGHESVersion parses a version such as 3.14 or 3.14.2, ignoring the patch release.
+func ParseGHESVersion(s string) (GHESVersion, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), ".", 3)
+	if len(parts) < 2 {
+		return GHESVersion{}, fmt.Errorf("invalid GitHub Enterprise Server version %q, expected e.g. 3.14", s)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return GHESVersion{}, fmt.Errorf("invalid GitHub Enterprise Server version %q, expected e.g. 3.14", s)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return GHESVersion{}, fmt.Errorf("invalid GitHub Enterprise Server version %q, expected e.g. 3.14", s)
+	}
+	return GHESVersion{Major: major, Minor: minor}, nil
+}
+
+func (v GHESVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// AtLeast reports whether v is the same release as other or a later one.
+func (v GHESVersion) AtLeast(other GHESVersion) bool {
+	return v.Major > other.Major || (v.Major == other.Major && v.Minor >= other.Minor)
+}
+
+// DetectGHESVersion asks a GitHub Enterprise Server instance for its version.
+func DetectGHESVersion(ctx context.Context, client *github.Client) (GHESVersion, error) {
+	req, err := client.NewRequest(http.MethodGet, "meta", nil)
+	if err != nil {
+		return GHESVersion{}, err
+	}
+	var meta struct {
+		InstalledVersion string `json:"installed_version"`
+	}
+	resp, err := client.Do(ctx, req, &meta)
+	if err != nil {
+		return GHESVersion{}, fmt.Errorf("failed to get GitHub Enterprise Server version: %w", err)
+	}
+	// Instances also report their version in a header, which is used if meta leaves it out
+	if meta.InstalledVersion == "" {
+		meta.InstalledVersion = resp.Header.Get("X-GitHub-Enterprise-Version")
+	}
+	return ParseGHESVersion(meta.InstalledVersion)
+}
+
+// ghesRequirement is the first GitHub Enterprise Server release offering a feature. Features
+// that no release offers have no version.
+type ghesRequirement struct {
+	feature string
+	since   *GHESVersion
+}
+
+func (r ghesRequirement) check(version GHESVersion) error {
+	if r.since == nil {
+		return fmt.Errorf("GitHub Enterprise Server does not support %s", r.feature)
+	}
+	if !version.AtLeast(*r.since) {
+		return fmt.Errorf("GitHub Enterprise Server %s does not support %s; %s or later is required", version, r.feature, r.since)
+	}
+	return nil
+}
+
+var (
+	ghesCopilot     = ghesRequirement{feature: "Copilot"}
+	ghesRulesets    = ghesRequirement{feature: "repository rulesets", since: &GHESVersion{3, 11}}
+	ghesSubIssues   = ghesRequirement{feature: "sub-issues", since: &GHESVersion{3, 17}}
+	ghesIssueTypes  = ghesRequirement{feature: "issue types", since: &GHESVersion{3, 17}}
+	ghesDiscussions = ghesRequirement{feature: "discussions", since: &GHESVersion{3, 6}}
+	ghesProjects    = ghesRequirement{feature: "projects", since: &GHESVersion{3, 7}}
+)
+
+// ghesToolsetRequirements are the features that all tools of a toolset need.
+var ghesToolsetRequirements = map[string]ghesRequirement{
+	"discussions": ghesDiscussions,
+	"projects":    ghesProjects,
+}
+
+// ghesToolRequirements are the features that single tools need.
+var ghesToolRequirements = map[string]ghesRequirement{
+	"assign_copilot_to_issue":        ghesCopilot,
+	"request_copilot_review":         ghesCopilot,
+	"list_copilot_agent_tasks":       ghesCopilot,
+	"get_copilot_agent_task_status":  ghesCopilot,
+	"get_copilot_agent_session_logs": ghesCopilot,
+	"update_repository_ruleset":      ghesRulesets,
+	"delete_repository_ruleset":      ghesRulesets,
+	"add_sub_issue":                  ghesSubIssues,
+	"list_sub_issues":                ghesSubIssues,
+	"remove_sub_issue":               ghesSubIssues,
+	"reprioritize_sub_issue":         ghesSubIssues,
+	"list_issue_types":               ghesIssueTypes,
+}
+
+// ghesParameterRequirements are the features that parameters of tools need, by tool.
+var ghesParameterRequirements = map[string]map[string]ghesRequirement{
+	"create_issue": {"type": ghesIssueTypes},
+	"update_issue": {"type": ghesIssueTypes},
+}
+
+// GHESUnsupportedTools returns the tools of the default toolsets that a GitHub Enterprise
+// Server version does not offer, mapped to the reason.
+func GHESUnsupportedTools(tsg *toolsets.ToolsetGroup, version GHESVersion) map[string]error {
+	unsupported := make(map[string]error)
+	for name, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			requirement, ok := ghesToolRequirements[tool.Tool.Name]
+			if !ok {
+				requirement, ok = ghesToolsetRequirements[name]
+			}
+			if !ok {
+				continue
+			}
+			if err := requirement.check(version); err != nil {
+				unsupported[tool.Tool.Name] = err
+			}
+		}
+	}
+	return unsupported
+}
+
+// GHESParameterMiddleware refuses calls with parameters that a GitHub Enterprise Server version
+// does not support, explaining why, rather than passing them on for GitHub to fail with a 404.
+func GHESParameterMiddleware(version GHESVersion) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			requirements := ghesParameterRequirements[request.Params.Name]
+			args := request.GetArguments()
+			for _, param := range slices.Sorted(maps.Keys(requirements)) {
+				if value, ok := args[param]; !ok || value == nil || value == "" {
+					continue
+				}
+				if err := requirements[param].check(version); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("the %s parameter is not supported: %s", param, err)), nil
+				}
+			}
+			return next(ctx, request)
+		}
+	}
+}
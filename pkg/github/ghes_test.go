@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseGHESVersion(t *testing.T) {
+	version, err := ParseGHESVersion("3.14.2")
+	require.NoError(t, err)
+	assert.Equal(t, GHESVersion{Major: 3, Minor: 14}, version)
+	assert.Equal(t, "3.14", version.String())
+
+	version, err = ParseGHESVersion("3.9")
+	require.NoError(t, err)
+	assert.True(t, version.AtLeast(GHESVersion{3, 9}))
+	assert.True(t, version.AtLeast(GHESVersion{2, 22}))
+	assert.False(t, version.AtLeast(GHESVersion{3, 10}))
+
+	for _, invalid := range []string{"", "3", "three.four", "3.x"} {
+		_, err := ParseGHESVersion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_DetectGHESVersion(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetMeta,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"installed_version":"3.15.1","verifiable_password_authentication":true}`))
+			}),
+		),
+	))
+	version, err := DetectGHESVersion(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, GHESVersion{3, 15}, version)
+
+	// The version header is used if meta leaves the version out
+	client = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetMeta,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-GitHub-Enterprise-Version", "3.12.0")
+				_, _ = w.Write([]byte(`{}`))
+			}),
+		),
+	))
+	version, err = DetectGHESVersion(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, GHESVersion{3, 12}, version)
+
+	client = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetMeta,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"message":"Must authenticate to access this API."}`))
+			}),
+		),
+	))
+	_, err = DetectGHESVersion(context.Background(), client)
+	assert.ErrorContains(t, err, "failed to get GitHub Enterprise Server version")
+}
+
+func Test_GHESUnsupportedTools(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper, 5000)
+
+	unsupported := GHESUnsupportedTools(tsg, GHESVersion{3, 6})
+	assert.EqualError(t, unsupported["request_copilot_review"], "GitHub Enterprise Server does not support Copilot")
+	assert.EqualError(t, unsupported["update_repository_ruleset"], "GitHub Enterprise Server 3.6 does not support repository rulesets; 3.11 or later is required")
+	assert.Contains(t, unsupported, "list_project_items", "all tools of the projects toolset need 3.7")
+	assert.NotContains(t, unsupported, "list_discussions")
+	assert.NotContains(t, unsupported, "get_issue")
+
+	unsupported = GHESUnsupportedTools(tsg, GHESVersion{3, 17})
+	assert.Contains(t, unsupported, "assign_copilot_to_issue")
+	assert.NotContains(t, unsupported, "add_sub_issue")
+	assert.NotContains(t, unsupported, "list_project_items")
+}
+
+func Test_GHESParameterMiddleware(t *testing.T) {
+	handler := GHESParameterMiddleware(GHESVersion{3, 14})(
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+	call := func(name string, args map[string]any) *mcp.CallToolResult {
+		request := createMCPRequest(args)
+		request.Params.Name = name
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call("create_issue", map[string]any{"owner": "octo", "repo": "app", "title": "Bug", "type": "Bug"})
+	require.True(t, result.IsError)
+	assert.Equal(t, "the type parameter is not supported: GitHub Enterprise Server 3.14 does not support issue types; 3.17 or later is required", getErrorResult(t, result).Text)
+
+	result = call("create_issue", map[string]any{"owner": "octo", "repo": "app", "title": "Bug"})
+	assert.False(t, result.IsError)
+
+	result = call("get_issue", map[string]any{"owner": "octo", "repo": "app", "type": "Bug"})
+	assert.False(t, result.IsError)
+}
@@ -85,7 +85,7 @@ func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
 		}
 }
 
@@ -0,0 +1,535 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_qualifyRef(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected string
+		wantErr  bool
+	}{
+		{ref: "refs/heads/main", expected: "refs/heads/main"},
+		{ref: "heads/main", expected: "refs/heads/main"},
+		{ref: "tags/v1.0.0", expected: "refs/tags/v1.0.0"},
+		{ref: "main", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ref, func(t *testing.T) {
+			ref, err := qualifyRef(tc.ref)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func Test_CreateTag(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_tag", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult GitTagResult
+		expectedErrMsg string
+	}{
+		{
+			name: "annotated tag on a commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTagsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"tag":     "v1.0.0",
+						"message": "First release",
+						"object":  "abc123",
+						"type":    "commit",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tag{SHA: github.Ptr("tag456")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/tags/v1.0.0",
+						"sha": "tag456",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/tags/v1.0.0")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"tag":     "v1.0.0",
+				"sha":     "abc123",
+				"message": "First release",
+			},
+			expectedResult: GitTagResult{Ref: "refs/tags/v1.0.0", CommitSHA: "abc123", TagSHA: "tag456"},
+		},
+		{
+			name: "lightweight tag on a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					&github.Reference{
+						Ref:    github.Ptr("refs/heads/main"),
+						Object: &github.GitObject{SHA: github.Ptr("abc123")},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/tags/v1.0.0",
+						"sha": "abc123",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/tags/v1.0.0")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
+				"ref":   "refs/heads/main",
+			},
+			expectedResult: GitTagResult{Ref: "refs/tags/v1.0.0", CommitSHA: "abc123"},
+		},
+		{
+			name: "tag already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reference already exists"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
+				"sha":   "abc123",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create tag v1.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var tagResult GitTagResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &tagResult))
+			assert.Equal(t, tc.expectedResult, tagResult)
+		})
+	}
+}
+
+func Test_CreateRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_ref", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		ref            string
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates partially qualified ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/heads/feature",
+						"sha": "abc123",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{
+							Ref:    github.Ptr("refs/heads/feature"),
+							Object: &github.GitObject{SHA: github.Ptr("abc123")},
+						}),
+					),
+				),
+			),
+			ref: "heads/feature",
+		},
+		{
+			name:           "rejects bare name",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			ref:            "feature",
+			expectError:    true,
+			expectedErrMsg: "must be fully qualified",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   tc.ref,
+				"sha":   "abc123",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var ref github.Reference
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ref))
+			assert.Equal(t, "refs/heads/feature", ref.GetRef())
+			assert.Equal(t, "abc123", ref.GetObject().GetSHA())
+		})
+	}
+}
+
+func Test_UpdateRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_ref", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "force")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "force updates ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "def456",
+						"force": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Reference{
+							Ref:    github.Ptr("refs/heads/feature"),
+							Object: &github.GitObject{SHA: github.Ptr("def456")},
+						}),
+					),
+				),
+			),
+		},
+		{
+			name: "update is not a fast-forward",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Update is not a fast forward"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to update reference refs/heads/feature",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/feature",
+				"sha":   "def456",
+				"force": true,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var ref github.Reference
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &ref))
+			assert.Equal(t, "def456", ref.GetObject().GetSHA())
+		})
+	}
+}
+
+func Test_DeleteRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_ref", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "deletes ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reference does not exist"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to delete reference refs/tags/v1.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "tags/v1.0.0",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "reference refs/tags/v1.0.0 deleted", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_GetGitBlob(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitBlob(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_git_blob", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	tests := []struct {
+		name           string
+		blob           *github.Blob
+		expectedResult GitBlob
+	}{
+		{
+			name: "text blob is decoded",
+			blob: &github.Blob{
+				SHA:      github.Ptr("abc123"),
+				Size:     github.Ptr(12),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr("SGVsbG8s\nIHdvcmxk\n"),
+			},
+			expectedResult: GitBlob{SHA: "abc123", Size: 12, Encoding: "utf-8", Content: "Hello, world"},
+		},
+		{
+			name: "binary blob stays encoded",
+			blob: &github.Blob{
+				SHA:      github.Ptr("def456"),
+				Size:     github.Ptr(4),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr("iVBO/w==\n"),
+			},
+			expectedResult: GitBlob{SHA: "def456", Size: 4, Encoding: "base64", Content: "iVBO/w==\n"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitBlobsByOwnerByRepoByFileSha, tc.blob),
+			))
+			_, handler := GetGitBlob(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   tc.blob.GetSHA(),
+			}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var blob GitBlob
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &blob))
+			assert.Equal(t, tc.expectedResult, blob)
+		})
+	}
+}
+
+func Test_GetGitTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_git_tree", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+			expectQueryParams(t, map[string]string{"recursive": "1"}).andThen(
+				mockResponse(t, http.StatusOK, &github.Tree{
+					SHA: github.Ptr("tree123"),
+					Entries: []*github.TreeEntry{
+						{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), SHA: github.Ptr("abc123")},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := GetGitTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":     "owner",
+		"repo":      "repo",
+		"sha":       "tree123",
+		"recursive": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var tree github.Tree
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &tree))
+	assert.Equal(t, "tree123", tree.GetSHA())
+	require.Len(t, tree.Entries, 1)
+	assert.Equal(t, "README.md", tree.Entries[0].GetPath())
+}
+
+func Test_GetGitCommit(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_git_commit", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "gets commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					&github.Commit{
+						SHA:     github.Ptr("abc123"),
+						Message: github.Ptr("Initial commit"),
+						Tree:    &github.Tree{SHA: github.Ptr("tree123")},
+					},
+				),
+			),
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get commit abc123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGitCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var commit github.Commit
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &commit))
+			assert.Equal(t, "Initial commit", commit.GetMessage())
+			assert.Equal(t, "tree123", commit.GetTree().GetSHA())
+		})
+	}
+}
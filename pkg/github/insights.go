@@ -0,0 +1,328 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// statsComputingMessage is returned when GitHub answers a statistics request with 202 Accepted,
+// which it does while it computes statistics that are not cached.
+const statsComputingMessage = "GitHub is computing the statistics of this repository, retry in a few seconds"
+
+// withStatsWeeks adds the weeks parameter of the statistics tools.
+func withStatsWeeks() mcp.ToolOption {
+	return mcp.WithNumber("weeks",
+		mcp.Description("Number of most recent weeks to return, up to 52"),
+		mcp.Min(1),
+		mcp.Max(52),
+		mcp.DefaultNumber(12),
+	)
+}
+
+// statsWeeksParam returns the weeks parameter added by withStatsWeeks.
+func statsWeeksParam(request mcp.CallToolRequest) (int, error) {
+	weeks, err := OptionalIntParamWithDefault(request, "weeks", 12)
+	if err != nil {
+		return 0, err
+	}
+	if weeks < 1 || weeks > 52 {
+		return 0, fmt.Errorf("weeks must be between 1 and 52")
+	}
+	return weeks, nil
+}
+
+// lastWeeks returns the last n weeks of weekly statistics, which GitHub returns oldest first.
+func lastWeeks[T any](weeks []T, n int) []T {
+	if len(weeks) > n {
+		return weeks[len(weeks)-n:]
+	}
+	return weeks
+}
+
+// WeeklyCodeStats is the activity of a week, starting on Sunday.
+type WeeklyCodeStats struct {
+	Week      string `json:"week"`
+	Commits   int    `json:"commits,omitempty"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// weeklyCodeStats converts weekly statistics, whose JSON has single letter keys, and whose
+// deletions are negative in code frequency statistics.
+func weeklyCodeStats(weeks []*github.WeeklyStats) []WeeklyCodeStats {
+	result := make([]WeeklyCodeStats, 0, len(weeks))
+	for _, week := range weeks {
+		deletions := week.GetDeletions()
+		if deletions < 0 {
+			deletions = -deletions
+		}
+		result = append(result, WeeklyCodeStats{
+			Week:      week.GetWeek().UTC().Format("2006-01-02"),
+			Commits:   week.GetCommits(),
+			Additions: week.GetAdditions(),
+			Deletions: deletions,
+		})
+	}
+	return result
+}
+
+// ContributorActivity is a contributor's commits, additions and deletions over a number of weeks.
+type ContributorActivity struct {
+	Login string `json:"login"`
+	// TotalCommits is the number of commits of the contributor to the default branch ever.
+	TotalCommits int               `json:"total_commits"`
+	Commits      int               `json:"commits"`
+	Additions    int               `json:"additions"`
+	Deletions    int               `json:"deletions"`
+	Weeks        []WeeklyCodeStats `json:"weeks"`
+}
+
+// GetRepositoryTraffic creates a tool to get the views, clones, referrers and popular paths of a repository.
+func GetRepositoryTraffic(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_traffic",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TRAFFIC_DESCRIPTION", "Get the traffic of a repository over the last 14 days: views and clones per day or week, with unique visitors and cloners, the top 10 referring sites and the top 10 most viewed paths. Requires push access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TRAFFIC_USER_TITLE", "Get repository traffic"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("per",
+				mcp.Description("Whether to break views and clones down per day or per week"),
+				mcp.Enum("day", "week"),
+				mcp.DefaultString("day"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			per, err := OptionalParam[string](request, "per")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			breakdown := &github.TrafficBreakdownOptions{Per: per}
+			views, resp, err := client.Repositories.ListTrafficViews(ctx, owner, repo, breakdown)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository views", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			clones, resp, err := client.Repositories.ListTrafficClones(ctx, owner, repo, breakdown)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository clones", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			referrers, resp, err := client.Repositories.ListTrafficReferrers(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository referrers", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			paths, resp, err := client.Repositories.ListTrafficPaths(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository popular paths", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(map[string]any{
+				"views":         views,
+				"clones":        clones,
+				"referrers":     referrers,
+				"popular_paths": paths,
+			}), nil
+		}
+}
+
+// ListContributorStats creates a tool to list the contributors of a repository with their recent activity.
+func ListContributorStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_contributor_stats",
+			mcp.WithDescription(t("TOOL_LIST_CONTRIBUTOR_STATS_DESCRIPTION", "List the top 100 contributors to the default branch of a repository, most commits first, with their commits, additions and deletions per week over the last weeks. Merge commits and empty commits are not counted")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CONTRIBUTOR_STATS_USER_TITLE", "List contributor statistics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			withStatsWeeks(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weeks, err := statsWeeksParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			stats, resp, err := client.Repositories.ListContributorsStats(ctx, owner, repo)
+			if isAcceptedError(err) {
+				return mcp.NewToolResultError(statsComputingMessage), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get contributor statistics", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			contributors := make([]ContributorActivity, 0, len(stats))
+			for _, stat := range stats {
+				contributor := ContributorActivity{
+					Login:        stat.GetAuthor().GetLogin(),
+					TotalCommits: stat.GetTotal(),
+					Weeks:        weeklyCodeStats(lastWeeks(stat.Weeks, weeks)),
+				}
+				for _, week := range contributor.Weeks {
+					contributor.Commits += week.Commits
+					contributor.Additions += week.Additions
+					contributor.Deletions += week.Deletions
+				}
+				contributors = append(contributors, contributor)
+			}
+			sort.SliceStable(contributors, func(i, j int) bool {
+				return contributors[i].TotalCommits > contributors[j].TotalCommits
+			})
+
+			return MarshalledTextResult(contributors), nil
+		}
+}
+
+// GetCodeFrequency creates a tool to get the weekly additions and deletions of a repository.
+func GetCodeFrequency(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_code_frequency",
+			mcp.WithDescription(t("TOOL_GET_CODE_FREQUENCY_DESCRIPTION", "Get the lines added and deleted in a repository per week over the last weeks, oldest first, with weeks starting on Sunday. Not available for repositories with 10,000 or more commits")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODE_FREQUENCY_USER_TITLE", "Get code frequency"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			withStatsWeeks(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weeks, err := statsWeeksParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			frequency, resp, err := client.Repositories.ListCodeFrequency(ctx, owner, repo)
+			if isAcceptedError(err) {
+				return mcp.NewToolResultError(statsComputingMessage), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get code frequency", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(weeklyCodeStats(lastWeeks(frequency, weeks))), nil
+		}
+}
+
+// GetCommitActivity creates a tool to get the daily commits of a repository, grouped by week.
+func GetCommitActivity(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_commit_activity",
+			mcp.WithDescription(t("TOOL_GET_COMMIT_ACTIVITY_DESCRIPTION", "Get the commits to the default branch of a repository per week over the last weeks, oldest first, with the commits of each day of the week starting on Sunday")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COMMIT_ACTIVITY_USER_TITLE", "Get commit activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			withStatsWeeks(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			weeks, err := statsWeeksParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			activity, resp, err := client.Repositories.ListCommitActivity(ctx, owner, repo)
+			if isAcceptedError(err) {
+				return mcp.NewToolResultError(statsComputingMessage), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get commit activity", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(lastWeeks(activity, weeks)), nil
+		}
+}
@@ -0,0 +1,264 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryTraffic(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTraffic(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_traffic", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "per")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	day := &github.Timestamp{Time: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposTrafficViewsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{"per": "week"}).andThen(
+				mockResponse(t, http.StatusOK, &github.TrafficViews{
+					Count:   github.Ptr(120),
+					Uniques: github.Ptr(40),
+					Views:   []*github.TrafficData{{Timestamp: day, Count: github.Ptr(120), Uniques: github.Ptr(40)}},
+				}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposTrafficClonesByOwnerByRepo,
+			expectQueryParams(t, map[string]string{"per": "week"}).andThen(
+				mockResponse(t, http.StatusOK, &github.TrafficClones{Count: github.Ptr(9), Uniques: github.Ptr(3)}),
+			),
+		),
+		mock.WithRequestMatch(
+			mock.GetReposTrafficPopularReferrersByOwnerByRepo,
+			[]*github.TrafficReferrer{{Referrer: github.Ptr("news.ycombinator.com"), Count: github.Ptr(80), Uniques: github.Ptr(30)}},
+		),
+		mock.WithRequestMatch(
+			mock.GetReposTrafficPopularPathsByOwnerByRepo,
+			[]*github.TrafficPath{{Path: github.Ptr("/owner/repo"), Title: github.Ptr("owner/repo"), Count: github.Ptr(100), Uniques: github.Ptr(35)}},
+		),
+	))
+	_, handler := GetRepositoryTraffic(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "per": "week"}))
+	require.NoError(t, err)
+
+	var response struct {
+		Views        github.TrafficViews       `json:"views"`
+		Clones       github.TrafficClones      `json:"clones"`
+		Referrers    []*github.TrafficReferrer `json:"referrers"`
+		PopularPaths []*github.TrafficPath     `json:"popular_paths"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, 120, response.Views.GetCount())
+	assert.Equal(t, 3, response.Clones.GetUniques())
+	require.Len(t, response.Referrers, 1)
+	assert.Equal(t, "news.ycombinator.com", response.Referrers[0].GetReferrer())
+	require.Len(t, response.PopularPaths, 1)
+	assert.Equal(t, "/owner/repo", response.PopularPaths[0].GetPath())
+
+	// Traffic needs push access
+	client = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposTrafficViewsByOwnerByRepo,
+			mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have push access to repository"}),
+		),
+	))
+	_, handler = GetRepositoryTraffic(stubGetClientFn(client), translations.NullTranslationHelper)
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "failed to get repository views")
+}
+
+func Test_ListContributorStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListContributorStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_contributor_stats", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "weeks")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	week := func(day, commits, additions, deletions int) *github.WeeklyStats {
+		return &github.WeeklyStats{
+			Week:      &github.Timestamp{Time: time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC)},
+			Commits:   github.Ptr(commits),
+			Additions: github.Ptr(additions),
+			Deletions: github.Ptr(deletions),
+		}
+	}
+	// GitHub lists contributors by ascending total
+	stats := []*github.ContributorStats{
+		{
+			Author: &github.Contributor{Login: github.Ptr("newcomer")},
+			Total:  github.Ptr(2),
+			Weeks:  []*github.WeeklyStats{week(2, 0, 0, 0), week(9, 0, 0, 0), week(16, 2, 30, 4)},
+		},
+		{
+			Author: &github.Contributor{Login: github.Ptr("maintainer")},
+			Total:  github.Ptr(500),
+			Weeks:  []*github.WeeklyStats{week(2, 10, 100, 50), week(9, 3, 20, 10), week(16, 1, 5, 0)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       []ContributorActivity
+	}{
+		{
+			name: "contributors over the last weeks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposStatsContributorsByOwnerByRepo, stats),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "weeks": float64(2)},
+			expected: []ContributorActivity{
+				{
+					Login:        "maintainer",
+					TotalCommits: 500,
+					Commits:      4,
+					Additions:    25,
+					Deletions:    10,
+					Weeks: []WeeklyCodeStats{
+						{Week: "2025-03-09", Commits: 3, Additions: 20, Deletions: 10},
+						{Week: "2025-03-16", Commits: 1, Additions: 5},
+					},
+				},
+				{
+					Login:        "newcomer",
+					TotalCommits: 2,
+					Commits:      2,
+					Additions:    30,
+					Deletions:    4,
+					Weeks: []WeeklyCodeStats{
+						{Week: "2025-03-09"},
+						{Week: "2025-03-16", Commits: 2, Additions: 30, Deletions: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "statistics being computed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStatsContributorsByOwnerByRepo,
+					mockResponse(t, http.StatusAccepted, map[string]string{}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo"},
+			expectError:    true,
+			expectedErrMsg: statsComputingMessage,
+		},
+		{
+			name:           "too many weeks",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "weeks": float64(53)},
+			expectError:    true,
+			expectedErrMsg: "weeks must be between 1 and 52",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListContributorStats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			var contributors []ContributorActivity
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &contributors))
+			assert.Equal(t, tc.expected, contributors)
+		})
+	}
+}
+
+func Test_GetCodeFrequency(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeFrequency(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_code_frequency", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	march2 := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC).Unix()
+	march9 := time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC).Unix()
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposStatsCodeFrequencyByOwnerByRepo,
+			[][]int64{{march2, 1200, -300}, {march9, 40, -15}},
+		),
+	))
+	_, handler := GetCodeFrequency(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"}))
+	require.NoError(t, err)
+
+	var weeks []WeeklyCodeStats
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &weeks))
+	assert.Equal(t, []WeeklyCodeStats{
+		{Week: "2025-03-02", Additions: 1200, Deletions: 300},
+		{Week: "2025-03-09", Additions: 40, Deletions: 15},
+	}, weeks)
+}
+
+func Test_GetCommitActivity(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommitActivity(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_commit_activity", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	activity := make([]*github.WeeklyCommitActivity, 52)
+	for i := range activity {
+		activity[i] = &github.WeeklyCommitActivity{
+			Days:  []int{0, i, 0, 0, 0, 0, 0},
+			Total: github.Ptr(i),
+			Week:  &github.Timestamp{Time: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*i)},
+		}
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposStatsCommitActivityByOwnerByRepo, activity),
+	))
+	_, handler := GetCommitActivity(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "weeks": float64(4)}))
+	require.NoError(t, err)
+
+	var weeks []*github.WeeklyCommitActivity
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &weeks))
+	require.Len(t, weeks, 4)
+	assert.Equal(t, 48, weeks[0].GetTotal())
+	assert.Equal(t, 51, weeks[3].GetTotal())
+	assert.Equal(t, []int{0, 51, 0, 0, 0, 0, 0}, weeks[3].Days)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
@@ -152,6 +153,7 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				Title:        t("TOOL_GET_ISSUE_USER_TITLE", "Get issue details"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			mcp.WithOutputSchema[issueOutput](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The owner of the repository"),
@@ -505,6 +507,75 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 
 }
 
+// GetParentIssue creates a tool to get the parent issue of a GitHub issue.
+func GetParentIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_parent_issue",
+			mcp.WithDescription(t("TOOL_GET_PARENT_ISSUE_DESCRIPTION", "Get the parent issue of a sub-issue in a GitHub repository. Use together with list_sub_issues to navigate an issue hierarchy.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PARENT_ISSUE_USER_TITLE", "Get parent issue"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("The number of the sub-issue"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github has no method for the parent endpoint yet.
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/issues/%d/parent", owner, repo, issueNumber), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var parent github.Issue
+			resp, err := client.Do(ctx, req, &parent)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultText(fmt.Sprintf("issue %s/%s#%d has no parent issue", owner, repo, issueNumber)), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get parent issue",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // RemoveSubIssue creates a tool to remove a sub-issue from a parent issue.
 // Unlike other sub-issue tools, this currently uses a direct HTTP DELETE request
 // because of a bug in the go-github library.
@@ -746,6 +817,139 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
+// IssueSearchResult is a trimmed issue returned by search_issues_graphql.
+type IssueSearchResult struct {
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	State      string    `json:"state"`
+	Labels     []string  `json:"labels"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	URL        string    `json:"url"`
+}
+
+// SearchIssuesGraphQL creates a tool to search issues through the GraphQL API, returning only the key fields of each issue.
+func SearchIssuesGraphQL(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_issues_graphql",
+			mcp.WithDescription(t("TOOL_SEARCH_ISSUES_GRAPHQL_DESCRIPTION", "Search for issues using the full GitHub issues search syntax, already scoped to is:issue. Returns only the repository, number, title, state, labels, update time and URL of each issue, which is much smaller than search_issues. For pagination, use the 'endCursor' from the previous response's 'pageInfo' in the 'after' parameter.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_ISSUES_GRAPHQL_USER_TITLE", "Search issues (compact)"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query using GitHub issues search syntax, including sort qualifiers such as sort:updated-desc"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Optional repository owner. If provided with repo, only issues for this repository are listed."),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Optional repository name. If provided with owner, only issues for this repository are listed."),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if !hasSpecificFilter(query, "is", "issue") {
+				query = fmt.Sprintf("is:issue %s", query)
+			}
+			if owner != "" && repo != "" && !hasRepoFilter(query) {
+				query = fmt.Sprintf("repo:%s/%s %s", owner, repo, query)
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				Search struct {
+					IssueCount githubv4.Int
+					PageInfo   PageInfoFragment
+					Nodes      []struct {
+						Issue struct {
+							Number     githubv4.Int
+							Title      githubv4.String
+							State      githubv4.IssueState
+							UpdatedAt  githubv4.DateTime
+							URL        githubv4.URI
+							Repository struct {
+								NameWithOwner githubv4.String
+							}
+							Labels struct {
+								Nodes []struct {
+									Name githubv4.String
+								}
+							} `graphql:"labels(first: 20)"`
+						} `graphql:"... on Issue"`
+					}
+				} `graphql:"search(query: $query, type: ISSUE, first: $first, after: $after)"`
+			}
+			vars := map[string]any{
+				"query": githubv4.String(query),
+				"first": githubv4.Int(*paginationParams.First),
+				"after": (*githubv4.String)(paginationParams.After),
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to search issues", err), nil
+			}
+
+			issues := make([]IssueSearchResult, 0, len(q.Search.Nodes))
+			for _, node := range q.Search.Nodes {
+				issue := node.Issue
+				labels := make([]string, 0, len(issue.Labels.Nodes))
+				for _, label := range issue.Labels.Nodes {
+					labels = append(labels, string(label.Name))
+				}
+				issues = append(issues, IssueSearchResult{
+					Repository: string(issue.Repository.NameWithOwner),
+					Number:     int(issue.Number),
+					Title:      string(issue.Title),
+					State:      strings.ToLower(string(issue.State)),
+					Labels:     labels,
+					UpdatedAt:  issue.UpdatedAt.Time,
+					URL:        issue.URL.String(),
+				})
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"issues": issues,
+				"pageInfo": map[string]any{
+					"hasNextPage":     q.Search.PageInfo.HasNextPage,
+					"hasPreviousPage": q.Search.PageInfo.HasPreviousPage,
+					"startCursor":     string(q.Search.PageInfo.StartCursor),
+					"endCursor":       string(q.Search.PageInfo.EndCursor),
+				},
+				"totalCount": int(q.Search.IssueCount),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), graphQLPageInfo(bool(q.Search.PageInfo.HasNextPage), q.Search.PageInfo.EndCursor, github.Ptr(int(q.Search.IssueCount)))), nil
+		}
+}
+
 // CreateIssue creates a tool to create a new issue in a GitHub repository.
 func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_issue",
@@ -889,6 +1093,7 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_ISSUES_USER_TITLE", "List issues"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			mcp.WithOutputSchema[issueListOutput](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1089,7 +1294,7 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal issues: %w", err)
 			}
-			return mcp.NewToolResultText(string(out)), nil
+			return withPageInfo(mcp.NewToolResultText(string(out)), graphQLPageInfo(bool(pageInfo.HasNextPage), pageInfo.EndCursor, &totalCount)), nil
 		}
 }
 
@@ -1251,6 +1456,597 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 		}
 }
 
+// TransferIssue creates a tool to transfer an issue to another repository.
+func TransferIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_issue",
+			mcp.WithDescription(t("TOOL_TRANSFER_ISSUE_DESCRIPTION", "Transfer an issue to another repository owned by the same user or organization. The issue gets a new number in the target repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_TRANSFER_ISSUE_USER_TITLE", "Transfer issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue number to transfer"),
+			),
+			mcp.WithString("target_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository to transfer the issue to"),
+			),
+			mcp.WithString("target_owner",
+				mcp.Description("Owner of the repository to transfer the issue to. Defaults to owner"),
+			),
+			mcp.WithBoolean("create_labels_if_missing",
+				mcp.Description("Create the issue's labels in the target repository if they do not exist there"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetRepo, err := RequiredParam[string](request, "target_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetOwner, err := OptionalParam[string](request, "target_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if targetOwner == "" {
+				targetOwner = owner
+			}
+			createLabels, err := OptionalParam[bool](request, "create_labels_if_missing")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var idsQuery struct {
+				Repository struct {
+					Issue struct {
+						ID githubv4.ID
+					} `graphql:"issue(number: $issueNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+				Target struct {
+					ID githubv4.ID
+				} `graphql:"target: repository(owner: $targetOwner, name: $targetRepo)"`
+			}
+			vars := map[string]any{
+				"owner":       githubv4.String(owner),
+				"repo":        githubv4.String(repo),
+				"issueNumber": githubv4.Int(issueNumber), // #nosec G115 - issue numbers are always small positive integers
+				"targetOwner": githubv4.String(targetOwner),
+				"targetRepo":  githubv4.String(targetRepo),
+			}
+			if err := client.Query(ctx, &idsQuery, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find issue or target repository", err), nil
+			}
+
+			var mutation struct {
+				TransferIssue struct {
+					Issue struct {
+						Number githubv4.Int
+						URL    githubv4.URI
+					}
+				} `graphql:"transferIssue(input: $input)"`
+			}
+			input := githubv4.TransferIssueInput{
+				IssueID:      idsQuery.Repository.Issue.ID,
+				RepositoryID: idsQuery.Target.ID,
+			}
+			if createLabels {
+				input.CreateLabelsIfMissing = githubv4.NewBoolean(true)
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to transfer issue", err), nil
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"number": int(mutation.TransferIssue.Issue.Number),
+				"url":    mutation.TransferIssue.Issue.URL.String(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// LockIssue creates a tool to lock the conversation on an issue or pull request.
+func LockIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("lock_issue",
+			mcp.WithDescription(t("TOOL_LOCK_ISSUE_DESCRIPTION", "Lock the conversation on an issue or pull request so that only collaborators can comment.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_LOCK_ISSUE_USER_TITLE", "Lock issue conversation"),
+				ReadOnlyHint:   ToBoolPtr(false),
+				IdempotentHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithString("reason",
+				mcp.Description("The reason for locking the conversation"),
+				mcp.Enum("off-topic", "too heated", "resolved", "spam"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reason, err := OptionalParam[string](request, "reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.Lock(ctx, owner, repo, issueNumber, &github.LockIssueOptions{LockReason: reason})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to lock issue %s/%s#%d", owner, repo, issueNumber),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("issue %s/%s#%d locked", owner, repo, issueNumber)), nil
+		}
+}
+
+// UnlockIssue creates a tool to unlock the conversation on an issue or pull request.
+func UnlockIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unlock_issue",
+			mcp.WithDescription(t("TOOL_UNLOCK_ISSUE_DESCRIPTION", "Unlock the conversation on an issue or pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_UNLOCK_ISSUE_USER_TITLE", "Unlock issue conversation"),
+				ReadOnlyHint:   ToBoolPtr(false),
+				IdempotentHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.Unlock(ctx, owner, repo, issueNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to unlock issue %s/%s#%d", owner, repo, issueNumber),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("issue %s/%s#%d unlocked", owner, repo, issueNumber)), nil
+		}
+}
+
+// MarkIssueAsDuplicate creates a tool to close an issue as a duplicate of another issue.
+func MarkIssueAsDuplicate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("mark_issue_as_duplicate",
+			mcp.WithDescription(t("TOOL_MARK_ISSUE_AS_DUPLICATE_DESCRIPTION", "Close an issue as a duplicate of another issue. Adds a 'Duplicate of' comment linking the original issue and closes the issue with the duplicate reason.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MARK_ISSUE_AS_DUPLICATE_USER_TITLE", "Mark issue as duplicate"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the duplicate issue, which is closed"),
+			),
+			mcp.WithNumber("duplicate_of",
+				mcp.Required(),
+				mcp.Description("Number of the original issue"),
+			),
+			mcp.WithString("duplicate_of_owner",
+				mcp.Description("Owner of the original issue's repository. Defaults to owner"),
+			),
+			mcp.WithString("duplicate_of_repo",
+				mcp.Description("Name of the original issue's repository. Defaults to repo"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			duplicateOf, err := RequiredInt(request, "duplicate_of")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			originalOwner, err := OptionalParam[string](request, "duplicate_of_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			originalRepo, err := OptionalParam[string](request, "duplicate_of_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			reference := fmt.Sprintf("#%d", duplicateOf)
+			if originalOwner != "" || originalRepo != "" {
+				if originalOwner == "" {
+					originalOwner = owner
+				}
+				if originalRepo == "" {
+					originalRepo = repo
+				}
+				reference = fmt.Sprintf("%s/%s#%d", originalOwner, originalRepo, duplicateOf)
+			}
+			if reference == fmt.Sprintf("#%d", issueNumber) || reference == fmt.Sprintf("%s/%s#%d", owner, repo, issueNumber) {
+				return mcp.NewToolResultError("an issue cannot be a duplicate of itself"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// GitHub recognises the "Duplicate of" comment and links the two issues.
+			comment := &github.IssueComment{Body: github.Ptr("Duplicate of " + reference)}
+			_, resp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, comment)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to add duplicate comment",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			issue, resp, err := client.Issues.Edit(ctx, owner, repo, issueNumber, &github.IssueRequest{
+				State:       github.Ptr("closed"),
+				StateReason: github.Ptr("duplicate"),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to close duplicate issue",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(issue)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// maxBulkIssues is the maximum number of issues bulk_update_issues changes in one call.
+	maxBulkIssues = 100
+	// bulkIssueConcurrency is the number of issues bulk_update_issues updates at the same time.
+	bulkIssueConcurrency = 5
+)
+
+// BulkIssueFailure reports an issue that bulk_update_issues could not fully update.
+type BulkIssueFailure struct {
+	IssueNumber int    `json:"issue_number"`
+	Error       string `json:"error"`
+}
+
+// BulkIssueUpdateResult is the JSON output of bulk_update_issues.
+type BulkIssueUpdateResult struct {
+	Updated []int              `json:"updated"`
+	Failed  []BulkIssueFailure `json:"failed"`
+}
+
+// bulkIssueUpdate holds the changes bulk_update_issues applies to every issue.
+type bulkIssueUpdate struct {
+	addLabels       []string
+	removeLabels    []string
+	addAssignees    []string
+	removeAssignees []string
+	edit            *github.IssueRequest
+}
+
+// apply makes the changes to a single issue, stopping at the first request that fails.
+func (u bulkIssueUpdate) apply(ctx context.Context, client *github.Client, owner, repo string, number int) error {
+	if len(u.addLabels) > 0 {
+		_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, u.addLabels)
+		if err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	for _, label := range u.removeLabels {
+		resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+		// A 404 means the issue does not have the label, which is the desired end state.
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			_ = resp.Body.Close()
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to remove label %q: %w", label, err)
+		}
+		_ = resp.Body.Close()
+	}
+	if len(u.addAssignees) > 0 {
+		_, resp, err := client.Issues.AddAssignees(ctx, owner, repo, number, u.addAssignees)
+		if err != nil {
+			return fmt.Errorf("failed to add assignees: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if len(u.removeAssignees) > 0 {
+		_, resp, err := client.Issues.RemoveAssignees(ctx, owner, repo, number, u.removeAssignees)
+		if err != nil {
+			return fmt.Errorf("failed to remove assignees: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if u.edit != nil {
+		_, resp, err := client.Issues.Edit(ctx, owner, repo, number, u.edit)
+		if err != nil {
+			return fmt.Errorf("failed to update issue: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	return nil
+}
+
+// BulkUpdateIssues creates a tool to apply the same changes to many issues at once.
+func BulkUpdateIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("bulk_update_issues",
+			mcp.WithDescription(t("TOOL_BULK_UPDATE_ISSUES_DESCRIPTION", fmt.Sprintf("Apply the same label, assignee, milestone and state changes to up to %d issues in a repository in one call. Issues that fail are reported individually and do not stop the others from being updated.", maxBulkIssues))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BULK_UPDATE_ISSUES_USER_TITLE", "Bulk update issues"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Required(),
+				mcp.Description("Numbers of the issues to update"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithArray("add_labels",
+				mcp.Description("Labels to add to each issue"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("remove_labels",
+				mcp.Description("Labels to remove from each issue"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("add_assignees",
+				mcp.Description("Usernames to assign to each issue"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("remove_assignees",
+				mcp.Description("Usernames to unassign from each issue"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Description("Milestone number to set on each issue"),
+			),
+			mcp.WithString("state",
+				mcp.Description("New state of each issue"),
+				mcp.Enum("open", "closed"),
+			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for the state change"),
+				mcp.Enum("completed", "not_planned", "duplicate", "reopened"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumbers, err := OptionalIntArrayParam(request, "issue_numbers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(issueNumbers) == 0 {
+				return mcp.NewToolResultError("issue_numbers must contain at least one issue number"), nil
+			}
+			if len(issueNumbers) > maxBulkIssues {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d issues can be updated at once", maxBulkIssues)), nil
+			}
+
+			var update bulkIssueUpdate
+			for param, target := range map[string]*[]string{
+				"add_labels":       &update.addLabels,
+				"remove_labels":    &update.removeLabels,
+				"add_assignees":    &update.addAssignees,
+				"remove_assignees": &update.removeAssignees,
+			} {
+				if *target, err = OptionalStringArrayParam(request, param); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			edit := &github.IssueRequest{}
+			hasEdit := false
+			milestone, err := OptionalIntParam(request, "milestone")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if milestone != 0 {
+				edit.Milestone = github.Ptr(milestone)
+				hasEdit = true
+			}
+			for param, target := range map[string]**string{
+				"state":        &edit.State,
+				"state_reason": &edit.StateReason,
+			} {
+				value, err := OptionalParam[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if value != "" {
+					*target = github.Ptr(value)
+					hasEdit = true
+				}
+			}
+			if hasEdit {
+				update.edit = edit
+			}
+
+			if len(update.addLabels)+len(update.removeLabels)+len(update.addAssignees)+len(update.removeAssignees) == 0 && update.edit == nil {
+				return mcp.NewToolResultError("at least one change must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			progress := newProgressReporter(ctx, request, len(issueNumbers))
+			errs := make([]error, len(issueNumbers))
+			sem := make(chan struct{}, bulkIssueConcurrency)
+			var wg sync.WaitGroup
+			for i, number := range issueNumbers {
+				wg.Add(1)
+				sem <- struct{}{}
+				go func() {
+					defer wg.Done()
+					defer func() { <-sem }()
+					errs[i] = update.apply(ctx, client, owner, repo, number)
+					if errs[i] != nil {
+						progress.step(ctx, fmt.Sprintf("Failed to update issue #%d", number))
+					} else {
+						progress.step(ctx, fmt.Sprintf("Updated issue #%d", number))
+					}
+				}()
+			}
+			wg.Wait()
+
+			result := BulkIssueUpdateResult{Updated: []int{}, Failed: []BulkIssueFailure{}}
+			for i, number := range issueNumbers {
+				if errs[i] != nil {
+					result.Failed = append(result.Failed, BulkIssueFailure{IssueNumber: number, Error: errs[i].Error()})
+					continue
+				}
+				result.Updated = append(result.Updated, number)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // GetIssueComments creates a tool to get comments for a GitHub issue.
 func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_issue_comments",
@@ -1321,7 +2117,7 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
 		}
 }
 
@@ -2357,6 +2357,90 @@ func Test_ListSubIssues(t *testing.T) {
 	}
 }
 
+func Test_GetParentIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetParentIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_parent_issue", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	getParent := mock.EndpointPattern{
+		Pattern: "/repos/{owner}/{repo}/issues/{issue_number}/parent",
+		Method:  "GET",
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "issue has a parent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getParent,
+					expectPath(t, "/repos/owner/repo/issues/42/parent").andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							Number: github.Ptr(7),
+							Title:  github.Ptr("Epic"),
+						}),
+					),
+				),
+			),
+			expectedText: `"title":"Epic"`,
+		},
+		{
+			name: "issue has no parent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getParent,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectedText: "issue owner/repo#42 has no parent issue",
+		},
+		{
+			name: "request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getParent,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get parent issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetParentIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
+
 func Test_RemoveSubIssue(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -2989,3 +3073,562 @@ func Test_ListIssueTypes(t *testing.T) {
 		})
 	}
 }
+
+func Test_TransferIssue(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := TransferIssue(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "transfer_issue", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "target_repo"})
+
+	var idsQuery struct {
+		Repository struct {
+			Issue struct {
+				ID githubv4.ID
+			} `graphql:"issue(number: $issueNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+		Target struct {
+			ID githubv4.ID
+		} `graphql:"target: repository(owner: $targetOwner, name: $targetRepo)"`
+	}
+	idsVars := map[string]any{
+		"owner":       githubv4.String("owner"),
+		"repo":        githubv4.String("repo"),
+		"issueNumber": githubv4.Int(42),
+		"targetOwner": githubv4.String("owner"),
+		"targetRepo":  githubv4.String("other"),
+	}
+	idsResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"issue": map[string]any{"id": "I_1"},
+		},
+		"target": map[string]any{"id": "R_2"},
+	})
+	var transferMutation struct {
+		TransferIssue struct {
+			Issue struct {
+				Number githubv4.Int
+				URL    githubv4.URI
+			}
+		} `graphql:"transferIssue(input: $input)"`
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "transfers issue",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(idsQuery, idsVars, idsResponse),
+				githubv4mock.NewMutationMatcher(
+					transferMutation,
+					githubv4.TransferIssueInput{
+						IssueID:               "I_1",
+						RepositoryID:          "R_2",
+						CreateLabelsIfMissing: githubv4.NewBoolean(true),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"transferIssue": map[string]any{
+							"issue": map[string]any{
+								"number": 7,
+								"url":    "https://github.com/owner/other/issues/7",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                    "owner",
+				"repo":                     "repo",
+				"issue_number":             float64(42),
+				"target_repo":              "other",
+				"create_labels_if_missing": true,
+			},
+		},
+		{
+			name: "target repository not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(idsQuery, idsVars, githubv4mock.ErrorResponse("Could not resolve to a Repository with the name 'owner/other'.")),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"target_repo":  "other",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to find issue or target repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := TransferIssue(stubGetGQLClientFn(githubv4.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.JSONEq(t, `{"number":7,"url":"https://github.com/owner/other/issues/7"}`, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_LockIssue(t *testing.T) {
+	// Verify tool definitions once
+	mockClient := github.NewClient(nil)
+	lockTool, _ := LockIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(lockTool.Name, lockTool))
+	unlockTool, _ := UnlockIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(unlockTool.Name, unlockTool))
+
+	assert.Equal(t, "lock_issue", lockTool.Name)
+	assert.Contains(t, lockTool.InputSchema.Properties, "reason")
+	assert.Equal(t, "unlock_issue", unlockTool.Name)
+
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	args := map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+		"reason":       "too heated",
+	}
+
+	t.Run("locks with reason", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+				expectRequestBody(t, map[string]interface{}{
+					"lock_reason": "too heated",
+				}).andThen(noContent),
+			),
+		))
+		_, handler := LockIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Equal(t, "issue owner/repo#42 locked", getTextResult(t, result).Text)
+	})
+
+	t.Run("lock fails", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+			),
+		))
+		_, handler := LockIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to lock issue owner/repo#42")
+	})
+
+	t.Run("unlocks", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.DeleteReposIssuesLockByOwnerByRepoByIssueNumber, noContent),
+		))
+		_, handler := UnlockIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Equal(t, "issue owner/repo#42 unlocked", getTextResult(t, result).Text)
+	})
+}
+
+func Test_MarkIssueAsDuplicate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MarkIssueAsDuplicate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "mark_issue_as_duplicate", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "duplicate_of"})
+
+	closedIssue := &github.Issue{
+		Number:      github.Ptr(42),
+		State:       github.Ptr("closed"),
+		StateReason: github.Ptr("duplicate"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "same repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"body": "Duplicate of #7",
+					}).andThen(mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(1))})),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state":        "closed",
+						"state_reason": "duplicate",
+					}).andThen(mockResponse(t, http.StatusOK, closedIssue)),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"duplicate_of": float64(7),
+			},
+		},
+		{
+			name: "other repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"body": "Duplicate of owner/other#7",
+					}).andThen(mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(1))})),
+				),
+				mock.WithRequestMatch(mock.PatchReposIssuesByOwnerByRepoByIssueNumber, closedIssue),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"issue_number":      float64(42),
+				"duplicate_of":      float64(7),
+				"duplicate_of_repo": "other",
+			},
+		},
+		{
+			name:         "duplicate of itself",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"duplicate_of": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "an issue cannot be a duplicate of itself",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := MarkIssueAsDuplicate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var issue github.Issue
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &issue))
+			assert.Equal(t, "duplicate", issue.GetStateReason())
+		})
+	}
+}
+
+func Test_BulkUpdateIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkUpdateIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "bulk_update_issues", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_numbers"})
+
+	// failOn returns a handler that fails requests for the given issue and succeeds for the others.
+	failOn := func(number int, body any) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.URL.Path, fmt.Sprintf("/issues/%d", number)) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, body)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult BulkIssueUpdateResult
+		expectedErrMsg string
+	}{
+		{
+			name: "updates all issues",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, []interface{}{"triaged"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("triaged")}}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					failOn(2, []*github.Label{}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state":        "closed",
+						"state_reason": "not_planned",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{State: github.Ptr("closed")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(2)},
+				"add_labels":    []any{"triaged"},
+				"remove_labels": []any{"needs-triage"},
+				"state":         "closed",
+				"state_reason":  "not_planned",
+			},
+			expectedResult: BulkIssueUpdateResult{
+				Updated: []int{1, 2},
+				Failed:  []BulkIssueFailure{},
+			},
+		},
+		{
+			name: "reports partial failures",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					failOn(3, &github.Issue{}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1), float64(3), float64(5)},
+				"add_assignees": []any{"octocat"},
+			},
+			expectedResult: BulkIssueUpdateResult{
+				Updated: []int{1, 5},
+				Failed: []BulkIssueFailure{
+					{IssueNumber: 3},
+				},
+			},
+		},
+		{
+			name:         "no changes",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{float64(1)},
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one change must be given",
+		},
+		{
+			name:         "no issues",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_numbers": []any{},
+				"state":         "closed",
+			},
+			expectError:    true,
+			expectedErrMsg: "issue_numbers must contain at least one issue number",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response BulkIssueUpdateResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedResult.Updated, response.Updated)
+			require.Len(t, response.Failed, len(tc.expectedResult.Failed))
+			for i, failure := range tc.expectedResult.Failed {
+				assert.Equal(t, failure.IssueNumber, response.Failed[i].IssueNumber)
+				assert.Contains(t, response.Failed[i].Error, "failed to add assignees")
+			}
+		})
+	}
+}
+
+func Test_SearchIssuesGraphQL(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := SearchIssuesGraphQL(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "search_issues_graphql", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+
+	var searchQuery struct {
+		Search struct {
+			IssueCount githubv4.Int
+			PageInfo   PageInfoFragment
+			Nodes      []struct {
+				Issue struct {
+					Number     githubv4.Int
+					Title      githubv4.String
+					State      githubv4.IssueState
+					UpdatedAt  githubv4.DateTime
+					URL        githubv4.URI
+					Repository struct {
+						NameWithOwner githubv4.String
+					}
+					Labels struct {
+						Nodes []struct {
+							Name githubv4.String
+						}
+					} `graphql:"labels(first: 20)"`
+				} `graphql:"... on Issue"`
+			}
+		} `graphql:"search(query: $query, type: ISSUE, first: $first, after: $after)"`
+	}
+	searchVars := func(query string) map[string]any {
+		return map[string]any{
+			"query": githubv4.String(query),
+			"first": githubv4.Int(30),
+			"after": (*githubv4.String)(nil),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedIssues []IssueSearchResult
+		expectedErrMsg string
+	}{
+		{
+			name: "scopes query to repository and returns trimmed issues",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(searchQuery, searchVars("repo:owner/repo is:issue label:bug"), githubv4mock.DataResponse(map[string]any{
+					"search": map[string]any{
+						"issueCount": 1,
+						"pageInfo": map[string]any{
+							"hasNextPage":     true,
+							"hasPreviousPage": false,
+							"startCursor":     "c1",
+							"endCursor":       "c1",
+						},
+						"nodes": []any{
+							map[string]any{
+								"number":     42,
+								"title":      "Crash on start",
+								"state":      "OPEN",
+								"updatedAt":  "2024-05-01T10:00:00Z",
+								"url":        "https://github.com/owner/repo/issues/42",
+								"repository": map[string]any{"nameWithOwner": "owner/repo"},
+								"labels": map[string]any{
+									"nodes": []any{map[string]any{"name": "bug"}},
+								},
+							},
+						},
+					},
+				})),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "label:bug",
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedIssues: []IssueSearchResult{
+				{
+					Repository: "owner/repo",
+					Number:     42,
+					Title:      "Crash on start",
+					State:      "open",
+					Labels:     []string{"bug"},
+					UpdatedAt:  time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+					URL:        "https://github.com/owner/repo/issues/42",
+				},
+			},
+		},
+		{
+			name: "search fails",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(searchQuery, searchVars("is:issue in:title (("), githubv4mock.ErrorResponse("invalid search query")),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "in:title ((",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search issues",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := SearchIssuesGraphQL(stubGetGQLClientFn(githubv4.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Issues   []IssueSearchResult `json:"issues"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				TotalCount int `json:"totalCount"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedIssues, response.Issues)
+			assert.True(t, response.PageInfo.HasNextPage)
+			assert.Equal(t, "c1", response.PageInfo.EndCursor)
+			assert.Equal(t, 1, response.TotalCount)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/localclone"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type localClonesCtxKey struct{}
+
+// LocalClonesMiddleware makes the manager of local clones available to tools, so that they can
+// serve repository contents from disk instead of the API.
+func LocalClonesMiddleware(clones *localclone.Manager) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(context.WithValue(ctx, localClonesCtxKey{}, clones), request)
+		}
+	}
+}
+
+// localClone returns the local clone of a repository's default branch and the commit it is at,
+// if the server keeps local clones and this one is ready.
+func localClone(ctx context.Context, owner, repo string) (*localclone.Repo, string, bool) {
+	clones, _ := ctx.Value(localClonesCtxKey{}).(*localclone.Manager)
+	if clones == nil {
+		return nil, "", false
+	}
+	clone, ok := clones.Repo(owner, repo)
+	if !ok {
+		return nil, "", false
+	}
+	commit, err := clone.Commit(ctx)
+	if err != nil {
+		return nil, "", false
+	}
+	return clone, commit, true
+}
+
+// setLocalCloneMeta tells the caller that a result was served from a local clone, and how
+// fresh the clone is.
+func setLocalCloneMeta(result *mcp.CallToolResult, clone *localclone.Repo, commit string) {
+	setResultMeta(result, "local_clone", map[string]any{
+		"commit":     commit,
+		"fetched_at": clone.FetchedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// localFileContents serves get_file_contents for a repository's default branch from its local
+// clone. It returns nil if the contents have to be fetched from the API instead.
+func localFileContents(ctx context.Context, owner, repo, path string, window fileWindowOptions) *mcp.CallToolResult {
+	clone, commit, ok := localClone(ctx, owner, repo)
+	if !ok {
+		return nil
+	}
+
+	var result *mcp.CallToolResult
+	if path == "" || strings.HasSuffix(path, "/") {
+		entries, err := clone.ListDir(ctx, commit, path)
+		if err != nil {
+			return nil
+		}
+		contents := make([]*github.RepositoryContent, 0, len(entries))
+		for _, entry := range entries {
+			contents = append(contents, &github.RepositoryContent{
+				Type: github.Ptr(entry.Type),
+				Name: github.Ptr(entry.Name),
+				Path: github.Ptr(entry.Path),
+				SHA:  github.Ptr(entry.SHA),
+				Size: github.Ptr(int(entry.Size)),
+			})
+		}
+		r, err := json.Marshal(contents)
+		if err != nil {
+			return nil
+		}
+		result = mcp.NewToolResultText(string(r))
+	} else {
+		content, sha, err := clone.ReadFile(ctx, commit, path)
+		if err != nil {
+			return nil
+		}
+		result, err = fileContentsResult(owner, repo, path, "", "", sha, content, http.DetectContentType(content), window)
+		if err != nil {
+			return nil
+		}
+	}
+	setLocalCloneMeta(result, clone, commit)
+	return result
+}
+
+// GrepMatch is a match found by grep_repository. Line and Text are only known for matches
+// found in a local clone.
+type GrepMatch struct {
+	Path string `json:"path"`
+	Line int    `json:"line,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// GrepResult is the output of grep_repository.
+type GrepResult struct {
+	// Source is local_clone or code_search.
+	Source    string      `json:"source"`
+	Commit    string      `json:"commit,omitempty"`
+	Matches   []GrepMatch `json:"matches"`
+	Truncated bool        `json:"truncated"`
+}
+
+// GrepRepository creates a tool to search the files of a repository's default branch.
+func GrepRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("grep_repository",
+			mcp.WithDescription(t("TOOL_GREP_REPOSITORY_DESCRIPTION", "Search the files on the default branch of a repository for a pattern, returning matching lines. Served from a local clone when the server keeps one for the repository, which supports extended regular expressions and reports line numbers. Otherwise falls back to code search, which matches the pattern as search terms and reports matching files only.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GREP_REPOSITORY_USER_TITLE", "Search repository files"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("pattern",
+				mcp.Required(),
+				mcp.Description("Extended regular expression to search for, e.g. 'func [A-Z][a-zA-Z]*\\('"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Only search files in this directory or file"),
+			),
+			mcp.WithBoolean("ignore_case",
+				mcp.Description("Match case-insensitively"),
+			),
+			mcp.WithNumber("max_results",
+				mcp.Description("Maximum number of matches to return"),
+				mcp.Min(1),
+				mcp.Max(1000),
+				mcp.DefaultNumber(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pattern, err := RequiredParam[string](request, "pattern")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ignoreCase, err := OptionalParam[bool](request, "ignore_case")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxResults, err := OptionalIntParamWithDefault(request, "max_results", 100)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if clone, commit, ok := localClone(ctx, owner, repo); ok {
+				matches, truncated, err := clone.Grep(ctx, commit, pattern, path, ignoreCase, maxResults)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to search local clone: %s", err)), nil
+				}
+				grepResult := GrepResult{Source: "local_clone", Commit: commit, Matches: make([]GrepMatch, 0, len(matches)), Truncated: truncated}
+				for _, match := range matches {
+					grepResult.Matches = append(grepResult.Matches, GrepMatch{Path: match.Path, Line: match.Line, Text: match.Text})
+				}
+				r, err := json.Marshal(grepResult)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				result := mcp.NewToolResultText(string(r))
+				setLocalCloneMeta(result, clone, commit)
+				return result, nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			query := fmt.Sprintf("%s repo:%s/%s", pattern, owner, repo)
+			if path = strings.Trim(path, "/"); path != "" {
+				query += " path:" + path
+			}
+			opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: min(maxResults, 100)}}
+			searchResult, resp, err := client.Search.Code(ctx, query, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to search code with query '%s'", query),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			grepResult := GrepResult{Source: "code_search", Matches: make([]GrepMatch, 0, len(searchResult.CodeResults))}
+			for _, code := range searchResult.CodeResults {
+				grepResult.Matches = append(grepResult.Matches, GrepMatch{Path: code.GetPath()})
+			}
+			grepResult.Truncated = searchResult.GetTotal() > len(grepResult.Matches)
+
+			r, err := json.Marshal(grepResult)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/localclone"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLocalClones returns a context whose tools serve octo/hello from a ready local clone
+// with the given files.
+func newLocalClones(t *testing.T, files map[string]string) context.Context {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	upstream := t.TempDir()
+	dir := filepath.Join(upstream, "octo", "hello.git")
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0600))
+	}
+	for _, args := range [][]string{{"init", "-q", "-b", "main"}, {"add", "-A"}, {"commit", "-q", "-m", "initial"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	clones, err := localclone.NewManager(localclone.Config{Dir: t.TempDir(), BaseURL: "file://" + upstream + "/"})
+	require.NoError(t, err)
+	require.NoError(t, clones.Sync(context.Background(), "octo", "hello"))
+	return context.WithValue(context.Background(), localClonesCtxKey{}, clones)
+}
+
+func Test_GetFileContents_LocalClone(t *testing.T) {
+	ctx := newLocalClones(t, map[string]string{
+		"README.md":   "# Hello\n",
+		"src/main.go": "package main\n",
+	})
+	// No API requests are expected.
+	client := github.NewClient(mock.NewMockedHTTPClient())
+	_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+
+	result, err := handler(ctx, createMCPRequest(map[string]interface{}{
+		"owner": "octo",
+		"repo":  "hello",
+		"path":  "README.md",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	resource := getTextResourceResult(t, result)
+	assert.Equal(t, "# Hello\n", resource.Text)
+	assert.Equal(t, "repo://octo/hello/contents/README.md", resource.URI)
+	assert.Contains(t, result.Meta.AdditionalFields, "local_clone")
+
+	result, err = handler(ctx, createMCPRequest(map[string]interface{}{
+		"owner": "octo",
+		"repo":  "hello",
+		"path":  "src/",
+	}))
+	require.NoError(t, err)
+	var entries []*github.RepositoryContent
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "src/main.go", entries[0].GetPath())
+	assert.Equal(t, "file", entries[0].GetType())
+}
+
+func Test_GrepRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GrepRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "grep_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pattern")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ignore_case")
+	assert.Contains(t, tool.InputSchema.Properties, "max_results")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pattern"})
+
+	t.Run("local clone", func(t *testing.T) {
+		ctx := newLocalClones(t, map[string]string{
+			"main.go":     "package main\n\nfunc main() {}\n",
+			"docs/API.md": "The Main entry point\n",
+		})
+		client := github.NewClient(mock.NewMockedHTTPClient())
+		_, handler := GrepRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(ctx, createMCPRequest(map[string]interface{}{
+			"owner":       "octo",
+			"repo":        "hello",
+			"pattern":     "main( |\\()",
+			"ignore_case": true,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var grepResult GrepResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &grepResult))
+		assert.Equal(t, "local_clone", grepResult.Source)
+		assert.Len(t, grepResult.Commit, 40)
+		assert.False(t, grepResult.Truncated)
+		assert.Equal(t, []GrepMatch{
+			{Path: "docs/API.md", Line: 1, Text: "The Main entry point"},
+			{Path: "main.go", Line: 3, Text: "func main() {}"},
+		}, grepResult.Matches)
+	})
+
+	t.Run("falls back to code search", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetSearchCode,
+				expectQueryParams(t, map[string]string{
+					"q":        "TODO repo:octo/hello path:src",
+					"per_page": "100",
+				}).andThen(
+					mockResponse(t, 200, &github.CodeSearchResult{
+						Total: github.Ptr(2),
+						CodeResults: []*github.CodeResult{
+							{Path: github.Ptr("src/main.go")},
+						},
+					}),
+				),
+			),
+		))
+		_, handler := GrepRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":   "octo",
+			"repo":    "hello",
+			"pattern": "TODO",
+			"path":    "src/",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var grepResult GrepResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &grepResult))
+		assert.Equal(t, GrepResult{
+			Source:    "code_search",
+			Matches:   []GrepMatch{{Path: "src/main.go"}},
+			Truncated: true,
+		}, grepResult)
+	})
+}
@@ -0,0 +1,210 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/macros"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MacrosToolset is the name of the toolset that offers the macros of the server.
+const MacrosToolset = "macros"
+
+// macroStepResult is the result of a step of a macro.
+type macroStepResult struct {
+	ID     string `json:"id,omitempty"`
+	Tool   string `json:"tool"`
+	Result any    `json:"result"`
+}
+
+// AddMacros adds a toolset offering each macro as a tool that calls the tools of its steps in
+// turn. Steps may call the tools of any toolset, enabled or not. Macros whose steps call tools
+// that are not available, such as write tools in read-only mode, are not offered and are
+// returned. The calls of the steps go through wrap, if it is not nil, as calls from the client go
+// through the middleware of the server.
+func AddMacros(tsg *toolsets.ToolsetGroup, defs []macros.Macro, wrap server.ToolHandlerMiddleware) ([]string, error) {
+	available := make(map[string]server.ServerTool)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			available[tool.Tool.Name] = tool
+		}
+	}
+
+	toolset := toolsets.NewToolset(MacrosToolset, "Composite operations configured for this server, each calling several tools in turn")
+	var skipped []string
+	for _, def := range defs {
+		if _, ok := available[def.Name]; ok {
+			return nil, fmt.Errorf("macro %s has the name of a tool", def.Name)
+		}
+		steps := make([]server.ServerTool, 0, len(def.Steps))
+		for _, step := range def.Steps {
+			if tool, ok := available[step.Tool]; ok {
+				steps = append(steps, tool)
+			}
+		}
+		if len(steps) < len(def.Steps) {
+			skipped = append(skipped, def.Name)
+			continue
+		}
+		tool := macroTool(def, steps, wrap)
+		if *tool.Tool.Annotations.ReadOnlyHint {
+			toolset.AddReadTools(tool)
+		} else {
+			toolset.AddWriteTools(tool)
+		}
+	}
+	tsg.AddToolset(toolset)
+	toolset.Enabled = true
+	return skipped, nil
+}
+
+// macroTool offers a macro as a tool. It is read-only if all of its steps are, and destructive if
+// any of them is.
+func macroTool(def macros.Macro, steps []server.ServerTool, wrap server.ToolHandlerMiddleware) server.ServerTool {
+	readOnly, destructive := true, false
+	for _, step := range steps {
+		annotations := step.Tool.Annotations
+		readOnly = readOnly && annotations.ReadOnlyHint != nil && *annotations.ReadOnlyHint
+		destructive = destructive || (annotations.DestructiveHint != nil && *annotations.DestructiveHint)
+	}
+	toolNames := make([]string, len(steps))
+	for i, step := range steps {
+		toolNames[i] = step.Tool.Name
+	}
+	description := def.Description
+	if description == "" {
+		description = fmt.Sprintf("Calls %s in turn", strings.Join(toolNames, ", "))
+	}
+
+	opts := []mcp.ToolOption{
+		mcp.WithDescription(description),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           def.Name,
+			ReadOnlyHint:    ToBoolPtr(readOnly),
+			DestructiveHint: ToBoolPtr(destructive),
+		}),
+	}
+	for _, param := range def.Params {
+		propertyOpts := []mcp.PropertyOption{mcp.Description(param.Description)}
+		if param.Required {
+			propertyOpts = append(propertyOpts, mcp.Required())
+		}
+		switch param.Type {
+		case macros.TypeNumber:
+			opts = append(opts, mcp.WithNumber(param.Name, propertyOpts...))
+		case macros.TypeBoolean:
+			opts = append(opts, mcp.WithBoolean(param.Name, propertyOpts...))
+		case macros.TypeArray:
+			propertyOpts = append(propertyOpts, mcp.Items(map[string]any{"type": "string"}))
+			opts = append(opts, mcp.WithArray(param.Name, propertyOpts...))
+		default:
+			opts = append(opts, mcp.WithString(param.Name, propertyOpts...))
+		}
+	}
+
+	return toolsets.NewServerTool(mcp.NewTool(def.Name, opts...), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		params, err := macroParams(def, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		results := make(map[string]any)
+		completed := make([]macroStepResult, 0, len(steps))
+		for i, step := range def.Steps {
+			failed := func(reason string) (*mcp.CallToolResult, error) {
+				message := fmt.Sprintf("step %d (%s) of %s failed: %s", i+1, step.Tool, def.Name, reason)
+				if len(completed) > 0 {
+					done := make([]string, len(completed))
+					for j, c := range completed {
+						done[j] = c.Tool
+					}
+					message += fmt.Sprintf("; steps completed before it: %s", strings.Join(done, ", "))
+				}
+				return mcp.NewToolResultError(message), nil
+			}
+
+			args, err := step.Arguments(params, results)
+			if err != nil {
+				return failed(err.Error())
+			}
+			stepRequest := mcp.CallToolRequest{}
+			stepRequest.Params.Name = step.Tool
+			stepRequest.Params.Arguments = args
+			handler := steps[i].Handler
+			if wrap != nil {
+				handler = wrap(handler)
+			}
+
+			result, err := handler(ctx, stepRequest)
+			if err != nil {
+				return failed(err.Error())
+			}
+			output := resultValue(result)
+			if result.IsError {
+				return failed(fmt.Sprint(output))
+			}
+			if step.ID != "" {
+				results[step.ID] = output
+			}
+			completed = append(completed, macroStepResult{ID: step.ID, Tool: step.Tool, Result: output})
+		}
+		return MarshalledTextResult(map[string]any{"steps": completed}), nil
+	})
+}
+
+// macroParams returns the parameters a macro was called with, with the defaults of those that
+// were not given.
+func macroParams(def macros.Macro, request mcp.CallToolRequest) (map[string]any, error) {
+	args := request.GetArguments()
+	params := make(map[string]any, len(def.Params))
+	for _, param := range def.Params {
+		value, ok := args[param.Name]
+		if !ok {
+			if param.Required {
+				return nil, fmt.Errorf("missing required parameter: %s", param.Name)
+			}
+			if param.Default != nil {
+				params[param.Name] = param.Default
+			}
+			continue
+		}
+		var valid bool
+		switch param.Type {
+		case macros.TypeNumber:
+			_, valid = value.(float64)
+		case macros.TypeBoolean:
+			_, valid = value.(bool)
+		case macros.TypeArray:
+			_, valid = value.([]any)
+		default:
+			_, valid = value.(string)
+		}
+		if !valid {
+			return nil, fmt.Errorf("parameter %s is not of type %s, is %T", param.Name, param.Type, value)
+		}
+		params[param.Name] = value
+	}
+	return params, nil
+}
+
+// resultValue returns the JSON text of a result decoded, or the text itself if it is not
+// JSON.
+func resultValue(result *mcp.CallToolResult) any {
+	for _, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal([]byte(text.Text), &v); err != nil {
+			return text.Text
+		}
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/macros"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMacros = `
+macros:
+  - name: my_milestone
+    description: Create a milestone named after the authenticated user
+    params:
+      - {name: owner, required: true}
+      - {name: repo, required: true}
+    steps:
+      - id: me
+        tool: get_me
+      - tool: create_milestone
+        args: {owner: "${{ params.owner }}", repo: "${{ params.repo }}", title: "Sprint of ${{ steps.me.login }}"}
+  - name: whoami
+    steps:
+      - tool: get_me
+`
+
+func Test_AddMacros(t *testing.T) {
+	defs, err := macros.Parse([]byte(testMacros))
+	require.NoError(t, err)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetUser,
+			mockResponse(t, http.StatusOK, &github.User{Login: github.Ptr("octocat")}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposMilestonesByOwnerByRepo,
+			expectRequestBody(t, map[string]any{"title": "Sprint of octocat"}).andThen(
+				mockResponse(t, http.StatusCreated, &github.Milestone{Number: github.Ptr(3), Title: github.Ptr("Sprint of octocat")}),
+			),
+		),
+	))
+	newToolsetGroup := func(readOnly bool) *toolsets.ToolsetGroup {
+		tsg := toolsets.NewToolsetGroup(readOnly)
+		tsg.AddToolset(toolsets.NewToolset("test", "Test tools").
+			AddReadTools(toolsets.NewServerTool(GetMe(stubGetClientFn(client), translations.NullTranslationHelper))).
+			AddWriteTools(toolsets.NewServerTool(CreateMilestone(stubGetClientFn(client), translations.NullTranslationHelper))))
+		return tsg
+	}
+
+	t.Run("offers macros as tools", func(t *testing.T) {
+		tsg := newToolsetGroup(false)
+		skipped, err := AddMacros(tsg, defs, nil)
+		require.NoError(t, err)
+		assert.Empty(t, skipped)
+
+		toolset := tsg.Toolsets[MacrosToolset]
+		require.NotNil(t, toolset)
+		assert.True(t, toolset.Enabled)
+		tools := toolset.GetActiveTools()
+		assert.ElementsMatch(t, []string{"my_milestone", "whoami"}, toolNames(tools))
+
+		tool, ok := findTool(tools, "my_milestone")
+		require.True(t, ok)
+		assert.False(t, *tool.Tool.Annotations.ReadOnlyHint)
+		assert.ElementsMatch(t, []string{"owner", "repo"}, tool.Tool.InputSchema.Required)
+
+		tool, ok = findTool(tools, "whoami")
+		require.True(t, ok)
+		assert.True(t, *tool.Tool.Annotations.ReadOnlyHint)
+		assert.Equal(t, "Calls get_me in turn", tool.Tool.Description)
+	})
+
+	t.Run("calls the steps in turn", func(t *testing.T) {
+		tsg := newToolsetGroup(false)
+		var called []string
+		wrap := func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+			return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				called = append(called, request.Params.Name)
+				return next(ctx, request)
+			}
+		}
+		_, err := AddMacros(tsg, defs, wrap)
+		require.NoError(t, err)
+		tool, _ := findTool(tsg.Toolsets[MacrosToolset].GetActiveTools(), "my_milestone")
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org", "repo": "api"}))
+		require.NoError(t, err)
+		var response struct {
+			Steps []struct {
+				ID     string         `json:"id"`
+				Tool   string         `json:"tool"`
+				Result map[string]any `json:"result"`
+			} `json:"steps"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		require.Len(t, response.Steps, 2)
+		assert.Equal(t, "me", response.Steps[0].ID)
+		assert.Equal(t, "create_milestone", response.Steps[1].Tool)
+		assert.Equal(t, float64(3), response.Steps[1].Result["number"])
+		assert.Equal(t, []string{"get_me", "create_milestone"}, called)
+	})
+
+	t.Run("stops at a failed step", func(t *testing.T) {
+		tsg := newToolsetGroup(false)
+		refuse := func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+			return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				if request.Params.Name == "create_milestone" {
+					return mcp.NewToolResultError("create_milestone may not be used in octo-org/api"), nil
+				}
+				return next(ctx, request)
+			}
+		}
+		_, err := AddMacros(tsg, defs, refuse)
+		require.NoError(t, err)
+		tool, _ := findTool(tsg.Toolsets[MacrosToolset].GetActiveTools(), "my_milestone")
+
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org", "repo": "api"}))
+		require.NoError(t, err)
+		assert.Equal(t, "step 2 (create_milestone) of my_milestone failed: create_milestone may not be used in octo-org/api; steps completed before it: get_me", getErrorResult(t, result).Text)
+
+		result, err = tool.Handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org"}))
+		require.NoError(t, err)
+		assert.Equal(t, "missing required parameter: repo", getErrorResult(t, result).Text)
+	})
+
+	t.Run("skips macros calling unavailable tools", func(t *testing.T) {
+		tsg := newToolsetGroup(true)
+		skipped, err := AddMacros(tsg, defs, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"my_milestone"}, skipped)
+		assert.Equal(t, []string{"whoami"}, toolNames(tsg.Toolsets[MacrosToolset].GetActiveTools()))
+	})
+
+	t.Run("rejects macros named after tools", func(t *testing.T) {
+		_, err := AddMacros(newToolsetGroup(false), []macros.Macro{{Name: "get_me", Steps: []macros.Step{{Tool: "get_me"}}}}, nil)
+		assert.EqualError(t, err, "macro get_me has the name of a tool")
+	})
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// rawMediaFormat is an alternate representation GitHub can return a resource in instead of JSON,
+// selected with the Accept header.
+type rawMediaFormat struct {
+	// mediaType is the media type requested from GitHub.
+	mediaType string
+	// mimeType is the MIME type of the resource returned to the caller.
+	mimeType string
+}
+
+// rawMediaFormats are the alternate representations supported by the format parameter. JSON is
+// the default and is not listed.
+var rawMediaFormats = map[string]rawMediaFormat{
+	"diff":  {mediaType: "application/vnd.github.diff", mimeType: "text/x-diff"},
+	"patch": {mediaType: "application/vnd.github.patch", mimeType: "text/x-patch"},
+}
+
+// WithRawMediaFormat adds a format parameter for requesting a resource in one of the given raw
+// media formats instead of JSON.
+func WithRawMediaFormat(description string, formats ...string) mcp.ToolOption {
+	return mcp.WithString("format",
+		mcp.Description(description),
+		mcp.Enum(append([]string{"json"}, formats...)...),
+		mcp.DefaultString("json"),
+	)
+}
+
+// optionalRawMediaFormat returns the raw media format requested with the format parameter, or
+// false if the resource is requested as JSON.
+func optionalRawMediaFormat(request mcp.CallToolRequest) (string, bool, error) {
+	format, err := OptionalParam[string](request, "format")
+	if err != nil {
+		return "", false, err
+	}
+	if format == "" || format == "json" {
+		return "", false, nil
+	}
+	if _, ok := rawMediaFormats[format]; !ok {
+		return "", false, fmt.Errorf("unsupported format: %s", format)
+	}
+	return format, true, nil
+}
+
+// getRawMedia gets the API resource at path in a raw media format.
+func getRawMedia(ctx context.Context, client *github.Client, path, format string) (string, *github.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Accept", rawMediaFormats[format].mediaType)
+
+	var buf bytes.Buffer
+	resp, err := client.Do(ctx, req, &buf)
+	if err != nil {
+		return "", resp, err
+	}
+	return buf.String(), resp, nil
+}
+
+// rawMediaResult returns content in a raw media format as a text resource.
+func rawMediaResult(uri, format, content string) *mcp.CallToolResult {
+	return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded %s", format), mcp.TextResourceContents{
+		URI:      uri,
+		MIMEType: rawMediaFormats[format].mimeType,
+		Text:     content,
+	})
+}
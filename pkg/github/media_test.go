@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rawMediaHandler serves content only to requests for the given media type.
+func rawMediaHandler(t *testing.T, mediaType, content string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, mediaType, r.Header.Get("Accept"))
+		_, _ = w.Write([]byte(content))
+	}
+}
+
+func Test_GetCommit_RawMediaFormat(t *testing.T) {
+	patch := "From abc123 Mon Sep 17 00:00:00 2001\nSubject: [PATCH] Fix bug\n"
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsByOwnerByRepoByRef,
+			rawMediaHandler(t, "application/vnd.github.patch", patch),
+		),
+	))
+	_, handler := GetCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":  "owner",
+		"repo":   "repo",
+		"sha":    "abc123",
+		"format": "patch",
+	}))
+	require.NoError(t, err)
+
+	resource := getTextResourceResult(t, result)
+	assert.Equal(t, "repo://owner/repo/commits/abc123.patch", resource.URI)
+	assert.Equal(t, "text/x-patch", resource.MIMEType)
+	assert.Equal(t, patch, resource.Text)
+}
+
+func Test_GetPullRequest_RawMediaFormat(t *testing.T) {
+	diff := "diff --git a/README.md b/README.md\n"
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		format         string
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "returns diff as text resource",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					rawMediaHandler(t, "application/vnd.github.diff", diff),
+				),
+			),
+			format: "diff",
+		},
+		{
+			name:           "unsupported format",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			format:         "html",
+			expectError:    true,
+			expectedErrMsg: "unsupported format: html",
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			format:         "diff",
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request diff",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"format":     tc.format,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			resource := getTextResourceResult(t, result)
+			assert.Equal(t, "repo://owner/repo/pulls/42.diff", resource.URI)
+			assert.Equal(t, "text/x-diff", resource.MIMEType)
+			assert.Equal(t, diff, resource.Text)
+		})
+	}
+}
@@ -0,0 +1,725 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxMilestoneProgressIssues bounds the number of milestone issues fetched to compute progress.
+const maxMilestoneProgressIssues = 1000
+
+// maxMilestoneProgressEvents bounds the number of repository issue events replayed to compute
+// the history of a milestone.
+const maxMilestoneProgressEvents = 3000
+
+// Risk flags reported by get_milestone_progress.
+const (
+	milestoneRiskOverdue            = "overdue"
+	milestoneRiskProjectedAfterDue  = "projected_after_due_date"
+	milestoneRiskNoRecentProgress   = "no_recent_progress"
+	milestoneRiskScopeGrowingFaster = "scope_growing_faster_than_closures"
+)
+
+// MilestoneProgressPoint is the number of open and closed milestone items at the end of a day.
+type MilestoneProgressPoint struct {
+	Date   string `json:"date"`
+	Open   int    `json:"open"`
+	Closed int    `json:"closed"`
+}
+
+// MilestoneProgress is the output of get_milestone_progress.
+type MilestoneProgress struct {
+	Milestone           int                      `json:"milestone"`
+	Title               string                   `json:"title"`
+	State               string                   `json:"state"`
+	DueOn               string                   `json:"due_on,omitempty"`
+	Open                int                      `json:"open"`
+	Closed              int                      `json:"closed"`
+	PercentComplete     float64                  `json:"percent_complete"`
+	ClosedInWindow      int                      `json:"closed_in_window"`
+	AddedInWindow       int                      `json:"added_in_window"`
+	VelocityPerWeek     float64                  `json:"velocity_per_week"`
+	ProjectedCompletion string                   `json:"projected_completion,omitempty"`
+	AtRisk              bool                     `json:"at_risk"`
+	RiskFlags           []string                 `json:"risk_flags"`
+	History             []MilestoneProgressPoint `json:"history"`
+	Truncated           bool                     `json:"truncated,omitempty"`
+}
+
+// milestoneItem is the state of an issue or pull request while replaying milestone events.
+type milestoneItem struct {
+	inMilestone bool
+	closed      bool
+	createdAt   time.Time
+}
+
+// computeMilestoneProgress derives burndown history, velocity and a projected completion date
+// for a milestone. Starting from the current state of its issues, it undoes the issue events
+// of the repository from the newest on, so that issues closed and reopened, or added to and
+// removed from the milestone, are counted as they were at the end of each day. Events must be
+// sorted newest first and cover the last windowDays days, which is the span of the returned
+// history and the period velocity is measured over.
+func computeMilestoneProgress(milestone *github.Milestone, issues []*github.Issue, events []*github.IssueEvent, now time.Time, windowDays int) MilestoneProgress {
+	now = now.UTC()
+	windowStart := now.AddDate(0, 0, -windowDays)
+
+	progress := MilestoneProgress{
+		Milestone: milestone.GetNumber(),
+		Title:     milestone.GetTitle(),
+		State:     milestone.GetState(),
+		RiskFlags: []string{},
+	}
+
+	items := map[int]*milestoneItem{}
+	for _, issue := range issues {
+		items[issue.GetNumber()] = &milestoneItem{
+			inMilestone: true,
+			closed:      issue.GetState() == "closed",
+			createdAt:   issue.GetCreatedAt().Time,
+		}
+		if issue.GetState() == "closed" {
+			progress.Closed++
+		} else {
+			progress.Open++
+		}
+	}
+	if total := progress.Open + progress.Closed; total > 0 {
+		progress.PercentComplete = math.Round(float64(progress.Closed)/float64(total)*1000) / 10
+	}
+
+	// Issues removed from the milestone are no longer listed with it, but were part of it before.
+	// Milestone events only name the milestone by its title.
+	for _, event := range events {
+		switch event.GetEvent() {
+		case "milestoned", "demilestoned":
+		default:
+			continue
+		}
+		number := event.GetIssue().GetNumber()
+		if event.GetMilestone().GetTitle() != milestone.GetTitle() || items[number] != nil {
+			continue
+		}
+		items[number] = &milestoneItem{
+			closed:    event.GetIssue().GetState() == "closed",
+			createdAt: event.GetIssue().GetCreatedAt().Time,
+		}
+	}
+
+	count := func(before time.Time) MilestoneProgressPoint {
+		var point MilestoneProgressPoint
+		for _, item := range items {
+			if !item.inMilestone || !item.createdAt.Before(before) {
+				continue
+			}
+			if item.closed {
+				point.Closed++
+			} else {
+				point.Open++
+			}
+		}
+		return point
+	}
+
+	// Undo events from the newest on, taking the counts at the end of each day once every later
+	// event is undone.
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	progress.History = make([]MilestoneProgressPoint, windowDays+1)
+	day := 0
+	snapshot := func() {
+		endOfDay := today.AddDate(0, 0, 1-day)
+		point := count(endOfDay)
+		point.Date = endOfDay.AddDate(0, 0, -1).Format("2006-01-02")
+		progress.History[windowDays-day] = point
+		day++
+	}
+	for _, event := range events {
+		at := event.GetCreatedAt().Time
+		if !at.After(windowStart) {
+			break
+		}
+		for day <= windowDays && at.Before(today.AddDate(0, 0, 1-day)) {
+			snapshot()
+		}
+		item := items[event.GetIssue().GetNumber()]
+		if item == nil {
+			continue
+		}
+		switch event.GetEvent() {
+		case "closed":
+			if item.inMilestone {
+				progress.ClosedInWindow++
+			}
+			item.closed = false
+		case "reopened":
+			if item.inMilestone {
+				progress.ClosedInWindow--
+			}
+			item.closed = true
+		case "milestoned":
+			if event.GetMilestone().GetTitle() == milestone.GetTitle() {
+				item.inMilestone = false
+			}
+		case "demilestoned":
+			if event.GetMilestone().GetTitle() == milestone.GetTitle() {
+				item.inMilestone = true
+			}
+		}
+	}
+	for day <= windowDays {
+		snapshot()
+	}
+	progress.ClosedInWindow = max(progress.ClosedInWindow, 0)
+	atWindowStart := count(windowStart)
+	progress.AddedInWindow = max(progress.Open+progress.Closed-atWindowStart.Open-atWindowStart.Closed, 0)
+
+	if windowDays > 0 {
+		progress.VelocityPerWeek = math.Round(float64(progress.ClosedInWindow)/float64(windowDays)*7*10) / 10
+	}
+
+	var projected time.Time
+	switch {
+	case progress.Open == 0:
+		projected = now
+	case progress.ClosedInWindow > 0:
+		daysRemaining := float64(progress.Open) * float64(windowDays) / float64(progress.ClosedInWindow)
+		projected = now.Add(time.Duration(daysRemaining * float64(24*time.Hour)))
+	}
+	if !projected.IsZero() {
+		progress.ProjectedCompletion = projected.Format("2006-01-02")
+	}
+
+	if milestone.DueOn != nil {
+		dueOn := milestone.GetDueOn().UTC()
+		progress.DueOn = dueOn.Format("2006-01-02")
+		if progress.Open > 0 && dueOn.Before(now) {
+			progress.RiskFlags = append(progress.RiskFlags, milestoneRiskOverdue)
+		} else if progress.Open > 0 && (projected.IsZero() || projected.After(dueOn)) {
+			progress.RiskFlags = append(progress.RiskFlags, milestoneRiskProjectedAfterDue)
+		}
+	}
+	if progress.Open > 0 && progress.ClosedInWindow == 0 {
+		progress.RiskFlags = append(progress.RiskFlags, milestoneRiskNoRecentProgress)
+	}
+	if progress.Open > 0 && progress.AddedInWindow > progress.ClosedInWindow {
+		progress.RiskFlags = append(progress.RiskFlags, milestoneRiskScopeGrowingFaster)
+	}
+	progress.AtRisk = len(progress.RiskFlags) > 0
+
+	return progress
+}
+
+// GetMilestoneProgress creates a tool to report burndown, velocity and projected completion of a milestone.
+func GetMilestoneProgress(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_milestone_progress",
+			mcp.WithDescription(t("TOOL_GET_MILESTONE_PROGRESS_DESCRIPTION", "Get the progress of a milestone: open and closed counts over time, recent velocity, projected completion date and at-risk flags. Prefer this over listing milestone issues to assess whether a milestone is on track.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MILESTONE_PROGRESS_USER_TITLE", "Get milestone progress"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Required(),
+				mcp.Description("Milestone number"),
+			),
+			mcp.WithNumber("window_days",
+				mcp.Description("Number of days of history to return and to measure velocity over (default: 28)"),
+				mcp.Min(1),
+				mcp.Max(365),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestoneNumber, err := RequiredInt(request, "milestone")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			windowDays, err := OptionalIntParamWithDefault(request, "window_days", 28)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if windowDays < 1 || windowDays > 365 {
+				return mcp.NewToolResultError("window_days must be between 1 and 365"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			milestone, resp, err := client.Issues.GetMilestone(ctx, owner, repo, milestoneNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get milestone",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			opts := &github.IssueListByRepoOptions{
+				Milestone: strconv.Itoa(milestoneNumber),
+				State:     "all",
+				ListOptions: github.ListOptions{
+					PerPage: 100,
+				},
+			}
+			var issues []*github.Issue
+			truncated := false
+			for {
+				page, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list milestone issues",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				issues = append(issues, page...)
+				if resp.NextPage == 0 {
+					break
+				}
+				if len(issues) >= maxMilestoneProgressIssues {
+					truncated = true
+					break
+				}
+				opts.ListOptions.Page = resp.NextPage
+			}
+
+			// Repository issue events are listed newest first; only those in the window are needed.
+			now := time.Now()
+			windowStart := now.AddDate(0, 0, -windowDays)
+			eventOpts := &github.ListOptions{PerPage: 100}
+			var events []*github.IssueEvent
+			for {
+				page, resp, err := client.Issues.ListRepositoryEvents(ctx, owner, repo, eventOpts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to list issue events",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				events = append(events, page...)
+				if resp.NextPage == 0 || len(page) == 0 || !page[len(page)-1].GetCreatedAt().After(windowStart) {
+					break
+				}
+				if len(events) >= maxMilestoneProgressEvents {
+					truncated = true
+					break
+				}
+				eventOpts.Page = resp.NextPage
+			}
+			sort.SliceStable(events, func(i, j int) bool {
+				return events[i].GetCreatedAt().After(events[j].GetCreatedAt().Time)
+			})
+
+			progress := computeMilestoneProgress(milestone, issues, events, now, windowDays)
+			progress.Truncated = truncated
+
+			r, err := json.Marshal(progress)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListMilestones creates a tool to list milestones in a repository.
+func ListMilestones(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_milestones",
+			mcp.WithDescription(t("TOOL_LIST_MILESTONES_DESCRIPTION", "List milestones in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_MILESTONES_USER_TITLE", "List milestones"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state (default: open)"),
+				mcp.Enum("open", "closed", "all"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort order (default: due_on)"),
+				mcp.Enum("due_on", "completeness"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction (default: asc)"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.MilestoneListOptions{
+				State:     state,
+				Sort:      sort,
+				Direction: direction,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list milestones",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(milestones)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// GetMilestone creates a tool to get a milestone in a repository.
+func GetMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_milestone",
+			mcp.WithDescription(t("TOOL_GET_MILESTONE_DESCRIPTION", "Get a milestone in a GitHub repository, including its open and closed issue counts")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MILESTONE_USER_TITLE", "Get milestone"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Required(),
+				mcp.Description("Milestone number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestoneNumber, err := RequiredInt(request, "milestone")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			milestone, resp, err := client.Issues.GetMilestone(ctx, owner, repo, milestoneNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get milestone: %d", milestoneNumber),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(milestone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// withMilestoneOptions adds the parameters for the editable fields of a milestone.
+func withMilestoneOptions() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("description",
+			mcp.Description("Milestone description"),
+		)(tool)
+		mcp.WithString("due_on",
+			mcp.Description("Due date in ISO 8601 format, e.g. 2025-06-30 or 2025-06-30T00:00:00Z"),
+		)(tool)
+		mcp.WithString("state",
+			mcp.Description("Milestone state"),
+			mcp.Enum("open", "closed"),
+		)(tool)
+	}
+}
+
+// milestoneFromParams builds a milestone from the parameters added by withMilestoneOptions,
+// setting only the fields that were given.
+func milestoneFromParams(request mcp.CallToolRequest) (*github.Milestone, error) {
+	milestone := &github.Milestone{}
+	for param, field := range map[string]**string{
+		"title":       &milestone.Title,
+		"description": &milestone.Description,
+		"state":       &milestone.State,
+	} {
+		value, ok, err := OptionalParamOK[string](request, param)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+
+	dueOn, ok, err := OptionalParamOK[string](request, "due_on")
+	if err != nil {
+		return nil, err
+	}
+	if ok && dueOn != "" {
+		due, err := parseISOTimestamp(dueOn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due_on: %w", err)
+		}
+		milestone.DueOn = &github.Timestamp{Time: due}
+	}
+	return milestone, nil
+}
+
+// CreateMilestone creates a tool to create a milestone in a repository.
+func CreateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_milestone",
+			mcp.WithDescription(t("TOOL_CREATE_MILESTONE_DESCRIPTION", "Create a milestone in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_MILESTONE_USER_TITLE", "Create milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Milestone title"),
+			),
+			withMilestoneOptions(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := RequiredParam[string](request, "title"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, err := milestoneFromParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Issues.CreateMilestone(ctx, owner, repo, milestone)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create milestone",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateMilestone creates a tool to update a milestone in a repository.
+func UpdateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_milestone",
+			mcp.WithDescription(t("TOOL_UPDATE_MILESTONE_DESCRIPTION", "Update a milestone in a GitHub repository. Only the given fields are changed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_MILESTONE_USER_TITLE", "Update milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Required(),
+				mcp.Description("Milestone number"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New milestone title"),
+			),
+			withMilestoneOptions(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestoneNumber, err := RequiredInt(request, "milestone")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, err := milestoneFromParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return editMilestone(ctx, getClient, owner, repo, milestoneNumber, milestone)
+		}
+}
+
+// CloseMilestone creates a tool to close a milestone in a repository.
+func CloseMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("close_milestone",
+			mcp.WithDescription(t("TOOL_CLOSE_MILESTONE_DESCRIPTION", "Close a milestone in a GitHub repository. Its issues keep the milestone.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CLOSE_MILESTONE_USER_TITLE", "Close milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Required(),
+				mcp.Description("Milestone number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestoneNumber, err := RequiredInt(request, "milestone")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return editMilestone(ctx, getClient, owner, repo, milestoneNumber, &github.Milestone{State: github.Ptr("closed")})
+		}
+}
+
+func editMilestone(ctx context.Context, getClient GetClientFn, owner, repo string, milestoneNumber int, milestone *github.Milestone) (*mcp.CallToolResult, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	updated, resp, err := client.Issues.EditMilestone(ctx, owner, repo, milestoneNumber, milestone)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to update milestone: %d", milestoneNumber),
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	r, err := json.Marshal(updated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
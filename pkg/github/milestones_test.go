@@ -0,0 +1,497 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ComputeMilestoneProgress(t *testing.T) {
+	now := time.Date(2024, 3, 29, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) *github.Timestamp {
+		return &github.Timestamp{Time: now.AddDate(0, 0, -d)}
+	}
+	issue := func(number int, state string, createdDaysAgo int) *github.Issue {
+		return &github.Issue{Number: github.Ptr(number), State: github.Ptr(state), CreatedAt: daysAgo(createdDaysAgo)}
+	}
+	event := func(kind string, i *github.Issue, d int) *github.IssueEvent {
+		e := &github.IssueEvent{Event: github.Ptr(kind), Issue: i, CreatedAt: daysAgo(d)}
+		if kind == "milestoned" || kind == "demilestoned" {
+			e.Milestone = &github.Milestone{Title: github.Ptr("v1.0")}
+		}
+		return e
+	}
+
+	issues := []*github.Issue{
+		issue(1, "closed", 20),
+		issue(2, "closed", 20),
+		issue(3, "closed", 30),
+		issue(4, "open", 20),
+		issue(5, "open", 3),
+	}
+	// Newest first, as listed by GitHub
+	events := []*github.IssueEvent{
+		event("closed", issues[1], 2),
+		event("milestoned", issues[4], 3),
+		event("closed", issues[0], 6),
+		event("closed", issues[2], 10),
+	}
+
+	t.Run("on track", func(t *testing.T) {
+		milestone := &github.Milestone{
+			Number: github.Ptr(4),
+			Title:  github.Ptr("v1.0"),
+			State:  github.Ptr("open"),
+			DueOn:  &github.Timestamp{Time: now.AddDate(0, 0, 30)},
+		}
+
+		progress := computeMilestoneProgress(milestone, issues, events, now, 7)
+		assert.Equal(t, 4, progress.Milestone)
+		assert.Equal(t, 2, progress.Open)
+		assert.Equal(t, 3, progress.Closed)
+		assert.Equal(t, 60.0, progress.PercentComplete)
+		assert.Equal(t, 2, progress.ClosedInWindow)
+		assert.Equal(t, 1, progress.AddedInWindow)
+		assert.Equal(t, 2.0, progress.VelocityPerWeek)
+		// Two open issues at two closures per week
+		assert.Equal(t, "2024-04-05", progress.ProjectedCompletion)
+		assert.Equal(t, "2024-04-28", progress.DueOn)
+		assert.False(t, progress.AtRisk)
+		assert.Empty(t, progress.RiskFlags)
+
+		require.Len(t, progress.History, 8)
+		assert.Equal(t, MilestoneProgressPoint{Date: "2024-03-22", Open: 3, Closed: 1}, progress.History[0])
+		assert.Equal(t, MilestoneProgressPoint{Date: "2024-03-26", Open: 3, Closed: 2}, progress.History[4])
+		assert.Equal(t, MilestoneProgressPoint{Date: "2024-03-29", Open: 2, Closed: 3}, progress.History[7])
+	})
+
+	t.Run("reopened and removed issues", func(t *testing.T) {
+		milestone := &github.Milestone{Number: github.Ptr(4), Title: github.Ptr("v1.0")}
+
+		// Issue 6 was closed and reopened, issue 7 was removed from the milestone
+		reopened := issue(6, "open", 20)
+		removed := issue(7, "open", 20)
+		events := []*github.IssueEvent{
+			event("reopened", reopened, 1),
+			event("closed", issues[1], 2),
+			event("demilestoned", removed, 2),
+			event("milestoned", issues[4], 3),
+			event("closed", reopened, 5),
+			event("closed", issues[0], 6),
+		}
+
+		progress := computeMilestoneProgress(milestone, append(issues[:5:5], reopened), events, now, 7)
+		assert.Equal(t, 3, progress.Open)
+		assert.Equal(t, 3, progress.Closed)
+		// The reopened issue's closure does not count, and the added issue replaced the removed one
+		assert.Equal(t, 2, progress.ClosedInWindow)
+		assert.Equal(t, 0, progress.AddedInWindow)
+
+		require.Len(t, progress.History, 8)
+		assert.Equal(t, MilestoneProgressPoint{Date: "2024-03-22", Open: 5, Closed: 1}, progress.History[0])
+		assert.Equal(t, MilestoneProgressPoint{Date: "2024-03-24", Open: 3, Closed: 3}, progress.History[2])
+		assert.Equal(t, MilestoneProgressPoint{Date: "2024-03-27", Open: 2, Closed: 4}, progress.History[5])
+		assert.Equal(t, MilestoneProgressPoint{Date: "2024-03-28", Open: 3, Closed: 3}, progress.History[6])
+		assert.Equal(t, MilestoneProgressPoint{Date: "2024-03-29", Open: 3, Closed: 3}, progress.History[7])
+	})
+
+	t.Run("at risk", func(t *testing.T) {
+		milestone := &github.Milestone{
+			Number: github.Ptr(5),
+			Title:  github.Ptr("v1.0"),
+			DueOn:  &github.Timestamp{Time: now.AddDate(0, 0, 3)},
+		}
+
+		progress := computeMilestoneProgress(milestone, issues, events, now, 7)
+		assert.True(t, progress.AtRisk)
+		assert.Equal(t, []string{milestoneRiskProjectedAfterDue}, progress.RiskFlags)
+
+		// Nothing closed in the last day, and the milestone has grown
+		progress = computeMilestoneProgress(milestone, append(issues, issue(8, "open", -4)), events, now.AddDate(0, 0, 4), 1)
+		assert.Equal(t, []string{milestoneRiskOverdue, milestoneRiskNoRecentProgress, milestoneRiskScopeGrowingFaster}, progress.RiskFlags)
+		assert.Empty(t, progress.ProjectedCompletion)
+	})
+}
+
+func Test_GetMilestoneProgress(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMilestoneProgress(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_milestone_progress", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "milestone")
+	assert.Contains(t, tool.InputSchema.Properties, "window_days")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone"})
+
+	recently := &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)}
+	mockMilestone := &github.Milestone{
+		Number: github.Ptr(3),
+		Title:  github.Ptr("Sprint 3"),
+		State:  github.Ptr("open"),
+	}
+	mockIssues := []*github.Issue{
+		{Number: github.Ptr(1), State: github.Ptr("closed"), CreatedAt: recently, ClosedAt: recently},
+		{Number: github.Ptr(2), State: github.Ptr("open"), CreatedAt: recently},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "computes progress for milestone",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+					mockMilestone,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"milestone": "3",
+						"state":     "all",
+						"per_page":  "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockIssues),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesEventsByOwnerByRepo,
+					[]*github.IssueEvent{
+						{Event: github.Ptr("closed"), Issue: mockIssues[0], CreatedAt: recently},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"milestone": float64(3),
+			},
+		},
+		{
+			name: "milestone not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"milestone": float64(99),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get milestone",
+		},
+		{
+			name:         "invalid window",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"milestone":   float64(3),
+				"window_days": float64(400),
+			},
+			expectError:    true,
+			expectedErrMsg: "window_days must be between 1 and 365",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetMilestoneProgress(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var progress MilestoneProgress
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &progress))
+			assert.Equal(t, 3, progress.Milestone)
+			assert.Equal(t, "Sprint 3", progress.Title)
+			assert.Equal(t, 1, progress.Open)
+			assert.Equal(t, 1, progress.Closed)
+			assert.Equal(t, 1, progress.ClosedInWindow)
+			assert.Len(t, progress.History, 29)
+			assert.NotEmpty(t, progress.ProjectedCompletion)
+		})
+	}
+}
+
+func Test_ListMilestones(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListMilestones(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_milestones", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockMilestones := []*github.Milestone{
+		{Number: github.Ptr(1), Title: github.Ptr("v1.0"), State: github.Ptr("closed")},
+		{Number: github.Ptr(2), Title: github.Ptr("v1.1"), State: github.Ptr("open")},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposMilestonesByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"state":     "all",
+				"sort":      "completeness",
+				"direction": "desc",
+				"page":      "1",
+				"per_page":  "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, mockMilestones),
+			),
+		),
+	))
+	_, handler := ListMilestones(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":     "owner",
+		"repo":      "repo",
+		"state":     "all",
+		"sort":      "completeness",
+		"direction": "desc",
+	}))
+	require.NoError(t, err)
+
+	var milestones []*github.Milestone
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestones))
+	require.Len(t, milestones, 2)
+	assert.Equal(t, "v1.1", milestones[1].GetTitle())
+}
+
+func Test_GetMilestone(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_milestone", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "gets milestone",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+					&github.Milestone{Number: github.Ptr(3), Title: github.Ptr("Sprint 3"), OpenIssues: github.Ptr(4)},
+				),
+			),
+		},
+		{
+			name: "milestone not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get milestone: 3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"milestone": float64(3),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var milestone github.Milestone
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestone))
+			assert.Equal(t, "Sprint 3", milestone.GetTitle())
+			assert.Equal(t, 4, milestone.GetOpenIssues())
+		})
+	}
+}
+
+func Test_CreateMilestone(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_milestone", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates milestone with due date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMilestonesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title":       "v2.0",
+						"description": "The big one",
+						"due_on":      "2025-06-30T00:00:00Z",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Milestone{Number: github.Ptr(5), Title: github.Ptr("v2.0")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"title":       "v2.0",
+				"description": "The big one",
+				"due_on":      "2025-06-30",
+			},
+		},
+		{
+			name:         "invalid due date",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"title":  "v2.0",
+				"due_on": "next tuesday",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid due_on",
+		},
+		{
+			name:         "missing title",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: title",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var milestone github.Milestone
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestone))
+			assert.Equal(t, 5, milestone.GetNumber())
+		})
+	}
+}
+
+func Test_UpdateMilestone(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_milestone", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+			expectRequestBody(t, map[string]any{
+				"title": "v2.0.1",
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.Milestone{Number: github.Ptr(5), Title: github.Ptr("v2.0.1")}),
+			),
+		),
+	))
+	_, handler := UpdateMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":     "owner",
+		"repo":      "repo",
+		"milestone": float64(5),
+		"title":     "v2.0.1",
+	}))
+	require.NoError(t, err)
+
+	var milestone github.Milestone
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestone))
+	assert.Equal(t, "v2.0.1", milestone.GetTitle())
+}
+
+func Test_CloseMilestone(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := CloseMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "close_milestone", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+			expectRequestBody(t, map[string]any{
+				"state": "closed",
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.Milestone{Number: github.Ptr(5), State: github.Ptr("closed")}),
+			),
+		),
+	))
+	_, handler := CloseMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":     "owner",
+		"repo":      "repo",
+		"milestone": float64(5),
+	}))
+	require.NoError(t, err)
+
+	var milestone github.Milestone
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestone))
+	assert.Equal(t, "closed", milestone.GetState())
+}
@@ -0,0 +1,233 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/journal"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type journalCtxKey struct{}
+type journalCallerCtxKey struct{}
+type operationCtxKey struct{}
+type resumeOperationCtxKey struct{}
+
+// operationRun ties a journaled operation to the journal it is recorded in.
+type operationRun struct {
+	journal *journal.Journal
+	op      *journal.Operation
+}
+
+// OperationJournalMiddleware makes the journal available to multi-step tools so that
+// they record each completed step, allowing failed operations to be resumed or rolled back.
+// Operations are recorded for the caller identified by caller, and only that caller may
+// resume them. A nil caller records every operation for the same caller.
+func OperationJournalMiddleware(j *journal.Journal, caller func(ctx context.Context) string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx = context.WithValue(ctx, journalCtxKey{}, j)
+			if caller != nil {
+				ctx = context.WithValue(ctx, journalCallerCtxKey{}, caller(ctx))
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+func journalFromContext(ctx context.Context) *journal.Journal {
+	j, _ := ctx.Value(journalCtxKey{}).(*journal.Journal)
+	return j
+}
+
+// journalCallerFromContext returns the caller operations of the request are recorded for.
+func journalCallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(journalCallerCtxKey{}).(string)
+	return caller
+}
+
+// withOperationJournal wraps the handler of a multi-step tool so that its progress is recorded
+// in the journal, if one is configured. Failed calls report the operation ID so that the
+// operation can be passed to resume_operation.
+func withOperationJournal(toolName string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		j := journalFromContext(ctx)
+		if j == nil {
+			return handler(ctx, request)
+		}
+
+		op, resumed := ctx.Value(resumeOperationCtxKey{}).(*journal.Operation)
+		if resumed {
+			if err := j.Restart(op); err != nil {
+				return nil, fmt.Errorf("failed to record operation: %w", err)
+			}
+		} else {
+			var err error
+			op, err = j.Begin(toolName, journalCallerFromContext(ctx), request.GetArguments())
+			if err != nil {
+				return nil, fmt.Errorf("failed to record operation: %w", err)
+			}
+		}
+
+		ctx = context.WithValue(ctx, operationCtxKey{}, &operationRun{journal: j, op: op})
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			reason := "unknown error"
+			if err != nil {
+				reason = err.Error()
+			} else if result != nil && len(result.Content) > 0 {
+				if text, ok := result.Content[0].(mcp.TextContent); ok {
+					reason = text.Text
+				}
+			}
+			if jErr := j.Fail(op, reason); jErr != nil {
+				return nil, errors.Join(err, fmt.Errorf("failed to record operation: %w", jErr))
+			}
+			if result != nil {
+				result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+					"Operation %s failed after completing steps [%s]. Call resume_operation with this operation_id to resume it or roll it back.",
+					op.ID, strings.Join(op.StepNames(), ", "),
+				)))
+			}
+			return result, err
+		}
+
+		if err := j.Complete(op); err != nil {
+			return nil, fmt.Errorf("failed to record operation: %w", err)
+		}
+		setResultMeta(result, "operation_id", op.ID)
+		return result, nil
+	}
+}
+
+// runOperationStep runs a named step of the current journaled operation. If the step already
+// completed in an earlier attempt, its recorded output is returned without running it again.
+// A non-nil result reports a failure that should be returned from the tool handler as is.
+func runOperationStep(ctx context.Context, name string, step func() (map[string]string, *mcp.CallToolResult, error)) (map[string]string, *mcp.CallToolResult, error) {
+	run, ok := ctx.Value(operationCtxKey{}).(*operationRun)
+	if !ok {
+		return step()
+	}
+	if completed, done := run.op.Step(name); done {
+		return completed.Output, nil, nil
+	}
+
+	output, result, err := step()
+	if err != nil || result != nil {
+		return nil, result, err
+	}
+	if err := run.journal.CompleteStep(run.op, name, output); err != nil {
+		return nil, nil, fmt.Errorf("failed to record step %s: %w", name, err)
+	}
+	return output, nil, nil
+}
+
+// resumableOperation describes how to resume and roll back a journaled tool.
+type resumableOperation struct {
+	handler  server.ToolHandlerFunc
+	rollback func(ctx context.Context, client *github.Client, op *journal.Operation) error
+}
+
+// ResumeOperation creates a tool to resume or roll back a failed multi-step operation.
+func ResumeOperation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	_, renderScaffold := RenderScaffold(getClient, t)
+	resumable := map[string]resumableOperation{
+		"render_scaffold": {handler: renderScaffold, rollback: rollbackRenderScaffold},
+	}
+
+	return mcp.NewTool("resume_operation",
+			mcp.WithDescription(t("TOOL_RESUME_OPERATION_DESCRIPTION", "Resume a failed multi-step operation from its last completed step, or roll back the steps the failed operation completed. Operation IDs are reported by tools such as render_scaffold when they fail part way through.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_RESUME_OPERATION_USER_TITLE", "Resume or roll back operation"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("operation_id",
+				mcp.Required(),
+				mcp.Description("ID of the operation to resume or roll back"),
+			),
+			mcp.WithString("action",
+				mcp.Description("Whether to resume the operation or roll back its completed steps"),
+				mcp.Enum("resume", "rollback"),
+				mcp.DefaultString("resume"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			operationID, err := RequiredParam[string](request, "operation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			action, err := OptionalParam[string](request, "action")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if action == "" {
+				action = "resume"
+			}
+
+			j := journalFromContext(ctx)
+			if j == nil {
+				return mcp.NewToolResultError("operation journal is not enabled on this server"), nil
+			}
+
+			op, err := j.Get(operationID, journalCallerFromContext(ctx))
+			if err != nil {
+				if errors.Is(err, journal.ErrOperationNotFound) {
+					return mcp.NewToolResultError(fmt.Sprintf("operation %s not found", operationID)), nil
+				}
+				return nil, fmt.Errorf("failed to load operation: %w", err)
+			}
+
+			target, ok := resumable[op.Tool]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("operations of tool %s cannot be resumed", op.Tool)), nil
+			}
+
+			switch action {
+			case "resume":
+				if op.Status != journal.StatusFailed {
+					return mcp.NewToolResultError(fmt.Sprintf("operation %s is %s; only failed operations can be resumed", op.ID, op.Status)), nil
+				}
+				resumeRequest := mcp.CallToolRequest{}
+				resumeRequest.Params.Name = op.Tool
+				resumeRequest.Params.Arguments = op.Arguments
+				return target.handler(context.WithValue(ctx, resumeOperationCtxKey{}, op), resumeRequest)
+
+			case "rollback":
+				if op.Status != journal.StatusFailed {
+					return mcp.NewToolResultError(fmt.Sprintf("operation %s is %s; only failed operations can be rolled back", op.ID, op.Status)), nil
+				}
+				client, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+				if err := target.rollback(ctx, client, op); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to roll back operation %s: %s", op.ID, err)), nil
+				}
+				if err := j.RolledBack(op); err != nil {
+					return nil, fmt.Errorf("failed to record operation: %w", err)
+				}
+
+				r, err := json.Marshal(op)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unknown action %s", action)), nil
+			}
+		}
+}
+
+// operationArgument returns a string argument recorded with an operation.
+func operationArgument(op *journal.Operation, name string) string {
+	s, _ := op.Arguments[name].(string)
+	return s
+}
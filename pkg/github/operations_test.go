@@ -0,0 +1,303 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/journal"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResumeOperation(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ResumeOperation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "resume_operation", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "operation_id")
+	assert.Contains(t, tool.InputSchema.Properties, "action")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"operation_id"})
+
+	mockTemplateTree := &github.Tree{
+		SHA: github.Ptr("tpl123"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("blob1")},
+		},
+	}
+	templateHandlers := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				mockTemplateTree,
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte("# {{name}}\n"))
+				}),
+			),
+		}
+	}
+	scaffoldArgs := map[string]interface{}{
+		"template_owner": "platform",
+		"template_repo":  "service-template",
+		"template_ref":   "main",
+		"variables":      map[string]interface{}{"name": "billing"},
+		"owner":          "owner",
+		"repo":           "service",
+		"branch":         "main",
+		"message":        "Scaffold billing service",
+	}
+
+	j := journal.New(journal.NewMemoryStore())
+	middleware := OperationJournalMiddleware(j, nil)
+
+	// The first attempt creates the commit but fails to move the branch.
+	failingClient := github.NewClient(mock.NewMockedHTTPClient(
+		append(templateHandlers(),
+			mock.WithRequestMatch(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				&github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("abc123")}},
+			),
+			mock.WithRequestMatch(
+				mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+				&github.Commit{SHA: github.Ptr("abc123"), Tree: &github.Tree{SHA: github.Ptr("def456")}},
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("ghi789")}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitCommitsByOwnerByRepo,
+				mockResponse(t, http.StatusCreated, &github.Commit{SHA: github.Ptr("jkl012")}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposGitRefsByOwnerByRepoByRef,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					_, _ = w.Write([]byte(`{"message": "Update is not a fast forward"}`))
+				}),
+			),
+		)...,
+	))
+	_, scaffoldHandler := RenderScaffold(stubGetClientFn(failingClient), translations.NullTranslationHelper)
+	result, err := middleware(scaffoldHandler)(context.Background(), createMCPRequest(scaffoldArgs))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "failed to update reference")
+
+	note := result.Content[1].(mcp.TextContent).Text
+	matches := regexp.MustCompile(`Operation ([0-9a-f]+) failed after completing steps \[create_commit\]`).FindStringSubmatch(note)
+	require.Len(t, matches, 2, note)
+	operationID := matches[1]
+
+	op, err := j.Get(operationID, "")
+	require.NoError(t, err)
+	assert.Equal(t, journal.StatusFailed, op.Status)
+	assert.Equal(t, "render_scaffold", op.Tool)
+
+	// Resuming skips the commit that was already created and only moves the branch.
+	resumeClient := github.NewClient(mock.NewMockedHTTPClient(
+		append(templateHandlers(),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposGitRefsByOwnerByRepoByRef,
+				expectRequestBody(t, map[string]interface{}{
+					"sha":   "jkl012",
+					"force": false,
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr("refs/heads/main")}),
+				),
+			),
+		)...,
+	))
+	_, resumeHandler := ResumeOperation(stubGetClientFn(resumeClient), translations.NullTranslationHelper)
+	result, err = middleware(resumeHandler)(context.Background(), createMCPRequest(map[string]interface{}{
+		"operation_id": operationID,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var scaffold ScaffoldResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &scaffold))
+	assert.Equal(t, "jkl012", scaffold.CommitSHA)
+	assert.Equal(t, operationID, result.Meta.AdditionalFields["operation_id"])
+
+	op, err = j.Get(operationID, "")
+	require.NoError(t, err)
+	assert.Equal(t, journal.StatusCompleted, op.Status)
+	assert.Equal(t, []string{"create_commit", "update_ref"}, op.StepNames())
+
+	// Completed operations cannot be resumed again, nor rolled back.
+	result, err = middleware(resumeHandler)(context.Background(), createMCPRequest(map[string]interface{}{
+		"operation_id": operationID,
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "only failed operations can be resumed")
+
+	result, err = middleware(resumeHandler)(context.Background(), createMCPRequest(map[string]interface{}{
+		"operation_id": operationID,
+		"action":       "rollback",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "only failed operations can be rolled back")
+
+	// Rolling back a failed operation that only created unreferenced objects changes nothing.
+	failed, err := j.Begin("render_scaffold", "", scaffoldArgs)
+	require.NoError(t, err)
+	require.NoError(t, j.CompleteStep(failed, "create_commit", map[string]string{"base_sha": "abc123", "commit_sha": "jkl012"}))
+	require.NoError(t, j.Fail(failed, "boom"))
+
+	_, rollbackHandler := ResumeOperation(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient())), translations.NullTranslationHelper)
+	result, err = middleware(rollbackHandler)(context.Background(), createMCPRequest(map[string]interface{}{
+		"operation_id": failed.ID,
+		"action":       "rollback",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	op, err = j.Get(failed.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, journal.StatusRolledBack, op.Status)
+}
+
+func Test_ResumeOperation_Errors(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient())
+	_, handler := ResumeOperation(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	// Without a journal there is nothing to resume.
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"operation_id": "abc",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "operation journal is not enabled")
+
+	j := journal.New(journal.NewMemoryStore())
+	handler = OperationJournalMiddleware(j, nil)(handler)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"operation_id": "abc",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "operation abc not found")
+
+	op, err := j.Begin("create_issue", "", map[string]any{})
+	require.NoError(t, err)
+	require.NoError(t, j.Fail(op, "boom"))
+	result, err = handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"operation_id": op.ID,
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "operations of tool create_issue cannot be resumed")
+
+	// Operations of other callers are not found
+	op, err = j.Begin("render_scaffold", "someone-else", map[string]any{})
+	require.NoError(t, err)
+	require.NoError(t, j.Fail(op, "boom"))
+	result, err = handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"operation_id": op.ID,
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "operation "+op.ID+" not found")
+}
+
+func Test_RollbackRenderScaffold_ResetsBranch(t *testing.T) {
+	op := &journal.Operation{
+		Tool:      "render_scaffold",
+		Arguments: map[string]any{"owner": "owner", "repo": "service"},
+		Steps: []journal.Step{
+			{Name: "create_commit", Output: map[string]string{"base_sha": "abc123", "commit_sha": "jkl012"}},
+			{Name: "update_ref", Output: map[string]string{"branch": "main", "previous_sha": "abc123"}},
+		},
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposGitRefByOwnerByRepoByRef,
+			&github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("jkl012")}},
+		),
+		mock.WithRequestMatchHandler(
+			mock.PatchReposGitRefsByOwnerByRepoByRef,
+			expectRequestBody(t, map[string]interface{}{
+				"sha":   "abc123",
+				"force": true,
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr("refs/heads/main")}),
+			),
+		),
+	))
+	require.NoError(t, rollbackRenderScaffold(context.Background(), client, op))
+
+	// A branch that has moved on is left alone
+	client = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposGitRefByOwnerByRepoByRef,
+			&github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("mno345")}},
+		),
+	))
+	assert.EqualError(t, rollbackRenderScaffold(context.Background(), client, op), "branch main has moved since the operation completed")
+}
+
+func Test_RollbackRenderScaffold_DeletesCreatedRepository(t *testing.T) {
+	deleteRepository := mock.WithRequestMatchHandler(
+		mock.DeleteReposByOwnerByRepo,
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	)
+	headAt := func(sha string) mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetReposGitRefByOwnerByRepoByRef,
+			&github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr(sha)}},
+		)
+	}
+
+	op := &journal.Operation{
+		Tool:      "render_scaffold",
+		Arguments: map[string]any{"owner": "owner", "repo": "service"},
+		Steps: []journal.Step{
+			{Name: "create_repository", Output: map[string]string{"default_branch": "main"}},
+		},
+	}
+
+	// Only the initial commit of the repository exists
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		headAt("init00"),
+		mock.WithRequestMatch(
+			mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+			&github.Commit{SHA: github.Ptr("init00")},
+		),
+		deleteRepository,
+	))
+	require.NoError(t, rollbackRenderScaffold(context.Background(), client, op))
+
+	// Something was pushed on top of the initial commit
+	client = github.NewClient(mock.NewMockedHTTPClient(
+		headAt("pqr678"),
+		mock.WithRequestMatch(
+			mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+			&github.Commit{SHA: github.Ptr("pqr678"), Parents: []*github.Commit{{SHA: github.Ptr("init00")}}},
+		),
+	))
+	assert.EqualError(t, rollbackRenderScaffold(context.Background(), client, op), "repository owner/service has changed since the operation created it")
+
+	// Once the scaffold was committed, the branch must still point at the commit it was based on
+	op.Steps = append(op.Steps, journal.Step{Name: "create_commit", Output: map[string]string{"base_sha": "init00", "commit_sha": "jkl012"}})
+	client = github.NewClient(mock.NewMockedHTTPClient(headAt("init00"), deleteRepository))
+	require.NoError(t, rollbackRenderScaffold(context.Background(), client, op))
+
+	client = github.NewClient(mock.NewMockedHTTPClient(headAt("pqr678")))
+	assert.EqualError(t, rollbackRenderScaffold(context.Background(), client, op), "repository owner/service has changed since the operation created it")
+}
@@ -0,0 +1,917 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxOrgInventoryRepos bounds the number of organization repositories scanned in a single call.
+const maxOrgInventoryRepos = 2000
+
+// OrgRepository is the inventory entry returned by list_org_repositories.
+type OrgRepository struct {
+	FullName         string            `json:"full_name"`
+	Visibility       string            `json:"visibility"`
+	Language         string            `json:"language,omitempty"`
+	Topics           []string          `json:"topics,omitempty"`
+	Archived         bool              `json:"archived"`
+	PushedAt         string            `json:"pushed_at,omitempty"`
+	HTMLURL          string            `json:"html_url"`
+	CustomProperties map[string]string `json:"custom_properties,omitempty"`
+}
+
+// OrgRepositoryInventory is the JSON output of list_org_repositories.
+type OrgRepositoryInventory struct {
+	Scanned      int             `json:"scanned"`
+	Matched      int             `json:"matched"`
+	Truncated    bool            `json:"truncated,omitempty"`
+	Repositories []OrgRepository `json:"repositories"`
+}
+
+// orgRepositoryFilter holds the filters of list_org_repositories. Zero values match everything.
+type orgRepositoryFilter struct {
+	language         string
+	topic            string
+	archived         *bool
+	visibility       string
+	pushedAfter      time.Time
+	pushedBefore     time.Time
+	customProperties map[string]string
+}
+
+func (f orgRepositoryFilter) matches(repo *github.Repository) bool {
+	if f.language != "" && !strings.EqualFold(repo.GetLanguage(), f.language) {
+		return false
+	}
+	if f.topic != "" {
+		found := false
+		for _, topic := range repo.Topics {
+			if strings.EqualFold(topic, f.topic) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.archived != nil && repo.GetArchived() != *f.archived {
+		return false
+	}
+	if f.visibility != "" && f.visibility != "all" && !strings.EqualFold(repo.GetVisibility(), f.visibility) {
+		return false
+	}
+	pushedAt := repo.GetPushedAt().Time
+	if !f.pushedAfter.IsZero() && pushedAt.Before(f.pushedAfter) {
+		return false
+	}
+	if !f.pushedBefore.IsZero() && !pushedAt.Before(f.pushedBefore) {
+		return false
+	}
+	for name, want := range f.customProperties {
+		if !strings.EqualFold(customPropertyString(repo.CustomProperties[name]), want) {
+			return false
+		}
+	}
+	return true
+}
+
+// customPropertyString renders a custom property value, which is either a string or,
+// for multi-select properties, a list of strings.
+func customPropertyString(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []any:
+		parts := make([]string, 0, len(value))
+		for _, p := range value {
+			parts = append(parts, fmt.Sprint(p))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func toOrgRepository(repo *github.Repository) OrgRepository {
+	r := OrgRepository{
+		FullName:   repo.GetFullName(),
+		Visibility: repo.GetVisibility(),
+		Language:   repo.GetLanguage(),
+		Topics:     repo.Topics,
+		Archived:   repo.GetArchived(),
+		HTMLURL:    repo.GetHTMLURL(),
+	}
+	if repo.PushedAt != nil {
+		r.PushedAt = repo.GetPushedAt().UTC().Format(time.RFC3339)
+	}
+	if len(repo.CustomProperties) > 0 {
+		r.CustomProperties = make(map[string]string, len(repo.CustomProperties))
+		for name, value := range repo.CustomProperties {
+			r.CustomProperties[name] = customPropertyString(value)
+		}
+	}
+	return r
+}
+
+// orgRepositoriesCSV renders the inventory as CSV, with one column per custom property present.
+func orgRepositoriesCSV(repos []OrgRepository) (string, error) {
+	propertySet := map[string]struct{}{}
+	for _, r := range repos {
+		for name := range r.CustomProperties {
+			propertySet[name] = struct{}{}
+		}
+	}
+	properties := make([]string, 0, len(propertySet))
+	for name := range propertySet {
+		properties = append(properties, name)
+	}
+	sort.Strings(properties)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := []string{"full_name", "visibility", "language", "topics", "archived", "pushed_at", "html_url"}
+	for _, name := range properties {
+		header = append(header, "property:"+name)
+	}
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+	for _, r := range repos {
+		record := []string{
+			r.FullName,
+			r.Visibility,
+			r.Language,
+			strings.Join(r.Topics, ";"),
+			strconv.FormatBool(r.Archived),
+			r.PushedAt,
+			r.HTMLURL,
+		}
+		for _, name := range properties {
+			record = append(record, r.CustomProperties[name])
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// ListOrgRepositories creates a tool to build a filtered inventory of an organization's repositories.
+func ListOrgRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_repositories",
+			mcp.WithDescription(t("TOOL_LIST_ORG_REPOSITORIES_DESCRIPTION", "List the repositories of an organization, filtered by language, topic, archived state, visibility, time since last push and custom property values. Use this to answer inventory questions such as which repositories still use a given language or have not been pushed to in a year. Results can be returned as JSON or CSV.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_REPOSITORIES_USER_TITLE", "List organization repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("language",
+				mcp.Description("Only include repositories whose primary language matches, e.g. 'Go'"),
+			),
+			mcp.WithString("topic",
+				mcp.Description("Only include repositories tagged with this topic"),
+			),
+			mcp.WithBoolean("archived",
+				mcp.Description("If set, only include archived (true) or non-archived (false) repositories"),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Only include repositories with this visibility"),
+				mcp.Enum("all", "public", "private", "internal"),
+			),
+			mcp.WithNumber("pushed_within_days",
+				mcp.Description("Only include repositories pushed to within this many days"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("not_pushed_within_days",
+				mcp.Description("Only include repositories that have not been pushed to for at least this many days"),
+				mcp.Min(1),
+			),
+			mcp.WithObject("custom_properties",
+				mcp.Description("Only include repositories whose custom properties have these values, e.g. {\"team\": \"payments\"}"),
+				mcp.AdditionalProperties(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("format",
+				mcp.Description("Output format (default: json)"),
+				mcp.Enum("json", "csv"),
+			),
+			mcp.WithNumber("max_results",
+				mcp.Description("Maximum number of matching repositories to return (default: 500)"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var filter orgRepositoryFilter
+			if filter.language, err = OptionalParam[string](request, "language"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if filter.topic, err = OptionalParam[string](request, "topic"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			archived, archivedSet, err := OptionalParamOK[bool](request, "archived")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if archivedSet {
+				filter.archived = &archived
+			}
+			if filter.visibility, err = OptionalParam[string](request, "visibility"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pushedWithin, err := OptionalIntParam(request, "pushed_within_days")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			notPushedWithin, err := OptionalIntParam(request, "not_pushed_within_days")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			now := time.Now()
+			if pushedWithin > 0 {
+				filter.pushedAfter = now.AddDate(0, 0, -pushedWithin)
+			}
+			if notPushedWithin > 0 {
+				filter.pushedBefore = now.AddDate(0, 0, -notPushedWithin)
+			}
+			if propsObj, ok := request.GetArguments()["custom_properties"].(map[string]any); ok {
+				filter.customProperties = make(map[string]string, len(propsObj))
+				for name, v := range propsObj {
+					s, ok := v.(string)
+					if !ok {
+						return mcp.NewToolResultError(fmt.Sprintf("custom property %s must be a string, is %T", name, v)), nil
+					}
+					filter.customProperties[name] = s
+				}
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxResults, err := OptionalIntParamWithDefault(request, "max_results", 500)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Narrow the listing server-side where the API allows it, and sort by push time so
+			// that the scan can stop early once repositories fall outside pushed_within_days.
+			opts := &github.RepositoryListByOrgOptions{
+				Sort:      "pushed",
+				Direction: "desc",
+				ListOptions: github.ListOptions{
+					PerPage: 100,
+				},
+			}
+			if filter.visibility == "public" || filter.visibility == "private" {
+				opts.Type = filter.visibility
+			}
+
+			inventory := OrgRepositoryInventory{Repositories: []OrgRepository{}}
+		scan:
+			for {
+				repos, resp, err := client.Repositories.ListByOrg(ctx, org, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list repositories for organization %s", org),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, repo := range repos {
+					if !filter.pushedAfter.IsZero() && repo.GetPushedAt().Before(filter.pushedAfter) {
+						break scan
+					}
+					inventory.Scanned++
+					if !filter.matches(repo) {
+						continue
+					}
+					inventory.Matched++
+					if len(inventory.Repositories) < maxResults {
+						inventory.Repositories = append(inventory.Repositories, toOrgRepository(repo))
+					} else {
+						inventory.Truncated = true
+					}
+				}
+
+				if resp.NextPage == 0 {
+					break
+				}
+				if inventory.Scanned >= maxOrgInventoryRepos {
+					inventory.Truncated = true
+					break
+				}
+				opts.ListOptions.Page = resp.NextPage
+			}
+
+			if format == "csv" {
+				out, err := orgRepositoriesCSV(inventory.Repositories)
+				if err != nil {
+					return nil, fmt.Errorf("failed to write CSV: %w", err)
+				}
+				// The CSV only holds the listed repositories, so the counts of the scan go alongside it.
+				result := mcp.NewToolResultText(out)
+				setResultMeta(result, "inventory", map[string]any{
+					"scanned":   inventory.Scanned,
+					"matched":   inventory.Matched,
+					"truncated": inventory.Truncated,
+				})
+				if inventory.Truncated {
+					result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+						"The inventory is truncated: %d repositories were scanned and %d matched, of which %d are listed. Narrow the filters or raise max_results to see more.",
+						inventory.Scanned, inventory.Matched, len(inventory.Repositories),
+					)))
+				}
+				return result, nil
+			}
+
+			r, err := json.Marshal(inventory)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// toMinimalUsers drops everything but the identity of users, which is all member listings need.
+func toMinimalUsers(users []*github.User) []MinimalUser {
+	minimalUsers := make([]MinimalUser, 0, len(users))
+	for _, user := range users {
+		minimalUsers = append(minimalUsers, MinimalUser{
+			Login:      user.GetLogin(),
+			ID:         user.GetID(),
+			ProfileURL: user.GetHTMLURL(),
+			AvatarURL:  user.GetAvatarURL(),
+		})
+	}
+	return minimalUsers
+}
+
+func GetOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org",
+			mcp.WithDescription(t("TOOL_GET_ORG_DESCRIPTION", "Get details of a GitHub organization, such as its description, plan and member settings.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_USER_TITLE", "Get organization"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			organization, resp, err := client.Organizations.Get(ctx, org)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(organization)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal organization: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func ListOrgMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_members",
+			mcp.WithDescription(t("TOOL_LIST_ORG_MEMBERS_DESCRIPTION", "List the members of a GitHub organization. Only public members are listed unless the authenticated user is a member of the organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_MEMBERS_USER_TITLE", "List organization members"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Only list members with this role. Defaults to all"),
+				mcp.Enum("all", "admin", "member"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("Only list members without two-factor authentication (2fa_disabled). Only available to organization owners"),
+				mcp.Enum("all", "2fa_disabled"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			members, resp, err := client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{
+				Role:   role,
+				Filter: filter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list members of organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(toMinimalUsers(members))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal members: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+func ListTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_teams",
+			mcp.WithDescription(t("TOOL_LIST_TEAMS_DESCRIPTION", "List the teams of a GitHub organization that are visible to the authenticated user.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAMS_USER_TITLE", "List organization teams"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			teams, resp, err := client.Teams.ListTeams(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list teams of organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(teams)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal teams: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+func GetTeam(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_team",
+			mcp.WithDescription(t("TOOL_GET_TEAM_DESCRIPTION", "Get details of a team in a GitHub organization, such as its description, privacy, parent team and member and repository counts.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_TEAM_USER_TITLE", "Get team"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			team, resp, err := client.Teams.GetTeamBySlug(ctx, org, teamSlug)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get team %s/%s", org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(team)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal team: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func ListTeamMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_members",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_MEMBERS_DESCRIPTION", "List the members of a team in a GitHub organization, including the members of its child teams.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_MEMBERS_USER_TITLE", "List team members"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Only list members with this role in the team. Defaults to all"),
+				mcp.Enum("all", "member", "maintainer"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			members, resp, err := client.Teams.ListTeamMembersBySlug(ctx, org, teamSlug, &github.TeamListTeamMembersOptions{
+				Role: role,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list members of team %s/%s", org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(toMinimalUsers(members))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal members: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+func AddTeamMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_team_member",
+			mcp.WithDescription(t("TOOL_ADD_TEAM_MEMBER_DESCRIPTION", "Add a user to a team in a GitHub organization, or change their role in the team. Users who are not members of the organization are invited to it and join the team once they accept.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_TEAM_MEMBER_USER_TITLE", "Add team member"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user to add"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role of the user in the team. Defaults to member"),
+				mcp.Enum("member", "maintainer"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			membership, resp, err := client.Teams.AddTeamMembershipBySlug(ctx, org, teamSlug, username, &github.TeamAddTeamMembershipOptions{
+				Role: role,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to add %s to team %s/%s", username, org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(membership)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal membership: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func ListTeamRepos(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_repos",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_REPOS_DESCRIPTION", "List the repositories a team in a GitHub organization has access to, with the team's permissions on each.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_REPOS_USER_TITLE", "List team repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Teams.ListTeamReposBySlug(ctx, org, teamSlug, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories of team %s/%s", org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(repos)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal repositories: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// auditLogPhrase builds the search phrase of an audit log query from its filters.
+func auditLogPhrase(phrase, actor, action, repo, since, until string) string {
+	var terms []string
+	if phrase != "" {
+		terms = append(terms, phrase)
+	}
+	if actor != "" {
+		terms = append(terms, "actor:"+actor)
+	}
+	if action != "" {
+		terms = append(terms, "action:"+action)
+	}
+	if repo != "" {
+		terms = append(terms, "repo:"+repo)
+	}
+	switch {
+	case since != "" && until != "":
+		terms = append(terms, fmt.Sprintf("created:%s..%s", since, until))
+	case since != "":
+		terms = append(terms, "created:>="+since)
+	case until != "":
+		terms = append(terms, "created:<="+until)
+	}
+	return strings.Join(terms, " ")
+}
+
+// GetOrgAuditLog creates a tool to search the audit log of an organization or enterprise.
+func GetOrgAuditLog(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_audit_log",
+			mcp.WithDescription(t("TOOL_GET_ORG_AUDIT_LOG_DESCRIPTION", "Search the audit log of an organization, or of an enterprise, for events such as membership, permission, repository and settings changes, newest first. Only available to owners of organizations on GitHub Enterprise Cloud and to enterprise administrators. Events are kept for 180 days, Git events for 7 days")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_AUDIT_LOG_USER_TITLE", "Get organization audit log"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Description("Organization login. Either org or enterprise is required"),
+			),
+			mcp.WithString("enterprise",
+				mcp.Description("Enterprise slug, to search the audit log of the whole enterprise instead of one organization"),
+			),
+			mcp.WithString("phrase",
+				mcp.Description("Audit log search phrase, e.g. 'operation:remove country:US'. The other filters are added to it"),
+			),
+			mcp.WithString("actor",
+				mcp.Description("Only return events of this user"),
+			),
+			mcp.WithString("action",
+				mcp.Description("Only return events with this action, e.g. 'repo.destroy', or a category of actions, e.g. 'team'"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Only return events of this repository, as 'owner/repo'"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only return events from this date on, as YYYY-MM-DD or an ISO 8601 timestamp"),
+			),
+			mcp.WithString("until",
+				mcp.Description("Only return events up to this date, as YYYY-MM-DD or an ISO 8601 timestamp"),
+			),
+			mcp.WithString("include",
+				mcp.Description("Whether to return web events, Git events or both. Defaults to web"),
+				mcp.Enum("web", "git", "all"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Order of the events by time. Defaults to desc"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := OptionalParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enterprise, err := OptionalParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (org == "") == (enterprise == "") {
+				return mcp.NewToolResultError("exactly one of org and enterprise is required"), nil
+			}
+			filters := make(map[string]string)
+			for _, name := range []string{"phrase", "actor", "action", "repo", "since", "until", "include", "order"} {
+				value, err := OptionalParam[string](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				filters[name] = value
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.GetAuditLogOptions{
+				Phrase:  ToStringPtr(auditLogPhrase(filters["phrase"], filters["actor"], filters["action"], filters["repo"], filters["since"], filters["until"])),
+				Include: ToStringPtr(filters["include"]),
+				Order:   ToStringPtr(filters["order"]),
+				ListCursorOptions: github.ListCursorOptions{
+					PerPage: pagination.PerPage,
+					After:   pagination.After,
+				},
+			}
+			var entries []*github.AuditEntry
+			var resp *github.Response
+			if enterprise != "" {
+				entries, resp, err = client.Enterprise.GetAuditLog(ctx, enterprise, opts)
+			} else {
+				entries, resp, err = client.Organizations.GetAuditLog(ctx, org, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get audit log", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(entries)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal audit log: %w", err)
+			}
+
+			// The audit log is paged with cursors in its Link header
+			return withPageInfo(mcp.NewToolResultText(string(r)), PageInfo{
+				HasNextPage: resp.After != "",
+				NextCursor:  resp.After,
+			}), nil
+		}
+}
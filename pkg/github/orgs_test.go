@@ -0,0 +1,712 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "language")
+	assert.Contains(t, tool.InputSchema.Properties, "topic")
+	assert.Contains(t, tool.InputSchema.Properties, "archived")
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.Contains(t, tool.InputSchema.Properties, "pushed_within_days")
+	assert.Contains(t, tool.InputSchema.Properties, "not_pushed_within_days")
+	assert.Contains(t, tool.InputSchema.Properties, "custom_properties")
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	daysAgo := func(d int) *github.Timestamp {
+		return &github.Timestamp{Time: time.Now().AddDate(0, 0, -d)}
+	}
+	mockRepos := []*github.Repository{
+		{
+			FullName:         github.Ptr("acme/api"),
+			Visibility:       github.Ptr("internal"),
+			Language:         github.Ptr("Go"),
+			Topics:           []string{"backend"},
+			PushedAt:         daysAgo(1),
+			HTMLURL:          github.Ptr("https://github.com/acme/api"),
+			CustomProperties: map[string]any{"team": "payments"},
+		},
+		{
+			FullName:         github.Ptr("acme/web"),
+			Visibility:       github.Ptr("public"),
+			Language:         github.Ptr("TypeScript"),
+			Topics:           []string{"frontend"},
+			PushedAt:         daysAgo(10),
+			HTMLURL:          github.Ptr("https://github.com/acme/web"),
+			CustomProperties: map[string]any{"team": "growth"},
+		},
+		{
+			FullName:         github.Ptr("acme/legacy"),
+			Visibility:       github.Ptr("private"),
+			Language:         github.Ptr("Go"),
+			Topics:           []string{"backend"},
+			Archived:         github.Ptr(true),
+			PushedAt:         daysAgo(400),
+			HTMLURL:          github.Ptr("https://github.com/acme/legacy"),
+			CustomProperties: map[string]any{"team": "payments", "tier": []any{"gold", "eu"}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRepos  []string
+		expectedCSV    string
+		expectedNote   string
+	}{
+		{
+			name: "filters by language and custom property",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					expectQueryParams(t, map[string]string{
+						"sort":      "pushed",
+						"direction": "desc",
+						"per_page":  "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepos),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":               "acme",
+				"language":          "go",
+				"custom_properties": map[string]interface{}{"team": "payments"},
+			},
+			expectedRepos: []string{"acme/api", "acme/legacy"},
+		},
+		{
+			name: "filters stale non-archived repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, mockRepos),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                    "acme",
+				"not_pushed_within_days": float64(5),
+				"archived":               false,
+			},
+			expectedRepos: []string{"acme/web"},
+		},
+		{
+			name: "stops scanning past pushed_within_days",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, mockRepos),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                "acme",
+				"pushed_within_days": float64(5),
+				"visibility":         "internal",
+			},
+			expectedRepos: []string{"acme/api"},
+		},
+		{
+			name: "CSV output",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					expectQueryParams(t, map[string]string{
+						"type":      "private",
+						"sort":      "pushed",
+						"direction": "desc",
+						"per_page":  "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepos[2:]),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "acme",
+				"visibility": "private",
+				"format":     "csv",
+			},
+			expectedCSV: "full_name,visibility,language,topics,archived,pushed_at,html_url,property:team,property:tier\n" +
+				"acme/legacy,private,Go,backend,true," + mockRepos[2].GetPushedAt().UTC().Format(time.RFC3339) + ",https://github.com/acme/legacy,payments,\"gold,eu\"\n",
+		},
+		{
+			name: "truncated CSV output",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, mockRepos),
+			),
+			requestArgs: map[string]interface{}{
+				"org":         "acme",
+				"format":      "csv",
+				"max_results": float64(1),
+			},
+			expectedCSV: "full_name,visibility,language,topics,archived,pushed_at,html_url,property:team\n" +
+				"acme/api,internal,Go,backend,false," + mockRepos[0].GetPushedAt().UTC().Format(time.RFC3339) + ",https://github.com/acme/api,payments\n",
+			expectedNote: "The inventory is truncated: 3 repositories were scanned and 3 matched, of which 1 are listed. Narrow the filters or raise max_results to see more.",
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories for organization missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			if tc.expectedNote != "" {
+				require.Len(t, result.Content, 2)
+				assert.Equal(t, tc.expectedCSV, result.Content[0].(mcp.TextContent).Text)
+				assert.Equal(t, tc.expectedNote, result.Content[1].(mcp.TextContent).Text)
+				assert.Equal(t, map[string]any{"scanned": 3, "matched": 3, "truncated": true}, result.Meta.AdditionalFields["inventory"])
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			if tc.expectedCSV != "" {
+				assert.Equal(t, tc.expectedCSV, textContent.Text)
+				return
+			}
+
+			var inventory OrgRepositoryInventory
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &inventory))
+			names := make([]string, 0, len(inventory.Repositories))
+			for _, r := range inventory.Repositories {
+				names = append(names, r.FullName)
+			}
+			assert.Equal(t, tc.expectedRepos, names)
+			assert.Equal(t, len(tc.expectedRepos), inventory.Matched)
+		})
+	}
+}
+
+func Test_GetOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful get",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsByOrg,
+					github.Organization{Login: github.Ptr("acme"), Name: github.Ptr("Acme Inc.")},
+				),
+			),
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get organization acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"org": "acme"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var org github.Organization
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &org))
+			assert.Equal(t, "Acme Inc.", org.GetName())
+		})
+	}
+}
+
+func Test_ListOrgMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_members", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsMembersByOrg,
+			expectQueryParams(t, map[string]string{
+				"role":     "admin",
+				"filter":   "2fa_disabled",
+				"page":     "2",
+				"per_page": "10",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{
+					{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1)), HTMLURL: github.Ptr("https://github.com/octocat")},
+				}),
+			),
+		),
+	))
+	_, handler := ListOrgMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":     "acme",
+		"role":    "admin",
+		"filter":  "2fa_disabled",
+		"page":    float64(2),
+		"perPage": float64(10),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var members []MinimalUser
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &members))
+	assert.Equal(t, []MinimalUser{{Login: "octocat", ID: 1, ProfileURL: "https://github.com/octocat"}}, members)
+}
+
+func Test_ListTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_teams", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsTeamsByOrg,
+					[]*github.Team{
+						{Slug: github.Ptr("platform"), Name: github.Ptr("Platform")},
+						{Slug: github.Ptr("security"), Name: github.Ptr("Security")},
+					},
+				),
+			),
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list teams of organization acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"org": "acme"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var teams []*github.Team
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &teams))
+			require.Len(t, teams, 2)
+			assert.Equal(t, "platform", teams[0].GetSlug())
+			assert.Equal(t, "security", teams[1].GetSlug())
+		})
+	}
+}
+
+func Test_GetTeam(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetTeam(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_team", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetOrgsTeamsByOrgByTeamSlug,
+			github.Team{Slug: github.Ptr("platform"), MembersCount: github.Ptr(12)},
+		),
+	))
+	_, handler := GetTeam(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "acme",
+		"team_slug": "platform",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var team github.Team
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &team))
+	assert.Equal(t, "platform", team.GetSlug())
+	assert.Equal(t, 12, team.GetMembersCount())
+}
+
+func Test_ListTeamMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_team_members", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+			expectQueryParams(t, map[string]string{
+				"role":     "maintainer",
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{{Login: github.Ptr("hubot")}}),
+			),
+		),
+	))
+	_, handler := ListTeamMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "acme",
+		"team_slug": "platform",
+		"role":      "maintainer",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var members []MinimalUser
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &members))
+	assert.Equal(t, []MinimalUser{{Login: "hubot"}}, members)
+}
+
+func Test_AddTeamMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddTeamMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_team_member", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedState  string
+		expectedErrMsg string
+	}{
+		{
+			name: "successful add as maintainer",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					expectRequestBody(t, map[string]interface{}{
+						"role": "maintainer",
+					}).andThen(
+						mockResponse(t, http.StatusOK, github.Membership{State: github.Ptr("active"), Role: github.Ptr("maintainer")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "acme",
+				"team_slug": "platform",
+				"username":  "octocat",
+				"role":      "maintainer",
+			},
+			expectedState: "active",
+		},
+		{
+			name: "user is invited",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					expectRequestBody(t, map[string]interface{}{}).andThen(
+						mockResponse(t, http.StatusOK, github.Membership{State: github.Ptr("pending"), Role: github.Ptr("member")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "acme",
+				"team_slug": "platform",
+				"username":  "outsider",
+			},
+			expectedState: "pending",
+		},
+		{
+			name: "add fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "acme",
+				"team_slug": "platform",
+				"username":  "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add octocat to team acme/platform",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddTeamMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var membership github.Membership
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &membership))
+			assert.Equal(t, tc.expectedState, membership.GetState())
+		})
+	}
+}
+
+func Test_ListTeamRepos(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamRepos(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_team_repos", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetOrgsTeamsReposByOrgByTeamSlug,
+			[]*github.Repository{
+				{FullName: github.Ptr("acme/api"), Permissions: map[string]bool{"push": true}},
+			},
+		),
+	))
+	_, handler := ListTeamRepos(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "acme",
+		"team_slug": "platform",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var repos []*github.Repository
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &repos))
+	require.Len(t, repos, 1)
+	assert.Equal(t, "acme/api", repos[0].GetFullName())
+	assert.True(t, repos[0].Permissions["push"])
+}
+
+func Test_GetOrgAuditLog(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgAuditLog(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_audit_log", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "actor")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	entries := []*github.AuditEntry{{
+		Action:     github.Ptr("repo.destroy"),
+		Actor:      github.Ptr("octocat"),
+		DocumentID: github.Ptr("doc-1"),
+	}}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedPageInfo PageInfo
+	}{
+		{
+			name: "organization events with filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsAuditLogByOrg,
+					expectQueryParams(t, map[string]string{
+						"phrase":   "operation:remove actor:octocat action:repo.destroy created:2025-01-01..2025-01-31",
+						"include":  "all",
+						"per_page": "50",
+						"after":    "cursor-1",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/orgs/acme/audit-log?after=cursor-2&before=>; rel="next"`)
+							w.WriteHeader(http.StatusOK)
+							_ = json.NewEncoder(w).Encode(entries)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":     "acme",
+				"phrase":  "operation:remove",
+				"actor":   "octocat",
+				"action":  "repo.destroy",
+				"since":   "2025-01-01",
+				"until":   "2025-01-31",
+				"include": "all",
+				"perPage": float64(50),
+				"after":   "cursor-1",
+			},
+			expectedPageInfo: PageInfo{HasNextPage: true, NextCursor: "cursor-2"},
+		},
+		{
+			name: "enterprise events",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetEnterprisesAuditLogByEnterprise,
+					expectQueryParams(t, map[string]string{
+						"phrase":   "created:>=2025-01-01",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, entries),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"enterprise": "acme-corp",
+				"since":      "2025-01-01",
+			},
+		},
+		{
+			name:           "neither org nor enterprise",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"actor": "octocat"},
+			expectError:    true,
+			expectedErrMsg: "exactly one of org and enterprise is required",
+		},
+		{
+			name: "not an enterprise organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsAuditLogByOrg,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    map[string]any{"org": "free-org"},
+			expectError:    true,
+			expectedErrMsg: "failed to get audit log",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrgAuditLog(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []*github.AuditEntry
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, "repo.destroy", returned[0].GetAction())
+			assert.Equal(t, tc.expectedPageInfo, result.Meta.AdditionalFields["pageInfo"])
+		})
+	}
+}
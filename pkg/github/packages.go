@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// packageTypes are the ecosystems of GitHub Packages.
+var packageTypes = []string{"container", "npm", "maven", "rubygems", "nuget", "docker"}
+
+// withPackageScope adds the org parameter of the package tools, which act on the packages of an
+// organization, or of the authenticated user when org is omitted.
+func withPackageScope(action string) mcp.ToolOption {
+	return mcp.WithString("org",
+		mcp.Description(fmt.Sprintf("Organization name. Omit to %s packages of the authenticated user.", action)),
+	)
+}
+
+// withPackage adds the parameters identifying a package.
+func withPackage() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("package_type",
+			mcp.Required(),
+			mcp.Description("Ecosystem of the package"),
+			mcp.Enum(packageTypes...),
+		)(tool)
+		mcp.WithString("package_name",
+			mcp.Required(),
+			mcp.Description("Name of the package. Container images in a namespace are named with a slash, e.g. 'repo/image'"),
+		)(tool)
+	}
+}
+
+// packageParams returns the parameters added by withPackageScope and withPackage.
+func packageParams(request mcp.CallToolRequest) (org, packageType, packageName string, err error) {
+	org, err = OptionalParam[string](request, "org")
+	if err != nil {
+		return "", "", "", err
+	}
+	packageType, err = RequiredParam[string](request, "package_type")
+	if err != nil {
+		return "", "", "", err
+	}
+	packageName, err = RequiredParam[string](request, "package_name")
+	if err != nil {
+		return "", "", "", err
+	}
+	return org, packageType, packageName, nil
+}
+
+// ListPackages creates a tool to list the packages of an organization or the authenticated user.
+func ListPackages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_packages",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGES_DESCRIPTION", "List the packages of one ecosystem published to GitHub Packages by an organization, or by the authenticated user when org is omitted, with their visibility, version count and repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PACKAGES_USER_TITLE", "List packages"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withPackageScope("list"),
+			mcp.WithString("package_type",
+				mcp.Required(),
+				mcp.Description("Ecosystem of the packages"),
+				mcp.Enum(packageTypes...),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Only list packages with this visibility"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := OptionalParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := RequiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := OptionalParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.PackageListOptions{
+				PackageType: github.Ptr(packageType),
+				Visibility:  ToStringPtr(visibility),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			var packages []*github.Package
+			var resp *github.Response
+			if org == "" {
+				packages, resp, err = client.Users.ListPackages(ctx, "", opts)
+			} else {
+				packages, resp, err = client.Organizations.ListPackages(ctx, org, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list packages", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withPageInfo(MarshalledTextResult(packages), restPageInfo(resp, nil)), nil
+		}
+}
+
+// ListPackageVersions creates a tool to list the versions of a package.
+func ListPackageVersions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_package_versions",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGE_VERSIONS_DESCRIPTION", "List the versions of a package of an organization, or of the authenticated user when org is omitted, newest first, with when they were created and, for container images, their tags. Untagged container versions are usually safe to clean up")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PACKAGE_VERSIONS_USER_TITLE", "List package versions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withPackageScope("list versions of"),
+			withPackage(),
+			mcp.WithString("state",
+				mcp.Description("List active versions, or deleted versions that can still be restored. Defaults to active"),
+				mcp.Enum("active", "deleted"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, packageType, packageName, err := packageParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.PackageListOptions{
+				State: ToStringPtr(state),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			var versions []*github.PackageVersion
+			var resp *github.Response
+			if org == "" {
+				// Unlike the organization methods, the user methods do not escape the package name
+				versions, resp, err = client.Users.PackageGetAllVersions(ctx, "", packageType, url.PathEscape(packageName), opts)
+			} else {
+				versions, resp, err = client.Organizations.PackageGetAllVersions(ctx, org, packageType, packageName, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list package versions", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withPageInfo(MarshalledTextResult(versions), restPageInfo(resp, nil)), nil
+		}
+}
+
+// DeletePackageVersion creates a tool to delete a version of a package.
+func DeletePackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_package_version",
+			mcp.WithDescription(t("TOOL_DELETE_PACKAGE_VERSION_DESCRIPTION", "Delete a version of a package of an organization, or of the authenticated user when org is omitted. Deleted versions can be restored with restore_package_version for 30 days, unless a version with the same name is published. A public version with more than 5,000 downloads cannot be deleted")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_PACKAGE_VERSION_USER_TITLE", "Delete package version"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withPackageScope("delete a version of"),
+			withPackage(),
+			mcp.WithNumber("package_version_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the package version, from list_package_versions"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, packageType, packageName, err := packageParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "package_version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if org == "" {
+				resp, err = client.Users.PackageDeleteVersion(ctx, "", packageType, url.PathEscape(packageName), int64(versionID))
+			} else {
+				resp, err = client.Organizations.PackageDeleteVersion(ctx, org, packageType, packageName, int64(versionID))
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete package version", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Version %d of %s package %s deleted", versionID, packageType, packageName)), nil
+		}
+}
+
+// RestorePackageVersion creates a tool to restore a deleted version of a package.
+func RestorePackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("restore_package_version",
+			mcp.WithDescription(t("TOOL_RESTORE_PACKAGE_VERSION_DESCRIPTION", "Restore a version of a package of an organization, or of the authenticated user when org is omitted, that was deleted in the last 30 days. Find deleted versions with list_package_versions and state deleted")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RESTORE_PACKAGE_VERSION_USER_TITLE", "Restore package version"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withPackageScope("restore a version of"),
+			withPackage(),
+			mcp.WithNumber("package_version_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the deleted package version"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, packageType, packageName, err := packageParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "package_version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if org == "" {
+				resp, err = client.Users.PackageRestoreVersion(ctx, "", packageType, url.PathEscape(packageName), int64(versionID))
+			} else {
+				resp, err = client.Organizations.PackageRestoreVersion(ctx, org, packageType, packageName, int64(versionID))
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to restore package version", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Version %d of %s package %s restored", versionID, packageType, packageName)), nil
+		}
+}
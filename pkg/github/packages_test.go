@@ -0,0 +1,272 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListPackages(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_packages", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"package_type"})
+
+	mockPackages := []*github.Package{{
+		ID:           github.Ptr(int64(1)),
+		Name:         github.Ptr("app/web"),
+		PackageType:  github.Ptr("container"),
+		Visibility:   github.Ptr("private"),
+		VersionCount: github.Ptr(int64(12)),
+	}}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "organization packages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					expectQueryParams(t, map[string]string{
+						"package_type": "container",
+						"visibility":   "private",
+						"page":         "1",
+						"per_page":     "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockPackages),
+					),
+				),
+			),
+			requestArgs: map[string]any{"org": "octo-org", "package_type": "container", "visibility": "private"},
+		},
+		{
+			name: "packages of the authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUserPackages, mockPackages),
+			),
+			requestArgs: map[string]any{"package_type": "container"},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible"}),
+				),
+			),
+			requestArgs:    map[string]any{"org": "octo-org", "package_type": "npm"},
+			expectError:    true,
+			expectedErrMsg: "failed to list packages",
+		},
+		{
+			name:           "missing package type",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"org": "octo-org"},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: package_type",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPackages(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var packages []*github.Package
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &packages))
+			require.Len(t, packages, 1)
+			assert.Equal(t, "app/web", packages[0].GetName())
+			assert.Equal(t, int64(12), packages[0].GetVersionCount())
+		})
+	}
+}
+
+func Test_ListPackageVersions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackageVersions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_package_versions", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"package_type", "package_name"})
+
+	mockVersions := []*github.PackageVersion{{
+		ID:       github.Ptr(int64(7)),
+		Name:     github.Ptr("sha256:abc"),
+		Metadata: json.RawMessage(`{"package_type":"container","container":{"tags":["latest"]}}`),
+	}}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "deleted versions of an organization package",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+					expectQueryParams(t, map[string]string{
+						"state":    "deleted",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockVersions),
+					),
+				),
+			),
+			requestArgs: map[string]any{"org": "octo-org", "package_type": "container", "package_name": "web", "state": "deleted"},
+		},
+		{
+			name: "versions of a namespaced package of the authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					// The mock routes on the unescaped path, where the name spans two segments
+					mock.EndpointPattern{Pattern: "/user/packages/{package_type}/{package_name:.+}/versions", Method: http.MethodGet},
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/user/packages/container/app%2Fweb/versions", r.URL.EscapedPath())
+						mockResponse(t, http.StatusOK, mockVersions)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]any{"package_type": "container", "package_name": "app/web"},
+		},
+		{
+			name: "package not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Package not found."}),
+				),
+			),
+			requestArgs:    map[string]any{"org": "octo-org", "package_type": "npm", "package_name": "missing"},
+			expectError:    true,
+			expectedErrMsg: "failed to list package versions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPackageVersions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var versions []*github.PackageVersion
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &versions))
+			require.Len(t, versions, 1)
+			metadata, ok := versions[0].GetMetadata()
+			require.True(t, ok)
+			assert.Equal(t, []string{"latest"}, metadata.GetContainer().Tags)
+		})
+	}
+}
+
+func Test_DeletePackageVersion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeletePackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_package_version", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"package_type", "package_name", "package_version_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsPackagesVersionsByOrgByPackageTypeByPackageNameByPackageVersionId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+	))
+	_, handler := DeletePackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"org":                "octo-org",
+		"package_type":       "container",
+		"package_name":       "web",
+		"package_version_id": float64(7),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "Version 7 of container package web deleted", getTextResult(t, result).Text)
+
+	client = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteUserPackagesVersionsByPackageTypeByPackageNameByPackageVersionId,
+			mockResponse(t, http.StatusBadRequest, map[string]string{"message": "Publicly visible package versions with more than 5000 downloads cannot be deleted."}),
+		),
+	))
+	_, handler = DeletePackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{
+		"package_type":       "npm",
+		"package_name":       "popular",
+		"package_version_id": float64(8),
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "failed to delete package version")
+}
+
+func Test_RestorePackageVersion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RestorePackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "restore_package_version", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"package_type", "package_name", "package_version_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostUserPackagesVersionsRestoreByPackageTypeByPackageNameByPackageVersionId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+	))
+	_, handler := RestorePackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"package_type":       "maven",
+		"package_name":       "com.example.lib",
+		"package_version_id": float64(9),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "Version 9 of maven package com.example.lib restored", getTextResult(t, result).Text)
+}
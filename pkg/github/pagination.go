@@ -0,0 +1,48 @@
+package github
+
+import (
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// PageInfo describes where a page of a list tool's results sits in the full list, the same way
+// for every list tool. It is returned in the "pageInfo" field of the result metadata. Tools
+// backed by the REST API are paged with page and perPage, so the next page is given as
+// nextPage. Tools backed by the GraphQL API are paged with perPage and after, so the next page is
+// given as nextCursor.
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	NextPage    int    `json:"nextPage,omitempty"`
+	LastPage    int    `json:"lastPage,omitempty"`
+	NextCursor  string `json:"nextCursor,omitempty"`
+	TotalCount  *int   `json:"totalCount,omitempty"`
+}
+
+// restPageInfo returns the PageInfo of a REST API response, going by its Link header.
+// totalCount is nil unless the API reports it, as the search API does.
+func restPageInfo(resp *github.Response, totalCount *int) PageInfo {
+	info := PageInfo{TotalCount: totalCount}
+	if resp != nil {
+		info.HasNextPage = resp.NextPage != 0
+		info.NextPage = resp.NextPage
+		info.LastPage = resp.LastPage
+	}
+	return info
+}
+
+// graphQLPageInfo returns the PageInfo of a GraphQL connection from its pageInfo. totalCount is
+// nil if the query did not select it.
+func graphQLPageInfo(hasNextPage bool, endCursor githubv4.String, totalCount *int) PageInfo {
+	info := PageInfo{HasNextPage: hasNextPage, TotalCount: totalCount}
+	if hasNextPage {
+		info.NextCursor = string(endCursor)
+	}
+	return info
+}
+
+// withPageInfo adds the PageInfo of a list tool's results to their metadata.
+func withPageInfo(result *mcp.CallToolResult, info PageInfo) *mcp.CallToolResult {
+	setResultMeta(result, "pageInfo", info)
+	return result
+}
@@ -0,0 +1,60 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_restPageInfo(t *testing.T) {
+	assert.Equal(t, PageInfo{HasNextPage: true, NextPage: 3, LastPage: 7}, restPageInfo(&github.Response{NextPage: 3, LastPage: 7}, nil))
+	assert.Equal(t, PageInfo{TotalCount: github.Ptr(12)}, restPageInfo(&github.Response{}, github.Ptr(12)), "the last page")
+	assert.Equal(t, PageInfo{}, restPageInfo(nil, nil))
+}
+
+func Test_graphQLPageInfo(t *testing.T) {
+	assert.Equal(t, PageInfo{HasNextPage: true, NextCursor: "Y3Vyc29y", TotalCount: github.Ptr(40)}, graphQLPageInfo(true, "Y3Vyc29y", github.Ptr(40)))
+	assert.Equal(t, PageInfo{}, graphQLPageInfo(false, "Y3Vyc29y", nil), "there is no next cursor on the last page")
+}
+
+func Test_PageInfo_REST(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposBranchesByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/branches?page=3&per_page=2>; rel="next", <https://api.github.com/repos/owner/repo/branches?page=5&per_page=2>; rel="last"`)
+				_, _ = w.Write([]byte(`[{"name":"main"},{"name":"develop"}]`))
+			}),
+		),
+	))
+	_, handler := ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":   "owner",
+		"repo":    "repo",
+		"page":    float64(2),
+		"perPage": float64(2),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, PageInfo{HasNextPage: true, NextPage: 3, LastPage: 5}, result.Meta.AdditionalFields["pageInfo"])
+}
+
+func Test_PageInfo_GraphQL(t *testing.T) {
+	matcher := ownerProjectsMatcher(30, "", "", []map[string]any{{"id": "PVT_1", "number": 1, "title": "Backlog"}}, true)
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+	_, handler := ListProjects(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, PageInfo{HasNextPage: true, NextCursor: "cursor"}, result.Meta.AdditionalFields["pageInfo"])
+}
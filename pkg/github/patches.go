@@ -0,0 +1,287 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/patch"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// PatchedFile describes a file changed by apply_patch.
+type PatchedFile struct {
+	Path    string `json:"path"`
+	OldPath string `json:"old_path,omitempty"`
+	Status  string `json:"status"`
+}
+
+// ApplyPatchResult is the output of apply_patch.
+type ApplyPatchResult struct {
+	CommitSHA string        `json:"commit_sha"`
+	CommitURL string        `json:"commit_url,omitempty"`
+	Files     []PatchedFile `json:"files"`
+}
+
+// ApplyPatch creates a tool to apply a unified diff to a branch as a single commit.
+func ApplyPatch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_patch",
+			mcp.WithDescription(t("TOOL_APPLY_PATCH_DESCRIPTION", "Apply a unified diff (as produced by 'git diff' or 'diff -u') to a branch and push the result as a single commit. Supports added, deleted and renamed files and file mode changes. The patch is rejected without any changes if any hunk does not apply to the current branch contents.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_APPLY_PATCH_USER_TITLE", "Apply patch to branch"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to apply the patch to"),
+			),
+			mcp.WithString("patch_text",
+				mcp.Required(),
+				mcp.Description("Unified diff to apply. Paths may use the a/ and b/ prefixes of git diffs."),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			patchText, err := RequiredParam[string](request, "patch_text")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			files, err := patch.Parse(patchText)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse patch: %s", err)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get branch reference",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, ref.GetObject().GetSHA())
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get base commit",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			baseTree, resp, err := client.Git.GetTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get base tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if baseTree.GetTruncated() {
+				return mcp.NewToolResultError("repository tree is too large to apply a patch to"), nil
+			}
+			existing := make(map[string]*github.TreeEntry, len(baseTree.Entries))
+			for _, entry := range baseTree.Entries {
+				if entry.GetType() == "blob" {
+					existing[entry.GetPath()] = entry
+				}
+			}
+
+			// Apply every file before writing anything, so that a conflict in any file leaves
+			// the branch untouched and all conflicts can be reported at once.
+			var entries []*github.TreeEntry
+			var patched []PatchedFile
+			var conflicts []string
+			for _, f := range files {
+				var source *github.TreeEntry
+				content := ""
+				if f.IsNew() {
+					if _, ok := existing[f.NewPath]; ok {
+						conflicts = append(conflicts, fmt.Sprintf("%s already exists", f.NewPath))
+						continue
+					}
+				} else {
+					source = existing[f.OldPath]
+					if source == nil {
+						conflicts = append(conflicts, fmt.Sprintf("%s does not exist", f.OldPath))
+						continue
+					}
+					if _, ok := existing[f.NewPath]; ok && f.IsRename() {
+						conflicts = append(conflicts, fmt.Sprintf("cannot rename %s to %s, which already exists", f.OldPath, f.NewPath))
+						continue
+					}
+					if len(f.Hunks) > 0 {
+						raw, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, source.GetSHA())
+						if err != nil {
+							return ghErrors.NewGitHubAPIErrorResponse(ctx,
+								fmt.Sprintf("failed to get file contents: %s", f.OldPath),
+								resp,
+								err,
+							), nil
+						}
+						_ = resp.Body.Close()
+						if isBinaryContent(raw) {
+							conflicts = append(conflicts, fmt.Sprintf("%s is a binary file", f.OldPath))
+							continue
+						}
+						content = string(raw)
+					}
+				}
+
+				result, err := patch.Apply(content, f)
+				if err != nil {
+					var conflict *patch.ConflictError
+					if errors.As(err, &conflict) {
+						conflicts = append(conflicts, conflict.Error())
+						continue
+					}
+					return nil, fmt.Errorf("failed to apply patch: %w", err)
+				}
+
+				switch {
+				case f.IsDeleted():
+					if result != "" {
+						conflicts = append(conflicts, fmt.Sprintf("%s has content not removed by the patch", f.OldPath))
+						continue
+					}
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(f.OldPath),
+						Mode: source.Mode,
+						Type: github.Ptr("blob"),
+					})
+					patched = append(patched, PatchedFile{Path: f.OldPath, Status: "deleted"})
+					continue
+				case f.IsRename():
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(f.OldPath),
+						Mode: source.Mode,
+						Type: github.Ptr("blob"),
+					})
+					patched = append(patched, PatchedFile{Path: f.NewPath, OldPath: f.OldPath, Status: "renamed"})
+				case f.IsNew():
+					patched = append(patched, PatchedFile{Path: f.NewPath, Status: "added"})
+				default:
+					patched = append(patched, PatchedFile{Path: f.NewPath, Status: "modified"})
+				}
+
+				mode := f.NewMode
+				if mode == "" && source != nil {
+					mode = source.GetMode()
+				}
+				if mode == "" {
+					mode = "100644"
+				}
+				entry := &github.TreeEntry{
+					Path: github.Ptr(f.NewPath),
+					Mode: github.Ptr(mode),
+					Type: github.Ptr("blob"),
+				}
+				if f.IsNew() || len(f.Hunks) > 0 {
+					entry.Content = github.Ptr(result)
+				} else {
+					// Pure renames and mode changes keep the existing blob.
+					entry.SHA = source.SHA
+				}
+				entries = append(entries, entry)
+			}
+			if len(conflicts) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("patch does not apply to %s:\n%s", branch, strings.Join(conflicts, "\n"))), nil
+			}
+
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), entries)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			commit := &github.Commit{
+				Message: github.Ptr(message),
+				Tree:    newTree,
+				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+			}
+			newCommit, resp, err := createCommit(ctx, client, owner, repo, commit)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create commit",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// The update is not forced, so concurrent pushes to the branch are detected rather than overwritten.
+			ref.Object.SHA = newCommit.SHA
+			_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to update reference",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update reference: unexpected status %d", resp.StatusCode)), nil
+			}
+
+			r, err := json.Marshal(ApplyPatchResult{
+				CommitSHA: newCommit.GetSHA(),
+				CommitURL: newCommit.GetHTMLURL(),
+				Files:     patched,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,233 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ApplyPatch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ApplyPatch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "apply_patch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "patch_text")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "patch_text", "message"})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	mockBaseCommit := &github.Commit{
+		SHA:  github.Ptr("abc123"),
+		Tree: &github.Tree{SHA: github.Ptr("def456")},
+	}
+	mockBaseTree := &github.Tree{
+		SHA: github.Ptr("def456"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("main.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("blob-main")},
+			{Path: github.Ptr("docs"), Type: github.Ptr("tree"), Mode: github.Ptr("040000"), SHA: github.Ptr("tree-docs")},
+			{Path: github.Ptr("docs/old.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("blob-doc")},
+			{Path: github.Ptr("run.sh"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("blob-run")},
+			{Path: github.Ptr("obsolete.txt"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("blob-obsolete")},
+		},
+	}
+	blobHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/blob-main"):
+			_, _ = w.Write([]byte("package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n"))
+		case strings.HasSuffix(r.URL.Path, "/blob-obsolete"):
+			_, _ = w.Write([]byte("gone\n"))
+		}
+	})
+	readHandlers := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+			mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, mockBaseCommit),
+			mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, mockBaseTree),
+			mock.WithRequestMatchHandler(mock.GetReposGitBlobsByOwnerByRepoByFileSha, blobHandler),
+		}
+	}
+
+	fullPatch := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -3,3 +3,3 @@
+ func main() {
+-	println("hello")
++	println("hello, world")
+ }
+diff --git a/docs/old.md b/docs/new.md
+similarity index 100%
+rename from docs/old.md
+rename to docs/new.md
+diff --git a/run.sh b/run.sh
+old mode 100644
+new mode 100755
+diff --git a/NOTES b/NOTES
+new file mode 100644
+--- /dev/null
++++ b/NOTES
+@@ -0,0 +1 @@
++remember
+diff --git a/obsolete.txt b/obsolete.txt
+deleted file mode 100644
+--- a/obsolete.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+`
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult ApplyPatchResult
+	}{
+		{
+			name: "applies modifications, renames, mode changes, additions and deletions",
+			mockedClient: mock.NewMockedHTTPClient(
+				append(readHandlers(),
+					mock.WithRequestMatchHandler(
+						mock.PostReposGitTreesByOwnerByRepo,
+						expectRequestBody(t, map[string]interface{}{
+							"base_tree": "def456",
+							"tree": []interface{}{
+								map[string]interface{}{"path": "main.go", "mode": "100644", "type": "blob", "content": "package main\n\nfunc main() {\n\tprintln(\"hello, world\")\n}\n"},
+								map[string]interface{}{"path": "docs/old.md", "mode": "100644", "type": "blob", "sha": nil},
+								map[string]interface{}{"path": "docs/new.md", "mode": "100644", "type": "blob", "sha": "blob-doc"},
+								map[string]interface{}{"path": "run.sh", "mode": "100755", "type": "blob", "sha": "blob-run"},
+								map[string]interface{}{"path": "NOTES", "mode": "100644", "type": "blob", "content": "remember\n"},
+								map[string]interface{}{"path": "obsolete.txt", "mode": "100644", "type": "blob", "sha": nil},
+							},
+						}).andThen(
+							mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("ghi789")}),
+						),
+					),
+					mock.WithRequestMatchHandler(
+						mock.PostReposGitCommitsByOwnerByRepo,
+						expectRequestBody(t, map[string]interface{}{
+							"message": "Apply patch",
+							"tree":    "ghi789",
+							"parents": []interface{}{"abc123"},
+						}).andThen(
+							mockResponse(t, http.StatusCreated, &github.Commit{
+								SHA:     github.Ptr("jkl012"),
+								HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
+							}),
+						),
+					),
+					mock.WithRequestMatchHandler(
+						mock.PatchReposGitRefsByOwnerByRepoByRef,
+						expectRequestBody(t, map[string]interface{}{
+							"sha":   "jkl012",
+							"force": false,
+						}).andThen(
+							mockResponse(t, http.StatusOK, mockRef),
+						),
+					),
+				)...,
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"branch":     "main",
+				"patch_text": fullPatch,
+				"message":    "Apply patch",
+			},
+			expectedResult: ApplyPatchResult{
+				CommitSHA: "jkl012",
+				CommitURL: "https://github.com/owner/repo/commit/jkl012",
+				Files: []PatchedFile{
+					{Path: "main.go", Status: "modified"},
+					{Path: "docs/new.md", OldPath: "docs/old.md", Status: "renamed"},
+					{Path: "run.sh", Status: "modified"},
+					{Path: "NOTES", Status: "added"},
+					{Path: "obsolete.txt", Status: "deleted"},
+				},
+			},
+		},
+		{
+			name:         "reports all conflicts without writing",
+			mockedClient: mock.NewMockedHTTPClient(readHandlers()...),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"patch_text": "--- a/main.go\n+++ b/main.go\n@@ -4 +4 @@\n-\tprintln(\"goodbye\")\n+\tprintln(\"bye\")\n" +
+					"--- /dev/null\n+++ b/run.sh\n@@ -0,0 +1 @@\n+echo\n" +
+					"--- a/missing.txt\n+++ b/missing.txt\n@@ -1 +1 @@\n-a\n+b\n",
+				"message": "Apply patch",
+			},
+			expectError:    true,
+			expectedErrMsg: "patch does not apply to main:\nhunk #1 does not apply to main.go\nrun.sh already exists\nmissing.txt does not exist",
+		},
+		{
+			name:         "rename onto an existing file",
+			mockedClient: mock.NewMockedHTTPClient(readHandlers()...),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"branch":     "main",
+				"patch_text": "diff --git a/docs/old.md b/run.sh\nsimilarity index 100%\nrename from docs/old.md\nrename to run.sh\n",
+				"message":    "Apply patch",
+			},
+			expectError:    true,
+			expectedErrMsg: "patch does not apply to main:\ncannot rename docs/old.md to run.sh, which already exists",
+		},
+		{
+			name:         "invalid patch",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"branch":     "main",
+				"patch_text": "not a diff",
+				"message":    "Apply patch",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to parse patch: no file changes found in patch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ApplyPatch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var returned ApplyPatchResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
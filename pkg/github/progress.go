@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter sends MCP progress notifications for a tool call that works through several
+// steps, such as pages or jobs, so that clients can show how far along it is. A nil
+// progressReporter, returned when the client did not ask for progress, does nothing.
+type progressReporter struct {
+	server *server.MCPServer
+	token  mcp.ProgressToken
+
+	mu    sync.Mutex
+	done  int
+	total int
+}
+
+// newProgressReporter returns a reporter for a tool call with total steps, or 0 if the number
+// of steps is not known yet. It returns nil if the request has no progress token.
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest, total int) *progressReporter {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return nil
+	}
+	return &progressReporter{server: s, token: request.Params.Meta.ProgressToken, total: total}
+}
+
+// setTotal sets the number of steps, once it is known.
+func (p *progressReporter) setTotal(total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+}
+
+// step records that a step has completed and notifies the client with a message describing it.
+// It is safe to call from several goroutines.
+func (p *progressReporter) step(ctx context.Context, message string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      p.done,
+	}
+	if p.total > 0 {
+		params["total"] = p.total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	// Progress is best effort, a client that cannot be notified still gets the result
+	_ = p.server.SendNotificationToClient(ctx, "notifications/progress", params)
+}
@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callWithProgress calls a tool through a server, as a client asking for progress would, and
+// returns the progress notifications sent for the call.
+func callWithProgress(t *testing.T, tool mcp.Tool, handler server.ToolHandlerFunc, args map[string]any, progressToken any) []map[string]any {
+	t.Helper()
+
+	s := NewServer("test")
+	s.AddTool(tool, handler)
+	session := &notificationSession{notifications: make(chan mcp.JSONRPCNotification, 200)}
+	require.NoError(t, s.RegisterSession(context.Background(), session))
+
+	params := map[string]any{"name": tool.Name, "arguments": args}
+	if progressToken != nil {
+		params["_meta"] = map[string]any{"progressToken": progressToken}
+	}
+	message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": params})
+	require.NoError(t, err)
+	response := s.HandleMessage(s.WithContext(context.Background(), session), message)
+	require.IsType(t, mcp.JSONRPCResponse{}, response)
+
+	var notifications []map[string]any
+	for {
+		select {
+		case n := <-session.notifications:
+			if n.Method == "notifications/progress" {
+				notifications = append(notifications, n.Params.AdditionalFields)
+			}
+		default:
+			return notifications
+		}
+	}
+}
+
+func Test_progressReporter(t *testing.T) {
+	tool := mcp.NewTool("count_to_three")
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		progress := newProgressReporter(ctx, request, 0)
+		progress.step(ctx, "counted one")
+		progress.setTotal(3)
+		progress.step(ctx, "counted two")
+		progress.step(ctx, "")
+		return mcp.NewToolResultText("done"), nil
+	}
+
+	notifications := callWithProgress(t, tool, handler, map[string]any{}, "count-token")
+	assert.Equal(t, []map[string]any{
+		{"progressToken": "count-token", "progress": 1, "message": "counted one"},
+		{"progressToken": "count-token", "progress": 2, "total": 3, "message": "counted two"},
+		{"progressToken": "count-token", "progress": 3, "total": 3},
+	}, notifications)
+
+	assert.Empty(t, callWithProgress(t, tool, handler, map[string]any{}, nil), "no progress is sent without a progress token")
+}
+
+func Test_progressReporter_Nil(t *testing.T) {
+	// Without a server in the context, as when a handler is called directly, nothing is sent
+	request := createMCPRequest(map[string]any{})
+	request.Params.Meta = &mcp.Meta{ProgressToken: "token"}
+	progress := newProgressReporter(context.Background(), request, 2)
+	assert.Nil(t, progress)
+	progress.setTotal(3)
+	progress.step(context.Background(), "ignored")
+}
+
+func Test_BulkUpdateIssues_Progress(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+			mockResponse(t, http.StatusOK, &github.Issue{State: github.Ptr("closed")}),
+		),
+	))
+	tool, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	notifications := callWithProgress(t, tool, handler, map[string]any{
+		"owner":         "owner",
+		"repo":          "repo",
+		"issue_numbers": []any{1, 2, 3},
+		"state":         "closed",
+	}, 7)
+	require.Len(t, notifications, 3)
+	for i, n := range notifications {
+		assert.Equal(t, i+1, n["progress"])
+		assert.Equal(t, 3, n["total"])
+		assert.Contains(t, n["message"], "Updated issue #")
+	}
+}
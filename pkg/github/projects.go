@@ -0,0 +1,1707 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// defaultProjectFieldValues is the default number of field values fetched per project item.
+	defaultProjectFieldValues = 20
+	// maxProjectFieldValues is the most field values GitHub returns per project item.
+	maxProjectFieldValues = 100
+	// defaultProjectBoardItems is the default number of items scanned to build a project board.
+	defaultProjectBoardItems = 500
+	// maxProjectBoardItems is the most items scanned to build a project board.
+	maxProjectBoardItems = 1000
+	// defaultProjectBoardColumnItems is the default number of items listed per board column.
+	defaultProjectBoardColumnItems = 20
+)
+
+// projectFieldName selects the name of a project field, whatever its type.
+type projectFieldName struct {
+	Common struct {
+		Name githubv4.String
+	} `graphql:"... on ProjectV2FieldCommon"`
+}
+
+// projectItemFieldValue is one of the field values of a project item.
+type projectItemFieldValue struct {
+	Typename githubv4.String `graphql:"__typename"`
+	Text     struct {
+		Text  githubv4.String
+		Field projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldTextValue"`
+	Number struct {
+		Number *githubv4.Float
+		Field  projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldNumberValue"`
+	Date struct {
+		Date  githubv4.String
+		Field projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldDateValue"`
+	SingleSelect struct {
+		Name     githubv4.String
+		OptionID githubv4.String `graphql:"optionId"`
+		Field    projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	Iteration struct {
+		Title       githubv4.String
+		IterationID githubv4.String `graphql:"iterationId"`
+		StartDate   githubv4.String
+		Field       projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+	Users struct {
+		Users struct {
+			Nodes []struct {
+				Login githubv4.String
+			}
+		} `graphql:"users(first: 10)"`
+		Field projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldUserValue"`
+	Labels struct {
+		Labels struct {
+			Nodes []struct {
+				Name githubv4.String
+			}
+		} `graphql:"labels(first: 10)"`
+		Field projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldLabelValue"`
+}
+
+// projectItemContent is the issue, pull request or draft issue a project item tracks.
+type projectItemContent struct {
+	Typename githubv4.String `graphql:"__typename"`
+	Issue    struct {
+		Number     githubv4.Int
+		Title      githubv4.String
+		State      githubv4.String
+		URL        githubv4.String `graphql:"url"`
+		Repository struct {
+			NameWithOwner githubv4.String
+		}
+	} `graphql:"... on Issue"`
+	PullRequest struct {
+		Number     githubv4.Int
+		Title      githubv4.String
+		State      githubv4.String
+		URL        githubv4.String `graphql:"url"`
+		Repository struct {
+			NameWithOwner githubv4.String
+		}
+	} `graphql:"... on PullRequest"`
+	DraftIssue struct {
+		Title githubv4.String
+	} `graphql:"... on DraftIssue"`
+}
+
+type projectItemNode struct {
+	ID          githubv4.ID
+	Type        githubv4.String
+	IsArchived  githubv4.Boolean
+	Content     projectItemContent
+	FieldValues struct {
+		Nodes []projectItemFieldValue
+	} `graphql:"fieldValues(first: $fieldValuesFirst)"`
+}
+
+type projectItemsFragment struct {
+	Nodes      []projectItemNode
+	PageInfo   PageInfoFragment
+	TotalCount githubv4.Int
+}
+
+// projectItems selects a page of the items of a project.
+type projectItems struct {
+	Items projectItemsFragment `graphql:"items(first: $first, last: $last, after: $after, before: $before, query: $query)"`
+}
+
+// queryProjectItems runs the project items query with the given paging and filter variables.
+func queryProjectItems(ctx context.Context, client *githubv4.Client, owner string, number int, vars map[string]any) (projectItemsFragment, error) {
+	project, err := queryProject[projectItems](ctx, client, owner, number, vars)
+	if err != nil {
+		return projectItemsFragment{}, err
+	}
+	return project.Items, nil
+}
+
+// ProjectItemContent is the issue, pull request or draft issue a project item tracks.
+type ProjectItemContent struct {
+	Type       string `json:"type"`
+	Number     int    `json:"number,omitempty"`
+	Title      string `json:"title"`
+	State      string `json:"state,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Repository string `json:"repository,omitempty"`
+}
+
+// ProjectItem is a project item with its field values keyed by field name.
+type ProjectItem struct {
+	ID         string              `json:"id"`
+	Type       string              `json:"type"`
+	IsArchived bool                `json:"is_archived,omitempty"`
+	Content    *ProjectItemContent `json:"content,omitempty"`
+	Fields     map[string]any      `json:"fields"`
+}
+
+func projectItemFromNode(node projectItemNode) ProjectItem {
+	item := ProjectItem{
+		ID:         fmt.Sprint(node.ID),
+		Type:       string(node.Type),
+		IsArchived: bool(node.IsArchived),
+		Fields:     map[string]any{},
+	}
+
+	switch c := node.Content; c.Typename {
+	case "Issue":
+		item.Content = &ProjectItemContent{
+			Type:       "Issue",
+			Number:     int(c.Issue.Number),
+			Title:      string(c.Issue.Title),
+			State:      string(c.Issue.State),
+			URL:        string(c.Issue.URL),
+			Repository: string(c.Issue.Repository.NameWithOwner),
+		}
+	case "PullRequest":
+		item.Content = &ProjectItemContent{
+			Type:       "PullRequest",
+			Number:     int(c.PullRequest.Number),
+			Title:      string(c.PullRequest.Title),
+			State:      string(c.PullRequest.State),
+			URL:        string(c.PullRequest.URL),
+			Repository: string(c.PullRequest.Repository.NameWithOwner),
+		}
+	case "DraftIssue":
+		item.Content = &ProjectItemContent{
+			Type:  "DraftIssue",
+			Title: string(c.DraftIssue.Title),
+		}
+	}
+
+	for _, v := range node.FieldValues.Nodes {
+		switch v.Typename {
+		case "ProjectV2ItemFieldTextValue":
+			item.Fields[string(v.Text.Field.Common.Name)] = string(v.Text.Text)
+		case "ProjectV2ItemFieldNumberValue":
+			if v.Number.Number != nil {
+				item.Fields[string(v.Number.Field.Common.Name)] = float64(*v.Number.Number)
+			}
+		case "ProjectV2ItemFieldDateValue":
+			item.Fields[string(v.Date.Field.Common.Name)] = string(v.Date.Date)
+		case "ProjectV2ItemFieldSingleSelectValue":
+			item.Fields[string(v.SingleSelect.Field.Common.Name)] = map[string]string{
+				"name":      string(v.SingleSelect.Name),
+				"option_id": string(v.SingleSelect.OptionID),
+			}
+		case "ProjectV2ItemFieldIterationValue":
+			item.Fields[string(v.Iteration.Field.Common.Name)] = map[string]string{
+				"title":        string(v.Iteration.Title),
+				"iteration_id": string(v.Iteration.IterationID),
+				"start_date":   string(v.Iteration.StartDate),
+			}
+		case "ProjectV2ItemFieldUserValue":
+			logins := make([]string, 0, len(v.Users.Users.Nodes))
+			for _, u := range v.Users.Users.Nodes {
+				logins = append(logins, string(u.Login))
+			}
+			item.Fields[string(v.Users.Field.Common.Name)] = logins
+		case "ProjectV2ItemFieldLabelValue":
+			names := make([]string, 0, len(v.Labels.Labels.Nodes))
+			for _, l := range v.Labels.Labels.Nodes {
+				names = append(names, string(l.Name))
+			}
+			item.Fields[string(v.Labels.Field.Common.Name)] = names
+		}
+	}
+	return item
+}
+
+// ListProjectItems creates a tool to list the items of a GitHub project.
+func ListProjectItems(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_items",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_ITEMS_DESCRIPTION", "List the items of a GitHub project (Projects V2) with their field values. Use the query parameter to filter items the way the project's filter bar does, and the after/before cursors from pageInfo to page through large projects.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_ITEMS_USER_TITLE", "List project items"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that owns the project"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("Project number, as shown in the project's URL"),
+			),
+			mcp.WithString("query",
+				mcp.Description("Filter items using the project filter syntax, e.g. 'status:\"In Progress\" assignee:octocat is:issue'"),
+			),
+			mcp.WithNumber("field_values_limit",
+				mcp.Description(fmt.Sprintf("Maximum number of field values returned per item (default %d, max %d)", defaultProjectFieldValues, maxProjectFieldValues)),
+				mcp.Min(1),
+				mcp.Max(maxProjectFieldValues),
+			),
+			WithCursorPagination(),
+			mcp.WithString("before",
+				mcp.Description("Cursor for paginating backwards. Use the startCursor from the previous page's pageInfo. Takes precedence over after"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(request, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldValuesLimit, err := OptionalIntParamWithDefault(request, "field_values_limit", defaultProjectFieldValues)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			before, err := OptionalParam[string](request, "before")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if fieldValuesLimit < 1 || fieldValuesLimit > maxProjectFieldValues {
+				return mcp.NewToolResultError(fmt.Sprintf("field_values_limit must be between 1 and %d", maxProjectFieldValues)), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			vars := map[string]any{
+				"fieldValuesFirst": githubv4.Int(fieldValuesLimit), // #nosec G115 - bounded by maxProjectFieldValues
+				"first":            (*githubv4.Int)(nil),
+				"last":             (*githubv4.Int)(nil),
+				"after":            (*githubv4.String)(nil),
+				"before":           (*githubv4.String)(nil),
+				"query":            (*githubv4.String)(nil),
+			}
+			// Paging backwards takes the last items before the cursor, forwards the first after it.
+			if before != "" {
+				vars["last"] = githubv4.NewInt(githubv4.Int(*paginationParams.First))
+				vars["before"] = githubv4.NewString(githubv4.String(before))
+			} else {
+				vars["first"] = githubv4.NewInt(githubv4.Int(*paginationParams.First))
+				if paginationParams.After != nil {
+					vars["after"] = githubv4.NewString(githubv4.String(*paginationParams.After))
+				}
+			}
+			if query != "" {
+				vars["query"] = githubv4.NewString(githubv4.String(query))
+			}
+
+			items, err := queryProjectItems(ctx, client, owner, projectNumber, vars)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list project items", err), nil
+			}
+
+			result := make([]ProjectItem, 0, len(items.Nodes))
+			for _, node := range items.Nodes {
+				result = append(result, projectItemFromNode(node))
+			}
+
+			totalCount := int(items.TotalCount)
+			return withPageInfo(MarshalledTextResult(map[string]any{
+				"items": result,
+				"pageInfo": map[string]any{
+					"hasNextPage":     items.PageInfo.HasNextPage,
+					"hasPreviousPage": items.PageInfo.HasPreviousPage,
+					"startCursor":     string(items.PageInfo.StartCursor),
+					"endCursor":       string(items.PageInfo.EndCursor),
+				},
+				"totalCount": items.TotalCount,
+			}), graphQLPageInfo(items.PageInfo.HasNextPage, items.PageInfo.EndCursor, &totalCount)), nil
+		}
+}
+
+// projectQuery selects a field of a project looked up by its owner's login and its number.
+// Users and organizations both implement ProjectV2Owner, so the same query serves either.
+type projectQuery[T any] struct {
+	RepositoryOwner *struct {
+		Owner struct {
+			ProjectV2 T `graphql:"projectV2(number: $number)"`
+		} `graphql:"... on ProjectV2Owner"`
+	} `graphql:"repositoryOwner(login: $owner)"`
+}
+
+// queryProject runs a projectQuery with the given extra variables and returns the selected project.
+func queryProject[T any](ctx context.Context, client *githubv4.Client, owner string, number int, vars map[string]any) (T, error) {
+	queryVars := map[string]any{
+		"owner":  githubv4.String(owner),
+		"number": githubv4.Int(number), // #nosec G115 - project numbers are always small positive integers
+	}
+	maps.Copy(queryVars, vars)
+
+	var q projectQuery[T]
+	if err := client.Query(ctx, &q, queryVars); err != nil {
+		var zero T
+		return zero, err
+	}
+	if q.RepositoryOwner == nil {
+		var zero T
+		return zero, fmt.Errorf("could not resolve to a user or organization with the login of '%s'", owner)
+	}
+	return q.RepositoryOwner.Owner.ProjectV2, nil
+}
+
+// projectIDNode selects the node ID of a project.
+type projectIDNode struct {
+	ID githubv4.ID
+}
+
+// getProjectID looks up the node ID of a project from its owner and number.
+func getProjectID(ctx context.Context, client *githubv4.Client, owner string, number int) (githubv4.ID, error) {
+	project, err := queryProject[projectIDNode](ctx, client, owner, number, nil)
+	if err != nil {
+		return nil, err
+	}
+	return project.ID, nil
+}
+
+// projectOptionColors are the colors a single select option can have.
+var projectOptionColors = []string{"GRAY", "BLUE", "GREEN", "YELLOW", "ORANGE", "RED", "PINK", "PURPLE"}
+
+// projectOptionsSchema describes the single select options accepted by the field tools.
+var projectOptionsSchema = map[string]any{
+	"type":                 "object",
+	"additionalProperties": false,
+	"required":             []string{"name"},
+	"properties": map[string]any{
+		"name": map[string]any{
+			"type":        "string",
+			"description": "option name",
+		},
+		"color": map[string]any{
+			"type":        "string",
+			"enum":        projectOptionColors,
+			"description": "option color (default GRAY)",
+		},
+		"description": map[string]any{
+			"type":        "string",
+			"description": "option description",
+		},
+	},
+}
+
+// projectSingleSelectOptions reads the single select options of a field tool request.
+func projectSingleSelectOptions(request mcp.CallToolRequest) ([]githubv4.ProjectV2SingleSelectFieldOptionInput, error) {
+	raw, ok := request.GetArguments()["options"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	list, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("options must be an array of objects")
+	}
+
+	options := make([]githubv4.ProjectV2SingleSelectFieldOptionInput, 0, len(list))
+	for i, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("option %d must be an object", i)
+		}
+		name, _ := m["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("option %d is missing a name", i)
+		}
+		color, _ := m["color"].(string)
+		if color == "" {
+			color = "GRAY"
+		}
+		description, _ := m["description"].(string)
+		options = append(options, githubv4.ProjectV2SingleSelectFieldOptionInput{
+			Name:        githubv4.String(name),
+			Color:       githubv4.ProjectV2SingleSelectFieldOptionColor(color),
+			Description: githubv4.String(description),
+		})
+	}
+	return options, nil
+}
+
+// ProjectFieldOption is an option of a single select project field.
+type ProjectFieldOption struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ProjectIteration is an iteration of an iteration project field.
+type ProjectIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	Duration  int    `json:"duration"`
+	Completed bool   `json:"completed,omitempty"`
+}
+
+// ProjectField is a field of a project. Options are only set for single select fields and
+// iterations only for iteration fields.
+type ProjectField struct {
+	ID         string               `json:"id"`
+	Name       string               `json:"name"`
+	DataType   string               `json:"data_type"`
+	Options    []ProjectFieldOption `json:"options,omitempty"`
+	Iterations []ProjectIteration   `json:"iterations,omitempty"`
+}
+
+type projectIterationNode struct {
+	ID        githubv4.String
+	Title     githubv4.String
+	StartDate githubv4.String
+	Duration  githubv4.Int
+}
+
+type projectFieldNode struct {
+	Common struct {
+		ID       githubv4.ID
+		Name     githubv4.String
+		DataType githubv4.String
+	} `graphql:"... on ProjectV2FieldCommon"`
+	SingleSelect struct {
+		Options []struct {
+			ID          githubv4.String
+			Name        githubv4.String
+			Color       githubv4.String
+			Description githubv4.String
+		}
+	} `graphql:"... on ProjectV2SingleSelectField"`
+	Iteration struct {
+		Configuration struct {
+			Iterations          []projectIterationNode
+			CompletedIterations []projectIterationNode
+		}
+	} `graphql:"... on ProjectV2IterationField"`
+}
+
+type projectFieldsFragment struct {
+	Nodes []projectFieldNode
+}
+
+// projectFields selects the fields of a project.
+type projectFields struct {
+	Fields projectFieldsFragment `graphql:"fields(first: 100)"`
+}
+
+func projectFieldFromNode(node projectFieldNode) ProjectField {
+	field := ProjectField{
+		ID:       fmt.Sprint(node.Common.ID),
+		Name:     string(node.Common.Name),
+		DataType: string(node.Common.DataType),
+	}
+	for _, o := range node.SingleSelect.Options {
+		field.Options = append(field.Options, ProjectFieldOption{
+			ID:          string(o.ID),
+			Name:        string(o.Name),
+			Color:       string(o.Color),
+			Description: string(o.Description),
+		})
+	}
+	iterations := node.Iteration.Configuration
+	for _, it := range iterations.Iterations {
+		field.Iterations = append(field.Iterations, ProjectIteration{
+			ID:        string(it.ID),
+			Title:     string(it.Title),
+			StartDate: string(it.StartDate),
+			Duration:  int(it.Duration),
+		})
+	}
+	for _, it := range iterations.CompletedIterations {
+		field.Iterations = append(field.Iterations, ProjectIteration{
+			ID:        string(it.ID),
+			Title:     string(it.Title),
+			StartDate: string(it.StartDate),
+			Duration:  int(it.Duration),
+			Completed: true,
+		})
+	}
+	return field
+}
+
+// getProjectFields lists the fields of a project.
+func getProjectFields(ctx context.Context, client *githubv4.Client, owner string, number int) ([]ProjectField, error) {
+	project, err := queryProject[projectFields](ctx, client, owner, number, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ProjectField, 0, len(project.Fields.Nodes))
+	for _, node := range project.Fields.Nodes {
+		result = append(result, projectFieldFromNode(node))
+	}
+	return result, nil
+}
+
+// ListProjectFields creates a tool to list the fields of a GitHub project.
+func ListProjectFields(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_fields",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_FIELDS_DESCRIPTION", "List the fields of a GitHub project (Projects V2) with their IDs, including the options of single select fields and the iterations of iteration fields.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_FIELDS_USER_TITLE", "List project fields"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that owns the project"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("Project number, as shown in the project's URL"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(request, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			fields, err := getProjectFields(ctx, client, owner, projectNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list project fields", err), nil
+			}
+			return MarshalledTextResult(fields), nil
+		}
+}
+
+// ProjectBoardCard is the compact form of a project item shown in a board column.
+type ProjectBoardCard struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	Number     int      `json:"number,omitempty"`
+	Title      string   `json:"title"`
+	State      string   `json:"state,omitempty"`
+	Repository string   `json:"repository,omitempty"`
+	Assignees  []string `json:"assignees,omitempty"`
+}
+
+// ProjectBoardColumn is one option of the grouping field with the items set to it.
+type ProjectBoardColumn struct {
+	OptionID string             `json:"option_id,omitempty"`
+	Name     string             `json:"name"`
+	Count    int                `json:"count"`
+	Items    []ProjectBoardCard `json:"items"`
+}
+
+// ProjectBoard is a snapshot of a project's items grouped by a single select field.
+type ProjectBoard struct {
+	Field      string               `json:"field"`
+	Columns    []ProjectBoardColumn `json:"columns"`
+	TotalItems int                  `json:"total_items"`
+	// Truncated is set when the project has more items than were scanned.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+func projectBoardCard(item ProjectItem) ProjectBoardCard {
+	card := ProjectBoardCard{
+		ID:   item.ID,
+		Type: item.Type,
+	}
+	if item.Content != nil {
+		card.Number = item.Content.Number
+		card.Title = item.Content.Title
+		card.State = item.Content.State
+		card.Repository = item.Content.Repository
+	}
+	if assignees, ok := item.Fields["Assignees"].([]string); ok {
+		card.Assignees = assignees
+	}
+	return card
+}
+
+// GetProjectBoard creates a tool to get a board view of a GitHub project, with its items grouped by a single select field.
+func GetProjectBoard(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_project_board",
+			mcp.WithDescription(t("TOOL_GET_PROJECT_BOARD_DESCRIPTION", "Get a board view of a GitHub project (Projects V2): its items grouped into columns by a single select field such as Status, in the field's option order, with the number of items in each column. Archived items are left out.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PROJECT_BOARD_USER_TITLE", "Get project board"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that owns the project"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("Project number, as shown in the project's URL"),
+			),
+			mcp.WithString("group_by",
+				mcp.Description("Name of the single select field to group items by (default: Status)"),
+			),
+			mcp.WithString("query",
+				mcp.Description("Filter items using the project filter syntax, e.g. 'assignee:octocat is:issue'"),
+			),
+			mcp.WithNumber("items_per_column",
+				mcp.Description(fmt.Sprintf("Maximum number of items listed per column; counts always cover every scanned item (default %d)", defaultProjectBoardColumnItems)),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("max_items",
+				mcp.Description(fmt.Sprintf("Maximum number of project items scanned (default %d, max %d)", defaultProjectBoardItems, maxProjectBoardItems)),
+				mcp.Min(1),
+				mcp.Max(maxProjectBoardItems),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(request, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			groupBy, err := OptionalParam[string](request, "group_by")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if groupBy == "" {
+				groupBy = "Status"
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			itemsPerColumn, err := OptionalIntParamWithDefault(request, "items_per_column", defaultProjectBoardColumnItems)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(request, "max_items", defaultProjectBoardItems)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if itemsPerColumn < 0 {
+				return mcp.NewToolResultError("items_per_column must not be negative"), nil
+			}
+			if maxItems < 1 || maxItems > maxProjectBoardItems {
+				return mcp.NewToolResultError(fmt.Sprintf("max_items must be between 1 and %d", maxProjectBoardItems)), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			fields, err := getProjectFields(ctx, client, owner, projectNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project fields", err), nil
+			}
+			var groupField *ProjectField
+			var singleSelectFields []string
+			for i, f := range fields {
+				if f.DataType != "SINGLE_SELECT" {
+					continue
+				}
+				singleSelectFields = append(singleSelectFields, f.Name)
+				if strings.EqualFold(f.Name, groupBy) {
+					groupField = &fields[i]
+				}
+			}
+			if groupField == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("project has no single select field named %q, available fields: %s", groupBy, strings.Join(singleSelectFields, ", "))), nil
+			}
+
+			// Columns follow the field's option order, with items that have no value for the field last.
+			board := ProjectBoard{Field: groupField.Name}
+			columnIndex := make(map[string]int, len(groupField.Options))
+			for _, opt := range groupField.Options {
+				columnIndex[opt.ID] = len(board.Columns)
+				board.Columns = append(board.Columns, ProjectBoardColumn{OptionID: opt.ID, Name: opt.Name, Items: []ProjectBoardCard{}})
+			}
+			noValue := ProjectBoardColumn{Name: "No " + groupField.Name, Items: []ProjectBoardCard{}}
+
+			vars := map[string]any{
+				"fieldValuesFirst": githubv4.Int(maxProjectFieldValues), // the grouping field may be any of the item's values
+				"first":            (*githubv4.Int)(nil),
+				"last":             (*githubv4.Int)(nil),
+				"after":            (*githubv4.String)(nil),
+				"before":           (*githubv4.String)(nil),
+				"query":            (*githubv4.String)(nil),
+			}
+			if query != "" {
+				vars["query"] = githubv4.NewString(githubv4.String(query))
+			}
+
+			// Progress is reported by page, once the first page tells how many items there are
+			progress := newProgressReporter(ctx, request, 0)
+			scanned := 0
+			for scanned < maxItems {
+				vars["first"] = githubv4.NewInt(githubv4.Int(min(100, maxItems-scanned))) // #nosec G115 - bounded by maxProjectBoardItems
+				items, err := queryProjectItems(ctx, client, owner, projectNumber, vars)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list project items", err), nil
+				}
+				toScan := min(int(items.TotalCount), maxItems)
+				if scanned == 0 {
+					progress.setTotal((toScan + 99) / 100)
+				}
+				scanned += len(items.Nodes)
+				progress.step(ctx, fmt.Sprintf("Scanned %d of %d project items", min(scanned, toScan), toScan))
+
+				for _, node := range items.Nodes {
+					if node.IsArchived {
+						continue
+					}
+					item := projectItemFromNode(node)
+					column := &noValue
+					if value, ok := item.Fields[groupField.Name].(map[string]string); ok {
+						if i, ok := columnIndex[value["option_id"]]; ok {
+							column = &board.Columns[i]
+						}
+					}
+					column.Count++
+					if len(column.Items) < itemsPerColumn {
+						column.Items = append(column.Items, projectBoardCard(item))
+					}
+					board.TotalItems++
+				}
+
+				if !items.PageInfo.HasNextPage || len(items.Nodes) == 0 {
+					break
+				}
+				if scanned >= maxItems {
+					board.Truncated = true
+					break
+				}
+				vars["after"] = githubv4.NewString(items.PageInfo.EndCursor)
+			}
+			board.Columns = append(board.Columns, noValue)
+
+			return MarshalledTextResult(board), nil
+		}
+}
+
+// ProjectSummary identifies a project created or updated by the project tools.
+type ProjectSummary struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Closed bool   `json:"closed"`
+	Public bool   `json:"public"`
+}
+
+type projectSummaryNode struct {
+	ID     githubv4.ID
+	Number githubv4.Int
+	Title  githubv4.String
+	URL    githubv4.String `graphql:"url"`
+	Closed githubv4.Boolean
+	Public githubv4.Boolean
+}
+
+func (n projectSummaryNode) summary() ProjectSummary {
+	return ProjectSummary{
+		ID:     fmt.Sprint(n.ID),
+		Number: int(n.Number),
+		Title:  string(n.Title),
+		URL:    string(n.URL),
+		Closed: bool(n.Closed),
+		Public: bool(n.Public),
+	}
+}
+
+const (
+	// projectSearchResults is the number of projects a search by name considers.
+	projectSearchResults = 20
+	// maxProjectNameScanPages bounds the pages of projects scanned when a search by name finds
+	// nothing, since GitHub's search matches whole words only.
+	maxProjectNameScanPages = 5
+)
+
+// ProjectListing describes a project returned by list_projects.
+type ProjectListing struct {
+	ProjectSummary
+	UpdatedAt string `json:"updated_at"`
+}
+
+type projectListingNode struct {
+	projectSummaryNode
+	UpdatedAt githubv4.DateTime
+}
+
+func (n projectListingNode) listing() ProjectListing {
+	return ProjectListing{
+		ProjectSummary: n.summary(),
+		UpdatedAt:      n.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// projectsPage is a page of the projects of a user or organization.
+type projectsPage struct {
+	Nodes    []projectListingNode
+	PageInfo PageInfoFragment
+}
+
+// ownerProjectsQuery selects a page of the projects of a user or organization, most recently
+// updated first.
+type ownerProjectsQuery struct {
+	RepositoryOwner *struct {
+		Owner struct {
+			ProjectsV2 projectsPage `graphql:"projectsV2(first: $first, after: $after, query: $query, orderBy: {field: UPDATED_AT, direction: DESC})"`
+		} `graphql:"... on ProjectV2Owner"`
+	} `graphql:"repositoryOwner(login: $owner)"`
+}
+
+// queryOwnerProjects returns a page of the projects of owner, optionally filtered by a search query.
+func queryOwnerProjects(ctx context.Context, client *githubv4.Client, owner string, first int, after, query string) (projectsPage, error) {
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"first": githubv4.Int(first), // #nosec G115 - page sizes are at most 100
+		"after": (*githubv4.String)(nil),
+		"query": (*githubv4.String)(nil),
+	}
+	if after != "" {
+		vars["after"] = githubv4.NewString(githubv4.String(after))
+	}
+	if query != "" {
+		vars["query"] = githubv4.NewString(githubv4.String(query))
+	}
+
+	var q ownerProjectsQuery
+	if err := client.Query(ctx, &q, vars); err != nil {
+		return projectsPage{}, err
+	}
+	if q.RepositoryOwner == nil {
+		return projectsPage{}, fmt.Errorf("could not resolve to a user or organization with the login of '%s'", owner)
+	}
+	return q.RepositoryOwner.Owner.ProjectsV2, nil
+}
+
+// matchProjectName returns the projects whose title is name, or failing that, the projects
+// whose title contains it, ignoring case.
+func matchProjectName(projects []projectListingNode, name string) []projectListingNode {
+	name = strings.ToLower(name)
+	var exact, partial []projectListingNode
+	for _, p := range projects {
+		title := strings.ToLower(string(p.Title))
+		switch {
+		case title == name:
+			exact = append(exact, p)
+		case strings.Contains(title, name):
+			partial = append(partial, p)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return partial
+}
+
+// findProjectByName looks up the projects of owner matching name with a search, and if that
+// finds nothing, by scanning a bounded number of pages of the most recently updated projects.
+func findProjectByName(ctx context.Context, client *githubv4.Client, owner, name string) ([]projectListingNode, error) {
+	page, err := queryOwnerProjects(ctx, client, owner, projectSearchResults, "", name)
+	if err != nil {
+		return nil, err
+	}
+	if matches := matchProjectName(page.Nodes, name); len(matches) > 0 {
+		return matches, nil
+	}
+
+	after := ""
+	for range maxProjectNameScanPages {
+		page, err := queryOwnerProjects(ctx, client, owner, 100, after, "")
+		if err != nil {
+			return nil, err
+		}
+		if matches := matchProjectName(page.Nodes, name); len(matches) > 0 {
+			return matches, nil
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		after = string(page.PageInfo.EndCursor)
+	}
+	return nil, nil
+}
+
+// ListProjects creates a tool to list the projects of a user or organization.
+func ListProjects(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_projects",
+			mcp.WithDescription(t("TOOL_LIST_PROJECTS_DESCRIPTION", "List the GitHub projects (Projects V2) of a user or organization, most recently updated first. Use the endCursor of the returned pageInfo as after to get the next page.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECTS_USER_TITLE", "List projects"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that owns the projects"),
+			),
+			mcp.WithString("query",
+				mcp.Description("Only list projects matching this search, e.g. words from their titles"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			after := ""
+			if paginationParams.After != nil {
+				after = *paginationParams.After
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			page, err := queryOwnerProjects(ctx, client, owner, int(*paginationParams.First), after, query)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list projects", err), nil
+			}
+
+			projects := make([]ProjectListing, 0, len(page.Nodes))
+			for _, node := range page.Nodes {
+				projects = append(projects, node.listing())
+			}
+
+			return withPageInfo(MarshalledTextResult(map[string]any{
+				"projects": projects,
+				"pageInfo": map[string]any{
+					"hasNextPage":     page.PageInfo.HasNextPage,
+					"hasPreviousPage": page.PageInfo.HasPreviousPage,
+					"startCursor":     string(page.PageInfo.StartCursor),
+					"endCursor":       string(page.PageInfo.EndCursor),
+				},
+			}), graphQLPageInfo(page.PageInfo.HasNextPage, page.PageInfo.EndCursor, nil)), nil
+		}
+}
+
+// ProjectDetails describes a project returned by get_project.
+type ProjectDetails struct {
+	ProjectSummary
+	ShortDescription string `json:"short_description,omitempty"`
+	Readme           string `json:"readme,omitempty"`
+	ItemCount        int    `json:"item_count"`
+	CreatedAt        string `json:"created_at"`
+	UpdatedAt        string `json:"updated_at"`
+}
+
+type projectDetailsNode struct {
+	projectSummaryNode
+	ShortDescription githubv4.String
+	Readme           githubv4.String
+	CreatedAt        githubv4.DateTime
+	UpdatedAt        githubv4.DateTime
+	Items            struct {
+		TotalCount githubv4.Int
+	}
+}
+
+func (n projectDetailsNode) details() ProjectDetails {
+	return ProjectDetails{
+		ProjectSummary:   n.summary(),
+		ShortDescription: string(n.ShortDescription),
+		Readme:           string(n.Readme),
+		ItemCount:        int(n.Items.TotalCount),
+		CreatedAt:        n.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        n.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// GetProject creates a tool to get a GitHub project by its number or name.
+func GetProject(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_project",
+			mcp.WithDescription(t("TOOL_GET_PROJECT_DESCRIPTION", "Get a GitHub project (Projects V2) by its number, or look it up by its name. If several projects match the name, they are listed so that one can be picked by number.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PROJECT_USER_TITLE", "Get project"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that owns the project"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Description("Project number, as shown in the project's URL. Either this or project_name is required"),
+			),
+			mcp.WithString("project_name",
+				mcp.Description("Project title to look the project up by, matching it exactly or else partially, ignoring case"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := OptionalIntParam(request, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectName, err := OptionalParam[string](request, "project_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (projectNumber == 0) == (projectName == "") {
+				return mcp.NewToolResultError("exactly one of project_number and project_name is required"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			if projectName != "" {
+				matches, err := findProjectByName(ctx, client, owner, projectName)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find project", err), nil
+				}
+				switch len(matches) {
+				case 0:
+					return mcp.NewToolResultError(fmt.Sprintf("no project of %s matches the name %q", owner, projectName)), nil
+				case 1:
+					projectNumber = int(matches[0].Number)
+				default:
+					candidates := make([]ProjectSummary, len(matches))
+					for i, m := range matches {
+						candidates[i] = m.summary()
+					}
+					r, err := json.Marshal(candidates)
+					if err != nil {
+						return nil, fmt.Errorf("failed to marshal candidates: %w", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("%d projects of %s match the name %q, call get_project again with the project_number of one of them: %s", len(matches), owner, projectName, r)), nil
+				}
+			}
+
+			project, err := queryProject[projectDetailsNode](ctx, client, owner, projectNumber, nil)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get project", err), nil
+			}
+			return MarshalledTextResult(project.details()), nil
+		}
+}
+
+// CreateProject creates a tool to create a GitHub project.
+func CreateProject(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_project",
+			mcp.WithDescription(t("TOOL_CREATE_PROJECT_DESCRIPTION", "Create a GitHub project (Projects V2) owned by a user or organization. New projects are private; use update_project to change that.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_PROJECT_USER_TITLE", "Create project"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that will own the project"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Project title"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				RepositoryOwner *struct {
+					ID githubv4.ID
+				} `graphql:"repositoryOwner(login: $owner)"`
+			}
+			if err := client.Query(ctx, &q, map[string]any{"owner": githubv4.String(owner)}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find project owner", err), nil
+			}
+			if q.RepositoryOwner == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not resolve to a user or organization with the login of '%s'", owner)), nil
+			}
+
+			var mutation struct {
+				CreateProjectV2 struct {
+					ProjectV2 projectSummaryNode
+				} `graphql:"createProjectV2(input: $input)"`
+			}
+			input := githubv4.CreateProjectV2Input{
+				OwnerID: q.RepositoryOwner.ID,
+				Title:   githubv4.String(title),
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create project", err), nil
+			}
+
+			return MarshalledTextResult(mutation.CreateProjectV2.ProjectV2.summary()), nil
+		}
+}
+
+// UpdateProject creates a tool to update the settings of a GitHub project.
+func UpdateProject(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_project",
+			mcp.WithDescription(t("TOOL_UPDATE_PROJECT_DESCRIPTION", "Update the title, description, readme or visibility of a GitHub project (Projects V2), or close or reopen it. Only the given settings are changed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_PROJECT_USER_TITLE", "Update project"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that owns the project"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("Project number, as shown in the project's URL"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New project title"),
+			),
+			mcp.WithString("short_description",
+				mcp.Description("New short description"),
+			),
+			mcp.WithString("readme",
+				mcp.Description("New readme, in Markdown"),
+			),
+			mcp.WithBoolean("public",
+				mcp.Description("Make the project public (true) or private (false)"),
+			),
+			mcp.WithBoolean("closed",
+				mcp.Description("Close (true) or reopen (false) the project"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(request, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var input githubv4.UpdateProjectV2Input
+			for name, target := range map[string]**githubv4.String{
+				"title":             &input.Title,
+				"short_description": &input.ShortDescription,
+				"readme":            &input.Readme,
+			} {
+				value, ok, err := OptionalParamOK[string](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*target = githubv4.NewString(githubv4.String(value))
+				}
+			}
+			for name, target := range map[string]**githubv4.Boolean{
+				"public": &input.Public,
+				"closed": &input.Closed,
+			} {
+				value, ok, err := OptionalParamOK[bool](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*target = githubv4.NewBoolean(githubv4.Boolean(value))
+				}
+			}
+			if input.Title == nil && input.ShortDescription == nil && input.Readme == nil && input.Public == nil && input.Closed == nil {
+				return mcp.NewToolResultError("at least one of title, short_description, readme, public or closed must be provided"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			input.ProjectID, err = getProjectID(ctx, client, owner, projectNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find project", err), nil
+			}
+
+			var mutation struct {
+				UpdateProjectV2 struct {
+					ProjectV2 projectSummaryNode
+				} `graphql:"updateProjectV2(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update project", err), nil
+			}
+
+			return MarshalledTextResult(mutation.UpdateProjectV2.ProjectV2.summary()), nil
+		}
+}
+
+// DeleteProject creates a tool to delete a GitHub project.
+func DeleteProject(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_project",
+			mcp.WithDescription(t("TOOL_DELETE_PROJECT_DESCRIPTION", "Delete a GitHub project (Projects V2) and all of its items. Consider closing it with update_project instead, which can be undone.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_PROJECT_USER_TITLE", "Delete project"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that owns the project"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("Project number, as shown in the project's URL"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(request, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			projectID, err := getProjectID(ctx, client, owner, projectNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find project", err), nil
+			}
+
+			var mutation struct {
+				DeleteProjectV2 struct {
+					ProjectV2 struct {
+						ID githubv4.ID
+					}
+				} `graphql:"deleteProjectV2(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.DeleteProjectV2Input{ProjectID: projectID}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to delete project", err), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("deleted project %d of %s", projectNumber, owner)), nil
+		}
+}
+
+type projectFieldMutationNode struct {
+	Common struct {
+		ID       githubv4.ID
+		Name     githubv4.String
+		DataType githubv4.String
+	} `graphql:"... on ProjectV2FieldCommon"`
+	SingleSelect struct {
+		Options []struct {
+			ID          githubv4.String
+			Name        githubv4.String
+			Color       githubv4.String
+			Description githubv4.String
+		}
+	} `graphql:"... on ProjectV2SingleSelectField"`
+}
+
+func (n projectFieldMutationNode) field() ProjectField {
+	return projectFieldFromNode(projectFieldNode{
+		Common:       n.Common,
+		SingleSelect: n.SingleSelect,
+	})
+}
+
+// CreateProjectField creates a tool to add a custom field to a GitHub project.
+func CreateProjectField(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_project_field",
+			mcp.WithDescription(t("TOOL_CREATE_PROJECT_FIELD_DESCRIPTION", "Add a custom field to a GitHub project (Projects V2). Single select fields need at least one option.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_PROJECT_FIELD_USER_TITLE", "Create project field"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that owns the project"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("Project number, as shown in the project's URL"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Field name"),
+			),
+			mcp.WithString("data_type",
+				mcp.Required(),
+				mcp.Description("Field type"),
+				mcp.Enum("TEXT", "NUMBER", "DATE", "SINGLE_SELECT"),
+			),
+			mcp.WithArray("options",
+				mcp.Items(projectOptionsSchema),
+				mcp.Description("Options of a SINGLE_SELECT field"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(request, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dataType, err := RequiredParam[string](request, "data_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			options, err := projectSingleSelectOptions(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if dataType == "SINGLE_SELECT" && len(options) == 0 {
+				return mcp.NewToolResultError("options are required for SINGLE_SELECT fields"), nil
+			}
+			if dataType != "SINGLE_SELECT" && len(options) > 0 {
+				return mcp.NewToolResultError("options can only be set for SINGLE_SELECT fields"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			projectID, err := getProjectID(ctx, client, owner, projectNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find project", err), nil
+			}
+
+			var mutation struct {
+				CreateProjectV2Field struct {
+					ProjectV2Field projectFieldMutationNode
+				} `graphql:"createProjectV2Field(input: $input)"`
+			}
+			input := githubv4.CreateProjectV2FieldInput{
+				ProjectID: projectID,
+				DataType:  githubv4.ProjectV2CustomFieldType(dataType),
+				Name:      githubv4.String(name),
+			}
+			if len(options) > 0 {
+				input.SingleSelectOptions = &options
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create project field", err), nil
+			}
+
+			return MarshalledTextResult(mutation.CreateProjectV2Field.ProjectV2Field.field()), nil
+		}
+}
+
+// UpdateProjectV2FieldInput is the input of the updateProjectV2Field mutation, which
+// shurcooL/githubv4 does not define yet.
+type UpdateProjectV2FieldInput struct {
+	FieldID             githubv4.ID                                       `json:"fieldId"`
+	Name                *githubv4.String                                  `json:"name,omitempty"`
+	SingleSelectOptions *[]githubv4.ProjectV2SingleSelectFieldOptionInput `json:"singleSelectOptions,omitempty"`
+}
+
+// UpdateProjectField creates a tool to rename a project field or replace its single select options.
+func UpdateProjectField(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_project_field",
+			mcp.WithDescription(t("TOOL_UPDATE_PROJECT_FIELD_DESCRIPTION", "Rename a custom field of a GitHub project (Projects V2) or replace the options of a single select field. Replacing options clears the field on items whose option is no longer present, so include every option that should remain. Use list_project_fields to find field IDs.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_PROJECT_FIELD_USER_TITLE", "Update project field"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("field_id",
+				mcp.Required(),
+				mcp.Description("Node ID of the field"),
+			),
+			mcp.WithString("name",
+				mcp.Description("New field name"),
+			),
+			mcp.WithArray("options",
+				mcp.Items(projectOptionsSchema),
+				mcp.Description("The complete new list of options of a SINGLE_SELECT field"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			fieldID, err := RequiredParam[string](request, "field_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			options, err := projectSingleSelectOptions(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if name == "" && len(options) == 0 {
+				return mcp.NewToolResultError("at least one of name or options must be provided"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				UpdateProjectV2Field struct {
+					ProjectV2Field projectFieldMutationNode
+				} `graphql:"updateProjectV2Field(input: $input)"`
+			}
+			input := UpdateProjectV2FieldInput{
+				FieldID: githubv4.ID(fieldID),
+			}
+			if name != "" {
+				input.Name = githubv4.NewString(githubv4.String(name))
+			}
+			if len(options) > 0 {
+				input.SingleSelectOptions = &options
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update project field", err), nil
+			}
+
+			return MarshalledTextResult(mutation.UpdateProjectV2Field.ProjectV2Field.field()), nil
+		}
+}
+
+// DeleteProjectField creates a tool to delete a custom field from a GitHub project.
+func DeleteProjectField(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_project_field",
+			mcp.WithDescription(t("TOOL_DELETE_PROJECT_FIELD_DESCRIPTION", "Delete a custom field, and its values on all items, from a GitHub project (Projects V2). Use list_project_fields to find field IDs.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_PROJECT_FIELD_USER_TITLE", "Delete project field"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("field_id",
+				mcp.Required(),
+				mcp.Description("Node ID of the field"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			fieldID, err := RequiredParam[string](request, "field_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				DeleteProjectV2Field struct {
+					Typename githubv4.String `graphql:"__typename"`
+				} `graphql:"deleteProjectV2Field(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.DeleteProjectV2FieldInput{FieldID: githubv4.ID(fieldID)}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to delete project field", err), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("deleted project field %s", fieldID)), nil
+		}
+}
+
+// UpdateProjectItemField creates a tool to set or clear a field value of a project item.
+func UpdateProjectItemField(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_project_item_field",
+			mcp.WithDescription(t("TOOL_UPDATE_PROJECT_ITEM_FIELD_DESCRIPTION", "Set the value of a field of a GitHub project (Projects V2) item, e.g. move it to another status column or sprint, or clear the value. Provide exactly one of text, number, date, single_select_option_id, iteration_id or clear. Use list_project_fields to find field, option and iteration IDs, and list_project_items to find item IDs.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_PROJECT_ITEM_FIELD_USER_TITLE", "Update project item field"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Login of the user or organization that owns the project"),
+			),
+			mcp.WithNumber("project_number",
+				mcp.Required(),
+				mcp.Description("Project number, as shown in the project's URL"),
+			),
+			mcp.WithString("item_id",
+				mcp.Required(),
+				mcp.Description("Node ID of the project item"),
+			),
+			mcp.WithString("field_id",
+				mcp.Required(),
+				mcp.Description("Node ID of the field"),
+			),
+			mcp.WithString("text",
+				mcp.Description("Value of a text field"),
+			),
+			mcp.WithNumber("number",
+				mcp.Description("Value of a number field"),
+			),
+			mcp.WithString("date",
+				mcp.Description("Value of a date field, as YYYY-MM-DD"),
+			),
+			mcp.WithString("single_select_option_id",
+				mcp.Description("ID of the option to select in a single select field, such as a status column"),
+			),
+			mcp.WithString("iteration_id",
+				mcp.Description("ID of the iteration to set in an iteration field"),
+			),
+			mcp.WithBoolean("clear",
+				mcp.Description("Clear the field's value instead of setting it"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			projectNumber, err := RequiredInt(request, "project_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			itemID, err := RequiredParam[string](request, "item_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fieldID, err := RequiredParam[string](request, "field_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			clearValue, err := OptionalParam[bool](request, "clear")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var value githubv4.ProjectV2FieldValue
+			values := 0
+			if text, ok, err := OptionalParamOK[string](request, "text"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				value.Text = githubv4.NewString(githubv4.String(text))
+				values++
+			}
+			if number, ok, err := OptionalParamOK[float64](request, "number"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				value.Number = githubv4.NewFloat(githubv4.Float(number))
+				values++
+			}
+			if date, ok, err := OptionalParamOK[string](request, "date"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				parsed, err := time.Parse(time.DateOnly, date)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid date %q, must be YYYY-MM-DD", date)), nil
+				}
+				value.Date = githubv4.NewDate(githubv4.Date{Time: parsed})
+				values++
+			}
+			if optionID, ok, err := OptionalParamOK[string](request, "single_select_option_id"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				value.SingleSelectOptionID = githubv4.NewString(githubv4.String(optionID))
+				values++
+			}
+			if iterationID, ok, err := OptionalParamOK[string](request, "iteration_id"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				value.IterationID = githubv4.NewString(githubv4.String(iterationID))
+				values++
+			}
+			if clearValue {
+				values++
+			}
+			if values != 1 {
+				return mcp.NewToolResultError("exactly one of text, number, date, single_select_option_id, iteration_id or clear must be provided"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			projectID, err := getProjectID(ctx, client, owner, projectNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find project", err), nil
+			}
+
+			if clearValue {
+				var mutation struct {
+					ClearProjectV2ItemFieldValue struct {
+						ProjectV2Item struct {
+							ID githubv4.ID
+						}
+					} `graphql:"clearProjectV2ItemFieldValue(input: $input)"`
+				}
+				input := githubv4.ClearProjectV2ItemFieldValueInput{
+					ProjectID: projectID,
+					ItemID:    githubv4.ID(itemID),
+					FieldID:   githubv4.ID(fieldID),
+				}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to clear project item field", err), nil
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("cleared field %s of project item %s", fieldID, itemID)), nil
+			}
+
+			var mutation struct {
+				UpdateProjectV2ItemFieldValue struct {
+					ProjectV2Item struct {
+						ID githubv4.ID
+					}
+				} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+			}
+			input := githubv4.UpdateProjectV2ItemFieldValueInput{
+				ProjectID: projectID,
+				ItemID:    githubv4.ID(itemID),
+				FieldID:   githubv4.ID(fieldID),
+				Value:     value,
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update project item field", err), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("updated field %s of project item %s", fieldID, itemID)), nil
+		}
+}
@@ -0,0 +1,1370 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNullableQueryMatcher matches a query whose variables include non-nil pointers. The pointers
+// make the query declare the variables as nullable, while the request carries their plain values.
+func newNullableQueryMatcher(query any, vars map[string]any, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+	matcher := githubv4mock.NewQueryMatcher(query, vars, response)
+	matcher.Variables = make(map[string]any, len(vars))
+	for k, v := range vars {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+			v = rv.Elem().Interface()
+		}
+		matcher.Variables[k] = v
+	}
+	return matcher
+}
+
+func Test_queryProject(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      githubv4mock.GQLResponse
+		expectedID    string
+		expectedError string
+	}{
+		{
+			name: "project owned by a user or organization",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repositoryOwner": map[string]any{"projectV2": map[string]any{"id": "PVT_5"}},
+			}),
+			expectedID: "PVT_5",
+		},
+		{
+			name: "unknown owner",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repositoryOwner": nil,
+			}),
+			expectedError: "could not resolve to a user or organization with the login of 'octo-org'",
+		},
+		{
+			name:          "unknown project",
+			response:      githubv4mock.ErrorResponse("Could not resolve to a ProjectV2 with the number 5."),
+			expectedError: "Could not resolve to a ProjectV2 with the number 5.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher := githubv4mock.NewQueryMatcher(
+				projectQuery[projectIDNode]{},
+				map[string]any{
+					"owner":  githubv4.String("octo-org"),
+					"number": githubv4.Int(5),
+				},
+				tc.response,
+			)
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+
+			id, err := getProjectID(context.Background(), client, "octo-org", 5)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedID, id)
+		})
+	}
+}
+
+func Test_queryProjectExtraVariables(t *testing.T) {
+	// Extra variables are sent alongside the owner and number without replacing them.
+	matcher := githubv4mock.NewQueryMatcher(
+		projectQuery[projectItems]{},
+		map[string]any{
+			"owner":            githubv4.String("octocat"),
+			"number":           githubv4.Int(1),
+			"fieldValuesFirst": githubv4.Int(1),
+			"first":            (*githubv4.Int)(nil),
+			"last":             (*githubv4.Int)(nil),
+			"after":            (*githubv4.String)(nil),
+			"before":           (*githubv4.String)(nil),
+			"query":            (*githubv4.String)(nil),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repositoryOwner": map[string]any{"projectV2": map[string]any{"items": map[string]any{
+				"nodes":      []map[string]any{{"id": "PVTI_1", "type": "ISSUE"}},
+				"totalCount": 1,
+			}}},
+		}),
+	)
+	client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+
+	items, err := queryProjectItems(context.Background(), client, "octocat", 1, map[string]any{
+		"fieldValuesFirst": githubv4.Int(1),
+		"first":            (*githubv4.Int)(nil),
+		"last":             (*githubv4.Int)(nil),
+		"after":            (*githubv4.String)(nil),
+		"before":           (*githubv4.String)(nil),
+		"query":            (*githubv4.String)(nil),
+	})
+	require.NoError(t, err)
+	require.Len(t, items.Nodes, 1)
+	assert.Equal(t, githubv4.Int(1), items.TotalCount)
+}
+
+// sequentialTransport answers each request with the next of its clients, for tools that send the
+// same query several times with different variables.
+type sequentialTransport []*http.Client
+
+func (s *sequentialTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(*s) == 0 {
+		return nil, fmt.Errorf("unexpected request to %s", req.URL)
+	}
+	client := (*s)[0]
+	*s = (*s)[1:]
+	return client.Transport.RoundTrip(req)
+}
+
+func ownerProjectsMatcher(first int, after, query string, nodes []map[string]any, hasNextPage bool) githubv4mock.Matcher {
+	vars := map[string]any{
+		"owner": githubv4.String("octo-org"),
+		"first": githubv4.Int(first),
+		"after": (*githubv4.String)(nil),
+		"query": (*githubv4.String)(nil),
+	}
+	if after != "" {
+		vars["after"] = githubv4.NewString(githubv4.String(after))
+	}
+	if query != "" {
+		vars["query"] = githubv4.NewString(githubv4.String(query))
+	}
+	return newNullableQueryMatcher(ownerProjectsQuery{}, vars, githubv4mock.DataResponse(map[string]any{
+		"repositoryOwner": map[string]any{"projectsV2": map[string]any{
+			"nodes":    nodes,
+			"pageInfo": map[string]any{"hasNextPage": hasNextPage, "endCursor": "cursor"},
+		}},
+	}))
+}
+
+func Test_ListProjects(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjects(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_projects", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+
+	nodes := []map[string]any{
+		{"id": "PVT_9", "number": 9, "title": "Roadmap 2026", "url": "https://github.com/orgs/octo-org/projects/9", "public": true, "updatedAt": "2026-03-01T00:00:00Z"},
+		{"id": "PVT_3", "number": 3, "title": "Roadmap 2025", "url": "https://github.com/orgs/octo-org/projects/3", "closed": true, "updatedAt": "2025-12-31T00:00:00Z"},
+	}
+
+	tests := []struct {
+		name        string
+		requestArgs map[string]any
+		matcher     githubv4mock.Matcher
+	}{
+		{
+			name:        "first page",
+			requestArgs: map[string]any{"owner": "octo-org"},
+			matcher:     ownerProjectsMatcher(30, "", "", nodes, true),
+		},
+		{
+			name:        "next page of a search",
+			requestArgs: map[string]any{"owner": "octo-org", "query": "roadmap", "perPage": float64(2), "after": "Y3Vyc29yOjI="},
+			matcher:     ownerProjectsMatcher(2, "Y3Vyc29yOjI=", "roadmap", nodes, true),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matcher))
+			_, handler := ListProjects(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var response struct {
+				Projects []ProjectListing
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, []ProjectListing{
+				{
+					ProjectSummary: ProjectSummary{ID: "PVT_9", Number: 9, Title: "Roadmap 2026", URL: "https://github.com/orgs/octo-org/projects/9", Public: true},
+					UpdatedAt:      "2026-03-01T00:00:00Z",
+				},
+				{
+					ProjectSummary: ProjectSummary{ID: "PVT_3", Number: 3, Title: "Roadmap 2025", URL: "https://github.com/orgs/octo-org/projects/3", Closed: true},
+					UpdatedAt:      "2025-12-31T00:00:00Z",
+				},
+			}, response.Projects)
+			assert.True(t, response.PageInfo.HasNextPage)
+			assert.Equal(t, "cursor", response.PageInfo.EndCursor)
+		})
+	}
+
+	t.Run("perPage over the maximum", func(t *testing.T) {
+		_, handler := ListProjects(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org", "perPage": float64(101)}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "perPage value 101 exceeds maximum of 100")
+	})
+}
+
+func Test_GetProject(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetProject(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_project", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	roadmap := map[string]any{"id": "PVT_7", "number": 7, "title": "Roadmap", "url": "https://github.com/orgs/octo-org/projects/7"}
+	roadmap2025 := map[string]any{"id": "PVT_3", "number": 3, "title": "Roadmap 2025", "url": "https://github.com/orgs/octo-org/projects/3"}
+	roadmap2026 := map[string]any{"id": "PVT_9", "number": 9, "title": "Roadmap 2026", "url": "https://github.com/orgs/octo-org/projects/9"}
+	detailsMatcher := githubv4mock.NewQueryMatcher(
+		projectQuery[projectDetailsNode]{},
+		map[string]any{
+			"owner":  githubv4.String("octo-org"),
+			"number": githubv4.Int(7),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repositoryOwner": map[string]any{"projectV2": map[string]any{
+				"id": "PVT_7", "number": 7, "title": "Roadmap", "url": "https://github.com/orgs/octo-org/projects/7",
+				"shortDescription": "What we ship next", "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-06-01T00:00:00Z",
+				"items": map[string]any{"totalCount": 12},
+			}},
+		}),
+	)
+	expected := ProjectDetails{
+		ProjectSummary:   ProjectSummary{ID: "PVT_7", Number: 7, Title: "Roadmap", URL: "https://github.com/orgs/octo-org/projects/7"},
+		ShortDescription: "What we ship next",
+		ItemCount:        12,
+		CreatedAt:        "2024-01-01T00:00:00Z",
+		UpdatedAt:        "2024-06-01T00:00:00Z",
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		responses      []githubv4mock.Matcher
+		expectedResult *ProjectDetails
+		expectedErrMsg string
+	}{
+		{
+			name:           "by number",
+			requestArgs:    map[string]any{"owner": "octo-org", "project_number": float64(7)},
+			responses:      []githubv4mock.Matcher{detailsMatcher},
+			expectedResult: &expected,
+		},
+		{
+			name:        "exact name match preferred over partial ones",
+			requestArgs: map[string]any{"owner": "octo-org", "project_name": "roadmap"},
+			responses: []githubv4mock.Matcher{
+				ownerProjectsMatcher(projectSearchResults, "", "roadmap", []map[string]any{roadmap2025, roadmap, roadmap2026}, false),
+				detailsMatcher,
+			},
+			expectedResult: &expected,
+		},
+		{
+			name:        "ambiguous name",
+			requestArgs: map[string]any{"owner": "octo-org", "project_name": "Roadmap 20"},
+			responses: []githubv4mock.Matcher{
+				ownerProjectsMatcher(projectSearchResults, "", "Roadmap 20", []map[string]any{roadmap2026, roadmap, roadmap2025}, false),
+			},
+			expectedErrMsg: `2 projects of octo-org match the name "Roadmap 20", call get_project again with the project_number of one of them: [{"id":"PVT_9","number":9,"title":"Roadmap 2026","url":"https://github.com/orgs/octo-org/projects/9","closed":false,"public":false},{"id":"PVT_3"`,
+		},
+		{
+			name:        "search finds nothing, so projects are scanned",
+			requestArgs: map[string]any{"owner": "octo-org", "project_name": "Road"},
+			responses: []githubv4mock.Matcher{
+				ownerProjectsMatcher(projectSearchResults, "", "Road", nil, false),
+				ownerProjectsMatcher(100, "", "", []map[string]any{{"id": "PVT_1", "number": 1, "title": "Backlog"}}, true),
+				ownerProjectsMatcher(100, "cursor", "", []map[string]any{roadmap}, false),
+				detailsMatcher,
+			},
+			expectedResult: &expected,
+		},
+		{
+			name:        "no match",
+			requestArgs: map[string]any{"owner": "octo-org", "project_name": "Launch"},
+			responses: []githubv4mock.Matcher{
+				ownerProjectsMatcher(projectSearchResults, "", "Launch", nil, false),
+				ownerProjectsMatcher(100, "", "", []map[string]any{roadmap}, false),
+			},
+			expectedErrMsg: `no project of octo-org matches the name "Launch"`,
+		},
+		{
+			name:           "neither number nor name",
+			requestArgs:    map[string]any{"owner": "octo-org"},
+			expectedErrMsg: "exactly one of project_number and project_name is required",
+		},
+		{
+			name:           "both number and name",
+			requestArgs:    map[string]any{"owner": "octo-org", "project_number": float64(7), "project_name": "Roadmap"},
+			expectedErrMsg: "exactly one of project_number and project_name is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := make(sequentialTransport, len(tc.responses))
+			for i, m := range tc.responses {
+				transport[i] = githubv4mock.NewMockedHTTPClient(m)
+			}
+			gqlClient := githubv4.NewClient(&http.Client{Transport: &transport})
+			_, handler := GetProject(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			assert.Empty(t, transport, "all queries are sent")
+
+			var project ProjectDetails
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &project))
+			assert.Equal(t, *tc.expectedResult, project)
+		})
+	}
+}
+
+func Test_ListProjectItems(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjectItems(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_project_items", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "before")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	itemsResponse := map[string]any{
+		"nodes": []map[string]any{
+			{
+				"id":         "PVTI_1",
+				"type":       "ISSUE",
+				"isArchived": false,
+				"content": map[string]any{
+					"__typename": "Issue",
+					"number":     12,
+					"title":      "Crash on start",
+					"state":      "OPEN",
+					"url":        "https://github.com/octo-org/app/issues/12",
+					"repository": map[string]any{"nameWithOwner": "octo-org/app"},
+				},
+				"fieldValues": map[string]any{
+					"nodes": []map[string]any{
+						{
+							"__typename": "ProjectV2ItemFieldSingleSelectValue",
+							"name":       "In Progress",
+							"optionId":   "opt_2",
+							"field":      map[string]any{"name": "Status"},
+						},
+						{
+							"__typename": "ProjectV2ItemFieldNumberValue",
+							"number":     3,
+							"field":      map[string]any{"name": "Estimate"},
+						},
+						{
+							"__typename": "ProjectV2ItemFieldUserValue",
+							"users":      map[string]any{"nodes": []map[string]any{{"login": "octocat"}}},
+							"field":      map[string]any{"name": "Assignees"},
+						},
+					},
+				},
+			},
+			{
+				"id":      "PVTI_2",
+				"type":    "DRAFT_ISSUE",
+				"content": map[string]any{"__typename": "DraftIssue", "title": "Write docs"},
+				"fieldValues": map[string]any{
+					"nodes": []map[string]any{},
+				},
+			},
+		},
+		"pageInfo": map[string]any{
+			"hasNextPage":     true,
+			"hasPreviousPage": false,
+			"startCursor":     "Y3Vyc29yOjE=",
+			"endCursor":       "Y3Vyc29yOjI=",
+		},
+		"totalCount": 150,
+	}
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]any
+		matcher       githubv4mock.Matcher
+		expectError   bool
+		expectedError string
+	}{
+		{
+			name: "organization project with query",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(5),
+				"query":          `status:"In Progress"`,
+				"perPage":        float64(2),
+			},
+			matcher: newNullableQueryMatcher(
+				projectQuery[projectItems]{},
+				map[string]any{
+					"owner":            githubv4.String("octo-org"),
+					"number":           githubv4.Int(5),
+					"fieldValuesFirst": githubv4.Int(20),
+					"first":            githubv4.NewInt(2),
+					"last":             (*githubv4.Int)(nil),
+					"after":            (*githubv4.String)(nil),
+					"before":           (*githubv4.String)(nil),
+					"query":            githubv4.NewString(`status:"In Progress"`),
+				},
+				githubv4mock.DataResponse(map[string]any{
+					"repositoryOwner": map[string]any{"projectV2": map[string]any{"items": itemsResponse}},
+				}),
+			),
+		},
+		{
+			name: "user project paging backwards",
+			requestArgs: map[string]any{
+				"owner":              "octocat",
+				"project_number":     float64(1),
+				"before":             "Y3Vyc29yOjM=",
+				"field_values_limit": float64(5),
+			},
+			matcher: newNullableQueryMatcher(
+				projectQuery[projectItems]{},
+				map[string]any{
+					"owner":            githubv4.String("octocat"),
+					"number":           githubv4.Int(1),
+					"fieldValuesFirst": githubv4.Int(5),
+					"first":            (*githubv4.Int)(nil),
+					"last":             githubv4.NewInt(30),
+					"after":            (*githubv4.String)(nil),
+					"before":           githubv4.NewString("Y3Vyc29yOjM="),
+					"query":            (*githubv4.String)(nil),
+				},
+				githubv4mock.DataResponse(map[string]any{
+					"repositoryOwner": map[string]any{"projectV2": map[string]any{"items": itemsResponse}},
+				}),
+			),
+		},
+		{
+			name: "project not found",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(99),
+			},
+			matcher: newNullableQueryMatcher(
+				projectQuery[projectItems]{},
+				map[string]any{
+					"owner":            githubv4.String("octo-org"),
+					"number":           githubv4.Int(99),
+					"fieldValuesFirst": githubv4.Int(20),
+					"first":            githubv4.NewInt(30),
+					"last":             (*githubv4.Int)(nil),
+					"after":            (*githubv4.String)(nil),
+					"before":           (*githubv4.String)(nil),
+					"query":            (*githubv4.String)(nil),
+				},
+				githubv4mock.ErrorResponse("Could not resolve to a ProjectV2 with the number 99."),
+			),
+			expectError:   true,
+			expectedError: "failed to list project items: Could not resolve to a ProjectV2 with the number 99.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matcher))
+			_, handler := ListProjectItems(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedError)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Items    []ProjectItem `json:"items"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				TotalCount int `json:"totalCount"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response.Items, 2)
+			assert.True(t, response.PageInfo.HasNextPage)
+			assert.Equal(t, "Y3Vyc29yOjI=", response.PageInfo.EndCursor)
+			assert.Equal(t, 150, response.TotalCount)
+
+			issue := response.Items[0]
+			assert.Equal(t, "PVTI_1", issue.ID)
+			assert.Equal(t, &ProjectItemContent{
+				Type:       "Issue",
+				Number:     12,
+				Title:      "Crash on start",
+				State:      "OPEN",
+				URL:        "https://github.com/octo-org/app/issues/12",
+				Repository: "octo-org/app",
+			}, issue.Content)
+			assert.Equal(t, map[string]any{
+				"Status":    map[string]any{"name": "In Progress", "option_id": "opt_2"},
+				"Estimate":  float64(3),
+				"Assignees": []any{"octocat"},
+			}, issue.Fields)
+
+			draft := response.Items[1]
+			assert.Equal(t, "DraftIssue", draft.Content.Type)
+			assert.Equal(t, "Write docs", draft.Content.Title)
+			assert.Empty(t, draft.Fields)
+		})
+	}
+}
+
+func Test_ListProjectFields(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjectFields(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_project_fields", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	matcher := githubv4mock.NewQueryMatcher(
+		projectQuery[projectFields]{},
+		map[string]any{
+			"owner":  githubv4.String("octocat"),
+			"number": githubv4.Int(1),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repositoryOwner": map[string]any{"projectV2": map[string]any{"fields": map[string]any{
+				"nodes": []map[string]any{
+					{"id": "PVTF_title", "name": "Title", "dataType": "TITLE"},
+					{
+						"id": "PVTSSF_status", "name": "Status", "dataType": "SINGLE_SELECT",
+						"options": []map[string]any{
+							{"id": "opt_1", "name": "Todo", "color": "GRAY", "description": ""},
+							{"id": "opt_2", "name": "Done", "color": "GREEN", "description": "Shipped"},
+						},
+					},
+					{
+						"id": "PVTIF_sprint", "name": "Sprint", "dataType": "ITERATION",
+						"configuration": map[string]any{
+							"iterations":          []map[string]any{{"id": "it_2", "title": "Sprint 2", "startDate": "2024-06-15", "duration": 14}},
+							"completedIterations": []map[string]any{{"id": "it_1", "title": "Sprint 1", "startDate": "2024-06-01", "duration": 14}},
+						},
+					},
+				},
+			}}},
+		}),
+	)
+
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+	_, handler := ListProjectFields(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "octocat",
+		"project_number": float64(1),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var fields []ProjectField
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &fields))
+	assert.Equal(t, []ProjectField{
+		{ID: "PVTF_title", Name: "Title", DataType: "TITLE"},
+		{
+			ID: "PVTSSF_status", Name: "Status", DataType: "SINGLE_SELECT",
+			Options: []ProjectFieldOption{
+				{ID: "opt_1", Name: "Todo", Color: "GRAY"},
+				{ID: "opt_2", Name: "Done", Color: "GREEN", Description: "Shipped"},
+			},
+		},
+		{
+			ID: "PVTIF_sprint", Name: "Sprint", DataType: "ITERATION",
+			Iterations: []ProjectIteration{
+				{ID: "it_2", Title: "Sprint 2", StartDate: "2024-06-15", Duration: 14},
+				{ID: "it_1", Title: "Sprint 1", StartDate: "2024-06-01", Duration: 14, Completed: true},
+			},
+		},
+	}, fields)
+}
+
+func Test_GetProjectBoard(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetProjectBoard(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_project_board", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "group_by")
+	assert.Contains(t, tool.InputSchema.Properties, "items_per_column")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	fieldsMatcher := githubv4mock.NewQueryMatcher(
+		projectQuery[projectFields]{},
+		map[string]any{
+			"owner":  githubv4.String("octo-org"),
+			"number": githubv4.Int(5),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repositoryOwner": map[string]any{"projectV2": map[string]any{"fields": map[string]any{
+				"nodes": []map[string]any{
+					{"id": "PVTF_title", "name": "Title", "dataType": "TITLE"},
+					{
+						"id": "PVTSSF_status", "name": "Status", "dataType": "SINGLE_SELECT",
+						"options": []map[string]any{
+							{"id": "opt_1", "name": "Todo", "color": "GRAY", "description": ""},
+							{"id": "opt_2", "name": "In Progress", "color": "YELLOW", "description": ""},
+							{"id": "opt_3", "name": "Done", "color": "GREEN", "description": ""},
+						},
+					},
+				},
+			}}},
+		}),
+	)
+
+	status := func(name, optionID string) map[string]any {
+		return map[string]any{
+			"__typename": "ProjectV2ItemFieldSingleSelectValue",
+			"name":       name,
+			"optionId":   optionID,
+			"field":      map[string]any{"name": "Status"},
+		}
+	}
+	issue := func(number int, title string) map[string]any {
+		return map[string]any{
+			"__typename": "Issue",
+			"number":     number,
+			"title":      title,
+			"state":      "OPEN",
+			"url":        "https://github.com/octo-org/app/issues/1",
+			"repository": map[string]any{"nameWithOwner": "octo-org/app"},
+		}
+	}
+	itemsResponse := map[string]any{
+		"nodes": []map[string]any{
+			{
+				"id": "PVTI_1", "type": "ISSUE", "content": issue(1, "Crash on start"),
+				"fieldValues": map[string]any{"nodes": []map[string]any{
+					status("In Progress", "opt_2"),
+					{
+						"__typename": "ProjectV2ItemFieldUserValue",
+						"users":      map[string]any{"nodes": []map[string]any{{"login": "octocat"}}},
+						"field":      map[string]any{"name": "Assignees"},
+					},
+				}},
+			},
+			{
+				"id": "PVTI_2", "type": "ISSUE", "content": issue(2, "Slow search"),
+				"fieldValues": map[string]any{"nodes": []map[string]any{status("In Progress", "opt_2")}},
+			},
+			{
+				"id": "PVTI_3", "type": "ISSUE", "content": issue(3, "Old bug"), "isArchived": true,
+				"fieldValues": map[string]any{"nodes": []map[string]any{status("Done", "opt_3")}},
+			},
+			{
+				"id": "PVTI_4", "type": "DRAFT_ISSUE", "content": map[string]any{"__typename": "DraftIssue", "title": "Write docs"},
+				"fieldValues": map[string]any{"nodes": []map[string]any{}},
+			},
+		},
+		"pageInfo": map[string]any{
+			"hasNextPage":     true,
+			"hasPreviousPage": false,
+			"startCursor":     "Y3Vyc29yOjE=",
+			"endCursor":       "Y3Vyc29yOjQ=",
+		},
+		"totalCount": 10,
+	}
+	itemsMatcher := newNullableQueryMatcher(
+		projectQuery[projectItems]{},
+		map[string]any{
+			"owner":            githubv4.String("octo-org"),
+			"number":           githubv4.Int(5),
+			"fieldValuesFirst": githubv4.Int(100),
+			"first":            githubv4.NewInt(4),
+			"last":             (*githubv4.Int)(nil),
+			"after":            (*githubv4.String)(nil),
+			"before":           (*githubv4.String)(nil),
+			"query":            githubv4.NewString("is:issue,draft"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repositoryOwner": map[string]any{"projectV2": map[string]any{"items": itemsResponse}},
+		}),
+	)
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]any
+		expectError   bool
+		expectedError string
+		expectedBoard ProjectBoard
+	}{
+		{
+			name: "groups items by status",
+			requestArgs: map[string]any{
+				"owner":            "octo-org",
+				"project_number":   float64(5),
+				"query":            "is:issue,draft",
+				"items_per_column": float64(1),
+				"max_items":        float64(4),
+			},
+			expectedBoard: ProjectBoard{
+				Field: "Status",
+				Columns: []ProjectBoardColumn{
+					{OptionID: "opt_1", Name: "Todo", Items: []ProjectBoardCard{}},
+					{
+						OptionID: "opt_2", Name: "In Progress", Count: 2,
+						Items: []ProjectBoardCard{{
+							ID: "PVTI_1", Type: "ISSUE", Number: 1, Title: "Crash on start", State: "OPEN",
+							Repository: "octo-org/app", Assignees: []string{"octocat"},
+						}},
+					},
+					{OptionID: "opt_3", Name: "Done", Items: []ProjectBoardCard{}},
+					{Name: "No Status", Count: 1, Items: []ProjectBoardCard{{ID: "PVTI_4", Type: "DRAFT_ISSUE", Title: "Write docs"}}},
+				},
+				TotalItems: 3,
+				Truncated:  true,
+			},
+		},
+		{
+			name: "unknown group_by field",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(5),
+				"group_by":       "Priority",
+			},
+			expectError:   true,
+			expectedError: `project has no single select field named "Priority", available fields: Status`,
+		},
+		{
+			name: "max_items out of range",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(5),
+				"max_items":      float64(5000),
+			},
+			expectError:   true,
+			expectedError: "max_items must be between 1 and 1000",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(fieldsMatcher, itemsMatcher))
+			_, handler := GetProjectBoard(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedError)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var board ProjectBoard
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &board))
+			assert.Equal(t, tc.expectedBoard, board)
+		})
+	}
+}
+
+// projectIDMatcher matches the lookup of the ID of project number 5 of the octo-org organization.
+func projectIDMatcher() githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		projectQuery[projectIDNode]{},
+		map[string]any{
+			"owner":  githubv4.String("octo-org"),
+			"number": githubv4.Int(5),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repositoryOwner": map[string]any{"projectV2": map[string]any{"id": "PVT_5"}},
+		}),
+	)
+}
+
+var projectSummaryResponse = map[string]any{
+	"id":     "PVT_5",
+	"number": 5,
+	"title":  "Roadmap",
+	"url":    "https://github.com/orgs/octo-org/projects/5",
+	"closed": false,
+	"public": true,
+}
+
+func Test_CreateProject(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := CreateProject(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_project", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "title"})
+
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				RepositoryOwner *struct {
+					ID githubv4.ID
+				} `graphql:"repositoryOwner(login: $owner)"`
+			}{},
+			map[string]any{"owner": githubv4.String("octo-org")},
+			githubv4mock.DataResponse(map[string]any{
+				"repositoryOwner": map[string]any{"id": "O_1"},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				CreateProjectV2 struct {
+					ProjectV2 projectSummaryNode
+				} `graphql:"createProjectV2(input: $input)"`
+			}{},
+			githubv4.CreateProjectV2Input{
+				OwnerID: githubv4.ID("O_1"),
+				Title:   githubv4.String("Roadmap"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"createProjectV2": map[string]any{"projectV2": projectSummaryResponse},
+			}),
+		),
+	)
+
+	gqlClient := githubv4.NewClient(httpClient)
+	_, handler := CreateProject(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "octo-org",
+		"title": "Roadmap",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var project ProjectSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &project))
+	assert.Equal(t, ProjectSummary{
+		ID:     "PVT_5",
+		Number: 5,
+		Title:  "Roadmap",
+		URL:    "https://github.com/orgs/octo-org/projects/5",
+		Public: true,
+	}, project)
+}
+
+func Test_UpdateProject(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := UpdateProject(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_project", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number"})
+
+	mutation := struct {
+		UpdateProjectV2 struct {
+			ProjectV2 projectSummaryNode
+		} `graphql:"updateProjectV2(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]any
+		matchers      []githubv4mock.Matcher
+		expectError   bool
+		expectedError string
+	}{
+		{
+			name: "updates only the given settings",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(5),
+				"readme":         "# Roadmap",
+				"public":         true,
+				"closed":         false,
+			},
+			matchers: []githubv4mock.Matcher{
+				projectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					mutation,
+					githubv4.UpdateProjectV2Input{
+						ProjectID: githubv4.ID("PVT_5"),
+						Readme:    githubv4.NewString("# Roadmap"),
+						Public:    githubv4.NewBoolean(true),
+						Closed:    githubv4.NewBoolean(false),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"updateProjectV2": map[string]any{"projectV2": projectSummaryResponse},
+					}),
+				),
+			},
+		},
+		{
+			name: "nothing to update",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(5),
+			},
+			expectError:   true,
+			expectedError: "at least one of title, short_description, readme, public or closed must be provided",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))
+			_, handler := UpdateProject(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedError)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var project ProjectSummary
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &project))
+			assert.Equal(t, "PVT_5", project.ID)
+			assert.True(t, project.Public)
+		})
+	}
+}
+
+func Test_DeleteProject(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := DeleteProject(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_project", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		projectIDMatcher(),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				DeleteProjectV2 struct {
+					ProjectV2 struct {
+						ID githubv4.ID
+					}
+				} `graphql:"deleteProjectV2(input: $input)"`
+			}{},
+			githubv4.DeleteProjectV2Input{ProjectID: githubv4.ID("PVT_5")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"deleteProjectV2": map[string]any{"projectV2": map[string]any{"id": "PVT_5"}},
+			}),
+		),
+	)
+
+	gqlClient := githubv4.NewClient(httpClient)
+	_, handler := DeleteProject(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "octo-org",
+		"project_number": float64(5),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "deleted project 5 of octo-org", getTextResult(t, result).Text)
+}
+
+func Test_CreateProjectField(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := CreateProjectField(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_project_field", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number", "name", "data_type"})
+
+	mutation := struct {
+		CreateProjectV2Field struct {
+			ProjectV2Field projectFieldMutationNode
+		} `graphql:"createProjectV2Field(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name          string
+		requestArgs   map[string]any
+		matchers      []githubv4mock.Matcher
+		expectError   bool
+		expectedError string
+		expectedField ProjectField
+	}{
+		{
+			name: "single select field",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(5),
+				"name":           "Priority",
+				"data_type":      "SINGLE_SELECT",
+				"options": []any{
+					map[string]any{"name": "High", "color": "RED"},
+					map[string]any{"name": "Low", "description": "Some day"},
+				},
+			},
+			matchers: []githubv4mock.Matcher{
+				projectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					mutation,
+					githubv4.CreateProjectV2FieldInput{
+						ProjectID: githubv4.ID("PVT_5"),
+						DataType:  githubv4.ProjectV2CustomFieldType("SINGLE_SELECT"),
+						Name:      githubv4.String("Priority"),
+						SingleSelectOptions: &[]githubv4.ProjectV2SingleSelectFieldOptionInput{
+							{Name: "High", Color: "RED", Description: ""},
+							{Name: "Low", Color: "GRAY", Description: "Some day"},
+						},
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"createProjectV2Field": map[string]any{"projectV2Field": map[string]any{
+							"id":       "PVTSSF_priority",
+							"name":     "Priority",
+							"dataType": "SINGLE_SELECT",
+							"options": []map[string]any{
+								{"id": "opt_h", "name": "High", "color": "RED", "description": ""},
+								{"id": "opt_l", "name": "Low", "color": "GRAY", "description": "Some day"},
+							},
+						}},
+					}),
+				),
+			},
+			expectedField: ProjectField{
+				ID: "PVTSSF_priority", Name: "Priority", DataType: "SINGLE_SELECT",
+				Options: []ProjectFieldOption{
+					{ID: "opt_h", Name: "High", Color: "RED"},
+					{ID: "opt_l", Name: "Low", Color: "GRAY", Description: "Some day"},
+				},
+			},
+		},
+		{
+			name: "single select field without options",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(5),
+				"name":           "Priority",
+				"data_type":      "SINGLE_SELECT",
+			},
+			expectError:   true,
+			expectedError: "options are required for SINGLE_SELECT fields",
+		},
+		{
+			name: "options on a text field",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"project_number": float64(5),
+				"name":           "Notes",
+				"data_type":      "TEXT",
+				"options":        []any{map[string]any{"name": "High"}},
+			},
+			expectError:   true,
+			expectedError: "options can only be set for SINGLE_SELECT fields",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))
+			_, handler := CreateProjectField(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedError)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var field ProjectField
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &field))
+			assert.Equal(t, tc.expectedField, field)
+		})
+	}
+}
+
+func Test_UpdateProjectField(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := UpdateProjectField(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_project_field", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"field_id"})
+
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UpdateProjectV2Field struct {
+					ProjectV2Field projectFieldMutationNode
+				} `graphql:"updateProjectV2Field(input: $input)"`
+			}{},
+			UpdateProjectV2FieldInput{
+				FieldID: githubv4.ID("PVTSSF_priority"),
+				Name:    githubv4.NewString("Urgency"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"updateProjectV2Field": map[string]any{"projectV2Field": map[string]any{
+					"id":       "PVTSSF_priority",
+					"name":     "Urgency",
+					"dataType": "SINGLE_SELECT",
+				}},
+			}),
+		),
+	)
+
+	gqlClient := githubv4.NewClient(httpClient)
+	_, handler := UpdateProjectField(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"field_id": "PVTSSF_priority",
+		"name":     "Urgency",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var field ProjectField
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &field))
+	assert.Equal(t, "Urgency", field.Name)
+
+	// Nothing to update
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{
+		"field_id": "PVTSSF_priority",
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getErrorResult(t, result).Text, "at least one of name or options must be provided")
+}
+
+func Test_DeleteProjectField(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := DeleteProjectField(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_project_field", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(
+			struct {
+				DeleteProjectV2Field struct {
+					Typename githubv4.String `graphql:"__typename"`
+				} `graphql:"deleteProjectV2Field(input: $input)"`
+			}{},
+			githubv4.DeleteProjectV2FieldInput{FieldID: githubv4.ID("PVTF_notes")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"deleteProjectV2Field": map[string]any{"__typename": "DeleteProjectV2FieldPayload"},
+			}),
+		),
+	)
+
+	gqlClient := githubv4.NewClient(httpClient)
+	_, handler := DeleteProjectField(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"field_id": "PVTF_notes",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "deleted project field PVTF_notes", getTextResult(t, result).Text)
+}
+
+func Test_UpdateProjectItemField(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := UpdateProjectItemField(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_project_item_field", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "project_number", "item_id", "field_id"})
+
+	updateMutation := struct {
+		UpdateProjectV2ItemFieldValue struct {
+			ProjectV2Item struct {
+				ID githubv4.ID
+			}
+		} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+	}{}
+	updateMatcher := func(value githubv4.ProjectV2FieldValue) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			updateMutation,
+			githubv4.UpdateProjectV2ItemFieldValueInput{
+				ProjectID: githubv4.ID("PVT_5"),
+				ItemID:    githubv4.ID("PVTI_1"),
+				FieldID:   githubv4.ID("PVTF_1"),
+				Value:     value,
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"updateProjectV2ItemFieldValue": map[string]any{"projectV2Item": map[string]any{"id": "PVTI_1"}},
+			}),
+		)
+	}
+	args := func(extra map[string]any) map[string]any {
+		a := map[string]any{
+			"owner":          "octo-org",
+			"project_number": float64(5),
+			"item_id":        "PVTI_1",
+			"field_id":       "PVTF_1",
+		}
+		for k, v := range extra {
+			a[k] = v
+		}
+		return a
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		matchers       []githubv4mock.Matcher
+		expectError    bool
+		expectedResult string
+	}{
+		{
+			name:        "single select option",
+			requestArgs: args(map[string]any{"single_select_option_id": "opt_2"}),
+			matchers: []githubv4mock.Matcher{
+				projectIDMatcher(),
+				updateMatcher(githubv4.ProjectV2FieldValue{SingleSelectOptionID: githubv4.NewString("opt_2")}),
+			},
+			expectedResult: "updated field PVTF_1 of project item PVTI_1",
+		},
+		{
+			name:        "iteration",
+			requestArgs: args(map[string]any{"iteration_id": "it_2"}),
+			matchers: []githubv4mock.Matcher{
+				projectIDMatcher(),
+				updateMatcher(githubv4.ProjectV2FieldValue{IterationID: githubv4.NewString("it_2")}),
+			},
+			expectedResult: "updated field PVTF_1 of project item PVTI_1",
+		},
+		{
+			name:        "number",
+			requestArgs: args(map[string]any{"number": float64(3)}),
+			matchers: []githubv4mock.Matcher{
+				projectIDMatcher(),
+				updateMatcher(githubv4.ProjectV2FieldValue{Number: githubv4.NewFloat(3)}),
+			},
+			expectedResult: "updated field PVTF_1 of project item PVTI_1",
+		},
+		{
+			name:        "date",
+			requestArgs: args(map[string]any{"date": "2024-06-01"}),
+			matchers: []githubv4mock.Matcher{
+				projectIDMatcher(),
+				updateMatcher(githubv4.ProjectV2FieldValue{Date: githubv4.NewDate(githubv4.Date{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)})}),
+			},
+			expectedResult: "updated field PVTF_1 of project item PVTI_1",
+		},
+		{
+			name:        "clear",
+			requestArgs: args(map[string]any{"clear": true}),
+			matchers: []githubv4mock.Matcher{
+				projectIDMatcher(),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						ClearProjectV2ItemFieldValue struct {
+							ProjectV2Item struct {
+								ID githubv4.ID
+							}
+						} `graphql:"clearProjectV2ItemFieldValue(input: $input)"`
+					}{},
+					githubv4.ClearProjectV2ItemFieldValueInput{
+						ProjectID: githubv4.ID("PVT_5"),
+						ItemID:    githubv4.ID("PVTI_1"),
+						FieldID:   githubv4.ID("PVTF_1"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"clearProjectV2ItemFieldValue": map[string]any{"projectV2Item": map[string]any{"id": "PVTI_1"}},
+					}),
+				),
+			},
+			expectedResult: "cleared field PVTF_1 of project item PVTI_1",
+		},
+		{
+			name:           "invalid date",
+			requestArgs:    args(map[string]any{"date": "June 1st"}),
+			expectError:    true,
+			expectedResult: `invalid date "June 1st", must be YYYY-MM-DD`,
+		},
+		{
+			name:           "no value",
+			requestArgs:    args(nil),
+			expectError:    true,
+			expectedResult: "exactly one of text, number, date, single_select_option_id, iteration_id or clear must be provided",
+		},
+		{
+			name:           "several values",
+			requestArgs:    args(map[string]any{"text": "done", "clear": true}),
+			expectError:    true,
+			expectedResult: "exactly one of text, number, date, single_select_option_id, iteration_id or clear must be provided",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))
+			_, handler := UpdateProjectItemField(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedResult)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedResult, getTextResult(t, result).Text)
+		})
+	}
+}
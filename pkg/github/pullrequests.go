@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v74/github"
@@ -25,6 +26,7 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_GET_PULL_REQUEST_USER_TITLE", "Get pull request details"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			mcp.WithOutputSchema[pullRequestOutput](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -37,6 +39,7 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithRawMediaFormat("Format to return the pull request in. 'diff' and 'patch' return the raw diff or its commits as email patches.", "diff", "patch"),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -51,11 +54,30 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			format, raw, err := optionalRawMediaFormat(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+
+			if raw {
+				content, resp, err := getRawMedia(ctx, client, fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, pullNumber), format)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get pull request %s", format),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return rawMediaResult(fmt.Sprintf("repo://%s/%s/pulls/%d.%s", owner, repo, pullNumber, format), format, content), nil
+			}
+
 			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -581,14 +603,14 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
 		}
 }
 
 // MergePullRequest creates a tool to merge a pull request.
-func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+func MergePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("merge_pull_request",
-			mcp.WithDescription(t("TOOL_MERGE_PULL_REQUEST_DESCRIPTION", "Merge a pull request in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_MERGE_PULL_REQUEST_DESCRIPTION", "Merge a pull request in a GitHub repository, or enable auto-merge so that it is merged once its requirements are met.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_MERGE_PULL_REQUEST_USER_TITLE", "Merge pull request"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -615,6 +637,12 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description("Merge method"),
 				mcp.Enum("merge", "squash", "rebase"),
 			),
+			mcp.WithString("sha",
+				mcp.Description("SHA the head of the pull request must still be at for the merge to happen, to avoid merging commits that were pushed after it was reviewed"),
+			),
+			mcp.WithBoolean("enable_auto_merge",
+				mcp.Description("Enable auto-merge instead of merging now, so that the pull request is merged once its required reviews and checks pass. Auto-merge must be allowed in the repository"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -641,10 +669,27 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enableAutoMerge, err := OptionalParam[bool](request, "enable_auto_merge")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if enableAutoMerge {
+				gqlClient, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+				}
+				return enablePullRequestAutoMerge(ctx, gqlClient, owner, repo, pullNumber, mergeMethod, commitTitle, commitMessage, sha)
+			}
 
 			options := &github.PullRequestOptions{
 				CommitTitle: commitTitle,
 				MergeMethod: mergeMethod,
+				SHA:         sha,
 			}
 
 			client, err := getClient(ctx)
@@ -678,6 +723,70 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// enablePullRequestAutoMerge enables auto-merge on a pull request.
+func enablePullRequestAutoMerge(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int, mergeMethod, commitTitle, commitMessage, sha string) (*mcp.CallToolResult, error) {
+	var prQuery struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	err := client.Query(ctx, &prQuery, map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+	})
+	if err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find pull request", err), nil
+	}
+
+	input := githubv4.EnablePullRequestAutoMergeInput{
+		PullRequestID: prQuery.Repository.PullRequest.ID,
+	}
+	if mergeMethod != "" {
+		method := githubv4.PullRequestMergeMethod(strings.ToUpper(mergeMethod))
+		input.MergeMethod = &method
+	}
+	if commitTitle != "" {
+		input.CommitHeadline = githubv4.NewString(githubv4.String(commitTitle))
+	}
+	if commitMessage != "" {
+		input.CommitBody = githubv4.NewString(githubv4.String(commitMessage))
+	}
+	if sha != "" {
+		input.ExpectedHeadOid = githubv4.NewGitObjectID(githubv4.GitObjectID(sha))
+	}
+
+	var mutation struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest struct {
+				URL              githubv4.URI
+				AutoMergeRequest struct {
+					EnabledAt   githubv4.DateTime
+					MergeMethod githubv4.PullRequestMergeMethod
+				}
+			}
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to enable auto-merge", err), nil
+	}
+
+	pr := mutation.EnablePullRequestAutoMerge.PullRequest
+	r, err := json.Marshal(map[string]any{
+		"auto_merge_enabled": true,
+		"merge_method":       strings.ToLower(string(pr.AutoMergeRequest.MergeMethod)),
+		"enabled_at":         pr.AutoMergeRequest.EnabledAt.Time,
+		"url":                pr.URL.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
 // SearchPullRequests creates a tool to search for pull requests.
 func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_pull_requests",
@@ -743,6 +852,13 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			mcp.WithBoolean("include_patch",
+				mcp.Description("Include the patch of each file. Defaults to true"),
+			),
+			mcp.WithNumber("max_patch_size",
+				mcp.Description("Truncate the patch of each file to this many bytes. Truncated files are marked with patch_truncated. 0 means no limit"),
+				mcp.Min(0),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -758,6 +874,16 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			includePatch := true
+			if v, ok, err := OptionalParamOK[bool](request, "include_patch"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				includePatch = v
+			}
+			maxPatchSize, err := OptionalIntParam(request, "max_patch_size")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -789,13 +915,41 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(files)
+			r, err := json.Marshal(pullRequestFiles(files, includePatch, maxPatchSize))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// PullRequestFile is a file changed by a pull request, with its patch possibly truncated.
+type PullRequestFile struct {
+	*github.CommitFile
+	PatchTruncated bool `json:"patch_truncated,omitempty"`
+}
+
+// pullRequestFiles drops or truncates the patches of the given files. The
+// patch of a file is cut at the last complete line within maxPatchSize bytes.
+func pullRequestFiles(files []*github.CommitFile, includePatch bool, maxPatchSize int) []PullRequestFile {
+	result := make([]PullRequestFile, 0, len(files))
+	for _, file := range files {
+		f := PullRequestFile{CommitFile: file}
+		switch {
+		case !includePatch:
+			file.Patch = nil
+		case maxPatchSize > 0 && len(file.GetPatch()) > maxPatchSize:
+			patch := file.GetPatch()[:maxPatchSize]
+			if i := strings.LastIndexByte(patch, '\n'); i > 0 {
+				patch = patch[:i]
+			}
+			file.Patch = github.Ptr(patch)
+			f.PatchTruncated = true
 		}
+		result = append(result, f)
+	}
+	return result
 }
 
 // GetPullRequestStatus creates a tool to get the combined status of all status checks for a pull request.
@@ -884,9 +1038,9 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 }
 
 // UpdatePullRequestBranch creates a tool to update a pull request branch with the latest changes from the base branch.
-func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+func UpdatePullRequestBranch(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("update_pull_request_branch",
-			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BRANCH_DESCRIPTION", "Update the branch of a pull request with the latest changes from the base branch.")),
+			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BRANCH_DESCRIPTION", "Update the branch of a pull request with the latest changes from the base branch, by merging the base branch into it or rebasing it onto the base branch.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_UPDATE_PULL_REQUEST_BRANCH_USER_TITLE", "Update pull request branch"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -906,6 +1060,10 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 			mcp.WithString("expectedHeadSha",
 				mcp.Description("The expected SHA of the pull request's HEAD ref"),
 			),
+			mcp.WithString("update_method",
+				mcp.Description("How to bring in the changes from the base branch. Defaults to merge"),
+				mcp.Enum("merge", "rebase"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -924,6 +1082,20 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			updateMethod, err := OptionalParam[string](request, "update_method")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Only the GraphQL API can rebase a pull request branch.
+			if updateMethod == "rebase" {
+				gqlClient, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+				}
+				return rebasePullRequestBranch(ctx, gqlClient, owner, repo, pullNumber, expectedHeadSHA)
+			}
+
 			opts := &github.PullRequestBranchUpdateOptions{}
 			if expectedHeadSHA != "" {
 				opts.ExpectedHeadSHA = github.Ptr(expectedHeadSHA)
@@ -965,6 +1137,58 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 		}
 }
 
+// rebasePullRequestBranch rebases the branch of a pull request onto its base branch.
+func rebasePullRequestBranch(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int, expectedHeadSHA string) (*mcp.CallToolResult, error) {
+	var prQuery struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	err := client.Query(ctx, &prQuery, map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+	})
+	if err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find pull request", err), nil
+	}
+
+	rebase := githubv4.PullRequestBranchUpdateMethodRebase
+	input := githubv4.UpdatePullRequestBranchInput{
+		PullRequestID: prQuery.Repository.PullRequest.ID,
+		UpdateMethod:  &rebase,
+	}
+	if expectedHeadSHA != "" {
+		input.ExpectedHeadOid = githubv4.NewGitObjectID(githubv4.GitObjectID(expectedHeadSHA))
+	}
+
+	var mutation struct {
+		UpdatePullRequestBranch struct {
+			PullRequest struct {
+				HeadRefOid githubv4.GitObjectID
+				URL        githubv4.URI
+			}
+		} `graphql:"updatePullRequestBranch(input: $input)"`
+	}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to rebase pull request branch", err), nil
+	}
+
+	pr := mutation.UpdatePullRequestBranch.PullRequest
+	r, err := json.Marshal(map[string]any{
+		"message":  "Pull request branch rebased onto the base branch",
+		"head_sha": string(pr.HeadRefOid),
+		"url":      pr.URL.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
 // GetPullRequestComments creates a tool to get the review comments on a pull request.
 func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_comments",
@@ -1752,6 +1976,29 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub client: %v", err)), nil
 			}
 
+			// The diff only changes when the base or head of the pull request moves, so it can
+			// be cached by their SHAs.
+			var diffKey string
+			if blobCacheFromContext(ctx) != nil {
+				pr, resp, err := client.PullRequests.Get(ctx, params.Owner, params.Repo, int(params.PullNumber))
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get pull request",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if pr.GetBase().GetSHA() != "" && pr.GetHead().GetSHA() != "" {
+					diffKey = "diff:" + pr.GetBase().GetSHA() + "..." + pr.GetHead().GetSHA()
+				}
+				if blob, tier, ok := cachedBlob(ctx, params.Owner, params.Repo, diffKey); ok {
+					result := mcp.NewToolResultText(string(blob.Content))
+					setBlobCacheMeta(ctx, result, tier)
+					return result, nil
+				}
+			}
+
 			raw, resp, err := client.PullRequests.GetRaw(
 				ctx,
 				params.Owner,
@@ -1776,6 +2023,7 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 			}
 
 			defer func() { _ = resp.Body.Close() }()
+			cacheBlob(ctx, params.Owner, params.Repo, diffKey, []byte(raw), "text/x-diff")
 
 			// Return the raw response
 			return mcp.NewToolResultText(string(raw)), nil
@@ -745,7 +745,7 @@ func Test_ListPullRequests(t *testing.T) {
 func Test_MergePullRequest(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := MergePullRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := MergePullRequest(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "merge_pull_request", tool.Name)
@@ -798,6 +798,27 @@ func Test_MergePullRequest(t *testing.T) {
 			expectError:         false,
 			expectedMergeResult: mockMergeResult,
 		},
+		{
+			name: "head SHA changed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"sha": "abc123",
+					}).andThen(
+						mockResponse(t, http.StatusConflict, `{"message": "Head branch was modified. Review and try the merge again."}`),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"sha":        "abc123",
+			},
+			expectError:    true,
+			expectedErrMsg: "Head branch was modified",
+		},
 		{
 			name: "merge fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -823,7 +844,7 @@ func Test_MergePullRequest(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := MergePullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := MergePullRequest(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -857,6 +878,128 @@ func Test_MergePullRequest(t *testing.T) {
 	}
 }
 
+func Test_MergePullRequest_EnableAutoMerge(t *testing.T) {
+	prQuery := struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	prQueryVars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"prNum": githubv4.Int(42),
+	}
+	prQueryResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"pullRequest": map[string]any{"id": "PR_kwDOA0xdyM50BPaO"},
+		},
+	})
+	enableMutation := struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest struct {
+				URL              githubv4.URI
+				AutoMergeRequest struct {
+					EnabledAt   githubv4.DateTime
+					MergeMethod githubv4.PullRequestMergeMethod
+				}
+			}
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}{}
+	squash := githubv4.PullRequestMergeMethodSquash
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "enables auto-merge",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(prQuery, prQueryVars, prQueryResponse),
+				githubv4mock.NewMutationMatcher(
+					enableMutation,
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID:   "PR_kwDOA0xdyM50BPaO",
+						MergeMethod:     &squash,
+						CommitHeadline:  githubv4.NewString("Add feature (#42)"),
+						ExpectedHeadOid: githubv4.NewGitObjectID("abc123"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"enablePullRequestAutoMerge": map[string]any{
+							"pullRequest": map[string]any{
+								"url": "https://github.com/owner/repo/pull/42",
+								"autoMergeRequest": map[string]any{
+									"enabledAt":   "2024-05-01T10:00:00Z",
+									"mergeMethod": "SQUASH",
+								},
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"pullNumber":        float64(42),
+				"merge_method":      "squash",
+				"commit_title":      "Add feature (#42)",
+				"sha":               "abc123",
+				"enable_auto_merge": true,
+			},
+		},
+		{
+			name: "auto-merge not allowed",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(prQuery, prQueryVars, prQueryResponse),
+				githubv4mock.NewMutationMatcher(
+					enableMutation,
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID: "PR_kwDOA0xdyM50BPaO",
+					},
+					nil,
+					githubv4mock.ErrorResponse("Pull request Auto merge is not allowed for this repository"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"pullNumber":        float64(42),
+				"enable_auto_merge": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to enable auto-merge",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(tc.mockedClient)
+			_, handler := MergePullRequest(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, true, response["auto_merge_enabled"])
+			assert.Equal(t, "squash", response["merge_method"])
+			assert.Equal(t, "https://github.com/owner/repo/pull/42", response["url"])
+		})
+	}
+}
+
 func Test_SearchPullRequests(t *testing.T) {
 	mockClient := github.NewClient(nil)
 	tool, _ := SearchPullRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
@@ -1303,6 +1446,77 @@ func Test_GetPullRequestFiles(t *testing.T) {
 	}
 }
 
+func Test_GetPullRequestFiles_PatchControls(t *testing.T) {
+	mockFiles := []*github.CommitFile{
+		{
+			Filename: github.Ptr("file1.go"),
+			Status:   github.Ptr("modified"),
+			Patch:    github.Ptr("@@ -1,2 +1,2 @@\n-old line\n+new line"),
+		},
+		{
+			Filename: github.Ptr("file2.go"),
+			Status:   github.Ptr("added"),
+			Patch:    github.Ptr("@@ -0,0 +1 @@"),
+		},
+	}
+
+	tests := []struct {
+		name              string
+		requestArgs       map[string]interface{}
+		expectedPatches   []string
+		expectedTruncated []bool
+	}{
+		{
+			name: "truncates long patches at a line boundary",
+			requestArgs: map[string]interface{}{
+				"max_patch_size": float64(20),
+			},
+			expectedPatches:   []string{"@@ -1,2 +1,2 @@", "@@ -0,0 +1 @@"},
+			expectedTruncated: []bool{true, false},
+		},
+		{
+			name: "omits patches",
+			requestArgs: map[string]interface{}{
+				"include_patch": false,
+			},
+			expectedPatches:   []string{"", ""},
+			expectedTruncated: []bool{false, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					mockFiles,
+				),
+			))
+			_, handler := GetPullRequestFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var files []PullRequestFile
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &files))
+			require.Len(t, files, 2)
+			for i, f := range files {
+				assert.Equal(t, tc.expectedPatches[i], f.GetPatch())
+				assert.Equal(t, tc.expectedTruncated[i], f.PatchTruncated)
+			}
+		})
+	}
+}
+
 func Test_GetPullRequestStatus(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1471,7 +1685,7 @@ func Test_GetPullRequestStatus(t *testing.T) {
 func Test_UpdatePullRequestBranch(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := UpdatePullRequestBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := UpdatePullRequestBranch(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "update_pull_request_branch", tool.Name)
@@ -1560,7 +1774,7 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := UpdatePullRequestBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := UpdatePullRequestBranch(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1588,6 +1802,119 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 	}
 }
 
+func Test_UpdatePullRequestBranch_Rebase(t *testing.T) {
+	prQuery := struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	prQueryVars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"prNum": githubv4.Int(42),
+	}
+	prQueryResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"pullRequest": map[string]any{"id": "PR_kwDOA0xdyM50BPaO"},
+		},
+	})
+	rebaseMutation := struct {
+		UpdatePullRequestBranch struct {
+			PullRequest struct {
+				HeadRefOid githubv4.GitObjectID
+				URL        githubv4.URI
+			}
+		} `graphql:"updatePullRequestBranch(input: $input)"`
+	}{}
+	rebase := githubv4.PullRequestBranchUpdateMethodRebase
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "rebases the branch",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(prQuery, prQueryVars, prQueryResponse),
+				githubv4mock.NewMutationMatcher(
+					rebaseMutation,
+					githubv4.UpdatePullRequestBranchInput{
+						PullRequestID:   "PR_kwDOA0xdyM50BPaO",
+						ExpectedHeadOid: githubv4.NewGitObjectID("abc123"),
+						UpdateMethod:    &rebase,
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"updatePullRequestBranch": map[string]any{
+							"pullRequest": map[string]any{
+								"headRefOid": "def456",
+								"url":        "https://github.com/owner/repo/pull/42",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"pullNumber":      float64(42),
+				"expectedHeadSha": "abc123",
+				"update_method":   "rebase",
+			},
+		},
+		{
+			name: "rebase fails",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(prQuery, prQueryVars, prQueryResponse),
+				githubv4mock.NewMutationMatcher(
+					rebaseMutation,
+					githubv4.UpdatePullRequestBranchInput{
+						PullRequestID: "PR_kwDOA0xdyM50BPaO",
+						UpdateMethod:  &rebase,
+					},
+					nil,
+					githubv4mock.ErrorResponse("Cannot rebase: merge conflict"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"pullNumber":    float64(42),
+				"update_method": "rebase",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to rebase pull request branch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(tc.mockedClient)
+			_, handler := UpdatePullRequestBranch(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "def456", response["head_sha"])
+			assert.Equal(t, "https://github.com/owner/repo/pull/42", response["url"])
+		})
+	}
+}
+
 func Test_GetPullRequestComments(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
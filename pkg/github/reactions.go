@@ -0,0 +1,461 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// reactionContents maps the REST names of reactions to their GraphQL names.
+var reactionContents = map[string]githubv4.ReactionContent{
+	"+1":       githubv4.ReactionContentThumbsUp,
+	"-1":       githubv4.ReactionContentThumbsDown,
+	"laugh":    githubv4.ReactionContentLaugh,
+	"confused": githubv4.ReactionContentConfused,
+	"heart":    githubv4.ReactionContentHeart,
+	"hooray":   githubv4.ReactionContentHooray,
+	"rocket":   githubv4.ReactionContentRocket,
+	"eyes":     githubv4.ReactionContentEyes,
+}
+
+// restReactionContent returns the REST name of a GraphQL reaction.
+func restReactionContent(content githubv4.ReactionContent) string {
+	for name, c := range reactionContents {
+		if c == content {
+			return name
+		}
+	}
+	return string(content)
+}
+
+// reactionSubject identifies what a reaction is attached to.
+type reactionSubject struct {
+	Type      string
+	Owner     string
+	Repo      string
+	Number    int
+	CommentID int64
+}
+
+func (s reactionSubject) String() string {
+	switch s.Type {
+	case "issue_comment", "pull_request_review_comment":
+		return fmt.Sprintf("%s %d in %s/%s", s.Type, s.CommentID, s.Owner, s.Repo)
+	default:
+		return fmt.Sprintf("%s %s/%s#%d", s.Type, s.Owner, s.Repo, s.Number)
+	}
+}
+
+// withReactionSubject adds the parameters identifying a reaction subject to a tool.
+func withReactionSubject() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		for _, opt := range []mcp.ToolOption{
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("subject_type",
+				mcp.Required(),
+				mcp.Description("What the reaction is on. Pull requests are issues, use 'issue' with the pull request number for reactions on a pull request itself"),
+				mcp.Enum("issue", "issue_comment", "pull_request_review_comment", "discussion"),
+			),
+			mcp.WithNumber("number",
+				mcp.Description("The issue, pull request or discussion number. Required for 'issue' and 'discussion'"),
+			),
+			mcp.WithNumber("comment_id",
+				mcp.Description("The comment ID. Required for 'issue_comment' and 'pull_request_review_comment'"),
+			),
+		} {
+			opt(tool)
+		}
+	}
+}
+
+func reactionSubjectFromRequest(request mcp.CallToolRequest) (reactionSubject, error) {
+	var s reactionSubject
+	var err error
+	if s.Owner, err = RequiredParam[string](request, "owner"); err != nil {
+		return s, err
+	}
+	if s.Repo, err = RequiredParam[string](request, "repo"); err != nil {
+		return s, err
+	}
+	if s.Type, err = RequiredParam[string](request, "subject_type"); err != nil {
+		return s, err
+	}
+
+	switch s.Type {
+	case "issue", "discussion":
+		if s.Number, err = RequiredInt(request, "number"); err != nil {
+			return s, fmt.Errorf("number is required for subject_type %s", s.Type)
+		}
+	case "issue_comment", "pull_request_review_comment":
+		commentID, err := RequiredInt(request, "comment_id")
+		if err != nil {
+			return s, fmt.Errorf("comment_id is required for subject_type %s", s.Type)
+		}
+		s.CommentID = int64(commentID)
+	default:
+		return s, fmt.Errorf("unsupported subject_type: %s", s.Type)
+	}
+	return s, nil
+}
+
+// discussionNodeID returns the GraphQL node ID of a repository discussion.
+func discussionNodeID(ctx context.Context, client *githubv4.Client, owner, repo string, number int) (githubv4.ID, error) {
+	var q struct {
+		Repository struct {
+			Discussion struct {
+				ID githubv4.ID
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":            githubv4.String(owner),
+		"repo":             githubv4.String(repo),
+		"discussionNumber": githubv4.Int(number), // #nosec G115 - discussion numbers are always small positive integers
+	}
+	if err := client.Query(ctx, &q, vars); err != nil {
+		return nil, err
+	}
+	return q.Repository.Discussion.ID, nil
+}
+
+func listRESTReactions(ctx context.Context, client *github.Client, s reactionSubject, opts *github.ListReactionOptions) ([]*github.Reaction, *github.Response, error) {
+	switch s.Type {
+	case "issue":
+		return client.Reactions.ListIssueReactions(ctx, s.Owner, s.Repo, s.Number, opts)
+	case "issue_comment":
+		return client.Reactions.ListIssueCommentReactions(ctx, s.Owner, s.Repo, s.CommentID, opts)
+	default:
+		return client.Reactions.ListPullRequestCommentReactions(ctx, s.Owner, s.Repo, s.CommentID, opts)
+	}
+}
+
+// ListReactions creates a tool to list the reactions on an issue, comment or discussion.
+func ListReactions(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_reactions",
+			mcp.WithDescription(t("TOOL_LIST_REACTIONS_DESCRIPTION", "List the reactions on an issue, pull request, issue comment, pull request review comment or discussion.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REACTIONS_USER_TITLE", "List reactions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Description("Only list reactions of this type"),
+				mcp.Enum("+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			subject, err := reactionSubjectFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var reactions []*github.Reaction
+			// Reactions to discussions come from the GraphQL API, without a response to page by
+			var resp *github.Response
+			if subject.Type == "discussion" {
+				gqlClient, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+
+				var q struct {
+					Repository struct {
+						Discussion struct {
+							Reactions struct {
+								Nodes []struct {
+									Content githubv4.ReactionContent
+									User    *struct {
+										Login githubv4.String
+									}
+									CreatedAt githubv4.DateTime
+								}
+							} `graphql:"reactions(first: $first, content: $content)"`
+						} `graphql:"discussion(number: $discussionNumber)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner":            githubv4.String(subject.Owner),
+					"repo":             githubv4.String(subject.Repo),
+					"discussionNumber": githubv4.Int(subject.Number),     // #nosec G115 - discussion numbers are always small positive integers
+					"first":            githubv4.Int(pagination.PerPage), // #nosec G115 - perPage is capped at 100
+					"content":          (*githubv4.ReactionContent)(nil),
+				}
+				if content != "" {
+					c := reactionContents[content]
+					vars["content"] = &c
+				}
+				if err := gqlClient.Query(ctx, &q, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to list reactions on %s", subject), err), nil
+				}
+
+				reactions = make([]*github.Reaction, 0, len(q.Repository.Discussion.Reactions.Nodes))
+				for _, node := range q.Repository.Discussion.Reactions.Nodes {
+					reaction := &github.Reaction{Content: github.Ptr(restReactionContent(node.Content))}
+					if node.User != nil {
+						reaction.User = &github.User{Login: github.Ptr(string(node.User.Login))}
+					}
+					reactions = append(reactions, reaction)
+				}
+			} else {
+				client, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+
+				opts := &github.ListReactionOptions{
+					Content: content,
+					ListOptions: github.ListOptions{
+						Page:    pagination.Page,
+						PerPage: pagination.PerPage,
+					},
+				}
+				reactions, resp, err = listRESTReactions(ctx, client, subject, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list reactions on %s", subject),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+			}
+
+			r, err := json.Marshal(reactions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			result := mcp.NewToolResultText(string(r))
+			if resp != nil {
+				result = withPageInfo(result, restPageInfo(resp, nil))
+			}
+			return result, nil
+		}
+}
+
+// AddReaction creates a tool to react to an issue, comment or discussion.
+func AddReaction(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("add_reaction",
+			mcp.WithDescription(t("TOOL_ADD_REACTION_DESCRIPTION", "Add a reaction to an issue, pull request, issue comment, pull request review comment or discussion. Adding a reaction that the user already added has no effect.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ADD_REACTION_USER_TITLE", "Add reaction"),
+				ReadOnlyHint:   ToBoolPtr(false),
+				IdempotentHint: ToBoolPtr(true),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("The reaction to add"),
+				mcp.Enum("+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			subject, err := reactionSubjectFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := reactionContents[content]; !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported reaction: %s", content)), nil
+			}
+
+			if subject.Type == "discussion" {
+				gqlClient, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				subjectID, err := discussionNodeID(ctx, gqlClient, subject.Owner, subject.Repo, subject.Number)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to find %s", subject), err), nil
+				}
+
+				var mutation struct {
+					AddReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"addReaction(input: $input)"`
+				}
+				input := githubv4.AddReactionInput{SubjectID: subjectID, Content: reactionContents[content]}
+				if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to add reaction to %s", subject), err), nil
+				}
+
+				return mcp.NewToolResultText(fmt.Sprintf("added %s reaction to %s", content, subject)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var reaction *github.Reaction
+			var resp *github.Response
+			switch subject.Type {
+			case "issue":
+				reaction, resp, err = client.Reactions.CreateIssueReaction(ctx, subject.Owner, subject.Repo, subject.Number, content)
+			case "issue_comment":
+				reaction, resp, err = client.Reactions.CreateIssueCommentReaction(ctx, subject.Owner, subject.Repo, subject.CommentID, content)
+			default:
+				reaction, resp, err = client.Reactions.CreatePullRequestCommentReaction(ctx, subject.Owner, subject.Repo, subject.CommentID, content)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to add reaction to %s", subject),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(reaction)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RemoveReaction creates a tool to remove the authenticated user's reaction from an issue, comment or discussion.
+func RemoveReaction(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_reaction",
+			mcp.WithDescription(t("TOOL_REMOVE_REACTION_DESCRIPTION", "Remove the authenticated user's reaction from an issue, pull request, issue comment, pull request review comment or discussion.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_REACTION_USER_TITLE", "Remove reaction"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("The reaction to remove"),
+				mcp.Enum("+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			subject, err := reactionSubjectFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := reactionContents[content]; !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported reaction: %s", content)), nil
+			}
+
+			if subject.Type == "discussion" {
+				gqlClient, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				subjectID, err := discussionNodeID(ctx, gqlClient, subject.Owner, subject.Repo, subject.Number)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to find %s", subject), err), nil
+				}
+
+				var mutation struct {
+					RemoveReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"removeReaction(input: $input)"`
+				}
+				input := githubv4.RemoveReactionInput{SubjectID: subjectID, Content: reactionContents[content]}
+				if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, fmt.Sprintf("failed to remove reaction from %s", subject), err), nil
+				}
+
+				return mcp.NewToolResultText(fmt.Sprintf("removed %s reaction from %s", content, subject)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The REST API deletes reactions by ID, so find the user's reaction first.
+			user, resp, err := client.Users.Get(ctx, "")
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get authenticated user", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			opts := &github.ListReactionOptions{
+				Content:     content,
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			var reactionID int64
+			for reactionID == 0 {
+				reactions, resp, err := listRESTReactions(ctx, client, subject, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list reactions on %s", subject),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, reaction := range reactions {
+					if reaction.GetUser().GetID() == user.GetID() {
+						reactionID = reaction.GetID()
+						break
+					}
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			if reactionID == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("%s has no %s reaction from %s", subject, content, user.GetLogin())), nil
+			}
+
+			switch subject.Type {
+			case "issue":
+				resp, err = client.Reactions.DeleteIssueReaction(ctx, subject.Owner, subject.Repo, subject.Number, reactionID)
+			case "issue_comment":
+				resp, err = client.Reactions.DeleteIssueCommentReaction(ctx, subject.Owner, subject.Repo, subject.CommentID, reactionID)
+			default:
+				resp, err = client.Reactions.DeletePullRequestCommentReaction(ctx, subject.Owner, subject.Repo, subject.CommentID, reactionID)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to remove reaction from %s", subject),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("removed %s reaction from %s", content, subject)), nil
+		}
+}
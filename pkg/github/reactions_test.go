@@ -0,0 +1,366 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListReactions(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListReactions(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_reactions", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type"})
+
+	// The content filter is an optional variable, so the query is given as text
+	// to keep it nullable.
+	discussionQuery := "query($content:ReactionContent$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){reactions(first: $first, content: $content){nodes{content,user{login},createdAt}}}}}"
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		mockedGQLClient  *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedContents []string
+		expectedErrMsg   string
+	}{
+		{
+			name: "issue reactions filtered by content",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{
+						"content":  "+1",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Reaction{
+							{ID: github.Ptr(int64(1)), Content: github.Ptr("+1"), User: &github.User{Login: github.Ptr("octocat")}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(42),
+				"content":      "+1",
+			},
+			expectedContents: []string{"+1"},
+		},
+		{
+			name: "pull request review comment reactions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsCommentsReactionsByOwnerByRepoByCommentId,
+					[]*github.Reaction{
+						{ID: github.Ptr(int64(1)), Content: github.Ptr("eyes")},
+						{ID: github.Ptr(int64(2)), Content: github.Ptr("rocket")},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "pull_request_review_comment",
+				"comment_id":   float64(1001),
+			},
+			expectedContents: []string{"eyes", "rocket"},
+		},
+		{
+			name: "discussion reactions",
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(discussionQuery, map[string]any{
+					"owner":            githubv4.String("owner"),
+					"repo":             githubv4.String("repo"),
+					"discussionNumber": githubv4.Int(7),
+					"first":            githubv4.Int(30),
+					"content":          githubv4.ReactionContentHeart,
+				}, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussion": map[string]any{
+							"reactions": map[string]any{
+								"nodes": []any{
+									map[string]any{
+										"content":   "HEART",
+										"user":      map[string]any{"login": "octocat"},
+										"createdAt": "2024-05-01T10:00:00Z",
+									},
+								},
+							},
+						},
+					},
+				})),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "discussion",
+				"number":       float64(7),
+				"content":      "heart",
+			},
+			expectedContents: []string{"heart"},
+		},
+		{
+			name: "comment ID missing",
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"number":       float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "comment_id is required for subject_type issue_comment",
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list reactions on issue owner/repo#42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQLClient)
+			_, handler := ListReactions(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var reactions []*github.Reaction
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &reactions))
+			contents := make([]string, 0, len(reactions))
+			for _, r := range reactions {
+				contents = append(contents, r.GetContent())
+			}
+			assert.Equal(t, tc.expectedContents, contents)
+		})
+	}
+}
+
+func Test_AddReaction(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := AddReaction(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_reaction", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "content"})
+
+	var discussionQuery struct {
+		Repository struct {
+			Discussion struct {
+				ID githubv4.ID
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	var addMutation struct {
+		AddReaction struct {
+			Reaction struct {
+				Content githubv4.ReactionContent
+			}
+		} `graphql:"addReaction(input: $input)"`
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		mockedGQLClient *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedText    string
+		expectedErrMsg  string
+	}{
+		{
+			name: "reacts to issue comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsReactionsByOwnerByRepoByCommentId,
+					expectRequestBody(t, map[string]interface{}{
+						"content": "hooray",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reaction{ID: github.Ptr(int64(5)), Content: github.Ptr("hooray")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"comment_id":   float64(1001),
+				"content":      "hooray",
+			},
+			expectedText: `"content":"hooray"`,
+		},
+		{
+			name: "reacts to discussion",
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(discussionQuery, map[string]any{
+					"owner":            githubv4.String("owner"),
+					"repo":             githubv4.String("repo"),
+					"discussionNumber": githubv4.Int(7),
+				}, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"discussion": map[string]any{"id": "D_kwDOA"},
+					},
+				})),
+				githubv4mock.NewMutationMatcher(
+					addMutation,
+					githubv4.AddReactionInput{SubjectID: githubv4.ID("D_kwDOA"), Content: githubv4.ReactionContentRocket},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addReaction": map[string]any{
+							"reaction": map[string]any{"content": "ROCKET"},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "discussion",
+				"number":       float64(7),
+				"content":      "rocket",
+			},
+			expectedText: "added rocket reaction to discussion owner/repo#7",
+		},
+		{
+			name: "unsupported reaction",
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(42),
+				"content":      "party",
+			},
+			expectError:    true,
+			expectedErrMsg: "unsupported reaction: party",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQLClient)
+			_, handler := AddReaction(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
+
+func Test_RemoveReaction(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := RemoveReaction(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_reaction", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "content"})
+
+	me := &github.User{ID: github.Ptr(int64(10)), Login: github.Ptr("me")}
+	reactions := []*github.Reaction{
+		{ID: github.Ptr(int64(1)), Content: github.Ptr("+1"), User: &github.User{ID: github.Ptr(int64(11))}},
+		{ID: github.Ptr(int64(2)), Content: github.Ptr("+1"), User: &github.User{ID: github.Ptr(int64(10))}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "removes the user's reaction",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUser, me),
+				mock.WithRequestMatch(mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber, reactions),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesReactionsByOwnerByRepoByIssueNumberByReactionId,
+					expectPath(t, "/repos/owner/repo/issues/42/reactions/2").andThen(
+						mockResponse(t, http.StatusNoContent, ""),
+					),
+				),
+			),
+		},
+		{
+			name: "user has not reacted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUser, me),
+				mock.WithRequestMatch(mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber, reactions[:1]),
+			),
+			expectError:    true,
+			expectedErrMsg: "issue owner/repo#42 has no +1 reaction from me",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveReaction(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"number":       float64(42),
+				"content":      "+1",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, "removed +1 reaction from issue owner/repo#42", getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,784 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// PromoteReleaseResult is the output of promote_release.
+type PromoteReleaseResult struct {
+	Channel            string `json:"channel"`
+	FromTag            string `json:"from_tag"`
+	CommitSHA          string `json:"commit_sha"`
+	PreviousCommitSHA  string `json:"previous_commit_sha,omitempty"`
+	ReleaseURL         string `json:"release_url"`
+	WorkflowDispatched bool   `json:"workflow_dispatched"`
+}
+
+// PromoteRelease creates a tool to promote a release to a channel, such as stable, by moving
+// the channel tag to the release's commit and publishing a channel release that links back to it.
+func PromoteRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("promote_release",
+			mcp.WithDescription(t("TOOL_PROMOTE_RELEASE_DESCRIPTION", "Promote a release to a channel, such as stable or beta. Points the channel tag at the release's commit, creates or updates the channel release with notes linking back to the promoted release, and optionally dispatches a deployment workflow.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_PROMOTE_RELEASE_USER_TITLE", "Promote release to channel"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("from_tag",
+				mcp.Required(),
+				mcp.Description("Tag of the release to promote, e.g. v1.2.3"),
+			),
+			mcp.WithString("channel",
+				mcp.Required(),
+				mcp.Description("Channel to promote the release to. Used as the name of the channel tag and release, e.g. stable."),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Description("Optional ID or file name of a workflow to dispatch after promoting, e.g. deploy.yml. It runs on the channel tag with the inputs tag and channel."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fromTag, err := RequiredParam[string](request, "from_tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			channel, err := RequiredParam[string](request, "channel")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := OptionalParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if channel == fromTag {
+				return mcp.NewToolResultError("channel must be different from from_tag"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			source, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, fromTag)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get release for tag: %s", fromTag),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			commitSHA, result, err := resolveTagCommit(ctx, client, owner, repo, fromTag)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			// Move the channel tag, creating it on the first promotion.
+			promotion := PromoteReleaseResult{Channel: channel, FromTag: fromTag, CommitSHA: commitSHA}
+			channelRef, resp, err := client.Git.GetRef(ctx, owner, repo, "tags/"+channel)
+			switch {
+			case err == nil:
+				defer func() { _ = resp.Body.Close() }()
+				promotion.PreviousCommitSHA = channelRef.GetObject().GetSHA()
+				channelRef.Object.SHA = github.Ptr(commitSHA)
+				_, resp, err = client.Git.UpdateRef(ctx, owner, repo, channelRef, true)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to update channel tag",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				_, resp, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+					Ref:    github.Ptr("refs/tags/" + channel),
+					Object: &github.GitObject{SHA: github.Ptr(commitSHA)},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to create channel tag",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get channel tag",
+					resp,
+					err,
+				), nil
+			}
+
+			// Publish the channel release, linking back to the promoted release so that its
+			// notes stay the single source of truth.
+			notes := fmt.Sprintf("Promoted [%s](%s) to %s.\n\n%s", fromTag, source.GetHTMLURL(), channel, source.GetBody())
+			name := fmt.Sprintf("%s (%s)", channel, fromTag)
+			channelRelease, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, channel)
+			switch {
+			case err == nil:
+				defer func() { _ = resp.Body.Close() }()
+				channelRelease, resp, err = client.Repositories.EditRelease(ctx, owner, repo, channelRelease.GetID(), &github.RepositoryRelease{
+					Name:       github.Ptr(name),
+					Body:       github.Ptr(notes),
+					Prerelease: source.Prerelease,
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to update channel release",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				channelRelease, resp, err = client.Repositories.CreateRelease(ctx, owner, repo, &github.RepositoryRelease{
+					TagName:    github.Ptr(channel),
+					Name:       github.Ptr(name),
+					Body:       github.Ptr(notes),
+					Prerelease: source.Prerelease,
+					// The versioned release stays the latest release.
+					MakeLatest: github.Ptr("false"),
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to create channel release",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get channel release",
+					resp,
+					err,
+				), nil
+			}
+			promotion.ReleaseURL = channelRelease.GetHTMLURL()
+
+			if workflowID != "" {
+				event := github.CreateWorkflowDispatchEventRequest{
+					Ref: channel,
+					Inputs: map[string]any{
+						"tag":     fromTag,
+						"channel": channel,
+					},
+				}
+				if workflowIDInt, parseErr := strconv.ParseInt(workflowID, 10, 64); parseErr == nil {
+					resp, err = client.Actions.CreateWorkflowDispatchEventByID(ctx, owner, repo, workflowIDInt, event)
+				} else {
+					resp, err = client.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflowID, event)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("release promoted to %s, but failed to dispatch workflow %s", channel, workflowID),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				promotion.WorkflowDispatched = true
+			}
+
+			r, err := json.Marshal(promotion)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// resolveTagCommit returns the SHA of the commit a tag points to, following annotated tags.
+// A non-nil result reports a failure that should be returned from the tool handler as is.
+func resolveTagCommit(ctx context.Context, client *github.Client, owner, repo, tag string) (string, *mcp.CallToolResult, error) {
+	ref, resp, err := client.Git.GetRef(ctx, owner, repo, "tags/"+tag)
+	if err != nil {
+		return "", ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to get tag reference: %s", tag),
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	object := ref.GetObject()
+	if object.GetType() != "tag" {
+		return object.GetSHA(), nil, nil
+	}
+
+	annotated, resp, err := client.Git.GetTag(ctx, owner, repo, object.GetSHA())
+	if err != nil {
+		return "", ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to get annotated tag: %s", tag),
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return annotated.GetObject().GetSHA(), nil, nil
+}
+
+// maxReleaseAssetDownloadBytes is the size of the largest release asset download_release_asset
+// returns the content of. Larger assets have to be downloaded from their browser_download_url.
+const maxReleaseAssetDownloadBytes = 10 << 20
+
+// GetRelease creates a tool to get a release by its ID.
+func GetRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_DESCRIPTION", "Get a release by its ID in a GitHub repository, including draft releases, which have no tag yet")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RELEASE_USER_TITLE", "Get release"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The release ID"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			release, resp, err := client.Repositories.GetRelease(ctx, owner, repo, int64(releaseID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get release: %d", releaseID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(release)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// withReleaseOptions adds the parameters shared by create_release and update_release.
+func withReleaseOptions() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("target_commitish",
+			mcp.Description("Branch or commit SHA the tag is created from, if it does not exist yet. Defaults to the default branch."),
+		)(tool)
+		mcp.WithString("name",
+			mcp.Description("Release title"),
+		)(tool)
+		mcp.WithString("body",
+			mcp.Description("Release notes in Markdown"),
+		)(tool)
+		mcp.WithBoolean("draft",
+			mcp.Description("Whether the release is an unpublished draft"),
+		)(tool)
+		mcp.WithBoolean("prerelease",
+			mcp.Description("Whether the release is a prerelease"),
+		)(tool)
+		mcp.WithString("make_latest",
+			mcp.Description("Whether this release is marked as the latest release. 'legacy' picks the latest release by creation date and version."),
+			mcp.Enum("true", "false", "legacy"),
+		)(tool)
+	}
+}
+
+// releaseFromParams returns a release with the fields given in the request set, so that
+// update_release leaves the other fields unchanged.
+func releaseFromParams(request mcp.CallToolRequest) (*github.RepositoryRelease, error) {
+	release := &github.RepositoryRelease{}
+	for param, field := range map[string]**string{
+		"tag_name":         &release.TagName,
+		"target_commitish": &release.TargetCommitish,
+		"name":             &release.Name,
+		"body":             &release.Body,
+		"make_latest":      &release.MakeLatest,
+	} {
+		value, ok, err := OptionalParamOK[string](request, param)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	for param, field := range map[string]**bool{
+		"draft":      &release.Draft,
+		"prerelease": &release.Prerelease,
+	} {
+		value, ok, err := OptionalParamOK[bool](request, param)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	return release, nil
+}
+
+// CreateRelease creates a tool to create a release.
+func CreateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_release",
+			mcp.WithDescription(t("TOOL_CREATE_RELEASE_DESCRIPTION", "Create a release in a GitHub repository. The tag is created from target_commitish if it does not exist yet.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_RELEASE_USER_TITLE", "Create release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag_name",
+				mcp.Required(),
+				mcp.Description("Tag of the release, e.g. v1.2.3"),
+			),
+			withReleaseOptions(),
+			mcp.WithBoolean("generate_release_notes",
+				mcp.Description("Generate the name and notes of the release from the pull requests merged since the previous release. A given name or body is used instead of, or prepended to, the generated one."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := RequiredParam[string](request, "tag_name"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release, err := releaseFromParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			generateNotes, err := OptionalParam[bool](request, "generate_release_notes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if generateNotes {
+				release.GenerateReleaseNotes = github.Ptr(true)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateRelease(ctx, owner, repo, release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create release: %s", release.GetTagName()),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateRelease creates a tool to update a release.
+func UpdateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_release",
+			mcp.WithDescription(t("TOOL_UPDATE_RELEASE_DESCRIPTION", "Update a release in a GitHub repository, e.g. to edit its notes or publish a draft. Only the given fields are changed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_RELEASE_USER_TITLE", "Update release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The release ID"),
+			),
+			mcp.WithString("tag_name",
+				mcp.Description("New tag of the release"),
+			),
+			withReleaseOptions(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release, err := releaseFromParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updated, resp, err := client.Repositories.EditRelease(ctx, owner, repo, int64(releaseID), release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update release: %d", releaseID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(updated)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteRelease creates a tool to delete a release.
+func DeleteRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_release",
+			mcp.WithDescription(t("TOOL_DELETE_RELEASE_DESCRIPTION", "Delete a release and its assets from a GitHub repository. The release's tag is kept.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_RELEASE_USER_TITLE", "Delete release"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The release ID"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteRelease(ctx, owner, repo, int64(releaseID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete release: %d", releaseID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("release %d deleted", releaseID)), nil
+		}
+}
+
+// UploadReleaseAsset creates a tool to upload an asset to a release.
+func UploadReleaseAsset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("upload_release_asset",
+			mcp.WithDescription(t("TOOL_UPLOAD_RELEASE_ASSET_DESCRIPTION", "Upload a file as an asset of a release")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPLOAD_RELEASE_ASSET_USER_TITLE", "Upload release asset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The release ID"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("File name of the asset, e.g. checksums.txt"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Content of the asset"),
+			),
+			mcp.WithString("encoding",
+				mcp.Description("Encoding of content. Use base64 for binary files."),
+				mcp.Enum("utf-8", "base64"),
+				mcp.DefaultString("utf-8"),
+			),
+			mcp.WithString("content_type",
+				mcp.Description("Media type of the asset. Detected from the name and content if not given."),
+			),
+			mcp.WithString("label",
+				mcp.Description("Label shown for the asset instead of its name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			encoding, err := OptionalParam[string](request, "encoding")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentType, err := OptionalParam[string](request, "content_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := OptionalParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			data := []byte(content)
+			if encoding == "base64" {
+				data, err = base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to decode base64 content: %s", err)), nil
+				}
+			}
+			if contentType == "" {
+				contentType = mime.TypeByExtension(path.Ext(name))
+			}
+			if contentType == "" {
+				contentType = http.DetectContentType(data)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// RepositoriesService.UploadReleaseAsset only uploads from an *os.File.
+			query := url.Values{"name": {name}}
+			if label != "" {
+				query.Set("label", label)
+			}
+			u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?%s", owner, repo, releaseID, query.Encode())
+			req, err := client.NewUploadRequest(u, bytes.NewReader(data), int64(len(data)), contentType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create upload request: %w", err)
+			}
+			asset := new(github.ReleaseAsset)
+			resp, err := client.Do(ctx, req, asset)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to upload release asset: %s", name),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(asset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DownloadReleaseAsset creates a tool to download the content of a release asset.
+func DownloadReleaseAsset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("download_release_asset",
+			mcp.WithDescription(t("TOOL_DOWNLOAD_RELEASE_ASSET_DESCRIPTION", "Download the content of a release asset. Text assets are returned as text and other assets as base64. Assets larger than 10 MB are not downloaded; use their browser_download_url instead.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DOWNLOAD_RELEASE_ASSET_USER_TITLE", "Download release asset"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("asset_id",
+				mcp.Required(),
+				mcp.Description("The asset ID, listed in the assets of a release"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assetID, err := RequiredInt(request, "asset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			asset, resp, err := client.Repositories.GetReleaseAsset(ctx, owner, repo, int64(assetID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get release asset: %d", assetID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if asset.GetSize() > maxReleaseAssetDownloadBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("release asset %s is too large to download (%d bytes), download it from %s instead", asset.GetName(), asset.GetSize(), asset.GetBrowserDownloadURL())), nil
+			}
+
+			// Assets are served from a signed URL GitHub redirects to, which must not be sent
+			// the GitHub token.
+			rc, _, err := client.Repositories.DownloadReleaseAsset(ctx, owner, repo, int64(assetID), http.DefaultClient)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to download release asset: %s", asset.GetName()),
+					nil,
+					err,
+				), nil
+			}
+			defer func() { _ = rc.Close() }()
+			content, err := io.ReadAll(io.LimitReader(rc, maxReleaseAssetDownloadBytes))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to read release asset: %s", err)), nil
+			}
+
+			contentType := asset.GetContentType()
+			if contentType == "" || contentType == "application/octet-stream" {
+				contentType = http.DetectContentType(content)
+			}
+			message := fmt.Sprintf("successfully downloaded release asset %s", asset.GetName())
+			if strings.HasPrefix(contentType, "text") || contentType == "application/json" {
+				return mcp.NewToolResultResource(message, mcp.TextResourceContents{
+					URI:      asset.GetBrowserDownloadURL(),
+					Text:     string(content),
+					MIMEType: contentType,
+				}), nil
+			}
+			return mcp.NewToolResultResource(message, mcp.BlobResourceContents{
+				URI:      asset.GetBrowserDownloadURL(),
+				Blob:     base64.StdEncoding.EncodeToString(content),
+				MIMEType: contentType,
+			}), nil
+		}
+}
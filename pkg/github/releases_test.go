@@ -0,0 +1,610 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PromoteRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PromoteRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "promote_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "from_tag")
+	assert.Contains(t, tool.InputSchema.Properties, "channel")
+	assert.Contains(t, tool.InputSchema.Properties, "workflow_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "from_tag", "channel"})
+
+	sourceRelease := &github.RepositoryRelease{
+		ID:      github.Ptr(int64(1)),
+		TagName: github.Ptr("v1.2.3"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/v1.2.3"),
+		Body:    github.Ptr("Bug fixes"),
+	}
+	channelRelease := &github.RepositoryRelease{
+		ID:      github.Ptr(int64(2)),
+		TagName: github.Ptr("stable"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/stable"),
+	}
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	}
+	// releases serves the source release, and the channel release if it exists.
+	releases := func(channelExists bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/v1.2.3"):
+				mockResponse(t, http.StatusOK, sourceRelease)(w, r)
+			case channelExists:
+				mockResponse(t, http.StatusOK, channelRelease)(w, r)
+			default:
+				notFound(w)
+			}
+		}
+	}
+	// refs serves the release tag, and the channel tag if it exists.
+	refs := func(annotated, channelExists bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/v1.2.3") && annotated:
+				mockResponse(t, http.StatusOK, &github.Reference{
+					Ref:    github.Ptr("refs/tags/v1.2.3"),
+					Object: &github.GitObject{Type: github.Ptr("tag"), SHA: github.Ptr("tag-object")},
+				})(w, r)
+			case strings.HasSuffix(r.URL.Path, "/v1.2.3"):
+				mockResponse(t, http.StatusOK, &github.Reference{
+					Ref:    github.Ptr("refs/tags/v1.2.3"),
+					Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr("c0ffee")},
+				})(w, r)
+			case channelExists:
+				mockResponse(t, http.StatusOK, &github.Reference{
+					Ref:    github.Ptr("refs/tags/stable"),
+					Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr("0ld5ha")},
+				})(w, r)
+			default:
+				notFound(w)
+			}
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult PromoteReleaseResult
+	}{
+		{
+			name: "first promotion of an annotated tag with workflow dispatch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposReleasesTagsByOwnerByRepoByTag, releases(false)),
+				mock.WithRequestMatchHandler(mock.GetReposGitRefByOwnerByRepoByRef, refs(true, false)),
+				mock.WithRequestMatch(mock.GetReposGitTagsByOwnerByRepoByTagSha, &github.Tag{
+					SHA:    github.Ptr("tag-object"),
+					Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr("c0ffee")},
+				}),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/tags/stable",
+						"sha": "c0ffee",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/tags/stable")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"tag_name":    "stable",
+						"name":        "stable (v1.2.3)",
+						"body":        "Promoted [v1.2.3](https://github.com/owner/repo/releases/tag/v1.2.3) to stable.\n\nBug fixes",
+						"make_latest": "false",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, channelRelease),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsWorkflowsDispatchesByOwnerByRepoByWorkflowId,
+					expectRequestBody(t, map[string]interface{}{
+						"ref":    "stable",
+						"inputs": map[string]interface{}{"tag": "v1.2.3", "channel": "stable"},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"from_tag":    "v1.2.3",
+				"channel":     "stable",
+				"workflow_id": "deploy.yml",
+			},
+			expectedResult: PromoteReleaseResult{
+				Channel:            "stable",
+				FromTag:            "v1.2.3",
+				CommitSHA:          "c0ffee",
+				ReleaseURL:         "https://github.com/owner/repo/releases/tag/stable",
+				WorkflowDispatched: true,
+			},
+		},
+		{
+			name: "moves an existing channel",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposReleasesTagsByOwnerByRepoByTag, releases(true)),
+				mock.WithRequestMatchHandler(mock.GetReposGitRefByOwnerByRepoByRef, refs(false, true)),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "c0ffee",
+						"force": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr("refs/tags/stable")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "stable (v1.2.3)",
+						"body": "Promoted [v1.2.3](https://github.com/owner/repo/releases/tag/v1.2.3) to stable.\n\nBug fixes",
+					}).andThen(
+						mockResponse(t, http.StatusOK, channelRelease),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"from_tag": "v1.2.3",
+				"channel":  "stable",
+			},
+			expectedResult: PromoteReleaseResult{
+				Channel:           "stable",
+				FromTag:           "v1.2.3",
+				CommitSHA:         "c0ffee",
+				PreviousCommitSHA: "0ld5ha",
+				ReleaseURL:        "https://github.com/owner/repo/releases/tag/stable",
+			},
+		},
+		{
+			name: "release not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { notFound(w) }),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"from_tag": "v9.9.9",
+				"channel":  "stable",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get release for tag: v9.9.9",
+		},
+		{
+			name:         "channel same as tag",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"from_tag": "stable",
+				"channel":  "stable",
+			},
+			expectError:    true,
+			expectedErrMsg: "channel must be different from from_tag",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := PromoteRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var returned PromoteReleaseResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful get",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepoByReleaseId,
+					expectPath(t, "/repos/owner/repo/releases/7").andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryRelease{ID: github.Ptr(int64(7)), Draft: github.Ptr(true)}),
+					),
+				),
+			),
+		},
+		{
+			name: "release not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepoByReleaseId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get release: 7",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(7),
+			}))
+			require.NoError(t, err)
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var release github.RepositoryRelease
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &release))
+			assert.Equal(t, int64(7), release.GetID())
+			assert.True(t, release.GetDraft())
+		})
+	}
+}
+
+func Test_CreateRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "target_commitish")
+	assert.Contains(t, tool.InputSchema.Properties, "make_latest")
+	assert.Contains(t, tool.InputSchema.Properties, "generate_release_notes")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposReleasesByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"tag_name":               "v2.0.0",
+				"target_commitish":       "release-2.0",
+				"name":                   "Version 2",
+				"draft":                  true,
+				"make_latest":            "false",
+				"generate_release_notes": true,
+			}).andThen(
+				mockResponse(t, http.StatusCreated, &github.RepositoryRelease{ID: github.Ptr(int64(9)), TagName: github.Ptr("v2.0.0")}),
+			),
+		),
+	))
+	_, handler := CreateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":                  "owner",
+		"repo":                   "repo",
+		"tag_name":               "v2.0.0",
+		"target_commitish":       "release-2.0",
+		"name":                   "Version 2",
+		"draft":                  true,
+		"make_latest":            "false",
+		"generate_release_notes": true,
+	}))
+	require.NoError(t, err)
+	var release github.RepositoryRelease
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &release))
+	assert.Equal(t, int64(9), release.GetID())
+}
+
+func Test_UpdateRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "tag_name")
+	assert.NotContains(t, tool.InputSchema.Properties, "generate_release_notes")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+
+	// Only the given fields are sent, so publishing a draft keeps its notes.
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+			expectRequestBody(t, map[string]any{
+				"draft": false,
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.RepositoryRelease{ID: github.Ptr(int64(9)), Draft: github.Ptr(false)}),
+			),
+		),
+	))
+	_, handler := UpdateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"release_id": float64(9),
+		"draft":      false,
+	}))
+	require.NoError(t, err)
+	var release github.RepositoryRelease
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &release))
+	assert.False(t, release.GetDraft())
+}
+
+func Test_DeleteRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_release", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposReleasesByOwnerByRepoByReleaseId,
+			expectPath(t, "/repos/owner/repo/releases/9").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	))
+	_, handler := DeleteRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"release_id": float64(9),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "release 9 deleted", getTextResult(t, result).Text)
+}
+
+func Test_UploadReleaseAsset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UploadReleaseAsset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "upload_release_asset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "encoding")
+	assert.Contains(t, tool.InputSchema.Properties, "content_type")
+	assert.Contains(t, tool.InputSchema.Properties, "label")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id", "name", "content"})
+
+	tests := []struct {
+		name                string
+		requestArgs         map[string]interface{}
+		expectedContent     string
+		expectedContentType string
+		expectedQuery       map[string]string
+		expectedErrMsg      string
+	}{
+		{
+			name: "text content with detected content type",
+			requestArgs: map[string]interface{}{
+				"name":    "checksums.txt",
+				"content": "abc123  app.tar.gz\n",
+			},
+			expectedContent:     "abc123  app.tar.gz\n",
+			expectedContentType: "text/plain; charset=utf-8",
+			expectedQuery:       map[string]string{"name": "checksums.txt"},
+		},
+		{
+			name: "base64 content with label",
+			requestArgs: map[string]interface{}{
+				"name":         "app.bin",
+				"content":      "AAEC",
+				"encoding":     "base64",
+				"content_type": "application/octet-stream",
+				"label":        "App binary",
+			},
+			expectedContent:     "\x00\x01\x02",
+			expectedContentType: "application/octet-stream",
+			expectedQuery:       map[string]string{"name": "app.bin", "label": "App binary"},
+		},
+		{
+			name: "invalid base64 content",
+			requestArgs: map[string]interface{}{
+				"name":     "app.bin",
+				"content":  "not base64!",
+				"encoding": "base64",
+			},
+			expectedErrMsg: "failed to decode base64 content",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesAssetsByOwnerByRepoByReleaseId,
+					expectQueryParams(t, tc.expectedQuery).andThen(
+						func(w http.ResponseWriter, r *http.Request) {
+							body, err := io.ReadAll(r.Body)
+							require.NoError(t, err)
+							assert.Equal(t, tc.expectedContent, string(body))
+							assert.Equal(t, tc.expectedContentType, r.Header.Get("Content-Type"))
+							mockResponse(t, http.StatusCreated, &github.ReleaseAsset{ID: github.Ptr(int64(3)), Name: github.Ptr(tc.expectedQuery["name"])})(w, r)
+						},
+					),
+				),
+			))
+			_, handler := UploadReleaseAsset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(9),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var asset github.ReleaseAsset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &asset))
+			assert.Equal(t, int64(3), asset.GetID())
+		})
+	}
+}
+
+func Test_DownloadReleaseAsset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DownloadReleaseAsset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "download_release_asset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "asset_id"})
+
+	// The asset metadata and its content are served from the same URL, depending on the
+	// Accept header.
+	assetHandler := func(asset *github.ReleaseAsset, content []byte) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Accept") == "application/octet-stream" {
+				_, _ = w.Write(content)
+				return
+			}
+			mockResponse(t, http.StatusOK, asset)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		asset          *github.ReleaseAsset
+		content        []byte
+		expectedResult interface{}
+		expectedErrMsg string
+	}{
+		{
+			name: "text asset",
+			asset: &github.ReleaseAsset{
+				Name:               github.Ptr("checksums.txt"),
+				ContentType:        github.Ptr("text/plain"),
+				Size:               github.Ptr(19),
+				BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1/checksums.txt"),
+			},
+			content: []byte("abc123  app.tar.gz\n"),
+			expectedResult: mcp.TextResourceContents{
+				URI:      "https://github.com/owner/repo/releases/download/v1/checksums.txt",
+				Text:     "abc123  app.tar.gz\n",
+				MIMEType: "text/plain",
+			},
+		},
+		{
+			name: "binary asset",
+			asset: &github.ReleaseAsset{
+				Name:               github.Ptr("app.gz"),
+				ContentType:        github.Ptr("application/gzip"),
+				Size:               github.Ptr(3),
+				BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1/app.gz"),
+			},
+			content: []byte{0x1f, 0x8b, 0x08},
+			expectedResult: mcp.BlobResourceContents{
+				URI:      "https://github.com/owner/repo/releases/download/v1/app.gz",
+				Blob:     "H4sI",
+				MIMEType: "application/gzip",
+			},
+		},
+		{
+			name: "asset too large",
+			asset: &github.ReleaseAsset{
+				Name:               github.Ptr("image.iso"),
+				Size:               github.Ptr(1 << 30),
+				BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1/image.iso"),
+			},
+			expectedErrMsg: "download it from https://github.com/owner/repo/releases/download/v1/image.iso instead",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesAssetsByOwnerByRepoByAssetId,
+					assetHandler(tc.asset, tc.content),
+				),
+			))
+			_, handler := DownloadReleaseAsset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"asset_id": float64(3),
+			}))
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.Len(t, result.Content, 2)
+			resource, ok := result.Content[1].(mcp.EmbeddedResource)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedResult, resource.Resource)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/raw"
@@ -16,6 +17,7 @@ import (
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
 )
 
 func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
@@ -37,6 +39,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Required(),
 				mcp.Description("Commit SHA, branch name, or tag name"),
 			),
+			WithRawMediaFormat("Format to return the commit in. 'diff' and 'patch' return the raw diff or the commit as an email patch, e.g. to apply it elsewhere.", "diff", "patch"),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -52,6 +55,10 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			format, raw, err := optionalRawMediaFormat(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -66,6 +73,21 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+
+			if raw {
+				content, resp, err := getRawMedia(ctx, client, fmt.Sprintf("repos/%s/%s/commits/%s", owner, repo, url.PathEscape(sha)), format)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get commit %s: %s", format, sha),
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return rawMediaResult(fmt.Sprintf("repo://%s/%s/commits/%s.%s", owner, repo, sha, format), format, content), nil
+			}
+
 			commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, opts)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -89,7 +111,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
 		}
 }
 
@@ -179,7 +201,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
 		}
 }
 
@@ -250,10 +272,242 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// BranchDivergence describes how two refs have diverged since their merge base.
+type BranchDivergence struct {
+	Base         string `json:"base"`
+	Head         string `json:"head"`
+	MergeBaseSHA string `json:"merge_base_sha"`
+	// Status is one of "identical", "ahead", "behind" or "diverged", describing head relative to base.
+	Status   string `json:"status"`
+	AheadBy  int    `json:"ahead_by"`
+	BehindBy int    `json:"behind_by"`
+	// FastForward reports whether base can be fast-forwarded to head.
+	FastForward bool `json:"fast_forward"`
+}
+
+// GetBranchDivergence creates a tool to compare the history of two refs.
+func GetBranchDivergence(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_divergence",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_DIVERGENCE_DESCRIPTION", "Get the merge base of two branches, tags or commits, how many commits head is ahead of and behind base, and whether base can be fast-forwarded to head")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_DIVERGENCE_USER_TITLE", "Get branch divergence"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Base branch, tag or commit SHA"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Head branch, tag or commit SHA. Use owner:branch for a branch in a fork."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Only the counts and merge base are needed, so keep the commit list as small as possible.
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{PerPage: 1})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to compare refs",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			divergence := BranchDivergence{
+				Base:         base,
+				Head:         head,
+				MergeBaseSHA: comparison.GetMergeBaseCommit().GetSHA(),
+				Status:       comparison.GetStatus(),
+				AheadBy:      comparison.GetAheadBy(),
+				BehindBy:     comparison.GetBehindBy(),
+				FastForward:  comparison.GetBehindBy() == 0,
+			}
+
+			r, err := json.Marshal(divergence)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
 
+// ComparedCommit is a commit in the list returned by compare_refs.
+type ComparedCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author,omitempty"`
+	Date    string `json:"date,omitempty"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+// RefComparison is the result of compare_refs.
+type RefComparison struct {
+	BranchDivergence
+	TotalCommits int               `json:"total_commits"`
+	HTMLURL      string            `json:"html_url"`
+	Commits      []ComparedCommit  `json:"commits"`
+	Files        []PullRequestFile `json:"files"`
+}
+
+// CompareRefs creates a tool to list the commits and file changes between two refs.
+func CompareRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_refs",
+			mcp.WithDescription(t("TOOL_COMPARE_REFS_DESCRIPTION", "Compare two branches, tags or commits in a GitHub repository, returning ahead/behind counts, the commits in head that are not in base, and the changed files. Use this to find out what changed since a release.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_REFS_USER_TITLE", "Compare refs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Base branch, tag or commit SHA"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Head branch, tag or commit SHA. Use owner:branch for a branch in a fork."),
+			),
+			mcp.WithBoolean("include_patch",
+				mcp.Description("Include the patch of each file. Defaults to true"),
+			),
+			mcp.WithNumber("max_patch_size",
+				mcp.Description("Truncate the patch of each file to this many bytes. Truncated files are marked with patch_truncated. 0 means no limit"),
+				mcp.Min(0),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includePatch := true
+			if v, ok, err := OptionalParamOK[bool](request, "include_patch"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				includePatch = v
+			}
+			maxPatchSize, err := OptionalIntParam(request, "max_patch_size")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to compare refs",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := RefComparison{
+				BranchDivergence: BranchDivergence{
+					Base:         base,
+					Head:         head,
+					MergeBaseSHA: comparison.GetMergeBaseCommit().GetSHA(),
+					Status:       comparison.GetStatus(),
+					AheadBy:      comparison.GetAheadBy(),
+					BehindBy:     comparison.GetBehindBy(),
+					FastForward:  comparison.GetBehindBy() == 0,
+				},
+				TotalCommits: comparison.GetTotalCommits(),
+				HTMLURL:      comparison.GetHTMLURL(),
+				Commits:      make([]ComparedCommit, 0, len(comparison.Commits)),
+				Files:        pullRequestFiles(comparison.Files, includePatch, maxPatchSize),
+			}
+			for _, c := range comparison.Commits {
+				commit := ComparedCommit{
+					SHA:     c.GetSHA(),
+					Message: c.GetCommit().GetMessage(),
+					Author:  c.GetAuthor().GetLogin(),
+					HTMLURL: c.GetHTMLURL(),
+				}
+				if commit.Author == "" {
+					commit.Author = c.GetCommit().GetAuthor().GetName()
+				}
+				if date := c.GetCommit().GetAuthor().GetDate(); !date.IsZero() {
+					commit.Date = date.Format(time.RFC3339)
+				}
+				result.Commits = append(result.Commits, commit)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
 // CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
 func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_or_update_file",
@@ -340,6 +594,10 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+			if commitSignerFromContext(ctx) != nil {
+				// The contents API cannot sign commits, so signed ones go through the Git database API
+				return writeFileSigned(ctx, client, owner, repo, branch, path, content, sha, message)
+			}
 			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -445,150 +703,421 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
-// GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
-func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("get_file_contents",
-			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository")),
+// CreateRepositoryFromTemplate creates a tool to create a new repository from a template repository.
+func CreateRepositoryFromTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_from_template",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_DESCRIPTION", "Create a new GitHub repository from a template repository, in your account or an organization")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
-				ReadOnlyHint: ToBoolPtr(true),
+				Title:        t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_USER_TITLE", "Create repository from template"),
+				ReadOnlyHint: ToBoolPtr(false),
 			}),
-			mcp.WithString("owner",
+			mcp.WithString("template_owner",
 				mcp.Required(),
-				mcp.Description("Repository owner (username or organization)"),
+				mcp.Description("Owner of the template repository"),
 			),
-			mcp.WithString("repo",
+			mcp.WithString("template_repo",
 				mcp.Required(),
-				mcp.Description("Repository name"),
+				mcp.Description("Name of the template repository"),
 			),
-			mcp.WithString("path",
-				mcp.Description("Path to file/directory (directories must end with a slash '/')"),
-				mcp.DefaultString("/"),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the new repository"),
 			),
-			mcp.WithString("ref",
-				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`"),
+			mcp.WithString("owner",
+				mcp.Description("User or organization to create the repository in. Defaults to the authenticated user"),
 			),
-			mcp.WithString("sha",
-				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			mcp.WithString("description",
+				mcp.Description("Repository description"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether repo should be private"),
+			),
+			mcp.WithBoolean("include_all_branches",
+				mcp.Description("Copy all branches of the template instead of only the default branch"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := RequiredParam[string](request, "owner")
+			templateOwner, err := RequiredParam[string](request, "template_owner")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			repo, err := RequiredParam[string](request, "repo")
+			templateRepo, err := RequiredParam[string](request, "template_repo")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			path, err := RequiredParam[string](request, "path")
+			name, err := RequiredParam[string](request, "name")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			ref, err := OptionalParam[string](request, "ref")
+			owner, err := OptionalParam[string](request, "owner")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			sha, err := OptionalParam[string](request, "sha")
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAllBranches, err := OptionalParam[bool](request, "include_all_branches")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			templateReq := &github.TemplateRepoRequest{
+				Name:               github.Ptr(name),
+				Private:            github.Ptr(private),
+				IncludeAllBranches: github.Ptr(includeAllBranches),
+			}
+			if owner != "" {
+				templateReq.Owner = github.Ptr(owner)
+			}
+			if description != "" {
+				templateReq.Description = github.Ptr(description)
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
-				return mcp.NewToolResultError("failed to get GitHub client"), nil
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			createdRepo, resp, err := client.Repositories.CreateFromTemplate(ctx, templateOwner, templateRepo, templateReq)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create repository from template %s/%s", templateOwner, templateRepo),
+					resp,
+					err,
+				), nil
 			}
+			defer func() { _ = resp.Body.Close() }()
 
-			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			r, err := json.Marshal(createdRepo)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			// If the path is (most likely) not to be a directory, we will
-			// first try to get the raw content from the GitHub raw content API.
-			if path != "" && !strings.HasSuffix(path, "/") {
-				// First, get file info from Contents API to retrieve SHA
-				var fileSHA string
-				opts := &github.RepositoryContentGetOptions{Ref: ref}
-				fileContent, _, respContents, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
-				if respContents != nil {
-					defer func() { _ = respContents.Body.Close() }()
-				}
-				if err != nil {
-					return ghErrors.NewGitHubAPIErrorResponse(ctx,
-						"failed to get file SHA",
-						respContents,
-						err,
-					), nil
-				}
-				if fileContent == nil || fileContent.SHA == nil {
-					return mcp.NewToolResultError("file content SHA is nil"), nil
-				}
-				fileSHA = *fileContent.SHA
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
 
-				rawClient, err := getRawClient(ctx)
-				if err != nil {
-					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
-				}
-				resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
-				if err != nil {
-					return mcp.NewToolResultError("failed to get raw repository content"), nil
-				}
-				defer func() {
-					_ = resp.Body.Close()
-				}()
+// repositoryFromParams returns the repository settings set in the request, leaving the others nil
+// so that they are not changed.
+func repositoryFromParams(request mcp.CallToolRequest) (*github.Repository, bool, error) {
+	repository := &github.Repository{}
+	changed := false
+	for param, field := range map[string]**string{
+		"description":    &repository.Description,
+		"homepage":       &repository.Homepage,
+		"visibility":     &repository.Visibility,
+		"default_branch": &repository.DefaultBranch,
+	} {
+		value, ok, err := OptionalParamOK[string](request, param)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+			changed = true
+		}
+	}
+	for param, field := range map[string]**bool{
+		"has_issues":             &repository.HasIssues,
+		"has_wiki":               &repository.HasWiki,
+		"has_projects":           &repository.HasProjects,
+		"has_discussions":        &repository.HasDiscussions,
+		"allow_merge_commit":     &repository.AllowMergeCommit,
+		"allow_squash_merge":     &repository.AllowSquashMerge,
+		"allow_rebase_merge":     &repository.AllowRebaseMerge,
+		"allow_auto_merge":       &repository.AllowAutoMerge,
+		"delete_branch_on_merge": &repository.DeleteBranchOnMerge,
+	} {
+		value, ok, err := OptionalParamOK[bool](request, param)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+			changed = true
+		}
+	}
+	return repository, changed, nil
+}
 
-				if resp.StatusCode == http.StatusOK {
-					// If the raw content is found, return it directly
-					body, err := io.ReadAll(resp.Body)
-					if err != nil {
-						return mcp.NewToolResultError("failed to read response body"), nil
-					}
-					contentType := resp.Header.Get("Content-Type")
+// UpdateRepository creates a tool to change the settings of a repository.
+func UpdateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_repository",
+			mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_DESCRIPTION", "Update the settings of a GitHub repository, such as its description, topics, default branch, visibility, enabled features and allowed merge methods. Only the given settings are changed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_REPOSITORY_USER_TITLE", "Update repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Repository description"),
+			),
+			mcp.WithString("homepage",
+				mcp.Description("URL of the repository's homepage"),
+			),
+			mcp.WithArray("topics",
+				mcp.Description("Topics of the repository, replacing the current ones. Pass an empty list to remove all topics"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("default_branch",
+				mcp.Description("Name of the default branch. The branch must exist"),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Repository visibility. Internal is only available to organizations on GitHub Enterprise"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			mcp.WithBoolean("has_issues",
+				mcp.Description("Whether issues are enabled"),
+			),
+			mcp.WithBoolean("has_wiki",
+				mcp.Description("Whether the wiki is enabled"),
+			),
+			mcp.WithBoolean("has_projects",
+				mcp.Description("Whether projects are enabled"),
+			),
+			mcp.WithBoolean("has_discussions",
+				mcp.Description("Whether discussions are enabled"),
+			),
+			mcp.WithBoolean("allow_merge_commit",
+				mcp.Description("Whether pull requests can be merged with a merge commit"),
+			),
+			mcp.WithBoolean("allow_squash_merge",
+				mcp.Description("Whether pull requests can be squash merged"),
+			),
+			mcp.WithBoolean("allow_rebase_merge",
+				mcp.Description("Whether pull requests can be rebase merged"),
+			),
+			mcp.WithBoolean("allow_auto_merge",
+				mcp.Description("Whether pull requests can be set to merge automatically once requirements are met"),
+			),
+			mcp.WithBoolean("delete_branch_on_merge",
+				mcp.Description("Whether head branches are deleted automatically when pull requests are merged"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			settings, changed, err := repositoryFromParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, replaceTopics := request.GetArguments()["topics"]
+			if !changed && !replaceTopics {
+				return mcp.NewToolResultError("at least one setting to update must be given"), nil
+			}
 
-					var resourceURI string
-					switch {
-					case sha != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					case ref != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					default:
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					}
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
 
-					if strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text") {
-						result := mcp.TextResourceContents{
-							URI:      resourceURI,
-							Text:     string(body),
-							MIMEType: contentType,
-						}
-						// Include SHA in the result metadata
-						if fileSHA != "" {
-							return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded text file (SHA: %s)", fileSHA), result), nil
-						}
-						return mcp.NewToolResultResource("successfully downloaded text file", result), nil
-					}
+			var updatedRepo *github.Repository
+			if changed {
+				var resp *github.Response
+				updatedRepo, resp, err = client.Repositories.Edit(ctx, owner, repo, settings)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to update repository %s/%s", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+			} else {
+				var resp *github.Response
+				updatedRepo, resp, err = client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get repository %s/%s", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			if replaceTopics {
+				if topics == nil {
+					topics = []string{}
+				}
+				updatedTopics, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to replace topics of repository %s/%s", owner, repo),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				updatedRepo.Topics = updatedTopics
+			}
+
+			r, err := json.Marshal(updatedRepo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
+func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_contents",
+			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Path to file/directory (directories must end with a slash '/')"),
+				mcp.DefaultString("/"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line of a text file to return, starting at 1. Use with end_line to page through large files"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line of a text file to return. Defaults to the end of the file"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description("Cut text files short after the last whole line within this many bytes, reporting the line to continue from. 0 means no limit"),
+				mcp.Min(0),
+				mcp.DefaultNumber(defaultFileMaxBytes),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			window, err := fileWindowParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Local clones only hold the default branch.
+			if ref == "" && sha == "" {
+				if result := localFileContents(ctx, owner, repo, path, window); result != nil {
+					return result, nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub client"), nil
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			// If the path is (most likely) not to be a directory, we will
+			// first try to get the raw content from the GitHub raw content API.
+			if path != "" && !strings.HasSuffix(path, "/") {
+				// First, get file info from Contents API to retrieve SHA
+				var fileSHA string
+				opts := &github.RepositoryContentGetOptions{Ref: ref}
+				fileContent, _, respContents, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+				if respContents != nil {
+					defer func() { _ = respContents.Body.Close() }()
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get file SHA",
+						respContents,
+						err,
+					), nil
+				}
+				if fileContent == nil || fileContent.SHA == nil {
+					return mcp.NewToolResultError("file content SHA is nil"), nil
+				}
+				fileSHA = *fileContent.SHA
 
-					result := mcp.BlobResourceContents{
-						URI:      resourceURI,
-						Blob:     base64.StdEncoding.EncodeToString(body),
-						MIMEType: contentType,
+				// The same blob may have been downloaded before, at this or any other commit.
+				if blob, tier, ok := cachedBlob(ctx, owner, repo, fileSHA); ok {
+					result, err := fileContentsResult(owner, repo, path, ref, sha, fileSHA, blob.Content, blob.ContentType, window)
+					if result != nil {
+						setBlobCacheMeta(ctx, result, tier)
 					}
-					// Include SHA in the result metadata
-					if fileSHA != "" {
-						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded binary file (SHA: %s)", fileSHA), result), nil
+					return result, err
+				}
+
+				rawClient, err := getRawClient(ctx)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+				}
+				resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get raw repository content"), nil
+				}
+				defer func() {
+					_ = resp.Body.Close()
+				}()
+
+				if resp.StatusCode == http.StatusOK {
+					// If the raw content is found, return it directly
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return mcp.NewToolResultError("failed to read response body"), nil
 					}
-					return mcp.NewToolResultResource("successfully downloaded binary file", result), nil
+					contentType := resp.Header.Get("Content-Type")
+					cacheBlob(ctx, owner, repo, fileSHA, body, contentType)
 
+					return fileContentsResult(owner, repo, path, ref, sha, fileSHA, body, contentType, window)
 				}
 			}
 
@@ -641,24 +1170,125 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 		}
 }
 
-// ForkRepository creates a tool to fork a repository.
-func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("fork_repository",
-			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization")),
+// fileContentsResult returns the contents of a file downloaded by get_file_contents as a text
+// or binary resource, depending on its content type.
+// Text files are limited to the lines that opts select.
+func fileContentsResult(owner, repo, path, ref, sha, fileSHA string, body []byte, contentType string, opts fileWindowOptions) (*mcp.CallToolResult, error) {
+	var window *FileWindow
+	if isTextContentType(contentType) {
+		var err error
+		body, window, err = windowFile(body, opts)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	resource, err := fileResourceContents(owner, repo, path, ref, sha, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := "binary"
+	if _, ok := resource.(mcp.TextResourceContents); ok {
+		kind = "text"
+	}
+	text := fmt.Sprintf("successfully downloaded %s file", kind)
+	// Include SHA in the result metadata
+	if fileSHA != "" {
+		text = fmt.Sprintf("successfully downloaded %s file (SHA: %s)", kind, fileSHA)
+	}
+	if window == nil {
+		return mcp.NewToolResultResource(text, resource), nil
+	}
+	result := mcp.NewToolResultResource(text+"; "+window.describe(), resource)
+	setResultMeta(result, "content_window", window)
+	return result, nil
+}
+
+// fileResourceContents wraps a downloaded file in a repo:// resource, as text for textual content
+// types and base64 encoded otherwise.
+func fileResourceContents(owner, repo, path, ref, sha string, body []byte, contentType string) (mcp.ResourceContents, error) {
+	var resourceURI string
+	var err error
+	switch {
+	case sha != "":
+		resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
+	case ref != "":
+		resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
+	default:
+		resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource URI: %w", err)
+	}
+
+	if isTextContentType(contentType) {
+		return mcp.TextResourceContents{
+			URI:      resourceURI,
+			Text:     string(body),
+			MIMEType: contentType,
+		}, nil
+	}
+	return mcp.BlobResourceContents{
+		URI:      resourceURI,
+		Blob:     base64.StdEncoding.EncodeToString(body),
+		MIMEType: contentType,
+	}, nil
+}
+
+func isTextContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text")
+}
+
+// RepositoryTreeEntry is a single entry of a repository tree returned by get_repository_tree.
+type RepositoryTreeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size,omitempty"`
+	SHA  string `json:"sha"`
+}
+
+// RepositoryTree is the result of get_repository_tree.
+type RepositoryTree struct {
+	SHA       string                `json:"sha"`
+	Entries   []RepositoryTreeEntry `json:"entries"`
+	Truncated bool                  `json:"truncated"`
+}
+
+const defaultMaxTreeEntries = 1000
+
+// GetRepositoryTree creates a tool to list the git tree of a repository, optionally limited to a
+// subdirectory.
+func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_tree",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TREE_DESCRIPTION", "Get the tree of files and directories in a GitHub repository in a single call. Use this to explore the layout of a codebase instead of listing directories one at a time with get_file_contents.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_FORK_REPOSITORY_USER_TITLE", "Fork repository"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:        t("TOOL_GET_REPOSITORY_TREE_USER_TITLE", "Get repository tree"),
+				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
-				mcp.Description("Repository owner"),
+				mcp.Description("Repository owner (username or organization)"),
 			),
 			mcp.WithString("repo",
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("organization",
-				mcp.Description("Organization to fork to"),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or a branch name. Defaults to the default branch"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithString("path_filter",
+				mcp.Description("Only return entries under this directory, e.g. 'pkg/github'"),
+			),
+			mcp.WithBoolean("recursive",
+				mcp.Description("Include the contents of subdirectories"),
+				mcp.DefaultBool(true),
+			),
+			mcp.WithNumber("max_entries",
+				mcp.Description(fmt.Sprintf("Maximum number of entries to return (default %d)", defaultMaxTreeEntries)),
+				mcp.Min(1),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -670,44 +1300,507 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			org, err := OptionalParam[string](request, "organization")
+			ref, err := OptionalParam[string](request, "ref")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			opts := &github.RepositoryCreateForkOptions{}
-			if org != "" {
-				opts.Organization = org
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pathFilter, err := OptionalParam[string](request, "path_filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			recursive := true
+			if v, ok, err := OptionalParamOK[bool](request, "recursive"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				recursive = v
+			}
+			maxEntries, err := OptionalIntParamWithDefault(request, "max_entries", defaultMaxTreeEntries)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub client"), nil
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			// A subdirectory can only be reached through the recursive tree, so fetch
+			// that and keep just its direct children when recursion is disabled.
+			pathFilter = strings.Trim(pathFilter, "/")
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, rawOpts.SHA, recursive || pathFilter != "")
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get git tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := RepositoryTree{
+				SHA:       rawOpts.SHA,
+				Entries:   []RepositoryTreeEntry{},
+				Truncated: tree.GetTruncated(),
+			}
+			for _, entry := range tree.Entries {
+				entryPath := entry.GetPath()
+				if pathFilter != "" {
+					rel, ok := strings.CutPrefix(entryPath, pathFilter+"/")
+					if !ok || (!recursive && strings.Contains(rel, "/")) {
+						continue
+					}
+				}
+				if len(result.Entries) == maxEntries {
+					result.Truncated = true
+					break
+				}
+				result.Entries = append(result.Entries, RepositoryTreeEntry{
+					Path: entryPath,
+					Type: entry.GetType(),
+					Size: entry.GetSize(),
+					SHA:  entry.GetSHA(),
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const maxMultipleFiles = 20
+
+// GetMultipleFileContents creates a tool to fetch several files from a GitHub repository in one call.
+func GetMultipleFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_multiple_file_contents",
+			mcp.WithDescription(t("TOOL_GET_MULTIPLE_FILE_CONTENTS_DESCRIPTION", fmt.Sprintf("Get the contents of up to %d files from a GitHub repository in a single call. Files that cannot be fetched are reported without failing the whole call.", maxMultipleFiles))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MULTIPLE_FILE_CONTENTS_USER_TITLE", "Get multiple file contents"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("paths",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Paths of the files to fetch (at most %d)", maxMultipleFiles)),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or a branch name. Defaults to the default branch"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithString("format",
+				mcp.Description("'concatenated' returns all text files in a single text block with a header per file, 'resources' returns one embedded resource per file"),
+				mcp.Enum("concatenated", "resources"),
+				mcp.DefaultString("concatenated"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(paths) == 0 {
+				return mcp.NewToolResultError("paths must contain at least one file path"), nil
+			}
+			if len(paths) > maxMultipleFiles {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d paths can be fetched at once, got %d", maxMultipleFiles, len(paths))), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if format == "" {
+				format = "concatenated"
+			}
+			if format != "concatenated" && format != "resources" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid format %q, must be 'concatenated' or 'resources'", format)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub client"), nil
+			}
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+			}
+
+			// Resolve the ref once so every file is read from the same commit.
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			var text strings.Builder
+			content := []mcp.Content{}
+			var failed []string
+			for _, path := range paths {
+				path = strings.TrimPrefix(path, "/")
+				body, contentType, err := getRawFile(ctx, rawClient, owner, repo, path, rawOpts)
+				if err != nil {
+					failed = append(failed, fmt.Sprintf("%s: %s", path, err))
+					if format == "concatenated" {
+						fmt.Fprintf(&text, "=== %s ===\n(error: %s)\n\n", path, err)
+					}
+					continue
+				}
+
+				if format == "resources" {
+					resource, err := fileResourceContents(owner, repo, path, ref, sha, body, contentType)
+					if err != nil {
+						return nil, err
+					}
+					content = append(content, mcp.NewEmbeddedResource(resource))
+					continue
+				}
+
+				fmt.Fprintf(&text, "=== %s ===\n", path)
+				if isTextContentType(contentType) {
+					text.Write(body)
+					if len(body) > 0 && body[len(body)-1] != '\n' {
+						text.WriteByte('\n')
+					}
+				} else {
+					fmt.Fprintf(&text, "(binary content omitted: %s, %d bytes)\n", contentType, len(body))
+				}
+				text.WriteByte('\n')
+			}
+
+			summary := fmt.Sprintf("fetched %d of %d files at %s", len(paths)-len(failed), len(paths), rawOpts.SHA)
+			if format == "concatenated" {
+				return mcp.NewToolResultText(summary + "\n\n" + text.String()), nil
+			}
+			if len(failed) > 0 {
+				summary += "\nfailed:\n" + strings.Join(failed, "\n")
+			}
+			return &mcp.CallToolResult{
+				Content: append([]mcp.Content{mcp.NewTextContent(summary)}, content...),
+			}, nil
+		}
+}
+
+// getRawFile downloads a single file through the raw content API.
+func getRawFile(ctx context.Context, rawClient *raw.Client, owner, repo, path string, opts *raw.ContentOpts) ([]byte, string, error) {
+	resp, err := rawClient.GetRawContent(ctx, owner, repo, path, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get raw repository content: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, "", fmt.Errorf("file not found")
+	default:
+		return nil, "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// ForkRepository creates a tool to fork a repository.
+func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("fork_repository",
+			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FORK_REPOSITORY_USER_TITLE", "Fork repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("organization",
+				mcp.Description("Organization to fork to"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Name of the fork. Defaults to the name of the repository"),
+			),
+			mcp.WithBoolean("default_branch_only",
+				mcp.Description("Only copy the default branch into the fork"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "organization")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defaultBranchOnly, err := OptionalParam[bool](request, "default_branch_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.RepositoryCreateForkOptions{
+				Name:              name,
+				DefaultBranchOnly: defaultBranchOnly,
+			}
+			if org != "" {
+				opts.Organization = org
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, owner, repo, opts)
+			if err != nil {
+				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
+				// and it's not a real error.
+				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+					return mcp.NewToolResultText("Fork is in progress"), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to fork repository",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusAccepted {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(forkedRepo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteFile creates a tool to delete a file in a GitHub repository.
+// This tool uses a more roundabout way of deleting a file than just using the client.Repositories.DeleteFile.
+// This is because REST file deletion endpoint (and client.Repositories.DeleteFile) don't add commit signing to the deletion commit,
+// unlike how the endpoint backing the create_or_update_files tool does. This appears to be a quirk of the API.
+// The approach implemented here gets automatic commit signing when used with either the github-actions user or as an app,
+// both of which suit an LLM well.
+func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_file",
+			mcp.WithDescription(t("TOOL_DELETE_FILE_DESCRIPTION", "Delete a file from a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_FILE_USER_TITLE", "Delete file"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file to delete"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to delete the file from"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Get the reference for the branch
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Get the commit object that the branch points to
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get base commit",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
+			}
+
+			// Create a tree entry for the file deletion by setting SHA to nil
+			treeEntries := []*github.TreeEntry{
+				{
+					Path: github.Ptr(path),
+					Mode: github.Ptr("100644"), // Regular file mode
+					Type: github.Ptr("blob"),
+					SHA:  nil, // Setting SHA to nil deletes the file
+				},
+			}
+
+			// Create a new tree with the deletion
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, treeEntries)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create tree: %s", string(body))), nil
+			}
+
+			// Create a new commit with the new tree
+			commit := &github.Commit{
+				Message: github.Ptr(message),
+				Tree:    newTree,
+				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+			}
+			newCommit, resp, err := createCommit(ctx, client, owner, repo, commit)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create commit",
+					resp,
+					err,
+				), nil
 			}
+			defer func() { _ = resp.Body.Close() }()
 
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create commit: %s", string(body))), nil
 			}
-			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, owner, repo, opts)
+
+			// Update the branch reference to point to the new commit
+			ref.Object.SHA = newCommit.SHA
+			_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
 			if err != nil {
-				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
-				// and it's not a real error.
-				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Fork is in progress"), nil
-				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to fork repository",
+					"failed to update reference",
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusAccepted {
+			if resp.StatusCode != http.StatusOK {
 				body, err := io.ReadAll(resp.Body)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update reference: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(forkedRepo)
+			// Create a response similar to what the DeleteFile API would return
+			response := map[string]interface{}{
+				"commit":  newCommit,
+				"content": nil,
+			}
+
+			r, err := json.Marshal(response)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -716,19 +1809,13 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 		}
 }
 
-// DeleteFile creates a tool to delete a file in a GitHub repository.
-// This tool uses a more roundabout way of deleting a file than just using the client.Repositories.DeleteFile.
-// This is because REST file deletion endpoint (and client.Repositories.DeleteFile) don't add commit signing to the deletion commit,
-// unlike how the endpoint backing the create_or_update_files tool does. This appears to be a quirk of the API.
-// The approach implemented here gets automatic commit signing when used with either the github-actions user or as an app,
-// both of which suit an LLM well.
-func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("delete_file",
-			mcp.WithDescription(t("TOOL_DELETE_FILE_DESCRIPTION", "Delete a file from a GitHub repository")),
+// MoveFile creates a tool to rename or move a file in a single commit.
+func MoveFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("move_file",
+			mcp.WithDescription(t("TOOL_MOVE_FILE_DESCRIPTION", "Rename or move a file in a GitHub repository as a single commit, so that the change is shown as a rename. Optionally apply literal search and replace edits to the file content as part of the move.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:           t("TOOL_DELETE_FILE_USER_TITLE", "Delete file"),
-				ReadOnlyHint:    ToBoolPtr(false),
-				DestructiveHint: ToBoolPtr(true),
+				Title:        t("TOOL_MOVE_FILE_USER_TITLE", "Move file"),
+				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -738,17 +1825,40 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("path",
+			mcp.WithString("branch",
 				mcp.Required(),
-				mcp.Description("Path to the file to delete"),
+				mcp.Description("Branch to move the file on"),
+			),
+			mcp.WithString("from_path",
+				mcp.Required(),
+				mcp.Description("Current path of the file"),
+			),
+			mcp.WithString("to_path",
+				mcp.Required(),
+				mcp.Description("New path of the file"),
 			),
 			mcp.WithString("message",
 				mcp.Required(),
 				mcp.Description("Commit message"),
 			),
-			mcp.WithString("branch",
-				mcp.Required(),
-				mcp.Description("Branch to delete the file from"),
+			mcp.WithArray("replacements",
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"search", "replace"},
+						"properties": map[string]interface{}{
+							"search": map[string]interface{}{
+								"type":        "string",
+								"description": "text to search for",
+							},
+							"replace": map[string]interface{}{
+								"type":        "string",
+								"description": "text to replace every occurrence with",
+							},
+						},
+					}),
+				mcp.Description("Optional edits to the file content, applied in order. Each search text must occur in the file."),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -760,18 +1870,48 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			path, err := RequiredParam[string](request, "path")
+			branch, err := RequiredParam[string](request, "branch")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			message, err := RequiredParam[string](request, "message")
+			fromPath, err := RequiredParam[string](request, "from_path")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := RequiredParam[string](request, "branch")
+			toPath, err := RequiredParam[string](request, "to_path")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if fromPath == toPath {
+				return mcp.NewToolResultError("from_path and to_path must be different"), nil
+			}
+
+			var replacements [][2]string
+			if raw, ok := request.GetArguments()["replacements"]; ok && raw != nil {
+				items, ok := raw.([]interface{})
+				if !ok {
+					return mcp.NewToolResultError("replacements parameter must be an array of objects with search and replace"), nil
+				}
+				for _, item := range items {
+					itemMap, ok := item.(map[string]interface{})
+					if !ok {
+						return mcp.NewToolResultError("each replacement must be an object with search and replace"), nil
+					}
+					search, ok := itemMap["search"].(string)
+					if !ok || search == "" {
+						return mcp.NewToolResultError("each replacement must have a non-empty search"), nil
+					}
+					replace, ok := itemMap["replace"].(string)
+					if !ok {
+						return mcp.NewToolResultError("each replacement must have replace"), nil
+					}
+					replacements = append(replacements, [2]string{search, replace})
+				}
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -781,12 +1921,16 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			// Get the reference for the branch
 			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get branch reference",
+					resp,
+					err,
+				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, ref.GetObject().GetSHA())
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to get base commit",
@@ -796,26 +1940,75 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+			// Look up the source file to keep its mode and blob, and make sure the destination is free
+			baseTree, resp, err := client.Git.GetTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get base tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if baseTree.GetTruncated() {
+				return mcp.NewToolResultError("repository tree is too large to move a file in"), nil
+			}
+			var source *github.TreeEntry
+			for _, entry := range baseTree.Entries {
+				switch entry.GetPath() {
+				case fromPath:
+					source = entry
+				case toPath:
+					return mcp.NewToolResultError(fmt.Sprintf("%s already exists", toPath)), nil
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
+			}
+			if source == nil || source.GetType() != "blob" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is not a file on %s", fromPath, branch)), nil
 			}
 
-			// Create a tree entry for the file deletion by setting SHA to nil
+			// Delete the old path and add the new one in the same tree, so that the commit is a rename
+			destination := &github.TreeEntry{
+				Path: github.Ptr(toPath),
+				Mode: source.Mode,
+				Type: github.Ptr("blob"),
+				SHA:  source.SHA,
+			}
+			if len(replacements) > 0 {
+				rawContent, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, source.GetSHA())
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get file contents",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if isBinaryContent(rawContent) {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is a binary file and cannot be edited", fromPath)), nil
+				}
+
+				content := string(rawContent)
+				for _, r := range replacements {
+					if !strings.Contains(content, r[0]) {
+						return mcp.NewToolResultError(fmt.Sprintf("search text %q not found in %s", r[0], fromPath)), nil
+					}
+					content = strings.ReplaceAll(content, r[0], r[1])
+				}
+				destination.SHA = nil
+				destination.Content = github.Ptr(content)
+			}
 			treeEntries := []*github.TreeEntry{
 				{
-					Path: github.Ptr(path),
-					Mode: github.Ptr("100644"), // Regular file mode
+					Path: github.Ptr(fromPath),
+					Mode: source.Mode,
 					Type: github.Ptr("blob"),
 					SHA:  nil, // Setting SHA to nil deletes the file
 				},
+				destination,
 			}
 
-			// Create a new tree with the deletion
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, treeEntries)
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), treeEntries)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create tree",
@@ -825,21 +2018,13 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create tree: %s", string(body))), nil
-			}
-
 			// Create a new commit with the new tree
 			commit := &github.Commit{
 				Message: github.Ptr(message),
 				Tree:    newTree,
 				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
 			}
-			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			newCommit, resp, err := createCommit(ctx, client, owner, repo, commit)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create commit",
@@ -849,14 +2034,6 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create commit: %s", string(body))), nil
-			}
-
 			// Update the branch reference to point to the new commit
 			ref.Object.SHA = newCommit.SHA
 			_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
@@ -877,10 +2054,10 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(fmt.Sprintf("failed to update reference: %s", string(body))), nil
 			}
 
-			// Create a response similar to what the DeleteFile API would return
 			response := map[string]interface{}{
-				"commit":  newCommit,
-				"content": nil,
+				"commit":    newCommit,
+				"from_path": fromPath,
+				"to_path":   toPath,
 			}
 
 			r, err := json.Marshal(response)
@@ -996,7 +2173,7 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 // PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
 func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("push_files",
-			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple file additions, updates and deletions to a GitHub repository in a single commit")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -1019,7 +2196,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 					map[string]interface{}{
 						"type":                 "object",
 						"additionalProperties": false,
-						"required":             []string{"path", "content"},
+						"required":             []string{"path"},
 						"properties": map[string]interface{}{
 							"path": map[string]interface{}{
 								"type":        "string",
@@ -1027,11 +2204,20 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 							},
 							"content": map[string]interface{}{
 								"type":        "string",
-								"description": "file content",
+								"description": "file content, required unless the file is deleted",
+							},
+							"encoding": map[string]interface{}{
+								"type":        "string",
+								"enum":        []string{"utf-8", "base64"},
+								"description": "encoding of content, use base64 for binary files (default utf-8)",
+							},
+							"delete": map[string]interface{}{
+								"type":        "boolean",
+								"description": "delete the file instead of writing it",
 							},
 						},
 					}),
-				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
+				mcp.Description("Array of file objects to push, each object with path (string) and content (string), or delete (true) to remove the file"),
 			),
 			mcp.WithString("message",
 				mcp.Required(),
@@ -1103,18 +2289,51 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 					return mcp.NewToolResultError("each file must have a path"), nil
 				}
 
+				if del, _ := fileMap["delete"].(bool); del {
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(path),
+						Mode: github.Ptr("100644"),
+						Type: github.Ptr("blob"),
+						SHA:  nil, // Setting SHA to nil deletes the file
+					})
+					continue
+				}
+
 				content, ok := fileMap["content"].(string)
 				if !ok {
 					return mcp.NewToolResultError("each file must have content"), nil
 				}
 
-				// Create a tree entry for the file
-				entries = append(entries, &github.TreeEntry{
-					Path:    github.Ptr(path),
-					Mode:    github.Ptr("100644"), // Regular file mode
-					Type:    github.Ptr("blob"),
-					Content: github.Ptr(content),
-				})
+				entry := &github.TreeEntry{
+					Path: github.Ptr(path),
+					Mode: github.Ptr("100644"), // Regular file mode
+					Type: github.Ptr("blob"),
+				}
+				switch encoding, _ := fileMap["encoding"].(string); encoding {
+				case "", "utf-8":
+					entry.Content = github.Ptr(content)
+				case "base64":
+					// The tree API only accepts text content, so binary files are uploaded as blobs first.
+					if _, err := base64.StdEncoding.DecodeString(content); err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("content of %s is not valid base64: %s", path, err)), nil
+					}
+					blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+						Content:  github.Ptr(content),
+						Encoding: github.Ptr("base64"),
+					})
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to create blob: %s", path),
+							resp,
+							err,
+						), nil
+					}
+					_ = resp.Body.Close()
+					entry.SHA = blob.SHA
+				default:
+					return mcp.NewToolResultError(fmt.Sprintf("unsupported encoding %q for %s, must be 'utf-8' or 'base64'", encoding, path)), nil
+				}
+				entries = append(entries, entry)
 			}
 
 			// Create a new tree with the file entries
@@ -1134,7 +2353,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				Tree:    newTree,
 				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
 			}
-			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			newCommit, resp, err := createCommit(ctx, client, owner, repo, commit)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create commit",
@@ -1165,6 +2384,185 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 		}
 }
 
+// CreateCommitOnBranch creates a tool to commit file changes to a branch with the createCommitOnBranch
+// GraphQL mutation, which only succeeds if the branch still points to the expected commit.
+func CreateCommitOnBranch(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_on_branch",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_ON_BRANCH_DESCRIPTION", "Atomically commit file additions, updates and deletions to a branch, as long as its head is still the expected commit. Commits are authored by the authenticated user and signed by GitHub. If the branch has moved, nothing is committed: get the new head, check the changes still apply and try again")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_COMMIT_ON_BRANCH_USER_TITLE", "Create commit on branch"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to commit to"),
+			),
+			mcp.WithString("expectedHeadOid",
+				mcp.Required(),
+				mcp.Description("SHA of the commit the branch is expected to point to, such as the commit of the branch from list_branches"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message, its first line being the headline"),
+			),
+			mcp.WithArray("additions",
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path", "content"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "path to the file",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "file content",
+							},
+							"encoding": map[string]interface{}{
+								"type":        "string",
+								"enum":        []string{"utf-8", "base64"},
+								"description": "encoding of content, use base64 for binary files (default utf-8)",
+							},
+						},
+					}),
+				mcp.Description("Files to create or replace, each with path and content"),
+			),
+			mcp.WithArray("deletions",
+				mcp.Items(map[string]interface{}{"type": "string"}),
+				mcp.Description("Paths of files to delete, which must exist"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedHeadOid, err := RequiredParam[string](request, "expectedHeadOid")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deletionPaths, err := OptionalStringArrayParam(request, "deletions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var additions []githubv4.FileAddition
+			if v, ok := request.GetArguments()["additions"]; ok && v != nil {
+				additionsObj, ok := v.([]interface{})
+				if !ok {
+					return mcp.NewToolResultError("additions parameter must be an array of objects with path and content"), nil
+				}
+				for _, addition := range additionsObj {
+					additionMap, ok := addition.(map[string]interface{})
+					if !ok {
+						return mcp.NewToolResultError("each addition must be an object with path and content"), nil
+					}
+					path, ok := additionMap["path"].(string)
+					if !ok || path == "" {
+						return mcp.NewToolResultError("each addition must have a path"), nil
+					}
+					content, ok := additionMap["content"].(string)
+					if !ok {
+						return mcp.NewToolResultError(fmt.Sprintf("addition %s must have content", path)), nil
+					}
+					// The mutation takes the contents of every file base64 encoded
+					switch encoding, _ := additionMap["encoding"].(string); encoding {
+					case "", "utf-8":
+						content = base64.StdEncoding.EncodeToString([]byte(content))
+					case "base64":
+						if _, err := base64.StdEncoding.DecodeString(content); err != nil {
+							return mcp.NewToolResultError(fmt.Sprintf("content of %s is not valid base64: %s", path, err)), nil
+						}
+					default:
+						return mcp.NewToolResultError(fmt.Sprintf("unsupported encoding %q for %s, must be 'utf-8' or 'base64'", encoding, path)), nil
+					}
+					additions = append(additions, githubv4.FileAddition{
+						Path:     githubv4.String(path),
+						Contents: githubv4.Base64String(content),
+					})
+				}
+			}
+			deletions := make([]githubv4.FileDeletion, 0, len(deletionPaths))
+			for _, path := range deletionPaths {
+				deletions = append(deletions, githubv4.FileDeletion{Path: githubv4.String(path)})
+			}
+			if len(additions) == 0 && len(deletions) == 0 {
+				return mcp.NewToolResultError("at least one addition or deletion is required"), nil
+			}
+
+			// The headline of the message is its first line, and the rest its body
+			headline, body, _ := strings.Cut(message, "\n")
+			commitMessage := githubv4.CommitMessage{Headline: githubv4.String(headline)}
+			if body = strings.TrimSpace(body); body != "" {
+				commitMessage.Body = githubv4.NewString(githubv4.String(body))
+			}
+			fileChanges := &githubv4.FileChanges{}
+			if len(additions) > 0 {
+				fileChanges.Additions = &additions
+			}
+			if len(deletions) > 0 {
+				fileChanges.Deletions = &deletions
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				CreateCommitOnBranch struct {
+					Commit struct {
+						OID githubv4.GitObjectID `graphql:"oid"`
+						URL githubv4.URI         `graphql:"url"`
+					}
+				} `graphql:"createCommitOnBranch(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.CreateCommitOnBranchInput{
+				Branch: githubv4.CommittableBranch{
+					RepositoryNameWithOwner: githubv4.NewString(githubv4.String(owner + "/" + repo)),
+					BranchName:              githubv4.NewString(githubv4.String(branch)),
+				},
+				Message:         commitMessage,
+				ExpectedHeadOid: githubv4.GitObjectID(expectedHeadOid),
+				FileChanges:     fileChanges,
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to create commit on branch",
+					err,
+				), nil
+			}
+
+			commit := mutation.CreateCommitOnBranch.Commit
+			return MarshalledTextResult(map[string]any{
+				"sha":    string(commit.OID),
+				"url":    commit.URL.String(),
+				"branch": branch,
+			}), nil
+		}
+}
+
 // ListTags creates a tool to list tags in a GitHub repository.
 func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_tags",
@@ -1230,7 +2628,7 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
 		}
 }
 
@@ -1382,7 +2780,7 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
 		}
 }
 
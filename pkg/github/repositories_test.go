@@ -10,12 +10,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -112,6 +114,53 @@ func Test_GetFileContents(t *testing.T) {
 				MIMEType: "text/markdown",
 			},
 		},
+		{
+			name: "text content window",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						fileContent := &github.RepositoryContent{
+							Name: github.Ptr("README.md"),
+							Path: github.Ptr("README.md"),
+							SHA:  github.Ptr("abc123"),
+							Type: github.Ptr("file"),
+						}
+						contentBytes, _ := json.Marshal(fileContent)
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/markdown")
+						_, _ = w.Write(mockRawContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "README.md",
+				"ref":        "refs/heads/main",
+				"start_line": float64(3),
+				"end_line":   float64(3),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/README.md",
+				Text:     "This is a test repository.",
+				MIMEType: "text/markdown",
+			},
+		},
 		{
 			name: "successful file blob content fetch",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -282,6 +331,265 @@ func Test_GetFileContents(t *testing.T) {
 	}
 }
 
+func Test_GetRepositoryTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_tree", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "path_filter")
+	assert.Contains(t, tool.InputSchema.Properties, "recursive")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("tree123"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Size: github.Ptr(42), SHA: github.Ptr("a1")},
+			{Path: github.Ptr("pkg"), Type: github.Ptr("tree"), SHA: github.Ptr("b1")},
+			{Path: github.Ptr("pkg/github"), Type: github.Ptr("tree"), SHA: github.Ptr("b2")},
+			{Path: github.Ptr("pkg/github/server.go"), Type: github.Ptr("blob"), Size: github.Ptr(100), SHA: github.Ptr("c1")},
+			{Path: github.Ptr("pkg/main.go"), Type: github.Ptr("blob"), Size: github.Ptr(10), SHA: github.Ptr("c2")},
+			{Path: github.Ptr("pkgs/other.go"), Type: github.Ptr("blob"), Size: github.Ptr(5), SHA: github.Ptr("c3")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		treeResponse   http.HandlerFunc
+		expectError    bool
+		expectedPaths  []string
+		expectTrunc    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "full recursive tree",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			},
+			treeResponse: expectQueryParams(t, map[string]string{"recursive": "1"}).andThen(
+				mockResponse(t, http.StatusOK, mockTree),
+			),
+			expectedPaths: []string{"README.md", "pkg", "pkg/github", "pkg/github/server.go", "pkg/main.go", "pkgs/other.go"},
+		},
+		{
+			name: "filtered to a directory",
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"sha":         "abc123",
+				"path_filter": "pkg/",
+			},
+			treeResponse:  mockResponse(t, http.StatusOK, mockTree),
+			expectedPaths: []string{"pkg/github", "pkg/github/server.go", "pkg/main.go"},
+		},
+		{
+			name: "direct children only",
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"sha":         "abc123",
+				"path_filter": "pkg",
+				"recursive":   false,
+			},
+			treeResponse:  mockResponse(t, http.StatusOK, mockTree),
+			expectedPaths: []string{"pkg/github", "pkg/main.go"},
+		},
+		{
+			name: "limited number of entries",
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"sha":         "abc123",
+				"max_entries": float64(2),
+			},
+			treeResponse:  mockResponse(t, http.StatusOK, mockTree),
+			expectedPaths: []string{"README.md", "pkg"},
+			expectTrunc:   true,
+		},
+		{
+			name: "tree not found",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			},
+			treeResponse:   mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			expectError:    true,
+			expectedErrMsg: "failed to get git tree",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expectPath(t, "/repos/owner/repo/git/trees/abc123").andThen(tc.treeResponse),
+				),
+			))
+			_, handler := GetRepositoryTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var tree RepositoryTree
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &tree))
+			assert.Equal(t, "abc123", tree.SHA)
+			assert.Equal(t, tc.expectTrunc, tree.Truncated)
+			paths := make([]string, 0, len(tree.Entries))
+			for _, entry := range tree.Entries {
+				paths = append(paths, entry.Path)
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+		})
+	}
+}
+
+func Test_GetMultipleFileContents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := GetMultipleFileContents(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_multiple_file_contents", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "paths"})
+
+	rawFiles := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/owner/repo/abc123/README.md":
+			w.Header().Set("Content-Type", "text/markdown")
+			_, _ = w.Write([]byte("# Title"))
+		case "/owner/repo/abc123/logo.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte{0x89, 0x50, 0x4e, 0x47})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		check          func(t *testing.T, result *mcp.CallToolResult)
+	}{
+		{
+			name: "concatenated text",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"paths": []interface{}{"README.md", "logo.png", "missing.go"},
+			},
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				text := getTextResult(t, result).Text
+				assert.Contains(t, text, "fetched 2 of 3 files at abc123")
+				assert.Contains(t, text, "=== README.md ===\n# Title\n")
+				assert.Contains(t, text, "=== logo.png ===\n(binary content omitted: image/png, 4 bytes)")
+				assert.Contains(t, text, "=== missing.go ===\n(error: file not found)")
+			},
+		},
+		{
+			name: "embedded resources",
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"sha":    "abc123",
+				"paths":  []interface{}{"README.md", "logo.png", "missing.go"},
+				"format": "resources",
+			},
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				require.Len(t, result.Content, 3)
+				summary, ok := result.Content[0].(mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, summary.Text, "fetched 2 of 3 files")
+				assert.Contains(t, summary.Text, "missing.go: file not found")
+
+				textResource, ok := result.Content[1].(mcp.EmbeddedResource)
+				require.True(t, ok)
+				assert.Equal(t, mcp.TextResourceContents{
+					URI:      "repo://owner/repo/sha/abc123/contents/README.md",
+					Text:     "# Title",
+					MIMEType: "text/markdown",
+				}, textResource.Resource)
+
+				blobResource, ok := result.Content[2].(mcp.EmbeddedResource)
+				require.True(t, ok)
+				assert.Equal(t, mcp.BlobResourceContents{
+					URI:      "repo://owner/repo/sha/abc123/contents/logo.png",
+					Blob:     base64.StdEncoding.EncodeToString([]byte{0x89, 0x50, 0x4e, 0x47}),
+					MIMEType: "image/png",
+				}, blobResource.Resource)
+			},
+		},
+		{
+			name: "too many paths",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": func() []interface{} {
+					paths := make([]interface{}, maxMultipleFiles+1)
+					for i := range paths {
+						paths[i] = "file.go"
+					}
+					return paths
+				}(),
+			},
+			expectError:    true,
+			expectedErrMsg: "at most 20 paths can be fetched at once",
+		},
+		{
+			name: "invalid format",
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"paths":  []interface{}{"README.md"},
+				"format": "zip",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid format",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(raw.GetRawReposContentsByOwnerByRepoBySHAByPath, rawFiles),
+			))
+			rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetMultipleFileContents(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			tc.check(t, result)
+		})
+	}
+}
+
 func Test_ForkRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -293,6 +601,8 @@ func Test_ForkRepository(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "organization")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "default_branch_only")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Setup mock forked repo for success case
@@ -332,6 +642,30 @@ func Test_ForkRepository(t *testing.T) {
 			expectError:  false,
 			expectedRepo: mockForkedRepo,
 		},
+		{
+			name: "fork default branch into organization under a new name",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposForksByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"organization":        "new-owner",
+						"name":                "repo",
+						"default_branch_only": true,
+					}).andThen(
+						mockResponse(t, http.StatusAccepted, mockForkedRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":               "owner",
+				"repo":                "repo",
+				"organization":        "new-owner",
+				"name":                "repo",
+				"default_branch_only": true,
+			},
+			expectError:  false,
+			expectedRepo: mockForkedRepo,
+		},
 		{
 			name: "repository fork fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -890,91 +1224,330 @@ func Test_ListCommits(t *testing.T) {
 	}
 }
 
-func Test_CreateOrUpdateFile(t *testing.T) {
+func Test_GetBranchDivergence(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetBranchDivergence(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "create_or_update_file", tool.Name)
+	assert.Equal(t, "get_branch_divergence", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "path")
-	assert.Contains(t, tool.InputSchema.Properties, "content")
-	assert.Contains(t, tool.InputSchema.Properties, "message")
-	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	assert.Contains(t, tool.InputSchema.Properties, "sha")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "content", "message", "branch"})
-
-	// Setup mock file content response
-	mockFileResponse := &github.RepositoryContentResponse{
-		Content: &github.RepositoryContent{
-			Name:        github.Ptr("example.md"),
-			Path:        github.Ptr("docs/example.md"),
-			SHA:         github.Ptr("abc123def456"),
-			Size:        github.Ptr(42),
-			HTMLURL:     github.Ptr("https://github.com/owner/repo/blob/main/docs/example.md"),
-			DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/example.md"),
-		},
-		Commit: github.Commit{
-			SHA:     github.Ptr("def456abc789"),
-			Message: github.Ptr("Add example file"),
-			Author: &github.CommitAuthor{
-				Name:  github.Ptr("Test User"),
-				Email: github.Ptr("test@example.com"),
-				Date:  &github.Timestamp{Time: time.Now()},
-			},
-			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
-		},
-	}
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
 
 	tests := []struct {
-		name            string
-		mockedClient    *http.Client
-		requestArgs     map[string]interface{}
-		expectError     bool
-		expectedContent *github.RepositoryContentResponse
-		expectedErrMsg  string
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       BranchDivergence
 	}{
 		{
-			name: "successful file creation",
+			name: "head ahead of base can be fast-forwarded",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
-					expectRequestBody(t, map[string]interface{}{
-						"message": "Add example file",
-						"content": "IyBFeGFtcGxlCgpUaGlzIGlzIGFuIGV4YW1wbGUgZmlsZS4=", // Base64 encoded content
-						"branch":  "main",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockFileResponse),
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectQueryParams(t, map[string]string{"per_page": "1"}).andThen(
+						mockResponse(t, http.StatusOK, &github.CommitsComparison{
+							Status:          github.Ptr("ahead"),
+							AheadBy:         github.Ptr(3),
+							BehindBy:        github.Ptr(0),
+							MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("abc123")},
+						}),
 					),
 				),
 			),
-			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "# Example\n\nThis is an example file.",
-				"message": "Add example file",
-				"branch":  "main",
-			},
-			expectError:     false,
-			expectedContent: mockFileResponse,
+			expected: BranchDivergence{Base: "main", Head: "feature", MergeBaseSHA: "abc123", Status: "ahead", AheadBy: 3, FastForward: true},
 		},
 		{
-			name: "successful file update with SHA",
+			name: "diverged branches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					&github.CommitsComparison{
+						Status:          github.Ptr("diverged"),
+						AheadBy:         github.Ptr(2),
+						BehindBy:        github.Ptr(5),
+						MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("def456")},
+					},
+				),
+			),
+			expected: BranchDivergence{Base: "main", Head: "feature", MergeBaseSHA: "def456", Status: "diverged", AheadBy: 2, BehindBy: 5},
+		},
+		{
+			name: "unknown ref",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
-					expectRequestBody(t, map[string]interface{}{
-						"message": "Update example file",
-						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
-						"branch":  "main",
-						"sha":     "abc123def456",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockFileResponse),
-					),
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to compare refs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchDivergence(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "feature",
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned BranchDivergence
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_CompareRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_refs", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "include_patch")
+	assert.Contains(t, tool.InputSchema.Properties, "max_patch_size")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	commitDate := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	mockComparison := &github.CommitsComparison{
+		Status:          github.Ptr("ahead"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(0),
+		TotalCommits:    github.Ptr(2),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/compare/v1.0.0...main"),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base123")},
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA:    github.Ptr("abc123"),
+				Author: &github.User{Login: github.Ptr("octocat")},
+				Commit: &github.Commit{
+					Message: github.Ptr("Add feature"),
+					Author:  &github.CommitAuthor{Name: github.Ptr("The Octocat"), Date: &github.Timestamp{Time: commitDate}},
+				},
+			},
+			{
+				SHA: github.Ptr("def456"),
+				Commit: &github.Commit{
+					Message: github.Ptr("Fix bug"),
+					Author:  &github.CommitAuthor{Name: github.Ptr("Unlinked Author")},
+				},
+			},
+		},
+		Files: []*github.CommitFile{
+			{
+				Filename: github.Ptr("main.go"),
+				Status:   github.Ptr("modified"),
+				Patch:    github.Ptr("@@ -1,2 +1,2 @@\n-old line\n+new line\n"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedPatch   string
+		expectTruncated bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "compares refs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/v1.0.0...main").andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.0.0",
+				"head":  "main",
+			},
+			expectedPatch: "@@ -1,2 +1,2 @@\n-old line\n+new line\n",
+		},
+		{
+			name: "truncates patches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockComparison,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"base":           "v1.0.0",
+				"head":           "main",
+				"max_patch_size": float64(20),
+			},
+			expectedPatch:   "@@ -1,2 +1,2 @@",
+			expectTruncated: true,
+		},
+		{
+			name: "compare fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.0.0",
+				"head":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare refs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var comparison RefComparison
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &comparison))
+			assert.Equal(t, "ahead", comparison.Status)
+			assert.Equal(t, 2, comparison.AheadBy)
+			assert.Equal(t, "base123", comparison.MergeBaseSHA)
+			assert.Equal(t, 2, comparison.TotalCommits)
+			assert.Equal(t, []ComparedCommit{
+				{SHA: "abc123", Message: "Add feature", Author: "octocat", Date: "2024-05-01T10:00:00Z"},
+				{SHA: "def456", Message: "Fix bug", Author: "Unlinked Author"},
+			}, comparison.Commits)
+			require.Len(t, comparison.Files, 1)
+			assert.Equal(t, tc.expectedPatch, comparison.Files[0].GetPatch())
+			assert.Equal(t, tc.expectTruncated, comparison.Files[0].PatchTruncated)
+		})
+	}
+}
+
+func Test_CreateOrUpdateFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_or_update_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "content", "message", "branch"})
+
+	// Setup mock file content response
+	mockFileResponse := &github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{
+			Name:        github.Ptr("example.md"),
+			Path:        github.Ptr("docs/example.md"),
+			SHA:         github.Ptr("abc123def456"),
+			Size:        github.Ptr(42),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/blob/main/docs/example.md"),
+			DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/example.md"),
+		},
+		Commit: github.Commit{
+			SHA:     github.Ptr("def456abc789"),
+			Message: github.Ptr("Add example file"),
+			Author: &github.CommitAuthor{
+				Name:  github.Ptr("Test User"),
+				Email: github.Ptr("test@example.com"),
+				Date:  &github.Timestamp{Time: time.Now()},
+			},
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedContent *github.RepositoryContentResponse
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful file creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add example file",
+						"content": "IyBFeGFtcGxlCgpUaGlzIGlzIGFuIGV4YW1wbGUgZmlsZS4=", // Base64 encoded content
+						"branch":  "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Example\n\nThis is an example file.",
+				"message": "Add example file",
+				"branch":  "main",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "successful file update with SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update example file",
+						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
+						"branch":  "main",
+						"sha":     "abc123def456",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -1128,81 +1701,344 @@ func Test_CreateRepository(t *testing.T) {
 						Method:  "POST",
 					},
 					expectRequestBody(t, map[string]interface{}{
-						"name":        "test-repo",
-						"auto_init":   false,
-						"description": "",
-						"private":     false,
+						"name":        "test-repo",
+						"auto_init":   false,
+						"description": "",
+						"private":     false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "test-repo",
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "repository creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Repository creation failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "invalid-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedRepo github.Repository
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRepo)
+			assert.NoError(t, err)
+
+			// Verify repository details
+			assert.Equal(t, *tc.expectedRepo.Name, *returnedRepo.Name)
+			assert.Equal(t, *tc.expectedRepo.Description, *returnedRepo.Description)
+			assert.Equal(t, *tc.expectedRepo.Private, *returnedRepo.Private)
+			assert.Equal(t, *tc.expectedRepo.HTMLURL, *returnedRepo.HTMLURL)
+			assert.Equal(t, *tc.expectedRepo.Owner.Login, *returnedRepo.Owner.Login)
+		})
+	}
+}
+
+func Test_CreateRepositoryFromTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepositoryFromTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_repository_from_template", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "include_all_branches")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"template_owner", "template_repo", "name"})
+
+	mockRepo := &github.Repository{
+		Name:     github.Ptr("new-service"),
+		FullName: github.Ptr("my-org/new-service"),
+		HTMLURL:  github.Ptr("https://github.com/my-org/new-service"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates repository in organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name":                 "new-service",
+						"owner":                "my-org",
+						"description":          "A new service",
+						"private":              true,
+						"include_all_branches": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "my-org",
+				"template_repo":  "service-template",
+				"name":           "new-service",
+				"owner":          "my-org",
+				"description":    "A new service",
+				"private":        true,
+			},
+		},
+		{
+			name: "template not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "my-org",
+				"template_repo":  "service-template",
+				"name":           "new-service",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository from template my-org/service-template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepositoryFromTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var repo github.Repository
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &repo))
+			assert.Equal(t, "my-org/new-service", repo.GetFullName())
+		})
+	}
+}
+
+func Test_UpdateRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "topics")
+	assert.Contains(t, tool.InputSchema.Properties, "default_branch")
+	assert.Contains(t, tool.InputSchema.Properties, "has_wiki")
+	assert.Contains(t, tool.InputSchema.Properties, "allow_squash_merge")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRepo   *github.Repository
+		expectedErrMsg string
+	}{
+		{
+			name: "update settings only sends the given settings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"description":        "New description",
+						"default_branch":     "trunk",
+						"has_wiki":           false,
+						"allow_squash_merge": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							FullName:      github.Ptr("owner/repo"),
+							Description:   github.Ptr("New description"),
+							DefaultBranch: github.Ptr("trunk"),
+							HasWiki:       github.Ptr(false),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"description":        "New description",
+				"default_branch":     "trunk",
+				"has_wiki":           false,
+				"allow_squash_merge": true,
+			},
+			expectedRepo: &github.Repository{
+				FullName:      github.Ptr("owner/repo"),
+				Description:   github.Ptr("New description"),
+				DefaultBranch: github.Ptr("trunk"),
+			},
+		},
+		{
+			name: "update settings and topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"visibility": "private",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							FullName:   github.Ptr("owner/repo"),
+							Visibility: github.Ptr("private"),
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{"go", "mcp"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]interface{}{"names": []string{"go", "mcp"}}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "private",
+				"topics":     []interface{}{"go", "mcp"},
+			},
+			expectedRepo: &github.Repository{
+				FullName: github.Ptr("owner/repo"),
+				Topics:   []string{"go", "mcp"},
+			},
+		},
+		{
+			name: "clear topics only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{FullName: github.Ptr("owner/repo"), Topics: []string{"old"}},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{},
 					}).andThen(
-						mockResponse(t, http.StatusCreated, mockRepo),
+						mockResponse(t, http.StatusOK, map[string]interface{}{"names": []string{}}),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name": "test-repo",
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []interface{}{},
+			},
+			expectedRepo: &github.Repository{
+				FullName: github.Ptr("owner/repo"),
+				Topics:   []string{},
 			},
-			expectError:  false,
-			expectedRepo: mockRepo,
 		},
 		{
-			name: "repository creation fails",
+			name:         "nothing to update",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one setting to update must be given",
+		},
+		{
+			name: "update fails",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
+					mock.PatchReposByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.WriteHeader(http.StatusUnprocessableEntity)
-						_, _ = w.Write([]byte(`{"message": "Repository creation failed"}`))
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name": "invalid-repo",
+				"owner":          "owner",
+				"repo":           "repo",
+				"default_branch": "missing",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to create repository",
+			expectedErrMsg: "failed to update repository owner/repo",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := CreateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
+			_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Call handler
-			result, err := handler(context.Background(), request)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
 
-			// Verify results
 			if tc.expectError {
-				require.NoError(t, err)
 				require.True(t, result.IsError)
-				errorContent := getErrorResult(t, result)
-				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
-
-			// Unmarshal and verify the result
 			var returnedRepo github.Repository
-			err = json.Unmarshal([]byte(textContent.Text), &returnedRepo)
-			assert.NoError(t, err)
-
-			// Verify repository details
-			assert.Equal(t, *tc.expectedRepo.Name, *returnedRepo.Name)
-			assert.Equal(t, *tc.expectedRepo.Description, *returnedRepo.Description)
-			assert.Equal(t, *tc.expectedRepo.Private, *returnedRepo.Private)
-			assert.Equal(t, *tc.expectedRepo.HTMLURL, *returnedRepo.HTMLURL)
-			assert.Equal(t, *tc.expectedRepo.Owner.Login, *returnedRepo.Owner.Login)
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedRepo))
+			assert.Equal(t, tc.expectedRepo.GetFullName(), returnedRepo.GetFullName())
+			assert.Equal(t, tc.expectedRepo.GetDescription(), returnedRepo.GetDescription())
+			assert.Equal(t, tc.expectedRepo.GetDefaultBranch(), returnedRepo.GetDefaultBranch())
+			if tc.expectedRepo.Topics != nil {
+				assert.ElementsMatch(t, tc.expectedRepo.Topics, returnedRepo.Topics)
+			}
 		})
 	}
 }
@@ -1342,6 +2178,106 @@ func Test_PushFiles(t *testing.T) {
 			expectError: false,
 			expectedRef: mockUpdatedRef,
 		},
+		{
+			name: "successful push with binary file and deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Create blob for the binary file
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"content":  "iVBORw==",
+						"encoding": "base64",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("blob123")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path": "logo.png",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  "blob123",
+							},
+							map[string]interface{}{
+								"path": "old.md",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  nil,
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":     "logo.png",
+						"content":  "iVBORw==",
+						"encoding": "base64",
+					},
+					map[string]interface{}{
+						"path":   "old.md",
+						"delete": true,
+					},
+				},
+				"message": "Replace docs with logo",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name: "fails with unsupported encoding",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":     "README.md",
+						"content":  "# README",
+						"encoding": "latin1",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "unsupported encoding",
+		},
 		{
 			name:         "fails when files parameter is invalid",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1837,6 +2773,312 @@ func Test_DeleteFile(t *testing.T) {
 	}
 }
 
+func Test_MoveFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MoveFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "move_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "from_path")
+	assert.Contains(t, tool.InputSchema.Properties, "to_path")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "replacements")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "from_path", "to_path", "message"})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	mockBaseCommit := &github.Commit{
+		SHA:  github.Ptr("abc123"),
+		Tree: &github.Tree{SHA: github.Ptr("def456")},
+	}
+	mockBaseTree := &github.Tree{
+		SHA: github.Ptr("def456"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("cmd"), Type: github.Ptr("tree"), Mode: github.Ptr("040000"), SHA: github.Ptr("tree-cmd")},
+			{Path: github.Ptr("cmd/tool.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100755"), SHA: github.Ptr("blob-tool")},
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("blob-readme")},
+		},
+	}
+	mockNewCommit := &github.Commit{
+		SHA:     github.Ptr("jkl012"),
+		Message: github.Ptr("Move tool"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
+	}
+	readHandlers := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+			mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, mockBaseCommit),
+			mock.WithRequestMatch(mock.GetReposGitTreesByOwnerByRepoByTreeSha, mockBaseTree),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte("package cmd\n"))
+				}),
+			),
+		}
+	}
+	writeHandlers := func(destination map[string]interface{}) []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				expectRequestBody(t, map[string]interface{}{
+					"base_tree": "def456",
+					"tree": []interface{}{
+						map[string]interface{}{"path": "cmd/tool.go", "mode": "100755", "type": "blob", "sha": nil},
+						destination,
+					},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("ghi789")}),
+				),
+			),
+			mock.WithRequestMatch(mock.PostReposGitCommitsByOwnerByRepo, mockNewCommit),
+			mock.WithRequestMatch(mock.PatchReposGitRefsByOwnerByRepoByRef, mockRef),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "moves file keeping its blob and mode",
+			mockedClient: mock.NewMockedHTTPClient(append(readHandlers(),
+				writeHandlers(map[string]interface{}{"path": "internal/tool.go", "mode": "100755", "type": "blob", "sha": "blob-tool"})...,
+			)...),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"branch":    "main",
+				"from_path": "cmd/tool.go",
+				"to_path":   "internal/tool.go",
+				"message":   "Move tool",
+			},
+		},
+		{
+			name: "moves file with replacements",
+			mockedClient: mock.NewMockedHTTPClient(append(readHandlers(),
+				writeHandlers(map[string]interface{}{"path": "internal/tool.go", "mode": "100755", "type": "blob", "content": "package internal\n"})...,
+			)...),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"branch":    "main",
+				"from_path": "cmd/tool.go",
+				"to_path":   "internal/tool.go",
+				"message":   "Move tool",
+				"replacements": []interface{}{
+					map[string]interface{}{"search": "package cmd", "replace": "package internal"},
+				},
+			},
+		},
+		{
+			name:         "replacement not found",
+			mockedClient: mock.NewMockedHTTPClient(readHandlers()...),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"branch":    "main",
+				"from_path": "cmd/tool.go",
+				"to_path":   "internal/tool.go",
+				"message":   "Move tool",
+				"replacements": []interface{}{
+					map[string]interface{}{"search": "package main", "replace": "package internal"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: `search text "package main" not found in cmd/tool.go`,
+		},
+		{
+			name:         "destination exists",
+			mockedClient: mock.NewMockedHTTPClient(readHandlers()...),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"branch":    "main",
+				"from_path": "cmd/tool.go",
+				"to_path":   "README.md",
+				"message":   "Move tool",
+			},
+			expectError:    true,
+			expectedErrMsg: "README.md already exists",
+		},
+		{
+			name:         "source is not a file",
+			mockedClient: mock.NewMockedHTTPClient(readHandlers()...),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"branch":    "main",
+				"from_path": "cmd",
+				"to_path":   "bin",
+				"message":   "Move tool",
+			},
+			expectError:    true,
+			expectedErrMsg: "cmd is not a file on main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := MoveFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "internal/tool.go", response["to_path"])
+			commit, ok := response["commit"].(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, "jkl012", commit["sha"])
+		})
+	}
+}
+
+func Test_CreateCommitOnBranch(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := CreateCommitOnBranch(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_commit_on_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "additions")
+	assert.Contains(t, tool.InputSchema.Properties, "deletions")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "expectedHeadOid", "message"})
+
+	mutation := struct {
+		CreateCommitOnBranch struct {
+			Commit struct {
+				OID githubv4.GitObjectID `graphql:"oid"`
+				URL githubv4.URI         `graphql:"url"`
+			}
+		} `graphql:"createCommitOnBranch(input: $input)"`
+	}{}
+	additions := []githubv4.FileAddition{
+		{Path: "docs/README.md", Contents: githubv4.Base64String(base64.StdEncoding.EncodeToString([]byte("# Docs\n")))},
+		{Path: "logo.png", Contents: "iVBORw0KGgo="},
+	}
+	deletions := []githubv4.FileDeletion{{Path: "docs/old.md"}}
+	input := githubv4.CreateCommitOnBranchInput{
+		Branch: githubv4.CommittableBranch{
+			RepositoryNameWithOwner: githubv4.NewString("owner/repo"),
+			BranchName:              githubv4.NewString("main"),
+		},
+		Message: githubv4.CommitMessage{
+			Headline: "Update docs",
+			Body:     githubv4.NewString("Replace the old page with a README."),
+		},
+		ExpectedHeadOid: "abc123",
+		FileChanges:     &githubv4.FileChanges{Additions: &additions, Deletions: &deletions},
+	}
+	requestArgs := map[string]any{
+		"owner":           "owner",
+		"repo":            "repo",
+		"branch":          "main",
+		"expectedHeadOid": "abc123",
+		"message":         "Update docs\n\nReplace the old page with a README.",
+		"additions": []any{
+			map[string]any{"path": "docs/README.md", "content": "# Docs\n"},
+			map[string]any{"path": "logo.png", "content": "iVBORw0KGgo=", "encoding": "base64"},
+		},
+		"deletions": []any{"docs/old.md"},
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		response       githubv4mock.GQLResponse
+		expectedErrMsg string
+	}{
+		{
+			name:        "commits additions and deletions",
+			requestArgs: requestArgs,
+			response: githubv4mock.DataResponse(map[string]any{
+				"createCommitOnBranch": map[string]any{
+					"commit": map[string]any{
+						"oid": "def456",
+						"url": "https://github.com/owner/repo/commit/def456",
+					},
+				},
+			}),
+		},
+		{
+			name:           "branch has moved",
+			requestArgs:    requestArgs,
+			response:       githubv4mock.ErrorResponse(`Expected branch to point to "abc123" but it did not. Pull and try again.`),
+			expectedErrMsg: "failed to create commit on branch",
+		},
+		{
+			name: "no changes",
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"branch":          "main",
+				"expectedHeadOid": "abc123",
+				"message":         "Nothing",
+			},
+			expectedErrMsg: "at least one addition or deletion is required",
+		},
+		{
+			name: "invalid base64 content",
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"branch":          "main",
+				"expectedHeadOid": "abc123",
+				"message":         "Add logo",
+				"additions": []any{
+					map[string]any{"path": "logo.png", "content": "not base64!", "encoding": "base64"},
+				},
+			},
+			expectedErrMsg: "content of logo.png is not valid base64",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(mutation, input, nil, tc.response),
+			))
+			_, handler := CreateCommitOnBranch(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "def456", response["sha"])
+			assert.Equal(t, "https://github.com/owner/repo/commit/def456", response["url"])
+			assert.Equal(t, "main", response["branch"])
+		})
+	}
+}
+
 func Test_ListTags(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -64,6 +65,71 @@ func GetRepositoryResourcePrContent(getClient GetClientFn, getRawClient raw.GetR
 		RepositoryResourceContentsHandler(getClient, getRawClient)
 }
 
+// GetRepositoryResourceRefContent defines the resource template and handler for getting repository content for any branch, tag or commit.
+func GetRepositoryResourceRefContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/refs/{ref}/contents{/path*}", // Resource template
+			t("RESOURCE_REPOSITORY_CONTENT_REF_DESCRIPTION", "Repository Content for a specific branch, tag or commit"),
+		),
+		RepositoryResourceContentsHandler(getClient, getRawClient)
+}
+
+// resourceDirectoryEntry is an entry of a directory listing read as a resource.
+type resourceDirectoryEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size,omitempty"`
+	SHA  string `json:"sha"`
+	// URI is the resource URI to read the entry with.
+	URI string `json:"uri"`
+}
+
+// repositoryDirectoryContents returns the listing of a directory as a JSON resource. It returns
+// an error if the path is a file rather than a directory.
+func repositoryDirectoryContents(ctx context.Context, getClient GetClientFn, uri, owner, repo, path string, opts *github.RepositoryContentGetOptions) ([]mcp.ResourceContents, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	_, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get directory contents: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if dirContent == nil {
+		return nil, errors.New("404 Not Found")
+	}
+
+	base := strings.TrimSuffix(uri, "/")
+	entries := make([]resourceDirectoryEntry, 0, len(dirContent))
+	for _, content := range dirContent {
+		entry := resourceDirectoryEntry{
+			Name: content.GetName(),
+			Path: content.GetPath(),
+			Type: content.GetType(),
+			Size: content.GetSize(),
+			SHA:  content.GetSHA(),
+			URI:  base + "/" + content.GetName(),
+		}
+		if entry.Type == "dir" {
+			entry.URI += "/"
+		}
+		entries = append(entries, entry)
+	}
+	listing, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal directory contents: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(listing),
+		},
+	}, nil
+}
+
 // RepositoryResourceContentsHandler returns a handler function for repository content requests.
 func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.GetRawClientFn) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
@@ -103,6 +169,12 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			rawOpts.Ref = "refs/heads/" + branch[0]
 		}
 
+		ref, ok := request.Params.Arguments["ref"].([]string)
+		if ok && len(ref) > 0 {
+			opts.Ref = ref[0]
+			rawOpts.Ref = ref[0]
+		}
+
 		tag, ok := request.Params.Arguments["tag"].([]string)
 		if ok && len(tag) > 0 {
 			opts.Ref = "refs/tags/" + tag[0]
@@ -129,7 +201,7 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 		}
 		//  if it's a directory
 		if path == "" || strings.HasSuffix(path, "/") {
-			return nil, fmt.Errorf("directories are not supported: %s", path)
+			return repositoryDirectoryContents(ctx, getClient, request.Params.URI, owner, repo, path, opts)
 		}
 		rawClient, err := getRawClient(ctx)
 
@@ -185,8 +257,8 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			}
 			return nil, fmt.Errorf("failed to fetch raw content: %s", string(body))
 		default:
-			// This should be unreachable because GetContents should return an error if neither file nor directory content is found.
-			return nil, errors.New("404 Not Found")
+			// The path may be a directory given without a trailing slash
+			return repositoryDirectoryContents(ctx, getClient, request.Params.URI, owner, repo, path, opts)
 		}
 	}
 }
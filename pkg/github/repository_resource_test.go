@@ -175,6 +175,31 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 				URI:      "",
 			}},
 		},
+		{
+			name: "successful text content fetch (ref)",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, "/owner/repo/v1.0.0/README.md", r.URL.Path)
+						w.Header().Set("Content-Type", "text/markdown")
+						_, err := w.Write([]byte("# Test Repository\n\nThis is a test repository."))
+						require.NoError(t, err)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"README.md"},
+				"ref":   []string{"v1.0.0"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     "# Test Repository\n\nThis is a test repository.",
+				MIMEType: "text/markdown",
+				URI:      "",
+			}},
+		},
 		{
 			name: "successful text content fetch (pr)",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -256,6 +281,70 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 	}
 }
 
+func Test_repositoryResourceContentsHandler_Directory(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+	dirContent := []*github.RepositoryContent{
+		{Name: github.Ptr("github"), Path: github.Ptr("pkg/github"), Type: github.Ptr("dir"), SHA: github.Ptr("abc123")},
+		{Name: github.Ptr("go.mod"), Path: github.Ptr("pkg/go.mod"), Type: github.Ptr("file"), Size: github.Ptr(42), SHA: github.Ptr("def456")},
+	}
+	expectedListing := `[
+		{"name":"github","path":"pkg/github","type":"dir","sha":"abc123","uri":"repo://owner/repo/refs/main/contents/pkg/github/"},
+		{"name":"go.mod","path":"pkg/go.mod","type":"file","size":42,"sha":"def456","uri":"repo://owner/repo/refs/main/contents/pkg/go.mod"}
+	]`
+
+	tests := []struct {
+		name string
+		uri  string
+		path []string
+	}{
+		{
+			name: "path with trailing slash",
+			uri:  "repo://owner/repo/refs/main/contents/pkg/",
+			path: []string{"pkg", ""},
+		},
+		{
+			name: "path without trailing slash",
+			uri:  "repo://owner/repo/refs/main/contents/pkg",
+			path: []string{"pkg"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Raw content requests are not mocked, so files are not found
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, "main", r.URL.Query().Get("ref"))
+						w.Header().Set("Content-Type", "application/json")
+						_, _ = w.Write(mock.MustMarshal(dirContent))
+					}),
+				),
+			)
+			client := github.NewClient(mockedClient)
+			handler := RepositoryResourceContentsHandler(stubGetClientFn(client), stubGetRawClientFn(raw.NewClient(client, base)))
+
+			request := mcp.ReadResourceRequest{}
+			request.Params.URI = tc.uri
+			request.Params.Arguments = map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"ref":   []string{"main"},
+				"path":  tc.path,
+			}
+			resp, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.Len(t, resp, 1)
+			content, ok := resp[0].(mcp.TextResourceContents)
+			require.True(t, ok)
+			require.Equal(t, "application/json", content.MIMEType)
+			require.Equal(t, tc.uri, content.URI)
+			require.JSONEq(t, expectedListing, content.Text)
+		})
+	}
+}
+
 func Test_GetRepositoryResourceContent(t *testing.T) {
 	mockRawClient := raw.NewClient(github.NewClient(nil), &url.URL{})
 	tmpl, _ := GetRepositoryResourceContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
@@ -278,3 +367,9 @@ func Test_GetRepositoryResourceTagContent(t *testing.T) {
 	tmpl, _ := GetRepositoryResourceTagContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
 	require.Equal(t, "repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}", tmpl.URITemplate.Raw())
 }
+
+func Test_GetRepositoryResourceRefContent(t *testing.T) {
+	mockRawClient := raw.NewClient(github.NewClient(nil), &url.URL{})
+	tmpl, _ := GetRepositoryResourceRefContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/refs/{ref}/contents{/path*}", tmpl.URITemplate.Raw())
+}
@@ -0,0 +1,28 @@
+package github
+
+import (
+	"context"
+
+	"github.com/github/github-mcp-server/pkg/retry"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RetryMetadataMiddleware reports how often the GitHub API requests made by each tool call were
+// retried after transient failures, in the "retries" field of the result metadata.
+func RetryMetadataMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, stats := retry.WithStats(ctx)
+			result, err := next(ctx, request)
+			if result == nil {
+				return result, err
+			}
+
+			if retries := stats.Retries.Load(); retries > 0 {
+				setResultMeta(result, "retries", retries)
+			}
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/retry"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetryMetadataMiddleware(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name": "main"}]`))
+	}))
+	defer srv.Close()
+
+	policy := retry.DefaultPolicy()
+	policy.BaseDelay = 0
+	client := github.NewClient(&http.Client{Transport: retry.NewTransport(nil, policy)})
+	baseURL, err := client.BaseURL.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = baseURL
+
+	_, handler := ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+	handler = RetryMetadataMiddleware()(handler)
+	request := createMCPRequest(map[string]interface{}{"owner": "owner", "repo": "repo"})
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, int64(1), result.Meta.AdditionalFields["retries"])
+
+	// Calls that were not retried leave the metadata out
+	result, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.NotContains(t, result.Meta.AdditionalFields, "retries")
+	assert.Equal(t, 3, requests)
+}
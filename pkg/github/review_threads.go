@@ -0,0 +1,350 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// maxReviewThreadComments is the number of comments returned for each review thread.
+const maxReviewThreadComments = 20
+
+// ReviewThread is a review thread on a pull request, with the first comments of the conversation.
+type ReviewThread struct {
+	ID            string                `json:"id"`
+	Path          string                `json:"path"`
+	Line          int                   `json:"line,omitempty"`
+	StartLine     int                   `json:"start_line,omitempty"`
+	IsResolved    bool                  `json:"is_resolved"`
+	IsOutdated    bool                  `json:"is_outdated"`
+	ResolvedBy    string                `json:"resolved_by,omitempty"`
+	TotalComments int                   `json:"total_comments"`
+	Comments      []ReviewThreadComment `json:"comments"`
+}
+
+// ReviewThreadComment is a comment in a review thread. DatabaseID is the ID
+// accepted by reply_to_review_comment.
+type ReviewThreadComment struct {
+	DatabaseID int64     `json:"database_id"`
+	Author     string    `json:"author"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+	URL        string    `json:"url"`
+}
+
+type reviewThreadNode struct {
+	ID         githubv4.ID
+	Path       githubv4.String
+	Line       *githubv4.Int
+	StartLine  *githubv4.Int
+	IsResolved githubv4.Boolean
+	IsOutdated githubv4.Boolean
+	ResolvedBy *struct {
+		Login githubv4.String
+	}
+	Comments struct {
+		TotalCount githubv4.Int
+		Nodes      []struct {
+			DatabaseID githubv4.Int
+			Author     *struct {
+				Login githubv4.String
+			}
+			Body      githubv4.String
+			CreatedAt githubv4.DateTime
+			URL       githubv4.URI
+		}
+	} `graphql:"comments(first: $commentsFirst)"`
+}
+
+func newReviewThread(node reviewThreadNode) ReviewThread {
+	thread := ReviewThread{
+		ID:            fmt.Sprint(node.ID),
+		Path:          string(node.Path),
+		IsResolved:    bool(node.IsResolved),
+		IsOutdated:    bool(node.IsOutdated),
+		TotalComments: int(node.Comments.TotalCount),
+		Comments:      make([]ReviewThreadComment, 0, len(node.Comments.Nodes)),
+	}
+	if node.Line != nil {
+		thread.Line = int(*node.Line)
+	}
+	if node.StartLine != nil {
+		thread.StartLine = int(*node.StartLine)
+	}
+	if node.ResolvedBy != nil {
+		thread.ResolvedBy = string(node.ResolvedBy.Login)
+	}
+	for _, c := range node.Comments.Nodes {
+		comment := ReviewThreadComment{
+			DatabaseID: int64(c.DatabaseID),
+			Body:       string(c.Body),
+			CreatedAt:  c.CreatedAt.Time,
+			URL:        c.URL.String(),
+		}
+		if c.Author != nil {
+			comment.Author = string(c.Author.Login)
+		}
+		thread.Comments = append(thread.Comments, comment)
+	}
+	return thread
+}
+
+// ListReviewThreads creates a tool to list the review threads of a pull request.
+func ListReviewThreads(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_review_threads",
+			mcp.WithDescription(t("TOOL_LIST_REVIEW_THREADS_DESCRIPTION", "List the review threads of a pull request, including whether each thread is resolved and its first comments. Use the thread ID with resolve_review_thread and unresolve_review_thread, and a comment's database_id with reply_to_review_comment. For pagination, use the 'endCursor' from the previous response's 'pageInfo' in the 'after' parameter.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REVIEW_THREADS_USER_TITLE", "List pull request review threads"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				Repository struct {
+					PullRequest struct {
+						ReviewThreads struct {
+							Nodes      []reviewThreadNode
+							PageInfo   PageInfoFragment
+							TotalCount githubv4.Int
+						} `graphql:"reviewThreads(first: $first, after: $after)"`
+					} `graphql:"pullRequest(number: $prNum)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":         githubv4.String(owner),
+				"repo":          githubv4.String(repo),
+				"prNum":         githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+				"first":         githubv4.Int(*paginationParams.First),
+				"after":         (*githubv4.String)(paginationParams.After),
+				"commentsFirst": githubv4.Int(maxReviewThreadComments),
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list review threads", err), nil
+			}
+
+			threads := q.Repository.PullRequest.ReviewThreads
+			result := make([]ReviewThread, 0, len(threads.Nodes))
+			for _, node := range threads.Nodes {
+				result = append(result, newReviewThread(node))
+			}
+
+			totalCount := int(threads.TotalCount)
+			r, err := json.Marshal(map[string]any{
+				"threads": result,
+				"pageInfo": map[string]any{
+					"hasNextPage":     threads.PageInfo.HasNextPage,
+					"hasPreviousPage": threads.PageInfo.HasPreviousPage,
+					"startCursor":     string(threads.PageInfo.StartCursor),
+					"endCursor":       string(threads.PageInfo.EndCursor),
+				},
+				"totalCount": totalCount,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), graphQLPageInfo(bool(threads.PageInfo.HasNextPage), threads.PageInfo.EndCursor, &totalCount)), nil
+		}
+}
+
+// ResolveReviewThread creates a tool to mark a pull request review thread as resolved.
+func ResolveReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return setReviewThreadResolved(getGQLClient, t, true)
+}
+
+// UnresolveReviewThread creates a tool to mark a pull request review thread as unresolved.
+func UnresolveReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return setReviewThreadResolved(getGQLClient, t, false)
+}
+
+func setReviewThreadResolved(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc, resolve bool) (mcp.Tool, server.ToolHandlerFunc) {
+	name, description, title := "unresolve_review_thread",
+		t("TOOL_UNRESOLVE_REVIEW_THREAD_DESCRIPTION", "Mark a pull request review thread as unresolved, reopening the conversation."),
+		t("TOOL_UNRESOLVE_REVIEW_THREAD_USER_TITLE", "Unresolve pull request review thread")
+	if resolve {
+		name, description, title = "resolve_review_thread",
+			t("TOOL_RESOLVE_REVIEW_THREAD_DESCRIPTION", "Mark a pull request review thread as resolved."),
+			t("TOOL_RESOLVE_REVIEW_THREAD_USER_TITLE", "Resolve pull request review thread")
+	}
+
+	return mcp.NewTool(name,
+			mcp.WithDescription(description),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        title,
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("thread_id",
+				mcp.Required(),
+				mcp.Description("The node ID of the review thread, as returned by list_review_threads"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			threadID, err := RequiredParam[string](request, "thread_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			type threadState struct {
+				Thread struct {
+					ID         githubv4.ID
+					IsResolved githubv4.Boolean
+				}
+			}
+			var isResolved bool
+			if resolve {
+				var mutation struct {
+					ResolveReviewThread threadState `graphql:"resolveReviewThread(input: $input)"`
+				}
+				input := githubv4.ResolveReviewThreadInput{ThreadID: githubv4.ID(threadID)}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to resolve review thread", err), nil
+				}
+				isResolved = bool(mutation.ResolveReviewThread.Thread.IsResolved)
+			} else {
+				var mutation struct {
+					UnresolveReviewThread threadState `graphql:"unresolveReviewThread(input: $input)"`
+				}
+				input := githubv4.UnresolveReviewThreadInput{ThreadID: githubv4.ID(threadID)}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unresolve review thread", err), nil
+				}
+				isResolved = bool(mutation.UnresolveReviewThread.Thread.IsResolved)
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"id":          threadID,
+				"is_resolved": isResolved,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ReplyToReviewComment creates a tool to reply to a pull request review comment.
+func ReplyToReviewComment(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("reply_to_review_comment",
+			mcp.WithDescription(t("TOOL_REPLY_TO_REVIEW_COMMENT_DESCRIPTION", "Reply to a pull request review comment. The reply is added to the comment's review thread.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REPLY_TO_REVIEW_COMMENT_USER_TITLE", "Reply to pull request review comment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("comment_id",
+				mcp.Required(),
+				mcp.Description("The database ID of the review comment to reply to. Replies to replies are not supported, use the ID of the first comment in the thread"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("The text of the reply"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commentID, err := RequiredInt(request, "comment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			comment, resp, err := client.PullRequests.CreateCommentInReplyTo(ctx, owner, repo, pullNumber, body, int64(commentID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to reply to review comment %d", commentID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(comment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,329 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListReviewThreads(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListReviewThreads(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_review_threads", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	var query struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes      []reviewThreadNode
+					PageInfo   PageInfoFragment
+					TotalCount githubv4.Int
+				} `graphql:"reviewThreads(first: $first, after: $after)"`
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":         githubv4.String("owner"),
+		"repo":          githubv4.String("repo"),
+		"prNum":         githubv4.Int(42),
+		"first":         githubv4.Int(30),
+		"after":         (*githubv4.String)(nil),
+		"commentsFirst": githubv4.Int(20),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "lists threads",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(query, vars, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"pullRequest": map[string]any{
+							"reviewThreads": map[string]any{
+								"nodes": []any{
+									map[string]any{
+										"id":         "PRRT_1",
+										"path":       "main.go",
+										"line":       12,
+										"startLine":  nil,
+										"isResolved": true,
+										"isOutdated": false,
+										"resolvedBy": map[string]any{"login": "octocat"},
+										"comments": map[string]any{
+											"totalCount": 1,
+											"nodes": []any{
+												map[string]any{
+													"databaseId": 1001,
+													"author":     map[string]any{"login": "reviewer"},
+													"body":       "Please rename this",
+													"createdAt":  "2024-05-01T10:00:00Z",
+													"url":        "https://github.com/owner/repo/pull/42#discussion_r1001",
+												},
+											},
+										},
+									},
+								},
+								"pageInfo": map[string]any{
+									"hasNextPage":     false,
+									"hasPreviousPage": false,
+									"startCursor":     "c1",
+									"endCursor":       "c1",
+								},
+								"totalCount": 1,
+							},
+						},
+					},
+				})),
+			),
+		},
+		{
+			name: "pull request not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(query, vars, githubv4mock.ErrorResponse("Could not resolve to a PullRequest with the number of 42.")),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list review threads",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListReviewThreads(stubGetGQLClientFn(githubv4.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Threads    []ReviewThread `json:"threads"`
+				TotalCount int            `json:"totalCount"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response.Threads, 1)
+			thread := response.Threads[0]
+			assert.Equal(t, "PRRT_1", thread.ID)
+			assert.Equal(t, 12, thread.Line)
+			assert.True(t, thread.IsResolved)
+			assert.Equal(t, "octocat", thread.ResolvedBy)
+			require.Len(t, thread.Comments, 1)
+			assert.Equal(t, int64(1001), thread.Comments[0].DatabaseID)
+			assert.Equal(t, "reviewer", thread.Comments[0].Author)
+			assert.Equal(t, 1, response.TotalCount)
+		})
+	}
+}
+
+func Test_ResolveReviewThread(t *testing.T) {
+	// Verify tool definitions once
+	resolveTool, _ := ResolveReviewThread(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(resolveTool.Name, resolveTool))
+	unresolveTool, _ := UnresolveReviewThread(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(unresolveTool.Name, unresolveTool))
+
+	assert.Equal(t, "resolve_review_thread", resolveTool.Name)
+	assert.Equal(t, "unresolve_review_thread", unresolveTool.Name)
+	assert.ElementsMatch(t, resolveTool.InputSchema.Required, []string{"thread_id"})
+
+	type threadState struct {
+		Thread struct {
+			ID         githubv4.ID
+			IsResolved githubv4.Boolean
+		}
+	}
+	var resolveMutation struct {
+		ResolveReviewThread threadState `graphql:"resolveReviewThread(input: $input)"`
+	}
+	var unresolveMutation struct {
+		UnresolveReviewThread threadState `graphql:"unresolveReviewThread(input: $input)"`
+	}
+
+	tests := []struct {
+		name             string
+		resolve          bool
+		mockedClient     *http.Client
+		expectError      bool
+		expectedResolved bool
+		expectedErrMsg   string
+	}{
+		{
+			name:    "resolves thread",
+			resolve: true,
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					resolveMutation,
+					githubv4.ResolveReviewThreadInput{ThreadID: githubv4.ID("PRRT_1")},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"resolveReviewThread": map[string]any{
+							"thread": map[string]any{"id": "PRRT_1", "isResolved": true},
+						},
+					}),
+				),
+			),
+			expectedResolved: true,
+		},
+		{
+			name:    "unresolves thread",
+			resolve: false,
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					unresolveMutation,
+					githubv4.UnresolveReviewThreadInput{ThreadID: githubv4.ID("PRRT_1")},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"unresolveReviewThread": map[string]any{
+							"thread": map[string]any{"id": "PRRT_1", "isResolved": false},
+						},
+					}),
+				),
+			),
+			expectedResolved: false,
+		},
+		{
+			name:    "resolve fails",
+			resolve: true,
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					resolveMutation,
+					githubv4.ResolveReviewThreadInput{ThreadID: githubv4.ID("PRRT_1")},
+					nil,
+					githubv4mock.ErrorResponse("Could not resolve to a node with the global id of 'PRRT_1'"),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to resolve review thread",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			getGQLClient := stubGetGQLClientFn(githubv4.NewClient(tc.mockedClient))
+			_, handler := UnresolveReviewThread(getGQLClient, translations.NullTranslationHelper)
+			if tc.resolve {
+				_, handler = ResolveReviewThread(getGQLClient, translations.NullTranslationHelper)
+			}
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"thread_id": "PRRT_1",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "PRRT_1", response["id"])
+			assert.Equal(t, tc.expectedResolved, response["is_resolved"])
+		})
+	}
+}
+
+func Test_ReplyToReviewComment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReplyToReviewComment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "reply_to_review_comment", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "comment_id", "body"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "replies to comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsCommentsByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"body":        "Done, thanks!",
+						"in_reply_to": float64(1001),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.PullRequestComment{
+							ID:        github.Ptr(int64(1002)),
+							Body:      github.Ptr("Done, thanks!"),
+							InReplyTo: github.Ptr(int64(1001)),
+						}),
+					),
+				),
+			),
+		},
+		{
+			name: "comment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsCommentsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to reply to review comment 1001",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReplyToReviewComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"comment_id": float64(1001),
+				"body":       "Done, thanks!",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var comment github.PullRequestComment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &comment))
+			assert.Equal(t, int64(1002), comment.GetID())
+			assert.Equal(t, int64(1001), comment.GetInReplyTo())
+		})
+	}
+}
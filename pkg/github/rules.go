@@ -0,0 +1,205 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// withRulesetOwner adds the parameters that tell repository, organization and enterprise
+// rulesets apart.
+func withRulesetOwner() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Description("Repository owner for a repository ruleset, or the organization for an organization ruleset"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Description("Repository name for a repository ruleset. Leave out for an organization ruleset"),
+		)(tool)
+		mcp.WithString("enterprise",
+			mcp.Description("Enterprise slug for an enterprise ruleset. Cannot be combined with owner or repo"),
+		)(tool)
+		mcp.WithNumber("ruleset_id",
+			mcp.Required(),
+			mcp.Description("The ID of the ruleset"),
+		)(tool)
+	}
+}
+
+// rulesetPath returns the API path of the ruleset given by the owner parameters, along with a
+// description of it for error messages.
+func rulesetPath(request mcp.CallToolRequest) (string, string, error) {
+	owner, err := OptionalParam[string](request, "owner")
+	if err != nil {
+		return "", "", err
+	}
+	repo, err := OptionalParam[string](request, "repo")
+	if err != nil {
+		return "", "", err
+	}
+	enterprise, err := OptionalParam[string](request, "enterprise")
+	if err != nil {
+		return "", "", err
+	}
+	rulesetID, err := RequiredInt(request, "ruleset_id")
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case enterprise != "":
+		if owner != "" || repo != "" {
+			return "", "", errors.New("enterprise cannot be combined with owner or repo")
+		}
+		return fmt.Sprintf("enterprises/%s/rulesets/%d", url.PathEscape(enterprise), rulesetID),
+			fmt.Sprintf("ruleset %d of enterprise %s", rulesetID, enterprise), nil
+	case owner != "" && repo != "":
+		return fmt.Sprintf("repos/%s/%s/rulesets/%d", url.PathEscape(owner), url.PathEscape(repo), rulesetID),
+			fmt.Sprintf("ruleset %d of repository %s/%s", rulesetID, owner, repo), nil
+	case owner != "":
+		return fmt.Sprintf("orgs/%s/rulesets/%d", url.PathEscape(owner), rulesetID),
+			fmt.Sprintf("ruleset %d of organization %s", rulesetID, owner), nil
+	default:
+		return "", "", errors.New("either owner or enterprise is required")
+	}
+}
+
+// UpdateRepositoryRuleset creates a tool to change a repository, organization or enterprise ruleset.
+func UpdateRepositoryRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_repository_ruleset",
+			mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_RULESET_DESCRIPTION", "Update a ruleset of a repository, organization or enterprise. Only the given fields are changed; bypass_actors, conditions and rules replace the current ones as a whole.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_REPOSITORY_RULESET_USER_TITLE", "Update ruleset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withRulesetOwner(),
+			mcp.WithString("name",
+				mcp.Description("Name of the ruleset"),
+			),
+			mcp.WithString("enforcement",
+				mcp.Description("Whether the ruleset is enforced. Evaluate mode reports violations without blocking them and is only available on GitHub Enterprise"),
+				mcp.Enum("active", "evaluate", "disabled"),
+			),
+			mcp.WithString("target",
+				mcp.Description("What the ruleset applies to"),
+				mcp.Enum("branch", "tag", "push", "repository"),
+			),
+			mcp.WithArray("bypass_actors",
+				mcp.Description("Actors that can bypass the ruleset, e.g. [{\"actor_id\": 5, \"actor_type\": \"RepositoryRole\", \"bypass_mode\": \"always\"}]. Pass an empty list to let no one bypass it"),
+				mcp.Items(map[string]any{"type": "object"}),
+			),
+			mcp.WithObject("conditions",
+				mcp.Description("Which refs, repositories or organizations the ruleset applies to, e.g. {\"ref_name\": {\"include\": [\"~DEFAULT_BRANCH\"], \"exclude\": []}}"),
+			),
+			mcp.WithArray("rules",
+				mcp.Description("Rules of the ruleset, e.g. [{\"type\": \"deletion\"}, {\"type\": \"pull_request\", \"parameters\": {\"required_approving_review_count\": 1}}]"),
+				mcp.Items(map[string]any{"type": "object"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			path, description, err := rulesetPath(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Send only the given fields, as the API leaves the others unchanged but a typed
+			// ruleset would reset its name and enforcement.
+			update := map[string]any{}
+			for _, param := range []string{"name", "enforcement", "target"} {
+				value, ok, err := OptionalParamOK[string](request, param)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					update[param] = value
+				}
+			}
+			for _, param := range []string{"bypass_actors", "rules"} {
+				if value, ok := request.GetArguments()[param]; ok {
+					if _, ok := value.([]any); !ok {
+						return mcp.NewToolResultError(fmt.Sprintf("%s must be an array of objects", param)), nil
+					}
+					update[param] = value
+				}
+			}
+			if value, ok := request.GetArguments()["conditions"]; ok {
+				if _, ok := value.(map[string]any); !ok {
+					return mcp.NewToolResultError("conditions must be an object"), nil
+				}
+				update["conditions"] = value
+			}
+			if len(update) == 0 {
+				return mcp.NewToolResultError("at least one field to update must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			req, err := client.NewRequest(http.MethodPut, path, update)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var ruleset json.RawMessage
+			resp, err := client.Do(ctx, req, &ruleset)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update %s", description),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(string(ruleset)), nil
+		}
+}
+
+// DeleteRepositoryRuleset creates a tool to delete a repository, organization or enterprise ruleset.
+func DeleteRepositoryRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_repository_ruleset",
+			mcp.WithDescription(t("TOOL_DELETE_REPOSITORY_RULESET_DESCRIPTION", "Delete a ruleset of a repository, organization or enterprise. To stop enforcing a ruleset without losing it, set its enforcement to disabled with update_repository_ruleset instead.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REPOSITORY_RULESET_USER_TITLE", "Delete ruleset"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withRulesetOwner(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			path, description, err := rulesetPath(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			req, err := client.NewRequest(http.MethodDelete, path, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			resp, err := client.Do(ctx, req, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete %s", description),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("%s deleted", description)), nil
+		}
+}
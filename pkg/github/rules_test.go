@@ -0,0 +1,283 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdateRepositoryRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepositoryRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_repository_ruleset", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "enterprise")
+	assert.Contains(t, tool.InputSchema.Properties, "rules")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"ruleset_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "repository ruleset sends only the given fields",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+					expectRequestBody(t, map[string]interface{}{
+						"enforcement": "disabled",
+					}).andThen(
+						mockResponse(t, http.StatusOK, `{"id":42,"name":"main","enforcement":"disabled"}`),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ruleset_id":  float64(42),
+				"enforcement": "disabled",
+			},
+			expectedText: `{"id":42,"name":"main","enforcement":"disabled"}`,
+		},
+		{
+			name: "organization ruleset replaces conditions and rules",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsRulesetsByOrgByRulesetId,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "protect default branches",
+						"conditions": map[string]interface{}{
+							"ref_name": map[string]interface{}{
+								"include": []interface{}{"~DEFAULT_BRANCH"},
+								"exclude": []interface{}{},
+							},
+						},
+						"rules": []interface{}{
+							map[string]interface{}{"type": "deletion"},
+						},
+						"bypass_actors": []interface{}{},
+					}).andThen(
+						mockResponse(t, http.StatusOK, `{"id":7,"name":"protect default branches"}`),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "acme",
+				"ruleset_id": float64(7),
+				"name":       "protect default branches",
+				"conditions": map[string]interface{}{
+					"ref_name": map[string]interface{}{
+						"include": []interface{}{"~DEFAULT_BRANCH"},
+						"exclude": []interface{}{},
+					},
+				},
+				"rules": []interface{}{
+					map[string]interface{}{"type": "deletion"},
+				},
+				"bypass_actors": []interface{}{},
+			},
+			expectedText: `"name":"protect default branches"`,
+		},
+		{
+			name: "enterprise ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutEnterprisesRulesetsByEnterpriseByRulesetId,
+					expectRequestBody(t, map[string]interface{}{
+						"target": "push",
+					}).andThen(
+						mockResponse(t, http.StatusOK, `{"id":3,"target":"push"}`),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme-corp",
+				"ruleset_id": float64(3),
+				"target":     "push",
+			},
+			expectedText: `"target":"push"`,
+		},
+		{
+			name:         "enterprise cannot be combined with owner",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme-corp",
+				"owner":      "acme",
+				"ruleset_id": float64(3),
+				"name":       "x",
+			},
+			expectError:    true,
+			expectedErrMsg: "enterprise cannot be combined with owner or repo",
+		},
+		{
+			name:         "owner or enterprise is required",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"ruleset_id": float64(3),
+				"name":       "x",
+			},
+			expectError:    true,
+			expectedErrMsg: "either owner or enterprise is required",
+		},
+		{
+			name:         "nothing to update",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one field to update must be given",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(42),
+				"name":       "x",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update ruleset 42 of repository owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRepositoryRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
+
+func Test_DeleteRepositoryRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRepositoryRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_repository_ruleset", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"ruleset_id"})
+
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "repository ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.DeleteReposRulesetsByOwnerByRepoByRulesetId, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(42),
+			},
+			expectedText: "ruleset 42 of repository owner/repo deleted",
+		},
+		{
+			name: "organization ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.DeleteOrgsRulesetsByOrgByRulesetId, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "acme",
+				"ruleset_id": float64(7),
+			},
+			expectedText: "ruleset 7 of organization acme deleted",
+		},
+		{
+			name: "enterprise ruleset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.DeleteEnterprisesRulesetsByEnterpriseByRulesetId, noContent),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme-corp",
+				"ruleset_id": float64(3),
+			},
+			expectedText: "ruleset 3 of enterprise acme-corp deleted",
+		},
+		{
+			name: "delete fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsRulesetsByOrgByRulesetId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must be an organization owner"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "acme",
+				"ruleset_id": float64(7),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete ruleset 7 of organization acme",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRepositoryRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
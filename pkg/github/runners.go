@@ -0,0 +1,511 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// runnerLabels is the response of the runner labels endpoints, which go-github does not cover.
+type runnerLabels struct {
+	TotalCount int                    `json:"total_count"`
+	Labels     []*github.RunnerLabels `json:"labels"`
+}
+
+// runnersPath returns the API path of the self-hosted runners of a repository, or of an
+// organization when repo is empty.
+func runnersPath(owner, repo string) string {
+	if repo == "" {
+		return fmt.Sprintf("orgs/%s/actions/runners", url.PathEscape(owner))
+	}
+	return fmt.Sprintf("repos/%s/%s/actions/runners", url.PathEscape(owner), url.PathEscape(repo))
+}
+
+// withRunnerScope adds the owner and repo parameters of the runner tools, which act on the
+// runners of a repository, or of an organization when repo is omitted.
+func withRunnerScope(action string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner, or organization name when repo is omitted"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Description(fmt.Sprintf("Repository name. Omit to %s organization runners.", action)),
+		)(tool)
+	}
+}
+
+// runnerScopeParams returns the owner and repo parameters added by withRunnerScope.
+func runnerScopeParams(request mcp.CallToolRequest) (owner, repo string, err error) {
+	owner, err = RequiredParam[string](request, "owner")
+	if err != nil {
+		return "", "", err
+	}
+	repo, err = OptionalParam[string](request, "repo")
+	if err != nil {
+		return "", "", err
+	}
+	return owner, repo, nil
+}
+
+// ListRunners creates a tool to list the self-hosted runners of a repository or organization.
+func ListRunners(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_runners",
+			mcp.WithDescription(t("TOOL_LIST_RUNNERS_DESCRIPTION", "List the self-hosted runners of a repository, or of an organization when repo is omitted, with their status, whether they are busy and their labels")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RUNNERS_USER_TITLE", "List self-hosted runners"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withRunnerScope("list"),
+			mcp.WithString("name",
+				mcp.Description("Only list runners with this name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := runnerScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListRunnersOptions{
+				Name: ToStringPtr(name),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			var runners *github.Runners
+			var resp *github.Response
+			if repo == "" {
+				runners, resp, err = client.Actions.ListOrganizationRunners(ctx, owner, opts)
+			} else {
+				runners, resp, err = client.Actions.ListRunners(ctx, owner, repo, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list runners", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withPageInfo(MarshalledTextResult(runners), restPageInfo(resp, &runners.TotalCount)), nil
+		}
+}
+
+// GetRunner creates a tool to get a self-hosted runner of a repository or organization.
+func GetRunner(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_runner",
+			mcp.WithDescription(t("TOOL_GET_RUNNER_DESCRIPTION", "Get a self-hosted runner of a repository, or of an organization when repo is omitted")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RUNNER_USER_TITLE", "Get self-hosted runner"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			withRunnerScope("get"),
+			mcp.WithNumber("runner_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the runner"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := runnerScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runnerID, err := RequiredInt(request, "runner_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var runner *github.Runner
+			var resp *github.Response
+			if repo == "" {
+				runner, resp, err = client.Actions.GetOrganizationRunner(ctx, owner, int64(runnerID))
+			} else {
+				runner, resp, err = client.Actions.GetRunner(ctx, owner, repo, int64(runnerID))
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get runner", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(runner), nil
+		}
+}
+
+// CreateRunnerToken creates a tool to create a token to register or remove a self-hosted runner.
+func CreateRunnerToken(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_runner_token",
+			mcp.WithDescription(t("TOOL_CREATE_RUNNER_TOKEN_DESCRIPTION", "Create a token for configuring a self-hosted runner of a repository, or of an organization when repo is omitted: a registration token for adding a runner with config.sh, or a remove token for removing one. Tokens expire after an hour")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_RUNNER_TOKEN_USER_TITLE", "Create runner token"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withRunnerScope("create a token for"),
+			mcp.WithString("kind",
+				mcp.Required(),
+				mcp.Description("Whether the token registers a runner or removes one"),
+				mcp.Enum("registration", "remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := runnerScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			kind, err := RequiredParam[string](request, "kind")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var token string
+			var expiresAt github.Timestamp
+			var resp *github.Response
+			switch kind {
+			case "registration":
+				var registration *github.RegistrationToken
+				if repo == "" {
+					registration, resp, err = client.Actions.CreateOrganizationRegistrationToken(ctx, owner)
+				} else {
+					registration, resp, err = client.Actions.CreateRegistrationToken(ctx, owner, repo)
+				}
+				token, expiresAt = registration.GetToken(), registration.GetExpiresAt()
+			case "remove":
+				var remove *github.RemoveToken
+				if repo == "" {
+					remove, resp, err = client.Actions.CreateOrganizationRemoveToken(ctx, owner)
+				} else {
+					remove, resp, err = client.Actions.CreateRemoveToken(ctx, owner, repo)
+				}
+				token, expiresAt = remove.GetToken(), remove.GetExpiresAt()
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported kind %q, must be 'registration' or 'remove'", kind)), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create %s token", kind), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"kind":       kind,
+				"token":      token,
+				"expires_at": expiresAt,
+			}), nil
+		}
+}
+
+// RemoveRunner creates a tool to remove a self-hosted runner from a repository or organization.
+func RemoveRunner(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_runner",
+			mcp.WithDescription(t("TOOL_REMOVE_RUNNER_DESCRIPTION", "Remove a self-hosted runner from a repository, or from an organization when repo is omitted, without needing access to the machine it runs on")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_RUNNER_USER_TITLE", "Remove self-hosted runner"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withRunnerScope("remove"),
+			mcp.WithNumber("runner_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the runner"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := runnerScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runnerID, err := RequiredInt(request, "runner_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if repo == "" {
+				resp, err = client.Actions.RemoveOrganizationRunner(ctx, owner, int64(runnerID))
+			} else {
+				resp, err = client.Actions.RemoveRunner(ctx, owner, repo, int64(runnerID))
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to remove runner", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Runner %d removed", runnerID)), nil
+		}
+}
+
+// UpdateRunnerLabels creates a tool to add, replace or remove the custom labels of a self-hosted runner.
+func UpdateRunnerLabels(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_runner_labels",
+			mcp.WithDescription(t("TOOL_UPDATE_RUNNER_LABELS_DESCRIPTION", "Add, replace or remove the custom labels of a self-hosted runner of a repository, or of an organization when repo is omitted. The labels runners are given automatically, such as self-hosted and linux, are left alone")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_RUNNER_LABELS_USER_TITLE", "Update runner labels"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withRunnerScope("update"),
+			mcp.WithNumber("runner_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the runner"),
+			),
+			mcp.WithString("action",
+				mcp.Required(),
+				mcp.Description("Whether to add the labels, replace all custom labels with them, or remove them"),
+				mcp.Enum("add", "set", "remove"),
+			),
+			mcp.WithArray("labels",
+				mcp.Required(),
+				mcp.Description("Names of the custom labels. With set, an empty list removes all custom labels"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, err := runnerScopeParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runnerID, err := RequiredInt(request, "runner_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			action, err := RequiredParam[string](request, "action")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labels, err := OptionalStringArrayParam(request, "labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(labels) == 0 && action != "set" {
+				return mcp.NewToolResultError(fmt.Sprintf("labels are required to %s labels", action)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github has no methods for runner labels
+			path := fmt.Sprintf("%s/%d/labels", runnersPath(owner, repo), runnerID)
+			var requests []*http.Request
+			switch action {
+			case "add", "set":
+				method := http.MethodPost
+				if action == "set" {
+					method = http.MethodPut
+				}
+				req, err := client.NewRequest(method, path, map[string][]string{"labels": labels})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create request: %w", err)
+				}
+				requests = append(requests, req)
+			case "remove":
+				for _, label := range labels {
+					req, err := client.NewRequest(http.MethodDelete, path+"/"+url.PathEscape(label), nil)
+					if err != nil {
+						return nil, fmt.Errorf("failed to create request: %w", err)
+					}
+					requests = append(requests, req)
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported action %q, must be 'add', 'set' or 'remove'", action)), nil
+			}
+
+			var result runnerLabels
+			for _, req := range requests {
+				resp, err := client.Do(ctx, req, &result)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update runner labels", resp, err), nil
+				}
+				_ = resp.Body.Close()
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// ListRunnerGroups creates a tool to list the self-hosted runner groups of an organization.
+func ListRunnerGroups(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_runner_groups",
+			mcp.WithDescription(t("TOOL_LIST_RUNNER_GROUPS_DESCRIPTION", "List the self-hosted runner groups of an organization, which control the repositories and workflows their runners may be used by")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RUNNER_GROUPS_USER_TITLE", "List runner groups"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithString("visible_to_repository",
+				mcp.Description("Only list the groups that this repository of the organization may use"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibleToRepository, err := OptionalParam[string](request, "visible_to_repository")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			groups, resp, err := client.Actions.ListOrganizationRunnerGroups(ctx, org, &github.ListOrgRunnerGroupOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+				VisibleToRepository: visibleToRepository,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list runner groups", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withPageInfo(MarshalledTextResult(groups), restPageInfo(resp, &groups.TotalCount)), nil
+		}
+}
+
+// ListRunnerGroupRunners creates a tool to list the self-hosted runners in an organization runner group.
+func ListRunnerGroupRunners(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_runner_group_runners",
+			mcp.WithDescription(t("TOOL_LIST_RUNNER_GROUP_RUNNERS_DESCRIPTION", "List the self-hosted runners in a runner group of an organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RUNNER_GROUP_RUNNERS_USER_TITLE", "List runner group runners"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the runner group"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			groupID, err := RequiredInt(request, "group_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			runners, resp, err := client.Actions.ListRunnerGroupRunners(ctx, org, int64(groupID), &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list runner group runners", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return withPageInfo(MarshalledTextResult(runners), restPageInfo(resp, &runners.TotalCount)), nil
+		}
+}
+
+// MoveRunnerToGroup creates a tool to move a self-hosted runner of an organization into a runner group.
+func MoveRunnerToGroup(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("move_runner_to_group",
+			mcp.WithDescription(t("TOOL_MOVE_RUNNER_TO_GROUP_DESCRIPTION", "Move a self-hosted runner of an organization into a runner group, taking it out of the group it was in. Move it to the Default group to undo this")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MOVE_RUNNER_TO_GROUP_USER_TITLE", "Move runner to group"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			mcp.WithNumber("group_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the runner group, as returned by list_runner_groups"),
+			),
+			mcp.WithNumber("runner_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the runner"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			groupID, err := RequiredInt(request, "group_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runnerID, err := RequiredInt(request, "runner_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.AddRunnerGroupRunners(ctx, org, int64(groupID), int64(runnerID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to move runner to group", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Runner %d moved to runner group %d", runnerID, groupID)), nil
+		}
+}
@@ -0,0 +1,411 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRunners(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRunners(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_runners", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	mockRunners := &github.Runners{
+		TotalCount: 1,
+		Runners: []*github.Runner{{
+			ID:     github.Ptr(int64(23)),
+			Name:   github.Ptr("build-1"),
+			Status: github.Ptr("online"),
+			Busy:   github.Ptr(true),
+			Labels: []*github.RunnerLabels{{Name: github.Ptr("self-hosted"), Type: github.Ptr("read-only")}},
+		}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository runners",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunnersByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"name":     "build-1",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRunners),
+					),
+				),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "name": "build-1"},
+		},
+		{
+			name: "organization runners",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsActionsRunnersByOrg, mockRunners),
+			),
+			requestArgs: map[string]any{"owner": "octo-org"},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsRunnersByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "octo-org"},
+			expectError:    true,
+			expectedErrMsg: "failed to list runners",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRunners(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var runners github.Runners
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &runners))
+			require.Len(t, runners.Runners, 1)
+			assert.Equal(t, "build-1", runners.Runners[0].GetName())
+			assert.True(t, runners.Runners[0].GetBusy())
+		})
+	}
+}
+
+func Test_GetRunner(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRunner(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_runner", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "runner_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetOrgsActionsRunnersByOrgByRunnerId, &github.Runner{
+			ID:     github.Ptr(int64(23)),
+			Name:   github.Ptr("build-1"),
+			Status: github.Ptr("offline"),
+		}),
+	))
+	_, handler := GetRunner(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "octo-org", "runner_id": float64(23)}))
+	require.NoError(t, err)
+
+	var runner github.Runner
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &runner))
+	assert.Equal(t, "offline", runner.GetStatus())
+}
+
+func Test_CreateRunnerToken(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRunnerToken(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_runner_token", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "kind"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedToken  string
+	}{
+		{
+			name: "repository registration token",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.PostReposActionsRunnersRegistrationTokenByOwnerByRepo, &github.RegistrationToken{Token: github.Ptr("REG123")}),
+			),
+			requestArgs:   map[string]any{"owner": "owner", "repo": "repo", "kind": "registration"},
+			expectedToken: "REG123",
+		},
+		{
+			name: "organization remove token",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.PostOrgsActionsRunnersRemoveTokenByOrg, &github.RemoveToken{Token: github.Ptr("REM456")}),
+			),
+			requestArgs:   map[string]any{"owner": "octo-org", "kind": "remove"},
+			expectedToken: "REM456",
+		},
+		{
+			name: "token creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsActionsRunnersRegistrationTokenByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "octo-org", "kind": "registration"},
+			expectError:    true,
+			expectedErrMsg: "failed to create registration token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRunnerToken(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedToken, response["token"])
+			assert.Equal(t, tc.requestArgs["kind"], response["kind"])
+		})
+	}
+}
+
+func Test_RemoveRunner(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveRunner(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_runner", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposActionsRunnersByOwnerByRepoByRunnerId,
+			mockResponse(t, http.StatusNoContent, nil),
+		),
+	))
+	_, handler := RemoveRunner(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "runner_id": float64(23)}))
+	require.NoError(t, err)
+	assert.Equal(t, "Runner 23 removed", getTextResult(t, result).Text)
+}
+
+func Test_UpdateRunnerLabels(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRunnerLabels(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_runner_labels", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "runner_id", "action", "labels"})
+
+	labels := func(names ...string) runnerLabels {
+		result := runnerLabels{TotalCount: len(names)}
+		for _, name := range names {
+			result.Labels = append(result.Labels, &github.RunnerLabels{Name: github.Ptr(name)})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedLabels []string
+	}{
+		{
+			name: "adds labels",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunnersLabelsByOwnerByRepoByRunnerId,
+					expectRequestBody(t, map[string]any{"labels": []any{"gpu"}}).andThen(
+						mockResponse(t, http.StatusOK, labels("self-hosted", "linux", "gpu")),
+					),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "runner_id": float64(23), "action": "add", "labels": []any{"gpu"}},
+			expectedLabels: []string{"self-hosted", "linux", "gpu"},
+		},
+		{
+			name: "replaces organization runner labels",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsRunnersLabelsByOrgByRunnerId,
+					expectRequestBody(t, map[string]any{"labels": []any{"large", "arm64"}}).andThen(
+						mockResponse(t, http.StatusOK, labels("self-hosted", "large", "arm64")),
+					),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "octo-org", "runner_id": float64(23), "action": "set", "labels": []any{"large", "arm64"}},
+			expectedLabels: []string{"self-hosted", "large", "arm64"},
+		},
+		{
+			name: "removes labels one by one",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.DeleteReposActionsRunnersLabelsByOwnerByRepoByRunnerIdByName,
+					labels("self-hosted", "linux", "gpu"),
+					labels("self-hosted", "linux"),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "runner_id": float64(23), "action": "remove", "labels": []any{"large", "arm64"}},
+			expectedLabels: []string{"self-hosted", "linux"},
+		},
+		{
+			name:           "labels are required to add",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "runner_id": float64(23), "action": "add", "labels": []any{}},
+			expectError:    true,
+			expectedErrMsg: "labels are required to add labels",
+		},
+		{
+			name: "unknown label",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsRunnersLabelsByOwnerByRepoByRunnerIdByName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "runner_id": float64(23), "action": "remove", "labels": []any{"missing"}},
+			expectError:    true,
+			expectedErrMsg: "failed to update runner labels",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRunnerLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response runnerLabels
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			var names []string
+			for _, label := range response.Labels {
+				names = append(names, label.GetName())
+			}
+			assert.Equal(t, tc.expectedLabels, names)
+		})
+	}
+}
+
+func Test_ListRunnerGroups(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRunnerGroups(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_runner_groups", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsActionsRunnerGroupsByOrg,
+			expectQueryParams(t, map[string]string{
+				"visible_to_repository": "hello-world",
+				"page":                  "1",
+				"per_page":              "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.RunnerGroups{
+					TotalCount:   1,
+					RunnerGroups: []*github.RunnerGroup{{ID: github.Ptr(int64(1)), Name: github.Ptr("Default"), Default: github.Ptr(true)}},
+				}),
+			),
+		),
+	))
+	_, handler := ListRunnerGroups(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org", "visible_to_repository": "hello-world"}))
+	require.NoError(t, err)
+
+	var groups github.RunnerGroups
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &groups))
+	require.Len(t, groups.RunnerGroups, 1)
+	assert.Equal(t, "Default", groups.RunnerGroups[0].GetName())
+}
+
+func Test_ListRunnerGroupRunners(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRunnerGroupRunners(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_runner_group_runners", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "group_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetOrgsActionsRunnerGroupsRunnersByOrgByRunnerGroupId, &github.Runners{
+			TotalCount: 1,
+			Runners:    []*github.Runner{{ID: github.Ptr(int64(23)), Name: github.Ptr("build-1")}},
+		}),
+	))
+	_, handler := ListRunnerGroupRunners(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org", "group_id": float64(2)}))
+	require.NoError(t, err)
+
+	var runners github.Runners
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &runners))
+	require.Len(t, runners.Runners, 1)
+	assert.Equal(t, int64(23), runners.Runners[0].GetID())
+}
+
+func Test_MoveRunnerToGroup(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MoveRunnerToGroup(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "move_runner_to_group", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "group_id", "runner_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PutOrgsActionsRunnerGroupsRunnersByOrgByRunnerGroupIdByRunnerId,
+			mockResponse(t, http.StatusNoContent, nil),
+		),
+	))
+	_, handler := MoveRunnerToGroup(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"org": "octo-org", "group_id": float64(2), "runner_id": float64(23)}))
+	require.NoError(t, err)
+	assert.Equal(t, "Runner 23 moved to runner group 2", getTextResult(t, result).Text)
+}
@@ -0,0 +1,557 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/journal"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxScaffoldFiles bounds the number of template files rendered in a single call.
+const maxScaffoldFiles = 300
+
+// scaffoldPlaceholder matches cookiecutter-style placeholders such as
+// {{ cookiecutter.project_name }} or {{project_name}}.
+var scaffoldPlaceholder = regexp.MustCompile(`\{\{\s*(?:cookiecutter\.)?([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// renderScaffoldTemplate replaces all placeholders in text with values from vars.
+// It returns the names of any placeholders that have no value, in which case the
+// returned text should not be used.
+func renderScaffoldTemplate(text string, vars map[string]string) (string, []string) {
+	var missing []string
+	rendered := scaffoldPlaceholder.ReplaceAllStringFunc(text, func(match string) string {
+		name := scaffoldPlaceholder.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return value
+	})
+	return rendered, missing
+}
+
+// isBinaryContent reports whether content looks like a binary file, in which case
+// it is copied verbatim instead of being rendered. Content that is not valid UTF-8 is
+// binary too, as it would be mangled if written as a string.
+func isBinaryContent(content []byte) bool {
+	return bytes.IndexByte(content, 0) != -1 || !utf8.Valid(content)
+}
+
+// ScaffoldFile describes a file written by render_scaffold.
+type ScaffoldFile struct {
+	TemplatePath string `json:"template_path"`
+	Path         string `json:"path"`
+	Rendered     bool   `json:"rendered"`
+}
+
+// ScaffoldResult is the output of render_scaffold.
+type ScaffoldResult struct {
+	Repository string         `json:"repository"`
+	Branch     string         `json:"branch"`
+	CommitSHA  string         `json:"commit_sha"`
+	CommitURL  string         `json:"commit_url,omitempty"`
+	Created    bool           `json:"repository_created"`
+	Files      []ScaffoldFile `json:"files"`
+}
+
+// RenderScaffold creates a tool that renders a template repository with a set of variables and
+// pushes the result into a new or existing repository as a single commit.
+func RenderScaffold(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("render_scaffold",
+			mcp.WithDescription(t("TOOL_RENDER_SCAFFOLD_DESCRIPTION", "Render a template repository with cookiecutter-style {{variable}} placeholders in file paths and contents, and push the rendered files into a new or existing repository as a single commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RENDER_SCAFFOLD_USER_TITLE", "Render scaffold from template repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("template_owner",
+				mcp.Required(),
+				mcp.Description("Template repository owner"),
+			),
+			mcp.WithString("template_repo",
+				mcp.Required(),
+				mcp.Description("Template repository name"),
+			),
+			mcp.WithString("template_ref",
+				mcp.Description("Branch, tag or commit SHA of the template to render (defaults to the template's default branch)"),
+			),
+			mcp.WithString("template_path",
+				mcp.Description("Only render files under this directory of the template. The directory prefix is stripped from the rendered paths."),
+			),
+			mcp.WithObject("variables",
+				mcp.Required(),
+				mcp.Description("Values for the template placeholders, e.g. {\"project_name\": \"billing\"}. Both {{project_name}} and {{cookiecutter.project_name}} placeholders are supported."),
+				mcp.AdditionalProperties(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Target repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Target repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Target branch to commit to (defaults to the target repository's default branch)"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithBoolean("create_repository",
+				mcp.Description("Create the target repository first. It must not already exist."),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether a newly created target repository should be private"),
+			),
+		),
+		withOperationJournal("render_scaffold", func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			templateOwner, err := RequiredParam[string](request, "template_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateRepo, err := RequiredParam[string](request, "template_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateRef, err := OptionalParam[string](request, "template_ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templatePath, err := OptionalParam[string](request, "template_path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			createRepo, err := OptionalParam[bool](request, "create_repository")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			variablesObj, ok := request.GetArguments()["variables"].(map[string]interface{})
+			if !ok {
+				return mcp.NewToolResultError("variables parameter must be an object of string values"), nil
+			}
+			variables := make(map[string]string, len(variablesObj))
+			for k, v := range variablesObj {
+				s, ok := v.(string)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("variable %s must be a string, is %T", k, v)), nil
+				}
+				variables[k] = s
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Resolve the template tree to render
+			if templateRef == "" {
+				templateRepository, resp, err := client.Repositories.Get(ctx, templateOwner, templateRepo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get template repository",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				templateRef = templateRepository.GetDefaultBranch()
+			}
+
+			templateTree, resp, err := client.Git.GetTree(ctx, templateOwner, templateRepo, templateRef, true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get template tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if templateTree.GetTruncated() {
+				return mcp.NewToolResultError("template tree is too large to render; use template_path to select a subdirectory"), nil
+			}
+
+			prefix := strings.Trim(templatePath, "/")
+			if prefix != "" {
+				prefix += "/"
+			}
+
+			var templateEntries []*github.TreeEntry
+			for _, entry := range templateTree.Entries {
+				if entry.GetType() != "blob" || !strings.HasPrefix(entry.GetPath(), prefix) {
+					continue
+				}
+				templateEntries = append(templateEntries, entry)
+			}
+			if len(templateEntries) == 0 {
+				return mcp.NewToolResultError("no template files found to render"), nil
+			}
+			if len(templateEntries) > maxScaffoldFiles {
+				return mcp.NewToolResultError(fmt.Sprintf("template contains %d files which exceeds the maximum of %d; use template_path to select a subdirectory", len(templateEntries), maxScaffoldFiles)), nil
+			}
+
+			// Render all file paths and contents before touching the target repository,
+			// so that a missing variable never leaves a half-created scaffold behind.
+			type renderedFile struct {
+				file    ScaffoldFile
+				mode    string
+				content []byte
+			}
+			renderedFiles := make([]renderedFile, 0, len(templateEntries))
+			missingVars := map[string]struct{}{}
+			for _, entry := range templateEntries {
+				path, missing := renderScaffoldTemplate(strings.TrimPrefix(entry.GetPath(), prefix), variables)
+				for _, m := range missing {
+					missingVars[m] = struct{}{}
+				}
+
+				content, resp, err := client.Git.GetBlobRaw(ctx, templateOwner, templateRepo, entry.GetSHA())
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get template file: %s", entry.GetPath()),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				rendered := false
+				if !isBinaryContent(content) {
+					text, missing := renderScaffoldTemplate(string(content), variables)
+					for _, m := range missing {
+						missingVars[m] = struct{}{}
+					}
+					rendered = text != string(content)
+					content = []byte(text)
+				}
+
+				renderedFiles = append(renderedFiles, renderedFile{
+					file: ScaffoldFile{
+						TemplatePath: entry.GetPath(),
+						Path:         path,
+						Rendered:     rendered,
+					},
+					mode:    entry.GetMode(),
+					content: content,
+				})
+			}
+			if len(missingVars) > 0 {
+				names := make([]string, 0, len(missingVars))
+				for name := range missingVars {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				return mcp.NewToolResultError(fmt.Sprintf("missing values for template variables: %s", strings.Join(names, ", "))), nil
+			}
+
+			// Prepare the target repository
+			if createRepo {
+				output, result, err := runOperationStep(ctx, "create_repository", func() (map[string]string, *mcp.CallToolResult, error) {
+					user, resp, err := client.Users.Get(ctx, "")
+					if err != nil {
+						return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to get authenticated user",
+							resp,
+							err,
+						), nil
+					}
+					defer func() { _ = resp.Body.Close() }()
+
+					org := ""
+					if !strings.EqualFold(user.GetLogin(), owner) {
+						org = owner
+					}
+					newRepo := &github.Repository{
+						Name:     github.Ptr(repo),
+						Private:  github.Ptr(private),
+						AutoInit: github.Ptr(true),
+					}
+					createdRepo, resp, err := client.Repositories.Create(ctx, org, newRepo)
+					if err != nil {
+						return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+							"failed to create repository",
+							resp,
+							err,
+						), nil
+					}
+					defer func() { _ = resp.Body.Close() }()
+
+					return map[string]string{"default_branch": createdRepo.GetDefaultBranch()}, nil, nil
+				})
+				if result != nil || err != nil {
+					return result, err
+				}
+
+				if branch == "" {
+					branch = output["default_branch"]
+				}
+			}
+			if branch == "" {
+				targetRepository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				branch = targetRepository.GetDefaultBranch()
+			}
+
+			commitOutput, result, err := runOperationStep(ctx, "create_commit", func() (map[string]string, *mcp.CallToolResult, error) {
+				ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+				if err != nil {
+					return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get branch reference",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, ref.GetObject().GetSHA())
+				if err != nil {
+					return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get base commit",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				// Build the tree; binary files must be uploaded as blobs since the tree API only accepts text content.
+				entries := make([]*github.TreeEntry, 0, len(renderedFiles))
+				for _, f := range renderedFiles {
+					mode := f.mode
+					if mode == "" {
+						mode = "100644"
+					}
+					entry := &github.TreeEntry{
+						Path: github.Ptr(f.file.Path),
+						Mode: github.Ptr(mode),
+						Type: github.Ptr("blob"),
+					}
+					if isBinaryContent(f.content) {
+						blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+							Content:  github.Ptr(base64.StdEncoding.EncodeToString(f.content)),
+							Encoding: github.Ptr("base64"),
+						})
+						if err != nil {
+							return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+								fmt.Sprintf("failed to create blob: %s", f.file.Path),
+								resp,
+								err,
+							), nil
+						}
+						_ = resp.Body.Close()
+						entry.SHA = blob.SHA
+					} else {
+						entry.Content = github.Ptr(string(f.content))
+					}
+					entries = append(entries, entry)
+				}
+
+				newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), entries)
+				if err != nil {
+					return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to create tree",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				commit := &github.Commit{
+					Message: github.Ptr(message),
+					Tree:    newTree,
+					Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+				}
+				newCommit, resp, err := createCommit(ctx, client, owner, repo, commit)
+				if err != nil {
+					return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to create commit",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return map[string]string{
+					"base_sha":   baseCommit.GetSHA(),
+					"commit_sha": newCommit.GetSHA(),
+					"commit_url": newCommit.GetHTMLURL(),
+				}, nil, nil
+			})
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			_, result, err = runOperationStep(ctx, "update_ref", func() (map[string]string, *mcp.CallToolResult, error) {
+				ref := &github.Reference{
+					Ref:    github.Ptr("refs/heads/" + branch),
+					Object: &github.GitObject{SHA: github.Ptr(commitOutput["commit_sha"])},
+				}
+				_, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, false)
+				if err != nil {
+					return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to update reference",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusOK {
+					return nil, mcp.NewToolResultError(fmt.Sprintf("failed to update reference: unexpected status %d", resp.StatusCode)), nil
+				}
+				return map[string]string{
+					"branch":       branch,
+					"previous_sha": commitOutput["base_sha"],
+				}, nil, nil
+			})
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			files := make([]ScaffoldFile, 0, len(renderedFiles))
+			for _, f := range renderedFiles {
+				files = append(files, f.file)
+			}
+
+			scaffold := ScaffoldResult{
+				Repository: owner + "/" + repo,
+				Branch:     branch,
+				CommitSHA:  commitOutput["commit_sha"],
+				CommitURL:  commitOutput["commit_url"],
+				Created:    createRepo,
+				Files:      files,
+			}
+
+			r, err := json.Marshal(scaffold)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		})
+}
+
+// rollbackRenderScaffold undoes the steps of a render_scaffold operation. A repository created by
+// the operation is deleted; otherwise the branch is reset to the commit it pointed at before the
+// scaffold was pushed. Either is only done provided nothing has been pushed since.
+func rollbackRenderScaffold(ctx context.Context, client *github.Client, op *journal.Operation) error {
+	owner := operationArgument(op, "owner")
+	repo := operationArgument(op, "repo")
+
+	if created, ok := op.Step("create_repository"); ok {
+		if err := checkScaffoldRepositoryUnchanged(ctx, client, op, created.Output["default_branch"]); err != nil {
+			return err
+		}
+		resp, err := client.Repositories.Delete(ctx, owner, repo)
+		if err != nil {
+			return fmt.Errorf("failed to delete repository %s/%s: %w", owner, repo, err)
+		}
+		_ = resp.Body.Close()
+		return nil
+	}
+
+	updated, ok := op.Step("update_ref")
+	if !ok {
+		// Only unreferenced git objects were created, there is nothing visible to undo.
+		return nil
+	}
+	committed, _ := op.Step("create_commit")
+	branch := updated.Output["branch"]
+
+	ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return fmt.Errorf("failed to get branch reference: %w", err)
+	}
+	_ = resp.Body.Close()
+	if ref.GetObject().GetSHA() != committed.Output["commit_sha"] {
+		return fmt.Errorf("branch %s has moved since the operation completed", branch)
+	}
+
+	ref.Object.SHA = github.Ptr(updated.Output["previous_sha"])
+	_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, true)
+	if err != nil {
+		return fmt.Errorf("failed to reset branch %s: %w", branch, err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// checkScaffoldRepositoryUnchanged checks that the default branch of a repository created by a
+// render_scaffold operation still points at the commit the operation left it at: the scaffold
+// commit once the branch was updated, and otherwise the initial commit of the repository.
+func checkScaffoldRepositoryUnchanged(ctx context.Context, client *github.Client, op *journal.Operation, branch string) error {
+	owner := operationArgument(op, "owner")
+	repo := operationArgument(op, "repo")
+
+	ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return fmt.Errorf("failed to get branch reference: %w", err)
+	}
+	_ = resp.Body.Close()
+	head := ref.GetObject().GetSHA()
+
+	expected := ""
+	if committed, ok := op.Step("create_commit"); ok {
+		expected = committed.Output["base_sha"]
+		if _, ok := op.Step("update_ref"); ok {
+			expected = committed.Output["commit_sha"]
+		}
+	}
+	if expected != "" {
+		if head != expected {
+			return fmt.Errorf("repository %s/%s has changed since the operation created it", owner, repo)
+		}
+		return nil
+	}
+
+	commit, resp, err := client.Git.GetCommit(ctx, owner, repo, head)
+	if err != nil {
+		return fmt.Errorf("failed to get commit: %w", err)
+	}
+	_ = resp.Body.Close()
+	if len(commit.Parents) > 0 {
+		return fmt.Errorf("repository %s/%s has changed since the operation created it", owner, repo)
+	}
+	return nil
+}
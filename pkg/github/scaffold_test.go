@@ -0,0 +1,315 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RenderScaffoldTemplate(t *testing.T) {
+	vars := map[string]string{"name": "billing", "owner": "octo"}
+
+	rendered, missing := renderScaffoldTemplate("# {{ cookiecutter.name }} by {{owner}}", vars)
+	assert.Equal(t, "# billing by octo", rendered)
+	assert.Empty(t, missing)
+
+	_, missing = renderScaffoldTemplate("{{name}} {{ version }} {{cookiecutter.team}}", vars)
+	assert.Equal(t, []string{"version", "team"}, missing)
+
+	// Dotted expressions other than cookiecutter.* (e.g. GitHub Actions expressions) are left alone.
+	rendered, missing = renderScaffoldTemplate("token: ${{ secrets.GITHUB_TOKEN }}", vars)
+	assert.Equal(t, "token: ${{ secrets.GITHUB_TOKEN }}", rendered)
+	assert.Empty(t, missing)
+}
+
+func Test_IsBinaryContent(t *testing.T) {
+	assert.False(t, isBinaryContent([]byte("# billing ✓\n")))
+	assert.True(t, isBinaryContent([]byte("PNG\x00\x01")))
+	// Text in other encodings, such as Latin-1, and binary files without NUL bytes are not valid UTF-8
+	assert.True(t, isBinaryContent([]byte("caf\xe9")))
+	assert.True(t, isBinaryContent([]byte("\x89PNG\r\n")))
+}
+
+func Test_RenderScaffold(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RenderScaffold(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "render_scaffold", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "template_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "template_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "template_ref")
+	assert.Contains(t, tool.InputSchema.Properties, "template_path")
+	assert.Contains(t, tool.InputSchema.Properties, "variables")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "create_repository")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"template_owner", "template_repo", "variables", "owner", "repo", "message"})
+
+	mockTemplateTree := &github.Tree{
+		SHA: github.Ptr("tpl123"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("template"), Type: github.Ptr("tree"), SHA: github.Ptr("dir1")},
+			{Path: github.Ptr("template/{{cookiecutter.name}}/README.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("blob1")},
+			{Path: github.Ptr("template/run.sh"), Type: github.Ptr("blob"), Mode: github.Ptr("100755"), SHA: github.Ptr("blob2")},
+			{Path: github.Ptr("LICENSE"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), SHA: github.Ptr("blob3")},
+		},
+	}
+
+	blobHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/blob1"):
+			_, _ = w.Write([]byte("# {{ cookiecutter.name }}\n"))
+		case strings.HasSuffix(r.URL.Path, "/blob2"):
+			_, _ = w.Write([]byte("#!/bin/sh\necho {{name}}-{{version}}\n"))
+		default:
+			_, _ = w.Write([]byte("MIT"))
+		}
+	})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	mockBaseCommit := &github.Commit{
+		SHA:  github.Ptr("abc123"),
+		Tree: &github.Tree{SHA: github.Ptr("def456")},
+	}
+	mockNewCommit := &github.Commit{
+		SHA:     github.Ptr("jkl012"),
+		HTMLURL: github.Ptr("https://github.com/owner/service/commit/jkl012"),
+	}
+
+	targetRepoHandlers := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				mockRef,
+			),
+			mock.WithRequestMatch(
+				mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+				mockBaseCommit,
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				expectRequestBody(t, map[string]interface{}{
+					"base_tree": "def456",
+					"tree": []interface{}{
+						map[string]interface{}{
+							"path":    "billing/README.md",
+							"mode":    "100644",
+							"type":    "blob",
+							"content": "# billing\n",
+						},
+						map[string]interface{}{
+							"path":    "run.sh",
+							"mode":    "100755",
+							"type":    "blob",
+							"content": "#!/bin/sh\necho billing-1.0\n",
+						},
+					},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("ghi789")}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitCommitsByOwnerByRepo,
+				expectRequestBody(t, map[string]interface{}{
+					"message": "Scaffold billing service",
+					"tree":    "ghi789",
+					"parents": []interface{}{"abc123"},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, mockNewCommit),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposGitRefsByOwnerByRepoByRef,
+				expectRequestBody(t, map[string]interface{}{
+					"sha":   "jkl012",
+					"force": false,
+				}).andThen(
+					mockResponse(t, http.StatusOK, mockRef),
+				),
+			),
+		}
+	}
+
+	templateHandlers := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				mockTemplateTree,
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+				blobHandler,
+			),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult ScaffoldResult
+		expectedErrMsg string
+	}{
+		{
+			name: "renders template into existing branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				append(templateHandlers(), targetRepoHandlers()...)...,
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "platform",
+				"template_repo":  "service-template",
+				"template_ref":   "main",
+				"template_path":  "template/",
+				"variables":      map[string]interface{}{"name": "billing", "version": "1.0"},
+				"owner":          "owner",
+				"repo":           "service",
+				"branch":         "main",
+				"message":        "Scaffold billing service",
+			},
+			expectedResult: ScaffoldResult{
+				Repository: "owner/service",
+				Branch:     "main",
+				CommitSHA:  "jkl012",
+				CommitURL:  "https://github.com/owner/service/commit/jkl012",
+				Files: []ScaffoldFile{
+					{TemplatePath: "template/{{cookiecutter.name}}/README.md", Path: "billing/README.md", Rendered: true},
+					{TemplatePath: "template/run.sh", Path: "run.sh", Rendered: true},
+				},
+			},
+		},
+		{
+			name: "creates target repository and uses its default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				append(append(templateHandlers(),
+					mock.WithRequestMatch(
+						mock.GetUser,
+						&github.User{Login: github.Ptr("someone-else")},
+					),
+					mock.WithRequestMatchHandler(
+						mock.PostOrgsReposByOrg,
+						expectRequestBody(t, map[string]interface{}{
+							"name":      "service",
+							"private":   true,
+							"auto_init": true,
+						}).andThen(
+							mockResponse(t, http.StatusCreated, &github.Repository{DefaultBranch: github.Ptr("main")}),
+						),
+					),
+				), targetRepoHandlers()...)...,
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner":    "platform",
+				"template_repo":     "service-template",
+				"template_ref":      "main",
+				"template_path":     "template",
+				"variables":         map[string]interface{}{"name": "billing", "version": "1.0"},
+				"owner":             "owner",
+				"repo":              "service",
+				"message":           "Scaffold billing service",
+				"create_repository": true,
+				"private":           true,
+			},
+			expectedResult: ScaffoldResult{
+				Repository: "owner/service",
+				Branch:     "main",
+				CommitSHA:  "jkl012",
+				CommitURL:  "https://github.com/owner/service/commit/jkl012",
+				Created:    true,
+				Files: []ScaffoldFile{
+					{TemplatePath: "template/{{cookiecutter.name}}/README.md", Path: "billing/README.md", Rendered: true},
+					{TemplatePath: "template/run.sh", Path: "run.sh", Rendered: true},
+				},
+			},
+		},
+		{
+			name:         "fails before writing when variables are missing",
+			mockedClient: mock.NewMockedHTTPClient(templateHandlers()...),
+			requestArgs: map[string]interface{}{
+				"template_owner": "platform",
+				"template_repo":  "service-template",
+				"template_ref":   "main",
+				"template_path":  "template",
+				"variables":      map[string]interface{}{},
+				"owner":          "owner",
+				"repo":           "service",
+				"branch":         "main",
+				"message":        "Scaffold billing service",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing values for template variables: name, version",
+		},
+		{
+			name:         "rejects non-string variables",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"template_owner": "platform",
+				"template_repo":  "service-template",
+				"variables":      map[string]interface{}{"name": float64(1)},
+				"owner":          "owner",
+				"repo":           "service",
+				"message":        "Scaffold billing service",
+			},
+			expectError:    true,
+			expectedErrMsg: "variable name must be a string",
+		},
+		{
+			name:         "no files under template path",
+			mockedClient: mock.NewMockedHTTPClient(templateHandlers()...),
+			requestArgs: map[string]interface{}{
+				"template_owner": "platform",
+				"template_repo":  "service-template",
+				"template_ref":   "main",
+				"template_path":  "missing",
+				"variables":      map[string]interface{}{},
+				"owner":          "owner",
+				"repo":           "service",
+				"message":        "Scaffold billing service",
+			},
+			expectError:    true,
+			expectedErrMsg: "no template files found to render",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RenderScaffold(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned ScaffoldResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
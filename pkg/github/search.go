@@ -38,10 +38,11 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			window := repoAffinityWindow(ctx, pagination.Page, pagination.PerPage)
 			opts := &github.SearchOptions{
 				ListOptions: github.ListOptions{
-					Page:    pagination.Page,
-					PerPage: pagination.PerPage,
+					Page:    window.fetchPage,
+					PerPage: window.fetchPerPage,
 				},
 			}
 
@@ -67,19 +68,22 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(fmt.Sprintf("failed to search repositories: %s", string(body))), nil
 			}
 
+			var pageInfo PageInfo
+			result.Repositories, pageInfo = rankSearchPage(ctx, window, result.Repositories, func(r *github.Repository) string { return r.GetFullName() }, resp, result.Total)
+
 			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), pageInfo), nil
 		}
 }
 
 // SearchCode creates a tool to search for code across GitHub repositories.
 func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_code",
-			mcp.WithDescription(t("TOOL_SEARCH_CODE_DESCRIPTION", "Fast and precise code search across ALL GitHub repositories using GitHub's native search engine. Best for finding exact symbols, functions, classes, or specific code patterns.")),
+			mcp.WithDescription(t("TOOL_SEARCH_CODE_DESCRIPTION", "Fast and precise code search across ALL GitHub repositories using GitHub's native search engine. Best for finding exact symbols, functions, classes, or specific code patterns. Returns the repository, path and matching fragments of each file; use get_file_contents with the repository, path and sha of a result to read the whole file.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_SEARCH_CODE_USER_TITLE", "Search code"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -115,12 +119,14 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			window := repoAffinityWindow(ctx, pagination.Page, pagination.PerPage)
 			opts := &github.SearchOptions{
-				Sort:  sort,
-				Order: order,
+				Sort:      sort,
+				Order:     order,
+				TextMatch: true,
 				ListOptions: github.ListOptions{
-					PerPage: pagination.PerPage,
-					Page:    pagination.Page,
+					PerPage: window.fetchPerPage,
+					Page:    window.fetchPage,
 				},
 			}
 
@@ -147,15 +153,76 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(fmt.Sprintf("failed to search code: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(result)
+			var pageInfo PageInfo
+			result.CodeResults, pageInfo = rankSearchPage(ctx, window, result.CodeResults, func(c *github.CodeResult) string { return c.GetRepository().GetFullName() }, resp, result.Total)
+
+			minimalResult := MinimalSearchCodeResult{
+				TotalCount:        result.GetTotal(),
+				IncompleteResults: result.GetIncompleteResults(),
+				Items:             make([]MinimalCodeResult, 0, len(result.CodeResults)),
+			}
+			for _, code := range result.CodeResults {
+				minimalResult.Items = append(minimalResult.Items, toMinimalCodeResult(code))
+			}
+
+			r, err := json.Marshal(minimalResult)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withPageInfo(mcp.NewToolResultText(string(r)), pageInfo), nil
 		}
 }
 
+// MinimalCodeResult is the output type for code search results.
+type MinimalCodeResult struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	SHA        string `json:"sha"`
+	HTMLURL    string `json:"html_url"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	TextMatches []MinimalTextMatch `json:"text_matches,omitempty"`
+}
+
+// MinimalTextMatch is a fragment of a file that matched a code search, with
+// the positions of the matched terms within the fragment.
+type MinimalTextMatch struct {
+	Fragment string   `json:"fragment"`
+	Matches  [][2]int `json:"matches,omitempty"`
+}
+
+type MinimalSearchCodeResult struct {
+	TotalCount        int                 `json:"total_count"`
+	IncompleteResults bool                `json:"incomplete_results"`
+	Items             []MinimalCodeResult `json:"items"`
+}
+
+func toMinimalCodeResult(code *github.CodeResult) MinimalCodeResult {
+	result := MinimalCodeResult{
+		Name:    code.GetName(),
+		Path:    code.GetPath(),
+		SHA:     code.GetSHA(),
+		HTMLURL: code.GetHTMLURL(),
+	}
+	result.Repository.FullName = code.GetRepository().GetFullName()
+	for _, tm := range code.TextMatches {
+		// Matches against the file path are already covered by the path field.
+		if tm.GetProperty() != "" && tm.GetProperty() != "content" {
+			continue
+		}
+		textMatch := MinimalTextMatch{Fragment: tm.GetFragment()}
+		for _, m := range tm.Matches {
+			if len(m.Indices) == 2 {
+				textMatch.Matches = append(textMatch.Matches, [2]int{m.Indices[0], m.Indices[1]})
+			}
+		}
+		result.TextMatches = append(result.TextMatches, textMatch)
+	}
+	return result
+}
+
 // MinimalUser is the output type for user and organization search results.
 type MinimalUser struct {
 	Login      string       `json:"login"`
@@ -255,7 +322,7 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
-		return mcp.NewToolResultText(string(r)), nil
+		return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, result.Total)), nil
 	}
 }
 
@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultRepoAffinitySize is the number of recently touched repositories remembered per session.
+const DefaultRepoAffinitySize = 10
+
+// maxRepoAffinitySessions is the number of sessions whose repositories are remembered. Sessions
+// that do not end explicitly, such as Streamable HTTP sessions, are forgotten least recently
+// used first.
+const maxRepoAffinitySessions = 1000
+
+// RepoAffinityTracker records the repositories each session has recently worked with,
+// so that search results from those repositories can be ranked ahead of others.
+type RepoAffinityTracker struct {
+	mu       sync.Mutex
+	maxRepos int
+	// sessions maps a session ID to its recently touched repositories.
+	sessions map[string]*affinitySession
+	// uses counts touches, ordering sessions by when they were last used.
+	uses uint64
+}
+
+// affinitySession holds the repositories recently touched by a session, most recent first.
+type affinitySession struct {
+	recent   []string
+	lastUsed uint64
+}
+
+// NewRepoAffinityTracker creates a tracker that remembers up to maxRepos repositories per session.
+func NewRepoAffinityTracker(maxRepos int) *RepoAffinityTracker {
+	if maxRepos <= 0 {
+		maxRepos = DefaultRepoAffinitySize
+	}
+	return &RepoAffinityTracker{
+		maxRepos: maxRepos,
+		sessions: make(map[string]*affinitySession),
+	}
+}
+
+// Touch marks owner/repo as the most recently used repository for the session.
+func (t *RepoAffinityTracker) Touch(sessionID, owner, repo string) {
+	if owner == "" || repo == "" {
+		return
+	}
+	fullName := strings.ToLower(owner + "/" + repo)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	session := t.sessions[sessionID]
+	if session == nil {
+		if len(t.sessions) >= maxRepoAffinitySessions {
+			t.evictLeastRecentlyUsed()
+		}
+		session = &affinitySession{}
+		t.sessions[sessionID] = session
+	}
+	t.uses++
+	session.lastUsed = t.uses
+
+	recent := []string{fullName}
+	for _, r := range session.recent {
+		if r != fullName && len(recent) < t.maxRepos {
+			recent = append(recent, r)
+		}
+	}
+	session.recent = recent
+}
+
+// evictLeastRecentlyUsed forgets the session that touched a repository least recently.
+func (t *RepoAffinityTracker) evictLeastRecentlyUsed() {
+	var oldestID string
+	var oldest *affinitySession
+	for id, session := range t.sessions {
+		if oldest == nil || session.lastUsed < oldest.lastUsed {
+			oldestID, oldest = id, session
+		}
+	}
+	delete(t.sessions, oldestID)
+}
+
+// Forget drops the repositories of a session that ended.
+func (t *RepoAffinityTracker) Forget(sessionID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.sessions, sessionID)
+}
+
+// Recent returns the repositories recently touched by the session, most recent first.
+func (t *RepoAffinityTracker) Recent(sessionID string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	session := t.sessions[sessionID]
+	if session == nil {
+		return []string{}
+	}
+	recent := make([]string, len(session.recent))
+	copy(recent, session.recent)
+	return recent
+}
+
+// affinityScore returns how strongly a repository full name matches the recent repositories.
+// Exact repository matches score higher the more recently they were touched, while
+// repositories sharing an owner with a recent repository receive a small boost.
+func affinityScore(recent []string, fullName string) int {
+	fullName = strings.ToLower(fullName)
+	if fullName == "" {
+		return 0
+	}
+	owner, _, _ := strings.Cut(fullName, "/")
+
+	score := 0
+	for i, r := range recent {
+		if r == fullName {
+			return 2 * (len(recent) - i)
+		}
+		if recentOwner, _, _ := strings.Cut(r, "/"); recentOwner == owner {
+			score = 1
+		}
+	}
+	return score
+}
+
+type repoAffinityCtxKey struct{}
+
+type repoAffinity struct {
+	tracker   *RepoAffinityTracker
+	sessionID string
+}
+
+// RepoAffinityMiddleware makes the tracker available to search tools and records the
+// owner/repo arguments of every successful tool call as recently touched.
+func RepoAffinityMiddleware(tracker *RepoAffinityTracker) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var sessionID string
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				sessionID = session.SessionID()
+			}
+
+			ctx = context.WithValue(ctx, repoAffinityCtxKey{}, &repoAffinity{tracker: tracker, sessionID: sessionID})
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+
+			owner, _ := OptionalParam[string](request, "owner")
+			repo, _ := OptionalParam[string](request, "repo")
+			tracker.Touch(sessionID, owner, repo)
+
+			return result, err
+		}
+	}
+}
+
+const (
+	// repoAffinityOverfetch is how many pages of results are fetched at once when ranking by
+	// repository affinity, so that results from recent repositories just past a page move onto it.
+	repoAffinityOverfetch = 3
+	// maxSearchPerPage is the most results the search API returns per page.
+	maxSearchPerPage = 100
+	// maxSearchResults is the most results the search API returns for a query.
+	maxSearchResults = 1000
+)
+
+// searchWindow is the page of search results a tool returns, and the larger page it fetches to
+// rank them among the results around them.
+type searchWindow struct {
+	page, perPage           int
+	fetchPage, fetchPerPage int
+	// offset is where the returned page starts in the fetched one.
+	offset int
+}
+
+// repoAffinityWindow returns the results to fetch for a page of search results. When the session
+// has recently touched repositories, pages are fetched repoAffinityOverfetch at a time, as far as
+// the search API allows, and ranked together before being cut down to the page asked for. Pages
+// then partition each fetched block, so that no result is returned twice or skipped.
+func repoAffinityWindow(ctx context.Context, page, perPage int) searchWindow {
+	w := searchWindow{page: page, perPage: perPage, fetchPage: page, fetchPerPage: perPage}
+	affinity, ok := ctx.Value(repoAffinityCtxKey{}).(*repoAffinity)
+	if !ok || affinity == nil || page < 1 || perPage < 1 || len(affinity.tracker.Recent(affinity.sessionID)) == 0 {
+		return w
+	}
+	factor := min(repoAffinityOverfetch, maxSearchPerPage/perPage)
+	if factor <= 1 {
+		return w
+	}
+	w.fetchPerPage = factor * perPage
+	w.fetchPage = (page-1)/factor + 1
+	w.offset = ((page - 1) % factor) * perPage
+	return w
+}
+
+// rankSearchPage ranks the results fetched for w by repository affinity and returns the page of
+// them the tool was asked for, along with its page info.
+func rankSearchPage[T any](ctx context.Context, w searchWindow, items []T, repoOf func(T) string, resp *github.Response, total *int) ([]T, PageInfo) {
+	rankByRepoAffinity(ctx, items, repoOf)
+	if w.fetchPerPage == w.perPage {
+		return items, restPageInfo(resp, total)
+	}
+
+	start := min(w.offset, len(items))
+	end := min(w.offset+w.perPage, len(items))
+	info := PageInfo{TotalCount: total}
+	if end < len(items) || (resp != nil && resp.NextPage != 0) {
+		info.HasNextPage = true
+		info.NextPage = w.page + 1
+	}
+	if total != nil {
+		info.LastPage = (min(*total, maxSearchResults) + w.perPage - 1) / w.perPage
+	}
+	return items[start:end], info
+}
+
+// rankByRepoAffinity stably reorders items so that results from repositories the
+// session has recently touched come first. It is a no-op when no tracker is configured.
+func rankByRepoAffinity[T any](ctx context.Context, items []T, repoOf func(T) string) {
+	affinity, ok := ctx.Value(repoAffinityCtxKey{}).(*repoAffinity)
+	if !ok || affinity == nil {
+		return
+	}
+	recent := affinity.tracker.Recent(affinity.sessionID)
+	if len(recent) == 0 {
+		return
+	}
+
+	scores := make([]int, len(items))
+	indexed := make([]int, len(items))
+	for i, item := range items {
+		indexed[i] = i
+		scores[i] = affinityScore(recent, repoOf(item))
+	}
+	sort.SliceStable(indexed, func(a, b int) bool {
+		return scores[indexed[a]] > scores[indexed[b]]
+	})
+
+	ranked := make([]T, len(items))
+	for i, idx := range indexed {
+		ranked[i] = items[idx]
+	}
+	copy(items, ranked)
+}
+
+// repoFullNameFromURL extracts "owner/repo" from a repository API URL such as
+// https://api.github.com/repos/owner/repo.
+func repoFullNameFromURL(repositoryURL string) string {
+	u, err := url.Parse(repositoryURL)
+	if err != nil {
+		return ""
+	}
+	_, rest, found := strings.Cut(u.Path, "/repos/")
+	if !found {
+		return ""
+	}
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0] + "/" + parts[1]
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RepoAffinityTracker(t *testing.T) {
+	tracker := NewRepoAffinityTracker(2)
+
+	tracker.Touch("session", "owner", "repo-a")
+	tracker.Touch("session", "Owner", "Repo-B")
+	tracker.Touch("session", "owner", "repo-a")
+	tracker.Touch("session", "", "ignored")
+	assert.Equal(t, []string{"owner/repo-a", "owner/repo-b"}, tracker.Recent("session"))
+
+	tracker.Touch("session", "other", "repo-c")
+	assert.Equal(t, []string{"other/repo-c", "owner/repo-a"}, tracker.Recent("session"))
+
+	assert.Empty(t, tracker.Recent("another-session"))
+
+	tracker.Forget("session")
+	assert.Empty(t, tracker.Recent("session"))
+}
+
+func Test_RepoAffinityTracker_ForgetsLeastRecentlyUsedSessions(t *testing.T) {
+	tracker := NewRepoAffinityTracker(DefaultRepoAffinitySize)
+	for i := 0; i < maxRepoAffinitySessions; i++ {
+		tracker.Touch(fmt.Sprintf("session-%d", i), "owner", "repo")
+	}
+	tracker.Touch("session-0", "owner", "repo")
+
+	tracker.Touch("new-session", "owner", "repo")
+	assert.Len(t, tracker.sessions, maxRepoAffinitySessions)
+	assert.Empty(t, tracker.Recent("session-1"))
+	assert.NotEmpty(t, tracker.Recent("session-0"))
+	assert.NotEmpty(t, tracker.Recent("new-session"))
+}
+
+func Test_AffinityScore(t *testing.T) {
+	recent := []string{"owner/repo-a", "owner/repo-b"}
+
+	assert.Equal(t, 4, affinityScore(recent, "owner/repo-a"))
+	assert.Equal(t, 2, affinityScore(recent, "OWNER/repo-b"))
+	assert.Equal(t, 1, affinityScore(recent, "owner/repo-c"))
+	assert.Equal(t, 0, affinityScore(recent, "someone/else"))
+	assert.Equal(t, 0, affinityScore(recent, ""))
+}
+
+func Test_RepoFullNameFromURL(t *testing.T) {
+	assert.Equal(t, "owner/repo", repoFullNameFromURL("https://api.github.com/repos/owner/repo"))
+	assert.Equal(t, "owner/repo", repoFullNameFromURL("https://ghes.example.com/api/v3/repos/owner/repo/issues/1"))
+	assert.Equal(t, "", repoFullNameFromURL("https://api.github.com/users/owner"))
+}
+
+func Test_RankByRepoAffinity(t *testing.T) {
+	items := []string{"x/one", "owner/repo-b", "y/two", "owner/repo-a"}
+	identity := func(s string) string { return s }
+
+	// Without a tracker in the context the order is untouched.
+	rankByRepoAffinity(context.Background(), items, identity)
+	assert.Equal(t, []string{"x/one", "owner/repo-b", "y/two", "owner/repo-a"}, items)
+
+	tracker := NewRepoAffinityTracker(DefaultRepoAffinitySize)
+	tracker.Touch("", "owner", "repo-b")
+	tracker.Touch("", "owner", "repo-a")
+	ctx := context.WithValue(context.Background(), repoAffinityCtxKey{}, &repoAffinity{tracker: tracker})
+
+	rankByRepoAffinity(ctx, items, identity)
+	assert.Equal(t, []string{"owner/repo-a", "owner/repo-b", "x/one", "y/two"}, items)
+}
+
+func Test_RepoAffinityMiddleware_SearchCode(t *testing.T) {
+	mockSearchResult := &github.CodeSearchResult{
+		Total:             github.Ptr(2),
+		IncompleteResults: github.Ptr(false),
+		CodeResults: []*github.CodeResult{
+			{
+				Path:       github.Ptr("elsewhere.go"),
+				Repository: &github.Repository{FullName: github.Ptr("someone/else")},
+			},
+			{
+				Path:       github.Ptr("mine.go"),
+				Repository: &github.Repository{FullName: github.Ptr("owner/repo")},
+			},
+		},
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetSearchCode,
+			mockResponse(t, http.StatusOK, mockSearchResult),
+		),
+	)
+	client := github.NewClient(mockedClient)
+
+	tracker := NewRepoAffinityTracker(DefaultRepoAffinitySize)
+	middleware := RepoAffinityMiddleware(tracker)
+
+	// A successful call with owner/repo arguments marks the repository as touched.
+	touch := middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	_, err := touch(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"owner/repo"}, tracker.Recent(""))
+
+	// Failed calls do not count as touching a repository.
+	fail := middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("boom"), nil
+	})
+	_, err = fail(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "failed",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"owner/repo"}, tracker.Recent(""))
+
+	_, handler := SearchCode(stubGetClientFn(client), translations.NullTranslationHelper)
+	result, err := middleware(handler)(context.Background(), createMCPRequest(map[string]interface{}{
+		"query": "fmt.Println",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var returnedResult github.CodeSearchResult
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedResult))
+	require.Len(t, returnedResult.CodeResults, 2)
+	assert.Equal(t, "mine.go", returnedResult.CodeResults[0].GetPath())
+	assert.Equal(t, "elsewhere.go", returnedResult.CodeResults[1].GetPath())
+}
+
+func Test_RepoAffinityWindow(t *testing.T) {
+	// Without recently touched repositories, the page is fetched as is
+	assert.Equal(t, searchWindow{page: 2, perPage: 30, fetchPage: 2, fetchPerPage: 30}, repoAffinityWindow(context.Background(), 2, 30))
+
+	tracker := NewRepoAffinityTracker(DefaultRepoAffinitySize)
+	tracker.Touch("", "owner", "repo")
+	ctx := context.WithValue(context.Background(), repoAffinityCtxKey{}, &repoAffinity{tracker: tracker})
+
+	assert.Equal(t, searchWindow{page: 1, perPage: 30, fetchPage: 1, fetchPerPage: 90}, repoAffinityWindow(ctx, 1, 30))
+	assert.Equal(t, searchWindow{page: 3, perPage: 30, fetchPage: 1, fetchPerPage: 90, offset: 60}, repoAffinityWindow(ctx, 3, 30))
+	assert.Equal(t, searchWindow{page: 4, perPage: 30, fetchPage: 2, fetchPerPage: 90}, repoAffinityWindow(ctx, 4, 30))
+	// Limited to the most results the search API returns per page
+	assert.Equal(t, searchWindow{page: 2, perPage: 50, fetchPage: 1, fetchPerPage: 100, offset: 50}, repoAffinityWindow(ctx, 2, 50))
+	assert.Equal(t, searchWindow{page: 1, perPage: 100, fetchPage: 1, fetchPerPage: 100}, repoAffinityWindow(ctx, 1, 100))
+}
+
+func Test_RepoAffinityMiddleware_RanksBeforeTruncating(t *testing.T) {
+	repository := func(fullName string) *github.Repository {
+		return &github.Repository{FullName: github.Ptr(fullName)}
+	}
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetSearchRepositories,
+			expectQueryParams(t, map[string]string{
+				"q":        "tools",
+				"page":     "1",
+				"per_page": "6",
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.RepositoriesSearchResult{
+					Total: github.Ptr(5),
+					Repositories: []*github.Repository{
+						repository("x/one"), repository("y/two"), repository("z/three"), repository("owner/repo"), repository("w/four"),
+					},
+				}),
+			),
+		),
+	)
+
+	tracker := NewRepoAffinityTracker(DefaultRepoAffinitySize)
+	tracker.Touch("", "owner", "repo")
+	_, handler := SearchRepositories(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	search := func(page int) ([]string, PageInfo) {
+		result, err := RepoAffinityMiddleware(tracker)(handler)(context.Background(), createMCPRequest(map[string]interface{}{
+			"query":   "tools",
+			"page":    float64(page),
+			"perPage": float64(2),
+		}))
+		require.NoError(t, err)
+		var returned github.RepositoriesSearchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		names := make([]string, 0, len(returned.Repositories))
+		for _, r := range returned.Repositories {
+			names = append(names, r.GetFullName())
+		}
+		pageInfo, ok := result.Meta.AdditionalFields["pageInfo"].(PageInfo)
+		require.True(t, ok)
+		return names, pageInfo
+	}
+
+	// The recently touched repository, fourth in GitHub's order, moves onto the first page
+	names, pageInfo := search(1)
+	assert.Equal(t, []string{"owner/repo", "x/one"}, names)
+	assert.Equal(t, PageInfo{HasNextPage: true, NextPage: 2, LastPage: 3, TotalCount: github.Ptr(5)}, pageInfo)
+
+	// and later pages of the same block hold the rest, without repeating it
+	names, _ = search(2)
+	assert.Equal(t, []string{"y/two", "z/three"}, names)
+	names, pageInfo = search(3)
+	assert.Equal(t, []string{"w/four"}, names)
+	assert.False(t, pageInfo.HasNextPage)
+}
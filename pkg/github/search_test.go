@@ -320,6 +320,62 @@ func Test_SearchCode(t *testing.T) {
 	}
 }
 
+func Test_SearchCode_TextMatches(t *testing.T) {
+	mockSearchResult := &github.CodeSearchResult{
+		Total:             github.Ptr(1),
+		IncompleteResults: github.Ptr(false),
+		CodeResults: []*github.CodeResult{
+			{
+				Name:       github.Ptr("server.go"),
+				Path:       github.Ptr("pkg/server.go"),
+				SHA:        github.Ptr("abc123"),
+				HTMLURL:    github.Ptr("https://github.com/owner/repo/blob/main/pkg/server.go"),
+				Repository: &github.Repository{Name: github.Ptr("repo"), FullName: github.Ptr("owner/repo")},
+				TextMatches: []*github.TextMatch{
+					{
+						Property: github.Ptr("content"),
+						Fragment: github.Ptr("func NewServer() *Server {"),
+						Matches: []*github.Match{
+							{Text: github.Ptr("NewServer"), Indices: []int{5, 14}},
+						},
+					},
+					{
+						Property: github.Ptr("path"),
+						Fragment: github.Ptr("pkg/server.go"),
+					},
+				},
+			},
+		},
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetSearchCode,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.Header.Get("Accept"), "text-match")
+				mockResponse(t, http.StatusOK, mockSearchResult)(w, r)
+			}),
+		),
+	))
+	_, handler := SearchCode(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"query": "NewServer repo:owner/repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returnedResult MinimalSearchCodeResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedResult))
+	require.Len(t, returnedResult.Items, 1)
+	item := returnedResult.Items[0]
+	assert.Equal(t, "pkg/server.go", item.Path)
+	assert.Equal(t, "owner/repo", item.Repository.FullName)
+	assert.Equal(t, []MinimalTextMatch{
+		{Fragment: "func NewServer() *Server {", Matches: [][2]int{{5, 14}}},
+	}, item.TextMatches)
+}
+
 func Test_SearchUsers(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -78,13 +78,14 @@ func searchHandler(
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
+	window := repoAffinityWindow(ctx, pagination.Page, pagination.PerPage)
 	opts := &github.SearchOptions{
 		// Default to "created" if no sort is provided, as it's a common use case.
 		Sort:  sort,
 		Order: order,
 		ListOptions: github.ListOptions{
-			Page:    pagination.Page,
-			PerPage: pagination.PerPage,
+			Page:    window.fetchPage,
+			PerPage: window.fetchPerPage,
 		},
 	}
 
@@ -106,10 +107,13 @@ func searchHandler(
 		return mcp.NewToolResultError(fmt.Sprintf("%s: %s", errorPrefix, string(body))), nil
 	}
 
+	var pageInfo PageInfo
+	result.Issues, pageInfo = rankSearchPage(ctx, window, result.Issues, func(i *github.Issue) string { return repoFullNameFromURL(i.GetRepositoryURL()) }, resp, result.Total)
+
 	r, err := json.Marshal(result)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to marshal response: %w", errorPrefix, err)
 	}
 
-	return mcp.NewToolResultText(string(r)), nil
+	return withPageInfo(mcp.NewToolResultText(string(r)), pageInfo), nil
 }
@@ -161,3 +161,104 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+func UpdateSecretScanningAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"update_secret_scanning_alert",
+			mcp.WithDescription(t("TOOL_UPDATE_SECRET_SCANNING_ALERT_DESCRIPTION", "Resolve or reopen a secret scanning alert in a GitHub repository. A resolution is required when resolving.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_SECRET_SCANNING_ALERT_USER_TITLE", "Update secret scanning alert"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("alertNumber",
+				mcp.Required(),
+				mcp.Description("The number of the alert."),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("The new state of the alert."),
+				mcp.Enum("open", "resolved"),
+			),
+			mcp.WithString("resolution",
+				mcp.Description("Why the alert is resolved. Required when state is resolved."),
+				mcp.Enum("false_positive", "wont_fix", "revoked", "used_in_tests"),
+			),
+			mcp.WithString("resolution_comment",
+				mcp.Description("A comment on why the alert is resolved."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertNumber, err := RequiredInt(request, "alertNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resolution, err := OptionalParam[string](request, "resolution")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resolutionComment, err := OptionalParam[string](request, "resolution_comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.SecretScanningAlertUpdateOptions{State: state}
+			switch state {
+			case "resolved":
+				if resolution == "" {
+					return mcp.NewToolResultError("resolution is required when resolving an alert"), nil
+				}
+				opts.Resolution = github.Ptr(resolution)
+				if resolutionComment != "" {
+					opts.ResolutionComment = github.Ptr(resolutionComment)
+				}
+			case "open":
+				if resolution != "" || resolutionComment != "" {
+					return mcp.NewToolResultError("resolution and resolution_comment can only be set when resolving an alert"), nil
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state: %s", state)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			alert, resp, err := client.SecretScanning.UpdateAlert(ctx, owner, repo, int64(alertNumber), opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update alert with number '%d'", alertNumber),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(alert)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal alert: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
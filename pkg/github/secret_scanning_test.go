@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -247,3 +248,123 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_UpdateSecretScanningAlert(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateSecretScanningAlert(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_secret_scanning_alert", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber", "state"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedState  string
+		expectedErrMsg string
+	}{
+		{
+			name: "resolves alert",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]any{
+						"state":              "resolved",
+						"resolution":         "revoked",
+						"resolution_comment": "Rotated the key",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.SecretScanningAlert{
+							Number:     github.Ptr(42),
+							State:      github.Ptr("resolved"),
+							Resolution: github.Ptr("revoked"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"alertNumber":        float64(42),
+				"state":              "resolved",
+				"resolution":         "revoked",
+				"resolution_comment": "Rotated the key",
+			},
+			expectedState: "resolved",
+		},
+		{
+			name: "reopens alert",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]any{
+						"state": "open",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.SecretScanningAlert{
+							Number: github.Ptr(42),
+							State:  github.Ptr("open"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "open",
+			},
+			expectedState: "open",
+		},
+		{
+			name:         "resolving requires a resolution",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "resolved",
+			},
+			expectError:    true,
+			expectedErrMsg: "resolution is required when resolving an alert",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(9999),
+				"state":       "open",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update alert with number '9999'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateSecretScanningAlert(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedAlert github.SecretScanningAlert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlert))
+			assert.Equal(t, 42, returnedAlert.GetNumber())
+			assert.Equal(t, tc.expectedState, returnedAlert.GetState())
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"crypto/sha1" // #nosec G505 - git object IDs are SHA-1
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	pathpkg "path"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type commitSignerCtxKey struct{}
+
+// CommitSigningMiddleware makes a commit signer available to tools, so that the commits made by
+// create_or_update_file, delete_file and push_files are signed and show as verified.
+func CommitSigningMiddleware(signer github.MessageSigner) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(context.WithValue(ctx, commitSignerCtxKey{}, signer), request)
+		}
+	}
+}
+
+func commitSignerFromContext(ctx context.Context) github.MessageSigner {
+	signer, _ := ctx.Value(commitSignerCtxKey{}).(github.MessageSigner)
+	return signer
+}
+
+// commitAuthor returns the authenticated user as a commit author. GitHub only verifies signatures
+// of commits whose committer email belongs to the owner of the key, so signed commits are
+// authored with the user's public email, or else their noreply email.
+func commitAuthor(ctx context.Context, client *github.Client) (*github.CommitAuthor, *github.Response, error) {
+	user, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	name := user.GetName()
+	if name == "" {
+		name = user.GetLogin()
+	}
+	email := user.GetEmail()
+	if email == "" {
+		email = fmt.Sprintf("%d+%s@users.noreply.github.com", user.GetID(), user.GetLogin())
+	}
+	// Signatures cover the date in seconds, as git records it
+	date := github.Timestamp{Time: time.Now().Truncate(time.Second)}
+	return &github.CommitAuthor{Name: github.Ptr(name), Email: github.Ptr(email), Date: &date}, resp, nil
+}
+
+// createCommit creates a commit with the Git database API, signing it if the server signs commits.
+func createCommit(ctx context.Context, client *github.Client, owner, repo string, commit *github.Commit) (*github.Commit, *github.Response, error) {
+	signer := commitSignerFromContext(ctx)
+	if signer == nil {
+		return client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+	}
+	author, resp, err := commitAuthor(ctx, client)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get the commit author: %w", err)
+	}
+	commit.Author = author
+	return client.Git.CreateCommit(ctx, owner, repo, commit, &github.CreateCommitOptions{Signer: signer})
+}
+
+// blobSHA returns the SHA git gives a blob with the given content.
+func blobSHA(content []byte) string {
+	h := sha1.New() // #nosec G401 - git object IDs are SHA-1
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeFileSigned creates or updates a file with a signed commit, checking the SHA of the file
+// being replaced as the contents API would.
+func writeFileSigned(ctx context.Context, client *github.Client, owner, repo, branch, path, content, sha, message string) (*mcp.CallToolResult, error) {
+	ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get branch reference", resp, err), nil
+	}
+	_ = resp.Body.Close()
+
+	current, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref.Object.GetSHA()})
+	switch {
+	case err == nil:
+		_ = resp.Body.Close()
+		if current == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s is a directory", path)), nil
+		}
+		if sha == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("%s already exists, give the SHA of its current blob, %s, to update it", path, current.GetSHA())), nil
+		}
+		if sha != current.GetSHA() {
+			return mcp.NewToolResultError(fmt.Sprintf("sha %s does not match the current blob SHA of %s, %s", sha, path, current.GetSHA())), nil
+		}
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		if sha != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("%s does not exist, so it cannot be updated", path)), nil
+		}
+	default:
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get file", resp, err), nil
+	}
+
+	baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, ref.Object.GetSHA())
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get base commit", resp, err), nil
+	}
+	_ = resp.Body.Close()
+
+	newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.Tree.GetSHA(), []*github.TreeEntry{{
+		Path:    github.Ptr(path),
+		Mode:    github.Ptr("100644"),
+		Type:    github.Ptr("blob"),
+		Content: github.Ptr(content),
+	}})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create tree", resp, err), nil
+	}
+	_ = resp.Body.Close()
+
+	newCommit, resp, err := createCommit(ctx, client, owner, repo, &github.Commit{
+		Message: github.Ptr(message),
+		Tree:    newTree,
+		Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+	})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create commit", resp, err), nil
+	}
+	_ = resp.Body.Close()
+
+	ref.Object.SHA = newCommit.SHA
+	_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update reference", resp, err), nil
+	}
+	_ = resp.Body.Close()
+
+	// Respond as the contents API would
+	return MarshalledTextResult(github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{
+			Type: github.Ptr("file"),
+			Name: github.Ptr(pathpkg.Base(path)),
+			Path: github.Ptr(path),
+			SHA:  github.Ptr(blobSHA([]byte(content))),
+		},
+		Commit: *newCommit,
+	}), nil
+}
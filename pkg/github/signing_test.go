@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSigner signs every commit with the same signature.
+var fakeSigner = github.MessageSignerFunc(func(w io.Writer, _ io.Reader) error {
+	_, err := io.WriteString(w, "-----BEGIN SSH SIGNATURE-----\nsigned\n-----END SSH SIGNATURE-----")
+	return err
+})
+
+func Test_blobSHA(t *testing.T) {
+	// As given by `echo hello | git hash-object --stdin`
+	assert.Equal(t, "ce013625030ba8dba906f756967f9e9ca394464a", blobSHA([]byte("hello\n")))
+}
+
+func Test_createCommit(t *testing.T) {
+	var created map[string]any
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetUser,
+			&github.User{ID: github.Ptr(int64(42)), Login: github.Ptr("octocat")},
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposGitCommitsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"sha":"abc123"}`))
+			}),
+		),
+	))
+	commit := &github.Commit{
+		Message: github.Ptr("Update README"),
+		Tree:    &github.Tree{SHA: github.Ptr("def456")},
+		Parents: []*github.Commit{{SHA: github.Ptr("ghi789")}},
+	}
+
+	// Without a signer, commits are left to GitHub to author
+	plain := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposGitCommitsByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"message": "Update README",
+				"tree":    "def456",
+				"parents": []any{"ghi789"},
+			}).andThen(mockResponse(t, http.StatusCreated, &github.Commit{SHA: github.Ptr("abc123")})),
+		),
+	))
+	_, _, err := createCommit(context.Background(), plain, "owner", "repo", commit)
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), commitSignerCtxKey{}, github.MessageSigner(fakeSigner))
+	newCommit, _, err := createCommit(ctx, client, "owner", "repo", commit)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", newCommit.GetSHA())
+
+	assert.Equal(t, "-----BEGIN SSH SIGNATURE-----\nsigned\n-----END SSH SIGNATURE-----", created["signature"])
+	author := created["author"].(map[string]any)
+	assert.Equal(t, "octocat", author["name"], "users without a name are authored by login")
+	assert.Equal(t, "42+octocat@users.noreply.github.com", author["email"], "users without a public email use their noreply email")
+}
+
+func Test_CreateOrUpdateFile_Signed(t *testing.T) {
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	existing := &github.RepositoryContent{
+		Type: github.Ptr("file"),
+		Path: github.Ptr("docs/example.md"),
+		SHA:  github.Ptr("0123456789abcdef0123456789abcdef01234567"),
+	}
+	notFound := mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})
+
+	tests := []struct {
+		name           string
+		contents       http.HandlerFunc
+		sha            string
+		expectCommit   bool
+		expectedErrMsg string
+	}{
+		{
+			name:         "creates a new file",
+			contents:     notFound,
+			expectCommit: true,
+		},
+		{
+			name:         "updates a file with its current SHA",
+			contents:     mockResponse(t, http.StatusOK, existing),
+			sha:          existing.GetSHA(),
+			expectCommit: true,
+		},
+		{
+			name:           "refuses to overwrite a file without its SHA",
+			contents:       mockResponse(t, http.StatusOK, existing),
+			expectedErrMsg: "docs/example.md already exists, give the SHA of its current blob, " + existing.GetSHA(),
+		},
+		{
+			name:           "refuses to update a file with a stale SHA",
+			contents:       mockResponse(t, http.StatusOK, existing),
+			sha:            "fedcba9876543210fedcba9876543210fedcba98",
+			expectedErrMsg: "sha fedcba9876543210fedcba9876543210fedcba98 does not match the current blob SHA of docs/example.md",
+		},
+		{
+			name:           "refuses to update a file that does not exist",
+			contents:       notFound,
+			sha:            existing.GetSHA(),
+			expectedErrMsg: "docs/example.md does not exist, so it cannot be updated",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var signature string
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, tc.contents),
+				mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, &github.Commit{
+					SHA:  github.Ptr("abc123"),
+					Tree: &github.Tree{SHA: github.Ptr("def456")},
+				}),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"base_tree": "def456",
+						"tree": []any{
+							map[string]any{
+								"path":    "docs/example.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "hello\n",
+							},
+						},
+					}).andThen(mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("ghi789")})),
+				),
+				mock.WithRequestMatch(mock.GetUser, &github.User{
+					ID:    github.Ptr(int64(1)),
+					Login: github.Ptr("octocat"),
+					Name:  github.Ptr("The Octocat"),
+					Email: github.Ptr("octocat@github.com"),
+				}),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body struct {
+							Signature string `json:"signature"`
+						}
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						signature = body.Signature
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write([]byte(`{"sha":"jkl012","message":"Add example"}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]any{
+						"sha":   "jkl012",
+						"force": false,
+					}).andThen(mockResponse(t, http.StatusOK, mockRef)),
+				),
+			))
+			_, handler := CreateOrUpdateFile(stubGetClientFn(client), translations.NullTranslationHelper)
+			args := map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "hello\n",
+				"message": "Add example",
+				"branch":  "main",
+			}
+			if tc.sha != "" {
+				args["sha"] = tc.sha
+			}
+
+			result, err := CommitSigningMiddleware(fakeSigner)(handler)(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			text := getTextResult(t, result).Text
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, text, tc.expectedErrMsg)
+				assert.Empty(t, signature, "no commit is made")
+				return
+			}
+			require.False(t, result.IsError, text)
+			assert.True(t, strings.HasPrefix(signature, "-----BEGIN SSH SIGNATURE-----"), fmt.Sprintf("signature %q", signature))
+
+			var response github.RepositoryContentResponse
+			require.NoError(t, json.Unmarshal([]byte(text), &response))
+			assert.Equal(t, "ce013625030ba8dba906f756967f9e9ca394464a", response.Content.GetSHA())
+			assert.Equal(t, "example.md", response.Content.GetName())
+			assert.Equal(t, "jkl012", response.Commit.GetSHA())
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListStarredRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListStarredRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_starred_repositories", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	starredAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	mockStarred := []*github.StarredRepository{
+		{
+			StarredAt: &github.Timestamp{Time: starredAt},
+			Repository: &github.Repository{
+				FullName:        github.Ptr("octo/hello"),
+				HTMLURL:         github.Ptr("https://github.com/octo/hello"),
+				Language:        github.Ptr("Go"),
+				StargazersCount: github.Ptr(42),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserStarred,
+					expectQueryParams(t, map[string]string{
+						"sort":     "created",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockStarred),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"sort": "created",
+			},
+		},
+		{
+			name: "other user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersStarredByUsername,
+					expectPath(t, "/users/octocat/starred").andThen(
+						mockResponse(t, http.StatusOK, mockStarred),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersStarredByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "ghost",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list starred repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListStarredRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var repos []MinimalRepository
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &repos))
+			assert.Equal(t, []MinimalRepository{{
+				FullName:        "octo/hello",
+				HTMLURL:         "https://github.com/octo/hello",
+				Language:        "Go",
+				StargazersCount: 42,
+				StarredAt:       "2024-05-01T10:00:00Z",
+			}}, repos)
+		})
+	}
+}
+
+func Test_StarRepository(t *testing.T) {
+	// Verify tool definitions once
+	mockClient := github.NewClient(nil)
+	starTool, _ := StarRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(starTool.Name, starTool))
+	unstarTool, _ := UnstarRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(unstarTool.Name, unstarTool))
+
+	assert.Equal(t, "star_repository", starTool.Name)
+	assert.Equal(t, "unstar_repository", unstarTool.Name)
+	assert.False(t, *starTool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, starTool.InputSchema.Required, []string{"owner", "repo"})
+	assert.ElementsMatch(t, unstarTool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		star           bool
+		mockedClient   *http.Client
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "stars repository",
+			star: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutUserStarredByOwnerByRepo,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			expectedText: "starred repository owner/repo",
+		},
+		{
+			name: "unstars repository",
+			star: false,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteUserStarredByOwnerByRepo,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			expectedText: "unstarred repository owner/repo",
+		},
+		{
+			name: "star fails",
+			star: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutUserStarredByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to star repository owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UnstarRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+			if tc.star {
+				_, handler = StarRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+			}
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_ListStargazers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListStargazers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_stargazers", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposStargazersByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"page":     "2",
+				"per_page": "10",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.Stargazer{
+					{
+						StarredAt: &github.Timestamp{Time: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+						User:      &github.User{Login: github.Ptr("octocat")},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListStargazers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":   "owner",
+		"repo":    "repo",
+		"page":    float64(2),
+		"perPage": float64(10),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var stargazers []Stargazer
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &stargazers))
+	assert.Equal(t, []Stargazer{{Login: "octocat", StarredAt: "2024-05-01T10:00:00Z"}}, stargazers)
+}
+
+func Test_ListWatchers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWatchers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_watchers", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "lists watchers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSubscribersByOwnerByRepo,
+					[]*github.User{
+						{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1)), HTMLURL: github.Ptr("https://github.com/octocat")},
+					},
+				),
+			),
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSubscribersByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list watchers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWatchers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var watchers []MinimalUser
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &watchers))
+			assert.Equal(t, []MinimalUser{{Login: "octocat", ID: 1, ProfileURL: "https://github.com/octocat"}}, watchers)
+		})
+	}
+}
+
+func Test_ListWatchedRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWatchedRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_watched_repositories", tool.Name)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockRepos := []*github.Repository{
+		{
+			FullName: github.Ptr("octo/hello"),
+			HTMLURL:  github.Ptr("https://github.com/octo/hello"),
+			Private:  github.Ptr(true),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		requestArgs  map[string]interface{}
+	}{
+		{
+			name: "authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUserSubscriptions, mockRepos),
+			),
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name: "other user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersSubscriptionsByUsername,
+					expectPath(t, "/users/octocat/subscriptions").andThen(
+						mockResponse(t, http.StatusOK, mockRepos),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWatchedRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var repos []MinimalRepository
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &repos))
+			assert.Equal(t, []MinimalRepository{{
+				FullName: "octo/hello",
+				HTMLURL:  "https://github.com/octo/hello",
+				Private:  true,
+			}}, repos)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// structuredItemsKey holds the elements of results that are JSON arrays, since structured content
+// must be an object.
+const structuredItemsKey = "items"
+
+// The types below declare the output schemas of the tools returning issues, pull requests and
+// workflow runs. They describe the fields agents use most rather than all of GitHub's, and leave
+// every field optional so that results trimmed with the fields parameter still match.
+
+type userOutput struct {
+	Login   string `json:"login,omitempty"`
+	ID      int64  `json:"id,omitempty"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+type labelOutput struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type milestoneOutput struct {
+	Number int    `json:"number,omitempty"`
+	Title  string `json:"title,omitempty"`
+	State  string `json:"state,omitempty"`
+}
+
+type issueOutput struct {
+	ID          int64            `json:"id,omitempty"`
+	Number      int              `json:"number,omitempty"`
+	Title       string           `json:"title,omitempty"`
+	State       string           `json:"state,omitempty"`
+	StateReason string           `json:"state_reason,omitempty"`
+	Body        string           `json:"body,omitempty"`
+	HTMLURL     string           `json:"html_url,omitempty"`
+	User        *userOutput      `json:"user,omitempty"`
+	Assignees   []userOutput     `json:"assignees,omitempty"`
+	Labels      []labelOutput    `json:"labels,omitempty"`
+	Milestone   *milestoneOutput `json:"milestone,omitempty"`
+	Comments    int              `json:"comments,omitempty"`
+	CreatedAt   string           `json:"created_at,omitempty"`
+	UpdatedAt   string           `json:"updated_at,omitempty"`
+	ClosedAt    string           `json:"closed_at,omitempty"`
+}
+
+type pageInfoOutput struct {
+	HasNextPage     bool   `json:"hasNextPage,omitempty"`
+	HasPreviousPage bool   `json:"hasPreviousPage,omitempty"`
+	StartCursor     string `json:"startCursor,omitempty"`
+	EndCursor       string `json:"endCursor,omitempty"`
+}
+
+type issueListOutput struct {
+	Issues     []issueOutput   `json:"issues,omitempty"`
+	PageInfo   *pageInfoOutput `json:"pageInfo,omitempty"`
+	TotalCount int             `json:"totalCount,omitempty"`
+}
+
+type branchOutput struct {
+	Label string `json:"label,omitempty"`
+	Ref   string `json:"ref,omitempty"`
+	SHA   string `json:"sha,omitempty"`
+}
+
+type pullRequestOutput struct {
+	ID           int64         `json:"id,omitempty"`
+	Number       int           `json:"number,omitempty"`
+	Title        string        `json:"title,omitempty"`
+	State        string        `json:"state,omitempty"`
+	Draft        bool          `json:"draft,omitempty"`
+	Merged       bool          `json:"merged,omitempty"`
+	Mergeable    *bool         `json:"mergeable,omitempty"`
+	Body         string        `json:"body,omitempty"`
+	HTMLURL      string        `json:"html_url,omitempty"`
+	User         *userOutput   `json:"user,omitempty"`
+	Head         *branchOutput `json:"head,omitempty"`
+	Base         *branchOutput `json:"base,omitempty"`
+	Labels       []labelOutput `json:"labels,omitempty"`
+	Comments     int           `json:"comments,omitempty"`
+	Commits      int           `json:"commits,omitempty"`
+	Additions    int           `json:"additions,omitempty"`
+	Deletions    int           `json:"deletions,omitempty"`
+	ChangedFiles int           `json:"changed_files,omitempty"`
+	CreatedAt    string        `json:"created_at,omitempty"`
+	UpdatedAt    string        `json:"updated_at,omitempty"`
+	ClosedAt     string        `json:"closed_at,omitempty"`
+	MergedAt     string        `json:"merged_at,omitempty"`
+}
+
+type workflowRunOutput struct {
+	ID           int64       `json:"id,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	RunNumber    int         `json:"run_number,omitempty"`
+	RunAttempt   int         `json:"run_attempt,omitempty"`
+	Event        string      `json:"event,omitempty"`
+	Status       string      `json:"status,omitempty"`
+	Conclusion   string      `json:"conclusion,omitempty"`
+	HeadBranch   string      `json:"head_branch,omitempty"`
+	HeadSHA      string      `json:"head_sha,omitempty"`
+	WorkflowID   int64       `json:"workflow_id,omitempty"`
+	HTMLURL      string      `json:"html_url,omitempty"`
+	Actor        *userOutput `json:"actor,omitempty"`
+	CreatedAt    string      `json:"created_at,omitempty"`
+	UpdatedAt    string      `json:"updated_at,omitempty"`
+	RunStartedAt string      `json:"run_started_at,omitempty"`
+}
+
+type workflowRunListOutput struct {
+	TotalCount   int                 `json:"total_count,omitempty"`
+	WorkflowRuns []workflowRunOutput `json:"workflow_runs,omitempty"`
+}
+
+// structuredContent returns the JSON text of a result as structured content, wrapping arrays in
+// an object under structuredItemsKey. It returns nil if the result has no JSON text.
+func structuredContent(result *mcp.CallToolResult) any {
+	for _, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal([]byte(text.Text), &v); err != nil {
+			return nil
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			return v
+		case []any:
+			return map[string]any{structuredItemsKey: v}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// withStructuredContent sets the structured content of the results of a tool from their JSON
+// text, unless the tool sets it itself.
+func withStructuredContent(tool server.ServerTool) server.ServerTool {
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError || result.StructuredContent != nil {
+			return result, err
+		}
+		result.StructuredContent = structuredContent(result)
+		return result, nil
+	}
+	return tool
+}
+
+// AddStructuredContent makes every tool in tsg return its JSON results as MCP structured content
+// as well as text, so that clients supporting structured output can use them without parsing the
+// text. It should be applied after AddFieldsParameter, so that the structured content is trimmed
+// to the requested fields too.
+func AddStructuredContent(tsg *toolsets.ToolsetGroup) {
+	tsg.MapTools(withStructuredContent)
+}
@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_structuredContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *mcp.CallToolResult
+		expected any
+	}{
+		{
+			name:     "object",
+			result:   mcp.NewToolResultText(`{"number":1,"user":{"login":"octocat"}}`),
+			expected: map[string]any{"number": float64(1), "user": map[string]any{"login": "octocat"}},
+		},
+		{
+			name:     "array",
+			result:   mcp.NewToolResultText(`[{"number":1},{"number":2}]`),
+			expected: map[string]any{"items": []any{map[string]any{"number": float64(1)}, map[string]any{"number": float64(2)}}},
+		},
+		{
+			name:   "plain text",
+			result: mcp.NewToolResultText("Toolset users enabled"),
+		},
+		{
+			name:   "JSON scalar",
+			result: mcp.NewToolResultText(`"done"`),
+		},
+		{
+			name:   "no text",
+			result: &mcp.CallToolResult{Content: []mcp.Content{mcp.NewImageContent("aGk=", "image/png")}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, structuredContent(tc.result))
+		})
+	}
+}
+
+func Test_AddStructuredContent(t *testing.T) {
+	readOnly := mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})
+	preset := map[string]any{"total": 1}
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("issues", "Issue tools").
+		AddReadTools(
+			toolsets.NewServerTool(mcp.NewTool("get_issue", readOnly), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText(`{"number":1,"title":"Bug"}`), nil
+			}),
+			toolsets.NewServerTool(mcp.NewTool("count_issues", readOnly), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultStructured(preset, "1 issue"), nil
+			}),
+			toolsets.NewServerTool(mcp.NewTool("failing_tool", readOnly), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultError(`{"message":"Not Found"}`), nil
+			}),
+		))
+
+	AddStructuredContent(tsg)
+	tools := make(map[string]server.ServerTool)
+	for _, tool := range tsg.Toolsets["issues"].GetAvailableTools() {
+		tools[tool.Tool.Name] = tool
+	}
+	call := func(name string) *mcp.CallToolResult {
+		result, err := tools[name].Handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		return result
+	}
+
+	result := call("get_issue")
+	assert.Equal(t, map[string]any{"number": float64(1), "title": "Bug"}, result.StructuredContent)
+	assert.JSONEq(t, `{"number":1,"title":"Bug"}`, getTextResult(t, result).Text, "the text is kept for clients without structured output")
+
+	assert.Equal(t, preset, call("count_issues").StructuredContent, "structured content set by the tool is kept")
+	assert.Nil(t, call("failing_tool").StructuredContent, "errors have no structured content")
+}
+
+func Test_AddStructuredContent_WithFields(t *testing.T) {
+	readOnly := true
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("issues", "Issue tools").
+		AddReadTools(
+			toolsets.NewServerTool(mcp.NewTool("get_issue", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly})),
+				func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					return mcp.NewToolResultText(`{"number":1,"title":"Bug","user":{"login":"octocat","id":1}}`), nil
+				}),
+		))
+
+	AddFieldsParameter(tsg)
+	AddStructuredContent(tsg)
+	tool := tsg.Toolsets["issues"].GetAvailableTools()[0]
+	result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"fields": "number,user.login"}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"number": float64(1), "user": map[string]any{"login": "octocat"}}, result.StructuredContent)
+}
+
+func Test_OutputSchemas(t *testing.T) {
+	listIssues, _ := ListIssues(stubGetGQLClientFn(nil), translations.NullTranslationHelper)
+	tools := []mcp.Tool{listIssues}
+	for _, newTool := range []func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc){
+		GetIssue, GetPullRequest, GetWorkflowRun, ListWorkflowRuns,
+	} {
+		tool, _ := newTool(stubGetClientFn(nil), translations.NullTranslationHelper)
+		tools = append(tools, tool)
+	}
+
+	for _, tool := range tools {
+		t.Run(tool.Name, func(t *testing.T) {
+			assert.Equal(t, "object", tool.OutputSchema.Type)
+			assert.NotEmpty(t, tool.OutputSchema.Properties)
+			// Results trimmed with the fields parameter must still match the schema
+			assert.Empty(t, tool.OutputSchema.Required)
+		})
+	}
+}
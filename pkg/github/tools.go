@@ -25,23 +25,61 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetMultipleFileContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetRepositoryTree(getClient, t)),
+			toolsets.NewServerTool(DownloadRepositoryArchive(getClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
+			toolsets.NewServerTool(GrepRepository(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
+			toolsets.NewServerTool(GetBranchDivergence(getClient, t)),
+			toolsets.NewServerTool(CompareRefs(getClient, t)),
+			toolsets.NewServerTool(GetBranchProtection(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			// Also in the releases toolset, and kept here for configurations that only enable repos
 			toolsets.NewServerTool(ListReleases(getClient, t)),
 			toolsets.NewServerTool(GetLatestRelease(getClient, t)),
 			toolsets.NewServerTool(GetReleaseByTag(getClient, t)),
+			toolsets.NewServerTool(GetGitBlob(getClient, t)),
+			toolsets.NewServerTool(GetGitTree(getClient, t)),
+			toolsets.NewServerTool(GetGitCommit(getClient, t)),
+			toolsets.NewServerTool(DetectActivityAnomalies(getClient, t)),
+			toolsets.NewServerTool(ListCollaborators(getClient, t)),
+			toolsets.NewServerTool(ListInvitations(getClient, t)),
+			toolsets.NewServerTool(ListDeployKeys(getClient, t)),
+			toolsets.NewServerTool(ListAutolinks(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
+			toolsets.NewServerTool(CreateRepositoryFromTemplate(getClient, t)),
+			toolsets.NewServerTool(UpdateRepository(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
+			toolsets.NewServerTool(CreateTag(getClient, t)),
+			toolsets.NewServerTool(CreateRef(getClient, t)),
+			toolsets.NewServerTool(UpdateRef(getClient, t)),
+			toolsets.NewServerTool(DeleteRef(getClient, t)),
+			toolsets.NewServerTool(UpdateBranchProtection(getClient, t)),
+			toolsets.NewServerTool(DeleteBranchProtection(getClient, t)),
+			toolsets.NewServerTool(UpdateRequiredStatusChecks(getClient, t)),
+			toolsets.NewServerTool(UpdateRepositoryRuleset(getClient, t)),
+			toolsets.NewServerTool(DeleteRepositoryRuleset(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
+			toolsets.NewServerTool(CreateCommitOnBranch(getGQLClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(MoveFile(getClient, t)),
+			toolsets.NewServerTool(RenderScaffold(getClient, t)),
+			toolsets.NewServerTool(ApplyPatch(getClient, t)),
+			toolsets.NewServerTool(AddCollaborator(getClient, t)),
+			toolsets.NewServerTool(RemoveCollaborator(getClient, t)),
+			toolsets.NewServerTool(DeleteInvitation(getClient, t)),
+			toolsets.NewServerTool(CreateDeployKey(getClient, t)),
+			toolsets.NewServerTool(DeleteDeployKey(getClient, t)),
+			toolsets.NewServerTool(CreateAutolink(getClient, t)),
+			toolsets.NewServerTool(DeleteAutolink(getClient, t)),
 		).
 		AddResourceTemplates(
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
@@ -49,24 +87,60 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceCommitContent(getClient, getRawClient, t)),
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceTagContent(getClient, getRawClient, t)),
 			toolsets.NewServerResourceTemplate(GetRepositoryResourcePrContent(getClient, getRawClient, t)),
+			toolsets.NewServerResourceTemplate(GetRepositoryResourceRefContent(getClient, getRawClient, t)),
+		)
+	releases := toolsets.NewToolset("releases", "GitHub Release related tools, including release assets").
+		AddReadTools(
+			toolsets.NewServerTool(ListReleases(getClient, t)),
+			toolsets.NewServerTool(GetLatestRelease(getClient, t)),
+			toolsets.NewServerTool(GetReleaseByTag(getClient, t)),
+			toolsets.NewServerTool(GetRelease(getClient, t)),
+			toolsets.NewServerTool(DownloadReleaseAsset(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateRelease(getClient, t)),
+			toolsets.NewServerTool(UpdateRelease(getClient, t)),
+			toolsets.NewServerTool(DeleteRelease(getClient, t)),
+			toolsets.NewServerTool(UploadReleaseAsset(getClient, t)),
+			toolsets.NewServerTool(PromoteRelease(getClient, t)),
 		)
 	issues := toolsets.NewToolset("issues", "GitHub Issues related tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetIssue(getClient, t)),
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
+			toolsets.NewServerTool(SearchIssuesGraphQL(getGQLClient, t)),
 			toolsets.NewServerTool(ListIssues(getGQLClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
 			toolsets.NewServerTool(ListIssueTypes(getClient, t)),
 			toolsets.NewServerTool(ListSubIssues(getClient, t)),
+			toolsets.NewServerTool(GetParentIssue(getClient, t)),
+			toolsets.NewServerTool(FindClosingPullRequests(getGQLClient, t)),
+			toolsets.NewServerTool(ListMilestones(getClient, t)),
+			toolsets.NewServerTool(GetMilestone(getClient, t)),
+			toolsets.NewServerTool(GetMilestoneProgress(getClient, t)),
+			toolsets.NewServerTool(ListReactions(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(ListCopilotAgentTasks(getClient, t)),
+			toolsets.NewServerTool(GetCopilotAgentTaskStatus(getClient, t)),
+			toolsets.NewServerTool(GetCopilotAgentSessionLogs(getClient, t, contentWindowSize)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, t)),
+			toolsets.NewServerTool(TransferIssue(getGQLClient, t)),
+			toolsets.NewServerTool(LockIssue(getClient, t)),
+			toolsets.NewServerTool(UnlockIssue(getClient, t)),
+			toolsets.NewServerTool(MarkIssueAsDuplicate(getClient, t)),
+			toolsets.NewServerTool(BulkUpdateIssues(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
 			toolsets.NewServerTool(AddSubIssue(getClient, t)),
 			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
 			toolsets.NewServerTool(ReprioritizeSubIssue(getClient, t)),
+			toolsets.NewServerTool(CreateMilestone(getClient, t)),
+			toolsets.NewServerTool(UpdateMilestone(getClient, t)),
+			toolsets.NewServerTool(CloseMilestone(getClient, t)),
+			toolsets.NewServerTool(AddReaction(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(RemoveReaction(getClient, getGQLClient, t)),
 		).AddPrompts(
 		toolsets.NewServerPrompt(AssignCodingAgentPrompt(t)),
 		toolsets.NewServerPrompt(IssueToFixWorkflowPrompt(t)),
@@ -74,10 +148,37 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchUsers(getClient, t)),
+			toolsets.NewServerTool(GetUser(getClient, t)),
+			toolsets.NewServerTool(ListUserRepositories(getClient, t)),
+			toolsets.NewServerTool(ListUserOrganizations(getClient, t)),
+			toolsets.NewServerTool(ListFollowers(getClient, t)),
+			toolsets.NewServerTool(ListFollowing(getClient, t)),
+		)
+	stargazers := toolsets.NewToolset("stargazers", "GitHub Stargazers and watchers related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListStarredRepositories(getClient, t)),
+			toolsets.NewServerTool(ListStargazers(getClient, t)),
+			toolsets.NewServerTool(ListWatchers(getClient, t)),
+			toolsets.NewServerTool(ListWatchedRepositories(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(StarRepository(getClient, t)),
+			toolsets.NewServerTool(UnstarRepository(getClient, t)),
 		)
 	orgs := toolsets.NewToolset("orgs", "GitHub Organization related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchOrgs(getClient, t)),
+			toolsets.NewServerTool(ListOrgRepositories(getClient, t)),
+			toolsets.NewServerTool(GetOrg(getClient, t)),
+			toolsets.NewServerTool(ListOrgMembers(getClient, t)),
+			toolsets.NewServerTool(ListTeams(getClient, t)),
+			toolsets.NewServerTool(GetTeam(getClient, t)),
+			toolsets.NewServerTool(ListTeamMembers(getClient, t)),
+			toolsets.NewServerTool(ListTeamRepos(getClient, t)),
+			toolsets.NewServerTool(GetOrgAuditLog(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddTeamMember(getClient, t)),
 		)
 	pullRequests := toolsets.NewToolset("pull_requests", "GitHub Pull Request related tools").
 		AddReadTools(
@@ -89,10 +190,11 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetPullRequestComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
+			toolsets.NewServerTool(ListReviewThreads(getGQLClient, t)),
 		).
 		AddWriteTools(
-			toolsets.NewServerTool(MergePullRequest(getClient, t)),
-			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, t)),
+			toolsets.NewServerTool(MergePullRequest(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(RequestCopilotReview(getClient, t)),
@@ -103,6 +205,11 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(AddCommentToPendingReview(getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
+
+			// Review threads
+			toolsets.NewServerTool(ResolveReviewThread(getGQLClient, t)),
+			toolsets.NewServerTool(UnresolveReviewThread(getGQLClient, t)),
+			toolsets.NewServerTool(ReplyToReviewComment(getClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
@@ -113,11 +220,19 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(GetSecretScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListSecretScanningAlerts(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateSecretScanningAlert(getClient, t)),
 		)
 	dependabot := toolsets.NewToolset("dependabot", "Dependabot tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetDependabotAlert(getClient, t)),
 			toolsets.NewServerTool(ListDependabotAlerts(getClient, t)),
+			toolsets.NewServerTool(ListOrgDependabotAlerts(getClient, t)),
+			toolsets.NewServerTool(GetDependencySBOM(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateDependabotAlert(getClient, t)),
 		)
 
 	notifications := toolsets.NewToolset("notifications", "GitHub Notifications related tools").
@@ -138,26 +253,58 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetDiscussion(getGQLClient, t)),
 			toolsets.NewServerTool(GetDiscussionComments(getGQLClient, t)),
 			toolsets.NewServerTool(ListDiscussionCategories(getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateDiscussion(getGQLClient, t)),
+			toolsets.NewServerTool(AddDiscussionComment(getGQLClient, t)),
+			toolsets.NewServerTool(MarkAnswer(getGQLClient, t)),
 		)
 
 	actions := toolsets.NewToolset("actions", "GitHub Actions workflows and CI/CD operations").
 		AddReadTools(
 			toolsets.NewServerTool(ListWorkflows(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowDefinition(getClient, t)),
+			toolsets.NewServerTool(ValidateWorkflowFile(t)),
 			toolsets.NewServerTool(ListWorkflowRuns(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryWorkflowRuns(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowJobs(getClient, t)),
 			toolsets.NewServerTool(GetJobLogs(getClient, t, contentWindowSize)),
+			toolsets.NewServerTool(SummarizeWorkflowRunFailure(getClient, t, contentWindowSize)),
+			toolsets.NewServerTool(WaitForWorkflowRun(getClient, t, contentWindowSize)),
 			toolsets.NewServerTool(ListWorkflowRunArtifacts(getClient, t)),
 			toolsets.NewServerTool(DownloadWorkflowRunArtifact(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunUsage(getClient, t)),
+			toolsets.NewServerTool(ListPendingDeployments(getClient, t)),
+			toolsets.NewServerTool(ListCommitStatuses(getClient, t)),
+			toolsets.NewServerTool(ListCheckRunsForRef(getClient, t)),
+			toolsets.NewServerTool(GetCheckRun(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(RunWorkflow(getClient, t)),
 			toolsets.NewServerTool(RerunWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
 			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(ReviewPendingDeployments(getClient, t)),
+			toolsets.NewServerTool(ApproveWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(DeleteWorkflowRunLogs(getClient, t)),
+			toolsets.NewServerTool(CreateCommitStatus(getClient, t)),
+			toolsets.NewServerTool(CreateCheckRun(getClient, t)),
+			toolsets.NewServerTool(UpdateCheckRun(getClient, t)),
+		)
+
+	deployments := toolsets.NewToolset("deployments", "GitHub deployments and deployment environments").
+		AddReadTools(
+			toolsets.NewServerTool(ListDeployments(getClient, t)),
+			toolsets.NewServerTool(ListDeploymentStatuses(getClient, t)),
+			toolsets.NewServerTool(ListEnvironments(getClient, t)),
+			toolsets.NewServerTool(GetEnvironment(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateDeployment(getClient, t)),
+			toolsets.NewServerTool(CreateDeploymentStatus(getClient, t)),
+			toolsets.NewServerTool(CreateOrUpdateEnvironment(getClient, t)),
 		)
 
 	securityAdvisories := toolsets.NewToolset("security_advisories", "Security advisories related tools").
@@ -176,6 +323,7 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetMe(getClient, t)),
 			toolsets.NewServerTool(GetTeams(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(GetTeamMembers(getGQLClient, t)),
+			toolsets.NewServerTool(ListItemsAcrossRepos(getClient, t)),
 		)
 
 	gists := toolsets.NewToolset("gists", "GitHub Gist related tools").
@@ -187,22 +335,108 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(UpdateGist(getClient, t)),
 		)
 
+	projects := toolsets.NewToolset("projects", "GitHub Projects related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListProjects(getGQLClient, t)),
+			toolsets.NewServerTool(GetProject(getGQLClient, t)),
+			toolsets.NewServerTool(ListProjectItems(getGQLClient, t)),
+			toolsets.NewServerTool(ListProjectFields(getGQLClient, t)),
+			toolsets.NewServerTool(GetProjectBoard(getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateProject(getGQLClient, t)),
+			toolsets.NewServerTool(UpdateProject(getGQLClient, t)),
+			toolsets.NewServerTool(DeleteProject(getGQLClient, t)),
+			toolsets.NewServerTool(CreateProjectField(getGQLClient, t)),
+			toolsets.NewServerTool(UpdateProjectField(getGQLClient, t)),
+			toolsets.NewServerTool(DeleteProjectField(getGQLClient, t)),
+			toolsets.NewServerTool(UpdateProjectItemField(getGQLClient, t)),
+		)
+
+	operations := toolsets.NewToolset("operations", "Resume or roll back multi-step operations recorded in the operation journal").
+		AddWriteTools(
+			toolsets.NewServerTool(ResumeOperation(getClient, t)),
+		)
+
+	webhookTools := toolsets.NewToolset("webhooks", "Register repository and organization webhooks and receive their events").
+		AddReadTools(
+			toolsets.NewServerTool(ListWebhooks(getClient, t)),
+			toolsets.NewServerTool(ListWebhookEvents(t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateWebhook(getClient, t)),
+			toolsets.NewServerTool(DeleteWebhook(getClient, t)),
+		)
+
+	runners := toolsets.NewToolset("runners", "Self-hosted GitHub Actions runners, their labels and runner groups").
+		AddReadTools(
+			toolsets.NewServerTool(ListRunners(getClient, t)),
+			toolsets.NewServerTool(GetRunner(getClient, t)),
+			toolsets.NewServerTool(ListRunnerGroups(getClient, t)),
+			toolsets.NewServerTool(ListRunnerGroupRunners(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateRunnerToken(getClient, t)),
+			toolsets.NewServerTool(RemoveRunner(getClient, t)),
+			toolsets.NewServerTool(UpdateRunnerLabels(getClient, t)),
+			toolsets.NewServerTool(MoveRunnerToGroup(getClient, t)),
+		)
+
+	insights := toolsets.NewToolset("insights", "Repository traffic, contributor statistics and commit activity for activity reports").
+		AddReadTools(
+			toolsets.NewServerTool(GetRepositoryTraffic(getClient, t)),
+			toolsets.NewServerTool(ListContributorStats(getClient, t)),
+			toolsets.NewServerTool(GetCodeFrequency(getClient, t)),
+			toolsets.NewServerTool(GetCommitActivity(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryDigest(getClient, getGQLClient, t)),
+		)
+
+	copilotAdmin := toolsets.NewToolset("copilot_admin", "Copilot seat assignments and usage metrics of organizations").
+		AddReadTools(
+			toolsets.NewServerTool(ListCopilotSeats(getClient, t)),
+			toolsets.NewServerTool(GetCopilotMetrics(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddCopilotSeats(getClient, t)),
+			toolsets.NewServerTool(RemoveCopilotSeats(getClient, t)),
+		)
+
+	packages := toolsets.NewToolset("packages", "GitHub Packages of organizations and users, and their versions").
+		AddReadTools(
+			toolsets.NewServerTool(ListPackages(getClient, t)),
+			toolsets.NewServerTool(ListPackageVersions(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(DeletePackageVersion(getClient, t)),
+			toolsets.NewServerTool(RestorePackageVersion(getClient, t)),
+		)
+
 	// Add toolsets to the group
 	tsg.AddToolset(contextTools)
 	tsg.AddToolset(repos)
+	tsg.AddToolset(releases)
 	tsg.AddToolset(issues)
 	tsg.AddToolset(orgs)
 	tsg.AddToolset(users)
+	tsg.AddToolset(stargazers)
 	tsg.AddToolset(pullRequests)
 	tsg.AddToolset(actions)
+	tsg.AddToolset(runners)
+	tsg.AddToolset(packages)
+	tsg.AddToolset(deployments)
 	tsg.AddToolset(codeSecurity)
 	tsg.AddToolset(secretProtection)
 	tsg.AddToolset(dependabot)
 	tsg.AddToolset(notifications)
 	tsg.AddToolset(experiments)
 	tsg.AddToolset(discussions)
+	tsg.AddToolset(projects)
 	tsg.AddToolset(gists)
 	tsg.AddToolset(securityAdvisories)
+	tsg.AddToolset(operations)
+	tsg.AddToolset(webhookTools)
+	tsg.AddToolset(insights)
+	tsg.AddToolset(copilotAdmin)
 
 	return tsg
 }
@@ -216,6 +450,9 @@ func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t trans
 			toolsets.NewServerTool(ListAvailableToolsets(tsg, t)),
 			toolsets.NewServerTool(GetToolsetsTools(tsg, t)),
 			toolsets.NewServerTool(EnableToolset(s, tsg, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(DisableToolset(s, tsg, t)),
 		)
 
 	dynamicToolSelection.Enabled = true
@@ -0,0 +1,300 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalOrganization is the output type for organizations a user belongs to.
+type MinimalOrganization struct {
+	Login       string `json:"login"`
+	ID          int64  `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+	ProfileURL  string `json:"profile_url,omitempty"`
+	AvatarURL   string `json:"avatar_url,omitempty"`
+}
+
+func minimalUsers(users []*github.User) []MinimalUser {
+	result := make([]MinimalUser, 0, len(users))
+	for _, u := range users {
+		result = append(result, MinimalUser{
+			Login:      u.GetLogin(),
+			ID:         u.GetID(),
+			ProfileURL: u.GetHTMLURL(),
+			AvatarURL:  u.GetAvatarURL(),
+		})
+	}
+	return result
+}
+
+// GetUser creates a tool to get the public profile of a GitHub user.
+func GetUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_user",
+			mcp.WithDescription(t("TOOL_GET_USER_DESCRIPTION", "Get the profile of a GitHub user by username. Use get_me for the authenticated user.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_USER_USER_TITLE", "Get user profile"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			user, resp, err := client.Users.Get(ctx, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get user %s", username),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(minimalUserWithDetails(user))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListUserRepositories creates a tool to list the public repositories of a GitHub user.
+func ListUserRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_repositories",
+			mcp.WithDescription(t("TOOL_LIST_USER_REPOSITORIES_DESCRIPTION", "List the public repositories of a GitHub user. Use list_org_repositories for organizations.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_USER_REPOSITORIES_USER_TITLE", "List user repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Repositories owned by the user, those they are a member of, or both (default: owner)"),
+				mcp.Enum("owner", "member", "all"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort field (default: full_name)"),
+				mcp.Enum("created", "updated", "pushed", "full_name"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repoType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Repositories.ListByUser(ctx, username, &github.RepositoryListByUserOptions{
+				Type:      repoType,
+				Sort:      sort,
+				Direction: direction,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories of user %s", username),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalRepository, 0, len(repos))
+			for _, repo := range repos {
+				result = append(result, minimalRepository(repo))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// ListUserOrganizations creates a tool to list the organizations a user is a member of.
+func ListUserOrganizations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_organizations",
+			mcp.WithDescription(t("TOOL_LIST_USER_ORGANIZATIONS_DESCRIPTION", "List the organizations a GitHub user is a member of. For other users only public memberships are listed. Defaults to the authenticated user")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_USER_ORGANIZATIONS_USER_TITLE", "List user organizations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username. Defaults to the authenticated user"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			orgs, resp, err := client.Organizations.List(ctx, username, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list user organizations",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalOrganization, 0, len(orgs))
+			for _, org := range orgs {
+				result = append(result, MinimalOrganization{
+					Login:       org.GetLogin(),
+					ID:          org.GetID(),
+					Description: org.GetDescription(),
+					ProfileURL:  org.GetHTMLURL(),
+					AvatarURL:   org.GetAvatarURL(),
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// ListFollowers creates a tool to list the followers of a GitHub user.
+func ListFollowers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_followers",
+			mcp.WithDescription(t("TOOL_LIST_FOLLOWERS_DESCRIPTION", "List the followers of a GitHub user. Defaults to the authenticated user")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_FOLLOWERS_USER_TITLE", "List followers"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username. Defaults to the authenticated user"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listFollows(ctx, getClient, request, "followers")
+		}
+}
+
+// ListFollowing creates a tool to list the users a GitHub user follows.
+func ListFollowing(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_following",
+			mcp.WithDescription(t("TOOL_LIST_FOLLOWING_DESCRIPTION", "List the users a GitHub user follows. Defaults to the authenticated user")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_FOLLOWING_USER_TITLE", "List followed users"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username. Defaults to the authenticated user"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return listFollows(ctx, getClient, request, "following")
+		}
+}
+
+// listFollows lists either the followers of a user or the users they follow.
+func listFollows(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, direction string) (*mcp.CallToolResult, error) {
+	username, err := OptionalParam[string](request, "username")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	pagination, err := OptionalPaginationParams(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	opts := &github.ListOptions{
+		Page:    pagination.Page,
+		PerPage: pagination.PerPage,
+	}
+	var users []*github.User
+	var resp *github.Response
+	if direction == "followers" {
+		users, resp, err = client.Users.ListFollowers(ctx, username, opts)
+	} else {
+		users, resp, err = client.Users.ListFollowing(ctx, username, opts)
+	}
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to list %s", direction),
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	r, err := json.Marshal(minimalUsers(users))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+}
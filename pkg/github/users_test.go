@@ -0,0 +1,387 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_user", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	mockUser := &github.User{
+		Login:     github.Ptr("octocat"),
+		ID:        github.Ptr(int64(1)),
+		HTMLURL:   github.Ptr("https://github.com/octocat"),
+		Name:      github.Ptr("The Octocat"),
+		Company:   github.Ptr("@github"),
+		Followers: github.Ptr(100),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful get",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					expectPath(t, "/users/octocat").andThen(
+						mockResponse(t, http.StatusOK, mockUser),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "ghost",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get user ghost",
+		},
+		{
+			name:           "missing username",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: username",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetUser(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var user MinimalUser
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &user))
+			assert.Equal(t, "octocat", user.Login)
+			assert.Equal(t, "https://github.com/octocat", user.ProfileURL)
+			require.NotNil(t, user.Details)
+			assert.Equal(t, "The Octocat", user.Details.Name)
+			assert.Equal(t, 100, user.Details.Followers)
+		})
+	}
+}
+
+func Test_ListUserRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_user_repositories", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	mockRepos := []*github.Repository{
+		{
+			FullName:        github.Ptr("octocat/hello-world"),
+			HTMLURL:         github.Ptr("https://github.com/octocat/hello-world"),
+			StargazersCount: github.Ptr(7),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersReposByUsername,
+					expectQueryParams(t, map[string]string{
+						"type":     "owner",
+						"sort":     "updated",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepos),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+				"type":     "owner",
+				"sort":     "updated",
+			},
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersReposByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "ghost",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories of user ghost",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListUserRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var repos []MinimalRepository
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &repos))
+			require.Len(t, repos, 1)
+			assert.Equal(t, "octocat/hello-world", repos[0].FullName)
+			assert.Equal(t, 7, repos[0].StargazersCount)
+		})
+	}
+}
+
+func Test_ListUserOrganizations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserOrganizations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_user_organizations", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockOrgs := []*github.Organization{
+		{
+			Login:       github.Ptr("github"),
+			ID:          github.Ptr(int64(9919)),
+			Description: github.Ptr("How people build software."),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserOrgs,
+					mockResponse(t, http.StatusOK, mockOrgs),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name: "other user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersOrgsByUsername,
+					expectPath(t, "/users/octocat/orgs").andThen(
+						mockResponse(t, http.StatusOK, mockOrgs),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserOrgs,
+					mockResponse(t, http.StatusUnauthorized, `{"message": "Requires authentication"}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to list user organizations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListUserOrganizations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var orgs []MinimalOrganization
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &orgs))
+			require.Len(t, orgs, 1)
+			assert.Equal(t, "github", orgs[0].Login)
+			assert.Equal(t, "How people build software.", orgs[0].Description)
+		})
+	}
+}
+
+func Test_ListFollowersAndFollowing(t *testing.T) {
+	mockUsers := []*github.User{
+		{
+			Login:   github.Ptr("hubot"),
+			ID:      github.Ptr(int64(2)),
+			HTMLURL: github.Ptr("https://github.com/hubot"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		tool           func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "followers of authenticated user",
+			tool: ListFollowers,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserFollowers,
+					mockResponse(t, http.StatusOK, mockUsers),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name: "followers of other user",
+			tool: ListFollowers,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersFollowersByUsername,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockUsers),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+				"page":     float64(2),
+				"perPage":  float64(10),
+			},
+		},
+		{
+			name: "following of authenticated user",
+			tool: ListFollowing,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserFollowing,
+					mockResponse(t, http.StatusOK, mockUsers),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+		},
+		{
+			name: "following of unknown user",
+			tool: ListFollowing,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersFollowingByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "ghost",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list following",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := tc.tool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var users []MinimalUser
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &users))
+			require.Len(t, users, 1)
+			assert.Equal(t, "hubot", users[0].Login)
+			assert.Equal(t, "https://github.com/hubot", users[0].ProfileURL)
+		})
+	}
+
+	// Verify tool definitions
+	for _, newTool := range []func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc){ListFollowers, ListFollowing} {
+		tool, _ := newTool(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+		require.NoError(t, toolsnaps.Test(tool.Name, tool))
+		assert.True(t, *tool.Annotations.ReadOnlyHint)
+		assert.Empty(t, tool.InputSchema.Required)
+	}
+}
@@ -0,0 +1,389 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/webhooks"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// WebhookNotificationMethod is the MCP notification method used to forward webhook events to clients.
+const WebhookNotificationMethod = "notifications/github/webhook"
+
+// defaultWebhookEvents are subscribed to when create_webhook is called without events.
+var defaultWebhookEvents = []string{"push", "issues", "issue_comment", "pull_request", "pull_request_review", "pull_request_review_comment"}
+
+type webhookHubCtxKey struct{}
+
+// WebhookHubMiddleware makes the webhook receiver available to the webhooks tools, so that
+// they can register webhooks that deliver to it and query the events it received.
+func WebhookHubMiddleware(hub *webhooks.Hub) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(context.WithValue(ctx, webhookHubCtxKey{}, hub), request)
+		}
+	}
+}
+
+func webhookHubFromContext(ctx context.Context) *webhooks.Hub {
+	hub, _ := ctx.Value(webhookHubCtxKey{}).(*webhooks.Hub)
+	return hub
+}
+
+// Webhook is the subset of a repository or organization webhook returned by the webhooks tools.
+type Webhook struct {
+	ID          int64     `json:"id"`
+	URL         string    `json:"url"`
+	ContentType string    `json:"content_type,omitempty"`
+	Events      []string  `json:"events"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+func toWebhook(hook *github.Hook) Webhook {
+	return Webhook{
+		ID:          hook.GetID(),
+		URL:         hook.GetConfig().GetURL(),
+		ContentType: hook.GetConfig().GetContentType(),
+		Events:      hook.Events,
+		Active:      hook.GetActive(),
+		CreatedAt:   hook.GetCreatedAt().Time,
+	}
+}
+
+// ListWebhooks creates a tool to list the webhooks of a repository or organization.
+func ListWebhooks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_webhooks",
+			mcp.WithDescription(t("TOOL_LIST_WEBHOOKS_DESCRIPTION", "List the webhooks of a repository, or of an organization when repo is omitted")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WEBHOOKS_USER_TITLE", "List webhooks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, or organization name when repo is omitted"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name. Omit to list organization webhooks."),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+			var hooks []*github.Hook
+			var resp *github.Response
+			if repo == "" {
+				hooks, resp, err = client.Organizations.ListHooks(ctx, owner, opts)
+			} else {
+				hooks, resp, err = client.Repositories.ListHooks(ctx, owner, repo, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list webhooks",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]Webhook, 0, len(hooks))
+			for _, hook := range hooks {
+				result = append(result, toWebhook(hook))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return withPageInfo(mcp.NewToolResultText(string(r)), restPageInfo(resp, nil)), nil
+		}
+}
+
+// CreateWebhook creates a tool to register a repository or organization webhook.
+func CreateWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_webhook",
+			mcp.WithDescription(t("TOOL_CREATE_WEBHOOK_DESCRIPTION", "Register a webhook on a repository, or on an organization when repo is omitted. When url is omitted, the webhook delivers to this server's webhook receiver, which forwards events to connected clients as notifications and makes them available through list_webhook_events.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_WEBHOOK_USER_TITLE", "Create webhook"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, or organization name when repo is omitted"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name. Omit to create an organization webhook."),
+			),
+			mcp.WithArray("events",
+				mcp.Description("Events to subscribe to, e.g. push, issue_comment or pull_request. Defaults to push, issue, issue comment and pull request events."),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("url",
+				mcp.Description("URL to deliver events to. Defaults to this server's webhook receiver."),
+			),
+			mcp.WithString("secret",
+				mcp.Description("Secret used to sign deliveries to a custom url. Deliveries to this server's receiver are always signed with its own secret."),
+			),
+			mcp.WithBoolean("active",
+				mcp.Description("Whether the webhook delivers events. Defaults to true."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			events, err := OptionalStringArrayParam(request, "events")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(events) == 0 {
+				events = defaultWebhookEvents
+			}
+			url, err := OptionalParam[string](request, "url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			secret, err := OptionalParam[string](request, "secret")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			active, ok, err := OptionalParamOK[bool](request, "active")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				active = true
+			}
+
+			if url == "" {
+				hub := webhookHubFromContext(ctx)
+				if hub == nil {
+					return mcp.NewToolResultError("url is required because the webhook receiver is not enabled on this server"), nil
+				}
+				if hub.PublicURL() == "" {
+					return mcp.NewToolResultError("url is required because the webhook receiver has no public URL configured"), nil
+				}
+				url, secret = hub.PublicURL(), hub.Secret()
+			}
+
+			hook := &github.Hook{
+				Config: &github.HookConfig{
+					URL:         github.Ptr(url),
+					ContentType: github.Ptr("json"),
+				},
+				Events: events,
+				Active: github.Ptr(active),
+			}
+			if secret != "" {
+				hook.Config.Secret = github.Ptr(secret)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var created *github.Hook
+			var resp *github.Response
+			if repo == "" {
+				created, resp, err = client.Organizations.CreateHook(ctx, owner, hook)
+			} else {
+				created, resp, err = client.Repositories.CreateHook(ctx, owner, repo, hook)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create webhook",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(toWebhook(created))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteWebhook creates a tool to delete a repository or organization webhook.
+func DeleteWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_webhook",
+			mcp.WithDescription(t("TOOL_DELETE_WEBHOOK_DESCRIPTION", "Delete a webhook from a repository, or from an organization when repo is omitted")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_WEBHOOK_USER_TITLE", "Delete webhook"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, or organization name when repo is omitted"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Repository name. Omit to delete an organization webhook."),
+			),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("ID of the webhook to delete"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if repo == "" {
+				resp, err = client.Organizations.DeleteHook(ctx, owner, int64(hookID))
+			} else {
+				resp, err = client.Repositories.DeleteHook(ctx, owner, repo, int64(hookID))
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to delete webhook",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete webhook: unexpected status %d", resp.StatusCode)), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Webhook %d deleted", hookID)), nil
+		}
+}
+
+// ListWebhookEvents creates a tool to list the webhook events received by this server.
+func ListWebhookEvents(t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_webhook_events",
+			mcp.WithDescription(t("TOOL_LIST_WEBHOOK_EVENTS_DESCRIPTION", "List recent webhook events received by this server's webhook receiver, most recent first. Use this to catch up on events delivered while not listening for notifications.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WEBHOOK_EVENTS_USER_TITLE", "List received webhook events"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("event_type",
+				mcp.Description("Only return events of this type, e.g. push or issue_comment"),
+			),
+			mcp.WithString("repository",
+				mcp.Description("Only return events for this repository, as owner/repo"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only return events received after this time (ISO 8601 timestamp)"),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum number of events to return. Defaults to 50."),
+				mcp.Min(1),
+				mcp.Max(webhooks.DefaultHistorySize),
+			),
+			mcp.WithBoolean("include_payload",
+				mcp.Description("Whether to include the full event payloads. Defaults to false."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			eventType, err := OptionalParam[string](request, "event_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repository, err := OptionalParam[string](request, "repository")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 50)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includePayload, err := OptionalParam[bool](request, "include_payload")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			filter := webhooks.Filter{Type: eventType, Repository: repository, Limit: limit}
+			if since != "" {
+				filter.Since, err = time.Parse(time.RFC3339, since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since timestamp: %s", err)), nil
+				}
+			}
+
+			hub := webhookHubFromContext(ctx)
+			if hub == nil {
+				return mcp.NewToolResultError("the webhook receiver is not enabled on this server"), nil
+			}
+
+			events := hub.Events(filter)
+			if !includePayload {
+				for i := range events {
+					events[i].Payload = nil
+				}
+			}
+			if events == nil {
+				events = []webhooks.Event{}
+			}
+
+			r, err := json.Marshal(events)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
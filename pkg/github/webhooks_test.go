@@ -0,0 +1,388 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/webhooks"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListWebhooks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWebhooks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_webhooks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	mockHooks := []*github.Hook{
+		{
+			ID:     github.Ptr(int64(1)),
+			Config: &github.HookConfig{URL: github.Ptr("https://hooks.example.com/webhooks"), ContentType: github.Ptr("json")},
+			Events: []string{"push"},
+			Active: github.Ptr(true),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository webhooks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposHooksByOwnerByRepo, mockHooks),
+			),
+			requestArgs: map[string]interface{}{"owner": "owner", "repo": "repo"},
+		},
+		{
+			name: "organization webhooks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsHooksByOrg, mockHooks),
+			),
+			requestArgs: map[string]interface{}{"owner": "octo-org"},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs:    map[string]interface{}{"owner": "owner", "repo": "repo"},
+			expectError:    true,
+			expectedErrMsg: "failed to list webhooks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWebhooks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []Webhook
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, int64(1), returned[0].ID)
+			assert.Equal(t, "https://hooks.example.com/webhooks", returned[0].URL)
+			assert.Equal(t, []string{"push"}, returned[0].Events)
+			assert.True(t, returned[0].Active)
+		})
+	}
+}
+
+func Test_CreateWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "events")
+	assert.Contains(t, tool.InputSchema.Properties, "url")
+	assert.Contains(t, tool.InputSchema.Properties, "secret")
+	assert.Contains(t, tool.InputSchema.Properties, "active")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	hub, err := webhooks.NewHub("s3cret", "https://hooks.example.com/webhooks", 0)
+	require.NoError(t, err)
+
+	createdHook := &github.Hook{
+		ID:     github.Ptr(int64(42)),
+		Config: &github.HookConfig{URL: github.Ptr("https://hooks.example.com/webhooks"), ContentType: github.Ptr("json")},
+		Events: []string{"push", "pull_request"},
+		Active: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		hub            *webhooks.Hub
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository webhook delivering to the receiver",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"config": map[string]interface{}{
+							"url":          "https://hooks.example.com/webhooks",
+							"content_type": "json",
+							"secret":       "s3cret",
+						},
+						"events": []interface{}{"push", "pull_request"},
+						"active": true,
+						"name":   "web",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, createdHook),
+					),
+				),
+			),
+			hub: hub,
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"events": []interface{}{"push", "pull_request"},
+			},
+		},
+		{
+			name: "organization webhook with custom url and default events",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsHooksByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"config": map[string]interface{}{
+							"url":          "https://ci.example.com/hook",
+							"content_type": "json",
+						},
+						"events": []interface{}{"push", "issues", "issue_comment", "pull_request", "pull_request_review", "pull_request_review_comment"},
+						"active": false,
+						"name":   "web",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, createdHook),
+					),
+				),
+			),
+			hub: hub,
+			requestArgs: map[string]interface{}{
+				"owner":  "octo-org",
+				"url":    "https://ci.example.com/hook",
+				"active": false,
+			},
+		},
+		{
+			name:         "url required without receiver",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "url is required because the webhook receiver is not enabled on this server",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+			if tc.hub != nil {
+				handler = WebhookHubMiddleware(tc.hub)(handler)
+			}
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned Webhook
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int64(42), returned.ID)
+		})
+	}
+}
+
+func Test_DeleteWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "hook_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "hook_id"})
+
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository webhook",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.DeleteReposHooksByOwnerByRepoByHookId, noContent),
+			),
+			requestArgs: map[string]interface{}{"owner": "owner", "repo": "repo", "hook_id": float64(42)},
+		},
+		{
+			name: "organization webhook",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.DeleteOrgsHooksByOrgByHookId, noContent),
+			),
+			requestArgs: map[string]interface{}{"owner": "octo-org", "hook_id": float64(42)},
+		},
+		{
+			name:           "missing hook_id",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{"owner": "owner"},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: hook_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, "Webhook 42 deleted", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_ListWebhookEvents(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := ListWebhookEvents(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_webhook_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "event_type")
+	assert.Contains(t, tool.InputSchema.Properties, "repository")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.Contains(t, tool.InputSchema.Properties, "include_payload")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	hub, err := webhooks.NewHub("s3cret", "", 0)
+	require.NoError(t, err)
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	hub.Publish(webhooks.Event{DeliveryID: "1", Type: "push", Repository: "owner/repo", ReceivedAt: start, Payload: json.RawMessage(`{"ref":"refs/heads/main"}`)})
+	hub.Publish(webhooks.Event{DeliveryID: "2", Type: "issue_comment", Repository: "owner/repo", ReceivedAt: start.Add(time.Minute), Payload: json.RawMessage(`{"action":"created"}`)})
+	hub.Publish(webhooks.Event{DeliveryID: "3", Type: "push", Repository: "owner/other", ReceivedAt: start.Add(2 * time.Minute), Payload: json.RawMessage(`{"ref":"refs/heads/dev"}`)})
+
+	tests := []struct {
+		name            string
+		hub             *webhooks.Hub
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedIDs     []string
+		expectedPayload bool
+	}{
+		{
+			name:        "all events, most recent first",
+			hub:         hub,
+			requestArgs: map[string]interface{}{},
+			expectedIDs: []string{"3", "2", "1"},
+		},
+		{
+			name: "filtered with payloads",
+			hub:  hub,
+			requestArgs: map[string]interface{}{
+				"event_type":      "push",
+				"repository":      "owner/repo",
+				"include_payload": true,
+			},
+			expectedIDs:     []string{"1"},
+			expectedPayload: true,
+		},
+		{
+			name:        "since",
+			hub:         hub,
+			requestArgs: map[string]interface{}{"since": "2024-05-01T00:00:30Z"},
+			expectedIDs: []string{"3", "2"},
+		},
+		{
+			name:           "invalid since",
+			hub:            hub,
+			requestArgs:    map[string]interface{}{"since": "yesterday"},
+			expectError:    true,
+			expectedErrMsg: "invalid since timestamp",
+		},
+		{
+			name:           "receiver not enabled",
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "the webhook receiver is not enabled on this server",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListWebhookEvents(translations.NullTranslationHelper)
+			if tc.hub != nil {
+				handler = WebhookHubMiddleware(tc.hub)(handler)
+			}
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []webhooks.Event
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			var ids []string
+			for _, e := range returned {
+				ids = append(ids, e.DeliveryID)
+				assert.Equal(t, tc.expectedPayload, len(e.Payload) > 0)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
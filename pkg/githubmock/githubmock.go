@@ -0,0 +1,448 @@
+// Package githubmock serves a fake GitHub Enterprise Server API seeded with scenario fixtures,
+// so that the server and its tools can be tested end to end without a GitHub account.
+//
+// The fake serves the REST endpoints most tools start from: the authenticated user, and the
+// repositories, files, branches, pull requests, issues and workflow runs of a scenario. Any other
+// endpoint, including GraphQL, can be stubbed with canned responses, in the scenario or with
+// Server.Stub. Point the server at it by using Server.URL as the GitHub host.
+package githubmock
+
+import (
+	"crypto/sha1" //nolint:gosec // Git object IDs are SHA-1 hashes.
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// Scenario is the state of the fake GitHub.
+type Scenario struct {
+	// User is the user every token authenticates as.
+	User *github.User `json:"user"`
+	// Repos are the repositories of the scenario.
+	Repos []*Repo `json:"repos"`
+	// Stubs are canned responses for endpoints the fake does not implement. They take
+	// precedence over the implemented endpoints.
+	Stubs []Stub `json:"stubs"`
+}
+
+// Repo is a repository of a scenario. Pull requests, issues and workflow runs are served as
+// given, in the format of the GitHub API.
+type Repo struct {
+	Owner         string                `json:"owner"`
+	Name          string                `json:"name"`
+	DefaultBranch string                `json:"default_branch"`
+	Private       bool                  `json:"private"`
+	Branches      []string              `json:"branches"`
+	Files         map[string]string     `json:"files"`
+	PullRequests  []*github.PullRequest `json:"pull_requests"`
+	Issues        []*github.Issue       `json:"issues"`
+	WorkflowRuns  []*github.WorkflowRun `json:"workflow_runs"`
+}
+
+// Stub is a canned response for requests with a method and path, such as POST /graphql.
+// Paths are API paths, without the /api/v3 prefix of REST endpoints or the /api prefix of
+// the GraphQL endpoint. The query string is ignored.
+type Stub struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// Request is a request the fake received.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+}
+
+// LoadScenario reads a scenario from a JSON fixture.
+func LoadScenario(file string) (Scenario, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	var scenario Scenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return Scenario{}, fmt.Errorf("failed to parse scenario %s: %w", file, err)
+	}
+	return scenario, nil
+}
+
+// Server is a running fake GitHub.
+type Server struct {
+	// URL is the GitHub host to configure the server with, e.g. http://127.0.0.1:41234.
+	URL string
+
+	listener net.Listener
+	srv      *http.Server
+	scenario Scenario
+	mux      *http.ServeMux
+
+	mu       sync.Mutex
+	stubs    []Stub
+	requests []Request
+}
+
+// NewServer starts a fake GitHub for scenario on a free loopback port. Callers must Close it.
+func NewServer(scenario Scenario) (*Server, error) {
+	return Listen("127.0.0.1:0", scenario)
+}
+
+// Listen starts a fake GitHub for scenario on addr, such as :0 to also accept requests from
+// containers. Callers must Close it.
+func Listen(addr string, scenario Scenario) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	s := &Server{
+		URL:      "http://" + listener.Addr().String(),
+		listener: listener,
+		scenario: scenario,
+		stubs:    append([]Stub(nil), scenario.Stubs...),
+	}
+	if tcpAddr, ok := listener.Addr().(*net.TCPAddr); ok && tcpAddr.IP.IsUnspecified() {
+		s.URL = fmt.Sprintf("http://127.0.0.1:%d", tcpAddr.Port)
+	}
+	s.routes()
+	s.srv = &http.Server{Handler: http.HandlerFunc(s.serveHTTP)} //nolint:gosec // Only used in tests.
+	go func() { _ = s.srv.Serve(listener) }()
+	return s, nil
+}
+
+// Port is the port the fake listens on.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Close stops the fake.
+func (s *Server) Close() error {
+	return s.srv.Close()
+}
+
+// Stub adds a canned response, which takes precedence over earlier stubs for the same request.
+func (s *Server) Stub(stub Stub) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stubs = append([]Stub{stub}, s.stubs...)
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery})
+	var stub *Stub
+	apiPath := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/v3"), "/api")
+	for i := range s.stubs {
+		if strings.EqualFold(s.stubs[i].Method, r.Method) && s.stubs[i].Path == apiPath {
+			stub = &s.stubs[i]
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if stub != nil {
+		status := stub.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		_, _ = w.Write(stub.Body)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() {
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+	})
+	s.mux.HandleFunc("GET /api/v3/user", func(w http.ResponseWriter, _ *http.Request) {
+		if s.scenario.User == nil {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+			return
+		}
+		writeJSON(w, http.StatusOK, s.scenario.User)
+	})
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}", s.withRepo(s.getRepo))
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/branches", s.withRepo(s.listBranches))
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/git/ref/{ref...}", s.withRepo(s.getRef))
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/contents/{path...}", s.withRepo(s.getContents))
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/pulls", s.withRepo(s.listPullRequests))
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/pulls/{number}", s.withRepo(s.getPullRequest))
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/issues", s.withRepo(s.listIssues))
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/issues/{number}", s.withRepo(s.getIssue))
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/actions/runs", s.withRepo(s.listWorkflowRuns))
+	s.mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/actions/runs/{id}", s.withRepo(s.getWorkflowRun))
+	s.mux.HandleFunc("GET /raw/{owner}/{repo}/{path...}", s.withRepo(s.getRaw))
+}
+
+// withRepo looks up the repository of a request, responding with 404 if it does not exist.
+func (s *Server) withRepo(handler func(w http.ResponseWriter, r *http.Request, repo *Repo)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, repo := range s.scenario.Repos {
+			if strings.EqualFold(repo.Owner, r.PathValue("owner")) && strings.EqualFold(repo.Name, r.PathValue("repo")) {
+				handler(w, r, repo)
+				return
+			}
+		}
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+	}
+}
+
+func (s *Server) getRepo(w http.ResponseWriter, _ *http.Request, repo *Repo) {
+	writeJSON(w, http.StatusOK, &github.Repository{
+		Name:          github.Ptr(repo.Name),
+		FullName:      github.Ptr(repo.Owner + "/" + repo.Name),
+		Owner:         &github.User{Login: github.Ptr(repo.Owner)},
+		DefaultBranch: github.Ptr(repo.defaultBranch()),
+		Private:       github.Ptr(repo.Private),
+		HTMLURL:       github.Ptr(s.URL + "/" + repo.Owner + "/" + repo.Name),
+	})
+}
+
+func (s *Server) listBranches(w http.ResponseWriter, _ *http.Request, repo *Repo) {
+	branches := []*github.Branch{}
+	for _, name := range append([]string{repo.defaultBranch()}, repo.Branches...) {
+		branches = append(branches, &github.Branch{
+			Name:   github.Ptr(name),
+			Commit: &github.RepositoryCommit{SHA: github.Ptr(repo.commitSHA())},
+		})
+	}
+	writeJSON(w, http.StatusOK, branches)
+}
+
+func (s *Server) getRef(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	ref := r.PathValue("ref")
+	branch, ok := strings.CutPrefix(ref, "heads/")
+	if !ok || !repo.hasBranch(branch) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.Reference{
+		Ref:    github.Ptr("refs/" + ref),
+		Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr(repo.commitSHA())},
+	})
+}
+
+// getContents serves a file, or the entries of a directory. All branches have the same files.
+func (s *Server) getContents(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	p := strings.Trim(r.PathValue("path"), "/")
+	if content, ok := repo.Files[p]; ok {
+		writeJSON(w, http.StatusOK, &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Name:     github.Ptr(path.Base(p)),
+			Path:     github.Ptr(p),
+			SHA:      github.Ptr(blobSHA(content)),
+			Size:     github.Ptr(len(content)),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+		})
+		return
+	}
+
+	entries := map[string]*github.RepositoryContent{}
+	prefix := p + "/"
+	if p == "" {
+		prefix = ""
+	}
+	for file, content := range repo.Files {
+		rest, ok := strings.CutPrefix(file, prefix)
+		if !ok {
+			continue
+		}
+		name, _, isDir := strings.Cut(rest, "/")
+		entry := &github.RepositoryContent{
+			Type: github.Ptr("file"),
+			Name: github.Ptr(name),
+			Path: github.Ptr(prefix + name),
+			SHA:  github.Ptr(blobSHA(content)),
+			Size: github.Ptr(len(content)),
+		}
+		if isDir {
+			entry.Type = github.Ptr("dir")
+			entry.SHA = github.Ptr(blobSHA(prefix + name))
+			entry.Size = github.Ptr(0)
+		}
+		entries[name] = entry
+	}
+	if len(entries) == 0 {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return
+	}
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	listing := make([]*github.RepositoryContent, 0, len(names))
+	for _, name := range names {
+		listing = append(listing, entries[name])
+	}
+	writeJSON(w, http.StatusOK, listing)
+}
+
+// getRaw serves the raw content of a file at a branch, tag or commit, e.g.
+// /raw/owner/repo/refs/heads/main/README.md.
+func (s *Server) getRaw(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	p := r.PathValue("path")
+	if strings.HasPrefix(p, "refs/") {
+		// Skip refs/heads/ or refs/tags/.
+		p = strings.SplitN(p, "/", 3)[2]
+	}
+	_, file, _ := strings.Cut(p, "/")
+	content, ok := repo.Files[file]
+	if !ok {
+		http.Error(w, "404: Not Found", http.StatusNotFound)
+		return
+	}
+	contentType := "text/plain; charset=utf-8"
+	if !isText(content) {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write([]byte(content))
+}
+
+func (s *Server) listPullRequests(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	state := stateFilter(r)
+	pulls := []*github.PullRequest{}
+	for _, pr := range repo.PullRequests {
+		if state == "all" || pr.GetState() == state {
+			pulls = append(pulls, pr)
+		}
+	}
+	writeJSON(w, http.StatusOK, pulls)
+}
+
+func (s *Server) getPullRequest(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	number, _ := strconv.Atoi(r.PathValue("number"))
+	for _, pr := range repo.PullRequests {
+		if pr.GetNumber() == number {
+			writeJSON(w, http.StatusOK, pr)
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+}
+
+func (s *Server) listIssues(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	state := stateFilter(r)
+	issues := []*github.Issue{}
+	for _, issue := range repo.Issues {
+		if state == "all" || issue.GetState() == state {
+			issues = append(issues, issue)
+		}
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	number, _ := strconv.Atoi(r.PathValue("number"))
+	for _, issue := range repo.Issues {
+		if issue.GetNumber() == number {
+			writeJSON(w, http.StatusOK, issue)
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+}
+
+func (s *Server) listWorkflowRuns(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	runs := []*github.WorkflowRun{}
+	for _, run := range repo.WorkflowRuns {
+		if status := r.URL.Query().Get("status"); status != "" && run.GetStatus() != status && run.GetConclusion() != status {
+			continue
+		}
+		if branch := r.URL.Query().Get("branch"); branch != "" && run.GetHeadBranch() != branch {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	writeJSON(w, http.StatusOK, &github.WorkflowRuns{TotalCount: github.Ptr(len(runs)), WorkflowRuns: runs})
+}
+
+func (s *Server) getWorkflowRun(w http.ResponseWriter, r *http.Request, repo *Repo) {
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	for _, run := range repo.WorkflowRuns {
+		if run.GetID() == id {
+			writeJSON(w, http.StatusOK, run)
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+}
+
+func (repo *Repo) defaultBranch() string {
+	if repo.DefaultBranch == "" {
+		return "main"
+	}
+	return repo.DefaultBranch
+}
+
+func (repo *Repo) hasBranch(name string) bool {
+	if name == repo.defaultBranch() {
+		return true
+	}
+	for _, branch := range repo.Branches {
+		if branch == name {
+			return true
+		}
+	}
+	return false
+}
+
+// commitSHA is the commit all branches of the repository point to, derived from its files so
+// that it changes with them.
+func (repo *Repo) commitSHA() string {
+	files := make([]string, 0, len(repo.Files))
+	for file, content := range repo.Files {
+		files = append(files, file+"\x00"+blobSHA(content))
+	}
+	sort.Strings(files)
+	return blobSHA(repo.Owner + "/" + repo.Name + "\x00" + strings.Join(files, "\x00"))
+}
+
+// blobSHA returns the Git object ID of a blob with content.
+func blobSHA(content string) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("blob %d\x00%s", len(content), content))) //nolint:gosec // Git object IDs are SHA-1 hashes.
+	return hex.EncodeToString(sum[:])
+}
+
+func isText(content string) bool {
+	return !strings.ContainsRune(content, 0)
+}
+
+// stateFilter returns the state filter of a list request, which defaults to open.
+func stateFilter(r *http.Request) string {
+	if state := r.URL.Query().Get("state"); state != "" {
+		return state
+	}
+	return "open"
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,92 @@
+package githubmock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) (*Server, *github.Client) {
+	t.Helper()
+	srv, err := NewServer(Scenario{
+		User: &github.User{Login: github.Ptr("octocat")},
+		Repos: []*Repo{{
+			Owner: "octo",
+			Name:  "hello",
+			Files: map[string]string{
+				"README.md":     "# Hello\n",
+				"docs/guide.md": "Guide\n",
+			},
+			PullRequests: []*github.PullRequest{
+				{Number: github.Ptr(1), State: github.Ptr("open")},
+				{Number: github.Ptr(2), State: github.Ptr("closed")},
+			},
+		}},
+		Stubs: []Stub{{Method: "GET", Path: "/repos/octo/hello/releases/latest", Body: []byte(`{"tag_name": "v1.0.0"}`)}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = srv.Close() })
+
+	client, err := github.NewClient(nil).WithEnterpriseURLs(srv.URL, srv.URL)
+	require.NoError(t, err)
+	return srv, client
+}
+
+func TestServer(t *testing.T) {
+	srv, client := newTestServer(t)
+	ctx := context.Background()
+
+	user, _, err := client.Users.Get(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", user.GetLogin())
+
+	repo, _, err := client.Repositories.Get(ctx, "octo", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "main", repo.GetDefaultBranch())
+
+	file, _, _, err := client.Repositories.GetContents(ctx, "octo", "hello", "README.md", nil)
+	require.NoError(t, err)
+	content, err := file.GetContent()
+	require.NoError(t, err)
+	assert.Equal(t, "# Hello\n", content)
+	// The SHA is the Git object ID of the blob, as printed by git hash-object.
+	assert.Equal(t, "fec56017dc1b1ac87ad6e54e3cb3a20bb8dcc5ab", file.GetSHA())
+
+	_, dir, _, err := client.Repositories.GetContents(ctx, "octo", "hello", "", nil)
+	require.NoError(t, err)
+	require.Len(t, dir, 2)
+	assert.Equal(t, "README.md", dir[0].GetPath())
+	assert.Equal(t, "docs", dir[1].GetPath())
+	assert.Equal(t, "dir", dir[1].GetType())
+
+	ref, _, err := client.Git.GetRef(ctx, "octo", "hello", "refs/heads/main")
+	require.NoError(t, err)
+	resp, err := http.Get(srv.URL + "/raw/octo/hello/" + ref.GetObject().GetSHA() + "/docs/guide.md")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "Guide\n", string(body))
+
+	pulls, _, err := client.PullRequests.List(ctx, "octo", "hello", &github.PullRequestListOptions{State: "all"})
+	require.NoError(t, err)
+	assert.Len(t, pulls, 2)
+	pulls, _, err = client.PullRequests.List(ctx, "octo", "hello", nil)
+	require.NoError(t, err)
+	assert.Len(t, pulls, 1)
+
+	release, _, err := client.Repositories.GetLatestRelease(ctx, "octo", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", release.GetTagName())
+
+	_, notFound, err := client.Repositories.Get(ctx, "octo", "missing")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, notFound.StatusCode)
+
+	assert.Contains(t, srv.Requests(), Request{Method: "GET", Path: "/api/v3/repos/octo/hello/releases/latest"})
+}
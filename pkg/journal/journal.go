@@ -0,0 +1,358 @@
+package journal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrOperationNotFound is returned when an operation is not present in the store.
+var ErrOperationNotFound = errors.New("operation not found")
+
+const (
+	// DefaultRetention is how long operations are kept after they were last updated.
+	DefaultRetention = 7 * 24 * time.Hour
+	// pruneInterval is how often the journal removes operations past their retention.
+	pruneInterval = time.Hour
+)
+
+// Status is the state of a journaled operation.
+type Status string
+
+const (
+	StatusInProgress Status = "in_progress"
+	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
+	StatusRolledBack Status = "rolled_back"
+)
+
+// Step is a completed step of an operation, along with any values later steps
+// (or a rollback) need in order to continue without redoing it.
+type Step struct {
+	Name        string            `json:"name"`
+	Output      map[string]string `json:"output,omitempty"`
+	CompletedAt time.Time         `json:"completed_at"`
+}
+
+// Operation is a multi-step tool call recorded in the journal.
+type Operation struct {
+	ID        string         `json:"id"`
+	Tool      string         `json:"tool"`
+	Caller    string         `json:"caller,omitempty"`
+	Arguments map[string]any `json:"arguments"`
+	Status    Status         `json:"status"`
+	Steps     []Step         `json:"steps"`
+	Error     string         `json:"error,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Step returns the completed step with the given name, if any.
+func (o *Operation) Step(name string) (Step, bool) {
+	for _, s := range o.Steps {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Step{}, false
+}
+
+// StepNames returns the names of the completed steps in the order they completed.
+func (o *Operation) StepNames() []string {
+	names := make([]string, 0, len(o.Steps))
+	for _, s := range o.Steps {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// Store persists operations.
+type Store interface {
+	Save(op *Operation) error
+	Load(id string) (*Operation, error)
+	// Prune removes the operations last saved before the given time.
+	Prune(before time.Time) error
+}
+
+// MemoryStore keeps operations in memory for the lifetime of the process.
+type MemoryStore struct {
+	mu         sync.Mutex
+	operations map[string][]byte
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{operations: make(map[string][]byte)}
+}
+
+// Save stores a copy of the operation.
+func (s *MemoryStore) Save(op *Operation) error {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("failed to marshal operation: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.operations[op.ID] = data
+	return nil
+}
+
+// Load returns a copy of the stored operation.
+func (s *MemoryStore) Load(id string) (*Operation, error) {
+	s.mu.Lock()
+	data, ok := s.operations[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrOperationNotFound
+	}
+
+	var op Operation
+	if err := json.Unmarshal(data, &op); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal operation: %w", err)
+	}
+	return &op, nil
+}
+
+// Prune removes the operations last updated before the given time.
+func (s *MemoryStore) Prune(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, data := range s.operations {
+		var op Operation
+		if err := json.Unmarshal(data, &op); err != nil || op.UpdatedAt.Before(before) {
+			delete(s.operations, id)
+		}
+	}
+	return nil
+}
+
+// FileStore keeps each operation as a JSON file in a directory, so that
+// operations can be resumed after the server restarts.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a store in dir, creating the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(id string) (string, error) {
+	if id == "" || filepath.Base(id) != id || id == "." || id == ".." {
+		return "", ErrOperationNotFound
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// Save writes the operation to disk, replacing any previous version atomically.
+func (s *FileStore) Save(op *Operation) error {
+	path, err := s.path(op.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(op, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal operation: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, op.ID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write operation: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write operation: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write operation: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write operation: %w", err)
+	}
+	return nil
+}
+
+// Load reads the operation from disk.
+func (s *FileStore) Load(id string) (*Operation, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrOperationNotFound
+		}
+		return nil, fmt.Errorf("failed to read operation: %w", err)
+	}
+
+	var op Operation
+	if err := json.Unmarshal(data, &op); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal operation: %w", err)
+	}
+	return &op, nil
+}
+
+// Prune removes the files of the operations last saved before the given time.
+func (s *FileStore) Prune(before time.Time) error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read journal directory: %w", err)
+	}
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(before) {
+			if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to remove expired operations: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Journal records the progress of multi-step operations so that a failed
+// operation can later be resumed from its last completed step or rolled back.
+// Operations are kept for DefaultRetention after they were last updated.
+type Journal struct {
+	mu         sync.Mutex
+	store      Store
+	now        func() time.Time
+	retention  time.Duration
+	lastPruned time.Time
+}
+
+// New creates a journal backed by store.
+func New(store Store) *Journal {
+	return &Journal{store: store, now: time.Now, retention: DefaultRetention}
+}
+
+// Begin records the start of a new operation for the given tool call, made by caller. Only
+// the same caller may later get the operation, so that callers sharing a server with different
+// tokens cannot resume each other's operations.
+func (j *Journal) Begin(tool, caller string, arguments map[string]any) (*Operation, error) {
+	id, err := newOperationID()
+	if err != nil {
+		return nil, err
+	}
+	j.prune()
+
+	now := j.now()
+	op := &Operation{
+		ID:        id,
+		Tool:      tool,
+		Caller:    caller,
+		Arguments: arguments,
+		Status:    StatusInProgress,
+		Steps:     []Step{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := j.save(op); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Get loads an operation by ID. Operations begun by another caller, or past their retention,
+// are not found.
+func (j *Journal) Get(id, caller string) (*Operation, error) {
+	op, err := j.store.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if op.Caller != caller || op.UpdatedAt.Before(j.now().Add(-j.retention)) {
+		return nil, ErrOperationNotFound
+	}
+	return op, nil
+}
+
+// CompleteStep records that the named step of op has completed with the given output.
+func (j *Journal) CompleteStep(op *Operation, name string, output map[string]string) error {
+	op.Steps = append(op.Steps, Step{
+		Name:        name,
+		Output:      output,
+		CompletedAt: j.now(),
+	})
+	return j.save(op)
+}
+
+// Restart marks a previously failed operation as in progress again.
+func (j *Journal) Restart(op *Operation) error {
+	op.Status = StatusInProgress
+	op.Error = ""
+	return j.save(op)
+}
+
+// Complete marks op as successfully completed.
+func (j *Journal) Complete(op *Operation) error {
+	op.Status = StatusCompleted
+	op.Error = ""
+	return j.save(op)
+}
+
+// Fail marks op as failed with the given reason.
+func (j *Journal) Fail(op *Operation, reason string) error {
+	op.Status = StatusFailed
+	op.Error = reason
+	return j.save(op)
+}
+
+// RolledBack marks op as rolled back.
+func (j *Journal) RolledBack(op *Operation) error {
+	op.Status = StatusRolledBack
+	return j.save(op)
+}
+
+func (j *Journal) save(op *Operation) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	op.UpdatedAt = j.now()
+	return j.store.Save(op)
+}
+
+// prune removes the operations past their retention, at most once every pruneInterval.
+// Pruning is best effort: operations that cannot be removed are tried again next time, and
+// are not found by Get in the meantime.
+func (j *Journal) prune() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	if now.Sub(j.lastPruned) < pruneInterval {
+		return
+	}
+	if err := j.store.Prune(now.Add(-j.retention)); err == nil {
+		j.lastPruned = now
+	}
+}
+
+func newOperationID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate operation ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,122 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	fileStore, err := NewFileStore(filepath.Join(t.TempDir(), "operations"))
+	require.NoError(t, err)
+
+	stores := map[string]Store{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			j := New(store)
+
+			op, err := j.Begin("render_scaffold", "", map[string]any{"owner": "octo"})
+			require.NoError(t, err)
+			assert.Len(t, op.ID, 16)
+			assert.Equal(t, StatusInProgress, op.Status)
+
+			require.NoError(t, j.CompleteStep(op, "create_repository", map[string]string{"default_branch": "main"}))
+			require.NoError(t, j.Fail(op, "failed to create tree"))
+
+			loaded, err := j.Get(op.ID, "")
+			require.NoError(t, err)
+			assert.Equal(t, "render_scaffold", loaded.Tool)
+			assert.Equal(t, "octo", loaded.Arguments["owner"])
+			assert.Equal(t, StatusFailed, loaded.Status)
+			assert.Equal(t, "failed to create tree", loaded.Error)
+			assert.Equal(t, []string{"create_repository"}, loaded.StepNames())
+
+			step, ok := loaded.Step("create_repository")
+			require.True(t, ok)
+			assert.Equal(t, "main", step.Output["default_branch"])
+			_, ok = loaded.Step("create_commit")
+			assert.False(t, ok)
+
+			require.NoError(t, j.Restart(loaded))
+			require.NoError(t, j.Complete(loaded))
+			loaded, err = j.Get(op.ID, "")
+			require.NoError(t, err)
+			assert.Equal(t, StatusCompleted, loaded.Status)
+			assert.Empty(t, loaded.Error)
+
+			_, err = j.Get("does-not-exist", "")
+			assert.ErrorIs(t, err, ErrOperationNotFound)
+		})
+	}
+}
+
+func TestFileStore_RejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(filepath.Join(dir, "operations"))
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.json"), []byte(`{"id":"secret"}`), 0o600))
+
+	_, err = store.Load("../secret")
+	assert.ErrorIs(t, err, ErrOperationNotFound)
+	assert.ErrorIs(t, store.Save(&Operation{ID: "../secret"}), ErrOperationNotFound)
+}
+
+func TestJournal_OnlyCallerGetsOperation(t *testing.T) {
+	j := New(NewMemoryStore())
+
+	op, err := j.Begin("render_scaffold", "alice", map[string]any{})
+	require.NoError(t, err)
+
+	_, err = j.Get(op.ID, "bob")
+	assert.ErrorIs(t, err, ErrOperationNotFound)
+	loaded, err := j.Get(op.ID, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", loaded.Caller)
+}
+
+func TestJournal_Retention(t *testing.T) {
+	fileStore, err := NewFileStore(filepath.Join(t.TempDir(), "operations"))
+	require.NoError(t, err)
+
+	stores := map[string]Store{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			j := New(store)
+			j.now = func() time.Time { return now }
+
+			old, err := j.Begin("render_scaffold", "", map[string]any{})
+			require.NoError(t, err)
+			if fs, ok := store.(*FileStore); ok {
+				path, err := fs.path(old.ID)
+				require.NoError(t, err)
+				require.NoError(t, os.Chtimes(path, now, now))
+			}
+
+			// Past the retention, the operation is not found, and removed by the next prune
+			now = now.Add(DefaultRetention + time.Minute)
+			_, err = j.Get(old.ID, "")
+			assert.ErrorIs(t, err, ErrOperationNotFound)
+
+			recent, err := j.Begin("render_scaffold", "", map[string]any{})
+			require.NoError(t, err)
+			_, err = store.Load(old.ID)
+			assert.ErrorIs(t, err, ErrOperationNotFound)
+			_, err = store.Load(recent.ID)
+			assert.NoError(t, err)
+		})
+	}
+}
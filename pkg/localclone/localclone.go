@@ -0,0 +1,460 @@
+// Package localclone keeps shallow clones of frequently used repositories on disk, so that
+// file contents and searches can be served locally instead of through the GitHub API.
+package localclone
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHotThreshold is how often a repository is used before it is cloned.
+	DefaultHotThreshold = 3
+	// DefaultMaxRepos is how many clones are kept before the least recently used is removed.
+	DefaultMaxRepos = 20
+	// DefaultFetchInterval is how old a clone may get before it is fetched again.
+	DefaultFetchInterval = 5 * time.Minute
+
+	// gitTimeout bounds clones and fetches, which run in the background.
+	gitTimeout = 10 * time.Minute
+)
+
+// ErrNotFound is returned for paths that do not exist in a clone.
+var ErrNotFound = errors.New("path not found")
+
+// validName matches the owner and repository names GitHub allows.
+var validName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Config configures a Manager.
+type Config struct {
+	// Dir is the directory clones are kept in.
+	Dir string
+	// BaseURL is the URL repositories are cloned from, e.g. https://github.com/.
+	BaseURL string
+	// Token returns the token to clone and fetch with.
+	Token func() string
+	// HotThreshold is how often a repository is used before it is cloned.
+	HotThreshold int
+	// MaxRepos is how many clones are kept.
+	MaxRepos int
+	// FetchInterval is how old a clone may get before it is fetched again.
+	FetchInterval time.Duration
+	// Logger logs clone and fetch failures. Nothing is logged if nil.
+	Logger *slog.Logger
+}
+
+// Manager clones repositories once they have been used often enough, and fetches them
+// again when they get older than the fetch interval. Clones and fetches run in the
+// background, so callers fall back to the API until a clone is ready.
+type Manager struct {
+	cfg Config
+	now func() time.Time
+
+	mu    sync.Mutex
+	repos map[string]*repoState
+}
+
+type repoState struct {
+	hits      int
+	dir       string
+	ready     bool
+	busy      bool
+	fetchedAt time.Time
+	usedAt    time.Time
+}
+
+// NewManager creates a manager keeping its clones in cfg.Dir. It fails if git is not installed.
+func NewManager(cfg Config) (*Manager, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("local clones require git: %w", err)
+	}
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create local clone directory: %w", err)
+	}
+	if cfg.HotThreshold <= 0 {
+		cfg.HotThreshold = DefaultHotThreshold
+	}
+	if cfg.MaxRepos <= 0 {
+		cfg.MaxRepos = DefaultMaxRepos
+	}
+	if cfg.FetchInterval <= 0 {
+		cfg.FetchInterval = DefaultFetchInterval
+	}
+	if cfg.Token == nil {
+		cfg.Token = func() string { return "" }
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	if !strings.HasSuffix(cfg.BaseURL, "/") {
+		cfg.BaseURL += "/"
+	}
+	return &Manager{
+		cfg:   cfg,
+		now:   time.Now,
+		repos: make(map[string]*repoState),
+	}, nil
+}
+
+// Repo records a use of a repository and returns its clone if one is ready. Using a
+// repository often enough starts cloning it, and using a stale clone starts fetching it.
+func (m *Manager) Repo(owner, repo string) (*Repo, bool) {
+	key, ok := repoKey(owner, repo)
+	if !ok {
+		return nil, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.repos[key]
+	if !ok {
+		state = &repoState{dir: filepath.Join(m.cfg.Dir, key+".git")}
+		m.repos[key] = state
+	}
+	state.hits++
+	state.usedAt = m.now()
+
+	switch {
+	case state.busy:
+	case !state.ready && state.hits >= m.cfg.HotThreshold,
+		state.ready && m.now().Sub(state.fetchedAt) >= m.cfg.FetchInterval:
+		state.busy = true
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+			defer cancel()
+			if err := m.sync(ctx, state, owner, repo); err != nil {
+				m.cfg.Logger.Error("failed to update local clone", "repo", owner+"/"+repo, "error", err)
+			}
+		}()
+	}
+
+	if !state.ready {
+		return nil, false
+	}
+	return &Repo{dir: state.dir, FetchedAt: state.fetchedAt}, true
+}
+
+// Sync clones a repository, or fetches it if it is already cloned, and waits for it to finish.
+func (m *Manager) Sync(ctx context.Context, owner, repo string) error {
+	key, ok := repoKey(owner, repo)
+	if !ok {
+		return fmt.Errorf("invalid repository %s/%s", owner, repo)
+	}
+	m.mu.Lock()
+	state, ok := m.repos[key]
+	if !ok {
+		state = &repoState{dir: filepath.Join(m.cfg.Dir, key+".git"), usedAt: m.now()}
+		m.repos[key] = state
+	}
+	if state.busy {
+		m.mu.Unlock()
+		return fmt.Errorf("%s/%s is already being updated", owner, repo)
+	}
+	state.busy = true
+	m.mu.Unlock()
+	return m.sync(ctx, state, owner, repo)
+}
+
+// sync clones or fetches a repository that the caller marked busy.
+func (m *Manager) sync(ctx context.Context, state *repoState, owner, repo string) error {
+	err := m.update(ctx, state, owner, repo)
+	m.mu.Lock()
+	state.busy = false
+	if err == nil {
+		state.ready = true
+		state.fetchedAt = m.now()
+	}
+	m.mu.Unlock()
+	m.evict()
+	return err
+}
+
+func (m *Manager) update(ctx context.Context, state *repoState, owner, repo string) error {
+	m.mu.Lock()
+	ready, dir := state.ready, state.dir
+	m.mu.Unlock()
+
+	if ready {
+		// Fetch the default branch and move it to what was fetched.
+		if _, err := m.git(ctx, dir, "fetch", "--depth", "1", "--no-tags", "origin", "HEAD"); err != nil {
+			return err
+		}
+		_, err := m.git(ctx, dir, "update-ref", "HEAD", "FETCH_HEAD")
+		return err
+	}
+
+	// Clone into a temporary directory first, so that a failed clone leaves nothing behind.
+	if err := os.MkdirAll(filepath.Dir(dir), 0700); err != nil {
+		return fmt.Errorf("failed to create local clone directory: %w", err)
+	}
+	tmp := dir + ".tmp"
+	_ = os.RemoveAll(tmp)
+	_ = os.RemoveAll(dir)
+	url := m.cfg.BaseURL + owner + "/" + repo + ".git"
+	if _, err := m.git(ctx, "", "clone", "--bare", "--depth", "1", "--single-branch", "--no-tags", url, tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		_ = os.RemoveAll(tmp)
+		return fmt.Errorf("failed to move local clone into place: %w", err)
+	}
+	return nil
+}
+
+// evict removes the least recently used clones beyond the maximum.
+func (m *Manager) evict() {
+	m.mu.Lock()
+	var ready []string
+	for key, state := range m.repos {
+		if state.ready && !state.busy {
+			ready = append(ready, key)
+		}
+	}
+	if len(ready) <= m.cfg.MaxRepos {
+		m.mu.Unlock()
+		return
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		return m.repos[ready[i]].usedAt.Before(m.repos[ready[j]].usedAt)
+	})
+	var dirs []string
+	for _, key := range ready[:len(ready)-m.cfg.MaxRepos] {
+		dirs = append(dirs, m.repos[key].dir)
+		delete(m.repos, key)
+	}
+	m.mu.Unlock()
+
+	for _, dir := range dirs {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// git runs a git command, authenticating with the current token without exposing it in the
+// command line or persisting it in the clone's configuration.
+func (m *Manager) git(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if token := m.cfg.Token(); token != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+		cmd.Env = append(cmd.Env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+credentials,
+		)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+func repoKey(owner, repo string) (string, bool) {
+	for _, name := range []string{owner, repo} {
+		if !validName.MatchString(name) || strings.Trim(name, ".") == "" {
+			return "", false
+		}
+	}
+	return strings.ToLower(owner) + "/" + strings.ToLower(repo), true
+}
+
+// Repo is a ready clone of a repository's default branch.
+type Repo struct {
+	dir string
+	// FetchedAt is when the clone was last cloned or fetched.
+	FetchedAt time.Time
+}
+
+// Entry is a file or directory in a clone.
+type Entry struct {
+	Name string
+	Path string
+	// Type is file, dir, symlink or submodule.
+	Type string
+	SHA  string
+	Size int64
+}
+
+// Match is a line matching a search.
+type Match struct {
+	Path string
+	Line int
+	Text string
+}
+
+// Commit returns the SHA of the commit the clone is at. Callers resolve it once and pass it to
+// the other methods, so that a fetch in the background does not change what they read.
+func (r *Repo) Commit(ctx context.Context) (string, error) {
+	out, err := r.git(ctx, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ReadFile returns the content and blob SHA of a file at a commit.
+func (r *Repo) ReadFile(ctx context.Context, commit, path string) ([]byte, string, error) {
+	entries, err := r.lsTree(ctx, commit, strings.Trim(path, "/"))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(entries) != 1 || entries[0].Type != "file" {
+		return nil, "", ErrNotFound
+	}
+	content, err := r.git(ctx, "cat-file", "blob", entries[0].SHA)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, entries[0].SHA, nil
+}
+
+// ListDir returns the entries of a directory at a commit.
+func (r *Repo) ListDir(ctx context.Context, commit, path string) ([]Entry, error) {
+	path = strings.Trim(path, "/")
+	if path != "" {
+		path += "/"
+	}
+	entries, err := r.lsTree(ctx, commit, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 && path != "" {
+		return nil, ErrNotFound
+	}
+	return entries, nil
+}
+
+func (r *Repo) lsTree(ctx context.Context, commit, path string) ([]Entry, error) {
+	args := []string{"ls-tree", "-z", "--long", "--full-tree", commit}
+	if path != "" {
+		args = append(args, "--", path)
+	}
+	out, err := r.git(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, record := range strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00") {
+		if record == "" {
+			continue
+		}
+		// Each record is "<mode> <type> <sha> <size>\t<path>".
+		info, entryPath, ok := strings.Cut(record, "\t")
+		fields := strings.Fields(info)
+		if !ok || len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected git ls-tree output: %q", record)
+		}
+		entry := Entry{
+			Name: entryPath[strings.LastIndex(entryPath, "/")+1:],
+			Path: entryPath,
+			SHA:  fields[2],
+		}
+		entry.Size, _ = strconv.ParseInt(fields[3], 10, 64)
+		switch {
+		case fields[1] == "tree":
+			entry.Type = "dir"
+		case fields[1] == "commit":
+			entry.Type = "submodule"
+		case fields[0] == "120000":
+			entry.Type = "symlink"
+		default:
+			entry.Type = "file"
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Grep searches the text files at a commit for an extended regular expression, optionally
+// limited to a path, and returns at most limit matches. It reports whether there were more.
+func (r *Repo) Grep(ctx context.Context, commit, pattern, path string, ignoreCase bool, limit int) ([]Match, bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	args := []string{"grep", "-z", "-n", "-I", "-E", "--full-name"}
+	if ignoreCase {
+		args = append(args, "-i")
+	}
+	args = append(args, "-e", pattern, commit)
+	if path = strings.Trim(path, "/"); path != "" {
+		args = append(args, "--", path)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, false, err
+	}
+
+	var matches []Match
+	truncated := false
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		// Each line is "<commit>:<path>\0<line>\0<text>".
+		parts := strings.SplitN(scanner.Text(), "\x00", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if len(matches) == limit {
+			truncated = true
+			cancel()
+			break
+		}
+		line, _ := strconv.Atoi(parts[1])
+		matches = append(matches, Match{
+			Path: strings.TrimPrefix(parts[0], commit+":"),
+			Line: line,
+			Text: parts[2],
+		})
+	}
+	_, _ = io.Copy(io.Discard, stdout)
+
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	switch {
+	case truncated:
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		// git grep exits with 1 when nothing matched.
+	case err != nil:
+		return nil, false, fmt.Errorf("git grep failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return matches, truncated, nil
+}
+
+func (r *Repo) git(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,173 @@
+package localclone
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newUpstream creates a repository at <dir>/octo/hello.git with the given files committed,
+// and returns a function that commits more files to it.
+func newUpstream(t *testing.T, files map[string]string) (string, func(map[string]string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	base := t.TempDir()
+	dir := filepath.Join(base, "octo", "hello.git")
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	commit := func(files map[string]string) {
+		for path, content := range files {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0700))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0600))
+		}
+		run("add", "-A")
+		run("commit", "-q", "-m", "update")
+	}
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	run("init", "-q", "-b", "main")
+	commit(files)
+	return "file://" + base + "/", commit
+}
+
+func TestManager(t *testing.T) {
+	baseURL, commit := newUpstream(t, map[string]string{
+		"README.md":   "# Hello\n",
+		"src/main.go": "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n",
+		"src/util.go": "package main\n\n// TODO: remove\nfunc unused() {}\n",
+	})
+	m, err := NewManager(Config{Dir: t.TempDir(), BaseURL: baseURL, HotThreshold: 2, FetchInterval: time.Hour})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// The repository is not cloned until it is hot.
+	_, ok := m.Repo("octo", "hello")
+	assert.False(t, ok)
+
+	require.NoError(t, m.Sync(ctx, "octo", "hello"))
+	repo, ok := m.Repo("octo", "hello")
+	require.True(t, ok)
+	head, err := repo.Commit(ctx)
+	require.NoError(t, err)
+
+	content, sha, err := repo.ReadFile(ctx, head, "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Hello\n", string(content))
+	assert.Len(t, sha, 40)
+
+	_, _, err = repo.ReadFile(ctx, head, "src")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, _, err = repo.ReadFile(ctx, head, "missing.txt")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	entries, err := repo.ListDir(ctx, head, "/")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, Entry{Name: "README.md", Path: "README.md", Type: "file", SHA: sha, Size: 8}, entries[0])
+	assert.Equal(t, "dir", entries[1].Type)
+	assert.Equal(t, "src", entries[1].Path)
+
+	entries, err = repo.ListDir(ctx, head, "src/")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "src/main.go", entries[0].Path)
+
+	matches, truncated, err := repo.Grep(ctx, head, "func [a-z]+\\(", "", false, 10)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, []Match{
+		{Path: "src/main.go", Line: 3, Text: "func main() {"},
+		{Path: "src/util.go", Line: 4, Text: "func unused() {}"},
+	}, matches)
+
+	matches, truncated, err = repo.Grep(ctx, head, "todo", "src", true, 10)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, []Match{{Path: "src/util.go", Line: 3, Text: "// TODO: remove"}}, matches)
+
+	matches, truncated, err = repo.Grep(ctx, head, "package", "", false, 1)
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Len(t, matches, 1)
+
+	matches, _, err = repo.Grep(ctx, head, "no such text", "", false, 10)
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	// Fetching picks up new commits on the default branch.
+	commit(map[string]string{"NEW.md": "new\n"})
+	require.NoError(t, m.Sync(ctx, "octo", "hello"))
+	newHead, err := repo.Commit(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, head, newHead)
+	content, _, err = repo.ReadFile(ctx, newHead, "NEW.md")
+	require.NoError(t, err)
+	assert.Equal(t, "new\n", string(content))
+}
+
+func TestManager_CloneWhenHot(t *testing.T) {
+	baseURL, _ := newUpstream(t, map[string]string{"README.md": "# Hello\n"})
+	m, err := NewManager(Config{Dir: t.TempDir(), BaseURL: baseURL, HotThreshold: 2})
+	require.NoError(t, err)
+
+	_, ok := m.Repo("octo", "hello")
+	assert.False(t, ok)
+	_, ok = m.Repo("octo", "hello")
+	assert.False(t, ok, "cloning happens in the background")
+
+	assert.Eventually(t, func() bool {
+		_, ok := m.Repo("octo", "hello")
+		return ok
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestManager_Eviction(t *testing.T) {
+	baseURL, _ := newUpstream(t, map[string]string{"README.md": "# Hello\n"})
+	// Serve a second repository with the same content.
+	upstream := strings.TrimPrefix(baseURL, "file://")
+	require.NoError(t, os.Symlink(filepath.Join(upstream, "octo", "hello.git"), filepath.Join(upstream, "octo", "other.git")))
+
+	dir := t.TempDir()
+	m, err := NewManager(Config{Dir: dir, BaseURL: baseURL, MaxRepos: 1})
+	require.NoError(t, err)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	require.NoError(t, m.Sync(ctx, "octo", "hello"))
+	now = now.Add(time.Second)
+	require.NoError(t, m.Sync(ctx, "octo", "other"))
+
+	_, ok := m.Repo("octo", "hello")
+	assert.False(t, ok)
+	_, ok = m.Repo("octo", "other")
+	assert.True(t, ok)
+	assert.NoDirExists(t, filepath.Join(dir, "octo", "hello.git"))
+}
+
+func TestRepoKey(t *testing.T) {
+	key, ok := repoKey("Octo", "Hello.World")
+	assert.True(t, ok)
+	assert.Equal(t, "octo/hello.world", key)
+
+	for _, name := range [][2]string{{"..", "repo"}, {"octo", "../etc"}, {"octo", ""}, {"octo", "a/b"}} {
+		_, ok := repoKey(name[0], name[1])
+		assert.False(t, ok, name)
+	}
+}
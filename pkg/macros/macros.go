@@ -0,0 +1,315 @@
+// Package macros defines tool macros, composite operations that chain tools of the server into a
+// single tool, as configured in a macros file.
+package macros
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Types of macro parameters.
+const (
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeArray   = "array"
+)
+
+// Macro is a composite operation that calls tools one after the other, such as creating a branch,
+// pushing files to it and opening a pull request, and is offered as a tool of its own.
+type Macro struct {
+	// Name is the name of the tool the macro is offered as.
+	Name string `yaml:"name"`
+	// Description tells the model what the macro does.
+	Description string `yaml:"description"`
+	// Params are the parameters of the macro, which its steps refer to as ${{ params.name }}.
+	Params []Param `yaml:"params"`
+	// Steps are the tool calls the macro makes, in order.
+	Steps []Step `yaml:"steps"`
+}
+
+// Param is a parameter of a macro.
+type Param struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Type is string, number, boolean or array (of strings). Defaults to string.
+	Type     string `yaml:"type"`
+	Required bool   `yaml:"required"`
+	// Default is the value of the parameter if it is not given.
+	Default any `yaml:"default"`
+}
+
+// Step is a tool call made by a macro. String arguments may refer to the parameters of the
+// macro as ${{ params.name }}, and to the results of earlier steps as ${{ steps.id.field }}.
+type Step struct {
+	// ID names the step so that later steps can refer to its result.
+	ID string `yaml:"id"`
+	// Tool is the name of the tool to call.
+	Tool string `yaml:"tool"`
+	// Args are the arguments to call the tool with.
+	Args map[string]any `yaml:"args"`
+}
+
+type file struct {
+	Macros []Macro `yaml:"macros"`
+}
+
+var (
+	namePattern       = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	expressionPattern = regexp.MustCompile(`\$\{\{\s*([^}]*?)\s*\}\}`)
+)
+
+// Load reads macros from a YAML or JSON file.
+func Load(file string) ([]Macro, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read macros file: %w", err)
+	}
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid macros file %s: %w", file, err)
+	}
+	return m, nil
+}
+
+// Parse parses macros in YAML or JSON, rejecting unknown settings and references to parameters
+// or steps that do not exist.
+func Parse(data []byte) ([]Macro, error) {
+	var f file
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for i := range f.Macros {
+		m := &f.Macros[i]
+		if !namePattern.MatchString(m.Name) {
+			return nil, fmt.Errorf("macro %d has an invalid name %q, use letters, digits, _ and -", i+1, m.Name)
+		}
+		if names[m.Name] {
+			return nil, fmt.Errorf("macro %s is defined twice", m.Name)
+		}
+		names[m.Name] = true
+		if err := m.validate(); err != nil {
+			return nil, fmt.Errorf("macro %s: %w", m.Name, err)
+		}
+	}
+	return f.Macros, nil
+}
+
+func (m *Macro) validate() error {
+	params := make(map[string]bool)
+	for i := range m.Params {
+		p := &m.Params[i]
+		if !namePattern.MatchString(p.Name) {
+			return fmt.Errorf("parameter %d has an invalid name %q", i+1, p.Name)
+		}
+		if params[p.Name] {
+			return fmt.Errorf("parameter %s is defined twice", p.Name)
+		}
+		params[p.Name] = true
+		switch p.Type {
+		case "":
+			p.Type = TypeString
+		case TypeString, TypeNumber, TypeBoolean, TypeArray:
+		default:
+			return fmt.Errorf("parameter %s has an unsupported type %q", p.Name, p.Type)
+		}
+		p.Default = normalize(p.Default)
+	}
+	if len(m.Steps) == 0 {
+		return fmt.Errorf("no steps")
+	}
+	steps := make(map[string]bool)
+	for i := range m.Steps {
+		s := &m.Steps[i]
+		if s.Tool == "" {
+			return fmt.Errorf("step %d has no tool", i+1)
+		}
+		s.Args, _ = normalize(s.Args).(map[string]any)
+		var err error
+		walkStrings(s.Args, func(value string) {
+			for _, match := range expressionPattern.FindAllStringSubmatch(value, -1) {
+				if err == nil {
+					err = checkReference(match[1], params, steps)
+				}
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		if s.ID != "" {
+			if steps[s.ID] {
+				return fmt.Errorf("step id %s is used twice", s.ID)
+			}
+			steps[s.ID] = true
+		}
+	}
+	return nil
+}
+
+// checkReference checks that an expression refers to a parameter, or to an earlier step.
+func checkReference(expression string, params, steps map[string]bool) error {
+	parts := strings.Split(expression, ".")
+	switch {
+	case len(parts) == 2 && parts[0] == "params":
+		if !params[parts[1]] {
+			return fmt.Errorf("unknown parameter in ${{ %s }}", expression)
+		}
+	case len(parts) >= 2 && parts[0] == "steps":
+		if !steps[parts[1]] {
+			return fmt.Errorf("${{ %s }} does not refer to an earlier step", expression)
+		}
+	default:
+		return fmt.Errorf("invalid expression ${{ %s }}, expected params.name or steps.id.field", expression)
+	}
+	return nil
+}
+
+// normalize converts the integers YAML decodes to float64, as JSON arguments of tool calls are.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+	}
+	return value
+}
+
+func walkStrings(value any, fn func(string)) {
+	switch v := value.(type) {
+	case string:
+		fn(v)
+	case map[string]any:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	case []any:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	}
+}
+
+// Arguments returns the arguments of the step with its expressions replaced by the parameters of
+// the macro and the results of earlier steps, keyed by step ID. A string that is a single
+// expression is replaced by the value as is, and left out if it refers to a parameter that was not
+// given; expressions within longer strings are replaced by the value as text.
+func (s Step) Arguments(params, results map[string]any) (map[string]any, error) {
+	args := make(map[string]any, len(s.Args))
+	for key, value := range s.Args {
+		expanded, ok, err := expand(value, params, results)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", key, err)
+		}
+		if ok {
+			args[key] = expanded
+		}
+	}
+	return args, nil
+}
+
+func expand(value any, params, results map[string]any) (any, bool, error) {
+	switch v := value.(type) {
+	case string:
+		if match := expressionPattern.FindStringSubmatch(v); match != nil && match[0] == v {
+			return resolve(match[1], params, results)
+		}
+		var err error
+		expanded := expressionPattern.ReplaceAllStringFunc(v, func(expression string) string {
+			resolved, _, resolveErr := resolve(expressionPattern.FindStringSubmatch(expression)[1], params, results)
+			if resolveErr != nil {
+				err = resolveErr
+			}
+			return text(resolved)
+		})
+		return expanded, true, err
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for key, item := range v {
+			value, ok, err := expand(item, params, results)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				expanded[key] = value
+			}
+		}
+		return expanded, true, nil
+	case []any:
+		expanded := make([]any, 0, len(v))
+		for _, item := range v {
+			value, ok, err := expand(item, params, results)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				expanded = append(expanded, value)
+			}
+		}
+		return expanded, true, nil
+	default:
+		return value, true, nil
+	}
+}
+
+// resolve returns the value an expression refers to, and whether there is one.
+func resolve(expression string, params, results map[string]any) (any, bool, error) {
+	parts := strings.Split(expression, ".")
+	if parts[0] == "params" {
+		value, ok := params[parts[1]]
+		return value, ok, nil
+	}
+	value, ok := results[parts[1]]
+	if !ok {
+		return nil, false, fmt.Errorf("step %s has no result", parts[1])
+	}
+	for i, part := range parts[2:] {
+		switch v := value.(type) {
+		case map[string]any:
+			value, ok = v[part]
+		case []any:
+			index, err := strconv.Atoi(part)
+			ok = err == nil && index >= 0 && index < len(v)
+			if ok {
+				value = v[index]
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, false, fmt.Errorf("the result of step %s has no %s", parts[1], strings.Join(parts[2:i+3], "."))
+		}
+	}
+	return value, true, nil
+}
+
+// text formats a value for use within a string.
+func text(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,141 @@
+package macros
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMacros = `
+macros:
+  - name: open_change
+    description: Create a branch, push files to it and open a pull request
+    params:
+      - {name: owner, required: true}
+      - {name: repo, required: true}
+      - {name: branch, required: true}
+      - {name: files, type: array}
+      - {name: draft, type: boolean, default: true}
+    steps:
+      - id: branch
+        tool: create_branch
+        args: {owner: "${{ params.owner }}", repo: "${{ params.repo }}", branch: "${{ params.branch }}"}
+      - id: pr
+        tool: create_pull_request
+        args:
+          owner: ${{ params.owner }}
+          repo: ${{ params.repo }}
+          head: ${{ params.branch }}
+          base: main
+          title: "Update ${{ params.branch }}"
+          draft: ${{ params.draft }}
+      - tool: request_copilot_review
+        args: {owner: "${{ params.owner }}", repo: "${{ params.repo }}", pullNumber: "${{ steps.pr.number }}", attempt: 1}
+`
+
+func TestParse(t *testing.T) {
+	m, err := Parse([]byte(testMacros))
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	assert.Equal(t, "open_change", m[0].Name)
+	assert.Equal(t, TypeString, m[0].Params[0].Type)
+	assert.Equal(t, true, m[0].Params[4].Default)
+	require.Len(t, m[0].Steps, 3)
+	// Integers are decoded as JSON numbers are
+	assert.Equal(t, float64(1), m[0].Steps[2].Args["attempt"])
+
+	// An empty file defines no macros
+	m, err = Parse(nil)
+	require.NoError(t, err)
+	assert.Empty(t, m)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		macros        string
+		expectedError string
+	}{
+		{
+			name:          "unknown setting",
+			macros:        "macros: [{name: m, step: []}]",
+			expectedError: "field step not found",
+		},
+		{
+			name:          "invalid name",
+			macros:        "macros: [{name: 'open change', steps: [{tool: get_me}]}]",
+			expectedError: `macro 1 has an invalid name "open change"`,
+		},
+		{
+			name:          "duplicate macro",
+			macros:        "macros: [{name: m, steps: [{tool: get_me}]}, {name: m, steps: [{tool: get_me}]}]",
+			expectedError: "macro m is defined twice",
+		},
+		{
+			name:          "no steps",
+			macros:        "macros: [{name: m}]",
+			expectedError: "macro m: no steps",
+		},
+		{
+			name:          "unsupported type",
+			macros:        "macros: [{name: m, params: [{name: p, type: object}], steps: [{tool: get_me}]}]",
+			expectedError: `parameter p has an unsupported type "object"`,
+		},
+		{
+			name:          "unknown parameter",
+			macros:        "macros: [{name: m, steps: [{tool: get_issue, args: {owner: '${{ params.owner }}'}}]}]",
+			expectedError: "step 1: unknown parameter in ${{ params.owner }}",
+		},
+		{
+			name:          "later step",
+			macros:        "macros: [{name: m, steps: [{tool: get_issue, args: {n: '${{ steps.next.number }}'}}, {id: next, tool: get_me}]}]",
+			expectedError: "${{ steps.next.number }} does not refer to an earlier step",
+		},
+		{
+			name:          "invalid expression",
+			macros:        "macros: [{name: m, steps: [{tool: get_me, args: {x: '${{ env.HOME }}'}}]}]",
+			expectedError: "invalid expression ${{ env.HOME }}",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.macros))
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestStepArguments(t *testing.T) {
+	m, err := Parse([]byte(testMacros))
+	require.NoError(t, err)
+	steps := m[0].Steps
+
+	params := map[string]any{"owner": "octo-org", "repo": "api", "branch": "agent/fix", "draft": false}
+	args, err := steps[1].Arguments(params, map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"owner": "octo-org",
+		"repo":  "api",
+		"head":  "agent/fix",
+		"base":  "main",
+		"title": "Update agent/fix",
+		// Single expressions keep the type of their value
+		"draft": false,
+	}, args)
+
+	// Expressions referring to parameters that were not given leave the argument out
+	delete(params, "draft")
+	args, err = steps[1].Arguments(params, map[string]any{})
+	require.NoError(t, err)
+	assert.NotContains(t, args, "draft")
+
+	results := map[string]any{"pr": map[string]any{"number": float64(42)}}
+	args, err = steps[2].Arguments(params, results)
+	require.NoError(t, err)
+	assert.Equal(t, float64(42), args["pullNumber"])
+
+	_, err = steps[2].Arguments(params, map[string]any{"pr": map[string]any{"url": "https://github.com/octo-org/api/pull/42"}})
+	assert.ErrorContains(t, err, "the result of step pr has no number")
+}
@@ -0,0 +1,310 @@
+// Package patch parses unified diffs, including the git extensions for renames,
+// mode changes, new and deleted files, and applies them to file contents.
+package patch
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrBinaryPatch is returned when a diff contains binary changes, which cannot be applied from text.
+var ErrBinaryPatch = errors.New("binary patches are not supported")
+
+// Line is a single line of a hunk. Text includes the line terminator, unless the line
+// is the last line of a file that does not end with a newline.
+type Line struct {
+	Op   byte // ' ', '-' or '+'
+	Text string
+}
+
+// Hunk is a contiguous block of changes in a file.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Lines              []Line
+}
+
+// File is the set of changes to a single file.
+type File struct {
+	// OldPath is the path before the change, empty for new files.
+	OldPath string
+	// NewPath is the path after the change, empty for deleted files.
+	NewPath string
+	// OldMode and NewMode are the git file modes, e.g. "100644", when the diff includes them.
+	OldMode string
+	NewMode string
+	Hunks   []Hunk
+}
+
+// IsNew reports whether the file is created by the patch.
+func (f *File) IsNew() bool { return f.OldPath == "" }
+
+// IsDeleted reports whether the file is deleted by the patch.
+func (f *File) IsDeleted() bool { return f.NewPath == "" }
+
+// IsRename reports whether the file is moved by the patch.
+func (f *File) IsRename() bool {
+	return !f.IsNew() && !f.IsDeleted() && f.OldPath != f.NewPath
+}
+
+// ConflictError is returned when a hunk does not match the content it is applied to.
+type ConflictError struct {
+	Path string
+	Hunk int
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("hunk #%d does not apply to %s", e.Hunk, e.Path)
+}
+
+// Parse parses a unified diff into the files it changes.
+func Parse(diff string) ([]*File, error) {
+	lines := strings.SplitAfter(diff, "\n")
+	var files []*File
+	var current *File
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r\n")
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			current = &File{}
+			files = append(files, current)
+			oldPath, newPath, ok := parseGitHeaderPaths(strings.TrimPrefix(line, "diff --git "))
+			if ok {
+				current.OldPath, current.NewPath = oldPath, newPath
+			}
+
+		case current != nil && strings.HasPrefix(line, "new file mode "):
+			current.OldPath = ""
+			current.NewMode = strings.TrimPrefix(line, "new file mode ")
+		case current != nil && strings.HasPrefix(line, "deleted file mode "):
+			current.NewPath = ""
+			current.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+		case current != nil && strings.HasPrefix(line, "old mode "):
+			current.OldMode = strings.TrimPrefix(line, "old mode ")
+		case current != nil && strings.HasPrefix(line, "new mode "):
+			current.NewMode = strings.TrimPrefix(line, "new mode ")
+		case current != nil && strings.HasPrefix(line, "rename from "):
+			current.OldPath = strings.TrimPrefix(line, "rename from ")
+		case current != nil && strings.HasPrefix(line, "rename to "):
+			current.NewPath = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+			return nil, ErrBinaryPatch
+
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			// Plain unified diffs have no "diff --git" header, so the ---/+++ pair starts a new file.
+			if current == nil || len(current.Hunks) > 0 {
+				current = &File{}
+				files = append(files, current)
+			}
+			current.OldPath = parseFilePath(strings.TrimPrefix(line, "--- "))
+			current.NewPath = parseFilePath(strings.TrimPrefix(strings.TrimRight(lines[i+1], "\r\n"), "+++ "))
+			i++
+
+		case strings.HasPrefix(line, "@@ "):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: hunk without file header", i+1)
+			}
+			hunk, consumed, err := parseHunk(lines[i:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			current.Hunks = append(current.Hunks, hunk)
+			i += consumed - 1
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no file changes found in patch")
+	}
+	for _, f := range files {
+		if f.OldPath == "" && f.NewPath == "" {
+			return nil, errors.New("patch contains a file without a path")
+		}
+	}
+	return files, nil
+}
+
+// parseFilePath strips the a/ or b/ prefix and any trailing timestamp from a ---/+++ path,
+// returning an empty path for /dev/null.
+func parseFilePath(s string) string {
+	if tab := strings.IndexByte(s, '\t'); tab >= 0 {
+		s = s[:tab]
+	}
+	s = strings.TrimSpace(s)
+	if s == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(s, "a/") || strings.HasPrefix(s, "b/") {
+		return s[2:]
+	}
+	return s
+}
+
+// parseGitHeaderPaths parses "a/old b/new" from a "diff --git" header line. Paths containing
+// spaces are ambiguous here, in which case the ---/+++ or rename lines provide the paths.
+func parseGitHeaderPaths(s string) (string, string, bool) {
+	if !strings.HasPrefix(s, "a/") {
+		return "", "", false
+	}
+	idx := strings.Index(s, " b/")
+	if idx < 0 || strings.Contains(s[idx+3:], " b/") {
+		return "", "", false
+	}
+	return s[2:idx], s[idx+3:], true
+}
+
+// parseHunk parses a hunk starting at its "@@" header, returning the number of lines consumed.
+func parseHunk(lines []string) (Hunk, int, error) {
+	header := strings.TrimRight(lines[0], "\r\n")
+	var hunk Hunk
+	fields := strings.Fields(header)
+	if len(fields) < 4 || fields[0] != "@@" || fields[3] != "@@" {
+		return hunk, 0, fmt.Errorf("invalid hunk header %q", header)
+	}
+	var err error
+	if hunk.OldStart, hunk.OldLines, err = parseRange(fields[1], '-'); err != nil {
+		return hunk, 0, err
+	}
+	if hunk.NewStart, hunk.NewLines, err = parseRange(fields[2], '+'); err != nil {
+		return hunk, 0, err
+	}
+
+	oldRemaining, newRemaining := hunk.OldLines, hunk.NewLines
+	i := 1
+	for ; i < len(lines); i++ {
+		text := lines[i]
+		if strings.HasPrefix(text, `\`) {
+			// A "\ No newline at end of file" marker applies to the line before it.
+			if len(hunk.Lines) > 0 {
+				last := &hunk.Lines[len(hunk.Lines)-1]
+				last.Text = strings.TrimSuffix(strings.TrimSuffix(last.Text, "\n"), "\r")
+			}
+			continue
+		}
+		if (oldRemaining <= 0 && newRemaining <= 0) || text == "" {
+			break
+		}
+		op := text[0]
+		if text == "\n" || text == "\r\n" {
+			// Some tools strip the leading space from empty context lines.
+			op, text = ' ', " "+text
+		}
+		switch op {
+		case ' ':
+			oldRemaining--
+			newRemaining--
+		case '-':
+			oldRemaining--
+		case '+':
+			newRemaining--
+		default:
+			return hunk, 0, fmt.Errorf("unexpected line in hunk: %q", strings.TrimRight(text, "\r\n"))
+		}
+		hunk.Lines = append(hunk.Lines, Line{Op: op, Text: text[1:]})
+	}
+	if oldRemaining != 0 || newRemaining != 0 {
+		return hunk, 0, fmt.Errorf("hunk %q is truncated", header)
+	}
+	return hunk, i, nil
+}
+
+func parseRange(s string, prefix byte) (int, int, error) {
+	if len(s) == 0 || s[0] != prefix {
+		return 0, 0, fmt.Errorf("invalid hunk range %q", s)
+	}
+	start, count, found := strings.Cut(s[1:], ",")
+	startN, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid hunk range %q", s)
+	}
+	countN := 1
+	if found {
+		if countN, err = strconv.Atoi(count); err != nil {
+			return 0, 0, fmt.Errorf("invalid hunk range %q", s)
+		}
+	}
+	return startN, countN, nil
+}
+
+// Apply applies the hunks of f to content. Hunks are matched exactly, but may be found at an
+// offset from the line numbers in their headers, as long as they do not overlap earlier hunks.
+func Apply(content string, f *File) (string, error) {
+	path := f.NewPath
+	if path == "" {
+		path = f.OldPath
+	}
+
+	var original []string
+	if content != "" {
+		original = strings.SplitAfter(content, "\n")
+		if original[len(original)-1] == "" {
+			original = original[:len(original)-1]
+		}
+	}
+
+	var out strings.Builder
+	pos := 0
+	for n, hunk := range f.Hunks {
+		var oldLines, newLines []string
+		for _, l := range hunk.Lines {
+			if l.Op != '+' {
+				oldLines = append(oldLines, l.Text)
+			}
+			if l.Op != '-' {
+				newLines = append(newLines, l.Text)
+			}
+		}
+
+		expected := hunk.OldStart - 1
+		if hunk.OldLines == 0 {
+			// Pure insertions name the line after which the new lines go.
+			expected = hunk.OldStart
+		}
+		at := findHunk(original, oldLines, pos, expected)
+		if at < 0 {
+			return "", &ConflictError{Path: path, Hunk: n + 1}
+		}
+
+		for _, l := range original[pos:at] {
+			out.WriteString(l)
+		}
+		for _, l := range newLines {
+			out.WriteString(l)
+		}
+		pos = at + len(oldLines)
+	}
+	for _, l := range original[pos:] {
+		out.WriteString(l)
+	}
+	return out.String(), nil
+}
+
+// findHunk returns the index at which lines match original, searching outwards from expected
+// without going before minPos, or -1 if there is no match.
+func findHunk(original, lines []string, minPos, expected int) int {
+	matchesAt := func(at int) bool {
+		if at < minPos || at+len(lines) > len(original) {
+			return false
+		}
+		for i, l := range lines {
+			if original[at+i] != l {
+				return false
+			}
+		}
+		return true
+	}
+
+	for offset := 0; expected-offset >= minPos || expected+offset <= len(original); offset++ {
+		if matchesAt(expected + offset) {
+			return expected + offset
+		}
+		if offset > 0 && matchesAt(expected-offset) {
+			return expected - offset
+		}
+	}
+	return -1
+}
@@ -0,0 +1,144 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gitDiff = `diff --git a/main.go b/main.go
+index 83db48f..bf269f4 100644
+--- a/main.go
++++ b/main.go
+@@ -1,5 +1,5 @@
+ package main
+
+ func main() {
+-	println("hello")
++	println("hello, world")
+ }
+diff --git a/docs/old.md b/docs/new.md
+similarity index 100%
+rename from docs/old.md
+rename to docs/new.md
+diff --git a/run.sh b/run.sh
+old mode 100644
+new mode 100755
+diff --git a/NOTES b/NOTES
+new file mode 100644
+index 0000000..3b18e51
+--- /dev/null
++++ b/NOTES
+@@ -0,0 +1,2 @@
++first
++second
+\ No newline at end of file
+diff --git a/obsolete.txt b/obsolete.txt
+deleted file mode 100644
+index 3b18e51..0000000
+--- a/obsolete.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+`
+
+func TestParse(t *testing.T) {
+	files, err := Parse(gitDiff)
+	require.NoError(t, err)
+	require.Len(t, files, 5)
+
+	modified := files[0]
+	assert.Equal(t, "main.go", modified.OldPath)
+	assert.Equal(t, "main.go", modified.NewPath)
+	require.Len(t, modified.Hunks, 1)
+	assert.Equal(t, Hunk{
+		OldStart: 1, OldLines: 5, NewStart: 1, NewLines: 5,
+		Lines: []Line{
+			{Op: ' ', Text: "package main\n"},
+			{Op: ' ', Text: "\n"},
+			{Op: ' ', Text: "func main() {\n"},
+			{Op: '-', Text: "\tprintln(\"hello\")\n"},
+			{Op: '+', Text: "\tprintln(\"hello, world\")\n"},
+			{Op: ' ', Text: "}\n"},
+		},
+	}, modified.Hunks[0])
+
+	renamed := files[1]
+	assert.True(t, renamed.IsRename())
+	assert.Equal(t, "docs/old.md", renamed.OldPath)
+	assert.Equal(t, "docs/new.md", renamed.NewPath)
+	assert.Empty(t, renamed.Hunks)
+
+	modeChange := files[2]
+	assert.False(t, modeChange.IsRename())
+	assert.Equal(t, "100644", modeChange.OldMode)
+	assert.Equal(t, "100755", modeChange.NewMode)
+
+	added := files[3]
+	assert.True(t, added.IsNew())
+	assert.Equal(t, "NOTES", added.NewPath)
+	assert.Equal(t, []Line{{Op: '+', Text: "first\n"}, {Op: '+', Text: "second"}}, added.Hunks[0].Lines)
+
+	deleted := files[4]
+	assert.True(t, deleted.IsDeleted())
+	assert.Equal(t, "obsolete.txt", deleted.OldPath)
+}
+
+func TestParse_PlainUnifiedDiff(t *testing.T) {
+	files, err := Parse("--- a.txt\t2024-01-01 00:00:00\n+++ a.txt\t2024-01-02 00:00:00\n@@ -1 +1 @@\n-a\n+b\n--- b.txt\n+++ b.txt\n@@ -1 +1 @@\n-c\n+d\n")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "a.txt", files[0].NewPath)
+	assert.Equal(t, "b.txt", files[1].NewPath)
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse("just some text\n")
+	assert.EqualError(t, err, "no file changes found in patch")
+
+	_, err = Parse("diff --git a/img.png b/img.png\nBinary files a/img.png and b/img.png differ\n")
+	assert.ErrorIs(t, err, ErrBinaryPatch)
+
+	_, err = Parse("--- a/x\n+++ b/x\n@@ -1,3 +1,3 @@\n a\n-b\n")
+	assert.ErrorContains(t, err, "is truncated")
+}
+
+func TestApply(t *testing.T) {
+	files, err := Parse(gitDiff)
+	require.NoError(t, err)
+
+	result, err := Apply("package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n", files[0])
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n\nfunc main() {\n\tprintln(\"hello, world\")\n}\n", result)
+
+	result, err = Apply("", files[3])
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond", result)
+
+	result, err = Apply("gone\n", files[4])
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestApply_Offset(t *testing.T) {
+	files, err := Parse("--- a/list\n+++ b/list\n@@ -2,3 +2,3 @@\n b\n-c\n+C\n d\n@@ -8,2 +8,3 @@\n h\n i\n+j\n")
+	require.NoError(t, err)
+
+	// Two lines were inserted at the top since the diff was made, so both hunks apply two lines later.
+	result, err := Apply("x\ny\na\nb\nc\nd\ne\nf\ng\nh\ni\n", files[0])
+	require.NoError(t, err)
+	assert.Equal(t, "x\ny\na\nb\nC\nd\ne\nf\ng\nh\ni\nj\n", result)
+}
+
+func TestApply_Conflict(t *testing.T) {
+	files, err := Parse("--- a/list\n+++ b/list\n@@ -1,2 +1,2 @@\n a\n-b\n+B\n")
+	require.NoError(t, err)
+
+	_, err = Apply("a\nchanged\n", files[0])
+	var conflict *ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "list", conflict.Path)
+	assert.Equal(t, 1, conflict.Hunk)
+	assert.EqualError(t, err, "hunk #1 does not apply to list")
+}
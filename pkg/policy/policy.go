@@ -0,0 +1,246 @@
+// Package policy restricts the write tools of the server to specific repositories and branches,
+// and denies tools that should not be used at all, as configured in a policy file.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy restricts what the write tools of the server may do. Read-only tools are never
+// restricted.
+type Policy struct {
+	// Write lists the repositories, and branches in them, that write tools may be used in.
+	// Write tools may be used anywhere if it is empty.
+	Write []Scope `yaml:"write"`
+	// DenyTools lists tools that may not be used at all.
+	DenyTools []string `yaml:"deny_tools"`
+	// DenyDestructive denies all tools that are annotated as destructive, such as deleting a file.
+	DenyDestructive bool `yaml:"deny_destructive"`
+}
+
+// Scope is a set of repositories, and optionally of branches in them, that write tools may be used in.
+type Scope struct {
+	// Repos are owner/repo patterns, such as octo-org/* for all repositories of octo-org.
+	Repos []string `yaml:"repos"`
+	// Branches are branch patterns, such as agent/*, limiting the branches and tags write tools
+	// name in their arguments. Calls to tools that write to a ref they do not name, such as
+	// merging a pull request, are refused. Any branch may be written to if it is empty.
+	Branches []string `yaml:"branches"`
+}
+
+// refArgs are the arguments naming the branches and tags of write tool calls, with how to get
+// the name of the ref from them.
+var refArgs = []struct {
+	name string
+	ref  func(string) string
+}{
+	{"branch", nil},
+	{"from_branch", nil},
+	{"base", nil},
+	{"head", headRef},
+	{"ref", qualifiedRef},
+	{"tag", nil},
+	{"tag_name", nil},
+	{"channel", nil},
+}
+
+// refWriters are the write tools that write to a branch or tag or change its protection. A call
+// to one of them that names no ref writes to one it cannot be checked against, such as the
+// default branch, the base of a pull request or the branches a ruleset applies to.
+var refWriters = map[string]bool{
+	"apply_patch":                   true,
+	"create_commit_on_branch":       true,
+	"create_or_update_file":         true,
+	"create_ref":                    true,
+	"create_release":                true,
+	"create_tag":                    true,
+	"delete_branch_protection":      true,
+	"delete_file":                   true,
+	"delete_ref":                    true,
+	"delete_repository_ruleset":     true,
+	"merge_pull_request":            true,
+	"move_file":                     true,
+	"promote_release":               true,
+	"push_files":                    true,
+	"render_scaffold":               true,
+	"resume_operation":              true,
+	"update_branch_protection":      true,
+	"update_pull_request_branch":    true,
+	"update_ref":                    true,
+	"update_release":                true,
+	"update_repository_ruleset":     true,
+	"update_required_status_checks": true,
+}
+
+// qualifiedRef returns the name of a branch or tag given as heads/name, tags/name or with a
+// refs/ prefix.
+func qualifiedRef(ref string) string {
+	ref = strings.TrimPrefix(ref, "refs/")
+	if name, ok := strings.CutPrefix(ref, "heads/"); ok {
+		return name
+	}
+	if name, ok := strings.CutPrefix(ref, "tags/"); ok {
+		return name
+	}
+	return ref
+}
+
+// headRef returns the branch of a pull request head given as owner:branch.
+func headRef(head string) string {
+	if _, branch, ok := strings.Cut(head, ":"); ok {
+		return branch
+	}
+	return head
+}
+
+// writtenRefs returns the refs named in the arguments of a write tool call.
+func writtenRefs(args map[string]any) []string {
+	var refs []string
+	for _, arg := range refArgs {
+		value, _ := args[arg.name].(string)
+		if value == "" {
+			continue
+		}
+		if arg.ref != nil {
+			value = arg.ref(value)
+		}
+		refs = append(refs, value)
+	}
+	return refs
+}
+
+// Load reads a policy from a YAML or JSON file.
+func Load(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", file, err)
+	}
+	return p, nil
+}
+
+// Parse parses a policy in YAML or JSON, rejecting unknown settings and invalid patterns.
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for i, scope := range p.Write {
+		if len(scope.Repos) == 0 {
+			return nil, fmt.Errorf("write scope %d has no repos", i+1)
+		}
+		for _, pattern := range slices.Concat(scope.Repos, scope.Branches) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in write scope %d", pattern, i+1)
+			}
+		}
+		for _, pattern := range scope.Repos {
+			if !strings.Contains(pattern, "/") {
+				return nil, fmt.Errorf("invalid repo pattern %q in write scope %d, expected owner/repo", pattern, i+1)
+			}
+		}
+	}
+	return &p, nil
+}
+
+// Denies reports whether a tool may not be used at all.
+func (p *Policy) Denies(tool string, destructive bool) bool {
+	return (destructive && p.DenyDestructive) || slices.Contains(p.DenyTools, tool)
+}
+
+// CheckWrite returns an error if the policy does not allow a write tool to be called with the
+// given arguments. The repository is taken from the owner and repo arguments, and the branches
+// and tags from the arguments naming refs, such as branch, ref and base.
+func (p *Policy) CheckWrite(tool string, args map[string]any) error {
+	if len(p.Write) == 0 {
+		return nil
+	}
+	owner, _ := args["owner"].(string)
+	repo, _ := args["repo"].(string)
+	refs := writtenRefs(args)
+	unnamed := refWriters[tool] && len(refs) == 0
+
+	if p.allowsAny(owner, repo, refs, unnamed) {
+		return nil
+	}
+
+	target := owner
+	if repo != "" {
+		target = owner + "/" + repo
+	}
+	if target == "" {
+		return fmt.Errorf("policy does not allow %s, write tools may only be used in the repositories it lists", tool)
+	}
+	for _, ref := range refs {
+		if !p.allowsAny(owner, repo, []string{ref}, false) {
+			return fmt.Errorf("policy does not allow %s on branch %s of %s", tool, ref, target)
+		}
+	}
+	if unnamed && p.allowsAny(owner, repo, nil, false) {
+		return fmt.Errorf("policy does not allow %s in %s without naming the branch it writes to, as the policy limits branches", tool, target)
+	}
+	return fmt.Errorf("policy does not allow %s in %s", tool, target)
+}
+
+func (p *Policy) allowsAny(owner, repo string, refs []string, unnamed bool) bool {
+	for _, scope := range p.Write {
+		if scope.allows(owner, repo, refs, unnamed) {
+			return true
+		}
+	}
+	return false
+}
+
+// allows reports whether the scope covers a repository and the refs written to in it, of which
+// some are not named if unnamed is set. Calls for an owner rather than a single repository are
+// only covered by patterns for all of the owner's repositories.
+func (s Scope) allows(owner, repo string, refs []string, unnamed bool) bool {
+	if owner == "" {
+		return false
+	}
+	if repo == "" {
+		repo = "*"
+	}
+	target := strings.ToLower(owner + "/" + repo)
+	if !matchAny(s.Repos, target, strings.ToLower) {
+		return false
+	}
+	if len(s.Branches) == 0 {
+		return true
+	}
+	if unnamed {
+		return false
+	}
+	for _, ref := range refs {
+		if !matchAny(s.Branches, ref, nil) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAny(patterns []string, name string, normalize func(string) string) bool {
+	for _, pattern := range patterns {
+		if normalize != nil {
+			pattern = normalize(pattern)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
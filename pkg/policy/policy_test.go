@@ -0,0 +1,225 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+write:
+  - repos: ["octo-org/*"]
+    branches: ["agent/*"]
+  - repos: ["octocat/hello-world"]
+deny_tools: [delete_ref]
+deny_destructive: true
+`
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	require.NoError(t, err)
+	assert.Equal(t, &Policy{
+		Write: []Scope{
+			{Repos: []string{"octo-org/*"}, Branches: []string{"agent/*"}},
+			{Repos: []string{"octocat/hello-world"}},
+		},
+		DenyTools:       []string{"delete_ref"},
+		DenyDestructive: true,
+	}, p)
+
+	// JSON is accepted as well
+	p, err = Parse([]byte(`{"write": [{"repos": ["octo-org/*"]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []Scope{{Repos: []string{"octo-org/*"}}}, p.Write)
+
+	// An empty policy allows everything
+	p, err = Parse(nil)
+	require.NoError(t, err)
+	assert.Equal(t, &Policy{}, p)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        string
+		expectedError string
+	}{
+		{
+			name:          "unknown setting",
+			policy:        "deny_tool: [delete_ref]",
+			expectedError: "field deny_tool not found",
+		},
+		{
+			name:          "scope without repos",
+			policy:        "write: [{branches: [main]}]",
+			expectedError: "write scope 1 has no repos",
+		},
+		{
+			name:          "repo pattern without owner",
+			policy:        "write: [{repos: [hello-world]}]",
+			expectedError: `invalid repo pattern "hello-world" in write scope 1, expected owner/repo`,
+		},
+		{
+			name:          "malformed pattern",
+			policy:        "write: [{repos: [octo-org/*], branches: ['agent/[']}]",
+			expectedError: `invalid pattern "agent/[" in write scope 1`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.policy))
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(testPolicy), 0o600))
+
+	p, err := Load(file)
+	require.NoError(t, err)
+	assert.Len(t, p.Write, 2)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read policy file")
+}
+
+func TestPolicy_Denies(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	require.NoError(t, err)
+
+	assert.True(t, p.Denies("delete_ref", false))
+	assert.True(t, p.Denies("delete_file", true))
+	assert.False(t, p.Denies("create_branch", false))
+	assert.False(t, (&Policy{}).Denies("delete_file", true))
+}
+
+func TestPolicy_CheckWrite(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		tool          string
+		args          map[string]any
+		expectedError string
+	}{
+		{
+			name: "repository in scope",
+			tool: "create_issue",
+			args: map[string]any{"owner": "octo-org", "repo": "app", "title": "Bug"},
+		},
+		{
+			name: "owner matched case-insensitively",
+			tool: "create_issue",
+			args: map[string]any{"owner": "Octo-Org", "repo": "App"},
+		},
+		{
+			name: "branch in scope",
+			args: map[string]any{"owner": "octo-org", "repo": "app", "branch": "agent/fix-login"},
+		},
+		{
+			name:          "branch out of scope",
+			args:          map[string]any{"owner": "octo-org", "repo": "app", "branch": "main"},
+			expectedError: "policy does not allow push_files on branch main of octo-org/app",
+		},
+		{
+			name:          "default branch written to without naming it",
+			args:          map[string]any{"owner": "octo-org", "repo": "app"},
+			expectedError: "policy does not allow push_files in octo-org/app without naming the branch it writes to, as the policy limits branches",
+		},
+		{
+			name:          "ref out of scope",
+			tool:          "update_ref",
+			args:          map[string]any{"owner": "octo-org", "repo": "app", "ref": "heads/main", "sha": "abc123"},
+			expectedError: "policy does not allow update_ref on branch main of octo-org/app",
+		},
+		{
+			name: "ref in scope",
+			tool: "update_ref",
+			args: map[string]any{"owner": "octo-org", "repo": "app", "ref": "refs/heads/agent/fix-login", "sha": "abc123"},
+		},
+		{
+			name:          "channel tag out of scope",
+			tool:          "promote_release",
+			args:          map[string]any{"owner": "octo-org", "repo": "app", "from_tag": "v1.2.3", "channel": "stable"},
+			expectedError: "policy does not allow promote_release on branch stable of octo-org/app",
+		},
+		{
+			name:          "ruleset in a repository whose branches are limited",
+			tool:          "delete_repository_ruleset",
+			args:          map[string]any{"owner": "octo-org", "repo": "app", "ruleset_id": float64(1)},
+			expectedError: "policy does not allow delete_repository_ruleset in octo-org/app without naming the branch it writes to, as the policy limits branches",
+		},
+		{
+			name:          "base branch out of scope",
+			tool:          "create_pull_request",
+			args:          map[string]any{"owner": "octo-org", "repo": "app", "head": "octo-org:agent/fix-login", "base": "main"},
+			expectedError: "policy does not allow create_pull_request on branch main of octo-org/app",
+		},
+		{
+			name:          "source branch out of scope",
+			tool:          "create_commit_on_branch",
+			args:          map[string]any{"owner": "octo-org", "repo": "app", "branch": "agent/fix-login", "from_branch": "main"},
+			expectedError: "policy does not allow create_commit_on_branch on branch main of octo-org/app",
+		},
+		{
+			name:          "merge into a branch the call does not name",
+			tool:          "merge_pull_request",
+			args:          map[string]any{"owner": "octo-org", "repo": "app", "pullNumber": float64(42)},
+			expectedError: "policy does not allow merge_pull_request in octo-org/app without naming the branch it writes to, as the policy limits branches",
+		},
+		{
+			name: "merge in a scope without branches",
+			tool: "merge_pull_request",
+			args: map[string]any{"owner": "octocat", "repo": "hello-world", "pullNumber": float64(42)},
+		},
+		{
+			name: "any branch of a scope without branches",
+			args: map[string]any{"owner": "octocat", "repo": "hello-world", "branch": "main"},
+		},
+		{
+			name:          "repository out of scope",
+			args:          map[string]any{"owner": "octocat", "repo": "other"},
+			expectedError: "policy does not allow push_files in octocat/other",
+		},
+		{
+			name: "owner with all repositories in scope",
+			tool: "create_repository",
+			args: map[string]any{"owner": "octo-org"},
+		},
+		{
+			name:          "owner with some repositories in scope",
+			args:          map[string]any{"owner": "octocat"},
+			expectedError: "policy does not allow push_files in octocat",
+		},
+		{
+			name:          "no repository",
+			args:          map[string]any{"description": "gist"},
+			expectedError: "policy does not allow push_files, write tools may only be used in the repositories it lists",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := tc.tool
+			if tool == "" {
+				tool = "push_files"
+			}
+			err := p.CheckWrite(tool, tc.args)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+
+	// Without write scopes, write tools may be used anywhere
+	assert.NoError(t, (&Policy{}).CheckWrite("push_files", map[string]any{}))
+}
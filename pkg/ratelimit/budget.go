@@ -0,0 +1,175 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SessionBudget is the name of the budget that applies to each session separately.
+const SessionBudget = "session"
+
+// maxWindows is how many budget windows are kept before expired ones are dropped.
+const maxWindows = 1024
+
+// Budget is how many API requests may be made in a window of time.
+type Budget struct {
+	Calls  int
+	Window time.Duration
+}
+
+// ParseBudgets parses budgets of the form name=calls/window, such as search_code=50/1h.
+func ParseBudgets(specs []string) (map[string]Budget, error) {
+	budgets := make(map[string]Budget, len(specs))
+	for _, spec := range specs {
+		name, limit, ok := strings.Cut(strings.TrimSpace(spec), "=")
+		calls, window, ok2 := strings.Cut(limit, "/")
+		if !ok || !ok2 || name == "" {
+			return nil, fmt.Errorf("invalid API budget %q, expected name=calls/window", spec)
+		}
+		n, err := strconv.Atoi(calls)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid number of calls in API budget %q", spec)
+		}
+		d, err := time.ParseDuration(window)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid window in API budget %q", spec)
+		}
+		budgets[name] = Budget{Calls: n, Window: d}
+	}
+	return budgets, nil
+}
+
+// Scope is what API requests are made for, which determines the budgets they count against.
+type Scope struct {
+	Tool    string
+	Toolset string
+	Session string
+}
+
+type scopeCtxKey struct{}
+
+// WithScope returns a context whose API requests count against the budgets of scope.
+func WithScope(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, scopeCtxKey{}, scope)
+}
+
+// BudgetExceededError is returned for API requests that would exceed a budget.
+type BudgetExceededError struct {
+	// Name is the tool or toolset the budget is for, or SessionBudget.
+	Name    string
+	Budget  Budget
+	ResetAt time.Time
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("API budget for %s of %d calls per %s exceeded, resets at %s",
+		e.Name, e.Budget.Calls, e.Budget.Window, e.ResetAt.UTC().Format(time.RFC3339))
+}
+
+// Limiter enforces budgets on the API requests made for tools, toolsets and sessions, so that
+// one runaway client cannot use up the rate limit shared by everyone using the server. Tool and
+// toolset budgets are shared by all sessions, while the session budget applies to each session
+// separately.
+type Limiter struct {
+	budgets map[string]Budget
+	now     func() time.Time
+
+	mu      sync.Mutex
+	windows map[string]*window
+}
+
+type window struct {
+	start time.Time
+	calls int
+}
+
+// NewLimiter creates a limiter for budgets keyed by the name of a tool or toolset, or by
+// SessionBudget.
+func NewLimiter(budgets map[string]Budget) *Limiter {
+	return &Limiter{
+		budgets: budgets,
+		now:     time.Now,
+		windows: make(map[string]*window),
+	}
+}
+
+// Allow counts an API request against the budgets of the scope in ctx, or returns a
+// *BudgetExceededError without counting it if any of them is used up.
+func (l *Limiter) Allow(ctx context.Context) error {
+	scope, ok := ctx.Value(scopeCtxKey{}).(Scope)
+	if !ok {
+		return nil
+	}
+
+	type charge struct {
+		key    string
+		name   string
+		budget Budget
+	}
+	var charges []charge
+	for _, name := range []string{scope.Tool, scope.Toolset} {
+		if budget, ok := l.budgets[name]; ok && name != "" {
+			charges = append(charges, charge{key: name, name: name, budget: budget})
+		}
+	}
+	if budget, ok := l.budgets[SessionBudget]; ok && scope.Session != "" {
+		charges = append(charges, charge{key: SessionBudget + ":" + scope.Session, name: SessionBudget, budget: budget})
+	}
+	if len(charges) == 0 {
+		return nil
+	}
+
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.windows) > maxWindows {
+		l.dropExpired(now)
+	}
+	windows := make([]*window, len(charges))
+	for i, c := range charges {
+		w := l.windows[c.key]
+		if w == nil || now.Sub(w.start) >= c.budget.Window {
+			w = &window{start: now}
+			l.windows[c.key] = w
+		}
+		if w.calls >= c.budget.Calls {
+			return &BudgetExceededError{Name: c.name, Budget: c.budget, ResetAt: w.start.Add(c.budget.Window)}
+		}
+		windows[i] = w
+	}
+	for _, w := range windows {
+		w.calls++
+	}
+	return nil
+}
+
+func (l *Limiter) dropExpired(now time.Time) {
+	for key, w := range l.windows {
+		name, _, _ := strings.Cut(key, ":")
+		if now.Sub(w.start) >= l.budgets[name].Window {
+			delete(l.windows, key)
+		}
+	}
+}
+
+// Transport returns a transport that sends requests with next after counting them against
+// their budgets. Requests that exceed a budget fail with a *BudgetExceededError.
+func (l *Limiter) Transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if err := l.Allow(req.Context()); err != nil {
+			return nil, err
+		}
+		return next.RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBudgets(t *testing.T) {
+	budgets, err := ParseBudgets([]string{"search_code=50/1h", " session=200/30m"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Budget{
+		"search_code": {Calls: 50, Window: time.Hour},
+		"session":     {Calls: 200, Window: 30 * time.Minute},
+	}, budgets)
+
+	for _, spec := range []string{"search_code", "search_code=50", "=50/1h", "search_code=many/1h", "search_code=50/soon", "search_code=50/0s"} {
+		_, err := ParseBudgets([]string{spec})
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(map[string]Budget{
+		"search_code": {Calls: 2, Window: time.Hour},
+		"repos":       {Calls: 3, Window: time.Hour},
+		SessionBudget: {Calls: 4, Window: time.Minute},
+	})
+	limiter.now = func() time.Time { return now }
+
+	search := WithScope(context.Background(), Scope{Tool: "search_code", Toolset: "repos", Session: "a"})
+	contents := WithScope(context.Background(), Scope{Tool: "get_file_contents", Toolset: "repos", Session: "b"})
+	issues := WithScope(context.Background(), Scope{Tool: "get_issue", Toolset: "issues", Session: "a"})
+
+	require.NoError(t, limiter.Allow(search))
+	require.NoError(t, limiter.Allow(search))
+	var budgetErr *BudgetExceededError
+	require.ErrorAs(t, limiter.Allow(search), &budgetErr)
+	assert.Equal(t, "search_code", budgetErr.Name)
+	assert.Equal(t, now.Add(time.Hour), budgetErr.ResetAt)
+
+	// The rejected call did not count against the toolset budget.
+	require.NoError(t, limiter.Allow(contents))
+	require.ErrorAs(t, limiter.Allow(contents), &budgetErr)
+	assert.Equal(t, "repos", budgetErr.Name)
+
+	// Session a made two calls so far; each session has its own budget.
+	require.NoError(t, limiter.Allow(issues))
+	require.NoError(t, limiter.Allow(issues))
+	require.ErrorAs(t, limiter.Allow(issues), &budgetErr)
+	assert.Equal(t, SessionBudget, budgetErr.Name)
+
+	// Requests without a scope, such as those not made for a tool, are not limited.
+	require.NoError(t, limiter.Allow(context.Background()))
+
+	// Budgets are restored once their window has passed.
+	now = now.Add(time.Minute)
+	require.NoError(t, limiter.Allow(issues))
+	now = now.Add(time.Hour)
+	require.NoError(t, limiter.Allow(search))
+}
+
+func TestLimiter_Transport(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+
+	limiter := NewLimiter(map[string]Budget{"search_code": {Calls: 1, Window: time.Hour}})
+	client := &http.Client{Transport: limiter.Transport(http.DefaultTransport)}
+	ctx := WithScope(context.Background(), Scope{Tool: "search_code"})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	_, err = client.Do(req) //nolint:bodyclose // the request fails before a response is received
+	var urlErr *url.Error
+	require.True(t, errors.As(err, &urlErr))
+	var budgetErr *BudgetExceededError
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Equal(t, 1, requests)
+}
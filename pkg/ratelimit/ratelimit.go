@@ -0,0 +1,139 @@
+// Package ratelimit provides an HTTP transport that waits out GitHub API rate limits and
+// retries the rate limited request, instead of failing a burst of requests outright.
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how often a rate limited request is retried.
+	DefaultMaxRetries = 3
+	// DefaultMaxWait is the longest the transport waits before a retry. Requests that are
+	// rate limited for longer fail immediately, so that callers are not blocked for the
+	// up to an hour it takes for the primary rate limit to reset.
+	DefaultMaxWait = time.Minute
+
+	// baseBackoff is the first wait for secondary rate limits that do not say how long to wait.
+	baseBackoff = time.Second
+	// maxPeekSize is how much of a 403 response body is read to recognize a secondary rate limit.
+	maxPeekSize = 64 << 10
+)
+
+// Transport is an http.RoundTripper that retries requests rejected by a primary or
+// secondary rate limit, after waiting as long as GitHub asks it to.
+type Transport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+	now        func() time.Time
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// NewTransport creates a transport that sends requests with next, or http.DefaultTransport
+// if next is nil, retrying rate limited requests up to maxRetries times as long as each
+// wait is at most maxWait.
+func NewTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{
+		next:       next,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+		now:        time.Now,
+		sleep:      sleep,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+
+		wait, limited := t.retryAfter(resp, attempt)
+		if !limited || !replayable || attempt >= t.maxRetries || wait > t.maxWait {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryAfter reports whether a response was rejected by a rate limit, and if so how long
+// to wait before retrying, following
+// https://docs.github.com/en/rest/using-the-rest-api/best-practices-for-using-the-rest-api#handle-rate-limit-errors-appropriately
+func (t *Transport) retryAfter(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Unix(reset, 0).Sub(t.now()), 0) + time.Second, true
+		}
+	}
+
+	// Secondary rate limits do not always say how long to wait, and share the 403 status
+	// with permission errors, so they can only be told apart by their message.
+	if resp.StatusCode == http.StatusForbidden && !isSecondaryRateLimit(resp) {
+		return 0, false
+	}
+	return baseBackoff << attempt, true
+}
+
+// isSecondaryRateLimit reports whether the body of a 403 response is a secondary rate limit
+// error, leaving the body intact for the caller.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	peek, err := io.ReadAll(io.LimitReader(resp.Body, maxPeekSize))
+	resp.Body = readCloser{io.MultiReader(bytes.NewReader(peek), resp.Body), resp.Body}
+	if err != nil {
+		return false
+	}
+	message := strings.ToLower(string(peek))
+	return strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection")
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,159 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTransport returns a transport that records its waits instead of sleeping.
+func newTestTransport(now time.Time) (*Transport, *[]time.Duration) {
+	var waits []time.Duration
+	transport := NewTransport(nil, DefaultMaxRetries, DefaultMaxWait)
+	transport.now = func() time.Time { return now }
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return transport, &waits
+}
+
+// limitedServer rejects the first n requests with the given response, then succeeds.
+func limitedServer(t *testing.T, n int, reject http.HandlerFunc) (*httptest.Server, *[]string) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= n {
+			reject(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &bodies
+}
+
+func TestTransport(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		rejections     int
+		reject         http.HandlerFunc
+		expectedStatus int
+		expectedWaits  []time.Duration
+		expectedBody   string
+	}{
+		{
+			name:       "secondary rate limit with Retry-After",
+			rejections: 1,
+			reject: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", "5")
+				w.WriteHeader(http.StatusForbidden)
+			},
+			expectedStatus: http.StatusOK,
+			expectedWaits:  []time.Duration{5 * time.Second},
+		},
+		{
+			name:       "primary rate limit waits until the reset",
+			rejections: 1,
+			reject: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(20*time.Second).Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+			},
+			expectedStatus: http.StatusOK,
+			expectedWaits:  []time.Duration{21 * time.Second},
+		},
+		{
+			name:       "secondary rate limit without headers backs off exponentially",
+			rejections: 2,
+			reject: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = io.WriteString(w, `{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`)
+			},
+			expectedStatus: http.StatusOK,
+			expectedWaits:  []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:       "gives up after the maximum number of retries",
+			rejections: 10,
+			reject: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedWaits:  []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:       "does not wait longer than the maximum",
+			rejections: 1,
+			reject: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(time.Hour).Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:       "permission errors are not retried",
+			rejections: 1,
+			reject: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = io.WriteString(w, `{"message": "Resource not accessible by integration"}`)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "Resource not accessible by integration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, bodies := limitedServer(t, tc.rejections, tc.reject)
+			transport, waits := newTestTransport(now)
+			client := &http.Client{Transport: transport}
+
+			resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{"title":"bug"}`))
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedWaits, *waits)
+			// Every attempt sends the full request body.
+			require.Len(t, *bodies, len(tc.expectedWaits)+1)
+			for _, body := range *bodies {
+				assert.Equal(t, `{"title":"bug"}`, body)
+			}
+
+			// The response body is intact, including when it was inspected.
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), tc.expectedBody)
+		})
+	}
+}
+
+func TestTransport_ContextCancelled(t *testing.T) {
+	srv, _ := limitedServer(t, 1, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	client := &http.Client{Transport: NewTransport(nil, DefaultMaxRetries, DefaultMaxWait)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,201 @@
+// Package retry provides an HTTP transport that retries requests that failed with transient
+// server or network errors, such as the occasional 502 from the GitHub API.
+package retry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is how often a request is sent at most, including the first attempt.
+	DefaultMaxAttempts = 3
+	// DefaultBaseDelay is the longest wait before the first retry. It doubles for every retry.
+	DefaultBaseDelay = 500 * time.Millisecond
+	// DefaultMaxDelay is the longest wait before any retry.
+	DefaultMaxDelay = 10 * time.Second
+
+	// maxGraphQLPeekSize is how much of a GraphQL request body is read to tell queries from mutations.
+	maxGraphQLPeekSize = 1 << 20
+)
+
+// Policy configures which requests are retried and how often.
+type Policy struct {
+	// MaxAttempts is how often a request is sent at most. Requests are not retried if it is 1 or less.
+	MaxAttempts int
+	// BaseDelay is the longest wait before the first retry. Waits are drawn at random up to a
+	// limit that doubles for every retry, so that clients that failed together do not retry together.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait before any retry.
+	MaxDelay time.Duration
+	// NonIdempotent retries requests that may have had an effect even though they failed, such as
+	// creating an issue, at the risk of doing it twice. Only idempotent requests and GraphQL
+	// queries are retried otherwise.
+	NonIdempotent bool
+}
+
+// DefaultPolicy retries idempotent requests with the default attempts and delays.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: DefaultMaxAttempts,
+		BaseDelay:   DefaultBaseDelay,
+		MaxDelay:    DefaultMaxDelay,
+	}
+}
+
+// Stats counts the retries of requests made with a context.
+type Stats struct {
+	Retries atomic.Int64
+}
+
+type statsCtxKey struct{}
+
+// WithStats returns a context that counts the retries of the requests made with it.
+func WithStats(ctx context.Context) (context.Context, *Stats) {
+	stats := &Stats{}
+	return context.WithValue(ctx, statsCtxKey{}, stats), stats
+}
+
+// Transport is an http.RoundTripper that retries requests that failed with a network error or
+// a 5xx response, with jittered exponential backoff.
+type Transport struct {
+	next   http.RoundTripper
+	policy Policy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// NewTransport creates a transport that sends requests with next, or http.DefaultTransport if
+// next is nil, retrying them as configured by policy.
+func NewTransport(next http.RoundTripper, policy Policy) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{
+		next:   next,
+		policy: policy,
+		sleep:  sleep,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retryable := t.policy.MaxAttempts > 1 && (t.policy.NonIdempotent || isIdempotent(req))
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	if !retryable || !replayable {
+		return t.next.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if !isTransient(req.Context(), resp, err) || attempt >= t.policy.MaxAttempts {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+				wait = time.Duration(seconds) * time.Second
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if wait > t.policy.MaxDelay {
+			wait = t.policy.MaxDelay
+		}
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		if stats, ok := req.Context().Value(statsCtxKey{}).(*Stats); ok {
+			stats.Retries.Add(1)
+		}
+	}
+}
+
+// backoff returns a random wait before the given retry, up to a limit that doubles every time.
+func (t *Transport) backoff(attempt int) time.Duration {
+	limit := min(t.policy.BaseDelay<<(attempt-1), t.policy.MaxDelay)
+	if limit <= 0 {
+		return 0
+	}
+	return rand.N(limit) //nolint:gosec // jitter does not need a secure random number generator
+}
+
+// isTransient reports whether a request failed in a way that may succeed when retried.
+func isTransient(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// Requests cancelled by the caller must not be retried
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent reports whether sending a request twice has the same effect as sending it once.
+// GraphQL requests are all POSTs, so only queries are told apart from mutations by their body.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return strings.HasSuffix(req.URL.Path, "/graphql") && isGraphQLQuery(req)
+	}
+	return false
+}
+
+func isGraphQLQuery(req *http.Request) bool {
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer func() { _ = body.Close() }()
+
+	peek, err := io.ReadAll(io.LimitReader(body, maxGraphQLPeekSize))
+	if err != nil {
+		return false
+	}
+	var payload struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(bytes.NewReader(peek)).Decode(&payload); err != nil {
+		return false
+	}
+	query := strings.TrimSpace(payload.Query)
+	return query != "" && !strings.HasPrefix(query, "mutation") && !strings.HasPrefix(query, "subscription")
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
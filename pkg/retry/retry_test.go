@@ -0,0 +1,237 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTransport returns a transport that records its waits instead of sleeping.
+func newTestTransport(next http.RoundTripper, policy Policy) (*Transport, *[]time.Duration) {
+	var waits []time.Duration
+	transport := NewTransport(next, policy)
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return transport, &waits
+}
+
+// flakyServer fails the first n requests with the given status, then succeeds.
+func flakyServer(t *testing.T, n int, status int) (*httptest.Server, *[]string) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= n {
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, `{"message": "Server Error"}`)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &bodies
+}
+
+func TestTransport(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int
+		status           int
+		method           string
+		path             string
+		body             string
+		policy           Policy
+		expectedStatus   int
+		expectedAttempts int
+	}{
+		{
+			name:             "retries bad gateway",
+			failures:         2,
+			status:           http.StatusBadGateway,
+			method:           http.MethodGet,
+			path:             "/repos/octo/hello",
+			policy:           DefaultPolicy(),
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "gives up after max attempts",
+			failures:         5,
+			status:           http.StatusServiceUnavailable,
+			method:           http.MethodGet,
+			path:             "/repos/octo/hello",
+			policy:           DefaultPolicy(),
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "does not retry client errors",
+			failures:         1,
+			status:           http.StatusNotFound,
+			method:           http.MethodGet,
+			path:             "/repos/octo/hello",
+			policy:           DefaultPolicy(),
+			expectedStatus:   http.StatusNotFound,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "does not retry non-idempotent requests",
+			failures:         1,
+			status:           http.StatusBadGateway,
+			method:           http.MethodPost,
+			path:             "/repos/octo/hello/issues",
+			body:             `{"title":"Bug"}`,
+			policy:           DefaultPolicy(),
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retries non-idempotent requests if allowed",
+			failures:         1,
+			status:           http.StatusBadGateway,
+			method:           http.MethodPost,
+			path:             "/repos/octo/hello/issues",
+			body:             `{"title":"Bug"}`,
+			policy:           Policy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Second, NonIdempotent: true},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "retries GraphQL queries",
+			failures:         1,
+			status:           http.StatusBadGateway,
+			method:           http.MethodPost,
+			path:             "/graphql",
+			body:             `{"query":"query($owner:String!){repository(owner: $owner){id}}"}`,
+			policy:           DefaultPolicy(),
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "does not retry GraphQL mutations",
+			failures:         1,
+			status:           http.StatusBadGateway,
+			method:           http.MethodPost,
+			path:             "/api/graphql",
+			body:             `{"query":"mutation($input:AddCommentInput!){addComment(input: $input){clientMutationId}}"}`,
+			policy:           DefaultPolicy(),
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retries are disabled with one attempt",
+			failures:         1,
+			status:           http.StatusBadGateway,
+			method:           http.MethodGet,
+			path:             "/repos/octo/hello",
+			policy:           Policy{MaxAttempts: 1},
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, bodies := flakyServer(t, tc.failures, tc.status)
+			transport, waits := newTestTransport(nil, tc.policy)
+
+			ctx, stats := WithStats(context.Background())
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			req, err := http.NewRequestWithContext(ctx, tc.method, srv.URL+tc.path, body)
+			require.NoError(t, err)
+
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Len(t, *bodies, tc.expectedAttempts)
+			for _, b := range *bodies {
+				assert.Equal(t, tc.body, b, "every attempt sends the whole body")
+			}
+			assert.Len(t, *waits, tc.expectedAttempts-1)
+			for _, wait := range *waits {
+				assert.LessOrEqual(t, wait, tc.policy.MaxDelay)
+			}
+			assert.Equal(t, int64(tc.expectedAttempts-1), stats.Retries.Load())
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransport_NetworkErrors(t *testing.T) {
+	attempts := 0
+	failing := roundTripperFunc(func(_ *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	transport, _ := newTestTransport(failing, DefaultPolicy())
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, attempts)
+
+	// Cancelled requests are not retried
+	attempts = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cancelled := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, req.Context().Err()
+	})
+	transport, _ = newTestTransport(cancelled, DefaultPolicy())
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req) //nolint:bodyclose // no response is returned
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestTransport_RetryAfter(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+
+	transport, waits := newTestTransport(nil, DefaultPolicy())
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []time.Duration{DefaultMaxDelay}, *waits, "Retry-After is capped at the maximum delay")
+}
@@ -0,0 +1,76 @@
+// Package signing signs commits with a GPG or SSH key, the way git does, so that commits made
+// through the GitHub API show as verified.
+package signing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Formats of signatures.
+const (
+	// FormatGPG signs with gpg, using the key with the given ID
+	FormatGPG = "gpg"
+	// FormatSSH signs with ssh-keygen, using the private key file at the given path
+	FormatSSH = "ssh"
+)
+
+// signTimeout bounds how long signing a commit may take, e.g. if gpg waits for a passphrase.
+const signTimeout = 30 * time.Second
+
+// Signer signs commits. It implements the MessageSigner of go-github.
+type Signer struct {
+	format string
+	key    string
+	// program is the command run to sign, gpg or ssh-keygen by default.
+	program string
+}
+
+// New returns a Signer for a key of the given format.
+func New(format, key string) (*Signer, error) {
+	if key == "" {
+		return nil, fmt.Errorf("no signing key given")
+	}
+	switch format {
+	case FormatGPG:
+		return &Signer{format: format, key: key, program: "gpg"}, nil
+	case FormatSSH:
+		return &Signer{format: format, key: key, program: "ssh-keygen"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing format %q, must be %s or %s", format, FormatGPG, FormatSSH)
+	}
+}
+
+// args returns the arguments to sign the standard input and write an armored detached signature
+// to the standard output, which are those git uses.
+func (s *Signer) args() []string {
+	if s.format == FormatSSH {
+		return []string{"-Y", "sign", "-n", "git", "-f", s.key}
+	}
+	return []string{"--batch", "--status-fd=2", "-bsau", s.key}
+}
+
+// Sign writes a signature of the commit read from r to w.
+func (s *Signer) Sign(w io.Writer, r io.Reader) error {
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, s.program, s.args()...)
+	cmd.Stdin = r
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to sign commit with %s: %w: %s", s.program, err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() == 0 {
+		return fmt.Errorf("failed to sign commit with %s: no signature was written", s.program)
+	}
+	_, err := w.Write(stdout.Bytes())
+	return err
+}
@@ -0,0 +1,62 @@
+package signing
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(FormatSSH, "")
+	assert.ErrorContains(t, err, "no signing key given")
+
+	_, err = New("x509", "key")
+	assert.ErrorContains(t, err, `unsupported signing format "x509"`)
+
+	signer, err := New(FormatGPG, "ABCDEF")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--batch", "--status-fd=2", "-bsau", "ABCDEF"}, signer.args())
+}
+
+func TestSignSSH(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not installed")
+	}
+	key := filepath.Join(t.TempDir(), "id_ed25519")
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "test", "-f", key).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	signer, err := New(FormatSSH, key)
+	require.NoError(t, err)
+	commit := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nauthor test <test@example.com> 1700000000 +0000\n\nmessage"
+	var signature bytes.Buffer
+	require.NoError(t, signer.Sign(&signature, strings.NewReader(commit)))
+	assert.True(t, strings.HasPrefix(signature.String(), "-----BEGIN SSH SIGNATURE-----"), signature.String())
+
+	// The signature verifies against the public key, as GitHub would check it
+	publicKey, err := os.ReadFile(key + ".pub")
+	require.NoError(t, err)
+	dir := t.TempDir()
+	allowed := filepath.Join(dir, "allowed_signers")
+	require.NoError(t, os.WriteFile(allowed, []byte("test@example.com "+string(publicKey)), 0600))
+	sigFile := filepath.Join(dir, "commit.sig")
+	require.NoError(t, os.WriteFile(sigFile, signature.Bytes(), 0600))
+	verify := exec.Command("ssh-keygen", "-Y", "verify", "-f", allowed, "-I", "test@example.com", "-n", "git", "-s", sigFile)
+	verify.Stdin = strings.NewReader(commit)
+	out, err = verify.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestSignFailure(t *testing.T) {
+	signer, err := New(FormatSSH, filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	signer.program = "false"
+	err = signer.Sign(&bytes.Buffer{}, strings.NewReader("commit"))
+	assert.ErrorContains(t, err, "failed to sign commit with false")
+}
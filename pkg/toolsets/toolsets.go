@@ -2,6 +2,7 @@ package toolsets
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -132,6 +133,17 @@ func (t *Toolset) RegisterPrompts(s *server.MCPServer) {
 	}
 }
 
+func (t *Toolset) GetActivePrompts() []server.ServerPrompt {
+	if !t.Enabled {
+		return nil
+	}
+	return t.prompts
+}
+
+func (t *Toolset) GetAvailablePrompts() []server.ServerPrompt {
+	return t.prompts
+}
+
 func (t *Toolset) SetReadOnly() {
 	// Set the toolset to read-only
 	t.readOnly = true
@@ -238,6 +250,43 @@ func (tg *ToolsetGroup) EnableToolset(name string) error {
 	return nil
 }
 
+func (tg *ToolsetGroup) DisableToolset(name string) error {
+	toolset, exists := tg.Toolsets[name]
+	if !exists {
+		return NewToolsetDoesNotExistError(name)
+	}
+	toolset.Enabled = false
+	return nil
+}
+
+// FilterTools removes individual tools from the toolsets of the group. If enabled is not empty,
+// only the tools named in it are kept; tools named in disabled are removed either way.
+func (tg *ToolsetGroup) FilterTools(enabled, disabled []string) {
+	keep := func(tool server.ServerTool) bool {
+		if len(enabled) > 0 && !slices.Contains(enabled, tool.Tool.Name) {
+			return false
+		}
+		return !slices.Contains(disabled, tool.Tool.Name)
+	}
+	for _, toolset := range tg.Toolsets {
+		toolset.readTools = slices.DeleteFunc(toolset.readTools, func(tool server.ServerTool) bool { return !keep(tool) })
+		toolset.writeTools = slices.DeleteFunc(toolset.writeTools, func(tool server.ServerTool) bool { return !keep(tool) })
+	}
+}
+
+// MapTools replaces every tool of every toolset with the result of fn, such as the tool with
+// its handler wrapped.
+func (tg *ToolsetGroup) MapTools(fn func(server.ServerTool) server.ServerTool) {
+	for _, toolset := range tg.Toolsets {
+		for i, tool := range toolset.readTools {
+			toolset.readTools[i] = fn(tool)
+		}
+		for i, tool := range toolset.writeTools {
+			toolset.writeTools[i] = fn(tool)
+		}
+	}
+}
+
 func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
 	for _, toolset := range tg.Toolsets {
 		toolset.RegisterTools(s)
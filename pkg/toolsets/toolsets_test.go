@@ -2,9 +2,17 @@ package toolsets
 
 import (
 	"errors"
+	"slices"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
+func mockTool(name string, readOnly bool) server.ServerTool {
+	return NewServerTool(mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly})), nil)
+}
+
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
 	tsg := NewToolsetGroup(false)
 	if len(tsg.Toolsets) != 0 {
@@ -124,6 +132,98 @@ func TestEnableFeature(t *testing.T) {
 	}
 }
 
+func TestDisableToolset(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+
+	// Test disabling non-existent toolset
+	err := tsg.DisableToolset("non-existent")
+	if !errors.Is(err, NewToolsetDoesNotExistError("non-existent")) {
+		t.Errorf("Expected ToolsetDoesNotExistError when disabling non-existent toolset, got: %v", err)
+	}
+
+	testToolset := NewToolset("test-toolset", "A test toolset")
+	tsg.AddToolset(testToolset)
+	if err := tsg.EnableToolset("test-toolset"); err != nil {
+		t.Fatalf("Expected no error when enabling toolset, got: %v", err)
+	}
+
+	err = tsg.DisableToolset("test-toolset")
+	if err != nil {
+		t.Errorf("Expected no error when disabling toolset, got: %v", err)
+	}
+
+	if tsg.IsEnabled("test-toolset") {
+		t.Error("Expected toolset to be disabled after DisableToolset call")
+	}
+	if testToolset.GetActiveTools() != nil {
+		t.Error("Expected a disabled toolset to have no active tools")
+	}
+}
+
+func TestFilterTools(t *testing.T) {
+	newGroup := func() *ToolsetGroup {
+		tsg := NewToolsetGroup(false)
+		tsg.AddToolset(NewToolset("issues", "Issue tools").
+			AddReadTools(mockTool("get_issue", true), mockTool("list_issues", true)).
+			AddWriteTools(mockTool("create_issue", false), mockTool("delete_issue", false)))
+		tsg.AddToolset(NewToolset("repos", "Repository tools").
+			AddReadTools(mockTool("get_file_contents", true)).
+			AddWriteTools(mockTool("delete_file", false)))
+		return tsg
+	}
+	toolNames := func(tsg *ToolsetGroup, toolset string) []string {
+		var names []string
+		for _, tool := range tsg.Toolsets[toolset].GetAvailableTools() {
+			names = append(names, tool.Tool.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name     string
+		enabled  []string
+		disabled []string
+		issues   []string
+		repos    []string
+	}{
+		{
+			name:   "no filters",
+			issues: []string{"get_issue", "list_issues", "create_issue", "delete_issue"},
+			repos:  []string{"get_file_contents", "delete_file"},
+		},
+		{
+			name:     "denylist",
+			disabled: []string{"delete_issue", "delete_file"},
+			issues:   []string{"get_issue", "list_issues", "create_issue"},
+			repos:    []string{"get_file_contents"},
+		},
+		{
+			name:    "allowlist",
+			enabled: []string{"get_issue", "create_issue"},
+			issues:  []string{"get_issue", "create_issue"},
+		},
+		{
+			name:     "denylist wins over allowlist",
+			enabled:  []string{"get_issue", "create_issue"},
+			disabled: []string{"create_issue"},
+			issues:   []string{"get_issue"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsg := newGroup()
+			tsg.FilterTools(tc.enabled, tc.disabled)
+			if got := toolNames(tsg, "issues"); !slices.Equal(got, tc.issues) {
+				t.Errorf("expected issues tools %v, got %v", tc.issues, got)
+			}
+			if got := toolNames(tsg, "repos"); !slices.Equal(got, tc.repos) {
+				t.Errorf("expected repos tools %v, got %v", tc.repos, got)
+			}
+		})
+	}
+}
+
 func TestEnableToolsets(t *testing.T) {
 	tsg := NewToolsetGroup(false)
 
@@ -250,3 +350,21 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func TestMapTools(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("issues", "Issue tools").
+		AddReadTools(mockTool("get_issue", true)).
+		AddWriteTools(mockTool("create_issue", false)))
+
+	tsg.MapTools(func(tool server.ServerTool) server.ServerTool {
+		tool.Tool.Description = "mapped " + tool.Tool.Name
+		return tool
+	})
+
+	for _, tool := range tsg.Toolsets["issues"].GetAvailableTools() {
+		if tool.Tool.Description != "mapped "+tool.Tool.Name {
+			t.Errorf("expected %s to be mapped, got description %q", tool.Tool.Name, tool.Tool.Description)
+		}
+	}
+}
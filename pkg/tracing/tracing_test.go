@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a tracer provider recording the spans ended during the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_SDK_DISABLED", "")
+	assert.False(t, Enabled())
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	assert.True(t, Enabled())
+
+	t.Setenv("OTEL_SDK_DISABLED", "true")
+	assert.False(t, Enabled())
+}
+
+func TestMiddlewareAndTransport(t *testing.T) {
+	recorder := recordSpans(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if r.URL.Path == "/repos/octocat/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	handler := Middleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		repo, _ := request.GetArguments()["repo"].(string)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/repos/octocat/"+repo, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return mcp.NewToolResultError("not found"), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+	call := func(repo string) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "get_repository"
+		request.Params.Arguments = map[string]any{"owner": "octocat", "repo": repo}
+		_, err := handler(context.Background(), request)
+		require.NoError(t, err)
+	}
+
+	call("hello-world")
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	apiSpan, toolSpan := spans[0], spans[1]
+
+	assert.Equal(t, "tools/call get_repository", toolSpan.Name())
+	assert.Equal(t, codes.Unset, toolSpan.Status().Code)
+	toolAttrs := attributes(toolSpan)
+	assert.Equal(t, "get_repository", toolAttrs["mcp.tool.name"].AsString())
+	assert.Equal(t, "octocat", toolAttrs["github.owner"].AsString())
+	assert.Equal(t, "octocat/hello-world", toolAttrs["github.repository"].AsString())
+
+	assert.Equal(t, "GitHub API GET", apiSpan.Name())
+	assert.Equal(t, toolSpan.SpanContext().SpanID(), apiSpan.Parent().SpanID())
+	apiAttrs := attributes(apiSpan)
+	assert.Equal(t, "/repos/octocat/hello-world", apiAttrs["url.path"].AsString())
+	assert.Equal(t, int64(200), apiAttrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, int64(4999), apiAttrs["github.rate_limit.remaining"].AsInt64())
+
+	call("missing")
+	spans = recorder.Ended()
+	require.Len(t, spans, 4)
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+	assert.Equal(t, "404 Not Found", spans[2].Status().Description)
+	assert.Equal(t, codes.Error, spans[3].Status().Code)
+}
+
+func TestMiddlewareError(t *testing.T) {
+	recorder := recordSpans(t)
+	handler := Middleware()(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, errors.New("boom")
+	})
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_me"
+	_, err := handler(context.Background(), request)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "boom", spans[0].Status().Description)
+}
+
+func TestExtractHTTP(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+	recorder := recordSpans(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler := Middleware()(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_me"
+	_, err := handler(ExtractHTTP(context.Background(), r), request)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
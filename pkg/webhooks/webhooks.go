@@ -0,0 +1,190 @@
+// Package webhooks receives GitHub webhook deliveries and fans them out to subscribers,
+// keeping a bounded history of recent events so they can also be queried after the fact.
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// DefaultHistorySize is the number of recent events kept by a Hub when no size is given.
+const DefaultHistorySize = 200
+
+// Event is a webhook delivery received from GitHub.
+type Event struct {
+	// DeliveryID is the unique ID GitHub assigns to the delivery.
+	DeliveryID string `json:"delivery_id"`
+	// Type is the event name, e.g. "push" or "issue_comment".
+	Type string `json:"type"`
+	// Action is the activity that triggered the event, e.g. "opened", if the event has one.
+	Action       string          `json:"action,omitempty"`
+	Repository   string          `json:"repository,omitempty"`
+	Organization string          `json:"organization,omitempty"`
+	Sender       string          `json:"sender,omitempty"`
+	ReceivedAt   time.Time       `json:"received_at"`
+	Payload      json.RawMessage `json:"payload,omitempty"`
+}
+
+// Filter selects events from the history. Zero values match all events.
+type Filter struct {
+	Type       string
+	Repository string
+	Since      time.Time
+	Limit      int
+}
+
+func (f Filter) matches(e Event) bool {
+	if f.Type != "" && e.Type != f.Type {
+		return false
+	}
+	if f.Repository != "" && e.Repository != f.Repository {
+		return false
+	}
+	return f.Since.IsZero() || e.ReceivedAt.After(f.Since)
+}
+
+// Hub verifies webhook deliveries, records them and notifies subscribers. It implements
+// http.Handler so it can be mounted as the webhook endpoint.
+type Hub struct {
+	secret    []byte
+	publicURL string
+	size      int
+
+	mu          sync.Mutex
+	events      []Event
+	subscribers map[int]func(Event)
+	nextID      int
+}
+
+// NewHub creates a hub that accepts deliveries signed with secret. publicURL is the address
+// GitHub should deliver to, and is used as the default when registering webhooks.
+func NewHub(secret, publicURL string, historySize int) (*Hub, error) {
+	if secret == "" {
+		return nil, errors.New("a webhook secret is required to verify deliveries")
+	}
+	if historySize <= 0 {
+		historySize = DefaultHistorySize
+	}
+	return &Hub{
+		secret:      []byte(secret),
+		publicURL:   publicURL,
+		size:        historySize,
+		subscribers: make(map[int]func(Event)),
+	}, nil
+}
+
+// Secret returns the secret deliveries are signed with.
+func (h *Hub) Secret() string { return string(h.secret) }
+
+// PublicURL returns the address GitHub should deliver webhooks to.
+func (h *Hub) PublicURL() string { return h.publicURL }
+
+// Subscribe registers fn to be called for every event published from now on.
+// The returned function removes the subscription.
+func (h *Hub) Subscribe(fn func(Event)) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	id := h.nextID
+	h.nextID++
+	h.subscribers[id] = fn
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers, id)
+	}
+}
+
+// Publish records the event and notifies subscribers.
+func (h *Hub) Publish(e Event) {
+	h.mu.Lock()
+	h.events = append(h.events, e)
+	if len(h.events) > h.size {
+		h.events = h.events[len(h.events)-h.size:]
+	}
+	subscribers := make([]func(Event), 0, len(h.subscribers))
+	for _, fn := range h.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	h.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(e)
+	}
+}
+
+// Events returns the recorded events matching the filter, most recent first.
+func (h *Hub) Events(f Filter) []Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var out []Event
+	for i := len(h.events) - 1; i >= 0; i-- {
+		if !f.matches(h.events[i]) {
+			continue
+		}
+		out = append(out, h.events[i])
+		if f.Limit > 0 && len(out) == f.Limit {
+			break
+		}
+	}
+	return out
+}
+
+// ServeHTTP accepts a webhook delivery. Deliveries without a valid signature are rejected.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := github.ValidatePayload(r, h.secret)
+	if err != nil {
+		http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+	eventType := github.WebHookType(r)
+	if eventType == "" {
+		http.Error(w, "missing event type", http.StatusBadRequest)
+		return
+	}
+
+	event, err := newEvent(eventType, github.DeliveryID(r), payload)
+	if err != nil {
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+	h.Publish(event)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func newEvent(eventType, deliveryID string, payload []byte) (Event, error) {
+	// Only the fields common to all events are extracted; the payload is kept as is.
+	var common struct {
+		Action     string `json:"action"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+		Organization struct {
+			Login string `json:"login"`
+		} `json:"organization"`
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+	if err := json.Unmarshal(payload, &common); err != nil {
+		return Event{}, err
+	}
+	return Event{
+		DeliveryID:   deliveryID,
+		Type:         eventType,
+		Action:       common.Action,
+		Repository:   common.Repository.FullName,
+		Organization: common.Organization.Login,
+		Sender:       common.Sender.Login,
+		ReceivedAt:   time.Now().UTC(),
+		Payload:      json.RawMessage(payload),
+	}, nil
+}
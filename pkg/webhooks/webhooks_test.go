@@ -0,0 +1,110 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newDelivery(eventType, body, signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", eventType)
+	req.Header.Set("X-GitHub-Delivery", "delivery-1")
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature-256", signature)
+	}
+	return req
+}
+
+func TestNewHub_RequiresSecret(t *testing.T) {
+	_, err := NewHub("", "https://example.com/webhooks", 0)
+	assert.Error(t, err)
+}
+
+func TestHub_ServeHTTP(t *testing.T) {
+	hub, err := NewHub("s3cret", "https://example.com/webhooks", 0)
+	require.NoError(t, err)
+
+	var notified []Event
+	unsubscribe := hub.Subscribe(func(e Event) { notified = append(notified, e) })
+
+	body := `{"action":"created","repository":{"full_name":"octo/repo"},"organization":{"login":"octo"},"sender":{"login":"monalisa"}}`
+
+	rec := httptest.NewRecorder()
+	hub.ServeHTTP(rec, newDelivery("issue_comment", body, sign("s3cret", body)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	require.Len(t, notified, 1)
+	event := notified[0]
+	assert.Equal(t, "delivery-1", event.DeliveryID)
+	assert.Equal(t, "issue_comment", event.Type)
+	assert.Equal(t, "created", event.Action)
+	assert.Equal(t, "octo/repo", event.Repository)
+	assert.Equal(t, "octo", event.Organization)
+	assert.Equal(t, "monalisa", event.Sender)
+	assert.JSONEq(t, body, string(event.Payload))
+
+	// Deliveries with a wrong or missing signature are rejected and not recorded.
+	for _, signature := range []string{sign("wrong", body), ""} {
+		rec = httptest.NewRecorder()
+		hub.ServeHTTP(rec, newDelivery("issue_comment", body, signature))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+
+	unsubscribe()
+	rec = httptest.NewRecorder()
+	hub.ServeHTTP(rec, newDelivery("push", body, sign("s3cret", body)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Len(t, notified, 1)
+	assert.Len(t, hub.Events(Filter{}), 2)
+
+	rec = httptest.NewRecorder()
+	hub.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHub_Events(t *testing.T) {
+	hub, err := NewHub("s3cret", "", 3)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i, e := range []Event{
+		{DeliveryID: "1", Type: "push", Repository: "octo/a"},
+		{DeliveryID: "2", Type: "push", Repository: "octo/b"},
+		{DeliveryID: "3", Type: "issues", Repository: "octo/a"},
+		{DeliveryID: "4", Type: "push", Repository: "octo/a"},
+	} {
+		e.ReceivedAt = start.Add(time.Duration(i) * time.Minute)
+		hub.Publish(e)
+	}
+
+	ids := func(events []Event) []string {
+		var out []string
+		for _, e := range events {
+			out = append(out, e.DeliveryID)
+		}
+		return out
+	}
+
+	// The oldest event is evicted once the history is full.
+	assert.Equal(t, []string{"4", "3", "2"}, ids(hub.Events(Filter{})))
+	assert.Equal(t, []string{"4", "2"}, ids(hub.Events(Filter{Type: "push"})))
+	assert.Equal(t, []string{"4", "3"}, ids(hub.Events(Filter{Repository: "octo/a"})))
+	assert.Equal(t, []string{"4"}, ids(hub.Events(Filter{Since: start.Add(2 * time.Minute)})))
+	assert.Equal(t, []string{"4"}, ids(hub.Events(Filter{Limit: 1})))
+}
@@ -0,0 +1,409 @@
+// Package workflows checks GitHub Actions workflow files for the mistakes that would make GitHub
+// reject them, so that they can be caught before the file is committed.
+package workflows
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Events are the events that trigger workflows.
+var Events = []string{
+	"branch_protection_rule",
+	"check_run",
+	"check_suite",
+	"create",
+	"delete",
+	"deployment",
+	"deployment_status",
+	"discussion",
+	"discussion_comment",
+	"fork",
+	"gollum",
+	"issue_comment",
+	"issues",
+	"label",
+	"merge_group",
+	"milestone",
+	"page_build",
+	"public",
+	"pull_request",
+	"pull_request_review",
+	"pull_request_review_comment",
+	"pull_request_target",
+	"push",
+	"registry_package",
+	"release",
+	"repository_dispatch",
+	"schedule",
+	"status",
+	"watch",
+	"workflow_call",
+	"workflow_dispatch",
+	"workflow_run",
+}
+
+// Keys allowed in a workflow, a job and a step.
+var (
+	workflowKeys = []string{"name", "run-name", "on", "permissions", "env", "defaults", "concurrency", "jobs"}
+	jobKeys      = []string{"name", "permissions", "needs", "if", "runs-on", "snapshot", "environment", "concurrency", "outputs", "env", "defaults", "steps", "timeout-minutes", "strategy", "continue-on-error", "container", "services", "uses", "with", "secrets"}
+	stepKeys     = []string{"id", "if", "name", "uses", "run", "shell", "with", "env", "continue-on-error", "timeout-minutes", "working-directory"}
+)
+
+// Types of the inputs of workflow_dispatch and workflow_call.
+var (
+	dispatchInputTypes = []string{"string", "boolean", "choice", "number", "environment"}
+	callInputTypes     = []string{"string", "boolean", "number"}
+)
+
+var jobIDPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// Problem is an error or warning found in a workflow file.
+type Problem struct {
+	// Line is the line of the file the problem is on, or 0 if it is not on a specific line.
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// Input is an input of a workflow that can be run manually.
+type Input struct {
+	Type        string   `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+// Summary describes a workflow file.
+type Summary struct {
+	Name     string   `json:"name,omitempty"`
+	Triggers []string `json:"triggers"`
+	Jobs     []string `json:"jobs"`
+	// Inputs are the inputs of workflow_dispatch, which can be given when running the workflow.
+	Inputs map[string]Input `json:"inputs,omitempty"`
+}
+
+// Result is the outcome of validating a workflow file.
+type Result struct {
+	Valid    bool      `json:"valid"`
+	Errors   []Problem `json:"errors"`
+	Warnings []Problem `json:"warnings"`
+	// Summary describes the workflow, as far as it could be parsed. It is nil if the file is not YAML.
+	Summary *Summary `json:"summary,omitempty"`
+}
+
+type checker struct {
+	result Result
+}
+
+func (c *checker) errorf(node *yaml.Node, format string, args ...any) {
+	c.result.Errors = append(c.result.Errors, Problem{Line: line(node), Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) warnf(node *yaml.Node, format string, args ...any) {
+	c.result.Warnings = append(c.result.Warnings, Problem{Line: line(node), Message: fmt.Sprintf(format, args...)})
+}
+
+func line(node *yaml.Node) int {
+	if node == nil {
+		return 0
+	}
+	return node.Line
+}
+
+// Validate parses a workflow file and checks its structure: the triggers, jobs and steps it must
+// have, the keys they may have, the jobs they need and the inputs of manually run and reusable
+// workflows. Expressions are not evaluated, and actions are not looked up.
+func Validate(content []byte) Result {
+	c := &checker{result: Result{Errors: []Problem{}, Warnings: []Problem{}}}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		c.result.Errors = append(c.result.Errors, Problem{Message: fmt.Sprintf("invalid YAML: %s", strings.TrimPrefix(err.Error(), "yaml: "))})
+		return c.result
+	}
+	c.result.Summary = &Summary{Triggers: []string{}, Jobs: []string{}}
+	if len(document.Content) == 0 {
+		c.errorf(nil, "the workflow is empty")
+		return c.result
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		c.errorf(root, "the workflow must be a mapping of keys such as on and jobs")
+		return c.result
+	}
+
+	c.checkKeys(root, workflowKeys, "workflow")
+	if name := mappingValue(root, "name"); name != nil {
+		c.result.Summary.Name = name.Value
+	}
+	if on := mappingValue(root, "on"); on != nil {
+		c.checkTriggers(on)
+	} else {
+		c.errorf(root, "the workflow has no triggers, add an on key")
+	}
+	if jobs := mappingValue(root, "jobs"); jobs != nil {
+		c.checkJobs(jobs)
+	} else {
+		c.errorf(root, "the workflow has no jobs, add a jobs key")
+	}
+
+	c.result.Valid = len(c.result.Errors) == 0
+	return c.result
+}
+
+// checkKeys reports keys of a mapping that are not allowed, and keys that are repeated.
+func (c *checker) checkKeys(node *yaml.Node, allowed []string, what string) {
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if seen[key.Value] {
+			c.errorf(key, "%s key %q is repeated", what, key.Value)
+		}
+		seen[key.Value] = true
+		if !slices.Contains(allowed, key.Value) {
+			c.errorf(key, "unexpected %s key %q, expected one of %s", what, key.Value, strings.Join(allowed, ", "))
+		}
+	}
+}
+
+func (c *checker) checkTriggers(on *yaml.Node) {
+	var events []*yaml.Node
+	switch on.Kind {
+	case yaml.ScalarNode:
+		events = []*yaml.Node{on}
+	case yaml.SequenceNode:
+		events = on.Content
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			events = append(events, on.Content[i])
+		}
+	default:
+		c.errorf(on, "on must be an event, a list of events or a mapping of events to their configuration")
+		return
+	}
+	if len(events) == 0 {
+		c.errorf(on, "the workflow has no triggers")
+	}
+	for _, event := range events {
+		c.result.Summary.Triggers = append(c.result.Summary.Triggers, event.Value)
+		if !slices.Contains(Events, event.Value) {
+			c.errorf(event, "unknown event %q", event.Value)
+		}
+	}
+	if on.Kind != yaml.MappingNode {
+		return
+	}
+
+	if schedule := mappingValue(on, "schedule"); schedule != nil {
+		if schedule.Kind != yaml.SequenceNode || len(schedule.Content) == 0 {
+			c.errorf(schedule, "schedule must be a list of cron entries")
+		} else {
+			for _, entry := range schedule.Content {
+				cron := mappingValue(entry, "cron")
+				if cron == nil {
+					c.errorf(entry, "schedule entries must have a cron key")
+				} else if fields := strings.Fields(cron.Value); len(fields) != 5 {
+					c.errorf(cron, "cron %q must have 5 fields, it has %d", cron.Value, len(fields))
+				}
+			}
+		}
+	}
+	if dispatch := mappingValue(on, "workflow_dispatch"); dispatch != nil {
+		c.result.Summary.Inputs = c.checkInputs(mappingValue(dispatch, "inputs"), dispatchInputTypes, "workflow_dispatch")
+	}
+	if call := mappingValue(on, "workflow_call"); call != nil {
+		c.checkInputs(mappingValue(call, "inputs"), callInputTypes, "workflow_call")
+	}
+}
+
+func (c *checker) checkInputs(inputs *yaml.Node, types []string, event string) map[string]Input {
+	if inputs == nil {
+		return nil
+	}
+	if inputs.Kind != yaml.MappingNode {
+		c.errorf(inputs, "%s inputs must be a mapping of input names to their definitions", event)
+		return nil
+	}
+	result := make(map[string]Input)
+	for i := 0; i+1 < len(inputs.Content); i += 2 {
+		name, definition := inputs.Content[i], inputs.Content[i+1]
+		input := Input{Type: "string"}
+		if definition.Kind == yaml.MappingNode {
+			if v := mappingValue(definition, "type"); v != nil {
+				input.Type = v.Value
+			}
+			if v := mappingValue(definition, "description"); v != nil {
+				input.Description = v.Value
+			}
+			if v := mappingValue(definition, "required"); v != nil {
+				input.Required = v.Value == "true"
+			}
+			if v := mappingValue(definition, "default"); v != nil {
+				input.Default = v.Value
+			}
+			if v := mappingValue(definition, "options"); v != nil {
+				for _, option := range v.Content {
+					input.Options = append(input.Options, option.Value)
+				}
+			}
+		} else if definition.Tag != "!!null" {
+			c.errorf(definition, "%s input %q must be a mapping with keys such as type and description", event, name.Value)
+		}
+		result[name.Value] = input
+
+		if !slices.Contains(types, input.Type) {
+			c.errorf(name, "%s input %q has type %q, which must be one of %s", event, name.Value, input.Type, strings.Join(types, ", "))
+		}
+		if input.Type == "choice" {
+			if len(input.Options) == 0 {
+				c.errorf(name, "%s input %q is a choice, so it needs options", event, name.Value)
+			} else if input.Default != "" && !slices.Contains(input.Options, input.Default) {
+				c.errorf(name, "the default of %s input %q, %q, is not one of its options", event, name.Value, input.Default)
+			}
+		}
+		if input.Required && input.Default != "" {
+			c.warnf(name, "%s input %q is required, so its default is never used", event, name.Value)
+		}
+	}
+	return result
+}
+
+func (c *checker) checkJobs(jobs *yaml.Node) {
+	if jobs.Kind != yaml.MappingNode || len(jobs.Content) == 0 {
+		c.errorf(jobs, "jobs must be a mapping of job IDs to jobs, with at least one job")
+		return
+	}
+	ids := make([]string, 0, len(jobs.Content)/2)
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		ids = append(ids, jobs.Content[i].Value)
+	}
+	c.result.Summary.Jobs = ids
+
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		id, job := jobs.Content[i], jobs.Content[i+1]
+		if !jobIDPattern.MatchString(id.Value) {
+			c.errorf(id, "job ID %q must start with a letter or _ and contain only letters, digits, - and _", id.Value)
+		}
+		if job.Kind != yaml.MappingNode {
+			c.errorf(job, "job %q must be a mapping", id.Value)
+			continue
+		}
+		c.checkKeys(job, jobKeys, fmt.Sprintf("job %q", id.Value))
+		c.checkNeeds(id.Value, mappingValue(job, "needs"), ids)
+
+		uses, runsOn, steps := mappingValue(job, "uses"), mappingValue(job, "runs-on"), mappingValue(job, "steps")
+		switch {
+		case uses != nil:
+			// Jobs calling reusable workflows have no steps of their own
+			if steps != nil || runsOn != nil {
+				c.errorf(uses, "job %q calls a reusable workflow, so it cannot have runs-on or steps", id.Value)
+			}
+		case runsOn == nil:
+			c.errorf(id, "job %q has no runs-on", id.Value)
+			fallthrough
+		default:
+			c.checkSteps(id.Value, job, steps)
+		}
+	}
+}
+
+func (c *checker) checkNeeds(job string, needs *yaml.Node, ids []string) {
+	if needs == nil {
+		return
+	}
+	var names []*yaml.Node
+	switch needs.Kind {
+	case yaml.ScalarNode:
+		names = []*yaml.Node{needs}
+	case yaml.SequenceNode:
+		names = needs.Content
+	default:
+		c.errorf(needs, "needs of job %q must be a job ID or a list of job IDs", job)
+		return
+	}
+	for _, name := range names {
+		switch {
+		case name.Value == job:
+			c.errorf(name, "job %q needs itself", job)
+		case !slices.Contains(ids, name.Value):
+			c.errorf(name, "job %q needs job %q, which does not exist", job, name.Value)
+		}
+	}
+}
+
+func (c *checker) checkSteps(job string, jobNode, steps *yaml.Node) {
+	if steps == nil {
+		c.errorf(jobNode, "job %q has no steps", job)
+		return
+	}
+	if steps.Kind != yaml.SequenceNode || len(steps.Content) == 0 {
+		c.errorf(steps, "steps of job %q must be a list with at least one step", job)
+		return
+	}
+	stepIDs := make(map[string]bool)
+	for n, step := range steps.Content {
+		what := fmt.Sprintf("step %d of job %q", n+1, job)
+		if step.Kind != yaml.MappingNode {
+			c.errorf(step, "%s must be a mapping", what)
+			continue
+		}
+		c.checkKeys(step, stepKeys, what)
+
+		uses, run := mappingValue(step, "uses"), mappingValue(step, "run")
+		switch {
+		case uses == nil && run == nil:
+			c.errorf(step, "%s must have either uses or run", what)
+		case uses != nil && run != nil:
+			c.errorf(step, "%s cannot have both uses and run", what)
+		case uses != nil:
+			c.checkUses(uses, what)
+		}
+		if run == nil {
+			if shell := mappingValue(step, "shell"); shell != nil {
+				c.errorf(shell, "%s has a shell but no run", what)
+			}
+		}
+		if id := mappingValue(step, "id"); id != nil {
+			if stepIDs[id.Value] {
+				c.errorf(id, "step ID %q is repeated in job %q", id.Value, job)
+			}
+			stepIDs[id.Value] = true
+		}
+	}
+}
+
+// checkUses checks the reference of an action, which is a local path, a Docker image or an action
+// in a repository at a ref.
+func (c *checker) checkUses(uses *yaml.Node, what string) {
+	ref := uses.Value
+	if strings.HasPrefix(ref, "./") || strings.HasPrefix(ref, "docker://") || strings.Contains(ref, "${{") {
+		return
+	}
+	action, version, ok := strings.Cut(ref, "@")
+	if !ok || version == "" {
+		c.errorf(uses, "%s uses %q without a version, such as @v4 or a commit SHA", what, ref)
+		return
+	}
+	if strings.Count(action, "/") < 1 {
+		c.errorf(uses, "%s uses %q, which is not of the form owner/repo@ref", what, ref)
+	}
+}
+
+// mappingValue returns the value of a key of a mapping, or nil if the node is not a mapping or
+// does not have the key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
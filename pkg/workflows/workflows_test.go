@@ -0,0 +1,134 @@
+package workflows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	valid := `name: CI
+on:
+  push:
+    branches: [main]
+  schedule:
+    - cron: "0 0 * * 1"
+  workflow_dispatch:
+    inputs:
+      environment:
+        type: choice
+        options: [staging, production]
+        default: staging
+      dry_run:
+        type: boolean
+        required: true
+        default: true
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - id: test
+        run: go test ./...
+        shell: bash
+  deploy:
+    needs: build
+    uses: octo-org/workflows/.github/workflows/deploy.yml@main
+    with:
+      environment: ${{ inputs.environment }}
+`
+	result := Validate([]byte(valid))
+	assert.True(t, result.Valid, result.Errors)
+	assert.Empty(t, result.Errors)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, Problem{Line: 13, Message: `workflow_dispatch input "dry_run" is required, so its default is never used`}, result.Warnings[0])
+	require.NotNil(t, result.Summary)
+	assert.Equal(t, "CI", result.Summary.Name)
+	assert.Equal(t, []string{"push", "schedule", "workflow_dispatch"}, result.Summary.Triggers)
+	assert.Equal(t, []string{"build", "deploy"}, result.Summary.Jobs)
+	assert.Equal(t, map[string]Input{
+		"environment": {Type: "choice", Default: "staging", Options: []string{"staging", "production"}},
+		"dry_run":     {Type: "boolean", Required: true, Default: "true"},
+	}, result.Summary.Inputs)
+
+	tests := []struct {
+		name    string
+		content string
+		errors  []Problem
+	}{
+		{
+			name:    "invalid YAML",
+			content: "on: [push\n",
+			errors:  []Problem{{Message: "invalid YAML: line 1: did not find expected ',' or ']'"}},
+		},
+		{
+			name:    "not a mapping",
+			content: "- push\n",
+			errors:  []Problem{{Line: 1, Message: "the workflow must be a mapping of keys such as on and jobs"}},
+		},
+		{
+			name:    "no triggers or jobs",
+			content: "name: CI\nstages: []\n",
+			errors: []Problem{
+				{Line: 2, Message: `unexpected workflow key "stages", expected one of name, run-name, on, permissions, env, defaults, concurrency, jobs`},
+				{Line: 1, Message: "the workflow has no triggers, add an on key"},
+				{Line: 1, Message: "the workflow has no jobs, add a jobs key"},
+			},
+		},
+		{
+			name:    "unknown event and bad cron",
+			content: "on:\n  pushed:\n  schedule:\n    - cron: \"0 0 * *\"\njobs:\n  a:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n",
+			errors: []Problem{
+				{Line: 2, Message: `unknown event "pushed"`},
+				{Line: 4, Message: `cron "0 0 * *" must have 5 fields, it has 4`},
+			},
+		},
+		{
+			name:    "invalid inputs",
+			content: "on:\n  workflow_dispatch:\n    inputs:\n      env:\n        type: choice\n      count:\n        type: integer\n  workflow_call:\n    inputs:\n      env:\n        type: choice\n        options: [a]\njobs:\n  a:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n",
+			errors: []Problem{
+				{Line: 4, Message: `workflow_dispatch input "env" is a choice, so it needs options`},
+				{Line: 6, Message: `workflow_dispatch input "count" has type "integer", which must be one of string, boolean, choice, number, environment`},
+				{Line: 10, Message: `workflow_call input "env" has type "choice", which must be one of string, boolean, number`},
+			},
+		},
+		{
+			name:    "invalid jobs",
+			content: "on: push\njobs:\n  1build:\n    runs-on: ubuntu-latest\n    needs: [test, 1build]\n    steps:\n      - run: echo\n  test:\n    runs: ubuntu-latest\n  call:\n    uses: ./.github/workflows/reusable.yml\n    runs-on: ubuntu-latest\n",
+			errors: []Problem{
+				{Line: 3, Message: `job ID "1build" must start with a letter or _ and contain only letters, digits, - and _`},
+				{Line: 5, Message: `job "1build" needs itself`},
+				{Line: 9, Message: `unexpected job "test" key "runs", expected one of name, permissions, needs, if, runs-on, snapshot, environment, concurrency, outputs, env, defaults, steps, timeout-minutes, strategy, continue-on-error, container, services, uses, with, secrets`},
+				{Line: 8, Message: `job "test" has no runs-on`},
+				{Line: 9, Message: `job "test" has no steps`},
+				{Line: 11, Message: `job "call" calls a reusable workflow, so it cannot have runs-on or steps`},
+			},
+		},
+		{
+			name:    "needs a missing job",
+			content: "on: push\njobs:\n  a:\n    runs-on: ubuntu-latest\n    needs: b\n    steps:\n      - run: echo\n",
+			errors:  []Problem{{Line: 5, Message: `job "a" needs job "b", which does not exist`}},
+		},
+		{
+			name:    "invalid steps",
+			content: "on: push\njobs:\n  a:\n    runs-on: ubuntu-latest\n    steps:\n      - name: nothing\n      - uses: actions/checkout@v4\n        run: echo\n      - uses: actions/checkout\n      - uses: checkout@v4\n        shell: bash\n      - id: x\n        run: echo\n      - id: x\n        run: echo\n",
+			errors: []Problem{
+				{Line: 6, Message: `step 1 of job "a" must have either uses or run`},
+				{Line: 7, Message: `step 2 of job "a" cannot have both uses and run`},
+				{Line: 9, Message: `step 3 of job "a" uses "actions/checkout" without a version, such as @v4 or a commit SHA`},
+				{Line: 10, Message: `step 4 of job "a" uses "checkout@v4", which is not of the form owner/repo@ref`},
+				{Line: 11, Message: `step 4 of job "a" has a shell but no run`},
+				{Line: 14, Message: `step ID "x" is repeated in job "a"`},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Validate([]byte(tc.content))
+			assert.False(t, result.Valid)
+			assert.Equal(t, tc.errors, result.Errors)
+		})
+	}
+}